@@ -106,6 +106,8 @@ func (rs *Resource) getDashboardAnalytics(w http.ResponseWriter, r *http.Request
 		StudentsInTransit:    analytics.StudentsInTransit,
 		StudentsOnPlayground: analytics.StudentsOnPlayground,
 		StudentsInRooms:      analytics.StudentsInRooms,
+		StudentsAbsent:       analytics.StudentsAbsent,
+		StudentsMissing:      analytics.StudentsMissing,
 		ActiveActivities:     analytics.ActiveActivities,
 		FreeRooms:            analytics.FreeRooms,
 		TotalRooms:           analytics.TotalRooms,
@@ -24,6 +24,7 @@ type Resource struct {
 	PersonService      userSvc.PersonService
 	SchulhofService    facilities.SchulhofService
 	UserContextService usercontext.UserContextService
+	PickupAuthService  userSvc.PickupAuthorizationService
 	db                 *bun.DB
 	logger             *slog.Logger
 }
@@ -37,12 +38,13 @@ func (rs *Resource) getLogger() *slog.Logger {
 }
 
 // NewResource creates a new active resource
-func NewResource(activeService activeSvc.Service, personService userSvc.PersonService, schulhofService facilities.SchulhofService, userContextService usercontext.UserContextService, db *bun.DB, logger *slog.Logger) *Resource {
+func NewResource(activeService activeSvc.Service, personService userSvc.PersonService, schulhofService facilities.SchulhofService, userContextService usercontext.UserContextService, pickupAuthService userSvc.PickupAuthorizationService, db *bun.DB, logger *slog.Logger) *Resource {
 	return &Resource{
 		ActiveService:      activeService,
 		PersonService:      personService,
 		SchulhofService:    schulhofService,
 		UserContextService: userContextService,
+		PickupAuthService:  pickupAuthService,
 		db:                 db,
 		logger:             logger,
 	}
@@ -75,9 +77,9 @@ func (rs *Resource) Router() chi.Router {
 
 			// Write operations
 			r.With(authorize.RequiresPermission(permissions.GroupsCreate)).Post("/", rs.createActiveGroup)
-			r.With(authorize.RequiresPermission(permissions.GroupsUpdate)).Put("/{id}", rs.updateActiveGroup)
-			r.With(authorize.RequiresPermission(permissions.GroupsDelete)).Delete("/{id}", rs.deleteActiveGroup)
-			r.With(authorize.RequiresPermission(permissions.GroupsUpdate)).Post(routeEndByID, rs.endActiveGroup)
+			r.With(authorize.RequiresPermission(permissions.GroupsUpdate), requiresActiveGroupAccess(policy.ActionEdit)).Put("/{id}", rs.updateActiveGroup)
+			r.With(authorize.RequiresPermission(permissions.GroupsDelete), requiresActiveGroupAccess(policy.ActionDelete)).Delete("/{id}", rs.deleteActiveGroup)
+			r.With(authorize.RequiresPermission(permissions.GroupsUpdate), requiresActiveGroupAccess(policy.ActionEdit)).Post(routeEndByID, rs.endActiveGroup)
 			r.With(authorize.RequiresPermission(permissions.GroupsUpdate)).Post("/{id}/claim", rs.claimGroup)
 		})
 
@@ -129,9 +131,9 @@ func (rs *Resource) Router() chi.Router {
 
 			// Write operations
 			r.With(authorize.RequiresPermission(permissions.GroupsCreate)).Post("/", rs.createCombinedGroup)
-			r.With(authorize.RequiresPermission(permissions.GroupsUpdate)).Put("/{id}", rs.updateCombinedGroup)
-			r.With(authorize.RequiresPermission(permissions.GroupsDelete)).Delete("/{id}", rs.deleteCombinedGroup)
-			r.With(authorize.RequiresPermission(permissions.GroupsUpdate)).Post(routeEndByID, rs.endCombinedGroup)
+			r.With(authorize.RequiresPermission(permissions.GroupsUpdate), requiresCombinedGroupAccess(policy.ActionEdit)).Put("/{id}", rs.updateCombinedGroup)
+			r.With(authorize.RequiresPermission(permissions.GroupsDelete), requiresCombinedGroupAccess(policy.ActionDelete)).Delete("/{id}", rs.deleteCombinedGroup)
+			r.With(authorize.RequiresPermission(permissions.GroupsUpdate), requiresCombinedGroupAccess(policy.ActionEdit)).Post(routeEndByID, rs.endCombinedGroup)
 		})
 
 		// Group Mappings
@@ -182,3 +184,13 @@ func VisitIDExtractor() authorize.ResourceExtractor {
 		return id, nil
 	}
 }
+
+// requiresActiveGroupAccess restricts changing an active group to its current supervisors and admins
+func requiresActiveGroupAccess(action policy.Action) func(http.Handler) http.Handler {
+	return authorize.GetResourceAuthorizer().RequiresResourceAccess("active_group", action, authorize.URLParamExtractor("id"))
+}
+
+// requiresCombinedGroupAccess restricts managing a combined group to supervisors of its sessions and admins
+func requiresCombinedGroupAccess(action policy.Action) func(http.Handler) http.Handler {
+	return authorize.GetResourceAuthorizer().RequiresResourceAccess("combined_group", action, authorize.URLParamExtractor("id"))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/moto-nrw/project-phoenix/api/common"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	userSvc "github.com/moto-nrw/project-phoenix/services/users"
 )
 
 // checkoutStudent handles immediate checkout of a student.
@@ -41,14 +42,26 @@ func (rs *Resource) checkoutStudent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 5. Execute the checkout
-	result, err := rs.executeStudentCheckout(ctx, staff, checkoutCtx)
+	// 5. Verify who collects the student (optional body; blocks persons not authorized)
+	checkoutReq, err := parseCheckoutRequest(r)
+	if err != nil {
+		common.RespondWithError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	pickup, err := rs.verifyCheckoutPickup(ctx, studentID, checkoutReq)
+	if err != nil {
+		rs.handlePickupVerificationError(w, r, err)
+		return
+	}
+
+	// 6. Execute the checkout
+	result, err := rs.executeStudentCheckout(ctx, staff, checkoutCtx, pickup)
 	if err != nil {
 		common.RespondWithError(w, r, http.StatusInternalServerError, "Failed to checkout student from daily attendance")
 		return
 	}
 
-	// 6. Send success response
+	// 7. Send success response
 	common.RespondWithJSON(w, r, http.StatusOK, buildCheckoutResponse(studentID, result))
 }
 
@@ -70,3 +83,21 @@ func (rs *Resource) handleAuthorizationError(w http.ResponseWriter, r *http.Requ
 	}
 	common.RespondWithError(w, r, http.StatusInternalServerError, "Failed to get staff information")
 }
+
+// handlePickupVerificationError maps pickup verification errors to appropriate HTTP responses.
+// A person who may not collect the student blocks the checkout with 409 and the list entry.
+func (rs *Resource) handlePickupVerificationError(w http.ResponseWriter, r *http.Request, err error) {
+	if pickup, ok := userSvc.IsPickupNotAuthorized(err); ok {
+		common.RespondWithJSON(w, r, http.StatusConflict, map[string]interface{}{
+			"status":  "error",
+			"message": err.Error(),
+			"data":    pickup,
+		})
+		return
+	}
+	if errors.Is(err, userSvc.ErrPickupPersonNotFound) {
+		common.RespondWithError(w, r, http.StatusBadRequest, "Selected person is not on the student's pickup list")
+		return
+	}
+	common.RespondWithError(w, r, http.StatusInternalServerError, "Failed to verify pickup person")
+}
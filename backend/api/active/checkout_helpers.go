@@ -2,8 +2,10 @@ package active
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,9 +13,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/moto-nrw/project-phoenix/auth/device"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
 	"github.com/moto-nrw/project-phoenix/models/active"
 	"github.com/moto-nrw/project-phoenix/models/users"
 	activeService "github.com/moto-nrw/project-phoenix/services/active"
+	userSvc "github.com/moto-nrw/project-phoenix/services/users"
 )
 
 // checkoutContext holds all context needed for a checkout operation
@@ -37,6 +41,56 @@ var (
 	ErrCheckoutFailed = errors.New("failed to checkout student")
 )
 
+// CheckoutRequest is the optional body of a staff checkout
+type CheckoutRequest struct {
+	PickedUpBy *PickupSelectionRequest `json:"picked_up_by,omitempty"`
+}
+
+// PickupSelectionRequest identifies the guardian or pickup person who collects the student
+type PickupSelectionRequest struct {
+	Type string `json:"type"` // guardian or person
+	ID   int64  `json:"id"`   // Guardian profile ID or pickup person ID
+}
+
+// parseCheckoutRequest decodes the optional checkout body; an empty body means no pickup selection
+func parseCheckoutRequest(r *http.Request) (*CheckoutRequest, error) {
+	req := &CheckoutRequest{}
+	if r.Body == nil {
+		return req, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		if errors.Is(err, io.EOF) {
+			return req, nil
+		}
+		return nil, errors.New("invalid request body")
+	}
+
+	if p := req.PickedUpBy; p != nil {
+		if p.Type != userSvc.PickupTypeGuardian && p.Type != userSvc.PickupTypePerson {
+			return nil, errors.New("picked_up_by.type must be 'guardian' or 'person'")
+		}
+		if p.ID <= 0 {
+			return nil, errors.New("picked_up_by.id is required")
+		}
+	}
+	return req, nil
+}
+
+// verifyCheckoutPickup checks the selected pickup person against the student's pickup list.
+// Returns nil when no person was selected.
+func (rs *Resource) verifyCheckoutPickup(ctx context.Context, studentID int64, req *CheckoutRequest) (*userSvc.AuthorizedPickup, error) {
+	if req.PickedUpBy == nil {
+		return nil, nil
+	}
+	if rs.PickupAuthService == nil {
+		return nil, errors.New("pickup verification is not configured")
+	}
+	return rs.PickupAuthService.VerifyPickup(ctx, studentID, userSvc.PickupSelection{
+		Type: req.PickedUpBy.Type,
+		ID:   req.PickedUpBy.ID,
+	}, timezone.Today())
+}
+
 // parseStudentIDFromRequest extracts and validates the student ID from URL params
 func parseStudentIDFromRequest(r *http.Request) (int64, error) {
 	studentIDStr := chi.URLParam(r, "studentId")
@@ -94,6 +148,7 @@ func (rs *Resource) executeStudentCheckout(
 	ctx context.Context,
 	staff *users.Staff,
 	checkoutCtx *checkoutContext,
+	pickup *userSvc.AuthorizedPickup,
 ) (*checkoutResult, error) {
 	// Embed staff in context for EndVisit recording
 	actionCtx := context.WithValue(ctx, device.CtxStaff, staff)
@@ -101,9 +156,9 @@ func (rs *Resource) executeStudentCheckout(
 	// End active visit if exists
 	rs.endActiveVisit(actionCtx, checkoutCtx.CurrentVisit)
 
-	// Toggle attendance to checked out
-	result, err := rs.ActiveService.ToggleStudentAttendance(
-		ctx, checkoutCtx.StudentID, staff.ID, 0, true,
+	// Check out with the pickup person; both are stored or neither
+	result, err := rs.ActiveService.CheckOutStudent(
+		ctx, checkoutCtx.StudentID, staff.ID, 0, true, activeService.PickupCheckoutDetails(pickup),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrCheckoutFailed, err)
@@ -159,6 +214,9 @@ func buildCheckoutResponse(studentID int64, result *checkoutResult) map[string]i
 		responseData["check_out_time"] = result.UpdatedAttendance.CheckOutTime
 		responseData["checked_in_by"] = result.UpdatedAttendance.CheckedInBy
 		responseData["checked_out_by"] = result.UpdatedAttendance.CheckedOutBy
+		if result.UpdatedAttendance.PickedUpBy != "" {
+			responseData["picked_up_by"] = result.UpdatedAttendance.PickedUpBy
+		}
 	}
 
 	return map[string]interface{}{
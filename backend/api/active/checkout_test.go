@@ -4,12 +4,15 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	activeService "github.com/moto-nrw/project-phoenix/services/active"
+	userService "github.com/moto-nrw/project-phoenix/services/users"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // =============================================================================
@@ -214,3 +217,80 @@ func TestEndActiveVisit_NilVisit(_ *testing.T) {
 	// Should not panic when visit is nil
 	rs.endActiveVisit(context.Background(), nil)
 }
+
+// =============================================================================
+// Pickup Verification Tests
+// =============================================================================
+
+func TestParseCheckoutRequest(t *testing.T) {
+	t.Run("empty body has no pickup selection", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/student/123/checkout", nil)
+		req, err := parseCheckoutRequest(r)
+		require.NoError(t, err)
+		assert.Nil(t, req.PickedUpBy)
+	})
+
+	t.Run("guardian selection", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/student/123/checkout", strings.NewReader(`{"picked_up_by":{"type":"guardian","id":42}}`))
+		req, err := parseCheckoutRequest(r)
+		require.NoError(t, err)
+		require.NotNil(t, req.PickedUpBy)
+		assert.Equal(t, "guardian", req.PickedUpBy.Type)
+		assert.Equal(t, int64(42), req.PickedUpBy.ID)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/student/123/checkout", strings.NewReader(`{"picked_up_by":{"type":"neighbour","id":42}}`))
+		_, err := parseCheckoutRequest(r)
+		require.Error(t, err)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/student/123/checkout", strings.NewReader(`{"picked_up_by":{"type":"person"}}`))
+		_, err := parseCheckoutRequest(r)
+		require.Error(t, err)
+	})
+}
+
+func TestHandlePickupVerificationError(t *testing.T) {
+	rs := &Resource{}
+
+	t.Run("not authorized blocks with conflict", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/test", nil)
+		err := &userService.PickupNotAuthorizedError{Pickup: &userService.AuthorizedPickup{
+			Type:   userService.PickupTypeGuardian,
+			ID:     42,
+			Name:   "Jan Becker",
+			Reason: userService.PickupReasonNotAuthorized,
+		}}
+
+		rs.handlePickupVerificationError(w, r, err)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "Jan Becker")
+	})
+
+	t.Run("person not on list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/test", nil)
+
+		rs.handlePickupVerificationError(w, r, &userService.UsersError{Op: "verify pickup", Err: userService.ErrPickupPersonNotFound})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBuildCheckoutResponse_WithPickupPerson(t *testing.T) {
+	result := &checkoutResult{
+		Result: &activeService.AttendanceResult{Action: "checked_out", AttendanceID: 456},
+		UpdatedAttendance: &activeService.AttendanceStatus{
+			Status:     "checked_out",
+			PickedUpBy: "Erika Zimmer",
+		},
+	}
+
+	data := buildCheckoutResponse(123, result)["data"].(map[string]interface{})
+
+	assert.Equal(t, "Erika Zimmer", data["picked_up_by"])
+}
@@ -150,6 +150,8 @@ type DashboardAnalyticsResponse struct {
 	StudentsInTransit    int `json:"students_in_transit"` // Students present but not in any active visit
 	StudentsOnPlayground int `json:"students_on_playground"`
 	StudentsInRooms      int `json:"students_in_rooms"` // Students in indoor rooms (excluding playground)
+	StudentsAbsent       int `json:"students_absent"`   // Reported absent (sick, trip, ...)
+	StudentsMissing      int `json:"students_missing"`  // Neither present nor reported absent
 
 	// Activities & Rooms
 	ActiveActivities    int     `json:"active_activities"`
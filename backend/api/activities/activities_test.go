@@ -41,6 +41,8 @@ func setupTestContext(t *testing.T) *testContext {
 		svc.Schedule,
 		svc.Users,
 		svc.UserContext,
+		svc.ActivityAllocation,
+		svc.AttendanceRegister,
 	)
 
 	return &testContext{
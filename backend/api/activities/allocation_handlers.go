@@ -0,0 +1,321 @@
+package activities
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/base"
+)
+
+// AllocationRequest represents an AG lottery create or update request
+type AllocationRequest struct {
+	DateframeID              int64   `json:"dateframe_id"` // Create only; the term cannot change afterwards
+	Name                     string  `json:"name"`
+	Seed                     *int64  `json:"seed,omitempty"` // Omit to draw a random seed (create) or keep the current one (update)
+	MaxAssignmentsPerStudent int     `json:"max_assignments_per_student,omitempty"`
+	Notes                    *string `json:"notes,omitempty"`
+}
+
+// Bind validates the allocation request
+func (req *AllocationRequest) Bind(_ *http.Request) error {
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+	if req.MaxAssignmentsPerStudent < 0 || req.MaxAssignmentsPerStudent > activities.MaxAllocationPreferences {
+		return errors.New("max_assignments_per_student must be between 1 and 3")
+	}
+	return nil
+}
+
+// applyTo copies the request fields onto an allocation
+func (req *AllocationRequest) applyTo(allocation *activities.Allocation) {
+	allocation.Name = req.Name
+	allocation.MaxAssignmentsPerStudent = req.MaxAssignmentsPerStudent
+	allocation.Notes = req.Notes
+	if req.Seed != nil {
+		allocation.Seed = *req.Seed
+	}
+}
+
+// AllocationPreferencesRequest represents a student's ranked AG wishes
+type AllocationPreferencesRequest struct {
+	ActivityGroupIDs []int64 `json:"activity_group_ids"` // First entry is the first choice; empty clears the wishes
+}
+
+// Bind validates the preferences request
+func (req *AllocationPreferencesRequest) Bind(_ *http.Request) error {
+	if len(req.ActivityGroupIDs) > activities.MaxAllocationPreferences {
+		return errors.New("at most 3 activity groups can be wished for")
+	}
+	return nil
+}
+
+// ApplyAllocationRequest names the preview the caller confirmed
+type ApplyAllocationRequest struct {
+	Fingerprint string `json:"fingerprint"` // From the preview; a different outcome is rejected
+}
+
+// Bind validates the apply request
+func (req *ApplyAllocationRequest) Bind(_ *http.Request) error {
+	if req.Fingerprint == "" {
+		return errors.New("fingerprint of the preview is required")
+	}
+	return nil
+}
+
+// StudentPreferencesResponse lists one student's wishes in rank order
+type StudentPreferencesResponse struct {
+	StudentID        int64   `json:"student_id"`
+	ActivityGroupIDs []int64 `json:"activity_group_ids"`
+}
+
+// AllocationResponse represents an AG lottery API response
+type AllocationResponse struct {
+	ID                       int64                              `json:"id"`
+	DateframeID              int64                              `json:"dateframe_id"`
+	Name                     string                             `json:"name"`
+	Status                   string                             `json:"status"`
+	Seed                     int64                              `json:"seed"`
+	MaxAssignmentsPerStudent int                                `json:"max_assignments_per_student"`
+	Notes                    *string                            `json:"notes,omitempty"`
+	CreatedBy                int64                              `json:"created_by"`
+	CreatedAt                time.Time                          `json:"created_at"`
+	AppliedAt                *time.Time                         `json:"applied_at,omitempty"`
+	AppliedBy                *int64                             `json:"applied_by,omitempty"`
+	CanModify                bool                               `json:"can_modify"`
+	Preferences              []StudentPreferencesResponse       `json:"preferences,omitempty"`
+	Assignments              []*activities.AllocationAssignment `json:"assignments,omitempty"` // Applied allocations only
+}
+
+// newAllocationResponse converts an allocation model to a response object
+func newAllocationResponse(allocation *activities.Allocation) AllocationResponse {
+	resp := AllocationResponse{
+		ID:                       allocation.ID,
+		DateframeID:              allocation.DateframeID,
+		Name:                     allocation.Name,
+		Status:                   allocation.Status,
+		Seed:                     allocation.Seed,
+		MaxAssignmentsPerStudent: allocation.MaxAssignmentsPerStudent,
+		Notes:                    allocation.Notes,
+		CreatedBy:                allocation.CreatedBy,
+		CreatedAt:                allocation.CreatedAt,
+		AppliedAt:                allocation.AppliedAt,
+		AppliedBy:                allocation.AppliedBy,
+		CanModify:                allocation.CanModify(),
+		Assignments:              allocation.Assignments,
+	}
+
+	// Preferences arrive ordered by student and rank
+	for _, p := range allocation.Preferences {
+		if n := len(resp.Preferences); n == 0 || resp.Preferences[n-1].StudentID != p.StudentID {
+			resp.Preferences = append(resp.Preferences, StudentPreferencesResponse{StudentID: p.StudentID})
+		}
+		last := &resp.Preferences[len(resp.Preferences)-1]
+		last.ActivityGroupIDs = append(last.ActivityGroupIDs, p.ActivityGroupID)
+	}
+
+	return resp
+}
+
+// accountIDFromClaims returns the account ID of the caller (error already rendered when missing)
+func accountIDFromClaims(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	claims := jwt.ClaimsFromCtx(r.Context())
+	if claims.ID == 0 {
+		common.RenderError(w, r, common.ErrorUnauthorized(errors.New("no account ID in context")))
+		return 0, false
+	}
+	return int64(claims.ID), true
+}
+
+// parseAllocationID parses the allocation ID from URL param "id" (error already rendered on failure)
+func parseAllocationID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New("invalid allocation ID")))
+		return 0, false
+	}
+	return id, true
+}
+
+// listAllocations lists AG lotteries, optionally filtered by dateframe_id
+func (rs *Resource) listAllocations(w http.ResponseWriter, r *http.Request) {
+	options := base.NewQueryOptions()
+	filter := base.NewFilter()
+	if dateframeIDStr := r.URL.Query().Get("dateframe_id"); dateframeIDStr != "" {
+		dateframeID, err := strconv.ParseInt(dateframeIDStr, 10, 64)
+		if err != nil {
+			common.RenderError(w, r, ErrorInvalidRequest(errors.New("invalid dateframe_id")))
+			return
+		}
+		filter.Equal("dateframe_id", dateframeID)
+	}
+	options.Filter = filter
+
+	allocations, err := rs.AllocationService.ListAllocations(r.Context(), options)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	responses := make([]AllocationResponse, 0, len(allocations))
+	for _, allocation := range allocations {
+		responses = append(responses, newAllocationResponse(allocation))
+	}
+	common.Respond(w, r, http.StatusOK, responses, "Allocations retrieved successfully")
+}
+
+// createAllocation creates a draft AG lottery for a term
+func (rs *Resource) createAllocation(w http.ResponseWriter, r *http.Request) {
+	req := &AllocationRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+	if req.DateframeID <= 0 {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New("dateframe_id is required")))
+		return
+	}
+
+	accountID, ok := accountIDFromClaims(w, r)
+	if !ok {
+		return
+	}
+
+	allocation := &activities.Allocation{DateframeID: req.DateframeID, CreatedBy: accountID}
+	req.applyTo(allocation)
+
+	created, err := rs.AllocationService.CreateAllocation(r.Context(), allocation)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusCreated, newAllocationResponse(created), "Allocation created successfully")
+}
+
+// getAllocation returns an AG lottery with its preferences and, once applied, its assignments
+func (rs *Resource) getAllocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAllocationID(w, r)
+	if !ok {
+		return
+	}
+
+	allocation, err := rs.AllocationService.GetAllocation(r.Context(), id)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, newAllocationResponse(allocation), "Allocation retrieved successfully")
+}
+
+// updateAllocation updates a draft AG lottery
+func (rs *Resource) updateAllocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAllocationID(w, r)
+	if !ok {
+		return
+	}
+
+	req := &AllocationRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	allocation := &activities.Allocation{Model: base.Model{ID: id}}
+	req.applyTo(allocation)
+
+	updated, err := rs.AllocationService.UpdateAllocation(r.Context(), allocation)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, newAllocationResponse(updated), "Allocation updated successfully")
+}
+
+// deleteAllocation deletes a draft AG lottery
+func (rs *Resource) deleteAllocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAllocationID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := rs.AllocationService.DeleteAllocation(r.Context(), id); err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, nil, "Allocation deleted successfully")
+}
+
+// setAllocationPreferences replaces a student's ranked wishes
+func (rs *Resource) setAllocationPreferences(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAllocationID(w, r)
+	if !ok {
+		return
+	}
+	studentID, ok := rs.parseStudentID(w, r)
+	if !ok {
+		return
+	}
+
+	req := &AllocationPreferencesRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	if _, err := rs.AllocationService.SetStudentPreferences(r.Context(), id, studentID, req.ActivityGroupIDs); err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	groupIDs := req.ActivityGroupIDs
+	if groupIDs == nil {
+		groupIDs = []int64{}
+	}
+	common.Respond(w, r, http.StatusOK, StudentPreferencesResponse{StudentID: studentID, ActivityGroupIDs: groupIDs}, "Preferences saved successfully")
+}
+
+// previewAllocation computes the allocation from its seed without enrolling anyone
+func (rs *Resource) previewAllocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAllocationID(w, r)
+	if !ok {
+		return
+	}
+
+	preview, err := rs.AllocationService.Preview(r.Context(), id)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, preview, "Allocation preview generated successfully")
+}
+
+// applyAllocation enrolls the students as previewed
+func (rs *Resource) applyAllocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAllocationID(w, r)
+	if !ok {
+		return
+	}
+	accountID, ok := accountIDFromClaims(w, r)
+	if !ok {
+		return
+	}
+
+	req := &ApplyAllocationRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	result, err := rs.AllocationService.Apply(r.Context(), id, accountID, req.Fingerprint)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, result, "Allocation applied successfully")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/auth/authorize"
 	"github.com/moto-nrw/project-phoenix/auth/authorize/permissions"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
 	"github.com/moto-nrw/project-phoenix/internal/timezone"
@@ -32,19 +33,23 @@ const (
 
 // Resource defines the activities API resource
 type Resource struct {
-	ActivityService    activitiesSvc.ActivityService
-	ScheduleService    scheduleSvc.Service
-	UserService        usersSvc.PersonService
-	UserContextService usercontextSvc.UserContextService
+	ActivityService           activitiesSvc.ActivityService
+	ScheduleService           scheduleSvc.Service
+	UserService               usersSvc.PersonService
+	UserContextService        usercontextSvc.UserContextService
+	AllocationService         activitiesSvc.AllocationService
+	AttendanceRegisterService activitiesSvc.AttendanceRegisterService
 }
 
 // NewResource creates a new activities resource
-func NewResource(activityService activitiesSvc.ActivityService, scheduleService scheduleSvc.Service, userService usersSvc.PersonService, userContextService usercontextSvc.UserContextService) *Resource {
+func NewResource(activityService activitiesSvc.ActivityService, scheduleService scheduleSvc.Service, userService usersSvc.PersonService, userContextService usercontextSvc.UserContextService, allocationService activitiesSvc.AllocationService, attendanceRegisterService activitiesSvc.AttendanceRegisterService) *Resource {
 	return &Resource{
-		ActivityService:    activityService,
-		ScheduleService:    scheduleService,
-		UserService:        userService,
-		UserContextService: userContextService,
+		ActivityService:           activityService,
+		ScheduleService:           scheduleService,
+		UserService:               userService,
+		UserContextService:        userContextService,
+		AllocationService:         allocationService,
+		AttendanceRegisterService: attendanceRegisterService,
 	}
 }
 
@@ -95,6 +100,24 @@ func (rs *Resource) Router() chi.Router {
 		r.Post("/{id}/students/{studentId}", rs.enrollStudent)
 		r.Delete("/{id}/students/{studentId}", rs.unenrollStudent)
 		r.Put("/{id}/students", rs.updateGroupEnrollments)
+
+		// Attendance registers - derived from RFID visits per session day, excusable by staff
+		r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/{id}/registers", rs.listRegisters)
+		r.With(authorize.RequiresPermission(permissions.ActivitiesEnroll)).Post("/{id}/registers/{date}/derive", rs.deriveRegister)
+		r.With(authorize.RequiresPermission(permissions.ActivitiesEnroll)).Put("/{id}/registers/{date}/students/{studentId}/excuse", rs.excuseStudent)
+		r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/{id}/attendance-report", rs.getAttendanceReport)
+
+		// AG lottery - ranked wishes per term, previewed from a seed and applied as enrollments
+		r.Route("/allocations", func(r chi.Router) {
+			r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/", rs.listAllocations)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesManage)).Post("/", rs.createAllocation)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/{id}", rs.getAllocation)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesManage)).Put("/{id}", rs.updateAllocation)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesManage)).Delete("/{id}", rs.deleteAllocation)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesEnroll)).Put("/{id}/preferences/{studentId}", rs.setAllocationPreferences)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/{id}/preview", rs.previewAllocation)
+			r.With(authorize.RequiresPermission(permissions.ActivitiesManage)).Post("/{id}/apply", rs.applyAllocation)
+		})
 	})
 
 	return r
@@ -121,22 +144,25 @@ type SupervisorResponse struct {
 
 // ActivityResponse represents an activity group API response
 type ActivityResponse struct {
-	ID              int64                `json:"id"`
-	Name            string               `json:"name"`
-	MaxParticipants int                  `json:"max_participants"`
-	IsOpen          bool                 `json:"is_open"`
-	CategoryID      int64                `json:"category_id"`
-	PlannedRoomID   *int64               `json:"planned_room_id,omitempty"`
-	CreatedBy       int64                `json:"created_by"`
-	CreatedByName   string               `json:"created_by_name,omitempty"`
-	Category        *CategoryResponse    `json:"category,omitempty"`
-	SupervisorID    *int64               `json:"supervisor_id,omitempty"`  // Primary supervisor
-	SupervisorIDs   []int64              `json:"supervisor_ids,omitempty"` // All supervisors
-	Supervisors     []SupervisorResponse `json:"supervisors,omitempty"`    // Detailed supervisor info
-	Schedules       []ScheduleResponse   `json:"schedules,omitempty"`
-	EnrollmentCount int                  `json:"enrollment_count,omitempty"`
-	CreatedAt       time.Time            `json:"created_at"`
-	UpdatedAt       time.Time            `json:"updated_at"`
+	ID               int64                `json:"id"`
+	Name             string               `json:"name"`
+	MaxParticipants  int                  `json:"max_participants"`
+	IsOpen           bool                 `json:"is_open"`
+	CategoryID       int64                `json:"category_id"`
+	PlannedRoomID    *int64               `json:"planned_room_id,omitempty"`
+	CreatedBy        int64                `json:"created_by"`
+	CreatedByName    string               `json:"created_by_name,omitempty"`
+	Category         *CategoryResponse    `json:"category,omitempty"`
+	SupervisorID     *int64               `json:"supervisor_id,omitempty"`  // Primary supervisor
+	SupervisorIDs    []int64              `json:"supervisor_ids,omitempty"` // All supervisors
+	Supervisors      []SupervisorResponse `json:"supervisors,omitempty"`    // Detailed supervisor info
+	Schedules        []ScheduleResponse   `json:"schedules,omitempty"`
+	EnrollmentCount  int                  `json:"enrollment_count,omitempty"`
+	DateframeID      *int64               `json:"dateframe_id,omitempty"`
+	EnrollmentStatus string               `json:"enrollment_status,omitempty"` // Student enrollments only: enrolled or waitlisted
+	WaitlistPosition *int                 `json:"waitlist_position,omitempty"` // Student enrollments only
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
 }
 
 // ScheduleResponse represents a schedule API response
@@ -172,6 +198,7 @@ type ActivityRequest struct {
 	IsOpen          bool              `json:"is_open"`
 	CategoryID      int64             `json:"category_id"`
 	PlannedRoomID   *int64            `json:"planned_room_id,omitempty"`
+	DateframeID     *int64            `json:"dateframe_id,omitempty"`
 	Schedules       []ScheduleRequest `json:"schedules,omitempty"`
 	SupervisorIDs   []int64           `json:"supervisor_ids,omitempty"`
 }
@@ -276,6 +303,7 @@ func newActivityResponse(group *activities.Group, enrollmentCount int) ActivityR
 		MaxParticipants: group.MaxParticipants,
 		IsOpen:          group.IsOpen,
 		CategoryID:      group.CategoryID,
+		DateframeID:     group.DateframeID,
 		CreatedBy:       group.CreatedBy,
 		EnrollmentCount: enrollmentCount,
 		CreatedAt:       group.CreatedAt,
@@ -528,6 +556,7 @@ func buildBaseActivityResponse(group *activities.Group, enrollmentCount int) Act
 		IsOpen:          group.IsOpen,
 		CategoryID:      group.CategoryID,
 		PlannedRoomID:   group.PlannedRoomID,
+		DateframeID:     group.DateframeID,
 		CreatedBy:       group.CreatedBy,
 		EnrollmentCount: enrollmentCount,
 		CreatedAt:       group.CreatedAt,
@@ -601,6 +630,7 @@ func updateGroupFields(group *activities.Group, req *ActivityRequest) {
 	group.IsOpen = req.IsOpen
 	group.CategoryID = req.CategoryID
 	group.PlannedRoomID = req.PlannedRoomID
+	group.DateframeID = req.DateframeID
 }
 
 // updateSupervisorsWithLogging updates group supervisors and logs any errors without failing.
@@ -922,6 +952,7 @@ func (rs *Resource) createActivity(w http.ResponseWriter, r *http.Request) {
 		IsOpen:          req.IsOpen,
 		CategoryID:      req.CategoryID,
 		PlannedRoomID:   req.PlannedRoomID,
+		DateframeID:     req.DateframeID,
 		CreatedBy:       staffID,
 	}
 
@@ -1209,20 +1240,23 @@ func (rs *Resource) getStudentEnrollments(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Get activities that student is enrolled in
-	enrolledGroups, err := rs.ActivityService.GetStudentEnrollments(r.Context(), studentID)
+	// Get activities that student is enrolled in or waitlisted for
+	enrollments, err := rs.ActivityService.GetStudentEnrollments(r.Context(), studentID)
 	if err != nil {
 		common.RenderError(w, r, ErrorRenderer(err))
 		return
 	}
 
-	// Build activity responses
-	responses := make([]ActivityResponse, 0, len(enrolledGroups))
-	for _, group := range enrolledGroups {
-		if group == nil {
+	// Build activity responses with the student's status and waitlist position
+	responses := make([]ActivityResponse, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		if enrollment == nil || enrollment.ActivityGroup == nil {
 			continue // Skip nil groups to prevent panic
 		}
-		responses = append(responses, newActivityResponse(group, rs.getEnrollmentCount(r.Context(), group.ID)))
+		response := newActivityResponse(enrollment.ActivityGroup, rs.getEnrollmentCount(r.Context(), enrollment.ActivityGroupID))
+		response.EnrollmentStatus = enrollment.Status
+		response.WaitlistPosition = enrollment.WaitlistPosition
+		responses = append(responses, response)
 	}
 
 	common.Respond(w, r, http.StatusOK, responses, fmt.Sprintf("Activities for student ID %d retrieved successfully", studentID))
@@ -1340,13 +1374,18 @@ func (rs *Resource) enrollStudent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Enroll student
-	if err := rs.ActivityService.EnrollStudent(r.Context(), activity.ID, studentID); err != nil {
+	// Enroll student, or waitlist them when the activity is full
+	enrollment, err := rs.ActivityService.EnrollStudent(r.Context(), activity.ID, studentID)
+	if err != nil {
 		common.RenderError(w, r, ErrorRenderer(err))
 		return
 	}
 
-	common.Respond(w, r, http.StatusOK, nil, "Student enrolled successfully")
+	if enrollment.IsWaitlisted() {
+		common.Respond(w, r, http.StatusOK, enrollment, fmt.Sprintf("Activity is full, student added to the waitlist at position %d", *enrollment.WaitlistPosition))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, enrollment, "Student enrolled successfully")
 }
 
 // getTimespans handles retrieving all available time spans for activities
@@ -46,10 +46,30 @@ func ErrorRenderer(err error) render.Renderer {
 			return ErrorNotFound(actErr)
 		case activities.ErrGroupClosed:
 			return ErrorForbidden(actErr)
+		case activities.ErrEnrollmentWindowClosed:
+			return ErrorForbidden(actErr)
 		case activities.ErrInvalidAttendanceStatus:
 			return ErrorInvalidRequest(actErr)
 		case activities.ErrStaffNotFound:
 			return ErrorNotFound(actErr)
+		case activities.ErrAllocationNotFound:
+			return ErrorNotFound(actErr)
+		case activities.ErrAllocationApplied:
+			return ErrorConflict(actErr)
+		case activities.ErrAllocationPreviewOutdated:
+			return ErrorConflict(actErr)
+		case activities.ErrInvalidPreferences:
+			return ErrorInvalidRequest(actErr)
+		case activities.ErrNoPreferences:
+			return ErrorInvalidRequest(actErr)
+		case activities.ErrNoEnrolledStudents:
+			return ErrorInvalidRequest(actErr)
+		case activities.ErrGroupHasNoTerm:
+			return ErrorInvalidRequest(actErr)
+		case activities.ErrNoSessionOnDate:
+			return ErrorNotFound(actErr)
+		case activities.ErrStudentWasPresent:
+			return ErrorConflict(actErr)
 		default:
 			return ErrorInternalServer(actErr)
 		}
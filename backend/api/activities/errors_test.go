@@ -23,6 +23,7 @@ func TestErrorRenderer_NotFoundErrors(t *testing.T) {
 		{"ErrEnrollmentNotFound", activities.ErrEnrollmentNotFound},
 		{"ErrNotEnrolled", activities.ErrNotEnrolled},
 		{"ErrStaffNotFound", activities.ErrStaffNotFound},
+		{"ErrAllocationNotFound", activities.ErrAllocationNotFound},
 	}
 
 	for _, tt := range tests {
@@ -44,6 +45,8 @@ func TestErrorRenderer_ConflictErrors(t *testing.T) {
 	}{
 		{"ErrGroupFull", activities.ErrGroupFull},
 		{"ErrAlreadyEnrolled", activities.ErrAlreadyEnrolled},
+		{"ErrAllocationApplied", activities.ErrAllocationApplied},
+		{"ErrStudentWasPresent", activities.ErrStudentWasPresent},
 	}
 
 	for _, tt := range tests {
@@ -59,21 +62,25 @@ func TestErrorRenderer_ConflictErrors(t *testing.T) {
 }
 
 func TestErrorRenderer_ForbiddenErrors(t *testing.T) {
-	actErr := &activities.ActivityError{Err: activities.ErrGroupClosed}
-	renderer := activitiesAPI.ErrorRenderer(actErr)
-	resp, ok := renderer.(*activitiesAPI.ErrorResponse)
-	assert.True(t, ok)
-	assert.Equal(t, http.StatusForbidden, resp.HTTPStatusCode)
-	assert.Equal(t, "error", resp.Status)
+	for _, err := range []error{activities.ErrGroupClosed, activities.ErrEnrollmentWindowClosed} {
+		actErr := &activities.ActivityError{Err: err}
+		renderer := activitiesAPI.ErrorRenderer(actErr)
+		resp, ok := renderer.(*activitiesAPI.ErrorResponse)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, resp.HTTPStatusCode)
+		assert.Equal(t, "error", resp.Status)
+	}
 }
 
 func TestErrorRenderer_BadRequestErrors(t *testing.T) {
-	actErr := &activities.ActivityError{Err: activities.ErrInvalidAttendanceStatus}
-	renderer := activitiesAPI.ErrorRenderer(actErr)
-	resp, ok := renderer.(*activitiesAPI.ErrorResponse)
-	assert.True(t, ok)
-	assert.Equal(t, http.StatusBadRequest, resp.HTTPStatusCode)
-	assert.Equal(t, "error", resp.Status)
+	for _, err := range []error{activities.ErrInvalidAttendanceStatus, activities.ErrInvalidPreferences, activities.ErrNoPreferences, activities.ErrNoEnrolledStudents, activities.ErrGroupHasNoTerm} {
+		actErr := &activities.ActivityError{Err: err}
+		renderer := activitiesAPI.ErrorRenderer(actErr)
+		resp, ok := renderer.(*activitiesAPI.ErrorResponse)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, resp.HTTPStatusCode)
+		assert.Equal(t, "error", resp.Status)
+	}
 }
 
 func TestErrorRenderer_UnknownActivityError(t *testing.T) {
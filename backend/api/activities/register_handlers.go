@@ -0,0 +1,169 @@
+package activities
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+)
+
+const registerDateLayout = "2006-01-02"
+
+// ExcuseRequest represents a manual excuse for a child missing an activity session
+type ExcuseRequest struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// Bind validates the excuse request
+func (req *ExcuseRequest) Bind(_ *http.Request) error {
+	if req.Note != nil && len(*req.Note) > 500 {
+		return errors.New("note must be at most 500 characters")
+	}
+	return nil
+}
+
+// parseRegisterDate parses a YYYY-MM-DD date from URL param "date" (error already rendered on failure)
+func parseRegisterDate(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	date, err := time.ParseInLocation(registerDateLayout, chi.URLParam(r, "date"), timezone.Berlin)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New("invalid date, expected YYYY-MM-DD")))
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// parseActivityID parses the activity group ID from URL param "id" (error already rendered on failure)
+func parseActivityID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(common.MsgInvalidActivityID)))
+		return 0, false
+	}
+	return id, true
+}
+
+// listRegisters lists an activity's session registers, by default for the last 30 days
+func (rs *Resource) listRegisters(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseActivityID(w, r)
+	if !ok {
+		return
+	}
+
+	to := timezone.Today()
+	from := to.AddDate(0, 0, -30)
+	for param, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseInLocation(registerDateLayout, value, timezone.Berlin)
+		if err != nil {
+			common.RenderError(w, r, ErrorInvalidRequest(errors.New("invalid "+param+", expected YYYY-MM-DD")))
+			return
+		}
+		*target = parsed
+	}
+
+	registers, err := rs.AttendanceRegisterService.GetRegisters(r.Context(), id, from, to)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	if registers == nil {
+		registers = []*activities.SessionRegister{}
+	}
+	common.Respond(w, r, http.StatusOK, registers, "Registers retrieved successfully")
+}
+
+// deriveRegister (re)builds one day's register from the RFID visits of that day's sessions
+func (rs *Resource) deriveRegister(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseActivityID(w, r)
+	if !ok {
+		return
+	}
+	date, ok := parseRegisterDate(w, r)
+	if !ok {
+		return
+	}
+
+	register, err := rs.AttendanceRegisterService.DeriveRegister(r.Context(), id, date)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, register, "Register derived successfully")
+}
+
+// excuseStudent marks a child as excused for one day's session
+func (rs *Resource) excuseStudent(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseActivityID(w, r)
+	if !ok {
+		return
+	}
+	date, ok := parseRegisterDate(w, r)
+	if !ok {
+		return
+	}
+	studentID, ok := rs.parseStudentID(w, r)
+	if !ok {
+		return
+	}
+
+	req := &ExcuseRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	staff, err := rs.UserContextService.GetCurrentStaff(r.Context())
+	if err != nil || staff == nil {
+		common.RenderError(w, r, ErrorForbidden(errors.New("only staff members can excuse students")))
+		return
+	}
+
+	entry, err := rs.AttendanceRegisterService.ExcuseStudent(r.Context(), id, date, studentID, staff.ID, req.Note)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+	common.Respond(w, r, http.StatusOK, entry, "Student excused successfully")
+}
+
+// getAttendanceReport returns the term attendance report, or downloads it with ?format=xlsx
+func (rs *Resource) getAttendanceReport(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseActivityID(w, r)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Get("format") != "xlsx" {
+		report, err := rs.AttendanceRegisterService.GetTermReport(r.Context(), id)
+		if err != nil {
+			common.RenderError(w, r, ErrorRenderer(err))
+			return
+		}
+		common.Respond(w, r, http.StatusOK, report, "Attendance report retrieved successfully")
+		return
+	}
+
+	fileBytes, filename, err := rs.AttendanceRegisterService.ExportTermReport(r.Context(), id)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", strconv.Itoa(len(fileBytes)))
+	if _, err := w.Write(fileBytes); err != nil {
+		// Response already started, just log the error
+		slog.Default().Error("failed to write attendance report", slog.String("error", err.Error()))
+	}
+}
@@ -37,6 +37,7 @@ import (
 	usersAPI "github.com/moto-nrw/project-phoenix/api/users"
 
 	operatorAPI "github.com/moto-nrw/project-phoenix/api/operator"
+	parentAPI "github.com/moto-nrw/project-phoenix/api/parent"
 	platformAPI "github.com/moto-nrw/project-phoenix/api/platform"
 
 	"github.com/moto-nrw/project-phoenix/database"
@@ -72,6 +73,7 @@ type API struct {
 	Database         *databaseAPI.Resource
 	GradeTransitions *adminAPI.GradeTransitionResource
 	TimeTracking     *timeTrackingAPI.Resource
+	Parent           *parentAPI.Resource // Guardian portal (parent-scoped tokens)
 
 	// Operator Dashboard (platform domain)
 	Operator *operatorAPI.Resource
@@ -226,17 +228,22 @@ func initializeAPIResources(api *API, repoFactory *repositories.Factory, db *bun
 		IoTService:            api.Services.IoT,
 		PrivacyConsentRepo:    repoFactory.PrivacyConsent,
 		PickupScheduleService: api.Services.PickupSchedule,
+		StudentAbsenceService: api.Services.StudentAbsence,
+		MissingStudentService: api.Services.MissingStudents,
+		PickupBoardService:    api.Services.PickupBoard,
+		PickupAuthService:     api.Services.PickupAuthorization,
+		TimelineService:       api.Services.StudentTimeline,
 	})
 	api.Groups = groupsAPI.NewResource(api.Services.Education, api.Services.Active, api.Services.Users, api.Services.UserContext, repoFactory.Student, repoFactory.GroupSubstitution)
 	api.Guardians = guardiansAPI.NewResource(api.Services.Guardian, api.Services.Users, api.Services.Education, api.Services.UserContext, repoFactory.Student)
 	api.Import = importAPI.NewResource(api.Services.Import, repoFactory.DataImport)
-	api.Activities = activitiesAPI.NewResource(api.Services.Activities, api.Services.Schedule, api.Services.Users, api.Services.UserContext)
+	api.Activities = activitiesAPI.NewResource(api.Services.Activities, api.Services.Schedule, api.Services.Users, api.Services.UserContext, api.Services.ActivityAllocation, api.Services.AttendanceRegister)
 	api.Staff = staffAPI.NewResource(api.Services.Users, api.Services.Education, api.Services.Auth, repoFactory.GroupSupervisor, api.Services.WorkSession, repoFactory.StaffAbsence)
 	api.Feedback = feedbackAPI.NewResource(api.Services.Feedback)
 	api.Suggestions = suggestionsAPI.NewResource(api.Services.Suggestions)
-	api.Schedules = schedulesAPI.NewResource(api.Services.Schedule)
+	api.Schedules = schedulesAPI.NewResource(api.Services.Schedule, api.Services.BusRoute, api.Services.CalendarFeed, api.Services.Users)
 	api.Config = configAPI.NewResource(api.Services.Config, api.Services.ActiveCleanup)
-	api.Active = activeAPI.NewResource(api.Services.Active, api.Services.Users, api.Services.Schulhof, api.Services.UserContext, db, logger.With("handler", "active"))
+	api.Active = activeAPI.NewResource(api.Services.Active, api.Services.Users, api.Services.Schulhof, api.Services.UserContext, api.Services.PickupAuthorization, db, logger.With("handler", "active"))
 	api.IoT = iotAPI.NewResource(iotAPI.ServiceDependencies{
 		IoTService:        api.Services.IoT,
 		UsersService:      api.Services.Users,
@@ -246,6 +253,7 @@ func initializeAPIResources(api *API, repoFactory *repositories.Factory, db *bun
 		FacilityService:   api.Services.Facilities,
 		EducationService:  api.Services.Education,
 		FeedbackService:   api.Services.Feedback,
+		PickupAuthService: api.Services.PickupAuthorization,
 		Logger:            logger.With("handler", "iot"),
 	})
 	api.SSE = sseAPI.NewResource(api.Services.RealtimeHub, api.Services.Active, api.Services.Users, api.Services.UserContext, logger.With("handler", "sse"))
@@ -254,7 +262,11 @@ func initializeAPIResources(api *API, repoFactory *repositories.Factory, db *bun
 	api.Substitutions = substitutionsAPI.NewResource(api.Services.Education)
 	api.Database = databaseAPI.NewResource(api.Services.Database)
 	api.GradeTransitions = adminAPI.NewGradeTransitionResource(api.Services.GradeTransition)
-	api.TimeTracking = timeTrackingAPI.NewResource(api.Services.WorkSession, api.Services.StaffAbsence, api.Services.Users)
+	api.TimeTracking = timeTrackingAPI.NewResource(api.Services.WorkSession, api.Services.StaffAbsence, api.Services.TimeBalance, api.Services.Users)
+	api.Parent = parentAPI.NewResource(parentAPI.ResourceConfig{
+		Service:   api.Services.ParentPortal,
+		TokenAuth: nil, // Created internally by parent API
+	})
 
 	// Initialize operator dashboard resources
 	api.Operator = operatorAPI.NewResource(operatorAPI.ResourceConfig{
@@ -350,6 +362,10 @@ func (a *API) registerRoutesWithRateLimiting() {
 		r.Mount("/suggestions", a.Suggestions.Router())
 
 		// Mount schedule resources
+		// Apply the auth rate limiter to the public calendar feeds to slow down token guessing
+		if rateLimitEnabled && authRateLimiter != nil {
+			a.Schedules.SetFeedRateLimiter(authRateLimiter.Middleware())
+		}
 		r.Mount("/schedules", a.Schedules.Router())
 
 		// Mount config resources
@@ -359,6 +375,10 @@ func (a *API) registerRoutesWithRateLimiting() {
 		r.Mount("/active", a.Active.Router())
 
 		// Mount IoT resources
+		// Apply the auth rate limiter to device enrollment to slow down code guessing
+		if rateLimitEnabled && authRateLimiter != nil {
+			a.IoT.SetEnrollRateLimiter(authRateLimiter.Middleware())
+		}
 		r.Mount("/iot", a.IoT.Router())
 
 		// Mount users resources
@@ -388,6 +408,13 @@ func (a *API) registerRoutesWithRateLimiting() {
 		// Mount platform resources (user-facing announcements)
 		r.Mount("/platform", a.Platform.Router())
 
+		// Mount guardian portal resources (separate login, parent-scoped tokens)
+		// Apply the same auth rate limiter to parent login for brute-force protection
+		if rateLimitEnabled && authRateLimiter != nil {
+			a.Parent.SetAuthRateLimiter(authRateLimiter.Middleware())
+		}
+		r.Mount("/parent", a.Parent.Router())
+
 		// Add other resource routes here as they are implemented
 	})
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
 	educationModels "github.com/moto-nrw/project-phoenix/models/education"
 	userModels "github.com/moto-nrw/project-phoenix/models/users"
 	activeService "github.com/moto-nrw/project-phoenix/services/active"
@@ -81,6 +82,14 @@ func (s *StudentDataSnapshot) GetGroup(groupID int64) *educationModels.Group {
 	return s.Groups[groupID]
 }
 
+// GetCurrentVisit retrieves a checked-in student's current visit from the snapshot with nil safety
+func (s *StudentDataSnapshot) GetCurrentVisit(studentID int64) *activeModels.Visit {
+	if s == nil || s.LocationSnapshot == nil {
+		return nil
+	}
+	return s.LocationSnapshot.Visits[studentID]
+}
+
 // ResolveLocationWithTime retrieves location info including entry time from the snapshot
 func (s *StudentDataSnapshot) ResolveLocationWithTime(studentID int64, hasFullAccess bool) StudentLocationInfo {
 	if s == nil || s.LocationSnapshot == nil {
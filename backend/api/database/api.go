@@ -39,6 +39,9 @@ func (rs *Resource) Router() chi.Router {
 
 		// Stats endpoint - requires system:manage permission (admin only)
 		r.With(authorize.RequiresPermission("system:manage")).Get("/stats", rs.getStats)
+
+		// Email outbox delivery status per type - requires system:manage permission
+		r.With(authorize.RequiresPermission("system:manage")).Get("/email-delivery", rs.getEmailDeliveryStats)
 	})
 
 	return r
@@ -57,9 +60,24 @@ func (rs *Resource) getStats(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, stats)
 }
 
+// getEmailDeliveryStats returns email outbox counts grouped by delivery type and status
+func (rs *Resource) getEmailDeliveryStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := rs.DatabaseService.GetEmailDeliveryStats(r.Context())
+	if err != nil {
+		slog.Default().Error("failed to get email delivery stats", slog.String("error", err.Error()))
+		common.RenderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	render.JSON(w, r, stats)
+}
+
 // =============================================================================
 // HANDLER ACCESSOR METHODS (for testing)
 // =============================================================================
 
 // GetStatsHandler returns the getStats handler
 func (rs *Resource) GetStatsHandler() http.HandlerFunc { return rs.getStats }
+
+// GetEmailDeliveryStatsHandler returns the getEmailDeliveryStats handler
+func (rs *Resource) GetEmailDeliveryStatsHandler() http.HandlerFunc { return rs.getEmailDeliveryStats }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth/v5"
@@ -258,6 +259,11 @@ func (rs *Resource) previewStudentImport(w http.ResponseWriter, r *http.Request)
 		return // Error already handled by validateAndParseCSVFile
 	}
 
+	mode, ok := parseImportMode(w, r)
+	if !ok {
+		return
+	}
+
 	// Get user ID from context
 	userID, err := getUserIDFromContext(r.Context())
 	if err != nil {
@@ -269,9 +275,9 @@ func (rs *Resource) previewStudentImport(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	request := importModels.ImportRequest[importModels.StudentImportRow]{
 		Rows:            uploadResult.Rows,
-		Mode:            importModels.ImportModeCreate, // Create-only: duplicates will error
-		DryRun:          true,                          // PREVIEW ONLY
-		StopOnError:     false,                         // Collect all errors
+		Mode:            mode,
+		DryRun:          true,  // PREVIEW ONLY
+		StopOnError:     false, // Collect all errors
 		UserID:          userID,
 		SkipInvalidRows: false,
 	}
@@ -283,7 +289,7 @@ func (rs *Resource) previewStudentImport(w http.ResponseWriter, r *http.Request)
 	}
 
 	// GDPR Compliance: Audit log for preview (Article 30)
-	rs.logImportAudit(uploadResult.Filename, result, userID, true)
+	rs.logImportAudit(uploadResult.Filename, mode, result, userID, true)
 
 	common.Respond(w, r, http.StatusOK, result, "Import-Vorschau erfolgreich")
 }
@@ -296,6 +302,11 @@ func (rs *Resource) importStudents(w http.ResponseWriter, r *http.Request) {
 		return // Error already handled by validateAndParseCSVFile
 	}
 
+	mode, ok := parseImportMode(w, r)
+	if !ok {
+		return
+	}
+
 	// Get user ID from context
 	userID, err := getUserIDFromContext(r.Context())
 	if err != nil {
@@ -307,9 +318,9 @@ func (rs *Resource) importStudents(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	request := importModels.ImportRequest[importModels.StudentImportRow]{
 		Rows:            uploadResult.Rows,
-		Mode:            importModels.ImportModeCreate, // Create-only: duplicates will error
-		DryRun:          false,                         // ACTUAL IMPORT
-		StopOnError:     false,                         // Continue on errors
+		Mode:            mode,
+		DryRun:          false, // ACTUAL IMPORT
+		StopOnError:     false, // Continue on errors
 		UserID:          userID,
 		SkipInvalidRows: true, // Skip invalid rows, import valid ones
 	}
@@ -324,19 +335,36 @@ func (rs *Resource) importStudents(w http.ResponseWriter, r *http.Request) {
 	slog.Default().Info("Student import completed",
 		slog.Int("created", result.CreatedCount),
 		slog.Int("updated", result.UpdatedCount),
+		slog.Int("skipped", result.SkippedCount),
 		slog.Int("errors", result.ErrorCount),
+		slog.String("mode", string(mode)),
 		slog.String("filename", uploadResult.Filename))
 
 	// GDPR Compliance: Audit log for actual import (Article 30)
-	rs.logImportAudit(uploadResult.Filename, result, userID, false)
+	rs.logImportAudit(uploadResult.Filename, mode, result, userID, false)
 
 	// Build success message
-	message := fmt.Sprintf("Import abgeschlossen: %d erstellt, %d aktualisiert, %d Fehler",
-		result.CreatedCount, result.UpdatedCount, result.ErrorCount)
+	message := fmt.Sprintf("Import abgeschlossen: %d erstellt, %d aktualisiert, %d unverändert, %d Fehler",
+		result.CreatedCount, result.UpdatedCount, result.SkippedCount, result.ErrorCount)
 
 	common.Respond(w, r, http.StatusOK, result, message)
 }
 
+// parseImportMode reads the optional "mode" form field (create, update or upsert).
+// Defaults to create so existing students are never changed unless requested.
+func parseImportMode(w http.ResponseWriter, r *http.Request) (importModels.ImportMode, bool) {
+	mode := importModels.ImportMode(strings.ToLower(strings.TrimSpace(r.FormValue("mode"))))
+	switch mode {
+	case "":
+		return importModels.ImportModeCreate, true
+	case importModels.ImportModeCreate, importModels.ImportModeUpdate, importModels.ImportModeUpsert:
+		return mode, true
+	default:
+		common.RenderError(w, r, common.ErrorInvalidRequest(fmt.Errorf("ungültiger Import-Modus '%s' (erlaubt: create, update, upsert)", mode)))
+		return "", false
+	}
+}
+
 // getUserIDFromContext extracts the user ID from the JWT context
 func getUserIDFromContext(ctx context.Context) (int64, error) {
 	claims, ok := ctx.Value(jwt.CtxClaims).(jwt.AppClaims)
@@ -348,7 +376,7 @@ func getUserIDFromContext(ctx context.Context) (int64, error) {
 }
 
 // logImportAudit creates an audit record for import operations (GDPR compliance)
-func (rs *Resource) logImportAudit(filename string, result *importModels.ImportResult[importModels.StudentImportRow], userID int64, dryRun bool) {
+func (rs *Resource) logImportAudit(filename string, mode importModels.ImportMode, result *importModels.ImportResult[importModels.StudentImportRow], userID int64, dryRun bool) {
 	go func() {
 		auditCtx := context.Background()
 		auditRecord := &audit.DataImport{
@@ -357,14 +385,14 @@ func (rs *Resource) logImportAudit(filename string, result *importModels.ImportR
 			TotalRows:    result.TotalRows,
 			CreatedCount: result.CreatedCount,
 			UpdatedCount: result.UpdatedCount,
-			SkippedCount: 0, // Not tracked separately
+			SkippedCount: result.SkippedCount,
 			ErrorCount:   result.ErrorCount,
 			WarningCount: result.WarningCount,
 			DryRun:       dryRun,
 			ImportedBy:   userID,
 			StartedAt:    result.StartedAt,
 			CompletedAt:  &result.CompletedAt,
-			Metadata:     audit.JSONBMap{},
+			Metadata:     buildImportAuditMetadata(mode, result.Diffs),
 		}
 		if err := rs.auditRepo.Create(auditCtx, auditRecord); err != nil {
 			if dryRun {
@@ -376,6 +404,28 @@ func (rs *Resource) logImportAudit(filename string, result *importModels.ImportR
 	}()
 }
 
+// buildImportAuditMetadata records the import mode and which fields changed per updated student.
+// Old and new values are deliberately left out so the audit log holds no personal data.
+func buildImportAuditMetadata(mode importModels.ImportMode, diffs []importModels.RowDiff) audit.JSONBMap {
+	updates := make([]map[string]any, 0, len(diffs))
+	for _, diff := range diffs {
+		fields := make([]string, 0, len(diff.Changes))
+		for _, change := range diff.Changes {
+			fields = append(fields, change.Field)
+		}
+		updates = append(updates, map[string]any{
+			"row":        diff.RowNumber,
+			"student_id": diff.EntityID,
+			"fields":     fields,
+		})
+	}
+
+	return audit.JSONBMap{
+		"mode":    string(mode),
+		"updates": updates,
+	}
+}
+
 // =============================================================================
 // HANDLER ACCESSOR METHODS (for testing)
 // =============================================================================
@@ -317,6 +317,27 @@ func TestPreviewImport_WithMissingHeaders(t *testing.T) {
 // IMPORT STUDENTS WITH FILE TESTS
 // =============================================================================
 
+func TestPreviewImport_InvalidMode(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	admin, _ := testpkg.CreateTestTeacherWithAccount(t, ctx.db, "Preview", "InvalidMode")
+
+	router := chi.NewRouter()
+	router.Post("/preview", ctx.resource.PreviewImportHandler())
+
+	csvContent := "Vorname,Nachname,Klasse\nMax,Mustermann,1a"
+
+	req := testutil.NewMultipartRequest(t, "POST", "/preview?mode=replace", "file", "students.csv", csvContent,
+		testutil.WithClaims(testutil.AdminTestClaims(int(admin.ID))),
+	)
+
+	rr := testutil.ExecuteRequest(router, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "Expected 400 for unknown mode, got %d: %s", rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "Import-Modus")
+}
+
 func TestImportStudents_WithValidCSV(t *testing.T) {
 	ctx := setupTestContext(t)
 	defer func() { _ = ctx.db.Close() }()
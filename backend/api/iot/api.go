@@ -43,6 +43,7 @@ type ServiceDependencies struct {
 	FacilityService   facilitiesSvc.Service
 	EducationService  educationSvc.Service
 	FeedbackService   feedbackSvc.Service
+	PickupAuthService usersSvc.PickupAuthorizationService // Verifies pickup persons at device checkout
 	Logger            *slog.Logger
 }
 
@@ -56,7 +57,9 @@ type Resource struct {
 	FacilityService   facilitiesSvc.Service
 	EducationService  educationSvc.Service
 	FeedbackService   feedbackSvc.Service
+	PickupAuthService usersSvc.PickupAuthorizationService
 	logger            *slog.Logger
+	enrollRateLimiter func(http.Handler) http.Handler
 }
 
 // NewResource creates a new IoT resource
@@ -70,10 +73,16 @@ func NewResource(deps ServiceDependencies) *Resource {
 		FacilityService:   deps.FacilityService,
 		EducationService:  deps.EducationService,
 		FeedbackService:   deps.FeedbackService,
+		PickupAuthService: deps.PickupAuthService,
 		logger:            deps.Logger,
 	}
 }
 
+// SetEnrollRateLimiter sets the rate limiter middleware for the public enrollment endpoint.
+func (rs *Resource) SetEnrollRateLimiter(mw func(http.Handler) http.Handler) {
+	rs.enrollRateLimiter = mw
+}
+
 // getLogger returns the resource's logger, falling back to slog.Default() if nil.
 func (rs *Resource) getLogger() *slog.Logger {
 	if rs.logger != nil {
@@ -90,10 +99,12 @@ func (rs *Resource) Router() chi.Router {
 	// Create JWT auth instance for middleware
 	tokenAuth, _ := jwt.NewTokenAuth()
 
-	// Public routes (if any device endpoints should be public)
+	// Public routes - a new device exchanges its one-time enrollment code for an API key
 	r.Group(func(r chi.Router) {
-		// Some basic device info might be public
-		// Currently no public routes for IoT devices
+		if rs.enrollRateLimiter != nil {
+			r.Use(rs.enrollRateLimiter)
+		}
+		r.Post("/enroll", devices.NewResource(rs.IoTService).EnrollDeviceHandler())
 	})
 
 	// Protected routes that require authentication and permissions
@@ -128,6 +139,7 @@ func (rs *Resource) Router() chi.Router {
 			rs.FacilityService,
 			rs.ActivitiesService,
 			rs.EducationService,
+			rs.PickupAuthService,
 			rs.getLogger().With(slog.String("sub", "checkin")),
 		)
 		// Register routes directly instead of mounting at "/" to avoid Chi conflict
@@ -3,6 +3,7 @@ package iot
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -149,6 +150,35 @@ func TestResource_Router_HasRoutes(t *testing.T) {
 	assert.NotEqual(t, http.StatusNotFound, w.Code)
 }
 
+func TestResource_Router_EnrollRouteIsPublic(t *testing.T) {
+	resource := &Resource{}
+	router := resource.Router()
+
+	// No JWT or device key: an invalid body is rejected by validation, not by auth
+	req := httptest.NewRequest("POST", "/enroll", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestResource_Router_EnrollRateLimiter(t *testing.T) {
+	resource := &Resource{}
+	resource.SetEnrollRateLimiter(func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+	})
+	router := resource.Router()
+
+	req := httptest.NewRequest("POST", "/enroll", strings.NewReader(`{"code":"ABCD-EFGH"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
 func TestResource_Router_CheckinRoute(t *testing.T) {
 	resource := &Resource{}
 	router := resource.Router()
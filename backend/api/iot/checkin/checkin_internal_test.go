@@ -382,6 +382,38 @@ func TestBuildCheckinResponse_NoTransferNoPreviousRoom(t *testing.T) {
 	// No previous_room field for non-transfer actions
 	_, exists := response["previous_room"]
 	assert.False(t, exists)
+	_, exists = response["warning"]
+	assert.False(t, exists)
+}
+
+func TestBuildCheckinResponse_Warning(t *testing.T) {
+	student := &users.Student{
+		Model:  base.Model{ID: 1},
+		Person: &users.Person{FirstName: "Max", LastName: "Test"},
+	}
+	result := &checkinResult{Action: "checked_in", Warning: "Achtung: Max Test ist krank gemeldet"}
+
+	response := buildCheckinResponse(student, result, time.Now())
+
+	assert.Equal(t, "Achtung: Max Test ist krank gemeldet", response["warning"])
+}
+
+// =============================================================================
+// buildAbsenceWarning TESTS
+// =============================================================================
+
+func TestBuildAbsenceWarning(t *testing.T) {
+	student := &users.Student{
+		Model:  base.Model{ID: 1},
+		Person: &users.Person{FirstName: "Max", LastName: "Test"},
+	}
+
+	assert.Empty(t, buildAbsenceWarning(student, false, nil))
+	assert.Equal(t, "Achtung: Max Test ist krank gemeldet", buildAbsenceWarning(student, true, nil))
+	assert.Equal(t, "Achtung: Max Test ist krank gemeldet",
+		buildAbsenceWarning(student, false, &active.StudentAbsence{AbsenceType: active.StudentAbsenceTypeSick}))
+	assert.Equal(t, "Achtung: Max Test ist für heute abwesend gemeldet",
+		buildAbsenceWarning(student, false, &active.StudentAbsence{AbsenceType: active.StudentAbsenceTypeTrip}))
 }
 
 // =============================================================================
@@ -766,3 +798,34 @@ func TestCheckinResult_AllFields(t *testing.T) {
 	assert.True(t, result.DailyCheckoutAvailable)
 	assert.Equal(t, &activeStudents, result.ActiveStudents)
 }
+
+// =============================================================================
+// CheckinRequest.Bind TESTS
+// =============================================================================
+
+func TestCheckinRequestBind_PickedUpBy(t *testing.T) {
+	roomID := int64(12)
+
+	tests := []struct {
+		name    string
+		req     CheckinRequest
+		wantErr string
+	}{
+		{"valid guardian", CheckinRequest{StudentRFID: "AA11", Action: "checkout", PickedUpBy: &PickupSelectionRequest{Type: "guardian", ID: 31}}, ""},
+		{"invalid type", CheckinRequest{StudentRFID: "AA11", Action: "checkout", PickedUpBy: &PickupSelectionRequest{Type: "neighbour", ID: 31}}, "picked_up_by.type"},
+		{"missing id", CheckinRequest{StudentRFID: "AA11", Action: "checkout", PickedUpBy: &PickupSelectionRequest{Type: "person"}}, "picked_up_by.id"},
+		{"with room", CheckinRequest{StudentRFID: "AA11", Action: "checkout", RoomID: &roomID, PickedUpBy: &PickupSelectionRequest{Type: "person", ID: 42}}, "room_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Bind(nil)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -48,6 +48,7 @@ func setupTestContext(t *testing.T) *testContext {
 		svc.Facilities,
 		svc.Activities,
 		svc.Education,
+		svc.PickupAuthorization,
 		slog.Default(),
 	)
 
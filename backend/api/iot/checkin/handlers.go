@@ -131,6 +131,7 @@ func (rs *Resource) deviceCheckin(w http.ResponseWriter, r *http.Request) {
 		slog.String("class", student.SchoolClass),
 	)
 	student.Person = person
+	wasSick := student.Sick != nil && *student.Sick
 
 	// Step 5: Load current visit with room information
 	currentVisit := rs.loadCurrentVisitWithRoom(ctx, student.ID)
@@ -144,9 +145,15 @@ func (rs *Resource) deviceCheckin(w http.ResponseWriter, r *http.Request) {
 	// Checkout always produces "unterwegs" state — attendance is NOT synced here.
 	// Daily attendance checkout is confirmed separately via confirm_daily_checkout
 	// when the student selects "nach Hause" on the device.
+	// A selected pickup person checks the student out for the day, verified before the visit ends.
+	pickup, ok := rs.verifyCheckinPickup(ctx, w, r, student, currentVisit, req)
+	if !ok {
+		return
+	}
+
 	if currentVisit != nil {
 		var err error
-		checkoutVisitID, previousRoomName, err = rs.processCheckout(ctx, w, r, student, person, currentVisit)
+		checkoutVisitID, previousRoomName, err = rs.processCheckout(ctx, w, r, student, person, currentVisit, pickup)
 		if err != nil {
 			return
 		}
@@ -204,6 +211,17 @@ func (rs *Resource) deviceCheckin(w http.ResponseWriter, r *http.Request) {
 		result.DailyCheckoutAvailable = rs.shouldShowDailyCheckoutWithGroup(ctx, student, currentVisit)
 	}
 
+	// Step 10a: A student collected by a pickup person is already checked out for the day
+	if pickup != nil {
+		result.Action = "checked_out_daily"
+		result.DailyCheckoutAvailable = false
+	}
+
+	// Step 10b: Warn the supervisor when a student reported sick or absent checks in
+	if newVisitID != nil {
+		result.Warning = rs.absenceWarning(ctx, student, wasSick, now)
+	}
+
 	// Step 11: Update session activity for device monitoring
 	if req.RoomID != nil {
 		rs.updateSessionActivityForDevice(ctx, *req.RoomID, deviceCtx.ID)
@@ -22,6 +22,7 @@ type Resource struct {
 	FacilityService   facilitiesSvc.Service
 	ActivitiesService activitiesSvc.ActivityService
 	EducationService  educationSvc.Service
+	PickupAuthService usersSvc.PickupAuthorizationService
 	logger            *slog.Logger
 }
 
@@ -41,6 +42,7 @@ func NewResource(
 	facilityService facilitiesSvc.Service,
 	activitiesService activitiesSvc.ActivityService,
 	educationService educationSvc.Service,
+	pickupAuthService usersSvc.PickupAuthorizationService,
 	logger *slog.Logger,
 ) *Resource {
 	return &Resource{
@@ -50,6 +52,7 @@ func NewResource(
 		FacilityService:   facilityService,
 		ActivitiesService: activitiesService,
 		EducationService:  educationService,
+		PickupAuthService: pickupAuthService,
 		logger:            logger,
 	}
 }
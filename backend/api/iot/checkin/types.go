@@ -1,10 +1,12 @@
 package checkin
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
+	usersSvc "github.com/moto-nrw/project-phoenix/services/users"
 )
 
 // CheckinRequest represents a student check-in request from RFID devices
@@ -12,6 +14,15 @@ type CheckinRequest struct {
 	StudentRFID string `json:"student_rfid"`
 	Action      string `json:"action"` // "checkin" or "checkout"
 	RoomID      *int64 `json:"room_id,omitempty"`
+
+	// PickedUpBy checks the student out for the day with the guardian or pickup person collecting them
+	PickedUpBy *PickupSelectionRequest `json:"picked_up_by,omitempty"`
+}
+
+// PickupSelectionRequest identifies the guardian or pickup person who collects the student
+type PickupSelectionRequest struct {
+	Type string `json:"type"` // guardian or person
+	ID   int64  `json:"id"`   // Guardian profile ID or pickup person ID
 }
 
 // CheckinResponse represents the response to a student check-in request
@@ -28,9 +39,24 @@ type CheckinResponse struct {
 
 // Bind validates the checkin request
 func (req *CheckinRequest) Bind(_ *http.Request) error {
-	return validation.ValidateStruct(req,
+	if err := validation.ValidateStruct(req,
 		validation.Field(&req.StudentRFID, validation.Required),
 		// Note: Action field is ignored in logic but still required for API compatibility
 		validation.Field(&req.Action, validation.Required, validation.In("checkin", "checkout")),
-	)
+	); err != nil {
+		return err
+	}
+
+	if p := req.PickedUpBy; p != nil {
+		if p.Type != usersSvc.PickupTypeGuardian && p.Type != usersSvc.PickupTypePerson {
+			return errors.New("picked_up_by.type must be 'guardian' or 'person'")
+		}
+		if p.ID <= 0 {
+			return errors.New("picked_up_by.id is required")
+		}
+		if req.RoomID != nil {
+			return errors.New("picked_up_by cannot be combined with room_id")
+		}
+	}
+	return nil
 }
@@ -13,10 +13,13 @@ import (
 	iotCommon "github.com/moto-nrw/project-phoenix/api/iot/common"
 	"github.com/moto-nrw/project-phoenix/auth/device"
 	"github.com/moto-nrw/project-phoenix/constants"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
 	"github.com/moto-nrw/project-phoenix/models/active"
 	"github.com/moto-nrw/project-phoenix/models/facilities"
 	"github.com/moto-nrw/project-phoenix/models/iot"
 	"github.com/moto-nrw/project-phoenix/models/users"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	usersSvc "github.com/moto-nrw/project-phoenix/services/users"
 )
 
 // checkinResult holds the result of processing a checkin request
@@ -28,6 +31,7 @@ type checkinResult struct {
 	GreetingMsg            string
 	DailyCheckoutAvailable bool
 	ActiveStudents         *int
+	Warning                string // Shown on the device, e.g. when a student reported sick checks in
 }
 
 // checkinResultInput holds the input parameters for building a checkin result.
@@ -278,7 +282,7 @@ func (rs *Resource) loadCurrentVisitWithRoom(ctx context.Context, studentID int6
 
 // processCheckout handles the checkout logic for a student with an active visit
 // Returns: visitID, previousRoomName, error
-func (rs *Resource) processCheckout(ctx context.Context, w http.ResponseWriter, r *http.Request, student *users.Student, person *users.Person, currentVisit *active.Visit) (*int64, string, error) {
+func (rs *Resource) processCheckout(ctx context.Context, w http.ResponseWriter, r *http.Request, student *users.Student, person *users.Person, currentVisit *active.Visit, pickup *usersSvc.AuthorizedPickup) (*int64, string, error) {
 	rs.getLogger().DebugContext(ctx, "student has active visit, performing checkout",
 		slog.String("student_name", person.FirstName+" "+person.LastName),
 		slog.Int64("student_id", student.ID),
@@ -313,6 +317,18 @@ func (rs *Resource) processCheckout(ctx context.Context, w http.ResponseWriter,
 		return nil, "", err
 	}
 
+	// Collected by a pickup person: check out for the day, with the pickup person stored in the same transaction
+	if pickup != nil {
+		if err := rs.checkoutWithPickup(ctx, student.ID, pickup); err != nil {
+			rs.getLogger().ErrorContext(ctx, "failed to check out student with pickup person",
+				slog.Int64("student_id", student.ID),
+				slog.String("error", err.Error()),
+			)
+			iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+			return nil, "", err
+		}
+	}
+
 	rs.getLogger().InfoContext(ctx, "checked out student",
 		slog.Int64("student_id", student.ID),
 		slog.Int64("visit_id", currentVisit.ID),
@@ -322,6 +338,54 @@ func (rs *Resource) processCheckout(ctx context.Context, w http.ResponseWriter,
 	return &visitID, previousRoomName, nil
 }
 
+// verifyCheckinPickup checks the selected pickup person against the student's pickup list.
+// Returns nil when no person was selected; false when an error response was rendered.
+func (rs *Resource) verifyCheckinPickup(ctx context.Context, w http.ResponseWriter, r *http.Request, student *users.Student, currentVisit *active.Visit, req *CheckinRequest) (*usersSvc.AuthorizedPickup, bool) {
+	if req.PickedUpBy == nil {
+		return nil, true
+	}
+	if currentVisit == nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(errors.New("student has no active visit to check out")))
+		return nil, false
+	}
+	if rs.PickupAuthService == nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInternalServer(errors.New("pickup verification is not configured")))
+		return nil, false
+	}
+
+	pickup, err := rs.PickupAuthService.VerifyPickup(ctx, student.ID, usersSvc.PickupSelection{
+		Type: req.PickedUpBy.Type,
+		ID:   req.PickedUpBy.ID,
+	}, timezone.Today())
+	if err != nil {
+		// A person who may not collect the student blocks the checkout with 409
+		if _, notAuthorized := usersSvc.IsPickupNotAuthorized(err); notAuthorized {
+			iotCommon.RenderError(w, r, iotCommon.ErrorConflict(err))
+		} else if errors.Is(err, usersSvc.ErrPickupPersonNotFound) {
+			iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(errors.New("selected person is not on the student's pickup list")))
+		} else {
+			iotCommon.RenderError(w, r, iotCommon.ErrorInternalServer(errors.New("failed to verify pickup person")))
+		}
+		return nil, false
+	}
+	return pickup, true
+}
+
+// checkoutWithPickup checks the student out for the day and records the pickup person
+func (rs *Resource) checkoutWithPickup(ctx context.Context, studentID int64, pickup *usersSvc.AuthorizedPickup) error {
+	staffID, deviceID := int64(0), int64(0)
+	if deviceCtx := device.DeviceFromCtx(ctx); deviceCtx != nil {
+		deviceID = deviceCtx.ID
+	}
+	if staffCtx := device.StaffFromCtx(ctx); staffCtx != nil {
+		staffID = staffCtx.ID
+	}
+
+	// skipAuthCheck=true because the IoT device already authenticated this request
+	_, err := rs.ActiveService.CheckOutStudent(ctx, studentID, staffID, deviceID, true, activeSvc.PickupCheckoutDetails(pickup))
+	return err
+}
+
 // shouldSkipCheckin determines if checkin should be skipped (same room scenario)
 func shouldSkipCheckin(roomID *int64, checkedOut bool, currentVisit *active.Visit) bool {
 	if roomID == nil || !checkedOut || currentVisit == nil || currentVisit.ActiveGroup == nil {
@@ -798,6 +862,34 @@ func (rs *Resource) updateSessionActivityForDevice(ctx context.Context, roomID i
 	}
 }
 
+// absenceWarning looks up a reported absence for the student's check-in day.
+// The sick flag is read from the student loaded before check-in, since check-in clears it.
+func (rs *Resource) absenceWarning(ctx context.Context, student *users.Student, wasSick bool, now time.Time) string {
+	absence, err := rs.ActiveService.GetStudentAbsenceOnDate(ctx, student.ID, now)
+	if err != nil {
+		rs.getLogger().WarnContext(ctx, "failed to check student absence on checkin",
+			slog.Int64("student_id", student.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+	return buildAbsenceWarning(student, wasSick, absence)
+}
+
+// buildAbsenceWarning returns the device warning for a student who checks in although
+// reported sick or absent, or an empty string
+func buildAbsenceWarning(student *users.Student, wasSick bool, absence *active.StudentAbsence) string {
+	studentName := student.Person.FirstName + " " + student.Person.LastName
+
+	switch {
+	case wasSick || (absence != nil && absence.AbsenceType == active.StudentAbsenceTypeSick):
+		return "Achtung: " + studentName + " ist krank gemeldet"
+	case absence != nil:
+		return "Achtung: " + studentName + " ist für heute abwesend gemeldet"
+	default:
+		return ""
+	}
+}
+
 // buildCheckinResponse builds the final checkin response map
 func buildCheckinResponse(student *users.Student, result *checkinResult, now time.Time) map[string]interface{} {
 	studentName := student.Person.FirstName + " " + student.Person.LastName
@@ -822,6 +914,10 @@ func buildCheckinResponse(student *users.Student, result *checkinResult, now tim
 		response["active_students"] = *result.ActiveStudents
 	}
 
+	if result.Warning != "" {
+		response["warning"] = result.Warning
+	}
+
 	return response
 }
 
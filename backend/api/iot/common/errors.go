@@ -141,6 +141,11 @@ func ErrorNotFound(err error) render.Renderer {
 	return common.ErrorNotFound(err)
 }
 
+// ErrorUnauthorized returns a 401 Unauthorized error response
+func ErrorUnauthorized(err error) render.Renderer {
+	return common.ErrorUnauthorized(err)
+}
+
 // ErrorConflict returns a 409 Conflict error response
 func ErrorConflict(err error) render.Renderer {
 	return common.ErrorConflict(err)
@@ -186,6 +191,10 @@ func handleIoTServiceError(iotErr *iotSvc.IoTError) render.Renderer {
 		return ErrorInternalServer(iotErr)
 	case iotSvc.ErrDatabaseOperation:
 		return ErrorInternalServer(iotErr)
+	case iotSvc.ErrInvalidEnrollmentCode:
+		return ErrorUnauthorized(iotErr)
+	case iotSvc.ErrInvalidEnrollmentRequest, iotSvc.ErrInvalidGracePeriod:
+		return ErrorInvalidRequest(iotErr)
 	default:
 		return handleIoTErrorTypes(iotErr)
 	}
@@ -1,6 +1,7 @@
 package devices_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -8,10 +9,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
 
 	devicesAPI "github.com/moto-nrw/project-phoenix/api/iot/devices"
 	"github.com/moto-nrw/project-phoenix/api/testutil"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
 	"github.com/moto-nrw/project-phoenix/services"
 	testpkg "github.com/moto-nrw/project-phoenix/test"
 )
@@ -739,11 +742,57 @@ func TestGetDeviceStatistics_Success(t *testing.T) {
 	assert.Contains(t, data, "offline_devices", "Response should contain offline_devices")
 }
 
+// =============================================================================
+// GET DEVICE UPTIME TESTS
+// =============================================================================
+
+func TestGetDeviceUptime_Success(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	device := testpkg.CreateTestDevice(t, ctx.db, fmt.Sprintf("uptime-%d", time.Now().UnixNano()))
+	defer testpkg.CleanupActivityFixtures(t, ctx.db, device.ID)
+
+	router := chi.NewRouter()
+	router.Get("/devices/{id}/uptime", ctx.resource.GetDeviceUptimeHandler())
+
+	req := testutil.NewAuthenticatedRequest(t, "GET", fmt.Sprintf("/devices/%d/uptime?days=7", device.ID), nil,
+		testutil.WithClaims(testutil.DefaultTestClaims()),
+		testutil.WithPermissions("iot:read"),
+	)
+
+	rr := testutil.ExecuteRequest(router, req)
+
+	testutil.AssertSuccessResponse(t, rr, http.StatusOK)
+
+	response := testutil.ParseJSONResponse(t, rr.Body.Bytes())
+	data, ok := response["data"].(map[string]interface{})
+	assert.True(t, ok, "Response should have data field")
+	assert.Equal(t, float64(100), data["uptime_percent"], "Device without history should be fully available")
+}
+
+func TestGetDeviceUptime_InvalidDays(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	router := chi.NewRouter()
+	router.Get("/devices/{id}/uptime", ctx.resource.GetDeviceUptimeHandler())
+
+	req := testutil.NewAuthenticatedRequest(t, "GET", "/devices/1/uptime?days=365", nil,
+		testutil.WithClaims(testutil.DefaultTestClaims()),
+		testutil.WithPermissions("iot:read"),
+	)
+
+	rr := testutil.ExecuteRequest(router, req)
+
+	testutil.AssertBadRequest(t, rr)
+}
+
 // =============================================================================
 // DETECT NEW DEVICES TESTS
 // =============================================================================
 
-func TestDetectNewDevices_NotImplemented(t *testing.T) {
+func TestDetectNewDevices_ListsPendingEnrollments(t *testing.T) {
 	ctx := setupTestContext(t)
 	defer func() { _ = ctx.db.Close() }()
 
@@ -757,10 +806,8 @@ func TestDetectNewDevices_NotImplemented(t *testing.T) {
 
 	rr := testutil.ExecuteRequest(router, req)
 
-	// NOTE: This endpoint is not implemented in the service layer yet.
-	// Returns "device auto-discovery not implemented" error.
-	testutil.AssertErrorResponse(t, rr, http.StatusInternalServerError)
-	assert.Contains(t, rr.Body.String(), "not implemented")
+	// Lists devices with a pending enrollment code (possibly none)
+	testutil.AssertSuccessResponse(t, rr, http.StatusOK)
 }
 
 // =============================================================================
@@ -786,3 +833,136 @@ func TestScanNetwork_NotImplemented(t *testing.T) {
 	testutil.AssertErrorResponse(t, rr, http.StatusInternalServerError)
 	assert.Contains(t, rr.Body.String(), "not implemented")
 }
+
+// =============================================================================
+// PROVISIONING TESTS
+// =============================================================================
+
+// adminClaimsFor returns admin claims bound to a real account, so audit rows can reference it
+func adminClaimsFor(t *testing.T, db *bun.DB) jwt.AppClaims {
+	t.Helper()
+	account := testpkg.CreateTestAccount(t, db, fmt.Sprintf("iot-admin-%d@example.com", time.Now().UnixNano()))
+	t.Cleanup(func() { testpkg.CleanupAccount(t, db, account.ID) })
+
+	claims := testutil.DefaultTestClaims()
+	claims.ID = int(account.ID)
+	return claims
+}
+
+// cleanupEnrolledDevice removes an enrolled device, its enrollment codes and key audit rows
+func cleanupEnrolledDevice(t *testing.T, db *bun.DB, deviceID string) {
+	t.Helper()
+	bg := context.Background()
+	_, _ = db.NewDelete().TableExpr("audit.device_key_events").Where("device_identifier = ?", deviceID).Exec(bg)
+	_, _ = db.NewDelete().TableExpr("iot.device_enrollments").Where("device_id = ?", deviceID).Exec(bg)
+	_, _ = db.NewDelete().TableExpr("iot.devices").Where("device_id = ?", deviceID).Exec(bg)
+}
+
+func TestEnrollmentFlow_CodeExchangedOnce(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	deviceID := fmt.Sprintf("enroll-api-%d", time.Now().UnixNano())
+	defer cleanupEnrolledDevice(t, ctx.db, deviceID)
+
+	router := chi.NewRouter()
+	router.Post("/devices/enrollments", ctx.resource.CreateEnrollmentCodeHandler())
+	router.Get("/devices/enrollments", ctx.resource.ListPendingEnrollmentsHandler())
+	router.Post("/enroll", ctx.resource.EnrollDeviceHandler())
+
+	claims := adminClaimsFor(t, ctx.db)
+	req := testutil.NewAuthenticatedRequest(t, "POST", "/devices/enrollments",
+		map[string]interface{}{"device_id": deviceID, "device_type": "rfid_reader", "ttl_minutes": 30},
+		testutil.WithClaims(claims),
+		testutil.WithPermissions("iot:manage"),
+	)
+	rr := testutil.ExecuteRequest(router, req)
+	testutil.AssertSuccessResponse(t, rr, http.StatusCreated)
+
+	data := testutil.ParseResponse(t, rr.Body.Bytes()).Data.(map[string]interface{})
+	code, _ := data["code"].(string)
+	require.NotEmpty(t, code)
+
+	// The pending list never exposes the code
+	req = testutil.NewAuthenticatedRequest(t, "GET", "/devices/enrollments", nil,
+		testutil.WithClaims(claims),
+		testutil.WithPermissions("iot:manage"),
+	)
+	rr = testutil.ExecuteRequest(router, req)
+	testutil.AssertSuccessResponse(t, rr, http.StatusOK)
+	assert.NotContains(t, rr.Body.String(), code)
+
+	// Exchange without any authentication
+	rr = testutil.ExecuteRequest(router, testutil.NewJSONRequest(t, "POST", "/enroll", map[string]string{"code": code}))
+	testutil.AssertSuccessResponse(t, rr, http.StatusCreated)
+	enrolled := testutil.ParseResponse(t, rr.Body.Bytes()).Data.(map[string]interface{})
+	assert.Equal(t, deviceID, enrolled["device_id"])
+	assert.NotEmpty(t, enrolled["api_key"])
+
+	rr = testutil.ExecuteRequest(router, testutil.NewJSONRequest(t, "POST", "/enroll", map[string]string{"code": code}))
+	testutil.AssertErrorResponse(t, rr, http.StatusUnauthorized)
+}
+
+func TestEnrollDevice_MissingCode(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	router := chi.NewRouter()
+	router.Post("/enroll", ctx.resource.EnrollDeviceHandler())
+
+	rr := testutil.ExecuteRequest(router, testutil.NewJSONRequest(t, "POST", "/enroll", map[string]string{}))
+
+	testutil.AssertBadRequest(t, rr)
+}
+
+func TestRotateAndRevokeDeviceKey(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	device := testpkg.CreateTestDevice(t, ctx.db, "rotate-api")
+	defer cleanupEnrolledDevice(t, ctx.db, device.DeviceID)
+
+	router := chi.NewRouter()
+	router.Post("/devices/{id}/rotate-key", ctx.resource.RotateDeviceKeyHandler())
+	router.Post("/devices/{id}/revoke-key", ctx.resource.RevokeDeviceKeyHandler())
+
+	claims := adminClaimsFor(t, ctx.db)
+	req := testutil.NewAuthenticatedRequest(t, "POST", fmt.Sprintf("/devices/%d/rotate-key", device.ID),
+		map[string]int{"grace_hours": 2},
+		testutil.WithClaims(claims),
+		testutil.WithPermissions("iot:manage"),
+	)
+	rr := testutil.ExecuteRequest(router, req)
+	testutil.AssertSuccessResponse(t, rr, http.StatusOK)
+
+	rotated := testutil.ParseResponse(t, rr.Body.Bytes()).Data.(map[string]interface{})
+	assert.NotEqual(t, *device.APIKey, rotated["api_key"])
+	assert.NotNil(t, rotated["previous_key_expires_at"])
+
+	req = testutil.NewAuthenticatedRequest(t, "POST", fmt.Sprintf("/devices/%d/revoke-key", device.ID), nil,
+		testutil.WithClaims(claims),
+		testutil.WithPermissions("iot:manage"),
+	)
+	rr = testutil.ExecuteRequest(router, req)
+	testutil.AssertSuccessResponse(t, rr, http.StatusOK)
+
+	_, err := ctx.services.IoT.GetDeviceByAPIKey(context.Background(), *device.APIKey)
+	assert.Error(t, err, "revoked key must not authenticate")
+}
+
+func TestRotateDeviceKey_GraceTooLong(t *testing.T) {
+	ctx := setupTestContext(t)
+	defer func() { _ = ctx.db.Close() }()
+
+	router := chi.NewRouter()
+	router.Post("/devices/{id}/rotate-key", ctx.resource.RotateDeviceKeyHandler())
+
+	req := testutil.NewAuthenticatedRequest(t, "POST", "/devices/123/rotate-key",
+		map[string]int{"grace_hours": 1000},
+		testutil.WithClaims(testutil.DefaultTestClaims()),
+		testutil.WithPermissions("iot:manage"),
+	)
+	rr := testutil.ExecuteRequest(router, req)
+
+	testutil.AssertBadRequest(t, rr)
+}
@@ -409,6 +409,34 @@ func (rs *Resource) getDeviceStatistics(w http.ResponseWriter, r *http.Request)
 	common.Respond(w, r, http.StatusOK, response, "Device statistics retrieved successfully")
 }
 
+// getDeviceUptime handles getting a device's uptime and status history
+func (rs *Resource) getDeviceUptime(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(errors.New(errMsgInvalidDeviceID)))
+		return
+	}
+
+	// Get window in days (default 7, at most 90)
+	days := 7
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > 90 {
+			iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(errors.New("days must be between 1 and 90")))
+			return
+		}
+		days = parsed
+	}
+
+	uptime, err := rs.IoTService.GetDeviceUptime(r.Context(), id, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newDeviceUptimeResponse(uptime), "Device uptime retrieved successfully")
+}
+
 // detectNewDevices handles detecting new devices on the network
 func (rs *Resource) detectNewDevices(w http.ResponseWriter, r *http.Request) {
 	// Detect new devices
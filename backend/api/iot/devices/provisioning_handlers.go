@@ -0,0 +1,122 @@
+package devices
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	iotCommon "github.com/moto-nrw/project-phoenix/api/iot/common"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/middleware"
+	iotSvc "github.com/moto-nrw/project-phoenix/services/iot"
+)
+
+// keyActor identifies the authenticated admin and client IP for the key audit log
+func keyActor(r *http.Request) iotSvc.KeyActor {
+	return iotSvc.KeyActor{
+		AccountID: int64(jwt.ClaimsFromCtx(r.Context()).ID),
+		IPAddress: middleware.GetClientIP(r),
+	}
+}
+
+// createEnrollmentCode handles issuing a one-time enrollment code for a new device
+func (rs *Resource) createEnrollmentCode(w http.ResponseWriter, r *http.Request) {
+	req := &EnrollmentCodeRequest{}
+	if err := render.Bind(r, req); err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(err))
+		return
+	}
+
+	issued, err := rs.IoTService.CreateEnrollmentCode(r.Context(), iotSvc.EnrollmentCodeRequest{
+		DeviceID:   req.DeviceID,
+		DeviceType: req.DeviceType,
+		Name:       req.Name,
+		TTL:        time.Duration(req.TTLMinutes) * time.Minute,
+	}, keyActor(r))
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+		return
+	}
+
+	response := newEnrollmentResponse(issued.Enrollment)
+	response.Code = issued.Code
+
+	common.Respond(w, r, http.StatusCreated, response, "Enrollment code created successfully")
+}
+
+// listPendingEnrollments handles listing enrollment codes that have not been used yet
+func (rs *Resource) listPendingEnrollments(w http.ResponseWriter, r *http.Request) {
+	enrollments, err := rs.IoTService.ListPendingEnrollments(r.Context())
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+		return
+	}
+
+	responses := make([]EnrollmentResponse, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		responses = append(responses, newEnrollmentResponse(enrollment))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Pending enrollments retrieved successfully")
+}
+
+// enrollDevice handles a device exchanging its enrollment code for an API key (unauthenticated)
+func (rs *Resource) enrollDevice(w http.ResponseWriter, r *http.Request) {
+	req := &EnrollRequest{}
+	if err := render.Bind(r, req); err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(err))
+		return
+	}
+
+	creds, err := rs.IoTService.EnrollDevice(r.Context(), req.Code, middleware.GetClientIP(r))
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, newDeviceKeyResponse(creds), "Device enrolled successfully")
+}
+
+// rotateDeviceKey handles issuing a new API key; the old key stays valid for the grace period
+func (rs *Resource) rotateDeviceKey(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(errors.New(errMsgInvalidDeviceID)))
+		return
+	}
+
+	// The body is optional; an empty body uses the default grace period
+	req := &RotateKeyRequest{}
+	if r.ContentLength > 0 {
+		if err := render.Bind(r, req); err != nil {
+			iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(err))
+			return
+		}
+	}
+
+	creds, err := rs.IoTService.RotateAPIKey(r.Context(), id, time.Duration(req.GraceHours)*time.Hour, keyActor(r))
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newDeviceKeyResponse(creds), "API key rotated successfully")
+}
+
+// revokeDeviceKey handles revoking all API keys of a device
+func (rs *Resource) revokeDeviceKey(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorInvalidRequest(errors.New(errMsgInvalidDeviceID)))
+		return
+	}
+
+	if err := rs.IoTService.RevokeAPIKey(r.Context(), id, keyActor(r)); err != nil {
+		iotCommon.RenderError(w, r, iotCommon.ErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, nil, "API key revoked successfully")
+}
@@ -0,0 +1,94 @@
+package devices
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	iotSvc "github.com/moto-nrw/project-phoenix/services/iot"
+)
+
+// EnrollmentCodeRequest represents a request for a new enrollment code
+type EnrollmentCodeRequest struct {
+	DeviceID   string  `json:"device_id"`
+	DeviceType string  `json:"device_type"`
+	Name       *string `json:"name,omitempty"`
+	TTLMinutes int     `json:"ttl_minutes,omitempty"` // default 15, at most 1440
+}
+
+// Bind validates the enrollment code request
+func (req *EnrollmentCodeRequest) Bind(_ *http.Request) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.DeviceID, validation.Required),
+		validation.Field(&req.DeviceType, validation.Required),
+		validation.Field(&req.TTLMinutes, validation.Min(0), validation.Max(int(iotSvc.MaxEnrollmentCodeTTL.Minutes()))),
+	)
+}
+
+// EnrollmentResponse represents a pending or freshly issued enrollment code
+type EnrollmentResponse struct {
+	ID         int64       `json:"id"`
+	DeviceID   string      `json:"device_id"`
+	DeviceType string      `json:"device_type"`
+	Name       *string     `json:"name,omitempty"`
+	ExpiresAt  common.Time `json:"expires_at"`
+	CreatedAt  common.Time `json:"created_at"`
+	Code       string      `json:"code,omitempty"` // Only included when the code is issued
+}
+
+// EnrollRequest represents a device exchanging its enrollment code
+type EnrollRequest struct {
+	Code string `json:"code"`
+}
+
+// Bind validates the enroll request
+func (req *EnrollRequest) Bind(_ *http.Request) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.Code, validation.Required),
+	)
+}
+
+// RotateKeyRequest represents an API key rotation request
+type RotateKeyRequest struct {
+	GraceHours int `json:"grace_hours,omitempty"` // default 24, at most 168
+}
+
+// Bind validates the rotate key request
+func (req *RotateKeyRequest) Bind(_ *http.Request) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.GraceHours, validation.Min(0), validation.Max(int(iotSvc.MaxKeyRotationGrace.Hours()))),
+	)
+}
+
+// DeviceKeyResponse represents a newly issued API key
+type DeviceKeyResponse struct {
+	DeviceResponse
+	APIKey               string       `json:"api_key"`
+	PreviousKeyExpiresAt *common.Time `json:"previous_key_expires_at,omitempty"`
+}
+
+// newEnrollmentResponse converts an enrollment model to a response object
+func newEnrollmentResponse(enrollment *iot.DeviceEnrollment) EnrollmentResponse {
+	return EnrollmentResponse{
+		ID:         enrollment.ID,
+		DeviceID:   enrollment.DeviceID,
+		DeviceType: enrollment.DeviceType,
+		Name:       enrollment.Name,
+		ExpiresAt:  common.Time(enrollment.ExpiresAt),
+		CreatedAt:  common.Time(enrollment.CreatedAt),
+	}
+}
+
+// newDeviceKeyResponse converts issued credentials to a response object
+func newDeviceKeyResponse(creds *iotSvc.DeviceCredentials) DeviceKeyResponse {
+	response := DeviceKeyResponse{
+		DeviceResponse: newDeviceResponse(creds.Device),
+		APIKey:         creds.APIKey,
+	}
+	if creds.PreviousKeyExpiresAt != nil {
+		expiresAt := common.Time(*creds.PreviousKeyExpiresAt)
+		response.PreviousKeyExpiresAt = &expiresAt
+	}
+	return response
+}
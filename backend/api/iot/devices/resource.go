@@ -40,6 +40,7 @@ func (rs *Resource) Router() chi.Router {
 	r.With(authorize.RequiresPermission(permissions.IOTRead)).Get("/maintenance", rs.getDevicesRequiringMaintenance)
 	r.With(authorize.RequiresPermission(permissions.IOTRead)).Get("/offline", rs.getOfflineDevices)
 	r.With(authorize.RequiresPermission(permissions.IOTRead)).Get("/statistics", rs.getDeviceStatistics)
+	r.With(authorize.RequiresPermission(permissions.IOTRead)).Get("/{id}/uptime", rs.getDeviceUptime)
 
 	// Write operations require iot:update or iot:manage permission
 	r.With(authorize.RequiresPermission(permissions.IOTManage)).Post("/", rs.createDevice)
@@ -48,6 +49,12 @@ func (rs *Resource) Router() chi.Router {
 	r.With(authorize.RequiresPermission(permissions.IOTUpdate)).Patch("/{deviceId}/status", rs.updateDeviceStatus)
 	r.With(authorize.RequiresPermission(permissions.IOTUpdate)).Post("/{deviceId}/ping", rs.pingDevice)
 
+	// Provisioning requires iot:manage permission
+	r.With(authorize.RequiresPermission(permissions.IOTManage)).Post("/enrollments", rs.createEnrollmentCode)
+	r.With(authorize.RequiresPermission(permissions.IOTManage)).Get("/enrollments", rs.listPendingEnrollments)
+	r.With(authorize.RequiresPermission(permissions.IOTManage)).Post("/{id}/rotate-key", rs.rotateDeviceKey)
+	r.With(authorize.RequiresPermission(permissions.IOTManage)).Post("/{id}/revoke-key", rs.revokeDeviceKey)
+
 	// Network operations require iot:manage permission
 	r.With(authorize.RequiresPermission(permissions.IOTManage)).Post("/detect-new", rs.detectNewDevices)
 	r.With(authorize.RequiresPermission(permissions.IOTManage)).Post("/scan-network", rs.scanNetwork)
@@ -108,6 +115,27 @@ func (rs *Resource) GetOfflineDevicesHandler() http.HandlerFunc { return rs.getO
 // GetDeviceStatisticsHandler returns the getDeviceStatistics handler for testing.
 func (rs *Resource) GetDeviceStatisticsHandler() http.HandlerFunc { return rs.getDeviceStatistics }
 
+// GetDeviceUptimeHandler returns the getDeviceUptime handler for testing.
+func (rs *Resource) GetDeviceUptimeHandler() http.HandlerFunc { return rs.getDeviceUptime }
+
+// CreateEnrollmentCodeHandler returns the createEnrollmentCode handler for testing.
+func (rs *Resource) CreateEnrollmentCodeHandler() http.HandlerFunc { return rs.createEnrollmentCode }
+
+// ListPendingEnrollmentsHandler returns the listPendingEnrollments handler for testing.
+func (rs *Resource) ListPendingEnrollmentsHandler() http.HandlerFunc {
+	return rs.listPendingEnrollments
+}
+
+// EnrollDeviceHandler returns the unauthenticated enrollDevice handler.
+// It is mounted by the parent IoT router outside the JWT-protected group.
+func (rs *Resource) EnrollDeviceHandler() http.HandlerFunc { return rs.enrollDevice }
+
+// RotateDeviceKeyHandler returns the rotateDeviceKey handler for testing.
+func (rs *Resource) RotateDeviceKeyHandler() http.HandlerFunc { return rs.rotateDeviceKey }
+
+// RevokeDeviceKeyHandler returns the revokeDeviceKey handler for testing.
+func (rs *Resource) RevokeDeviceKeyHandler() http.HandlerFunc { return rs.revokeDeviceKey }
+
 // DetectNewDevicesHandler returns the detectNewDevices handler for testing.
 func (rs *Resource) DetectNewDevicesHandler() http.HandlerFunc { return rs.detectNewDevices }
 
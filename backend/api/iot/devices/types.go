@@ -2,12 +2,14 @@ package devices
 
 import (
 	"errors"
+	"math"
 	"net/http"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/moto-nrw/project-phoenix/api/common"
 	"github.com/moto-nrw/project-phoenix/models/iot"
+	iotSvc "github.com/moto-nrw/project-phoenix/services/iot"
 )
 
 // DeviceResponse represents a device API response
@@ -84,6 +86,24 @@ type DeviceStatisticsResponse struct {
 	LastUpdated     time.Time      `json:"last_updated"`
 }
 
+// DeviceEventResponse represents a device status transition
+type DeviceEventResponse struct {
+	FromStatus string       `json:"from_status"`
+	ToStatus   string       `json:"to_status"`
+	Reason     string       `json:"reason"`
+	LastSeen   *common.Time `json:"last_seen,omitempty"`
+	OccurredAt common.Time  `json:"occurred_at"`
+}
+
+// DeviceUptimeResponse represents a device's availability over a time window
+type DeviceUptimeResponse struct {
+	DeviceID      int64                 `json:"device_id"`
+	From          common.Time           `json:"from"`
+	To            common.Time           `json:"to"`
+	UptimePercent float64               `json:"uptime_percent"`
+	Events        []DeviceEventResponse `json:"events"`
+}
+
 // NetworkScanResponse represents network scan results
 type NetworkScanResponse struct {
 	Devices      map[string]string `json:"devices"`
@@ -122,6 +142,33 @@ func newDeviceResponses(devices []*iot.Device) []DeviceResponse {
 	return responses
 }
 
+// newDeviceUptimeResponse converts a device uptime result to a response object
+func newDeviceUptimeResponse(uptime *iotSvc.DeviceUptime) DeviceUptimeResponse {
+	response := DeviceUptimeResponse{
+		DeviceID:      uptime.DeviceID,
+		From:          common.Time(uptime.From),
+		To:            common.Time(uptime.To),
+		UptimePercent: math.Round(uptime.UptimePercent*100) / 100,
+		Events:        make([]DeviceEventResponse, 0, len(uptime.Events)),
+	}
+
+	for _, event := range uptime.Events {
+		eventResponse := DeviceEventResponse{
+			FromStatus: string(event.FromStatus),
+			ToStatus:   string(event.ToStatus),
+			Reason:     event.Reason,
+			OccurredAt: common.Time(event.OccurredAt),
+		}
+		if event.LastSeen != nil {
+			lastSeen := common.Time(*event.LastSeen)
+			eventResponse.LastSeen = &lastSeen
+		}
+		response.Events = append(response.Events, eventResponse)
+	}
+
+	return response
+}
+
 // newDeviceCreationResponse converts a device model to a creation response object with API key
 func newDeviceCreationResponse(device *iot.Device) DeviceCreationResponse {
 	response := DeviceCreationResponse{
@@ -0,0 +1,78 @@
+package parent
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	parentSvc "github.com/moto-nrw/project-phoenix/services/parent"
+)
+
+// Resource defines the parent portal API resource
+type Resource struct {
+	service         parentSvc.Service
+	tokenAuth       *jwt.TokenAuth
+	authRateLimiter func(http.Handler) http.Handler
+}
+
+// ResourceConfig holds dependencies for the parent portal resource
+type ResourceConfig struct {
+	Service   parentSvc.Service
+	TokenAuth *jwt.TokenAuth
+}
+
+// NewResource creates a new parent portal resource
+func NewResource(cfg ResourceConfig) *Resource {
+	tokenAuth := cfg.TokenAuth
+	if tokenAuth == nil {
+		// Create internal token auth for JWT verification
+		tokenAuth, _ = jwt.NewTokenAuth()
+	}
+
+	return &Resource{
+		service:   cfg.Service,
+		tokenAuth: tokenAuth,
+	}
+}
+
+// SetAuthRateLimiter sets the rate limiter middleware for parent login.
+func (rs *Resource) SetAuthRateLimiter(mw func(http.Handler) http.Handler) {
+	rs.authRateLimiter = mw
+}
+
+// Router returns a configured router for parent portal endpoints
+func (rs *Resource) Router() chi.Router {
+	r := chi.NewRouter()
+	r.Use(render.SetContentType(render.ContentTypeJSON))
+
+	// Public routes (no auth required) — apply auth rate limiter for brute-force protection
+	r.Route("/auth", func(r chi.Router) {
+		if rs.authRateLimiter != nil {
+			r.Use(rs.authRateLimiter)
+		}
+		r.Post("/login", rs.Login)
+	})
+
+	// Protected routes (parent-scoped tokens only; access is checked per student link)
+	r.Group(func(r chi.Router) {
+		r.Use(rs.tokenAuth.Verifier())
+		r.Use(jwt.ParentAuthenticator)
+
+		r.Get("/children", rs.ListChildren)
+		r.Route("/children/{id}", func(r chi.Router) {
+			r.Get("/attendance", rs.GetAttendance)
+			r.Put("/sickness", rs.ReportSickness)
+
+			r.Get("/absences", rs.ListAbsences)
+			r.Post("/absences", rs.ReportAbsence)
+
+			r.Get("/pickup-exceptions", rs.ListPickupExceptions)
+			r.Post("/pickup-exceptions", rs.CreatePickupException)
+			r.Put("/pickup-exceptions/{exceptionId}", rs.UpdatePickupException)
+			r.Delete("/pickup-exceptions/{exceptionId}", rs.DeletePickupException)
+		})
+	})
+
+	return r
+}
@@ -0,0 +1,297 @@
+package parent_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moto-nrw/project-phoenix/api/parent"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	parentSvc "github.com/moto-nrw/project-phoenix/services/parent"
+)
+
+const (
+	testSecret           = "parent-api-test-secret-at-least-32-chars"
+	testAccountID  int64 = 10
+	linkedStudent  int64 = 30
+	foreignStudent int64 = 31
+)
+
+// mockPortalService links the test account to linkedStudent only
+type mockPortalService struct {
+	created *schedule.StudentPickupException
+	sick    *bool
+	absence *activeSvc.StudentAbsenceRequest
+}
+
+func (m *mockPortalService) ensureLinked(accountID, studentID int64) error {
+	if accountID != testAccountID || studentID != linkedStudent {
+		return &parentSvc.StudentNotLinkedError{StudentID: studentID}
+	}
+	return nil
+}
+
+func (m *mockPortalService) Login(_ context.Context, email, password string) (string, *authModels.AccountParent, error) {
+	if email != "eva@example.com" || password != "Secret123!" {
+		return "", nil, &parentSvc.InvalidCredentialsError{}
+	}
+	account := &authModels.AccountParent{Model: base.Model{ID: testAccountID}, Email: email}
+	return "access-token", account, nil
+}
+
+func (m *mockPortalService) GetChildren(_ context.Context, _ int64) ([]*parentSvc.Child, error) {
+	return []*parentSvc.Child{
+		{StudentID: linkedStudent, FirstName: "Mia", Attendance: &activeSvc.AttendanceStatus{Status: "checked_in", Date: time.Now()}},
+	}, nil
+}
+
+func (m *mockPortalService) GetChildAttendance(_ context.Context, accountID, studentID int64) (*activeSvc.AttendanceStatus, error) {
+	if err := m.ensureLinked(accountID, studentID); err != nil {
+		return nil, err
+	}
+	return &activeSvc.AttendanceStatus{StudentID: studentID, Status: "checked_out", Date: time.Now()}, nil
+}
+
+func (m *mockPortalService) GetPickupExceptions(_ context.Context, accountID, studentID int64) ([]*schedule.StudentPickupException, error) {
+	if err := m.ensureLinked(accountID, studentID); err != nil {
+		return nil, err
+	}
+	return []*schedule.StudentPickupException{}, nil
+}
+
+func (m *mockPortalService) CreatePickupException(_ context.Context, accountID int64, exception *schedule.StudentPickupException) error {
+	if err := m.ensureLinked(accountID, exception.StudentID); err != nil {
+		return err
+	}
+	m.created = exception
+	return nil
+}
+
+func (m *mockPortalService) UpdatePickupException(_ context.Context, accountID int64, exception *schedule.StudentPickupException) error {
+	return m.ensureLinked(accountID, exception.StudentID)
+}
+
+func (m *mockPortalService) DeletePickupException(_ context.Context, accountID, studentID, exceptionID int64) error {
+	if err := m.ensureLinked(accountID, studentID); err != nil {
+		return err
+	}
+	return &parentSvc.PickupExceptionNotFoundError{ExceptionID: exceptionID}
+}
+
+func (m *mockPortalService) ReportSickness(_ context.Context, accountID, studentID int64, sick bool) (*users.Student, error) {
+	if err := m.ensureLinked(accountID, studentID); err != nil {
+		return nil, err
+	}
+	m.sick = &sick
+	return &users.Student{Model: base.Model{ID: studentID}, Sick: &sick}, nil
+}
+
+func (m *mockPortalService) GetAbsences(_ context.Context, accountID, studentID int64) ([]*active.StudentAbsence, error) {
+	if err := m.ensureLinked(accountID, studentID); err != nil {
+		return nil, err
+	}
+	return []*active.StudentAbsence{{StudentID: studentID, AbsenceType: active.StudentAbsenceTypeSick}}, nil
+}
+
+func (m *mockPortalService) ReportAbsence(_ context.Context, accountID, studentID int64, req activeSvc.StudentAbsenceRequest) (*active.StudentAbsence, error) {
+	if err := m.ensureLinked(accountID, studentID); err != nil {
+		return nil, err
+	}
+	if req.AbsenceType == active.StudentAbsenceTypeTrip {
+		return nil, &parentSvc.InvalidDataError{Err: errors.New("absence_type must be one of: sick, excused, vacation")}
+	}
+	m.absence = &req
+	return &active.StudentAbsence{StudentID: studentID, AbsenceType: req.AbsenceType}, nil
+}
+
+func newTestRouter(t *testing.T, service parentSvc.Service) (http.Handler, *jwt.TokenAuth) {
+	t.Helper()
+	viper.Set("auth_jwt_expiry", 15*time.Minute)
+	viper.Set("auth_jwt_refresh_expiry", 24*time.Hour)
+
+	tokenAuth, err := jwt.NewTokenAuthWithSecret(testSecret)
+	require.NoError(t, err)
+
+	rs := parent.NewResource(parent.ResourceConfig{Service: service, TokenAuth: tokenAuth})
+	return rs.Router(), tokenAuth
+}
+
+func tokenWithScope(t *testing.T, tokenAuth *jwt.TokenAuth, scope string) string {
+	t.Helper()
+	token, err := tokenAuth.CreateJWT(jwt.AppClaims{
+		ID:          int(testAccountID),
+		Sub:         "parent:10",
+		Roles:       []string{"guardian"},
+		Permissions: []string{},
+		Scope:       scope,
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func doRequest(router http.Handler, method, path, token string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestLogin(t *testing.T) {
+	router, _ := newTestRouter(t, &mockPortalService{})
+
+	rr := doRequest(router, http.MethodPost, "/auth/login", "", map[string]string{"email": "eva@example.com", "password": "Secret123!"})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]any)
+	assert.Equal(t, "access-token", data["access_token"])
+	assert.NotContains(t, data, "refresh_token")
+
+	rr = doRequest(router, http.MethodPost, "/auth/login", "", map[string]string{"email": "eva@example.com", "password": "wrong"})
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = doRequest(router, http.MethodPost, "/auth/login", "", map[string]string{"email": "eva@example.com"})
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestProtectedRoutes_RequireParentScope(t *testing.T) {
+	router, tokenAuth := newTestRouter(t, &mockPortalService{})
+
+	rr := doRequest(router, http.MethodGet, "/children", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// Staff and operator tokens must not reach the parent portal
+	for _, scope := range []string{"", jwt.ScopeTenant, jwt.ScopePlatform} {
+		rr = doRequest(router, http.MethodGet, "/children", tokenWithScope(t, tokenAuth, scope), nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, "scope %q", scope)
+	}
+
+	rr = doRequest(router, http.MethodGet, "/children", tokenWithScope(t, tokenAuth, jwt.ScopeParent), nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetAttendance(t *testing.T) {
+	router, tokenAuth := newTestRouter(t, &mockPortalService{})
+	token := tokenWithScope(t, tokenAuth, jwt.ScopeParent)
+
+	rr := doRequest(router, http.MethodGet, "/children/30/attendance", token, nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "checked_out")
+
+	// Unlinked students look like missing ones
+	rr = doRequest(router, http.MethodGet, "/children/31/attendance", token, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = doRequest(router, http.MethodGet, "/children/abc/attendance", token, nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestCreatePickupException(t *testing.T) {
+	service := &mockPortalService{}
+	router, tokenAuth := newTestRouter(t, service)
+	token := tokenWithScope(t, tokenAuth, jwt.ScopeParent)
+
+	pickupTime := "14:30"
+	body := map[string]any{"exception_date": "2030-05-06", "pickup_time": pickupTime, "reason": "Arzttermin"}
+	rr := doRequest(router, http.MethodPost, "/children/30/pickup-exceptions", token, body)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.NotNil(t, service.created)
+	assert.Equal(t, linkedStudent, service.created.StudentID)
+	assert.Equal(t, pickupTime, service.created.PickupTime.Format("15:04"))
+
+	rr = doRequest(router, http.MethodPost, "/children/30/pickup-exceptions", token, map[string]any{"exception_date": "06.05.2030"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = doRequest(router, http.MethodPost, "/children/31/pickup-exceptions", token, body)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestDeletePickupException_NotFound(t *testing.T) {
+	router, tokenAuth := newTestRouter(t, &mockPortalService{})
+	token := tokenWithScope(t, tokenAuth, jwt.ScopeParent)
+
+	rr := doRequest(router, http.MethodDelete, "/children/30/pickup-exceptions/99", token, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestReportSickness(t *testing.T) {
+	service := &mockPortalService{}
+	router, tokenAuth := newTestRouter(t, service)
+	token := tokenWithScope(t, tokenAuth, jwt.ScopeParent)
+
+	rr := doRequest(router, http.MethodPut, "/children/30/sickness", token, map[string]any{"sick": true})
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NotNil(t, service.sick)
+	assert.True(t, *service.sick)
+
+	rr = doRequest(router, http.MethodPut, "/children/30/sickness", token, map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = doRequest(router, http.MethodPut, "/children/31/sickness", token, map[string]any{"sick": true})
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestListAbsences(t *testing.T) {
+	router, tokenAuth := newTestRouter(t, &mockPortalService{})
+	token := tokenWithScope(t, tokenAuth, jwt.ScopeParent)
+
+	rr := doRequest(router, http.MethodGet, "/children/30/absences", token, nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"absence_type":"sick"`)
+
+	rr = doRequest(router, http.MethodGet, "/children/31/absences", token, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestReportAbsence(t *testing.T) {
+	service := &mockPortalService{}
+	router, tokenAuth := newTestRouter(t, service)
+	token := tokenWithScope(t, tokenAuth, jwt.ScopeParent)
+
+	rr := doRequest(router, http.MethodPost, "/children/30/absences", token, map[string]any{
+		"absence_type": "sick", "date_start": "2026-03-02", "guardian_note": "Fieber",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	require.NotNil(t, service.absence)
+	assert.Equal(t, "2026-03-02", service.absence.DateEnd, "date_end defaults to date_start")
+	assert.Equal(t, "Fieber", service.absence.GuardianNote)
+
+	rr = doRequest(router, http.MethodPost, "/children/30/absences", token, map[string]any{
+		"absence_type": "sick", "date_start": "02.03.2026",
+	})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = doRequest(router, http.MethodPost, "/children/30/absences", token, map[string]any{
+		"absence_type": "trip", "date_start": "2026-03-02",
+	})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = doRequest(router, http.MethodPost, "/children/31/absences", token, map[string]any{
+		"absence_type": "sick", "date_start": "2026-03-02",
+	})
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,61 @@
+package parent
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+)
+
+// LoginRequest represents the parent login request body
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Bind validates the login request
+func (req *LoginRequest) Bind(r *http.Request) error {
+	return nil
+}
+
+// LoginResponse represents the parent login response
+type LoginResponse struct {
+	AccessToken string          `json:"access_token"`
+	Account     AccountResponse `json:"account"`
+}
+
+// AccountResponse represents a parent account in the response
+type AccountResponse struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+// Login handles parent login
+func (rs *Resource) Login(w http.ResponseWriter, r *http.Request) {
+	req := &LoginRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	if req.Email == "" || req.Password == "" {
+		common.RenderError(w, r, ErrInvalidCredentials())
+		return
+	}
+
+	accessToken, account, err := rs.service.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		common.RenderError(w, r, AuthErrorRenderer(err))
+		return
+	}
+
+	response := &LoginResponse{
+		AccessToken: accessToken,
+		Account: AccountResponse{
+			ID:    account.ID,
+			Email: account.Email,
+		},
+	}
+
+	common.Respond(w, r, http.StatusOK, response, "Login successful")
+}
@@ -0,0 +1,401 @@
+package parent
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+const dateFormatISO = "2006-01-02"
+
+// ChildResponse represents a linked child in the parent portal
+type ChildResponse struct {
+	StudentID        int64               `json:"student_id"`
+	FirstName        string              `json:"first_name"`
+	LastName         string              `json:"last_name"`
+	SchoolClass      string              `json:"school_class"`
+	RelationshipType string              `json:"relationship_type"`
+	Sick             bool                `json:"sick"`
+	SickSince        *time.Time          `json:"sick_since,omitempty"`
+	Attendance       *AttendanceResponse `json:"attendance,omitempty"`
+}
+
+// AttendanceResponse represents today's attendance of a child
+type AttendanceResponse struct {
+	Status       string     `json:"status"` // "not_checked_in", "checked_in", "checked_out"
+	Date         string     `json:"date"`
+	CheckInTime  *time.Time `json:"check_in_time,omitempty"`
+	CheckOutTime *time.Time `json:"check_out_time,omitempty"`
+}
+
+// PickupExceptionResponse represents a pickup exception in the parent portal
+type PickupExceptionResponse struct {
+	ID                  int64   `json:"id"`
+	StudentID           int64   `json:"student_id"`
+	ExceptionDate       string  `json:"exception_date"`        // YYYY-MM-DD format
+	PickupTime          *string `json:"pickup_time,omitempty"` // HH:MM format, nil = no pickup
+	Reason              *string `json:"reason,omitempty"`
+	CreatedByGuardianID *int64  `json:"created_by_guardian_id,omitempty"`
+}
+
+// SicknessResponse represents a child's sickness status
+type SicknessResponse struct {
+	StudentID int64      `json:"student_id"`
+	Sick      bool       `json:"sick"`
+	SickSince *time.Time `json:"sick_since,omitempty"`
+}
+
+// AbsenceResponse represents a reported absence of a child
+type AbsenceResponse struct {
+	ID                   int64  `json:"id"`
+	StudentID            int64  `json:"student_id"`
+	AbsenceType          string `json:"absence_type"`
+	DateStart            string `json:"date_start"` // YYYY-MM-DD format
+	DateEnd              string `json:"date_end"`   // YYYY-MM-DD format
+	GuardianNote         string `json:"guardian_note,omitempty"`
+	ReportedByGuardianID *int64 `json:"reported_by_guardian_id,omitempty"`
+}
+
+// PickupExceptionRequest represents a request to create/update a pickup exception
+type PickupExceptionRequest struct {
+	ExceptionDate string  `json:"exception_date"` // YYYY-MM-DD format
+	PickupTime    *string `json:"pickup_time,omitempty"`
+	Reason        *string `json:"reason,omitempty"`
+}
+
+// Bind implements render.Binder
+func (req *PickupExceptionRequest) Bind(_ *http.Request) error {
+	if req.ExceptionDate == "" {
+		return errors.New("exception_date is required")
+	}
+	if _, err := time.Parse(dateFormatISO, req.ExceptionDate); err != nil {
+		return errors.New("invalid exception_date format, expected YYYY-MM-DD")
+	}
+	if req.PickupTime != nil && *req.PickupTime != "" {
+		if _, err := time.Parse("15:04", *req.PickupTime); err != nil {
+			return errors.New("invalid pickup_time format, expected HH:MM")
+		}
+	}
+	return nil
+}
+
+// SicknessRequest represents a sickness report
+type SicknessRequest struct {
+	Sick *bool `json:"sick"`
+}
+
+// Bind implements render.Binder
+func (req *SicknessRequest) Bind(_ *http.Request) error {
+	if req.Sick == nil {
+		return errors.New("sick is required")
+	}
+	return nil
+}
+
+// AbsenceRequest represents a guardian's absence report
+type AbsenceRequest struct {
+	AbsenceType  string `json:"absence_type"` // sick, excused, vacation
+	DateStart    string `json:"date_start"`   // YYYY-MM-DD format
+	DateEnd      string `json:"date_end"`     // YYYY-MM-DD format, defaults to date_start
+	GuardianNote string `json:"guardian_note,omitempty"`
+}
+
+// Bind implements render.Binder
+func (req *AbsenceRequest) Bind(_ *http.Request) error {
+	if req.AbsenceType == "" {
+		return errors.New("absence_type is required")
+	}
+	if _, err := time.Parse(dateFormatISO, req.DateStart); err != nil {
+		return errors.New("invalid date_start format, expected YYYY-MM-DD")
+	}
+	if req.DateEnd == "" {
+		req.DateEnd = req.DateStart
+	}
+	if len(req.GuardianNote) > 500 {
+		return errors.New("guardian_note cannot exceed 500 characters")
+	}
+	return nil
+}
+
+// accountIDFromCtx returns the parent account ID from the verified token
+func accountIDFromCtx(r *http.Request) int64 {
+	return int64(jwt.ClaimsFromCtx(r.Context()).ID)
+}
+
+// parseIDParam parses a numeric URL parameter, rendering a 400 on failure
+func parseIDParam(w http.ResponseWriter, r *http.Request, param string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+	if err != nil || id <= 0 {
+		common.RenderError(w, r, ErrInvalidRequest(errors.New("invalid "+param)))
+		return 0, false
+	}
+	return id, true
+}
+
+func newAttendanceResponse(status *activeSvc.AttendanceStatus) *AttendanceResponse {
+	if status == nil {
+		return nil
+	}
+	return &AttendanceResponse{
+		Status:       status.Status,
+		Date:         status.Date.Format(dateFormatISO),
+		CheckInTime:  status.CheckInTime,
+		CheckOutTime: status.CheckOutTime,
+	}
+}
+
+func newPickupExceptionResponse(e *schedule.StudentPickupException) PickupExceptionResponse {
+	resp := PickupExceptionResponse{
+		ID:                  e.ID,
+		StudentID:           e.StudentID,
+		ExceptionDate:       e.ExceptionDate.Format(dateFormatISO),
+		Reason:              e.Reason,
+		CreatedByGuardianID: e.CreatedByGuardianID,
+	}
+	if e.PickupTime != nil {
+		pickupTime := e.PickupTime.Format("15:04")
+		resp.PickupTime = &pickupTime
+	}
+	return resp
+}
+
+func newAbsenceResponse(a *active.StudentAbsence) AbsenceResponse {
+	return AbsenceResponse{
+		ID:                   a.ID,
+		StudentID:            a.StudentID,
+		AbsenceType:          a.AbsenceType,
+		DateStart:            a.DateStart.Format(dateFormatISO),
+		DateEnd:              a.DateEnd.Format(dateFormatISO),
+		GuardianNote:         a.GuardianNote,
+		ReportedByGuardianID: a.ReportedByGuardianID,
+	}
+}
+
+// newExceptionFromRequest builds an exception model from a validated request
+func newExceptionFromRequest(req *PickupExceptionRequest, studentID int64) *schedule.StudentPickupException {
+	exceptionDate, _ := time.Parse(dateFormatISO, req.ExceptionDate)
+	exception := &schedule.StudentPickupException{
+		StudentID:     studentID,
+		ExceptionDate: exceptionDate,
+		Reason:        req.Reason,
+	}
+	if req.PickupTime != nil && *req.PickupTime != "" {
+		pickupTime, _ := time.Parse("2006-01-02 15:04", "2000-01-01 "+*req.PickupTime)
+		exception.PickupTime = &pickupTime
+	}
+	return exception
+}
+
+// ListChildren handles GET /children
+func (rs *Resource) ListChildren(w http.ResponseWriter, r *http.Request) {
+	children, err := rs.service.GetChildren(r.Context(), accountIDFromCtx(r))
+	if err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	responses := make([]ChildResponse, 0, len(children))
+	for _, c := range children {
+		responses = append(responses, ChildResponse{
+			StudentID:        c.StudentID,
+			FirstName:        c.FirstName,
+			LastName:         c.LastName,
+			SchoolClass:      c.SchoolClass,
+			RelationshipType: c.RelationshipType,
+			Sick:             c.Sick,
+			SickSince:        c.SickSince,
+			Attendance:       newAttendanceResponse(c.Attendance),
+		})
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Children retrieved successfully")
+}
+
+// GetAttendance handles GET /children/{id}/attendance
+func (rs *Resource) GetAttendance(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	status, err := rs.service.GetChildAttendance(r.Context(), accountIDFromCtx(r), studentID)
+	if err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newAttendanceResponse(status), "Attendance retrieved successfully")
+}
+
+// ReportSickness handles PUT /children/{id}/sickness
+func (rs *Resource) ReportSickness(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &SicknessRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	student, err := rs.service.ReportSickness(r.Context(), accountIDFromCtx(r), studentID, *req.Sick)
+	if err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	response := SicknessResponse{
+		StudentID: student.ID,
+		Sick:      student.Sick != nil && *student.Sick,
+		SickSince: student.SickSince,
+	}
+	common.Respond(w, r, http.StatusOK, response, "Sickness status updated successfully")
+}
+
+// ListAbsences handles GET /children/{id}/absences
+func (rs *Resource) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	absences, err := rs.service.GetAbsences(r.Context(), accountIDFromCtx(r), studentID)
+	if err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	responses := make([]AbsenceResponse, 0, len(absences))
+	for _, a := range absences {
+		responses = append(responses, newAbsenceResponse(a))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Absences retrieved successfully")
+}
+
+// ReportAbsence handles POST /children/{id}/absences
+func (rs *Resource) ReportAbsence(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &AbsenceRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	absence, err := rs.service.ReportAbsence(r.Context(), accountIDFromCtx(r), studentID, activeSvc.StudentAbsenceRequest{
+		AbsenceType:  req.AbsenceType,
+		DateStart:    req.DateStart,
+		DateEnd:      req.DateEnd,
+		GuardianNote: req.GuardianNote,
+	})
+	if err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, newAbsenceResponse(absence), "Absence reported successfully")
+}
+
+// ListPickupExceptions handles GET /children/{id}/pickup-exceptions
+func (rs *Resource) ListPickupExceptions(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	exceptions, err := rs.service.GetPickupExceptions(r.Context(), accountIDFromCtx(r), studentID)
+	if err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	responses := make([]PickupExceptionResponse, 0, len(exceptions))
+	for _, e := range exceptions {
+		responses = append(responses, newPickupExceptionResponse(e))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Pickup exceptions retrieved successfully")
+}
+
+// CreatePickupException handles POST /children/{id}/pickup-exceptions
+func (rs *Resource) CreatePickupException(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+
+	req := &PickupExceptionRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	exception := newExceptionFromRequest(req, studentID)
+	if err := rs.service.CreatePickupException(r.Context(), accountIDFromCtx(r), exception); err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, newPickupExceptionResponse(exception), "Pickup exception created successfully")
+}
+
+// UpdatePickupException handles PUT /children/{id}/pickup-exceptions/{exceptionId}
+func (rs *Resource) UpdatePickupException(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	exceptionID, ok := parseIDParam(w, r, "exceptionId")
+	if !ok {
+		return
+	}
+
+	req := &PickupExceptionRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrInvalidRequest(err))
+		return
+	}
+
+	exception := newExceptionFromRequest(req, studentID)
+	exception.ID = exceptionID
+	if err := rs.service.UpdatePickupException(r.Context(), accountIDFromCtx(r), exception); err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newPickupExceptionResponse(exception), "Pickup exception updated successfully")
+}
+
+// DeletePickupException handles DELETE /children/{id}/pickup-exceptions/{exceptionId}
+func (rs *Resource) DeletePickupException(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := parseIDParam(w, r, "id")
+	if !ok {
+		return
+	}
+	exceptionID, ok := parseIDParam(w, r, "exceptionId")
+	if !ok {
+		return
+	}
+
+	if err := rs.service.DeletePickupException(r.Context(), accountIDFromCtx(r), studentID, exceptionID); err != nil {
+		common.RenderError(w, r, PortalErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, nil, "Pickup exception deleted successfully")
+}
@@ -0,0 +1,119 @@
+package parent
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	parentSvc "github.com/moto-nrw/project-phoenix/services/parent"
+)
+
+// ErrResponse is an error response struct
+type ErrResponse struct {
+	HTTPStatusCode int    `json:"-"`
+	StatusText     string `json:"status"`
+	ErrorText      string `json:"message,omitempty"`
+}
+
+// Render implements the render.Renderer interface
+func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, e.HTTPStatusCode)
+	return nil
+}
+
+// ErrInvalidRequest creates an error response for invalid requests
+func ErrInvalidRequest(err error) render.Renderer {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusBadRequest,
+		StatusText:     "error",
+		ErrorText:      err.Error(),
+	}
+}
+
+// ErrInvalidCredentials creates an error response for invalid credentials
+func ErrInvalidCredentials() render.Renderer {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusUnauthorized,
+		StatusText:     "error",
+		ErrorText:      "Invalid email or password",
+	}
+}
+
+// ErrNotFound creates a not found error response
+func ErrNotFound(message string) render.Renderer {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusNotFound,
+		StatusText:     "error",
+		ErrorText:      message,
+	}
+}
+
+// ErrForbidden creates a forbidden error response
+func ErrForbidden(message string) render.Renderer {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusForbidden,
+		StatusText:     "error",
+		ErrorText:      message,
+	}
+}
+
+// ErrConflict creates a conflict error response
+func ErrConflict(message string) render.Renderer {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusConflict,
+		StatusText:     "error",
+		ErrorText:      message,
+	}
+}
+
+// ErrInternal creates an internal server error response
+func ErrInternal(message string) render.Renderer {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusInternalServerError,
+		StatusText:     "error",
+		ErrorText:      message,
+	}
+}
+
+// AuthErrorRenderer maps parent login errors to HTTP responses
+func AuthErrorRenderer(err error) render.Renderer {
+	var invalidCreds *parentSvc.InvalidCredentialsError
+	var inactive *parentSvc.AccountInactiveError
+	var noProfile *parentSvc.GuardianProfileNotFoundError
+
+	switch {
+	case errors.As(err, &invalidCreds):
+		return ErrInvalidCredentials()
+	case errors.As(err, &inactive):
+		return ErrForbidden("Parent account is inactive")
+	case errors.As(err, &noProfile):
+		return ErrForbidden("Parent account is not linked to a guardian")
+	default:
+		return ErrInternal("Authentication failed")
+	}
+}
+
+// PortalErrorRenderer maps parent portal service errors to HTTP responses.
+// Unlinked students are reported as not found so guardians cannot probe student IDs.
+func PortalErrorRenderer(err error) render.Renderer {
+	var notLinked *parentSvc.StudentNotLinkedError
+	var noProfile *parentSvc.GuardianProfileNotFoundError
+	var exceptionNotFound *parentSvc.PickupExceptionNotFoundError
+	var invalidData *parentSvc.InvalidDataError
+	var overlap *parentSvc.AbsenceOverlapError
+
+	switch {
+	case errors.As(err, &notLinked):
+		return ErrNotFound("Child not found")
+	case errors.As(err, &noProfile):
+		return ErrForbidden("Parent account is not linked to a guardian")
+	case errors.As(err, &exceptionNotFound):
+		return ErrNotFound("Pickup exception not found")
+	case errors.As(err, &invalidData):
+		return ErrInvalidRequest(err)
+	case errors.As(err, &overlap):
+		return ErrConflict(err.Error())
+	default:
+		return ErrInternal("An error occurred")
+	}
+}
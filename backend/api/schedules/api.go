@@ -14,7 +14,9 @@ import (
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
 	"github.com/moto-nrw/project-phoenix/models/base"
 	"github.com/moto-nrw/project-phoenix/models/schedule"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
 	scheduleSvc "github.com/moto-nrw/project-phoenix/services/schedule"
+	userSvc "github.com/moto-nrw/project-phoenix/services/users"
 )
 
 // Use shared constants from common package
@@ -33,16 +35,29 @@ const (
 
 // Resource defines the schedules API resource
 type Resource struct {
-	ScheduleService scheduleSvc.Service
+	ScheduleService     scheduleSvc.Service
+	BusRouteService     activeSvc.BusRouteService
+	CalendarFeedService scheduleSvc.CalendarFeedService
+	PersonService       userSvc.PersonService
+
+	feedRateLimiter func(http.Handler) http.Handler
 }
 
 // NewResource creates a new schedules resource
-func NewResource(scheduleService scheduleSvc.Service) *Resource {
+func NewResource(scheduleService scheduleSvc.Service, busRouteService activeSvc.BusRouteService, calendarFeedService scheduleSvc.CalendarFeedService, personService userSvc.PersonService) *Resource {
 	return &Resource{
-		ScheduleService: scheduleService,
+		ScheduleService:     scheduleService,
+		BusRouteService:     busRouteService,
+		CalendarFeedService: calendarFeedService,
+		PersonService:       personService,
 	}
 }
 
+// SetFeedRateLimiter sets the rate limiter middleware for the public calendar feed endpoint.
+func (rs *Resource) SetFeedRateLimiter(mw func(http.Handler) http.Handler) {
+	rs.feedRateLimiter = mw
+}
+
 // Router returns a configured router for schedule endpoints
 func (rs *Resource) Router() chi.Router {
 	r := chi.NewRouter()
@@ -51,6 +66,14 @@ func (rs *Resource) Router() chi.Router {
 	// Create JWT auth instance for middleware
 	tokenAuth, _ := jwt.NewTokenAuth()
 
+	// Public iCalendar feeds: calendar apps authenticate with the token in the URL
+	r.Group(func(r chi.Router) {
+		if rs.feedRateLimiter != nil {
+			r.Use(rs.feedRateLimiter)
+		}
+		r.Get("/feeds/{token}", rs.serveCalendarFeed)
+	})
+
 	// Protected routes that require authentication and permissions
 	r.Group(func(r chi.Router) {
 		r.Use(tokenAuth.Verifier())
@@ -72,6 +95,12 @@ func (rs *Resource) Router() chi.Router {
 			r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/overlapping", rs.getOverlappingDateframes)
 		})
 
+		// Holiday and closure calendar (holiday/closure dateframes plus NRW public holidays)
+		r.Route("/closures", func(r chi.Router) {
+			r.With(authorize.RequiresPermission(permissions.SchedulesRead)).Get("/", rs.listClosures)
+			r.With(authorize.RequiresPermission(permissions.SchedulesCreate)).Post("/import", rs.importHolidayCalendar)
+		})
+
 		// Timeframe endpoints
 		r.Route("/timeframes", func(r chi.Router) {
 			r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Get("/", rs.listTimeframes)
@@ -99,6 +128,24 @@ func (rs *Resource) Router() chi.Router {
 			r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Post("/{id}/generate-events", rs.generateEvents)
 		})
 
+		// Bus route endpoints (departure times, bus children and bulk bus checkout)
+		r.Route("/bus-routes", func(r chi.Router) {
+			r.With(authorize.RequiresPermission(permissions.SchedulesRead)).Get("/", rs.listBusRoutes)
+			r.With(authorize.RequiresPermission(permissions.SchedulesRead)).Get("/{id}", rs.getBusRoute)
+			r.With(authorize.RequiresPermission(permissions.SchedulesCreate)).Post("/", rs.createBusRoute)
+			r.With(authorize.RequiresPermission(permissions.SchedulesUpdate)).Put("/{id}", rs.updateBusRoute)
+			r.With(authorize.RequiresPermission(permissions.SchedulesDelete)).Delete("/{id}", rs.deleteBusRoute)
+			r.With(authorize.RequiresPermission(permissions.SchedulesUpdate)).Put("/{id}/students", rs.setBusRouteStudents)
+			r.With(authorize.RequiresPermission(permissions.VisitsUpdate)).Post("/{id}/checkout", rs.checkoutBusRoute)
+		})
+
+		// Calendar feed subscriptions of the authenticated staff member
+		r.Route("/calendar-feeds", func(r chi.Router) {
+			r.With(authorize.RequiresPermission(permissions.SchedulesRead)).Get("/", rs.listCalendarFeeds)
+			r.With(authorize.RequiresPermission(permissions.SchedulesRead)).Post("/", rs.createCalendarFeed)
+			r.With(authorize.RequiresPermission(permissions.SchedulesRead)).Delete("/{id}", rs.revokeCalendarFeed)
+		})
+
 		// Advanced scheduling operations
 		r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Post("/check-conflict", rs.checkConflict)
 		r.With(authorize.RequiresPermission(permissions.ActivitiesRead)).Post("/find-available-slots", rs.findAvailableSlots)
@@ -111,29 +158,70 @@ func (rs *Resource) Router() chi.Router {
 
 // DateframeRequest represents a dateframe creation/update request
 type DateframeRequest struct {
-	StartDate   string `json:"start_date"`
-	EndDate     string `json:"end_date"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	StartDate          string  `json:"start_date"`
+	EndDate            string  `json:"end_date"`
+	Name               string  `json:"name,omitempty"`
+	Description        string  `json:"description,omitempty"`
+	Kind               string  `json:"kind,omitempty"`                 // term (default), holiday or closure
+	EnrollmentOpensAt  *string `json:"enrollment_opens_at,omitempty"`  // RFC3339, optional
+	EnrollmentClosesAt *string `json:"enrollment_closes_at,omitempty"` // RFC3339, optional
+
+	enrollmentOpensAt  *time.Time
+	enrollmentClosesAt *time.Time
 }
 
 // Bind validates the dateframe request
 func (req *DateframeRequest) Bind(_ *http.Request) error {
-	return validation.ValidateStruct(req,
+	if err := validation.ValidateStruct(req,
 		validation.Field(&req.StartDate, validation.Required),
 		validation.Field(&req.EndDate, validation.Required),
-	)
+		validation.Field(&req.Kind, validation.In(
+			schedule.DateframeKindTerm,
+			schedule.DateframeKindHoliday,
+			schedule.DateframeKindClosure,
+		)),
+	); err != nil {
+		return err
+	}
+
+	var err error
+	if req.enrollmentOpensAt, err = parseOptionalRFC3339(req.EnrollmentOpensAt); err != nil {
+		return errors.New("invalid enrollment_opens_at, expected RFC3339")
+	}
+	if req.enrollmentClosesAt, err = parseOptionalRFC3339(req.EnrollmentClosesAt); err != nil {
+		return errors.New("invalid enrollment_closes_at, expected RFC3339")
+	}
+	if req.enrollmentOpensAt != nil && req.enrollmentClosesAt != nil && !req.enrollmentClosesAt.After(*req.enrollmentOpensAt) {
+		return errors.New("enrollment_closes_at must be after enrollment_opens_at")
+	}
+	return nil
+}
+
+// parseOptionalRFC3339 parses an optional RFC3339 timestamp
+func parseOptionalRFC3339(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
 
 // DateframeResponse represents a dateframe response
 type DateframeResponse struct {
-	ID          int64       `json:"id"`
-	StartDate   common.Time `json:"start_date"`
-	EndDate     common.Time `json:"end_date"`
-	Name        string      `json:"name,omitempty"`
-	Description string      `json:"description,omitempty"`
-	CreatedAt   common.Time `json:"created_at"`
-	UpdatedAt   common.Time `json:"updated_at"`
+	ID                 int64        `json:"id"`
+	StartDate          common.Time  `json:"start_date"`
+	EndDate            common.Time  `json:"end_date"`
+	Name               string       `json:"name,omitempty"`
+	Description        string       `json:"description,omitempty"`
+	Kind               string       `json:"kind"`
+	ExternalUID        *string      `json:"external_uid,omitempty"`
+	EnrollmentOpensAt  *common.Time `json:"enrollment_opens_at,omitempty"`
+	EnrollmentClosesAt *common.Time `json:"enrollment_closes_at,omitempty"`
+	CreatedAt          common.Time  `json:"created_at"`
+	UpdatedAt          common.Time  `json:"updated_at"`
 }
 
 // TimeframeRequest represents a timeframe creation/update request
@@ -297,15 +385,26 @@ func (rs *Resource) parseOptionalEndDate(w http.ResponseWriter, r *http.Request,
 }
 
 func newDateframeResponse(dateframe *schedule.Dateframe) DateframeResponse {
-	return DateframeResponse{
+	resp := DateframeResponse{
 		ID:          dateframe.ID,
 		StartDate:   common.Time(dateframe.StartDate),
 		EndDate:     common.Time(dateframe.EndDate),
 		Name:        dateframe.Name,
 		Description: dateframe.Description,
+		Kind:        dateframe.Kind,
+		ExternalUID: dateframe.ExternalUID,
 		CreatedAt:   common.Time(dateframe.CreatedAt),
 		UpdatedAt:   common.Time(dateframe.UpdatedAt),
 	}
+	if dateframe.EnrollmentOpensAt != nil {
+		opensAt := common.Time(*dateframe.EnrollmentOpensAt)
+		resp.EnrollmentOpensAt = &opensAt
+	}
+	if dateframe.EnrollmentClosesAt != nil {
+		closesAt := common.Time(*dateframe.EnrollmentClosesAt)
+		resp.EnrollmentClosesAt = &closesAt
+	}
+	return resp
 }
 
 func newTimeframeResponse(timeframe *schedule.Timeframe) TimeframeResponse {
@@ -357,6 +456,9 @@ func (rs *Resource) listDateframes(w http.ResponseWriter, r *http.Request) {
 	if name != "" {
 		queryOptions.Filter.ILike("name", "%"+name+"%")
 	}
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		queryOptions.Filter.Equal("kind", kind)
+	}
 
 	// Add pagination
 	page, pageSize := common.ParsePagination(r)
@@ -412,10 +514,13 @@ func (rs *Resource) createDateframe(w http.ResponseWriter, r *http.Request) {
 
 	// Create dateframe
 	dateframe := &schedule.Dateframe{
-		StartDate:   startDate,
-		EndDate:     endDate,
-		Name:        req.Name,
-		Description: req.Description,
+		StartDate:          startDate,
+		EndDate:            endDate,
+		Name:               req.Name,
+		Description:        req.Description,
+		Kind:               req.Kind,
+		EnrollmentOpensAt:  req.enrollmentOpensAt,
+		EnrollmentClosesAt: req.enrollmentClosesAt,
 	}
 
 	if err := rs.ScheduleService.CreateDateframe(r.Context(), dateframe); err != nil {
@@ -459,6 +564,11 @@ func (rs *Resource) updateDateframe(w http.ResponseWriter, r *http.Request) {
 	dateframe.EndDate = endDate
 	dateframe.Name = req.Name
 	dateframe.Description = req.Description
+	if req.Kind != "" {
+		dateframe.Kind = req.Kind
+	}
+	dateframe.EnrollmentOpensAt = req.enrollmentOpensAt
+	dateframe.EnrollmentClosesAt = req.enrollmentClosesAt
 
 	// Update dateframe
 	if err := rs.ScheduleService.UpdateDateframe(r.Context(), dateframe); err != nil {
@@ -0,0 +1,347 @@
+package schedules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+const errMsgInvalidBusRouteID = "invalid bus route ID"
+
+// BusDepartureRequest is the departure of a bus route on one weekday
+type BusDepartureRequest struct {
+	Weekday       int    `json:"weekday"`        // 1 (Monday) to 5 (Friday)
+	DepartureTime string `json:"departure_time"` // HH:MM format
+}
+
+// BusRouteRequest represents a bus route creation/update request
+type BusRouteRequest struct {
+	Name        string                `json:"name"`
+	Description *string               `json:"description,omitempty"`
+	IsActive    *bool                 `json:"is_active,omitempty"` // Defaults to true
+	Departures  []BusDepartureRequest `json:"departures"`
+
+	departures []*schedule.BusRouteDeparture
+}
+
+// Bind validates the bus route request and parses the departure times
+func (req *BusRouteRequest) Bind(_ *http.Request) error {
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+
+	req.departures = make([]*schedule.BusRouteDeparture, 0, len(req.Departures))
+	for _, d := range req.Departures {
+		departureTime, err := time.Parse("2006-01-02 15:04", "2000-01-01 "+d.DepartureTime)
+		if err != nil {
+			return fmt.Errorf("invalid departure_time %q, expected HH:MM", d.DepartureTime)
+		}
+		req.departures = append(req.departures, &schedule.BusRouteDeparture{
+			Weekday:       d.Weekday,
+			DepartureTime: departureTime,
+		})
+	}
+	return nil
+}
+
+// applyTo copies the request fields onto the bus route
+func (req *BusRouteRequest) applyTo(route *schedule.BusRoute) {
+	route.Name = req.Name
+	route.Description = req.Description
+	route.IsActive = req.IsActive == nil || *req.IsActive
+	route.Departures = req.departures
+}
+
+// BusRouteStudentsRequest replaces the students of a bus route
+type BusRouteStudentsRequest struct {
+	StudentIDs []int64 `json:"student_ids"`
+}
+
+// Bind implements render.Binder
+func (req *BusRouteStudentsRequest) Bind(_ *http.Request) error {
+	if req.StudentIDs == nil {
+		return errors.New("student_ids is required")
+	}
+	return nil
+}
+
+// BusDepartureResponse represents a departure in API responses
+type BusDepartureResponse struct {
+	Weekday       int    `json:"weekday"`
+	WeekdayName   string `json:"weekday_name"`
+	DepartureTime string `json:"departure_time"` // HH:MM format
+}
+
+// BusRouteResponse represents a bus route in API responses
+type BusRouteResponse struct {
+	ID          int64                  `json:"id"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description,omitempty"`
+	IsActive    bool                   `json:"is_active"`
+	Departures  []BusDepartureResponse `json:"departures"`
+	StudentIDs  []int64                `json:"student_ids"`
+	CreatedBy   int64                  `json:"created_by"`
+	CreatedAt   common.Time            `json:"created_at"`
+	UpdatedAt   common.Time            `json:"updated_at"`
+}
+
+// newBusRouteResponse converts a bus route model to API response
+func newBusRouteResponse(route *schedule.BusRoute) BusRouteResponse {
+	resp := BusRouteResponse{
+		ID:          route.ID,
+		Name:        route.Name,
+		Description: route.Description,
+		IsActive:    route.IsActive,
+		Departures:  make([]BusDepartureResponse, 0, len(route.Departures)),
+		StudentIDs:  route.StudentIDs,
+		CreatedBy:   route.CreatedBy,
+		CreatedAt:   common.Time(route.CreatedAt),
+		UpdatedAt:   common.Time(route.UpdatedAt),
+	}
+	if resp.StudentIDs == nil {
+		resp.StudentIDs = []int64{}
+	}
+	for _, d := range route.Departures {
+		resp.Departures = append(resp.Departures, BusDepartureResponse{
+			Weekday:       d.Weekday,
+			WeekdayName:   d.GetWeekdayName(),
+			DepartureTime: d.DepartureTime.Format("15:04"),
+		})
+	}
+	return resp
+}
+
+// busRouteErrorRenderer maps bus route service errors to HTTP responses
+func busRouteErrorRenderer(err error) render.Renderer {
+	switch {
+	case errors.Is(err, activeSvc.ErrBusRouteNotFound):
+		return ErrorNotFound(err)
+	case errors.Is(err, activeSvc.ErrStudentNotBusChild),
+		errors.Is(err, activeSvc.ErrStudentNotFound),
+		errors.Is(err, activeSvc.ErrInvalidData):
+		return ErrorInvalidRequest(err)
+	default:
+		return ErrorInternalServer(err)
+	}
+}
+
+// listBusRoutes handles GET /schedules/bus-routes
+func (rs *Resource) listBusRoutes(w http.ResponseWriter, r *http.Request) {
+	routes, err := rs.BusRouteService.ListRoutes(r.Context())
+	if err != nil {
+		common.RenderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	responses := make([]BusRouteResponse, 0, len(routes))
+	for _, route := range routes {
+		responses = append(responses, newBusRouteResponse(route))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Bus routes retrieved successfully")
+}
+
+// getBusRoute handles GET /schedules/bus-routes/{id}
+func (rs *Resource) getBusRoute(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidBusRouteID)))
+		return
+	}
+
+	route, err := rs.BusRouteService.GetRoute(r.Context(), id)
+	if err != nil {
+		common.RenderError(w, r, busRouteErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newBusRouteResponse(route), "Bus route retrieved successfully")
+}
+
+// createBusRoute handles POST /schedules/bus-routes
+func (rs *Resource) createBusRoute(w http.ResponseWriter, r *http.Request) {
+	req := &BusRouteRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	staff, err := rs.getStaffFromJWT(r)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorForbidden(err))
+		return
+	}
+
+	route := &schedule.BusRoute{CreatedBy: staff.ID}
+	req.applyTo(route)
+
+	if err := rs.BusRouteService.CreateRoute(r.Context(), route); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, newBusRouteResponse(route), "Bus route created successfully")
+}
+
+// updateBusRoute handles PUT /schedules/bus-routes/{id}
+func (rs *Resource) updateBusRoute(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidBusRouteID)))
+		return
+	}
+
+	req := &BusRouteRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	route, err := rs.BusRouteService.GetRoute(r.Context(), id)
+	if err != nil {
+		common.RenderError(w, r, busRouteErrorRenderer(err))
+		return
+	}
+	req.applyTo(route)
+
+	if err := rs.BusRouteService.UpdateRoute(r.Context(), route); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newBusRouteResponse(route), "Bus route updated successfully")
+}
+
+// deleteBusRoute handles DELETE /schedules/bus-routes/{id}
+func (rs *Resource) deleteBusRoute(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidBusRouteID)))
+		return
+	}
+
+	if _, err := rs.BusRouteService.GetRoute(r.Context(), id); err != nil {
+		common.RenderError(w, r, busRouteErrorRenderer(err))
+		return
+	}
+
+	if err := rs.BusRouteService.DeleteRoute(r.Context(), id); err != nil {
+		common.RenderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, nil, "Bus route deleted successfully")
+}
+
+// setBusRouteStudents handles PUT /schedules/bus-routes/{id}/students
+// Only students marked as bus children can be assigned; a student rides at most one route.
+func (rs *Resource) setBusRouteStudents(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidBusRouteID)))
+		return
+	}
+
+	req := &BusRouteStudentsRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	route, err := rs.BusRouteService.AssignStudents(r.Context(), id, req.StudentIDs)
+	if err != nil {
+		common.RenderError(w, r, busRouteErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, newBusRouteResponse(route), "Bus route students updated successfully")
+}
+
+// checkoutBusRoute handles POST /schedules/bus-routes/{id}/checkout
+// Checks out all checked-in students of the route and records them as departed by bus.
+func (rs *Resource) checkoutBusRoute(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidBusRouteID)))
+		return
+	}
+
+	staff, err := rs.getStaffFromJWT(r)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorForbidden(err))
+		return
+	}
+
+	result, err := rs.BusRouteService.CheckoutRoute(r.Context(), id, staff, time.Now())
+	if err != nil {
+		common.RenderError(w, r, busRouteErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, result, fmt.Sprintf("%d students departed by bus", len(result.CheckedOut)))
+}
+
+// getStaffFromJWT resolves the staff member of the authenticated account
+func (rs *Resource) getStaffFromJWT(r *http.Request) (*users.Staff, error) {
+	claims := jwt.ClaimsFromCtx(r.Context())
+	if claims.ID == 0 {
+		return nil, errors.New("no valid JWT claims found")
+	}
+
+	person, err := rs.PersonService.FindByAccountID(r.Context(), int64(claims.ID))
+	if err != nil || person == nil {
+		return nil, errors.New("person not found for account")
+	}
+
+	staff, err := rs.PersonService.StaffRepository().FindByPersonID(r.Context(), person.ID)
+	if err != nil || staff == nil {
+		return nil, errors.New("user is not a staff member")
+	}
+
+	return staff, nil
+}
+
+// Handler accessor methods for testing
+
+// ListBusRoutesHandler returns the handler for listing bus routes
+func (rs *Resource) ListBusRoutesHandler() http.HandlerFunc {
+	return rs.listBusRoutes
+}
+
+// GetBusRouteHandler returns the handler for a single bus route
+func (rs *Resource) GetBusRouteHandler() http.HandlerFunc {
+	return rs.getBusRoute
+}
+
+// CreateBusRouteHandler returns the handler for creating a bus route
+func (rs *Resource) CreateBusRouteHandler() http.HandlerFunc {
+	return rs.createBusRoute
+}
+
+// UpdateBusRouteHandler returns the handler for updating a bus route
+func (rs *Resource) UpdateBusRouteHandler() http.HandlerFunc {
+	return rs.updateBusRoute
+}
+
+// DeleteBusRouteHandler returns the handler for deleting a bus route
+func (rs *Resource) DeleteBusRouteHandler() http.HandlerFunc {
+	return rs.deleteBusRoute
+}
+
+// SetBusRouteStudentsHandler returns the handler for assigning students to a bus route
+func (rs *Resource) SetBusRouteStudentsHandler() http.HandlerFunc {
+	return rs.setBusRouteStudents
+}
+
+// CheckoutBusRouteHandler returns the handler for the bulk bus checkout
+func (rs *Resource) CheckoutBusRouteHandler() http.HandlerFunc {
+	return rs.checkoutBusRoute
+}
@@ -0,0 +1,71 @@
+package schedules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusRouteRequest_Bind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	t.Run("parses departures and defaults to active", func(t *testing.T) {
+		r := &BusRouteRequest{
+			Name: "Linie 12 Nord",
+			Departures: []BusDepartureRequest{
+				{Weekday: schedule.WeekdayMonday, DepartureTime: "15:40"},
+				{Weekday: schedule.WeekdayFriday, DepartureTime: "13:10"},
+			},
+		}
+		require.NoError(t, r.Bind(req))
+
+		route := &schedule.BusRoute{}
+		r.applyTo(route)
+		assert.True(t, route.IsActive)
+		require.Len(t, route.Departures, 2)
+		assert.Equal(t, "13:10", route.Departures[1].DepartureTime.Format("15:04"))
+
+		resp := newBusRouteResponse(route)
+		assert.Equal(t, "Freitag", resp.Departures[1].WeekdayName)
+		assert.Equal(t, []int64{}, resp.StudentIDs)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		r := &BusRouteRequest{}
+		require.Error(t, r.Bind(req))
+	})
+
+	t.Run("invalid departure time", func(t *testing.T) {
+		r := &BusRouteRequest{Name: "Linie 12 Nord", Departures: []BusDepartureRequest{{Weekday: 1, DepartureTime: "15.40"}}}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "departure_time")
+	})
+}
+
+func TestBusRouteErrorRenderer(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{&activeSvc.ActiveError{Op: "get bus route", Err: activeSvc.ErrBusRouteNotFound}, http.StatusNotFound},
+		{&activeSvc.ActiveError{Op: "assign bus students", Err: fmt.Errorf("%w: student 20", activeSvc.ErrStudentNotBusChild)}, http.StatusBadRequest},
+		{&activeSvc.ActiveError{Op: "assign bus students", Err: activeSvc.ErrStudentNotFound}, http.StatusBadRequest},
+		{errors.New("connection reset"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			resp, ok := busRouteErrorRenderer(tt.err).(*common.ErrResponse)
+			require.True(t, ok)
+			assert.Equal(t, tt.status, resp.HTTPStatusCode)
+		})
+	}
+}
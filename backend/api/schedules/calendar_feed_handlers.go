@@ -0,0 +1,161 @@
+package schedules
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	scheduleSvc "github.com/moto-nrw/project-phoenix/services/schedule"
+)
+
+const (
+	errMsgInvalidCalendarFeedID = "invalid calendar feed ID"
+
+	// calendarFeedPathPrefix is where the public feeds are served; the token completes the URL
+	calendarFeedPathPrefix = "/api/schedules/feeds/"
+)
+
+// CalendarFeedRequest creates a calendar feed. Without activity_group_id the feed publishes the
+// supervision plan of the authenticated staff member.
+type CalendarFeedRequest struct {
+	Name            string `json:"name"`
+	ActivityGroupID *int64 `json:"activity_group_id,omitempty"`
+}
+
+// Bind validates the calendar feed request
+func (req *CalendarFeedRequest) Bind(_ *http.Request) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+	if req.ActivityGroupID != nil && *req.ActivityGroupID <= 0 {
+		return errors.New("invalid activity_group_id")
+	}
+	return nil
+}
+
+// CalendarFeedResponse represents a calendar feed in API responses. Token and SubscriptionPath
+// are only set when the feed is created.
+type CalendarFeedResponse struct {
+	ID               int64      `json:"id"`
+	Name             string     `json:"name"`
+	ActivityGroupID  *int64     `json:"activity_group_id,omitempty"`
+	Revoked          bool       `json:"revoked"`
+	LastAccessedAt   *time.Time `json:"last_accessed_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Token            string     `json:"token,omitempty"`
+	SubscriptionPath string     `json:"subscription_path,omitempty"`
+}
+
+// newCalendarFeedResponse converts a calendar feed model to a response object
+func newCalendarFeedResponse(feed *schedule.CalendarFeed) CalendarFeedResponse {
+	return CalendarFeedResponse{
+		ID:              feed.ID,
+		Name:            feed.Name,
+		ActivityGroupID: feed.ActivityGroupID,
+		Revoked:         feed.IsRevoked(),
+		LastAccessedAt:  feed.LastAccessedAt,
+		RevokedAt:       feed.RevokedAt,
+		CreatedAt:       feed.CreatedAt,
+	}
+}
+
+// listCalendarFeeds handles GET /schedules/calendar-feeds
+func (rs *Resource) listCalendarFeeds(w http.ResponseWriter, r *http.Request) {
+	staff, err := rs.getStaffFromJWT(r)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorForbidden(err))
+		return
+	}
+
+	feeds, err := rs.CalendarFeedService.ListFeeds(r.Context(), staff.ID)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	responses := make([]CalendarFeedResponse, 0, len(feeds))
+	for _, feed := range feeds {
+		responses = append(responses, newCalendarFeedResponse(feed))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Calendar feeds retrieved successfully")
+}
+
+// createCalendarFeed handles POST /schedules/calendar-feeds.
+// The token is returned only in this response; it cannot be retrieved later.
+func (rs *Resource) createCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	req := &CalendarFeedRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	staff, err := rs.getStaffFromJWT(r)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorForbidden(err))
+		return
+	}
+
+	feed, token, err := rs.CalendarFeedService.CreateFeed(r.Context(), staff.ID, req.Name, req.ActivityGroupID)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	resp := newCalendarFeedResponse(feed)
+	resp.Token = token
+	resp.SubscriptionPath = calendarFeedPathPrefix + token + ".ics"
+
+	common.Respond(w, r, http.StatusCreated, resp, "Calendar feed created successfully")
+}
+
+// revokeCalendarFeed handles DELETE /schedules/calendar-feeds/{id}
+func (rs *Resource) revokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	id, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidCalendarFeedID)))
+		return
+	}
+
+	staff, err := rs.getStaffFromJWT(r)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorForbidden(err))
+		return
+	}
+
+	if err := rs.CalendarFeedService.RevokeFeed(r.Context(), id, staff.ID); err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, nil, "Calendar feed revoked successfully")
+}
+
+// serveCalendarFeed handles GET /schedules/feeds/{token}. The endpoint is public: calendar apps
+// cannot send a JWT, so the unguessable token in the URL authorizes the read-only feed.
+func (rs *Resource) serveCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(chi.URLParam(r, "token"), ".ics")
+
+	body, err := rs.CalendarFeedService.RenderFeed(r.Context(), token, time.Now())
+	if err != nil {
+		if errors.Is(err, scheduleSvc.ErrCalendarFeedNotFound) {
+			http.Error(w, "calendar feed not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "calendar feed unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,61 @@
+package schedules
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	scheduleSvc "github.com/moto-nrw/project-phoenix/services/schedule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCalendarFeedService serves a fixed document for a single token
+type stubCalendarFeedService struct {
+	scheduleSvc.CalendarFeedService
+	token string
+}
+
+func (s *stubCalendarFeedService) RenderFeed(_ context.Context, token string, _ time.Time) ([]byte, error) {
+	if token != s.token {
+		return nil, &scheduleSvc.ScheduleError{Op: "render calendar feed", Err: scheduleSvc.ErrCalendarFeedNotFound}
+	}
+	return []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil
+}
+
+func TestCalendarFeedRequest_Bind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	invalidGroupID := int64(0)
+
+	r := &CalendarFeedRequest{Name: "  Mein Aufsichtsplan "}
+	require.NoError(t, r.Bind(req))
+	assert.Equal(t, "Mein Aufsichtsplan", r.Name)
+
+	require.Error(t, (&CalendarFeedRequest{Name: " "}).Bind(req))
+	require.Error(t, (&CalendarFeedRequest{Name: "AG", ActivityGroupID: &invalidGroupID}).Bind(req))
+}
+
+func TestServeCalendarFeed(t *testing.T) {
+	rs := &Resource{CalendarFeedService: &stubCalendarFeedService{token: "cal_abc"}}
+	router := chi.NewRouter()
+	router.Get("/feeds/{token}", rs.serveCalendarFeed)
+
+	t.Run("serves the feed without authentication", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/cal_abc.ics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "BEGIN:VCALENDAR")
+	})
+
+	t.Run("unknown or revoked token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feeds/cal_revoked.ics", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
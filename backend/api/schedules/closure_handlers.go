@@ -0,0 +1,120 @@
+package schedules
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/internal/holidays"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+)
+
+const (
+	maxCalendarFileSize = 1 * 1024 * 1024 // 1MB; a year of school holidays is a few KB
+	maxClosureRangeDays = 3 * 366
+)
+
+// PublicHolidayResponse is a statutory NRW public holiday within the requested range
+type PublicHolidayResponse struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Name string `json:"name"`
+}
+
+// ClosuresResponse lists the days without regular school within a date range
+type ClosuresResponse struct {
+	From           string                  `json:"from"`
+	To             string                  `json:"to"`
+	Closures       []DateframeResponse     `json:"closures"`
+	PublicHolidays []PublicHolidayResponse `json:"public_holidays"`
+}
+
+// listClosures handles GET /schedules/closures?from=YYYY-MM-DD&to=YYYY-MM-DD.
+// Without parameters the next 90 days are listed.
+func (rs *Resource) listClosures(w http.ResponseWriter, r *http.Request) {
+	from := timezone.TodayUTC()
+	to := from.AddDate(0, 0, 90)
+
+	var err error
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = time.Parse(dateLayout, v); err != nil {
+			common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidStartDate)))
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = time.Parse(dateLayout, v); err != nil {
+			common.RenderError(w, r, ErrorInvalidRequest(errors.New(errMsgInvalidEndDate)))
+			return
+		}
+	}
+	if to.Sub(from) > maxClosureRangeDays*24*time.Hour {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New("date range must not exceed three years")))
+		return
+	}
+
+	closures, err := rs.ScheduleService.ListClosures(r.Context(), from, to)
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	resp := ClosuresResponse{
+		From:           from.Format(dateLayout),
+		To:             to.Format(dateLayout),
+		Closures:       make([]DateframeResponse, 0, len(closures)),
+		PublicHolidays: make([]PublicHolidayResponse, 0),
+	}
+	for _, df := range closures {
+		resp.Closures = append(resp.Closures, newDateframeResponse(df))
+	}
+	for date, name := range holidays.InRange(from, to) {
+		resp.PublicHolidays = append(resp.PublicHolidays, PublicHolidayResponse{Date: date.Format(dateLayout), Name: name})
+	}
+	sort.Slice(resp.PublicHolidays, func(i, j int) bool {
+		return resp.PublicHolidays[i].Date < resp.PublicHolidays[j].Date
+	})
+
+	common.Respond(w, r, http.StatusOK, resp, "Closures retrieved successfully")
+}
+
+// importHolidayCalendar handles POST /schedules/closures/import (multipart field "file").
+// The optional "kind" query parameter selects holiday (default) or closure.
+func (rs *Resource) importHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCalendarFileSize)
+	if r.ParseMultipartForm(maxCalendarFileSize) != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New("file too large (max 1MB)")))
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		common.RenderError(w, r, ErrorInvalidRequest(errors.New("file is required")))
+		return
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			slog.Default().Error("failed to close calendar file", slog.String("error", err.Error()))
+		}
+	}()
+
+	result, err := rs.ScheduleService.ImportHolidayCalendar(r.Context(), file, r.URL.Query().Get("kind"))
+	if err != nil {
+		common.RenderError(w, r, ErrorRenderer(err))
+		return
+	}
+
+	dateframes := make([]DateframeResponse, 0, len(result.Dateframes))
+	for _, df := range result.Dateframes {
+		dateframes = append(dateframes, newDateframeResponse(df))
+	}
+
+	common.Respond(w, r, http.StatusOK, map[string]interface{}{
+		"created":    result.Created,
+		"updated":    result.Updated,
+		"unchanged":  result.Unchanged,
+		"dateframes": dateframes,
+	}, "Holiday calendar imported successfully")
+}
@@ -0,0 +1,53 @@
+package schedules
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateframeRequest_BindEnrollmentWindow(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	opens := "2026-08-01T08:00:00+02:00"
+	closes := "2026-08-15T18:00:00+02:00"
+	invalid := "2026-08-15"
+
+	t.Run("parses the window", func(t *testing.T) {
+		r := &DateframeRequest{StartDate: "2026-09-01", EndDate: "2027-01-31", EnrollmentOpensAt: &opens, EnrollmentClosesAt: &closes}
+		require.NoError(t, r.Bind(req))
+		require.NotNil(t, r.enrollmentOpensAt)
+		require.NotNil(t, r.enrollmentClosesAt)
+		assert.Equal(t, 15, r.enrollmentClosesAt.Day())
+	})
+
+	t.Run("window is optional", func(t *testing.T) {
+		r := &DateframeRequest{StartDate: "2026-09-01", EndDate: "2027-01-31"}
+		require.NoError(t, r.Bind(req))
+		assert.Nil(t, r.enrollmentOpensAt)
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		r := &DateframeRequest{StartDate: "2026-09-01", EndDate: "2027-01-31", EnrollmentClosesAt: &invalid}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "enrollment_closes_at")
+	})
+
+	t.Run("closes before it opens", func(t *testing.T) {
+		r := &DateframeRequest{StartDate: "2026-09-01", EndDate: "2027-01-31", EnrollmentOpensAt: &closes, EnrollmentClosesAt: &opens}
+		require.Error(t, r.Bind(req))
+	})
+}
+
+func TestDateframeRequest_BindKind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	r := &DateframeRequest{StartDate: "2026-10-17", EndDate: "2026-10-31", Kind: "holiday"}
+	require.NoError(t, r.Bind(req))
+
+	r = &DateframeRequest{StartDate: "2026-10-17", EndDate: "2026-10-31", Kind: "vacation"}
+	require.Error(t, r.Bind(req))
+}
@@ -48,6 +48,14 @@ func ErrorRenderer(err error) render.Renderer {
 			return ErrorInvalidRequest(schedErr)
 		case scheduleSvc.ErrInvalidDuration:
 			return ErrorInvalidRequest(schedErr)
+		case scheduleSvc.ErrInvalidCalendar:
+			return ErrorInvalidRequest(schedErr)
+		case scheduleSvc.ErrInvalidDateframeKind:
+			return ErrorInvalidRequest(schedErr)
+		case scheduleSvc.ErrCalendarFeedNotFound:
+			return ErrorNotFound(schedErr)
+		case scheduleSvc.ErrCalendarFeedGroupNotFound:
+			return ErrorInvalidRequest(schedErr)
 		default:
 			return ErrorInternalServer(schedErr)
 		}
@@ -146,6 +146,42 @@ func TestErrorRenderer_InvalidDuration(t *testing.T) {
 	assert.Contains(t, errResp.ErrorText, "invalid duration")
 }
 
+func TestErrorRenderer_CalendarImportErrors(t *testing.T) {
+	for _, sentinel := range []error{scheduleSvc.ErrInvalidCalendar, scheduleSvc.ErrInvalidDateframeKind} {
+		err := &scheduleSvc.ScheduleError{
+			Op:  "ImportHolidayCalendar",
+			Err: sentinel,
+		}
+
+		errResp, ok := ErrorRenderer(err).(*common.ErrResponse)
+		require.True(t, ok, "Expected *common.ErrResponse")
+		assert.Equal(t, http.StatusBadRequest, errResp.HTTPStatusCode)
+		assert.Contains(t, errResp.ErrorText, sentinel.Error())
+	}
+}
+
+func TestErrorRenderer_CalendarFeedErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{scheduleSvc.ErrCalendarFeedNotFound, http.StatusNotFound},
+		{scheduleSvc.ErrCalendarFeedGroupNotFound, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		err := &scheduleSvc.ScheduleError{
+			Op:  "CreateCalendarFeed",
+			Err: tt.err,
+		}
+
+		errResp, ok := ErrorRenderer(err).(*common.ErrResponse)
+		require.True(t, ok, "Expected *common.ErrResponse")
+		assert.Equal(t, tt.status, errResp.HTTPStatusCode)
+		assert.Contains(t, errResp.ErrorText, tt.err.Error())
+	}
+}
+
 func TestErrorRenderer_UnknownScheduleError(t *testing.T) {
 	// ScheduleError with unknown underlying error should fall to default case
 	unknownErr := errors.New("unknown schedule error")
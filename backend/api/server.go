@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/moto-nrw/project-phoenix/realtime"
 	"github.com/moto-nrw/project-phoenix/services/scheduler"
 	"github.com/spf13/viper"
 )
@@ -16,7 +17,9 @@ import (
 // Server provides an HTTP server for the API
 type Server struct {
 	*http.Server
-	scheduler *scheduler.Scheduler
+	scheduler   *scheduler.Scheduler
+	realtimeHub *realtime.Hub
+	stopHub     context.CancelFunc
 }
 
 // NewServer creates and configures a new API server
@@ -51,6 +54,10 @@ func NewServer(logger *slog.Logger) (*Server, error) {
 		scheduler: nil, // Will be initialized if cleanup is enabled
 	}
 
+	if api.Services != nil {
+		srv.realtimeHub = api.Services.RealtimeHub
+	}
+
 	// Initialize scheduler if cleanup is enabled
 	// Note: Session cleanup is now handled by the scheduler's scheduleSessionCleanupTask()
 	if api.Services != nil && api.Services.ActiveCleanup != nil && api.Services.Active != nil {
@@ -59,6 +66,26 @@ func NewServer(logger *slog.Logger) (*Server, error) {
 			srv.scheduler.SetWorkSessionCleaner(api.Services.WorkSession)
 			srv.scheduler.SetBreakAutoEnder(api.Services.WorkSession)
 		}
+		// Queue mail in the outbox only when this scheduler drains it; otherwise it is sent directly
+		if api.Services.EmailDispatcher != nil && api.Services.EmailOutbox != nil && scheduler.EmailOutboxEnabled() {
+			api.Services.EmailDispatcher.UseOutbox(api.Services.EmailOutbox)
+			srv.scheduler.SetEmailOutboxProcessor(api.Services.EmailDispatcher)
+		}
+		if api.Services.IoT != nil {
+			srv.scheduler.SetDeviceHealthMonitor(api.Services.IoT)
+		}
+		if api.Services.MissingStudents != nil {
+			srv.scheduler.SetMissingStudentChecker(api.Services.MissingStudents)
+		}
+		if api.Services.PickupBoard != nil {
+			srv.scheduler.SetPickupDispatcher(api.Services.PickupBoard)
+		}
+		if api.Services.BusRoute != nil {
+			srv.scheduler.SetBusReminderDispatcher(api.Services.BusRoute)
+		}
+		if api.Services.AttendanceRegister != nil {
+			srv.scheduler.SetActivityRegisterDeriver(api.Services.AttendanceRegister)
+		}
 	}
 
 	return srv, nil
@@ -71,6 +98,17 @@ func (srv *Server) Start() {
 		srv.scheduler.Start()
 	}
 
+	// Receive SSE events from other replicas (no-op without a broadcast backend)
+	if srv.realtimeHub != nil {
+		var hubCtx context.Context
+		hubCtx, srv.stopHub = context.WithCancel(context.Background())
+		go func() {
+			if err := srv.realtimeHub.Run(hubCtx); err != nil {
+				slog.Error("SSE broadcast backend stopped", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
 	// Start server in a goroutine so that it doesn't block
 	go func() {
 		slog.Info("Server listening", slog.String("addr", srv.Addr))
@@ -93,6 +131,10 @@ func (srv *Server) Start() {
 		srv.scheduler.Stop()
 	}
 
+	if srv.stopHub != nil {
+		srv.stopHub()
+	}
+
 	// Create a deadline for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
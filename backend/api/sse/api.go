@@ -1,6 +1,7 @@
 package sse
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -47,9 +48,19 @@ func (rs *Resource) eventsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	conn.staffID = staff.ID
 
-	// Step 3: Build subscription topics (active groups + educational groups)
-	topics, err := rs.buildSubscriptionTopics(ctx, staff.ID)
+	// Step 3: Build subscription topics (requested via ?topics=, or the defaults)
+	requested, err := parseRequestedTopics(r.URL.Query().Get("topics"))
 	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	topics, err := rs.buildSubscriptionTopics(ctx, staff.ID, requested)
+	if err != nil {
+		var forbidden *topicForbiddenError
+		if errors.As(err, &forbidden) {
+			http.Error(w, forbidden.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, "Failed to determine supervised groups", http.StatusInternalServerError)
 		return
 	}
@@ -67,8 +78,8 @@ func (rs *Resource) eventsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Step 6: Register client and run main event loop
-	rs.createAndRegisterClient(conn)
+	// Step 6: Register client, replay events missed since Last-Event-ID and run main event loop
+	rs.createAndRegisterClient(conn, r.Header.Get("Last-Event-ID"))
 	rs.runEventLoop(ctx, conn)
 }
 
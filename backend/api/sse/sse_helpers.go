@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
@@ -22,6 +21,9 @@ type sseConnection struct {
 	client  *realtime.Client
 	topics  *sseTopics
 	logger  *slog.Logger
+
+	replay         []realtime.Event // Missed events to send before live events
+	resyncRequired bool             // Missed events could not be replayed
 }
 
 // sseTopics holds subscription topic information
@@ -91,66 +93,6 @@ func (rs *Resource) resolveStaff(ctx context.Context) (*users.Staff, string, int
 	return staff, "", 0
 }
 
-// buildSubscriptionTopics builds the list of topics to subscribe to
-func (rs *Resource) buildSubscriptionTopics(ctx context.Context, staffID int64) (*sseTopics, error) {
-	// Get supervised active groups for this staff member
-	supervisions, err := rs.activeSvc.GetStaffActiveSupervisions(ctx, staffID)
-	if err != nil {
-		rs.getLogger().Error("failed to get staff active supervisions for SSE",
-			slog.String("error", err.Error()),
-			slog.Int64("staff_id", staffID),
-		)
-		return nil, err
-	}
-
-	// Prepare subscription topics (active groups + derived educational groups)
-	activeGroupIDs := make([]string, 0, len(supervisions))
-	eduTopics := make([]string, 0)
-	allTopics := make([]string, 0)
-	topicSet := make(map[string]struct{})
-
-	addTopic := func(topic string) {
-		if topic == "" {
-			return
-		}
-		if _, exists := topicSet[topic]; exists {
-			return
-		}
-		topicSet[topic] = struct{}{}
-		allTopics = append(allTopics, topic)
-	}
-
-	for _, supervision := range supervisions {
-		groupTopic := strconv.FormatInt(supervision.GroupID, 10)
-		activeGroupIDs = append(activeGroupIDs, groupTopic)
-		addTopic(groupTopic)
-	}
-
-	// Load educational groups if usercontext service is available
-	if rs.userCtx != nil {
-		eduGroups, err := rs.userCtx.GetMyGroups(ctx)
-		if err != nil {
-			rs.getLogger().Warn("failed to load educational groups for SSE subscription",
-				slog.String("error", err.Error()),
-				slog.Int64("staff_id", staffID),
-			)
-		} else {
-			eduTopics = make([]string, 0, len(eduGroups))
-			for _, group := range eduGroups {
-				topic := fmt.Sprintf("edu:%d", group.ID)
-				eduTopics = append(eduTopics, topic)
-				addTopic(topic)
-			}
-		}
-	}
-
-	return &sseTopics{
-		activeGroupIDs: activeGroupIDs,
-		eduTopics:      eduTopics,
-		allTopics:      allTopics,
-	}, nil
-}
-
 // sendConnectedEvent sends the initial "connected" event to the client
 func (conn *sseConnection) sendConnectedEvent(topics *sseTopics) error {
 	event := connectedEvent{
@@ -176,6 +118,17 @@ func (conn *sseConnection) sendConnectedEvent(topics *sseTopics) error {
 
 // writeSSEMessage writes a formatted SSE message to the connection
 func (conn *sseConnection) writeSSEMessage(eventType string, data []byte) error {
+	return conn.writeSSEEvent("", eventType, data)
+}
+
+// writeSSEEvent writes a formatted SSE message with an optional event ID.
+// Browsers send the last received ID as Last-Event-ID header when reconnecting.
+func (conn *sseConnection) writeSSEEvent(id, eventType string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(conn.writer, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
 	if _, err := fmt.Fprintf(conn.writer, "event: %s\n", eventType); err != nil {
 		return err
 	}
@@ -216,20 +169,55 @@ func (conn *sseConnection) runHeartbeatOnlyLoop(ctx context.Context) {
 	}
 }
 
-// createAndRegisterClient creates the SSE client and registers it with the hub
-func (rs *Resource) createAndRegisterClient(conn *sseConnection) {
+// createAndRegisterClient creates the SSE client and registers it with the hub.
+// Events missed since lastEventID are queued for replay before live events.
+func (rs *Resource) createAndRegisterClient(conn *sseConnection, lastEventID string) {
 	conn.client = &realtime.Client{
 		Channel:          make(chan realtime.Event, 10), // Buffer up to 10 events
 		UserID:           conn.staffID,
 		SubscribedGroups: make(map[string]bool),
+		Resync:           make(chan struct{}, 1),
+	}
+	conn.replay, conn.resyncRequired = rs.hub.Resume(conn.client, conn.topics.allTopics, lastEventID)
+
+	if lastEventID != "" {
+		rs.getLogger().Info("SSE client resumed",
+			slog.Int64("staff_id", conn.staffID),
+			slog.String("last_event_id", lastEventID),
+			slog.Int("replayed_events", len(conn.replay)),
+			slog.Bool("resync_required", conn.resyncRequired),
+		)
 	}
-	rs.hub.Register(conn.client, conn.topics.allTopics)
+}
+
+// sendMissedEvents sends the replayed events, or a resync event if they can't be replayed
+func (conn *sseConnection) sendMissedEvents() error {
+	if conn.resyncRequired {
+		return conn.sendResyncRequired()
+	}
+
+	for _, event := range conn.replay {
+		if err := conn.sendEvent(event); err != nil {
+			return err
+		}
+	}
+	conn.replay = nil
+	return nil
+}
+
+// sendResyncRequired tells the client that events were lost and it must refetch its data
+func (conn *sseConnection) sendResyncRequired() error {
+	return conn.sendEvent(realtime.NewEvent(realtime.EventResyncRequired, "", realtime.EventData{}))
 }
 
 // runEventLoop runs the main SSE event streaming loop
 func (rs *Resource) runEventLoop(ctx context.Context, conn *sseConnection) {
 	defer rs.hub.Unregister(conn.client)
 
+	if conn.sendMissedEvents() != nil {
+		return // Client disconnected
+	}
+
 	heartbeat := time.NewTicker(30 * time.Second)
 	defer heartbeat.Stop()
 
@@ -243,6 +231,12 @@ func (rs *Resource) runEventLoop(ctx context.Context, conn *sseConnection) {
 				return // Client disconnected
 			}
 
+		case <-conn.client.Resync:
+			// Hub dropped events because our buffer was full
+			if conn.sendResyncRequired() != nil {
+				return // Client disconnected
+			}
+
 		case <-heartbeat.C:
 			if conn.sendHeartbeat() != nil {
 				return // Client disconnected
@@ -263,5 +257,5 @@ func (conn *sseConnection) sendEvent(event realtime.Event) error {
 		return nil // Don't disconnect on marshal error, just skip this event
 	}
 
-	return conn.writeSSEMessage(string(event.Type), eventData)
+	return conn.writeSSEEvent(event.ID, string(event.Type), eventData)
 }
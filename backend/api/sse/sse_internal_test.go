@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/moto-nrw/project-phoenix/realtime"
@@ -163,6 +164,56 @@ func TestSSEConnection_SendEvent(t *testing.T) {
 	assert.Contains(t, body, "Test Student")
 }
 
+func TestSSEConnection_SendEvent_WritesID(t *testing.T) {
+	mf := newMockFlusher()
+	conn := &sseConnection{
+		writer:  mf,
+		flusher: mf,
+		staffID: 301,
+	}
+
+	event := realtime.Event{ID: "abc-42", Type: realtime.EventStudentCheckOut}
+
+	err := conn.sendEvent(event)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(mf.Body.String(), "id: abc-42\nevent: student_checkout\n"))
+}
+
+func TestSSEConnection_SendMissedEvents(t *testing.T) {
+	t.Run("replays events in order", func(t *testing.T) {
+		mf := newMockFlusher()
+		conn := &sseConnection{
+			writer:  mf,
+			flusher: mf,
+			replay: []realtime.Event{
+				{ID: "e-2", Type: realtime.EventStudentCheckIn},
+				{ID: "e-3", Type: realtime.EventActivityEnd},
+			},
+		}
+
+		require.NoError(t, conn.sendMissedEvents())
+
+		body := mf.Body.String()
+		assert.Less(t, strings.Index(body, "id: e-2"), strings.Index(body, "id: e-3"))
+		assert.NotContains(t, body, string(realtime.EventResyncRequired))
+		assert.Nil(t, conn.replay)
+	})
+
+	t.Run("sends resync event when replay is impossible", func(t *testing.T) {
+		mf := newMockFlusher()
+		conn := &sseConnection{
+			writer:         mf,
+			flusher:        mf,
+			resyncRequired: true,
+		}
+
+		require.NoError(t, conn.sendMissedEvents())
+
+		assert.Contains(t, mf.Body.String(), "event: resync_required\n")
+	})
+}
+
 // =============================================================================
 // SETUP CONNECTION TESTS
 // =============================================================================
@@ -0,0 +1,228 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/realtime"
+)
+
+// maxRequestedTopics limits how many topics one connection may request
+const maxRequestedTopics = 50
+
+// invalidTopicError is returned for malformed or too many requested topics
+type invalidTopicError struct {
+	msg string
+}
+
+func (e *invalidTopicError) Error() string { return e.msg }
+
+// topicForbiddenError is returned when the user may not subscribe to a requested topic
+type topicForbiddenError struct {
+	topic string
+}
+
+func (e *topicForbiddenError) Error() string {
+	return fmt.Sprintf("not authorized for topic %s", e.topic)
+}
+
+// topicAccess describes which topics a staff member may subscribe to
+type topicAccess struct {
+	staffID          int64
+	isAdmin          bool
+	activeGroupIDs   []int64        // Supervised active groups
+	supervisedGroups map[int64]bool // Active group ID -> supervised
+	eduGroups        map[int64]bool // OGS groups the staff member teaches (incl. substitutions)
+	eduGroupIDs      []int64        // Same, in load order
+	rooms            map[int64]bool // Rooms of supervised active groups (loaded on demand)
+	loadRooms        func() error   // Loads rooms when a room topic is requested
+}
+
+// parseRequestedTopics splits the comma-separated "topics" query parameter
+func parseRequestedTopics(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var topics []string
+	for _, part := range strings.Split(raw, ",") {
+		topic := strings.TrimSpace(part)
+		if topic == "" {
+			continue
+		}
+		if _, _, err := realtime.ParseTopic(topic); err != nil {
+			return nil, &invalidTopicError{msg: err.Error()}
+		}
+		topics = append(topics, topic)
+	}
+
+	if len(topics) > maxRequestedTopics {
+		return nil, &invalidTopicError{msg: fmt.Sprintf("too many topics (max %d)", maxRequestedTopics)}
+	}
+	return topics, nil
+}
+
+// hasAdminPermissions checks if the user has admin permissions
+func hasAdminPermissions(permissions []string) bool {
+	for _, perm := range permissions {
+		if perm == "admin:*" || perm == "*:*" {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTopicAccess loads the supervisions and OGS groups of the staff member
+func (rs *Resource) loadTopicAccess(ctx context.Context, staffID int64) (*topicAccess, error) {
+	// Get supervised active groups for this staff member
+	supervisions, err := rs.activeSvc.GetStaffActiveSupervisions(ctx, staffID)
+	if err != nil {
+		rs.getLogger().Error("failed to get staff active supervisions for SSE",
+			slog.String("error", err.Error()),
+			slog.Int64("staff_id", staffID),
+		)
+		return nil, err
+	}
+
+	access := &topicAccess{
+		staffID:          staffID,
+		isAdmin:          hasAdminPermissions(jwt.PermissionsFromCtx(ctx)),
+		supervisedGroups: make(map[int64]bool, len(supervisions)),
+		eduGroups:        make(map[int64]bool),
+	}
+	for _, supervision := range supervisions {
+		if !access.supervisedGroups[supervision.GroupID] {
+			access.supervisedGroups[supervision.GroupID] = true
+			access.activeGroupIDs = append(access.activeGroupIDs, supervision.GroupID)
+		}
+	}
+
+	// Load educational groups if usercontext service is available
+	if rs.userCtx != nil {
+		eduGroups, err := rs.userCtx.GetMyGroups(ctx)
+		if err != nil {
+			rs.getLogger().Warn("failed to load educational groups for SSE subscription",
+				slog.String("error", err.Error()),
+				slog.Int64("staff_id", staffID),
+			)
+		} else {
+			for _, group := range eduGroups {
+				access.eduGroups[group.ID] = true
+				access.eduGroupIDs = append(access.eduGroupIDs, group.ID)
+			}
+		}
+	}
+
+	access.loadRooms = func() error {
+		access.rooms = make(map[int64]bool)
+		if len(access.activeGroupIDs) == 0 {
+			return nil
+		}
+		groups, err := rs.activeSvc.GetActiveGroupsByIDs(ctx, access.activeGroupIDs)
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			access.rooms[group.RoomID] = true
+		}
+		return nil
+	}
+
+	return access, nil
+}
+
+// canSubscribe checks whether the staff member may subscribe to the topic.
+// Admins may subscribe to everything; staff only to what they supervise or teach.
+func (access *topicAccess) canSubscribe(topic string) (bool, error) {
+	kind, id, err := realtime.ParseTopic(topic)
+	if err != nil {
+		return false, nil
+	}
+	if access.isAdmin {
+		return true, nil
+	}
+
+	switch kind {
+	case realtime.TopicKindActiveGroup:
+		return access.supervisedGroups[id], nil
+	case realtime.TopicKindEducationGroup:
+		return access.eduGroups[id], nil
+	case realtime.TopicKindStaff:
+		return id == access.staffID, nil
+	case realtime.TopicKindRoom:
+		if access.rooms == nil {
+			if err := access.loadRooms(); err != nil {
+				return false, err
+			}
+		}
+		return access.rooms[id], nil
+	default:
+		return false, nil // School-wide topic is admin-only
+	}
+}
+
+// defaultTopics returns the topics used when the client requests none:
+// supervised active groups, own OGS groups and the own staff topic
+func (access *topicAccess) defaultTopics() []string {
+	topics := make([]string, 0, len(access.activeGroupIDs)+len(access.eduGroupIDs)+1)
+	for _, id := range access.activeGroupIDs {
+		topics = append(topics, realtime.ActiveGroupTopic(id))
+	}
+	for _, id := range access.eduGroupIDs {
+		topics = append(topics, realtime.EducationGroupTopic(id))
+	}
+	return append(topics, realtime.StaffTopic(access.staffID))
+}
+
+// buildSubscriptionTopics builds the list of topics to subscribe to.
+// Requested topics are authorized one by one; without requested topics the defaults are used.
+func (rs *Resource) buildSubscriptionTopics(ctx context.Context, staffID int64, requested []string) (*sseTopics, error) {
+	access, err := rs.loadTopicAccess(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := requested
+	if len(candidates) == 0 {
+		candidates = access.defaultTopics()
+	}
+
+	topics := &sseTopics{
+		activeGroupIDs: make([]string, 0),
+		eduTopics:      make([]string, 0),
+		allTopics:      make([]string, 0, len(candidates)),
+	}
+	seen := make(map[string]bool, len(candidates))
+	for _, topic := range candidates {
+		if seen[topic] {
+			continue
+		}
+		seen[topic] = true
+
+		allowed, err := access.canSubscribe(topic)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			rs.getLogger().Warn("SSE topic subscription denied",
+				slog.Int64("staff_id", staffID),
+				slog.String("topic", topic),
+			)
+			return nil, &topicForbiddenError{topic: topic}
+		}
+
+		kind, _, _ := realtime.ParseTopic(topic)
+		switch kind {
+		case realtime.TopicKindActiveGroup:
+			topics.activeGroupIDs = append(topics.activeGroupIDs, topic)
+		case realtime.TopicKindEducationGroup:
+			topics.eduTopics = append(topics.eduTopics, topic)
+		}
+		topics.allTopics = append(topics.allTopics, topic)
+	}
+
+	return topics, nil
+}
@@ -0,0 +1,112 @@
+package sse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStaffID       int64 = 7
+	testActiveGroupID int64 = 42
+	testEduGroupID    int64 = 5
+	testRoomID        int64 = 12
+)
+
+func newTestTopicAccess(isAdmin bool) *topicAccess {
+	access := &topicAccess{
+		staffID:          testStaffID,
+		isAdmin:          isAdmin,
+		activeGroupIDs:   []int64{testActiveGroupID},
+		supervisedGroups: map[int64]bool{testActiveGroupID: true},
+		eduGroups:        map[int64]bool{testEduGroupID: true},
+		eduGroupIDs:      []int64{testEduGroupID},
+	}
+	access.loadRooms = func() error {
+		access.rooms = map[int64]bool{testRoomID: true}
+		return nil
+	}
+	return access
+}
+
+func TestParseRequestedTopics(t *testing.T) {
+	topics, err := parseRequestedTopics(" 42, edu:5 ,,room:12,staff:7,school")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"42", "edu:5", "room:12", "staff:7", "school"}, topics)
+
+	topics, err = parseRequestedTopics("")
+	require.NoError(t, err)
+	assert.Nil(t, topics)
+}
+
+func TestParseRequestedTopics_Invalid(t *testing.T) {
+	_, err := parseRequestedTopics("42,room:abc")
+	var invalid *invalidTopicError
+	assert.True(t, errors.As(err, &invalid))
+
+	tooMany := "1"
+	for i := 0; i < maxRequestedTopics; i++ {
+		tooMany += ",1"
+	}
+	_, err = parseRequestedTopics(tooMany)
+	assert.True(t, errors.As(err, &invalid))
+}
+
+func TestTopicAccess_CanSubscribe(t *testing.T) {
+	tests := []struct {
+		topic   string
+		allowed bool
+	}{
+		{"42", true},
+		{"43", false},
+		{"edu:5", true},
+		{"edu:6", false},
+		{"room:12", true},
+		{"room:13", false},
+		{"staff:7", true},
+		{"staff:8", false},
+		{"school", false},
+	}
+
+	access := newTestTopicAccess(false)
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			allowed, err := access.canSubscribe(tt.topic)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
+
+func TestTopicAccess_CanSubscribe_Admin(t *testing.T) {
+	access := newTestTopicAccess(true)
+
+	for _, topic := range []string{"43", "edu:6", "room:13", "staff:8", "school"} {
+		allowed, err := access.canSubscribe(topic)
+		require.NoError(t, err)
+		assert.True(t, allowed, topic)
+	}
+}
+
+func TestTopicAccess_CanSubscribe_RoomLoadError(t *testing.T) {
+	access := newTestTopicAccess(false)
+	access.loadRooms = func() error { return errors.New("db down") }
+
+	_, err := access.canSubscribe("room:12")
+	assert.Error(t, err)
+}
+
+func TestTopicAccess_DefaultTopics(t *testing.T) {
+	access := newTestTopicAccess(false)
+
+	assert.Equal(t, []string{"42", "edu:5", "staff:7"}, access.defaultTopics())
+}
+
+func TestHasAdminPermissions(t *testing.T) {
+	assert.True(t, hasAdminPermissions([]string{"groups:read", "admin:*"}))
+	assert.True(t, hasAdminPermissions([]string{"*:*"}))
+	assert.False(t, hasAdminPermissions([]string{"groups:read"}))
+	assert.False(t, hasAdminPermissions(nil))
+}
@@ -0,0 +1,229 @@
+package students
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	activeService "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+// StudentAbsenceRequest represents a request to report or update a student absence
+type StudentAbsenceRequest struct {
+	AbsenceType  string `json:"absence_type"` // sick, excused, trip, vacation
+	DateStart    string `json:"date_start"`   // YYYY-MM-DD format
+	DateEnd      string `json:"date_end"`     // YYYY-MM-DD format
+	Note         string `json:"note,omitempty"`
+	GuardianNote string `json:"guardian_note,omitempty"`
+}
+
+// Bind implements render.Binder
+func (r *StudentAbsenceRequest) Bind(_ *http.Request) error {
+	if !slices.Contains(active.ValidStudentAbsenceTypes, r.AbsenceType) {
+		return errors.New("absence_type must be one of: sick, excused, trip, vacation")
+	}
+	if _, err := time.Parse(dateFormatISO, r.DateStart); err != nil {
+		return errors.New("invalid date_start format, expected YYYY-MM-DD")
+	}
+	if r.DateEnd == "" {
+		r.DateEnd = r.DateStart
+	}
+	if _, err := time.Parse(dateFormatISO, r.DateEnd); err != nil {
+		return errors.New("invalid date_end format, expected YYYY-MM-DD")
+	}
+	if len(r.Note) > 500 || len(r.GuardianNote) > 500 {
+		return errors.New("notes cannot exceed 500 characters")
+	}
+	return nil
+}
+
+// toServiceRequest converts the API request to the service request
+func (r *StudentAbsenceRequest) toServiceRequest() activeService.StudentAbsenceRequest {
+	return activeService.StudentAbsenceRequest{
+		AbsenceType:  r.AbsenceType,
+		DateStart:    r.DateStart,
+		DateEnd:      r.DateEnd,
+		Note:         r.Note,
+		GuardianNote: r.GuardianNote,
+	}
+}
+
+// StudentAbsenceResponse represents a student absence in API responses
+type StudentAbsenceResponse struct {
+	ID           int64  `json:"id"`
+	StudentID    int64  `json:"student_id"`
+	AbsenceType  string `json:"absence_type"`
+	DateStart    string `json:"date_start"` // YYYY-MM-DD format
+	DateEnd      string `json:"date_end"`   // YYYY-MM-DD format
+	DurationDays int    `json:"duration_days"`
+	Note         string `json:"note,omitempty"`
+	GuardianNote string `json:"guardian_note,omitempty"`
+	ReportedBy   int64  `json:"reported_by,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+
+	// ReportedByGuardianID is set for absences reported through the parent portal
+	ReportedByGuardianID *int64 `json:"reported_by_guardian_id,omitempty"`
+}
+
+// mapStudentAbsenceToResponse converts a student absence model to API response
+func mapStudentAbsenceToResponse(a *active.StudentAbsence) StudentAbsenceResponse {
+	return StudentAbsenceResponse{
+		ID:           a.ID,
+		StudentID:    a.StudentID,
+		AbsenceType:  a.AbsenceType,
+		DateStart:    a.DateStart.Format(dateFormatISO),
+		DateEnd:      a.DateEnd.Format(dateFormatISO),
+		DurationDays: a.DurationDays(),
+		Note:         a.Note,
+		GuardianNote: a.GuardianNote,
+		ReportedBy:   a.ReportedBy,
+		CreatedAt:    a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    a.UpdatedAt.Format(time.RFC3339),
+
+		ReportedByGuardianID: a.ReportedByGuardianID,
+	}
+}
+
+// renderAbsenceServiceError maps student absence service errors to HTTP errors
+func renderAbsenceServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, activeService.ErrStudentAbsenceNotFound):
+		renderError(w, r, ErrorNotFound(err))
+	case errors.Is(err, activeService.ErrStudentAbsenceOverlap):
+		renderError(w, r, ErrorConflict(err))
+	case errors.Is(err, activeService.ErrInvalidStudentAbsence):
+		renderError(w, r, ErrorInvalidRequest(err))
+	default:
+		renderError(w, r, ErrorInternalServer(err))
+	}
+}
+
+// getStudentAbsences handles GET /students/{id}/absences
+// Returns the student's absence history, most recent first (full access required: notes and
+// sick reports are health data)
+func (rs *Resource) getStudentAbsences(w http.ResponseWriter, r *http.Request) {
+	student, ok := rs.parseAndGetStudent(w, r)
+	if !ok {
+		return
+	}
+	if !rs.checkStudentFullAccess(r, student) {
+		renderError(w, r, ErrorForbidden(errors.New("full access required to view student absences")))
+		return
+	}
+
+	absences, err := rs.StudentAbsenceService.ListAbsences(r.Context(), student.ID)
+	if err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	responses := make([]StudentAbsenceResponse, 0, len(absences))
+	for _, a := range absences {
+		responses = append(responses, mapStudentAbsenceToResponse(a))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Student absences retrieved successfully")
+}
+
+// createStudentAbsence handles POST /students/{id}/absences
+func (rs *Resource) createStudentAbsence(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupWriteAccess(w, r, "report absences")
+	if student == nil {
+		return
+	}
+
+	req := &StudentAbsenceRequest{}
+	if err := render.Bind(r, req); err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	staffID, err := rs.getStaffIDFromJWT(r)
+	if err != nil {
+		renderError(w, r, ErrorForbidden(err))
+		return
+	}
+
+	absence, err := rs.StudentAbsenceService.CreateAbsence(r.Context(), student.ID,
+		activeService.StudentAbsenceReporter{StaffID: staffID}, req.toServiceRequest())
+	if err != nil {
+		renderAbsenceServiceError(w, r, err)
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, mapStudentAbsenceToResponse(absence), "Student absence created successfully")
+}
+
+// updateStudentAbsence handles PUT /students/{id}/absences/{absenceId}
+func (rs *Resource) updateStudentAbsence(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupWriteAccess(w, r, "update absences")
+	if student == nil {
+		return
+	}
+
+	absenceID, ok := parseEntityID(w, r, "absenceId", "absence")
+	if !ok {
+		return
+	}
+
+	req := &StudentAbsenceRequest{}
+	if err := render.Bind(r, req); err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	absence, err := rs.StudentAbsenceService.UpdateAbsence(r.Context(), student.ID, absenceID, req.toServiceRequest())
+	if err != nil {
+		renderAbsenceServiceError(w, r, err)
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, mapStudentAbsenceToResponse(absence), "Student absence updated successfully")
+}
+
+// deleteStudentAbsence handles DELETE /students/{id}/absences/{absenceId}
+func (rs *Resource) deleteStudentAbsence(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupWriteAccess(w, r, "delete absences")
+	if student == nil {
+		return
+	}
+
+	absenceID, ok := parseEntityID(w, r, "absenceId", "absence")
+	if !ok {
+		return
+	}
+
+	if err := rs.StudentAbsenceService.DeleteAbsence(r.Context(), student.ID, absenceID); err != nil {
+		renderAbsenceServiceError(w, r, err)
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, nil, "Student absence deleted successfully")
+}
+
+// Handler accessor methods for testing
+
+// GetStudentAbsencesHandler returns the handler for listing student absences
+func (rs *Resource) GetStudentAbsencesHandler() http.HandlerFunc {
+	return rs.getStudentAbsences
+}
+
+// CreateStudentAbsenceHandler returns the handler for reporting student absences
+func (rs *Resource) CreateStudentAbsenceHandler() http.HandlerFunc {
+	return rs.createStudentAbsence
+}
+
+// UpdateStudentAbsenceHandler returns the handler for updating student absences
+func (rs *Resource) UpdateStudentAbsenceHandler() http.HandlerFunc {
+	return rs.updateStudentAbsence
+}
+
+// DeleteStudentAbsenceHandler returns the handler for deleting student absences
+func (rs *Resource) DeleteStudentAbsenceHandler() http.HandlerFunc {
+	return rs.deleteStudentAbsence
+}
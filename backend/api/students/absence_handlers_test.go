@@ -0,0 +1,98 @@
+package students
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	activeService "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentAbsenceRequest_Bind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	t.Run("valid request", func(t *testing.T) {
+		r := &StudentAbsenceRequest{AbsenceType: "trip", DateStart: "2026-03-02", DateEnd: "2026-03-04"}
+		require.NoError(t, r.Bind(req))
+	})
+
+	t.Run("missing date_end defaults to date_start", func(t *testing.T) {
+		r := &StudentAbsenceRequest{AbsenceType: "sick", DateStart: "2026-03-02"}
+		require.NoError(t, r.Bind(req))
+		assert.Equal(t, "2026-03-02", r.DateEnd)
+	})
+
+	t.Run("invalid absence type", func(t *testing.T) {
+		r := &StudentAbsenceRequest{AbsenceType: "holiday", DateStart: "2026-03-02"}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "absence_type must be one of")
+	})
+
+	t.Run("invalid date_start", func(t *testing.T) {
+		r := &StudentAbsenceRequest{AbsenceType: "sick", DateStart: "02.03.2026"}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid date_start format")
+	})
+
+	t.Run("invalid date_end", func(t *testing.T) {
+		r := &StudentAbsenceRequest{AbsenceType: "sick", DateStart: "2026-03-02", DateEnd: "tomorrow"}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid date_end format")
+	})
+}
+
+func TestMapStudentAbsenceToResponse(t *testing.T) {
+	guardianID := int64(70)
+	created := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	absence := &active.StudentAbsence{
+		Model:                base.Model{ID: 20, CreatedAt: created, UpdatedAt: created},
+		StudentID:            10,
+		AbsenceType:          active.StudentAbsenceTypeSick,
+		DateStart:            time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		DateEnd:              time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		GuardianNote:         "Fieber",
+		ReportedByGuardianID: &guardianID,
+	}
+
+	resp := mapStudentAbsenceToResponse(absence)
+
+	assert.Equal(t, int64(20), resp.ID)
+	assert.Equal(t, "2026-03-02", resp.DateStart)
+	assert.Equal(t, "2026-03-03", resp.DateEnd)
+	assert.Equal(t, 2, resp.DurationDays)
+	assert.Equal(t, "Fieber", resp.GuardianNote)
+	assert.Zero(t, resp.ReportedBy)
+	assert.Equal(t, &guardianID, resp.ReportedByGuardianID)
+	assert.Equal(t, "2026-03-01T18:00:00Z", resp.CreatedAt)
+}
+
+func TestRenderAbsenceServiceError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not found", activeService.ErrStudentAbsenceNotFound, http.StatusNotFound},
+		{"overlap", fmt.Errorf("%w with existing sick absence", activeService.ErrStudentAbsenceOverlap), http.StatusConflict},
+		{"invalid", fmt.Errorf("%w: invalid absence type", activeService.ErrInvalidStudentAbsence), http.StatusBadRequest},
+		{"database", errors.New("failed to create student absence: invalid input syntax"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			renderAbsenceServiceError(w, httptest.NewRequest(http.MethodPost, "/", nil), tt.err)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/moto-nrw/project-phoenix/api/common"
 	"github.com/moto-nrw/project-phoenix/auth/authorize"
 	"github.com/moto-nrw/project-phoenix/auth/authorize/permissions"
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policy"
 	"github.com/moto-nrw/project-phoenix/auth/device"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
 	"github.com/moto-nrw/project-phoenix/models/education"
@@ -44,6 +45,11 @@ type Resource struct {
 	IoTService            iotSvc.Service
 	PrivacyConsentRepo    users.PrivacyConsentRepository
 	PickupScheduleService scheduleService.PickupScheduleService
+	StudentAbsenceService activeService.StudentAbsenceService
+	MissingStudentService activeService.MissingStudentService
+	PickupBoardService    activeService.PickupBoardService
+	PickupAuthService     userService.PickupAuthorizationService
+	TimelineService       activeService.StudentTimelineService
 }
 
 // ResourceConfig holds all dependencies for creating a students Resource.
@@ -57,6 +63,11 @@ type ResourceConfig struct {
 	IoTService            iotSvc.Service
 	PrivacyConsentRepo    users.PrivacyConsentRepository
 	PickupScheduleService scheduleService.PickupScheduleService
+	StudentAbsenceService activeService.StudentAbsenceService
+	MissingStudentService activeService.MissingStudentService
+	PickupBoardService    activeService.PickupBoardService
+	PickupAuthService     userService.PickupAuthorizationService
+	TimelineService       activeService.StudentTimelineService
 }
 
 // NewResource creates a new students resource from the provided configuration.
@@ -70,6 +81,11 @@ func NewResource(cfg ResourceConfig) *Resource {
 		IoTService:            cfg.IoTService,
 		PrivacyConsentRepo:    cfg.PrivacyConsentRepo,
 		PickupScheduleService: cfg.PickupScheduleService,
+		StudentAbsenceService: cfg.StudentAbsenceService,
+		MissingStudentService: cfg.MissingStudentService,
+		PickupBoardService:    cfg.PickupBoardService,
+		PickupAuthService:     cfg.PickupAuthService,
+		TimelineService:       cfg.TimelineService,
 	}
 }
 
@@ -93,15 +109,22 @@ func (rs *Resource) Router() chi.Router {
 		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/current-location", rs.getStudentCurrentLocation)
 		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/current-visit", rs.getStudentCurrentVisit)
 		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/visit-history", rs.getStudentVisitHistory)
+		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/timeline", rs.getStudentTimeline)
 
 		// Routes requiring users:create permission
 		r.With(authorize.RequiresPermission(permissions.UsersCreate)).Post("/", rs.createStudent)
 
 		// Routes requiring users:update permission
-		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Put("/{id}", rs.updateStudent)
+		r.With(
+			authorize.RequiresPermission(permissions.UsersUpdate),
+			authorize.GetResourceAuthorizer().RequiresResourceAccess("student", policy.ActionEdit, authorize.StudentIDFromURL()),
+		).Put("/{id}", rs.updateStudent)
 
 		// Routes requiring users:delete permission
-		r.With(authorize.RequiresPermission(permissions.UsersDelete)).Delete("/{id}", rs.deleteStudent)
+		r.With(
+			authorize.RequiresPermission(permissions.UsersDelete),
+			authorize.GetResourceAuthorizer().RequiresResourceAccess("student", policy.ActionDelete, authorize.StudentIDFromURL()),
+		).Delete("/{id}", rs.deleteStudent)
 
 		// Privacy consent routes
 		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/privacy-consent", rs.getStudentPrivacyConsent)
@@ -119,6 +142,26 @@ func (rs *Resource) Router() chi.Router {
 		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Put("/{id}/pickup-notes/{noteId}", rs.updateStudentPickupNote)
 		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Delete("/{id}/pickup-notes/{noteId}", rs.deleteStudentPickupNote)
 
+		// Authorized pickup persons (guardians plus named extra persons; full access required for writes - checked in handlers)
+		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/authorized-pickups", rs.getStudentAuthorizedPickups)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Post("/{id}/pickup-persons", rs.createStudentPickupPerson)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Put("/{id}/pickup-persons/{personId}", rs.updateStudentPickupPerson)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Delete("/{id}/pickup-persons/{personId}", rs.deleteStudentPickupPerson)
+
+		// Absence routes (sick notes, trips, vacations; full access required - checked in handlers)
+		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/absences", rs.getStudentAbsences)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Post("/{id}/absences", rs.createStudentAbsence)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Put("/{id}/absences/{absenceId}", rs.updateStudentAbsence)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Delete("/{id}/absences/{absenceId}", rs.deleteStudentAbsence)
+
+		// Missing student alerts (expected but not checked in)
+		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/missing-alerts", rs.listMissingAlerts)
+		r.With(authorize.RequiresPermission(permissions.UsersUpdate)).Post("/missing-alerts/{alertId}/resolve", rs.resolveMissingAlert)
+		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/{id}/missing-alerts", rs.getStudentMissingAlerts)
+
+		// Pickup dispatch board
+		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/pickup-board", rs.getPickupBoard)
+
 		// Bulk pickup times endpoint (returns pickup times for multiple students)
 		r.With(authorize.RequiresPermission(permissions.UsersRead)).Post("/pickup-times/bulk", rs.getBulkPickupTimes)
 	})
@@ -183,29 +226,15 @@ func (rs *Resource) getStudentGroup(ctx context.Context, student *users.Student)
 }
 
 // checkStudentFullAccess determines if the current user has full access to a student's data
-// Returns true if user is admin or supervises the student's group
+// Delegates to the student resource policy: admins, group teachers, substitutes and
+// supervisors of the student's current session have full access
 func (rs *Resource) checkStudentFullAccess(r *http.Request, student *users.Student) bool {
-	userPermissions := jwt.PermissionsFromCtx(r.Context())
-	if hasAdminPermissions(userPermissions) {
-		return true
-	}
-
-	if student.GroupID == nil {
-		return false
-	}
-
-	educationGroups, err := rs.UserContextService.GetMyGroups(r.Context())
-	if err != nil {
-		return false
-	}
-
-	for _, group := range educationGroups {
-		if group.ID == *student.GroupID {
-			return true
-		}
-	}
-
-	return false
+	return authorize.GetResourceAuthorizer().HasResourceAccess(
+		r,
+		policy.Resource{Type: "student", ID: student.ID},
+		policy.ActionView,
+		map[string]interface{}{"student_id": student.ID},
+	)
 }
 
 // buildSupervisorContacts creates supervisor contact list from group teachers
@@ -313,7 +342,7 @@ func (rs *Resource) buildStudentResponses(ctx context.Context, students []*users
 
 // buildSingleStudentResponse builds a response for a single student, returning nil if filtered out
 func (rs *Resource) buildSingleStudentResponse(ctx context.Context, student *users.Student, params *studentListParams, accessCtx *studentAccessContext, dataSnapshot *common.StudentDataSnapshot) *StudentResponse {
-	hasFullAccess := accessCtx.hasFullAccessToStudent(student)
+	hasFullAccess := accessCtx.hasFullAccessToStudent(student, dataSnapshot.GetCurrentVisit(student.ID))
 
 	// Get person data from snapshot
 	person := dataSnapshot.GetPerson(student.PersonID)
@@ -693,6 +722,7 @@ func (rs *Resource) updateStudent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update student fields using helper function
+	wasSick := student.Sick != nil && *student.Sick
 	applyStudentFieldUpdates(req, student)
 
 	// Update student
@@ -701,6 +731,11 @@ func (rs *Resource) updateStudent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Notify group teachers when the sick flag changed
+	if req.Sick != nil && *req.Sick != wasSick {
+		rs.PersonService.BroadcastStudentSickness(r.Context(), student)
+	}
+
 	// Get updated student with person data
 	updatedStudent, err := rs.StudentRepo.FindByID(r.Context(), student.ID)
 	if err != nil {
@@ -38,3 +38,8 @@ func ErrorUnauthorized(err error) render.Renderer {
 func ErrorForbidden(err error) render.Renderer {
 	return common.ErrorForbidden(err)
 }
+
+// ErrorConflict returns a 409 Conflict error response
+func ErrorConflict(err error) render.Renderer {
+	return common.ErrorConflict(err)
+}
@@ -2,10 +2,12 @@ package students
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/moto-nrw/project-phoenix/api/common"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/models/active"
 	"github.com/moto-nrw/project-phoenix/models/base"
 	"github.com/moto-nrw/project-phoenix/models/users"
 )
@@ -23,10 +25,12 @@ type studentListParams struct {
 	pageSize     int
 }
 
-// studentAccessContext holds access control information for student listing
+// studentAccessContext holds access control information for student listing.
+// It mirrors the StudentPolicy rules for a whole list without per-student queries.
 type studentAccessContext struct {
-	isAdmin    bool
-	myGroupIDs map[int64]struct{}
+	isAdmin            bool
+	myGroupIDs         map[int64]struct{} // OGS groups the user teaches or currently substitutes for
+	supervisedGroupIDs map[int64]struct{} // Active groups (sessions) the user currently supervises
 }
 
 // parseStudentListParams extracts query parameters from the request
@@ -101,30 +105,45 @@ func (p *studentListParams) buildCountOptions() *base.QueryOptions {
 // determineStudentAccess determines access level and group IDs for the current user
 func (rs *Resource) determineStudentAccess(r *http.Request) *studentAccessContext {
 	ctx := &studentAccessContext{
-		isAdmin: hasAdminPermissions(jwt.PermissionsFromCtx(r.Context())),
+		isAdmin: hasAdminPermissions(jwt.PermissionsFromCtx(r.Context())) ||
+			slices.Contains(jwt.ClaimsFromCtx(r.Context()).Roles, "admin"),
 	}
 
 	if !ctx.isAdmin {
 		if staff, err := rs.UserContextService.GetCurrentStaff(r.Context()); err == nil && staff != nil {
+			// GetMyGroups includes the groups the staff member substitutes for today
 			if educationGroups, err := rs.UserContextService.GetMyGroups(r.Context()); err == nil {
 				ctx.myGroupIDs = make(map[int64]struct{}, len(educationGroups))
 				for _, eduGroup := range educationGroups {
 					ctx.myGroupIDs[eduGroup.ID] = struct{}{}
 				}
 			}
+			if activeGroups, err := rs.UserContextService.GetMySupervisedGroups(r.Context()); err == nil {
+				ctx.supervisedGroupIDs = make(map[int64]struct{}, len(activeGroups))
+				for _, activeGroup := range activeGroups {
+					ctx.supervisedGroupIDs[activeGroup.ID] = struct{}{}
+				}
+			}
 		}
 	}
 
 	return ctx
 }
 
-// hasFullAccessToStudent checks if user has full access to a specific student
-func (ac *studentAccessContext) hasFullAccessToStudent(student *users.Student) bool {
+// hasFullAccessToStudent checks if user may view a student's sensitive data: admins, teachers and
+// substitutes of the student's OGS group, and supervisors of the session the student is currently in.
+// currentVisit is the student's open visit, nil when not checked in.
+func (ac *studentAccessContext) hasFullAccessToStudent(student *users.Student, currentVisit *active.Visit) bool {
 	if ac.isAdmin {
 		return true
 	}
-	if student.GroupID != nil && ac.myGroupIDs != nil {
-		_, ok := ac.myGroupIDs[*student.GroupID]
+	if student.GroupID != nil {
+		if _, ok := ac.myGroupIDs[*student.GroupID]; ok {
+			return true
+		}
+	}
+	if currentVisit != nil {
+		_, ok := ac.supervisedGroupIDs[currentVisit.ActiveGroupID]
 		return ok
 	}
 	return false
@@ -0,0 +1,36 @@
+package students
+
+import (
+	"testing"
+
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStudentAccessContext_HasFullAccessToStudent(t *testing.T) {
+	ownGroup, otherGroup := int64(10), int64(11)
+	inOwnGroup := &users.Student{Model: base.Model{ID: 20}, GroupID: &ownGroup}
+	inOtherGroup := &users.Student{Model: base.Model{ID: 21}, GroupID: &otherGroup}
+	withoutGroup := &users.Student{Model: base.Model{ID: 22}}
+	supervisedVisit := &active.Visit{StudentID: 21, ActiveGroupID: 30}
+	otherVisit := &active.Visit{StudentID: 21, ActiveGroupID: 31}
+
+	staff := &studentAccessContext{
+		myGroupIDs:         map[int64]struct{}{ownGroup: {}},
+		supervisedGroupIDs: map[int64]struct{}{30: {}},
+	}
+
+	assert.True(t, staff.hasFullAccessToStudent(inOwnGroup, nil), "group teacher or substitute")
+	assert.True(t, staff.hasFullAccessToStudent(inOtherGroup, supervisedVisit), "supervisor of the current session")
+	assert.False(t, staff.hasFullAccessToStudent(inOtherGroup, otherVisit))
+	assert.False(t, staff.hasFullAccessToStudent(inOtherGroup, nil))
+	assert.False(t, staff.hasFullAccessToStudent(withoutGroup, nil))
+
+	admin := &studentAccessContext{isAdmin: true}
+	assert.True(t, admin.hasFullAccessToStudent(withoutGroup, nil))
+
+	// Users without a staff record have no group maps
+	assert.False(t, (&studentAccessContext{}).hasFullAccessToStudent(inOwnGroup, supervisedVisit))
+}
@@ -0,0 +1,195 @@
+package students
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/models/active"
+)
+
+// MissingAlertResolveRequest represents a request to resolve a missing student alert
+type MissingAlertResolveRequest struct {
+	Resolution string `json:"resolution"` // found, sick, went_home
+	Note       string `json:"note,omitempty"`
+}
+
+// Bind implements render.Binder
+func (r *MissingAlertResolveRequest) Bind(_ *http.Request) error {
+	if !slices.Contains(active.ValidMissingAlertResolutions, r.Resolution) {
+		return errors.New("resolution must be one of: found, sick, went_home")
+	}
+	if len(r.Note) > 500 {
+		return errors.New("note cannot exceed 500 characters")
+	}
+	return nil
+}
+
+// MissingAlertResponse represents a missing student alert in API responses
+type MissingAlertResponse struct {
+	ID             int64   `json:"id"`
+	StudentID      int64   `json:"student_id"`
+	StudentName    string  `json:"student_name,omitempty"`
+	SchoolClass    string  `json:"school_class,omitempty"`
+	GroupID        *int64  `json:"group_id,omitempty"`
+	AlertDate      string  `json:"alert_date"`  // YYYY-MM-DD format
+	ExpectedBy     string  `json:"expected_by"` // RFC3339
+	Status         string  `json:"status"`
+	Resolution     string  `json:"resolution,omitempty"`
+	ResolutionNote string  `json:"resolution_note,omitempty"`
+	ResolvedBy     *int64  `json:"resolved_by,omitempty"`
+	ResolvedAt     *string `json:"resolved_at,omitempty"` // RFC3339
+	CreatedAt      string  `json:"created_at"`
+}
+
+// mapMissingAlertToResponse converts a missing student alert model to API response
+func mapMissingAlertToResponse(a *active.MissingStudentAlert) MissingAlertResponse {
+	resp := MissingAlertResponse{
+		ID:             a.ID,
+		StudentID:      a.StudentID,
+		GroupID:        a.GroupID,
+		AlertDate:      a.AlertDate.Format(dateFormatISO),
+		ExpectedBy:     a.ExpectedBy.Format(time.RFC3339),
+		Status:         a.Status,
+		Resolution:     a.Resolution,
+		ResolutionNote: a.ResolutionNote,
+		ResolvedBy:     a.ResolvedBy,
+		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
+	}
+	if a.ResolvedAt != nil {
+		resolvedAt := a.ResolvedAt.Format(time.RFC3339)
+		resp.ResolvedAt = &resolvedAt
+	}
+	if a.Student != nil {
+		resp.SchoolClass = a.Student.SchoolClass
+		if a.Student.Person != nil {
+			resp.StudentName = fmt.Sprintf("%s %s", a.Student.Person.FirstName, a.Student.Person.LastName)
+		}
+	}
+	return resp
+}
+
+// renderMissingAlertServiceError maps missing student service errors to HTTP errors
+func renderMissingAlertServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err.Error() {
+	case "missing student alert not found":
+		renderError(w, r, ErrorNotFound(err))
+	case "alert is already resolved", "invalid resolution", "resolved_by is required":
+		renderError(w, r, ErrorInvalidRequest(err))
+	default:
+		renderError(w, r, ErrorInternalServer(err))
+	}
+}
+
+// listMissingAlerts handles GET /students/missing-alerts
+// Query parameters: date (YYYY-MM-DD, default today), group_id (optional OGS group filter)
+func (rs *Resource) listMissingAlerts(w http.ResponseWriter, r *http.Request) {
+	date := timezone.Today()
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := time.ParseInLocation(dateFormatISO, dateStr, timezone.Berlin)
+		if err != nil {
+			renderError(w, r, ErrorInvalidRequest(errors.New("invalid date format, expected YYYY-MM-DD")))
+			return
+		}
+		date = parsed
+	}
+
+	var groupID int64
+	if groupStr := r.URL.Query().Get("group_id"); groupStr != "" {
+		parsed, err := strconv.ParseInt(groupStr, 10, 64)
+		if err != nil {
+			renderError(w, r, ErrorInvalidRequest(errors.New("invalid group ID")))
+			return
+		}
+		groupID = parsed
+	}
+
+	alerts, err := rs.MissingStudentService.ListAlerts(r.Context(), date)
+	if err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	responses := make([]MissingAlertResponse, 0, len(alerts))
+	for _, a := range alerts {
+		if groupID > 0 && (a.GroupID == nil || *a.GroupID != groupID) {
+			continue
+		}
+		responses = append(responses, mapMissingAlertToResponse(a))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Missing student alerts retrieved successfully")
+}
+
+// resolveMissingAlert handles POST /students/missing-alerts/{alertId}/resolve
+func (rs *Resource) resolveMissingAlert(w http.ResponseWriter, r *http.Request) {
+	alertID, ok := parseEntityID(w, r, "alertId", "alert")
+	if !ok {
+		return
+	}
+
+	req := &MissingAlertResolveRequest{}
+	if err := render.Bind(r, req); err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	staffID, err := rs.getStaffIDFromJWT(r)
+	if err != nil {
+		renderError(w, r, ErrorForbidden(err))
+		return
+	}
+
+	alert, err := rs.MissingStudentService.ResolveAlert(r.Context(), alertID, staffID, req.Resolution, req.Note)
+	if err != nil {
+		renderMissingAlertServiceError(w, r, err)
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, mapMissingAlertToResponse(alert), "Missing student alert resolved successfully")
+}
+
+// getStudentMissingAlerts handles GET /students/{id}/missing-alerts
+// Returns the student's missing alert history with resolutions, most recent first
+func (rs *Resource) getStudentMissingAlerts(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupReadAccess(w, r)
+	if student == nil {
+		return
+	}
+
+	alerts, err := rs.MissingStudentService.ListStudentAlerts(r.Context(), student.ID)
+	if err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	responses := make([]MissingAlertResponse, 0, len(alerts))
+	for _, a := range alerts {
+		responses = append(responses, mapMissingAlertToResponse(a))
+	}
+
+	common.Respond(w, r, http.StatusOK, responses, "Student missing alerts retrieved successfully")
+}
+
+// Handler accessor methods for testing
+
+// ListMissingAlertsHandler returns the handler for listing missing student alerts
+func (rs *Resource) ListMissingAlertsHandler() http.HandlerFunc {
+	return rs.listMissingAlerts
+}
+
+// ResolveMissingAlertHandler returns the handler for resolving missing student alerts
+func (rs *Resource) ResolveMissingAlertHandler() http.HandlerFunc {
+	return rs.resolveMissingAlert
+}
+
+// GetStudentMissingAlertsHandler returns the handler for a student's missing alert history
+func (rs *Resource) GetStudentMissingAlertsHandler() http.HandlerFunc {
+	return rs.getStudentMissingAlerts
+}
@@ -0,0 +1,64 @@
+package students
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingAlertResolveRequest_Bind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	t.Run("valid request", func(t *testing.T) {
+		r := &MissingAlertResolveRequest{Resolution: "went_home", Note: "Abgeholt von der Oma"}
+		require.NoError(t, r.Bind(req))
+	})
+
+	t.Run("invalid resolution", func(t *testing.T) {
+		r := &MissingAlertResolveRequest{Resolution: "unknown"}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resolution must be one of")
+	})
+}
+
+func TestMapMissingAlertToResponse(t *testing.T) {
+	groupID := int64(40)
+	staffID := int64(50)
+	created := time.Date(2026, 3, 4, 12, 35, 0, 0, time.UTC)
+	resolved := time.Date(2026, 3, 4, 12, 50, 0, 0, time.UTC)
+	alert := &active.MissingStudentAlert{
+		Model:          base.Model{ID: 30, CreatedAt: created},
+		StudentID:      10,
+		GroupID:        &groupID,
+		AlertDate:      time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		ExpectedBy:     time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC),
+		Status:         active.MissingAlertStatusResolved,
+		Resolution:     active.MissingAlertResolutionFound,
+		ResolutionNote: "War auf dem Schulhof",
+		ResolvedBy:     &staffID,
+		ResolvedAt:     &resolved,
+		Student: &users.Student{
+			SchoolClass: "3b",
+			Person:      &users.Person{FirstName: "Mia", LastName: "Schmidt"},
+		},
+	}
+
+	resp := mapMissingAlertToResponse(alert)
+
+	assert.Equal(t, int64(30), resp.ID)
+	assert.Equal(t, "Mia Schmidt", resp.StudentName)
+	assert.Equal(t, "3b", resp.SchoolClass)
+	assert.Equal(t, "2026-03-04", resp.AlertDate)
+	assert.Equal(t, "2026-03-04T12:30:00Z", resp.ExpectedBy)
+	assert.Equal(t, "found", resp.Resolution)
+	require.NotNil(t, resp.ResolvedAt)
+	assert.Equal(t, "2026-03-04T12:50:00Z", *resp.ResolvedAt)
+}
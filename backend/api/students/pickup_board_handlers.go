@@ -0,0 +1,53 @@
+package students
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/api/common"
+)
+
+// Pickup board window limits in minutes
+const (
+	defaultPickupBoardMinutes = 30
+	maxPickupBoardMinutes     = 240
+)
+
+// parsePickupBoardWindow parses the minutes query parameter of the pickup board
+func parsePickupBoardWindow(r *http.Request) (time.Duration, error) {
+	minutes := defaultPickupBoardMinutes
+	if minutesStr := r.URL.Query().Get("minutes"); minutesStr != "" {
+		parsed, err := strconv.Atoi(minutesStr)
+		if err != nil || parsed < 1 || parsed > maxPickupBoardMinutes {
+			return 0, errors.New("minutes must be a number between 1 and 240")
+		}
+		minutes = parsed
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// getPickupBoard handles GET /students/pickup-board
+// Query parameters: minutes (look-ahead window, default 30, max 240)
+// Returns checked-in students with upcoming, due ("leave now") and overdue pickups, grouped by current room
+func (rs *Resource) getPickupBoard(w http.ResponseWriter, r *http.Request) {
+	window, err := parsePickupBoardWindow(r)
+	if err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	board, err := rs.PickupBoardService.GetPickupBoard(r.Context(), time.Now(), window)
+	if err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, board, "Pickup board retrieved successfully")
+}
+
+// GetPickupBoardHandler returns the handler for the pickup dispatch board
+func (rs *Resource) GetPickupBoardHandler() http.HandlerFunc {
+	return rs.getPickupBoard
+}
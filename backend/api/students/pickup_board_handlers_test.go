@@ -0,0 +1,35 @@
+package students
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePickupBoardWindow(t *testing.T) {
+	t.Run("default window", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/students/pickup-board", nil)
+		window, err := parsePickupBoardWindow(req)
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, window)
+	})
+
+	t.Run("custom window", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/students/pickup-board?minutes=90", nil)
+		window, err := parsePickupBoardWindow(req)
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, window)
+	})
+
+	for _, minutes := range []string{"0", "241", "abc"} {
+		t.Run("invalid "+minutes, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/students/pickup-board?minutes="+minutes, nil)
+			_, err := parsePickupBoardWindow(req)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package students
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	userService "github.com/moto-nrw/project-phoenix/services/users"
+)
+
+// PickupPersonRequest represents a request to add or update an extra pickup person
+type PickupPersonRequest struct {
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	Relationship string  `json:"relationship,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+	IsAuthorized *bool   `json:"is_authorized,omitempty"` // Defaults to true; false flags a person who must not collect the student
+	ValidFrom    *string `json:"valid_from,omitempty"`    // YYYY-MM-DD format
+	ValidUntil   *string `json:"valid_until,omitempty"`   // YYYY-MM-DD format
+	Notes        *string `json:"notes,omitempty"`
+
+	validFrom  *time.Time
+	validUntil *time.Time
+}
+
+// Bind implements render.Binder
+func (req *PickupPersonRequest) Bind(_ *http.Request) error {
+	if req.FirstName == "" || req.LastName == "" {
+		return errors.New("first_name and last_name are required")
+	}
+
+	var err error
+	if req.validFrom, err = parseOptionalDate(req.ValidFrom, "valid_from"); err != nil {
+		return err
+	}
+	if req.validUntil, err = parseOptionalDate(req.ValidUntil, "valid_until"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseOptionalDate parses an optional YYYY-MM-DD date (stored as DATE)
+func parseOptionalDate(value *string, field string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(dateFormatISO, *value)
+	if err != nil {
+		return nil, errors.New("invalid " + field + " format, expected YYYY-MM-DD")
+	}
+	return &parsed, nil
+}
+
+// applyTo copies the request fields onto the pickup person
+func (req *PickupPersonRequest) applyTo(person *users.StudentPickupPerson) {
+	person.FirstName = req.FirstName
+	person.LastName = req.LastName
+	person.Relationship = req.Relationship
+	person.Phone = req.Phone
+	person.IsAuthorized = req.IsAuthorized == nil || *req.IsAuthorized
+	person.ValidFrom = req.validFrom
+	person.ValidUntil = req.validUntil
+	person.Notes = req.Notes
+}
+
+// PickupPersonResponse represents an extra pickup person in API responses
+type PickupPersonResponse struct {
+	ID           int64   `json:"id"`
+	StudentID    int64   `json:"student_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	Relationship string  `json:"relationship,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+	IsAuthorized bool    `json:"is_authorized"`
+	ValidFrom    *string `json:"valid_from,omitempty"`  // YYYY-MM-DD format
+	ValidUntil   *string `json:"valid_until,omitempty"` // YYYY-MM-DD format
+	Notes        *string `json:"notes,omitempty"`
+	CreatedBy    int64   `json:"created_by"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+// mapPickupPersonToResponse converts a pickup person model to API response
+func mapPickupPersonToResponse(p *users.StudentPickupPerson) PickupPersonResponse {
+	resp := PickupPersonResponse{
+		ID:           p.ID,
+		StudentID:    p.StudentID,
+		FirstName:    p.FirstName,
+		LastName:     p.LastName,
+		Relationship: p.Relationship,
+		Phone:        p.Phone,
+		IsAuthorized: p.IsAuthorized,
+		Notes:        p.Notes,
+		CreatedBy:    p.CreatedBy,
+		CreatedAt:    p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    p.UpdatedAt.Format(time.RFC3339),
+	}
+	if p.ValidFrom != nil {
+		from := p.ValidFrom.Format(dateFormatISO)
+		resp.ValidFrom = &from
+	}
+	if p.ValidUntil != nil {
+		until := p.ValidUntil.Format(dateFormatISO)
+		resp.ValidUntil = &until
+	}
+	return resp
+}
+
+// getStudentAuthorizedPickups handles GET /students/{id}/authorized-pickups
+// Returns guardians and extra pickup persons with their authorization state for today
+func (rs *Resource) getStudentAuthorizedPickups(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupReadAccess(w, r)
+	if student == nil {
+		return
+	}
+
+	pickups, err := rs.PickupAuthService.GetAuthorizedPickups(r.Context(), student.ID, timezone.Today())
+	if err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, pickups, "Authorized pickups retrieved successfully")
+}
+
+// createStudentPickupPerson handles POST /students/{id}/pickup-persons
+func (rs *Resource) createStudentPickupPerson(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupWriteAccess(w, r, "add pickup persons")
+	if student == nil {
+		return
+	}
+
+	req := &PickupPersonRequest{}
+	if err := render.Bind(r, req); err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	staffID, err := rs.getStaffIDFromJWT(r)
+	if err != nil {
+		renderError(w, r, ErrorForbidden(err))
+		return
+	}
+
+	person := &users.StudentPickupPerson{StudentID: student.ID, CreatedBy: staffID}
+	req.applyTo(person)
+
+	if err := rs.PickupAuthService.CreatePickupPerson(r.Context(), person); err != nil {
+		renderError(w, r, pickupPersonWriteError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, mapPickupPersonToResponse(person), "Pickup person created successfully")
+}
+
+// updateStudentPickupPerson handles PUT /students/{id}/pickup-persons/{personId}
+func (rs *Resource) updateStudentPickupPerson(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupWriteAccess(w, r, "update pickup persons")
+	if student == nil {
+		return
+	}
+
+	person := rs.requireStudentPickupPerson(w, r, student.ID)
+	if person == nil {
+		return
+	}
+
+	req := &PickupPersonRequest{}
+	if err := render.Bind(r, req); err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+	req.applyTo(person)
+
+	if err := rs.PickupAuthService.UpdatePickupPerson(r.Context(), person); err != nil {
+		renderError(w, r, pickupPersonWriteError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, mapPickupPersonToResponse(person), "Pickup person updated successfully")
+}
+
+// deleteStudentPickupPerson handles DELETE /students/{id}/pickup-persons/{personId}
+func (rs *Resource) deleteStudentPickupPerson(w http.ResponseWriter, r *http.Request) {
+	student := rs.requirePickupWriteAccess(w, r, "delete pickup persons")
+	if student == nil {
+		return
+	}
+
+	person := rs.requireStudentPickupPerson(w, r, student.ID)
+	if person == nil {
+		return
+	}
+
+	if err := rs.PickupAuthService.DeletePickupPerson(r.Context(), person.ID); err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, nil, "Pickup person deleted successfully")
+}
+
+// pickupPersonWriteError maps invalid pickup person data to 400 and all other failures to 500
+func pickupPersonWriteError(err error) render.Renderer {
+	if errors.Is(err, userService.ErrInvalidPersonData) {
+		return ErrorInvalidRequest(err)
+	}
+	return ErrorInternalServer(err)
+}
+
+// requireStudentPickupPerson loads the pickup person from the URL and checks it belongs to the student.
+// Returns nil after rendering an error response.
+func (rs *Resource) requireStudentPickupPerson(w http.ResponseWriter, r *http.Request, studentID int64) *users.StudentPickupPerson {
+	personID, ok := parseEntityID(w, r, "personId", "pickup person")
+	if !ok {
+		return nil
+	}
+
+	person, err := rs.PickupAuthService.GetPickupPerson(r.Context(), personID)
+	if err != nil || person.StudentID != studentID {
+		renderError(w, r, ErrorNotFound(userService.ErrPickupPersonNotFound))
+		return nil
+	}
+	return person
+}
+
+// Handler accessor methods for testing
+
+// GetStudentAuthorizedPickupsHandler returns the handler for a student's pickup list
+func (rs *Resource) GetStudentAuthorizedPickupsHandler() http.HandlerFunc {
+	return rs.getStudentAuthorizedPickups
+}
+
+// CreateStudentPickupPersonHandler returns the handler for adding a pickup person
+func (rs *Resource) CreateStudentPickupPersonHandler() http.HandlerFunc {
+	return rs.createStudentPickupPerson
+}
+
+// UpdateStudentPickupPersonHandler returns the handler for updating a pickup person
+func (rs *Resource) UpdateStudentPickupPersonHandler() http.HandlerFunc {
+	return rs.updateStudentPickupPerson
+}
+
+// DeleteStudentPickupPersonHandler returns the handler for removing a pickup person
+func (rs *Resource) DeleteStudentPickupPersonHandler() http.HandlerFunc {
+	return rs.deleteStudentPickupPerson
+}
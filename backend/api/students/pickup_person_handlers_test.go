@@ -0,0 +1,63 @@
+package students
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	userService "github.com/moto-nrw/project-phoenix/services/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickupPersonRequest_Bind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	t.Run("valid request defaults to authorized", func(t *testing.T) {
+		from := "2026-03-02"
+		r := &PickupPersonRequest{FirstName: "Erika", LastName: "Zimmer", Relationship: "Oma", ValidFrom: &from}
+		require.NoError(t, r.Bind(req))
+
+		person := &users.StudentPickupPerson{}
+		r.applyTo(person)
+		assert.True(t, person.IsAuthorized)
+		require.NotNil(t, person.ValidFrom)
+		assert.Equal(t, "2026-03-02", person.ValidFrom.Format(dateFormatISO))
+		assert.Nil(t, person.ValidUntil)
+	})
+
+	t.Run("flagged not authorized", func(t *testing.T) {
+		notAuthorized := false
+		r := &PickupPersonRequest{FirstName: "Klaus", LastName: "Adler", IsAuthorized: &notAuthorized}
+		require.NoError(t, r.Bind(req))
+
+		person := &users.StudentPickupPerson{}
+		r.applyTo(person)
+		assert.False(t, person.IsAuthorized)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		r := &PickupPersonRequest{FirstName: "Erika"}
+		require.Error(t, r.Bind(req))
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		until := "31.07.2026"
+		r := &PickupPersonRequest{FirstName: "Erika", LastName: "Zimmer", ValidUntil: &until}
+		err := r.Bind(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "valid_until")
+	})
+}
+
+func TestPickupPersonWriteError(t *testing.T) {
+	invalid := &userService.UsersError{Op: "update pickup person", Err: fmt.Errorf("%w: first and last name are required", userService.ErrInvalidPersonData)}
+	failed := &userService.UsersError{Op: "update pickup person", Err: errors.New("connection refused")}
+
+	assert.Equal(t, http.StatusBadRequest, pickupPersonWriteError(invalid).(*common.ErrResponse).HTTPStatusCode)
+	assert.Equal(t, http.StatusInternalServerError, pickupPersonWriteError(failed).(*common.ErrResponse).HTTPStatusCode)
+}
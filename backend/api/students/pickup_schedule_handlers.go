@@ -48,6 +48,9 @@ type PickupExceptionResponse struct {
 	CreatedBy     int64   `json:"created_by"`
 	CreatedAt     string  `json:"created_at"`
 	UpdatedAt     string  `json:"updated_at"`
+
+	// CreatedByGuardianID is set for exceptions entered through the parent portal
+	CreatedByGuardianID *int64 `json:"created_by_guardian_id,omitempty"`
 }
 
 // PickupNoteResponse represents a pickup note in API responses
@@ -200,6 +203,8 @@ func mapExceptionToResponse(e *schedule.StudentPickupException) PickupExceptionR
 		CreatedBy:     e.CreatedBy,
 		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     e.UpdatedAt.Format(time.RFC3339),
+
+		CreatedByGuardianID: e.CreatedByGuardianID,
 	}
 	if e.PickupTime != nil {
 		formatted := e.PickupTime.Format("15:04")
@@ -470,6 +475,8 @@ func (rs *Resource) updateStudentPickupException(w http.ResponseWriter, r *http.
 		ExceptionDate: exceptionDate,
 		Reason:        existingException.Reason, // Preserve existing reason by default
 		CreatedBy:     existingException.CreatedBy,
+
+		CreatedByGuardianID: existingException.CreatedByGuardianID,
 	}
 	exception.ID = exceptionID
 	exception.CreatedAt = existingException.CreatedAt // Preserve original creation timestamp
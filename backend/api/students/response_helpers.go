@@ -63,25 +63,12 @@ func populatePersonAndGuardianData(response *StudentResponse, person *users.Pers
 
 // populatePublicStudentFields sets fields visible to all authenticated staff
 func populatePublicStudentFields(response *StudentResponse, student *users.Student) {
-	if student.HealthInfo != nil {
-		response.HealthInfo = *student.HealthInfo
-	}
 	if student.Bus != nil {
 		response.Bus = *student.Bus
 	}
 	if student.PickupStatus != nil {
 		response.PickupStatus = *student.PickupStatus
 	}
-}
-
-// populateSensitiveStudentFields sets fields visible only to supervisors/admins
-func populateSensitiveStudentFields(response *StudentResponse, student *users.Student) {
-	if student.ExtraInfo != nil && *student.ExtraInfo != "" {
-		response.ExtraInfo = *student.ExtraInfo
-	}
-	if student.SupervisorNotes != nil {
-		response.SupervisorNotes = *student.SupervisorNotes
-	}
 	if student.Sick != nil {
 		response.Sick = *student.Sick
 	}
@@ -90,9 +77,9 @@ func populateSensitiveStudentFields(response *StudentResponse, student *users.St
 	}
 }
 
-// populateSnapshotSensitiveFields sets sensitive fields for the snapshot version
-// Note: This differs from populateSensitiveStudentFields by including HealthInfo
-func populateSnapshotSensitiveFields(response *StudentResponse, student *users.Student) {
+// populateSensitiveStudentFields sets fields visible only to staff granted by the student policy
+// (group teachers, substitutes, supervisors of the student's current session) and admins
+func populateSensitiveStudentFields(response *StudentResponse, student *users.Student) {
 	if student.ExtraInfo != nil && *student.ExtraInfo != "" {
 		response.ExtraInfo = *student.ExtraInfo
 	}
@@ -102,22 +89,6 @@ func populateSnapshotSensitiveFields(response *StudentResponse, student *users.S
 	if student.SupervisorNotes != nil {
 		response.SupervisorNotes = *student.SupervisorNotes
 	}
-	if student.Sick != nil {
-		response.Sick = *student.Sick
-	}
-	if student.SickSince != nil {
-		response.SickSince = student.SickSince
-	}
-}
-
-// populateSnapshotPublicFields sets fields visible to all staff in snapshot version
-func populateSnapshotPublicFields(response *StudentResponse, student *users.Student) {
-	if student.Bus != nil {
-		response.Bus = *student.Bus
-	}
-	if student.PickupStatus != nil {
-		response.PickupStatus = *student.PickupStatus
-	}
 }
 
 // presentOrTransit returns the appropriate location for a checked-in student
@@ -208,8 +179,10 @@ func newStudentResponseWithOpts(ctx context.Context, opts StudentResponseOpts, s
 	populatePersonAndGuardianData(&response, person, student, group, hasFullAccess)
 	populatePublicStudentFields(&response, student)
 
-	// Sensitive student fields (notes, sickness) are now visible to all authenticated staff
-	populateSensitiveStudentFields(&response, student)
+	// Health info and notes are only visible with full access (see policies.StudentPolicy)
+	if hasFullAccess {
+		populateSensitiveStudentFields(&response, student)
+	}
 
 	return response
 }
@@ -239,10 +212,12 @@ func newStudentResponseFromSnapshot(_ context.Context, student *users.Student, p
 	response.LocationSince = locationInfo.Since
 
 	populatePersonAndGuardianData(&response, person, student, group, hasFullAccess)
-	populateSnapshotPublicFields(&response, student)
+	populatePublicStudentFields(&response, student)
 
-	// Sensitive student fields (notes, sickness) are now visible to all authenticated staff
-	populateSnapshotSensitiveFields(&response, student)
+	// Health info and notes are only visible with full access (see policies.StudentPolicy)
+	if hasFullAccess {
+		populateSensitiveStudentFields(&response, student)
+	}
 
 	return response
 }
@@ -46,6 +46,11 @@ func setupTestContext(t *testing.T) *testContext {
 		IoTService:            svc.IoT,
 		PrivacyConsentRepo:    repoFactory.PrivacyConsent,
 		PickupScheduleService: svc.PickupSchedule,
+		StudentAbsenceService: svc.StudentAbsence,
+		MissingStudentService: svc.MissingStudents,
+		PickupBoardService:    svc.PickupBoard,
+		PickupAuthService:     svc.PickupAuthorization,
+		TimelineService:       svc.StudentTimeline,
 	})
 
 	t.Cleanup(func() {
@@ -0,0 +1,59 @@
+package students
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+)
+
+// parseTimelineDate parses the date query parameter of the student timeline (default today)
+func parseTimelineDate(r *http.Request) (time.Time, error) {
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		return timezone.Today(), nil
+	}
+	date, err := time.ParseInLocation(dateFormatISO, dateStr, timezone.Berlin)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date format, expected YYYY-MM-DD")
+	}
+	if date.After(timezone.Today()) {
+		return time.Time{}, errors.New("date cannot be in the future")
+	}
+	return date, nil
+}
+
+// getStudentTimeline handles GET /students/{id}/timeline
+// Query parameters: date (YYYY-MM-DD, default today)
+// Returns arrival, room changes, Schulhof hops, feedback and pickup of the day in order (full access required)
+func (rs *Resource) getStudentTimeline(w http.ResponseWriter, r *http.Request) {
+	student, ok := rs.parseAndGetStudent(w, r)
+	if !ok {
+		return
+	}
+	if !rs.checkStudentFullAccess(r, student) {
+		renderError(w, r, ErrorForbidden(errors.New("full access required to view student timeline")))
+		return
+	}
+
+	date, err := parseTimelineDate(r)
+	if err != nil {
+		renderError(w, r, ErrorInvalidRequest(err))
+		return
+	}
+
+	timeline, err := rs.TimelineService.GetStudentTimeline(r.Context(), student.ID, date)
+	if err != nil {
+		renderError(w, r, ErrorInternalServer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, timeline, "Student timeline retrieved successfully")
+}
+
+// GetStudentTimelineHandler returns the handler for a student's daily timeline
+func (rs *Resource) GetStudentTimelineHandler() http.HandlerFunc {
+	return rs.getStudentTimeline
+}
@@ -0,0 +1,36 @@
+package students
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimelineDate(t *testing.T) {
+	t.Run("defaults to today", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/students/20/timeline", nil)
+		date, err := parseTimelineDate(req)
+		require.NoError(t, err)
+		assert.Equal(t, timezone.Today(), date)
+	})
+
+	t.Run("explicit date", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/students/20/timeline?date=2026-03-04", nil)
+		date, err := parseTimelineDate(req)
+		require.NoError(t, err)
+		assert.Equal(t, "2026-03-04", date.Format(dateFormatISO))
+		assert.Equal(t, timezone.Berlin, date.Location())
+	})
+
+	for _, value := range []string{"04.03.2026", "2026-13-01", timezone.Today().AddDate(0, 0, 1).Format(dateFormatISO)} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/students/20/timeline?date="+value, nil)
+			_, err := parseTimelineDate(req)
+			require.Error(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -14,6 +15,7 @@ import (
 	"github.com/moto-nrw/project-phoenix/auth/authorize"
 	"github.com/moto-nrw/project-phoenix/auth/authorize/permissions"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
 	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
 	usersSvc "github.com/moto-nrw/project-phoenix/services/users"
 )
@@ -25,14 +27,16 @@ const errInvalidSessionID = "invalid session ID"
 type Resource struct {
 	WorkSessionService  activeSvc.WorkSessionService
 	StaffAbsenceService activeSvc.StaffAbsenceService
+	TimeBalanceService  activeSvc.TimeBalanceService
 	PersonService       usersSvc.PersonService
 }
 
 // NewResource creates a new time-tracking resource
-func NewResource(workSessionService activeSvc.WorkSessionService, staffAbsenceService activeSvc.StaffAbsenceService, personService usersSvc.PersonService) *Resource {
+func NewResource(workSessionService activeSvc.WorkSessionService, staffAbsenceService activeSvc.StaffAbsenceService, timeBalanceService activeSvc.TimeBalanceService, personService usersSvc.PersonService) *Resource {
 	return &Resource{
 		WorkSessionService:  workSessionService,
 		StaffAbsenceService: staffAbsenceService,
+		TimeBalanceService:  timeBalanceService,
 		PersonService:       personService,
 	}
 }
@@ -72,6 +76,28 @@ func (rs *Resource) Router() chi.Router {
 		r.With(authorize.RequiresPermission(permissions.TimeTrackingOwn)).Put("/absences/{id}", rs.updateAbsence)
 		r.With(authorize.RequiresPermission(permissions.TimeTrackingOwn)).Delete("/absences/{id}", rs.deleteAbsence)
 
+		// Absence review (Leitung)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Get("/absences/review", rs.listPendingAbsences)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Post("/absences/{id}/approve", rs.approveAbsence)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Post("/absences/{id}/decline", rs.declineAbsence)
+
+		// Substitution suggestions for the groups an absence leaves uncovered
+		r.With(authorize.RequiresPermission(permissions.SubstitutionsRead)).Get("/absences/{id}/substitutions", rs.getSubstitutionSuggestions)
+		r.With(authorize.RequiresPermission(permissions.SubstitutionsCreate)).Post("/absences/{id}/substitutions", rs.createAbsenceSubstitutions)
+
+		// Overtime balance
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingOwn)).Get("/balance", rs.getOwnBalance)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Get("/balance/report", rs.getBalanceReport)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Get("/balance/report/export", rs.exportBalanceReport)
+
+		// Working time compliance (ArbZG) report
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Get("/compliance/report", rs.getComplianceReport)
+
+		// Contracted hours (Leitung)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Get("/contracts", rs.listContracts)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Post("/contracts", rs.setContract)
+		r.With(authorize.RequiresPermission(permissions.TimeTrackingManage)).Delete("/contracts/{id}", rs.deleteContract)
+
 		// Presence map - for internal use by staff page
 		r.With(authorize.RequiresPermission(permissions.UsersRead)).Get("/presence-map", rs.getPresenceMap)
 	})
@@ -92,6 +118,43 @@ func (req *CheckInRequest) Bind(_ *http.Request) error {
 	return nil
 }
 
+// CheckOutResponse is the closed session together with any working time rule violations
+type CheckOutResponse struct {
+	*activeModels.WorkSession
+	Violations []*activeSvc.ComplianceViolation `json:"violations"`
+}
+
+// DeclineAbsenceRequest represents a request to decline an absence
+type DeclineAbsenceRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Bind validates the decline request
+func (req *DeclineAbsenceRequest) Bind(_ *http.Request) error {
+	if strings.TrimSpace(req.Reason) == "" {
+		return errors.New("decline reason is required")
+	}
+	return nil
+}
+
+// AbsenceSubstitutionsRequest represents the substitutes chosen for an absence's groups
+type AbsenceSubstitutionsRequest struct {
+	Assignments []activeSvc.SubstitutionAssignment `json:"assignments"`
+}
+
+// Bind validates the absence substitutions request
+func (req *AbsenceSubstitutionsRequest) Bind(_ *http.Request) error {
+	if len(req.Assignments) == 0 {
+		return errors.New("at least one assignment is required")
+	}
+	for _, a := range req.Assignments {
+		if a.GroupID <= 0 || a.SubstituteStaffID <= 0 {
+			return errors.New("group_id and substitute_staff_id are required")
+		}
+	}
+	return nil
+}
+
 // getStaffIDFromClaims resolves JWT account ID to staff ID through PersonService
 func (rs *Resource) getStaffIDFromClaims(ctx context.Context, claims jwt.AppClaims) (int64, error) {
 	if claims.ID == 0 {
@@ -181,7 +244,13 @@ func (rs *Resource) checkOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	common.Respond(w, r, http.StatusOK, session, "Check-out successful")
+	// The check-out itself succeeded; violations are advisory and omitted if they cannot be determined
+	violations, err := rs.WorkSessionService.GetSessionViolations(r.Context(), session)
+	if err != nil {
+		slog.Default().Warn("failed to check working time rules on check-out", slog.String("error", err.Error()))
+	}
+
+	common.Respond(w, r, http.StatusOK, CheckOutResponse{WorkSession: session, Violations: violations}, "Check-out successful")
 }
 
 // getCurrent handles GET /api/time-tracking/current
@@ -401,6 +470,11 @@ func (rs *Resource) exportSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	writeExportFile(w, format, filename, fileBytes)
+}
+
+// writeExportFile sends a CSV or XLSX export as a file download
+func writeExportFile(w http.ResponseWriter, format, filename string, fileBytes []byte) {
 	// Set response headers for file download
 	switch format {
 	case "xlsx":
@@ -520,6 +594,115 @@ func (rs *Resource) deleteAbsence(w http.ResponseWriter, r *http.Request) {
 	common.RespondNoContent(w, r)
 }
 
+// listPendingAbsences handles GET /api/time-tracking/absences/review
+func (rs *Resource) listPendingAbsences(w http.ResponseWriter, r *http.Request) {
+	absences, err := rs.StaffAbsenceService.ListPendingAbsences(r.Context())
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInternalServer(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, absences, "Pending absences retrieved successfully")
+}
+
+// approveAbsence handles POST /api/time-tracking/absences/{id}/approve
+func (rs *Resource) approveAbsence(w http.ResponseWriter, r *http.Request) {
+	userClaims := jwt.ClaimsFromCtx(r.Context())
+	reviewerID, err := rs.getStaffIDFromClaims(r.Context(), userClaims)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorUnauthorized(err))
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	absenceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("invalid absence ID")))
+		return
+	}
+
+	absence, err := rs.StaffAbsenceService.ApproveAbsence(r.Context(), reviewerID, absenceID)
+	if err != nil {
+		common.RenderError(w, r, classifyAbsenceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, absence, "Absence approved successfully")
+}
+
+// declineAbsence handles POST /api/time-tracking/absences/{id}/decline
+func (rs *Resource) declineAbsence(w http.ResponseWriter, r *http.Request) {
+	userClaims := jwt.ClaimsFromCtx(r.Context())
+	reviewerID, err := rs.getStaffIDFromClaims(r.Context(), userClaims)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorUnauthorized(err))
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	absenceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("invalid absence ID")))
+		return
+	}
+
+	req := &DeclineAbsenceRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(err))
+		return
+	}
+
+	absence, err := rs.StaffAbsenceService.DeclineAbsence(r.Context(), reviewerID, absenceID, req.Reason)
+	if err != nil {
+		common.RenderError(w, r, classifyAbsenceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, absence, "Absence declined successfully")
+}
+
+// getSubstitutionSuggestions handles GET /api/time-tracking/absences/{id}/substitutions
+func (rs *Resource) getSubstitutionSuggestions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	absenceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("invalid absence ID")))
+		return
+	}
+
+	suggestions, err := rs.StaffAbsenceService.GetSubstitutionSuggestions(r.Context(), absenceID)
+	if err != nil {
+		common.RenderError(w, r, classifyAbsenceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, suggestions, "Substitution suggestions retrieved successfully")
+}
+
+// createAbsenceSubstitutions handles POST /api/time-tracking/absences/{id}/substitutions
+func (rs *Resource) createAbsenceSubstitutions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	absenceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("invalid absence ID")))
+		return
+	}
+
+	req := &AbsenceSubstitutionsRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(err))
+		return
+	}
+
+	substitutions, err := rs.StaffAbsenceService.CreateSubstitutionsForAbsence(r.Context(), absenceID, req.Assignments)
+	if err != nil {
+		common.RenderError(w, r, classifyAbsenceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusCreated, substitutions, "Substitutions created successfully")
+}
+
 // getPresenceMap handles GET /api/time-tracking/presence-map
 func (rs *Resource) getPresenceMap(w http.ResponseWriter, r *http.Request) {
 	// Get today's presence map
@@ -15,6 +15,7 @@ import (
 	activeModels "github.com/moto-nrw/project-phoenix/models/active"
 	auditModels "github.com/moto-nrw/project-phoenix/models/audit"
 	"github.com/moto-nrw/project-phoenix/models/base"
+	educationModels "github.com/moto-nrw/project-phoenix/models/education"
 	userModels "github.com/moto-nrw/project-phoenix/models/users"
 	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
 	usersSvc "github.com/moto-nrw/project-phoenix/services/users"
@@ -117,6 +118,7 @@ func (m *mockPersonService) GetStudentsWithGroupsByTeacher(_ context.Context, _
 func (m *mockPersonService) GetAllStudentsWithGroups(_ context.Context) ([]usersSvc.StudentWithGroup, error) {
 	return nil, nil
 }
+func (m *mockPersonService) BroadcastStudentSickness(_ context.Context, _ *userModels.Student) {}
 
 // --- Mock WorkSessionService ---
 
@@ -133,6 +135,8 @@ type mockWorkSessionService struct {
 	getTodayPresenceFn   func(ctx context.Context) (map[int64]string, error)
 	exportSessionsFn     func(ctx context.Context, staffID int64, from, to time.Time, format string) ([]byte, string, error)
 	autoEndExpiredBreaks func(ctx context.Context) (int, error)
+	sessionViolationsFn  func(ctx context.Context, session *activeModels.WorkSession) ([]*activeSvc.ComplianceViolation, error)
+	complianceReportFn   func(ctx context.Context, month time.Time) ([]*activeSvc.StaffComplianceReport, error)
 }
 
 func (m *mockWorkSessionService) CheckIn(ctx context.Context, staffID int64, status string) (*activeModels.WorkSession, error) {
@@ -211,6 +215,18 @@ func (m *mockWorkSessionService) AutoEndExpiredBreaks(ctx context.Context) (int,
 	}
 	return 0, nil
 }
+func (m *mockWorkSessionService) GetSessionViolations(ctx context.Context, session *activeModels.WorkSession) ([]*activeSvc.ComplianceViolation, error) {
+	if m.sessionViolationsFn != nil {
+		return m.sessionViolationsFn(ctx, session)
+	}
+	return nil, nil
+}
+func (m *mockWorkSessionService) GetComplianceReport(ctx context.Context, month time.Time) ([]*activeSvc.StaffComplianceReport, error) {
+	if m.complianceReportFn != nil {
+		return m.complianceReportFn(ctx, month)
+	}
+	return nil, nil
+}
 
 // --- Mock StaffAbsenceService ---
 
@@ -220,6 +236,11 @@ type mockStaffAbsenceService struct {
 	deleteAbsenceFn     func(ctx context.Context, staffID int64, absenceID int64) error
 	getAbsencesForRange func(ctx context.Context, staffID int64, from, to time.Time) ([]*activeSvc.StaffAbsenceResponse, error)
 	hasAbsenceOnDateFn  func(ctx context.Context, staffID int64, date time.Time) (bool, *activeModels.StaffAbsence, error)
+	listPendingFn       func(ctx context.Context) ([]*activeSvc.AbsenceReviewItem, error)
+	approveAbsenceFn    func(ctx context.Context, reviewerStaffID int64, absenceID int64) (*activeSvc.StaffAbsenceResponse, error)
+	declineAbsenceFn    func(ctx context.Context, reviewerStaffID int64, absenceID int64, reason string) (*activeSvc.StaffAbsenceResponse, error)
+	suggestionsFn       func(ctx context.Context, absenceID int64) (*activeSvc.SubstitutionSuggestions, error)
+	createSubsFn        func(ctx context.Context, absenceID int64, assignments []activeSvc.SubstitutionAssignment) ([]*educationModels.GroupSubstitution, error)
 }
 
 func (m *mockStaffAbsenceService) CreateAbsence(ctx context.Context, staffID int64, req activeSvc.CreateAbsenceRequest) (*activeSvc.StaffAbsenceResponse, error) {
@@ -252,6 +273,36 @@ func (m *mockStaffAbsenceService) HasAbsenceOnDate(ctx context.Context, staffID
 	}
 	return false, nil, nil
 }
+func (m *mockStaffAbsenceService) ListPendingAbsences(ctx context.Context) ([]*activeSvc.AbsenceReviewItem, error) {
+	if m.listPendingFn != nil {
+		return m.listPendingFn(ctx)
+	}
+	return nil, nil
+}
+func (m *mockStaffAbsenceService) ApproveAbsence(ctx context.Context, reviewerStaffID int64, absenceID int64) (*activeSvc.StaffAbsenceResponse, error) {
+	if m.approveAbsenceFn != nil {
+		return m.approveAbsenceFn(ctx, reviewerStaffID, absenceID)
+	}
+	return &activeSvc.StaffAbsenceResponse{}, nil
+}
+func (m *mockStaffAbsenceService) DeclineAbsence(ctx context.Context, reviewerStaffID int64, absenceID int64, reason string) (*activeSvc.StaffAbsenceResponse, error) {
+	if m.declineAbsenceFn != nil {
+		return m.declineAbsenceFn(ctx, reviewerStaffID, absenceID, reason)
+	}
+	return &activeSvc.StaffAbsenceResponse{}, nil
+}
+func (m *mockStaffAbsenceService) GetSubstitutionSuggestions(ctx context.Context, absenceID int64) (*activeSvc.SubstitutionSuggestions, error) {
+	if m.suggestionsFn != nil {
+		return m.suggestionsFn(ctx, absenceID)
+	}
+	return &activeSvc.SubstitutionSuggestions{}, nil
+}
+func (m *mockStaffAbsenceService) CreateSubstitutionsForAbsence(ctx context.Context, absenceID int64, assignments []activeSvc.SubstitutionAssignment) ([]*educationModels.GroupSubstitution, error) {
+	if m.createSubsFn != nil {
+		return m.createSubsFn(ctx, absenceID, assignments)
+	}
+	return nil, nil
+}
 
 // --- Test helpers ---
 
@@ -273,7 +324,7 @@ func defaultPersonSvc() *mockPersonService {
 }
 
 func testResource(wsSvc *mockWorkSessionService, absSvc *mockStaffAbsenceService, pSvc *mockPersonService) *Resource {
-	return NewResource(wsSvc, absSvc, pSvc)
+	return NewResource(wsSvc, absSvc, &mockTimeBalanceService{}, pSvc)
 }
 
 func withClaims(r *http.Request, claims jwt.AppClaims) *http.Request {
@@ -310,6 +361,7 @@ func parseAPIResponse(t *testing.T, w *httptest.ResponseRecorder) apiResponse {
 var (
 	_ activeSvc.WorkSessionService  = (*mockWorkSessionService)(nil)
 	_ activeSvc.StaffAbsenceService = (*mockStaffAbsenceService)(nil)
+	_ activeSvc.TimeBalanceService  = (*mockTimeBalanceService)(nil)
 	_ usersSvc.PersonService        = (*mockPersonService)(nil)
 	_ userModels.StaffRepository    = (*mockStaffRepo)(nil)
 )
@@ -337,10 +389,11 @@ func TestCheckInRequest_Bind(t *testing.T) {
 // --- NewResource ---
 
 func TestNewResource(t *testing.T) {
-	rs := NewResource(&mockWorkSessionService{}, &mockStaffAbsenceService{}, defaultPersonSvc())
+	rs := NewResource(&mockWorkSessionService{}, &mockStaffAbsenceService{}, &mockTimeBalanceService{}, defaultPersonSvc())
 	assert.NotNil(t, rs)
 	assert.NotNil(t, rs.WorkSessionService)
 	assert.NotNil(t, rs.StaffAbsenceService)
+	assert.NotNil(t, rs.TimeBalanceService)
 	assert.NotNil(t, rs.PersonService)
 }
 
@@ -1186,8 +1239,217 @@ func TestDeleteAbsence_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, w.Code)
 }
 
+// --- absence review handlers ---
+
+func TestListPendingAbsences_Success(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		listPendingFn: func(_ context.Context) ([]*activeSvc.AbsenceReviewItem, error) {
+			return []*activeSvc.AbsenceReviewItem{{StaffName: "Anna Schmidt"}}, nil
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodGet, "/absences/review", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.listPendingAbsences(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Anna Schmidt")
+}
+
+func TestApproveAbsence_Success(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		approveAbsenceFn: func(_ context.Context, reviewerStaffID int64, absenceID int64) (*activeSvc.StaffAbsenceResponse, error) {
+			assert.Equal(t, int64(100), reviewerStaffID)
+			assert.Equal(t, int64(77), absenceID)
+			return &activeSvc.StaffAbsenceResponse{}, nil
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/approve", nil)
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.approveAbsence(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestApproveAbsence_AlreadyReviewed(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		approveAbsenceFn: func(_ context.Context, _ int64, _ int64) (*activeSvc.StaffAbsenceResponse, error) {
+			return nil, errors.New("absence has already been reviewed")
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/approve", nil)
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.approveAbsence(w, r)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestDeclineAbsence_Success(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		declineAbsenceFn: func(_ context.Context, reviewerStaffID int64, absenceID int64, reason string) (*activeSvc.StaffAbsenceResponse, error) {
+			assert.Equal(t, int64(100), reviewerStaffID)
+			assert.Equal(t, int64(77), absenceID)
+			assert.Equal(t, "Team unterbesetzt", reason)
+			return &activeSvc.StaffAbsenceResponse{}, nil
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	body := bytes.NewBufferString(`{"reason":"Team unterbesetzt"}`)
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/decline", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.declineAbsence(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDeclineAbsence_MissingReason(t *testing.T) {
+	rs := testResource(&mockWorkSessionService{}, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	body := bytes.NewBufferString(`{"reason":"  "}`)
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/decline", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.declineAbsence(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeclineAbsence_OwnAbsence(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		declineAbsenceFn: func(_ context.Context, _ int64, _ int64, _ string) (*activeSvc.StaffAbsenceResponse, error) {
+			return nil, errors.New("can only review absences of other staff")
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	body := bytes.NewBufferString(`{"reason":"Nein"}`)
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/decline", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.declineAbsence(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
 // --- getPresenceMap handler ---
 
+// --- absence substitution handlers ---
+
+func TestGetSubstitutionSuggestions_Success(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		suggestionsFn: func(_ context.Context, absenceID int64) (*activeSvc.SubstitutionSuggestions, error) {
+			assert.Equal(t, int64(77), absenceID)
+			return &activeSvc.SubstitutionSuggestions{
+				AbsenceID:  absenceID,
+				Groups:     []*activeSvc.AffectedGroup{{GroupID: 12, GroupName: "Sonnengruppe"}},
+				Candidates: []*activeSvc.SubstitutionCandidate{{StaffID: 200, Name: "Max Weber"}},
+			}, nil
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodGet, "/absences/77/substitutions", nil)
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.getSubstitutionSuggestions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Sonnengruppe")
+	assert.Contains(t, w.Body.String(), "Max Weber")
+}
+
+func TestGetSubstitutionSuggestions_NotFound(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		suggestionsFn: func(_ context.Context, _ int64) (*activeSvc.SubstitutionSuggestions, error) {
+			return nil, errors.New("absence not found")
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodGet, "/absences/77/substitutions", nil)
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.getSubstitutionSuggestions(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateAbsenceSubstitutions_Success(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		createSubsFn: func(_ context.Context, absenceID int64, assignments []activeSvc.SubstitutionAssignment) ([]*educationModels.GroupSubstitution, error) {
+			assert.Equal(t, int64(77), absenceID)
+			require.Len(t, assignments, 1)
+			assert.Equal(t, int64(12), assignments[0].GroupID)
+			assert.Equal(t, int64(200), assignments[0].SubstituteStaffID)
+			return []*educationModels.GroupSubstitution{{GroupID: 12, SubstituteStaffID: 200}}, nil
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	body := bytes.NewBufferString(`{"assignments":[{"group_id":12,"substitute_staff_id":200}]}`)
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/substitutions", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.createAbsenceSubstitutions(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCreateAbsenceSubstitutions_EmptyAssignments(t *testing.T) {
+	rs := testResource(&mockWorkSessionService{}, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	body := bytes.NewBufferString(`{"assignments":[]}`)
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/substitutions", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.createAbsenceSubstitutions(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateAbsenceSubstitutions_AlreadyCovered(t *testing.T) {
+	absSvc := &mockStaffAbsenceService{
+		createSubsFn: func(_ context.Context, _ int64, _ []activeSvc.SubstitutionAssignment) ([]*educationModels.GroupSubstitution, error) {
+			return nil, errors.New("group 12 already has a substitution for this absence")
+		},
+	}
+	rs := testResource(&mockWorkSessionService{}, absSvc, defaultPersonSvc())
+
+	body := bytes.NewBufferString(`{"assignments":[{"group_id":12,"substitute_staff_id":200}]}`)
+	r := httptest.NewRequest(http.MethodPost, "/absences/77/substitutions", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "77")
+	w := httptest.NewRecorder()
+
+	rs.createAbsenceSubstitutions(w, r)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
 func TestGetPresenceMap_Success(t *testing.T) {
 	wsSvc := &mockWorkSessionService{
 		getTodayPresenceFn: func(_ context.Context) (map[int64]string, error) {
@@ -1267,6 +1529,12 @@ func TestClassifyAbsenceError(t *testing.T) {
 		{"bad request - invalid", "invalid absence type", http.StatusBadRequest},
 		{"bad request - invalid status", "invalid absence status", http.StatusBadRequest},
 		{"bad request - invalid prefix", "invalid date format", http.StatusBadRequest},
+		{"forbidden - review own", "can only review absences of other staff", http.StatusForbidden},
+		{"conflict - already reviewed", "absence has already been reviewed", http.StatusConflict},
+		{"bad request - decline reason", "decline reason is required", http.StatusBadRequest},
+		{"conflict - declined", "absence has been declined", http.StatusConflict},
+		{"conflict - substitution exists", "group 12 already has a substitution for this absence", http.StatusConflict},
+		{"bad request - assignment", "invalid assignment: group 12 is assigned twice", http.StatusBadRequest},
 		{"internal server - unknown", "some unknown error", http.StatusInternalServerError},
 	}
 
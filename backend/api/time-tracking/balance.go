@@ -0,0 +1,168 @@
+package timetracking
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/moto-nrw/project-phoenix/api/common"
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+// ContractRequest represents a request to set a staff member's contracted hours
+type ContractRequest struct {
+	activeSvc.ContractRequest
+}
+
+// Bind validates the contract request
+func (req *ContractRequest) Bind(_ *http.Request) error {
+	if req.StaffID <= 0 {
+		return errors.New("staff_id is required")
+	}
+	if strings.TrimSpace(req.ValidFrom) == "" {
+		return errors.New("valid_from is required")
+	}
+	if req.WeeklyHours < 0 {
+		return errors.New("weekly hours cannot be negative")
+	}
+	return nil
+}
+
+// parseMonth extracts the optional "month" query parameter (YYYY-MM), defaulting to the current month.
+// Returns the first day of the month or renders an error and returns false.
+func parseMonth(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	monthStr := r.URL.Query().Get("month")
+	if monthStr == "" {
+		today := timezone.TodayUTC()
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC), true
+	}
+
+	month, err := time.Parse("2006-01", monthStr)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("invalid month format, expected YYYY-MM")))
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// getOwnBalance handles GET /api/time-tracking/balance?month=
+func (rs *Resource) getOwnBalance(w http.ResponseWriter, r *http.Request) {
+	userClaims := jwt.ClaimsFromCtx(r.Context())
+	staffID, err := rs.getStaffIDFromClaims(r.Context(), userClaims)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorUnauthorized(err))
+		return
+	}
+
+	month, ok := parseMonth(w, r)
+	if !ok {
+		return
+	}
+
+	balance, err := rs.TimeBalanceService.GetMonthlyBalance(r.Context(), staffID, month)
+	if err != nil {
+		common.RenderError(w, r, classifyBalanceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, balance, "Balance retrieved successfully")
+}
+
+// getBalanceReport handles GET /api/time-tracking/balance/report?month=
+func (rs *Resource) getBalanceReport(w http.ResponseWriter, r *http.Request) {
+	month, ok := parseMonth(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := rs.TimeBalanceService.GetBalanceReport(r.Context(), month)
+	if err != nil {
+		common.RenderError(w, r, classifyBalanceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, report, "Balance report retrieved successfully")
+}
+
+// exportBalanceReport handles GET /api/time-tracking/balance/report/export?month=&format=csv|xlsx
+func (rs *Resource) exportBalanceReport(w http.ResponseWriter, r *http.Request) {
+	month, ok := parseMonth(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "csv" && format != "xlsx" {
+		format = "csv"
+	}
+
+	fileBytes, filename, err := rs.TimeBalanceService.ExportBalanceReport(r.Context(), month, format)
+	if err != nil {
+		common.RenderError(w, r, classifyBalanceError(err))
+		return
+	}
+
+	writeExportFile(w, format, filename, fileBytes)
+}
+
+// listContracts handles GET /api/time-tracking/contracts?staff_id=
+func (rs *Resource) listContracts(w http.ResponseWriter, r *http.Request) {
+	staffID, err := strconv.ParseInt(r.URL.Query().Get("staff_id"), 10, 64)
+	if err != nil || staffID <= 0 {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("staff_id query parameter is required")))
+		return
+	}
+
+	contracts, err := rs.TimeBalanceService.ListContracts(r.Context(), staffID)
+	if err != nil {
+		common.RenderError(w, r, classifyBalanceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, contracts, "Contracts retrieved successfully")
+}
+
+// setContract handles POST /api/time-tracking/contracts
+func (rs *Resource) setContract(w http.ResponseWriter, r *http.Request) {
+	userClaims := jwt.ClaimsFromCtx(r.Context())
+	actorID, err := rs.getStaffIDFromClaims(r.Context(), userClaims)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorUnauthorized(err))
+		return
+	}
+
+	req := &ContractRequest{}
+	if err := render.Bind(r, req); err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(err))
+		return
+	}
+
+	contract, err := rs.TimeBalanceService.SetContract(r.Context(), actorID, req.ContractRequest)
+	if err != nil {
+		common.RenderError(w, r, classifyBalanceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, contract, "Contract saved successfully")
+}
+
+// deleteContract handles DELETE /api/time-tracking/contracts/{id}
+func (rs *Resource) deleteContract(w http.ResponseWriter, r *http.Request) {
+	contractID, err := common.ParseID(r)
+	if err != nil {
+		common.RenderError(w, r, common.ErrorInvalidRequest(errors.New("invalid contract ID")))
+		return
+	}
+
+	if err := rs.TimeBalanceService.DeleteContract(r.Context(), contractID); err != nil {
+		common.RenderError(w, r, classifyBalanceError(err))
+		return
+	}
+
+	common.RespondNoContent(w, r)
+}
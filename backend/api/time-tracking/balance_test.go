@@ -0,0 +1,232 @@
+package timetracking
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/render"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mock TimeBalanceService ---
+
+type mockTimeBalanceService struct {
+	listContractsFn  func(ctx context.Context, staffID int64) ([]*activeModels.StaffContract, error)
+	setContractFn    func(ctx context.Context, actorStaffID int64, req activeSvc.ContractRequest) (*activeModels.StaffContract, error)
+	deleteContractFn func(ctx context.Context, contractID int64) error
+	monthlyBalanceFn func(ctx context.Context, staffID int64, month time.Time) (*activeSvc.MonthlyBalance, error)
+	balanceReportFn  func(ctx context.Context, month time.Time) ([]*activeSvc.MonthlyBalance, error)
+	exportReportFn   func(ctx context.Context, month time.Time, format string) ([]byte, string, error)
+}
+
+func (m *mockTimeBalanceService) ListContracts(ctx context.Context, staffID int64) ([]*activeModels.StaffContract, error) {
+	if m.listContractsFn != nil {
+		return m.listContractsFn(ctx, staffID)
+	}
+	return nil, nil
+}
+func (m *mockTimeBalanceService) SetContract(ctx context.Context, actorStaffID int64, req activeSvc.ContractRequest) (*activeModels.StaffContract, error) {
+	if m.setContractFn != nil {
+		return m.setContractFn(ctx, actorStaffID, req)
+	}
+	return &activeModels.StaffContract{}, nil
+}
+func (m *mockTimeBalanceService) DeleteContract(ctx context.Context, contractID int64) error {
+	if m.deleteContractFn != nil {
+		return m.deleteContractFn(ctx, contractID)
+	}
+	return nil
+}
+func (m *mockTimeBalanceService) GetMonthlyBalance(ctx context.Context, staffID int64, month time.Time) (*activeSvc.MonthlyBalance, error) {
+	if m.monthlyBalanceFn != nil {
+		return m.monthlyBalanceFn(ctx, staffID, month)
+	}
+	return &activeSvc.MonthlyBalance{}, nil
+}
+func (m *mockTimeBalanceService) GetBalanceReport(ctx context.Context, month time.Time) ([]*activeSvc.MonthlyBalance, error) {
+	if m.balanceReportFn != nil {
+		return m.balanceReportFn(ctx, month)
+	}
+	return nil, nil
+}
+func (m *mockTimeBalanceService) ExportBalanceReport(ctx context.Context, month time.Time, format string) ([]byte, string, error) {
+	if m.exportReportFn != nil {
+		return m.exportReportFn(ctx, month, format)
+	}
+	return []byte("data"), "stundensaldo.csv", nil
+}
+
+func balanceResource(tbSvc *mockTimeBalanceService) *Resource {
+	return NewResource(&mockWorkSessionService{}, &mockStaffAbsenceService{}, tbSvc, defaultPersonSvc())
+}
+
+// --- getOwnBalance ---
+
+func TestGetOwnBalance_Success(t *testing.T) {
+	tbSvc := &mockTimeBalanceService{
+		monthlyBalanceFn: func(_ context.Context, staffID int64, month time.Time) (*activeSvc.MonthlyBalance, error) {
+			assert.Equal(t, int64(100), staffID)
+			assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), month)
+			return &activeSvc.MonthlyBalance{StaffID: staffID, Month: "2026-03", BalanceMinutes: 90}, nil
+		},
+	}
+	rs := balanceResource(tbSvc)
+
+	r := httptest.NewRequest(http.MethodGet, "/balance?month=2026-03", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.getOwnBalance(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"balance_minutes":90`)
+}
+
+func TestGetOwnBalance_InvalidMonth(t *testing.T) {
+	rs := balanceResource(&mockTimeBalanceService{})
+
+	r := httptest.NewRequest(http.MethodGet, "/balance?month=03-2026", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.getOwnBalance(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// --- balance report ---
+
+func TestGetBalanceReport_Success(t *testing.T) {
+	tbSvc := &mockTimeBalanceService{
+		balanceReportFn: func(_ context.Context, _ time.Time) ([]*activeSvc.MonthlyBalance, error) {
+			return []*activeSvc.MonthlyBalance{{StaffID: 100, StaffName: "Anna Schmidt"}}, nil
+		},
+	}
+	rs := balanceResource(tbSvc)
+
+	r := httptest.NewRequest(http.MethodGet, "/balance/report?month=2026-03", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.getBalanceReport(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Anna Schmidt")
+}
+
+func TestExportBalanceReport_XLSX(t *testing.T) {
+	tbSvc := &mockTimeBalanceService{
+		exportReportFn: func(_ context.Context, _ time.Time, format string) ([]byte, string, error) {
+			assert.Equal(t, "xlsx", format)
+			return []byte("xlsx-data"), "stundensaldo_2026-03.xlsx", nil
+		},
+	}
+	rs := balanceResource(tbSvc)
+
+	r := httptest.NewRequest(http.MethodGet, "/balance/report/export?month=2026-03&format=xlsx", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.exportBalanceReport(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "stundensaldo_2026-03.xlsx")
+	assert.Equal(t, "xlsx-data", w.Body.String())
+}
+
+// --- contracts ---
+
+func TestListContracts_MissingStaffID(t *testing.T) {
+	rs := balanceResource(&mockTimeBalanceService{})
+
+	r := httptest.NewRequest(http.MethodGet, "/contracts", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.listContracts(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSetContract_Success(t *testing.T) {
+	tbSvc := &mockTimeBalanceService{
+		setContractFn: func(_ context.Context, actorStaffID int64, req activeSvc.ContractRequest) (*activeModels.StaffContract, error) {
+			assert.Equal(t, int64(100), actorStaffID)
+			assert.Equal(t, int64(200), req.StaffID)
+			assert.InDelta(t, 19.5, req.WeeklyHours, 0.001)
+			assert.Equal(t, "2026-04-01", req.ValidFrom)
+			return &activeModels.StaffContract{StaffID: req.StaffID, WeeklyMinutes: 1170}, nil
+		},
+	}
+	rs := balanceResource(tbSvc)
+
+	body := bytes.NewBufferString(`{"staff_id":200,"weekly_hours":19.5,"valid_from":"2026-04-01"}`)
+	r := httptest.NewRequest(http.MethodPost, "/contracts", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.setContract(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSetContract_MissingValidFrom(t *testing.T) {
+	rs := balanceResource(&mockTimeBalanceService{})
+
+	body := bytes.NewBufferString(`{"staff_id":200,"weekly_hours":39}`)
+	r := httptest.NewRequest(http.MethodPost, "/contracts", body)
+	r.Header.Set("Content-Type", "application/json")
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.setContract(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteContract_NotFound(t *testing.T) {
+	tbSvc := &mockTimeBalanceService{
+		deleteContractFn: func(_ context.Context, _ int64) error {
+			return errors.New("contract not found")
+		},
+	}
+	rs := balanceResource(tbSvc)
+
+	r := httptest.NewRequest(http.MethodDelete, "/contracts/42", nil)
+	r = withClaims(r, validClaims())
+	r = withChiParam(r, "id", "42")
+	w := httptest.NewRecorder()
+
+	rs.deleteContract(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// --- classifyBalanceError ---
+
+func TestClassifyBalanceError(t *testing.T) {
+	tests := []struct {
+		name       string
+		errMsg     string
+		wantStatus int
+	}{
+		{"not found - contract", "contract not found", http.StatusNotFound},
+		{"not found - staff", "staff not found", http.StatusNotFound},
+		{"bad request - date", "invalid valid_from date: parsing time", http.StatusBadRequest},
+		{"bad request - hours", "weekly hours cannot exceed 48", http.StatusBadRequest},
+		{"internal server - unknown", "some unknown error", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := classifyBalanceError(errors.New(tt.errMsg))
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			err := render.Render(w, r, renderer)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
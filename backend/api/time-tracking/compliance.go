@@ -0,0 +1,23 @@
+package timetracking
+
+import (
+	"net/http"
+
+	"github.com/moto-nrw/project-phoenix/api/common"
+)
+
+// getComplianceReport handles GET /api/time-tracking/compliance/report?month=
+func (rs *Resource) getComplianceReport(w http.ResponseWriter, r *http.Request) {
+	month, ok := parseMonth(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := rs.WorkSessionService.GetComplianceReport(r.Context(), month)
+	if err != nil {
+		common.RenderError(w, r, classifyServiceError(err))
+		return
+	}
+
+	common.Respond(w, r, http.StatusOK, report, "Compliance report retrieved successfully")
+}
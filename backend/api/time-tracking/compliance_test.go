@@ -0,0 +1,101 @@
+package timetracking
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOut_IncludesViolations(t *testing.T) {
+	wsSvc := &mockWorkSessionService{
+		checkOutFn: func(_ context.Context, _ int64) (*activeModels.WorkSession, error) {
+			ws := &activeModels.WorkSession{}
+			ws.ID = 10
+			return ws, nil
+		},
+		sessionViolationsFn: func(_ context.Context, session *activeModels.WorkSession) ([]*activeSvc.ComplianceViolation, error) {
+			assert.Equal(t, int64(10), session.ID)
+			return []*activeSvc.ComplianceViolation{{Rule: activeSvc.ViolationBreakTooShort, SessionID: session.ID}}, nil
+		},
+	}
+	rs := testResource(wsSvc, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodPost, "/check-out", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.checkOut(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), activeSvc.ViolationBreakTooShort)
+}
+
+func TestCheckOut_ViolationErrorStillSucceeds(t *testing.T) {
+	wsSvc := &mockWorkSessionService{
+		checkOutFn: func(_ context.Context, _ int64) (*activeModels.WorkSession, error) {
+			return &activeModels.WorkSession{}, nil
+		},
+		sessionViolationsFn: func(_ context.Context, _ *activeModels.WorkSession) ([]*activeSvc.ComplianceViolation, error) {
+			return nil, errors.New("db error")
+		},
+	}
+	rs := testResource(wsSvc, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodPost, "/check-out", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.checkOut(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetComplianceReport_Success(t *testing.T) {
+	wsSvc := &mockWorkSessionService{
+		complianceReportFn: func(_ context.Context, month time.Time) ([]*activeSvc.StaffComplianceReport, error) {
+			assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), month)
+			return []*activeSvc.StaffComplianceReport{{StaffID: 100, StaffName: "Anna Schmidt"}}, nil
+		},
+	}
+	rs := testResource(wsSvc, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodGet, "/compliance/report?month=2026-03", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.getComplianceReport(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Anna Schmidt")
+}
+
+func TestGetComplianceReport_InvalidMonth(t *testing.T) {
+	rs := testResource(&mockWorkSessionService{}, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodGet, "/compliance/report?month=03-2026", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.getComplianceReport(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetComplianceReport_ServiceError(t *testing.T) {
+	wsSvc := &mockWorkSessionService{
+		complianceReportFn: func(_ context.Context, _ time.Time) ([]*activeSvc.StaffComplianceReport, error) {
+			return nil, errors.New("db error")
+		},
+	}
+	rs := testResource(wsSvc, &mockStaffAbsenceService{}, defaultPersonSvc())
+
+	r := httptest.NewRequest(http.MethodGet, "/compliance/report", nil)
+	r = withClaims(r, validClaims())
+	w := httptest.NewRecorder()
+
+	rs.getComplianceReport(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -49,14 +49,21 @@ func classifyAbsenceError(err error) render.Renderer {
 		return common.ErrorNotFound(err)
 
 	case msg == "can only update own absences",
-		msg == "can only delete own absences":
+		msg == "can only delete own absences",
+		msg == "can only review absences of other staff":
 		return common.ErrorForbidden(err)
 
 	case strings.HasPrefix(msg, "absence overlaps"),
-		strings.HasPrefix(msg, "updated dates overlap"):
+		strings.HasPrefix(msg, "updated dates overlap"),
+		msg == "absence has already been reviewed",
+		msg == "absence has been declined",
+		msg == "absence has already ended",
+		strings.Contains(msg, "already has a substitution"),
+		strings.Contains(msg, "not available for substitution"):
 		return common.ErrorConflict(err)
 
 	case strings.HasPrefix(msg, "invalid"),
+		strings.HasPrefix(msg, "decline reason"),
 		msg == "invalid absence type",
 		msg == "invalid absence status":
 		return common.ErrorInvalidRequest(err)
@@ -65,3 +72,22 @@ func classifyAbsenceError(err error) render.Renderer {
 		return common.ErrorInternalServer(err)
 	}
 }
+
+// classifyBalanceError maps known contract and balance business errors to HTTP status codes
+func classifyBalanceError(err error) render.Renderer {
+	msg := err.Error()
+
+	switch {
+	case msg == "contract not found",
+		msg == "staff not found":
+		return common.ErrorNotFound(err)
+
+	case strings.HasPrefix(msg, "invalid"),
+		strings.HasPrefix(msg, "weekly hours"),
+		strings.HasPrefix(msg, "valid_from"):
+		return common.ErrorInvalidRequest(err)
+
+	default:
+		return common.ErrorInternalServer(err)
+	}
+}
@@ -150,7 +150,8 @@ const (
 const (
 	ResourceTimeTracking = "time_tracking"
 
-	TimeTrackingOwn = ResourceTimeTracking + ":own" // Read + write own sessions
+	TimeTrackingOwn    = ResourceTimeTracking + ":own"             // Read + write own sessions
+	TimeTrackingManage = ResourceTimeTracking + ":" + ActionManage // Review staff absences
 )
 
 // Grade Transition permissions (admin only)
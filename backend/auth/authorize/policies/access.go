@@ -0,0 +1,89 @@
+package policies
+
+import (
+	"context"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/auth/authorize/permissions"
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policy"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/moto-nrw/project-phoenix/services/education"
+	"github.com/moto-nrw/project-phoenix/services/users"
+)
+
+// isAdmin checks if the subject has the admin role or admin permissions
+func isAdmin(subject policy.Subject) bool {
+	return hasRole(subject.Roles, "admin") || hasPermission(subject.Permissions, permissions.AdminWildcard)
+}
+
+// resolveStaff returns the staff record of the requesting account and, if any, its teacher record
+func resolveStaff(ctx context.Context, usersService users.PersonService, accountID int64) (*userModels.Staff, *userModels.Teacher) {
+	person, err := usersService.FindByAccountID(ctx, accountID)
+	if err != nil || person == nil {
+		return nil, nil
+	}
+
+	staff, err := usersService.StaffRepository().FindByPersonID(ctx, person.ID)
+	if err != nil || staff == nil {
+		return nil, nil
+	}
+
+	teacher, err := usersService.TeacherRepository().FindByStaffID(ctx, staff.ID)
+	if err != nil {
+		return staff, nil
+	}
+	return staff, teacher
+}
+
+// teachesGroup checks if the staff member teaches the OGS group or currently substitutes for it
+func teachesGroup(ctx context.Context, educationService education.Service, staff *userModels.Staff, teacher *userModels.Teacher, groupID int64) (bool, error) {
+	if teacher != nil {
+		teacherGroups, err := educationService.GetTeacherGroups(ctx, teacher.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, group := range teacherGroups {
+			if group.ID == groupID {
+				return true, nil
+			}
+		}
+	}
+
+	substitutions, err := educationService.GetActiveGroupSubstitutions(ctx, groupID, time.Now())
+	if err != nil {
+		return false, err
+	}
+	for _, substitution := range substitutions {
+		if substitution.SubstituteStaffID == staff.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// supervisedActiveGroups returns the IDs of the active groups the staff member currently supervises
+func supervisedActiveGroups(ctx context.Context, activeService active.Service, staffID int64) map[int64]bool {
+	supervisions, err := activeService.FindSupervisorsByStaffID(ctx, staffID)
+	if err != nil {
+		return nil
+	}
+
+	groupIDs := make(map[int64]bool, len(supervisions))
+	for _, supervision := range supervisions {
+		groupIDs[supervision.GroupID] = true
+	}
+	return groupIDs
+}
+
+// resourceID extracts an int64 resource ID from the resource or the named extra field
+func resourceID(authCtx *policy.Context, extraKey string) int64 {
+	if id, ok := authCtx.Extra[extraKey].(int64); ok {
+		return id
+	}
+	if id, ok := authCtx.Resource.ID.(int64); ok {
+		return id
+	}
+	return 0
+}
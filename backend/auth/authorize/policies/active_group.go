@@ -0,0 +1,56 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policy"
+	"github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/moto-nrw/project-phoenix/services/users"
+)
+
+// ActiveGroupPolicy controls who may change or end an active group (session).
+// Only staff currently supervising the session (and admins) may do so; viewing is not restricted.
+type ActiveGroupPolicy struct {
+	usersService  users.PersonService
+	activeService active.Service
+}
+
+// NewActiveGroupPolicy creates a new active group policy
+func NewActiveGroupPolicy(
+	usersService users.PersonService,
+	activeService active.Service,
+) policy.Policy {
+	return &ActiveGroupPolicy{
+		usersService:  usersService,
+		activeService: activeService,
+	}
+}
+
+// Name returns the name of this policy
+func (p *ActiveGroupPolicy) Name() string {
+	return "active_group_access"
+}
+
+// ResourceType returns the resource type this policy applies to
+func (p *ActiveGroupPolicy) ResourceType() string {
+	return "active_group"
+}
+
+// Evaluate evaluates whether the subject can perform the action on the active group
+func (p *ActiveGroupPolicy) Evaluate(ctx context.Context, authCtx *policy.Context) (bool, error) {
+	if authCtx.Action == policy.ActionView || isAdmin(authCtx.Subject) {
+		return true, nil
+	}
+
+	activeGroupID := resourceID(authCtx, "active_group_id")
+	if activeGroupID == 0 {
+		return false, nil
+	}
+
+	staff, _ := resolveStaff(ctx, p.usersService, authCtx.Subject.AccountID)
+	if staff == nil {
+		return false, nil
+	}
+
+	return supervisedActiveGroups(ctx, p.activeService, staff.ID)[activeGroupID], nil
+}
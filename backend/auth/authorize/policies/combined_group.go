@@ -0,0 +1,67 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policy"
+	"github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/moto-nrw/project-phoenix/services/users"
+)
+
+// CombinedGroupPolicy controls who may manage a combined group.
+// Staff supervising one of the combined sessions (and admins) may change, end or delete it.
+type CombinedGroupPolicy struct {
+	usersService  users.PersonService
+	activeService active.Service
+}
+
+// NewCombinedGroupPolicy creates a new combined group policy
+func NewCombinedGroupPolicy(
+	usersService users.PersonService,
+	activeService active.Service,
+) policy.Policy {
+	return &CombinedGroupPolicy{
+		usersService:  usersService,
+		activeService: activeService,
+	}
+}
+
+// Name returns the name of this policy
+func (p *CombinedGroupPolicy) Name() string {
+	return "combined_group_access"
+}
+
+// ResourceType returns the resource type this policy applies to
+func (p *CombinedGroupPolicy) ResourceType() string {
+	return "combined_group"
+}
+
+// Evaluate evaluates whether the subject can perform the action on the combined group
+func (p *CombinedGroupPolicy) Evaluate(ctx context.Context, authCtx *policy.Context) (bool, error) {
+	if authCtx.Action == policy.ActionView || isAdmin(authCtx.Subject) {
+		return true, nil
+	}
+
+	combinedGroupID := resourceID(authCtx, "combined_group_id")
+	if combinedGroupID == 0 {
+		return false, nil
+	}
+
+	staff, _ := resolveStaff(ctx, p.usersService, authCtx.Subject.AccountID)
+	if staff == nil {
+		return false, nil
+	}
+
+	mappings, err := p.activeService.GetGroupMappingsByCombinedGroupID(ctx, combinedGroupID)
+	if err != nil {
+		return false, nil
+	}
+
+	supervised := supervisedActiveGroups(ctx, p.activeService, staff.ID)
+	for _, mapping := range mappings {
+		if supervised[mapping.ActiveGroupID] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -33,9 +33,9 @@ func NewPolicyRegistry(
 func (r *PolicyRegistry) RegisterAll(authService authorize.AuthorizationService) error {
 	policies := []policy.Policy{
 		NewStudentVisitPolicy(r.educationService, r.usersService, r.activeService),
-		// Add more policies here as you create them
-		// NewActiveGroupPolicy(r.educationService, r.usersService),
-		// NewStudentPolicy(r.educationService, r.usersService),
+		NewStudentPolicy(r.educationService, r.usersService, r.activeService),
+		NewActiveGroupPolicy(r.usersService, r.activeService),
+		NewCombinedGroupPolicy(r.usersService, r.activeService),
 	}
 
 	for _, p := range policies {
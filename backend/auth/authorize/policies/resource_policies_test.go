@@ -0,0 +1,291 @@
+package policies_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/auth/authorize/permissions"
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policies"
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policy"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// StudentPolicy Tests
+// =============================================================================
+
+func TestStudentPolicy_AdminCanAlwaysAccess(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	eduService, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewStudentPolicy(eduService, usersService, activeService)
+
+	authCtx := &policy.Context{
+		Subject: policy.Subject{
+			AccountID:   99999, // Doesn't need to exist for admin bypass
+			Roles:       []string{"staff"},
+			Permissions: []string{permissions.AdminWildcard},
+		},
+		Resource: policy.Resource{Type: "student"},
+		Action:   policy.ActionEdit,
+		Extra:    map[string]interface{}{"student_id": int64(123)},
+	}
+
+	result, err := p.Evaluate(context.Background(), authCtx)
+
+	require.NoError(t, err)
+	assert.True(t, result, "Admin should always have access")
+}
+
+func TestStudentPolicy_GroupTeacherHasFullAccess(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	eduService, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewStudentPolicy(eduService, usersService, activeService)
+
+	// ARRANGE: Teacher of the student's OGS group
+	teacher, teacherAccount := testpkg.CreateTestTeacherWithAccount(t, db, "Group", "Teacher")
+	defer testpkg.CleanupActivityFixtures(t, db, teacher.ID, teacher.Staff.ID, teacherAccount.ID)
+
+	eduGroup := testpkg.CreateTestEducationGroup(t, db, "Policy Class")
+	testpkg.CreateTestGroupTeacher(t, db, eduGroup.ID, teacher.ID)
+	defer testpkg.CleanupActivityFixtures(t, db, eduGroup.ID)
+
+	student, studentAccount := testpkg.CreateTestStudentWithAccount(t, db, "Group", "Student", "1a")
+	testpkg.AssignStudentToGroup(t, db, student.ID, eduGroup.ID)
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, studentAccount.ID)
+
+	for _, action := range []policy.Action{policy.ActionView, policy.ActionEdit, policy.ActionDelete} {
+		// ACT
+		result, err := p.Evaluate(context.Background(), &policy.Context{
+			Subject:  policy.Subject{AccountID: teacherAccount.ID, Roles: []string{"teacher"}},
+			Resource: policy.Resource{Type: "student", ID: student.ID},
+			Action:   action,
+		})
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.True(t, result, "Group teacher should be allowed to %s", action)
+	}
+}
+
+func TestStudentPolicy_SubstituteHasFullAccess(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	eduService, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewStudentPolicy(eduService, usersService, activeService)
+
+	// ARRANGE: Staff substituting for the student's group today
+	substitute, substituteAccount := testpkg.CreateTestStaffWithAccount(t, db, "Sub", "Staff")
+	defer testpkg.CleanupActivityFixtures(t, db, substitute.ID, substituteAccount.ID)
+
+	eduGroup := testpkg.CreateTestEducationGroup(t, db, "Substituted Class")
+	defer testpkg.CleanupActivityFixtures(t, db, eduGroup.ID)
+
+	now := time.Now()
+	substitution := testpkg.CreateTestGroupSubstitution(t, db, eduGroup.ID, nil, substitute.ID, now.AddDate(0, 0, -1), now.AddDate(0, 0, 1))
+	defer testpkg.CleanupActivityFixtures(t, db, substitution.ID)
+
+	student, studentAccount := testpkg.CreateTestStudentWithAccount(t, db, "Sub", "Student", "2a")
+	testpkg.AssignStudentToGroup(t, db, student.ID, eduGroup.ID)
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, studentAccount.ID)
+
+	// ACT
+	result, err := p.Evaluate(context.Background(), &policy.Context{
+		Subject:  policy.Subject{AccountID: substituteAccount.ID, Roles: []string{"staff"}},
+		Resource: policy.Resource{Type: "student"},
+		Action:   policy.ActionEdit,
+		Extra:    map[string]interface{}{"student_id": student.ID},
+	})
+
+	// ASSERT
+	require.NoError(t, err)
+	assert.True(t, result, "Substitute should have full access to students of the substituted group")
+}
+
+func TestStudentPolicy_SessionSupervisorCanOnlyView(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	eduService, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewStudentPolicy(eduService, usersService, activeService)
+
+	// ARRANGE: Staff supervising the session the student is checked into
+	supervisor, supervisorAccount := testpkg.CreateTestStaffWithAccount(t, db, "Session", "Supervisor")
+	defer testpkg.CleanupActivityFixtures(t, db, supervisor.ID, supervisorAccount.ID)
+
+	eduGroup := testpkg.CreateTestEducationGroup(t, db, "Other Class")
+	defer testpkg.CleanupActivityFixtures(t, db, eduGroup.ID)
+
+	student, studentAccount := testpkg.CreateTestStudentWithAccount(t, db, "Visiting", "Student", "3a")
+	testpkg.AssignStudentToGroup(t, db, student.ID, eduGroup.ID)
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, studentAccount.ID)
+
+	activity := testpkg.CreateTestActivityGroup(t, db, "Policy Activity")
+	room := testpkg.CreateTestRoom(t, db, "Policy Room")
+	defer testpkg.CleanupActivityFixtures(t, db, activity.ID, room.ID)
+
+	activeGroup := testpkg.CreateTestActiveGroup(t, db, activity.ID, room.ID)
+	supervision := testpkg.CreateTestGroupSupervisor(t, db, supervisor.ID, activeGroup.ID, "supervisor")
+	visit := testpkg.CreateTestVisit(t, db, student.ID, activeGroup.ID, time.Now(), nil)
+	defer testpkg.CleanupActivityFixtures(t, db, visit.ID, supervision.ID, activeGroup.ID)
+
+	subject := policy.Subject{AccountID: supervisorAccount.ID, Roles: []string{"staff"}}
+	extra := map[string]interface{}{"student_id": student.ID}
+
+	// ACT
+	canView, err := p.Evaluate(context.Background(), &policy.Context{
+		Subject: subject, Resource: policy.Resource{Type: "student"}, Action: policy.ActionView, Extra: extra,
+	})
+	require.NoError(t, err)
+	canEdit, err := p.Evaluate(context.Background(), &policy.Context{
+		Subject: subject, Resource: policy.Resource{Type: "student"}, Action: policy.ActionEdit, Extra: extra,
+	})
+	require.NoError(t, err)
+
+	// ASSERT
+	assert.True(t, canView, "Session supervisor should see the student's sensitive data")
+	assert.False(t, canEdit, "Session supervisor should not be able to edit the student")
+}
+
+func TestStudentPolicy_UnrelatedTeacherCannotAccess(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	eduService, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewStudentPolicy(eduService, usersService, activeService)
+
+	// ARRANGE: Teacher of group A, student in group B
+	teacher, teacherAccount := testpkg.CreateTestTeacherWithAccount(t, db, "Unrelated", "Teacher")
+	defer testpkg.CleanupActivityFixtures(t, db, teacher.ID, teacher.Staff.ID, teacherAccount.ID)
+
+	groupA := testpkg.CreateTestEducationGroup(t, db, "Policy Class A")
+	testpkg.CreateTestGroupTeacher(t, db, groupA.ID, teacher.ID)
+	groupB := testpkg.CreateTestEducationGroup(t, db, "Policy Class B")
+	defer testpkg.CleanupActivityFixtures(t, db, groupA.ID, groupB.ID)
+
+	student, studentAccount := testpkg.CreateTestStudentWithAccount(t, db, "Other", "Student", "4b")
+	testpkg.AssignStudentToGroup(t, db, student.ID, groupB.ID)
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, studentAccount.ID)
+
+	// ACT
+	result, err := p.Evaluate(context.Background(), &policy.Context{
+		Subject:  policy.Subject{AccountID: teacherAccount.ID, Roles: []string{"teacher"}},
+		Resource: policy.Resource{Type: "student", ID: student.ID},
+		Action:   policy.ActionView,
+	})
+
+	// ASSERT
+	require.NoError(t, err)
+	assert.False(t, result, "Teacher should NOT see sensitive data of students outside their groups")
+}
+
+// =============================================================================
+// ActiveGroupPolicy / CombinedGroupPolicy Tests
+// =============================================================================
+
+func TestActiveGroupPolicy_SupervisorCanChange(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	_, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewActiveGroupPolicy(usersService, activeService)
+
+	// ARRANGE: One supervising staff member, one bystander
+	supervisor, supervisorAccount := testpkg.CreateTestStaffWithAccount(t, db, "Active", "Supervisor")
+	other, otherAccount := testpkg.CreateTestStaffWithAccount(t, db, "Other", "Staff")
+	defer testpkg.CleanupActivityFixtures(t, db, supervisor.ID, supervisorAccount.ID, other.ID, otherAccount.ID)
+
+	activity := testpkg.CreateTestActivityGroup(t, db, "Supervised Activity")
+	room := testpkg.CreateTestRoom(t, db, "Supervised Room")
+	defer testpkg.CleanupActivityFixtures(t, db, activity.ID, room.ID)
+
+	activeGroup := testpkg.CreateTestActiveGroup(t, db, activity.ID, room.ID)
+	supervision := testpkg.CreateTestGroupSupervisor(t, db, supervisor.ID, activeGroup.ID, "supervisor")
+	defer testpkg.CleanupActivityFixtures(t, db, supervision.ID, activeGroup.ID)
+
+	// ACT
+	supervisorAllowed, err := p.Evaluate(context.Background(), &policy.Context{
+		Subject:  policy.Subject{AccountID: supervisorAccount.ID, Roles: []string{"teacher"}},
+		Resource: policy.Resource{Type: "active_group", ID: activeGroup.ID},
+		Action:   policy.ActionEdit,
+	})
+	require.NoError(t, err)
+	otherAllowed, err := p.Evaluate(context.Background(), &policy.Context{
+		Subject:  policy.Subject{AccountID: otherAccount.ID, Roles: []string{"teacher"}, Permissions: []string{permissions.GroupsManage}},
+		Resource: policy.Resource{Type: "active_group", ID: activeGroup.ID},
+		Action:   policy.ActionDelete,
+	})
+	require.NoError(t, err)
+
+	// ASSERT
+	assert.True(t, supervisorAllowed, "Supervisor should be able to change the active group")
+	assert.False(t, otherAllowed, "Non-supervising staff should NOT be able to delete the active group")
+}
+
+func TestCombinedGroupPolicy_NonSupervisorCannotManage(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	_, usersService, activeService := setupPolicyServices(t, db)
+	p := policies.NewCombinedGroupPolicy(usersService, activeService)
+
+	staff, account := testpkg.CreateTestStaffWithAccount(t, db, "Combined", "Staff")
+	defer testpkg.CleanupActivityFixtures(t, db, staff.ID, account.ID)
+
+	authCtx := &policy.Context{
+		Subject:  policy.Subject{AccountID: account.ID, Roles: []string{"teacher"}},
+		Resource: policy.Resource{Type: "combined_group", ID: int64(99999)},
+		Action:   policy.ActionEdit,
+	}
+
+	// ACT: Staff without any supervision
+	result, err := p.Evaluate(context.Background(), authCtx)
+	require.NoError(t, err)
+	assert.False(t, result, "Staff without supervision should NOT manage combined groups")
+
+	// ACT: Admin role bypasses relationship checks
+	authCtx.Subject.Roles = []string{"admin"}
+	result, err = p.Evaluate(context.Background(), authCtx)
+	require.NoError(t, err)
+	assert.True(t, result, "Admin should always be able to manage combined groups")
+}
+
+func TestResourcePolicies_Metadata(t *testing.T) {
+	// Static metadata - no database needed
+	tests := []struct {
+		policy       policy.Policy
+		name         string
+		resourceType string
+	}{
+		{policies.NewStudentPolicy(nil, nil, nil), "student_access", "student"},
+		{policies.NewActiveGroupPolicy(nil, nil), "active_group_access", "active_group"},
+		{policies.NewCombinedGroupPolicy(nil, nil), "combined_group_access", "combined_group"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, tt.policy.Name())
+		assert.Equal(t, tt.resourceType, tt.policy.ResourceType())
+	}
+}
+
+func TestResourcePolicies_ViewIsUnrestrictedForGroups(t *testing.T) {
+	// Viewing active and combined groups is governed by permissions only
+	viewCtx := &policy.Context{
+		Subject:  policy.Subject{AccountID: 1, Roles: []string{"teacher"}},
+		Resource: policy.Resource{ID: int64(42)},
+		Action:   policy.ActionView,
+	}
+
+	for _, p := range []policy.Policy{policies.NewActiveGroupPolicy(nil, nil), policies.NewCombinedGroupPolicy(nil, nil)} {
+		result, err := p.Evaluate(context.Background(), viewCtx)
+		require.NoError(t, err)
+		assert.True(t, result, p.Name())
+	}
+}
@@ -0,0 +1,84 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/moto-nrw/project-phoenix/auth/authorize/policy"
+	"github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/moto-nrw/project-phoenix/services/education"
+	"github.com/moto-nrw/project-phoenix/services/users"
+)
+
+// StudentPolicy controls access to a student's sensitive data (health info, supervisor notes)
+// and to changing the student.
+//
+// Group teachers and substitutes of the student's OGS group may view and edit.
+// Staff supervising the session the student is currently in may view.
+type StudentPolicy struct {
+	educationService education.Service
+	usersService     users.PersonService
+	activeService    active.Service
+}
+
+// NewStudentPolicy creates a new student policy
+func NewStudentPolicy(
+	educationService education.Service,
+	usersService users.PersonService,
+	activeService active.Service,
+) policy.Policy {
+	return &StudentPolicy{
+		educationService: educationService,
+		usersService:     usersService,
+		activeService:    activeService,
+	}
+}
+
+// Name returns the name of this policy
+func (p *StudentPolicy) Name() string {
+	return "student_access"
+}
+
+// ResourceType returns the resource type this policy applies to
+func (p *StudentPolicy) ResourceType() string {
+	return "student"
+}
+
+// Evaluate evaluates whether the subject can access the student's sensitive data
+func (p *StudentPolicy) Evaluate(ctx context.Context, authCtx *policy.Context) (bool, error) {
+	if isAdmin(authCtx.Subject) {
+		return true, nil
+	}
+
+	studentID := resourceID(authCtx, "student_id")
+	if studentID == 0 {
+		return false, nil
+	}
+
+	student, err := p.usersService.StudentRepository().FindByID(ctx, studentID)
+	if err != nil || student == nil {
+		return false, nil
+	}
+
+	staff, teacher := resolveStaff(ctx, p.usersService, authCtx.Subject.AccountID)
+	if staff == nil {
+		return false, nil
+	}
+
+	// Group teachers and substitutes have full access
+	if student.GroupID != nil {
+		if allowed, err := teachesGroup(ctx, p.educationService, staff, teacher, *student.GroupID); err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	// Supervisors of the student's current session may only view
+	if authCtx.Action != policy.ActionView {
+		return false, nil
+	}
+
+	currentVisit, err := p.activeService.GetStudentCurrentVisit(ctx, studentID)
+	if err != nil || currentVisit == nil {
+		return false, nil
+	}
+	return supervisedActiveGroups(ctx, p.activeService, staff.ID)[currentVisit.ActiveGroupID], nil
+}
@@ -395,6 +395,7 @@ func TestPolicyRegistry_RegisterAll(t *testing.T) {
 	err := registry.RegisterAll(authService)
 	require.NoError(t, err)
 	assert.True(t, authService.registerCalled)
+	assert.Len(t, authService.policies, 4, "visit, student, active group and combined group policies")
 }
 
 // mockAuthService implements authorize.AuthorizationService for testing
@@ -50,6 +50,13 @@ func (ra *ResourceAuthorizer) RequiresResourceAccess(resourceType string, action
 	}
 }
 
+// HasResourceAccess evaluates resource policies for the requesting user from within a handler,
+// e.g. to decide which fields of a response may be included. Evaluation errors deny access.
+func (ra *ResourceAuthorizer) HasResourceAccess(r *http.Request, resource policy.Resource, action policy.Action, extra map[string]interface{}) bool {
+	allowed, err := ra.authService.AuthorizeResource(r.Context(), createSubjectFromContext(r), resource, action, extra)
+	return err == nil && allowed
+}
+
 // createSubjectFromContext creates a policy subject from JWT context
 func createSubjectFromContext(r *http.Request) policy.Subject {
 	claims := jwt.ClaimsFromCtx(r.Context())
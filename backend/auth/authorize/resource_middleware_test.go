@@ -145,6 +145,44 @@ func TestResourceAuthorizer_RequiresResourceAccess(t *testing.T) {
 	}
 }
 
+func TestResourceAuthorizer_HasResourceAccess(t *testing.T) {
+	tests := []struct {
+		name        string
+		policyAllow bool
+		policyError bool
+		expected    bool
+	}{
+		{name: "allowed when policy allows", policyAllow: true, expected: true},
+		{name: "denied when policy denies", policyAllow: false, expected: false},
+		{name: "denied when policy errors", policyError: true, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// ARRANGE
+			authService := authorize.NewAuthorizationService()
+			err := authService.RegisterPolicy(&TestAllowPolicy{
+				allowResult:  tt.policyAllow,
+				shouldError:  tt.policyError,
+				errorMsg:     "policy failure",
+				resourceType: "student",
+			})
+			assert.NoError(t, err)
+			authorizer := authorize.NewResourceAuthorizer(authService)
+
+			req := httptest.NewRequest("GET", "/students/42", nil)
+			ctx := context.WithValue(req.Context(), jwt.CtxClaims, jwt.AppClaims{ID: 1, Roles: []string{"teacher"}})
+			req = req.WithContext(ctx)
+
+			// ACT
+			allowed := authorizer.HasResourceAccess(req, policy.Resource{Type: "student", ID: int64(42)}, policy.ActionView, nil)
+
+			// ASSERT
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
 // TestResourceExtractors tests URL parameter extraction logic.
 // This is a pure unit test - no mocks or database needed.
 func TestResourceExtractors(t *testing.T) {
@@ -85,8 +85,9 @@ func extractAndValidateAPIKey(r *http.Request, iotService iotSvc.Service) (*iot.
 		return nil, ErrDeviceUnauthorized(ErrInvalidAPIKey)
 	}
 
-	// Check if device is active
-	if !device.IsActive() {
+	// Inactive and maintenance devices are locked out. Offline only means the health check missed
+	// heartbeats; such a device comes back online with this request (see updateDeviceLastSeen).
+	if !device.IsActive() && !device.IsOffline() {
 		slog.Warn("device authentication failed: device not active",
 			slog.String("status", string(device.Status)),
 		)
@@ -96,9 +97,13 @@ func extractAndValidateAPIKey(r *http.Request, iotService iotSvc.Service) (*iot.
 	return device, nil
 }
 
-// updateDeviceLastSeen updates the device's last seen timestamp, logging any errors.
+// updateDeviceLastSeen updates the device's last seen timestamp and brings offline devices back
+// online, logging any errors.
 func updateDeviceLastSeen(r *http.Request, iotService iotSvc.Service, device *iot.Device) {
 	device.UpdateLastSeen()
+	if device.IsOffline() {
+		device.Status = iot.DeviceStatusActive
+	}
 	if err := iotService.UpdateDevice(r.Context(), device); err != nil {
 		slog.Warn("failed to update device last seen time",
 			slog.String("error", err.Error()),
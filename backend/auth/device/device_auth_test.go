@@ -12,6 +12,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/moto-nrw/project-phoenix/models/iot"
 	"github.com/moto-nrw/project-phoenix/models/users"
+	iotSvc "github.com/moto-nrw/project-phoenix/services/iot"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
@@ -89,6 +90,30 @@ func (m *mockIoTService) GetOfflineDevices(_ context.Context, _ time.Duration) (
 func (m *mockIoTService) GetDeviceTypeStatistics(_ context.Context) (map[string]int, error) {
 	return nil, nil
 }
+func (m *mockIoTService) CheckDeviceHealth(_ context.Context, _ time.Duration) (*iotSvc.DeviceHealthResult, error) {
+	return nil, nil
+}
+func (m *mockIoTService) GetDeviceUptime(_ context.Context, _ int64, _ time.Time) (*iotSvc.DeviceUptime, error) {
+	return nil, nil
+}
+func (m *mockIoTService) CleanupDeviceEvents(_ context.Context, _ time.Duration) (int, error) {
+	return 0, nil
+}
+func (m *mockIoTService) CreateEnrollmentCode(_ context.Context, _ iotSvc.EnrollmentCodeRequest, _ iotSvc.KeyActor) (*iotSvc.EnrollmentCode, error) {
+	return nil, nil
+}
+func (m *mockIoTService) ListPendingEnrollments(_ context.Context) ([]*iot.DeviceEnrollment, error) {
+	return nil, nil
+}
+func (m *mockIoTService) EnrollDevice(_ context.Context, _ string, _ string) (*iotSvc.DeviceCredentials, error) {
+	return nil, nil
+}
+func (m *mockIoTService) RotateAPIKey(_ context.Context, _ int64, _ time.Duration, _ iotSvc.KeyActor) (*iotSvc.DeviceCredentials, error) {
+	return nil, nil
+}
+func (m *mockIoTService) RevokeAPIKey(_ context.Context, _ int64, _ iotSvc.KeyActor) error {
+	return nil
+}
 func (m *mockIoTService) DetectNewDevices(_ context.Context) ([]*iot.Device, error) { return nil, nil }
 func (m *mockIoTService) ScanNetwork(_ context.Context) (map[string]string, error)  { return nil, nil }
 
@@ -333,7 +358,7 @@ func TestDeviceOnlyAuthenticator_OfflineDevice(t *testing.T) {
 	device := &iot.Device{
 		DeviceID:   "device-001",
 		DeviceType: "rfid_reader",
-		Status:     iot.DeviceStatusOffline, // Offline
+		Status:     iot.DeviceStatusOffline, // Missed heartbeats, not locked out
 	}
 	mockService.addDevice(apiKey, device)
 
@@ -348,7 +373,8 @@ func TestDeviceOnlyAuthenticator_OfflineDevice(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	r.ServeHTTP(rr, req)
-	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, iot.DeviceStatusActive, device.Status, "authenticated offline devices come back online")
 }
 
 func TestDeviceOnlyAuthenticator_MaintenanceDevice(t *testing.T) {
@@ -573,6 +599,64 @@ func TestDeviceAuthenticator_InactiveDevice(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rr.Code)
 }
 
+func TestDeviceAuthenticator_OfflineDeviceComesBackOnline(t *testing.T) {
+	require.NoError(t, os.Setenv("OGS_DEVICE_PIN", "test-pin"))
+	defer func() { _ = os.Unsetenv("OGS_DEVICE_PIN") }()
+
+	mockIoT := newMockIoTService()
+	apiKey := "valid-api-key-123"
+	device := &iot.Device{
+		DeviceID:   "device-001",
+		DeviceType: "rfid_reader",
+		Status:     iot.DeviceStatusOffline, // Marked offline by the health check
+	}
+	mockIoT.addDevice(apiKey, device)
+
+	r := chi.NewRouter()
+	r.Use(DeviceAuthenticator(mockIoT, nil))
+	r.Post("/checkin", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/checkin", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("X-Staff-PIN", "test-pin")
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, mockIoT.updateCalled)
+	assert.Equal(t, iot.DeviceStatusActive, device.Status)
+	assert.NotNil(t, device.LastSeen)
+}
+
+func TestDeviceAuthenticator_MaintenanceDevice(t *testing.T) {
+	require.NoError(t, os.Setenv("OGS_DEVICE_PIN", "test-pin"))
+	defer func() { _ = os.Unsetenv("OGS_DEVICE_PIN") }()
+
+	mockIoT := newMockIoTService()
+	apiKey := "valid-api-key-123"
+	mockIoT.addDevice(apiKey, &iot.Device{
+		DeviceID:   "device-001",
+		DeviceType: "rfid_reader",
+		Status:     iot.DeviceStatusMaintenance,
+	})
+
+	r := chi.NewRouter()
+	r.Use(DeviceAuthenticator(mockIoT, nil))
+	r.Post("/checkin", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/checkin", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("X-Staff-PIN", "test-pin")
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
 // =============================================================================
 // Error Response Tests
 // =============================================================================
@@ -51,44 +51,83 @@ func RefreshTokenFromCtx(ctx context.Context) string {
 // Authenticator is a default authentication middleware to enforce access from the
 // Verifier middleware request context values. The Authenticator sends a 401 Unauthorized
 // response for any unverified tokens and passes the good ones through.
+// Parent portal tokens are rejected; they are only accepted by ParentAuthenticator.
 func Authenticator(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token, claims, err := jwtauth.FromContext(r.Context())
-
-		if err != nil {
-			slog.Warn("JWT error", slog.String("error", err.Error()))
-			_ = render.Render(w, r, ErrUnauthorized(ErrTokenUnauthorized))
+		c, ok := verifiedAppClaims(w, r)
+		if !ok {
 			return
 		}
 
-		if token == nil {
-			slog.Warn("no token found in context")
-			renderUnauthorized(w, r, ErrTokenUnauthorized)
+		// Parent token IDs reference auth.accounts_parents, not auth.accounts
+		if c.IsParentScope() {
+			slog.Warn("parent token used on staff route", slog.Int("parent_account_id", c.ID))
+			renderUnauthorized(w, r, ErrInvalidAccessToken)
 			return
 		}
 
-		if err := jwt.Validate(token); err != nil {
-			slog.Warn("token validation failed", slog.String("error", err.Error()))
-			renderUnauthorized(w, r, ErrTokenExpired)
+		next.ServeHTTP(w, r.WithContext(withAppClaims(r.Context(), c)))
+	})
+}
+
+// ParentAuthenticator enforces a verified parent portal token.
+// It sends a 401 Unauthorized response for any other token.
+func ParentAuthenticator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := verifiedAppClaims(w, r)
+		if !ok {
 			return
 		}
 
-		// Token is authenticated, parse claims
-		var c AppClaims
-		if err := c.ParseClaims(claims); err != nil {
-			slog.Error("failed to parse claims", slog.String("error", err.Error()))
+		if !c.IsParentScope() {
 			renderUnauthorized(w, r, ErrInvalidAccessToken)
 			return
 		}
 
-		// Set AppClaims and permissions on context
-		ctx := context.WithValue(r.Context(), CtxClaims, c)
-		ctx = context.WithValue(ctx, CtxPermissions, c.Permissions)
-
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(withAppClaims(r.Context(), c)))
 	})
 }
 
+// verifiedAppClaims validates the token set by the Verifier middleware and parses its claims.
+// On failure it writes a 401 response and returns false.
+func verifiedAppClaims(w http.ResponseWriter, r *http.Request) (AppClaims, bool) {
+	token, claims, err := jwtauth.FromContext(r.Context())
+
+	if err != nil {
+		slog.Warn("JWT error", slog.String("error", err.Error()))
+		_ = render.Render(w, r, ErrUnauthorized(ErrTokenUnauthorized))
+		return AppClaims{}, false
+	}
+
+	if token == nil {
+		slog.Warn("no token found in context")
+		renderUnauthorized(w, r, ErrTokenUnauthorized)
+		return AppClaims{}, false
+	}
+
+	if err := jwt.Validate(token); err != nil {
+		slog.Warn("token validation failed", slog.String("error", err.Error()))
+		renderUnauthorized(w, r, ErrTokenExpired)
+		return AppClaims{}, false
+	}
+
+	// Token is authenticated, parse claims
+	var c AppClaims
+	if err := c.ParseClaims(claims); err != nil {
+		slog.Error("failed to parse claims", slog.String("error", err.Error()))
+		renderUnauthorized(w, r, ErrInvalidAccessToken)
+		return AppClaims{}, false
+	}
+
+	return c, true
+}
+
+// withAppClaims sets AppClaims and permissions on the context
+func withAppClaims(ctx context.Context, c AppClaims) context.Context {
+	ctx = context.WithValue(ctx, CtxClaims, c)
+	return context.WithValue(ctx, CtxPermissions, c.Permissions)
+}
+
 // renderUnauthorized renders an unauthorized response with fallback to http.Error
 func renderUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
 	if render.Render(w, r, ErrUnauthorized(err)) != nil {
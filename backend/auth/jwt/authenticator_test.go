@@ -721,3 +721,64 @@ func TestAuthenticateRefreshJWT_NilToken(t *testing.T) {
 	r.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 }
+
+// =============================================================================
+// Parent Scope Tests
+// =============================================================================
+
+// serveWithScope issues a token with the given scope and runs it through the middleware.
+func serveWithScope(t *testing.T, middleware func(http.Handler) http.Handler, scope string) int {
+	t.Helper()
+	viper.Set("auth_jwt_expiry", 15*time.Minute)
+	viper.Set("auth_jwt_refresh_expiry", 24*time.Hour)
+
+	auth, err := NewTokenAuthWithSecret(testSecret)
+	require.NoError(t, err)
+
+	token, err := auth.CreateJWT(AppClaims{
+		ID:    42,
+		Sub:   "parent:42",
+		Roles: []string{"guardian"},
+		Scope: scope,
+	})
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(auth.Verifier())
+	r.Use(middleware)
+	r.Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, scope, ClaimsFromCtx(r.Context()).Scope)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func TestAuthenticator_RejectsParentScope(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveWithScope(t, Authenticator, ScopeParent))
+}
+
+func TestParentAuthenticator_AcceptsParentScope(t *testing.T) {
+	assert.Equal(t, http.StatusOK, serveWithScope(t, ParentAuthenticator, ScopeParent))
+}
+
+func TestParentAuthenticator_RejectsOtherScopes(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveWithScope(t, ParentAuthenticator, ""))
+	assert.Equal(t, http.StatusUnauthorized, serveWithScope(t, ParentAuthenticator, ScopePlatform))
+}
+
+func TestParentAuthenticator_NoToken(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(ParentAuthenticator)
+	r.Get("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
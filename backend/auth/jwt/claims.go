@@ -23,16 +23,29 @@ type AppClaims struct {
 	Permissions []string `json:"permissions,omitempty"` // Added permissions field
 	// Static role flags for quick access
 	IsAdmin bool `json:"is_admin,omitempty"`
-	// Scope distinguishes tenant tokens from platform tokens
+	// Scope distinguishes tenant tokens from platform and parent tokens
 	// "platform" = operator tokens (moto DevOps team)
+	// "parent" = guardian portal tokens (ID is an auth.accounts_parents ID)
 	// "" or "tenant" = regular user tokens
 	Scope string `json:"scope,omitempty"`
 	CommonClaims
 }
 
+// Token scopes
+const (
+	ScopeTenant   = "tenant"
+	ScopePlatform = "platform"
+	ScopeParent   = "parent"
+)
+
 // IsPlatformScope returns true if this is a platform/operator token
 func (c *AppClaims) IsPlatformScope() bool {
-	return c.Scope == "platform"
+	return c.Scope == ScopePlatform
+}
+
+// IsParentScope returns true if this is a guardian portal token
+func (c *AppClaims) IsParentScope() bool {
+	return c.Scope == ScopeParent
 }
 
 // Error format for missing claims
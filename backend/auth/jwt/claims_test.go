@@ -563,3 +563,20 @@ func TestAppClaims_IsPlatformScope_False_Other(t *testing.T) {
 	c := &AppClaims{Scope: "other"}
 	assert.False(t, c.IsPlatformScope())
 }
+
+// =============================================================================
+// IsParentScope Tests
+// =============================================================================
+
+func TestAppClaims_IsParentScope_True(t *testing.T) {
+	c := &AppClaims{Scope: ScopeParent}
+	assert.True(t, c.IsParentScope())
+	assert.False(t, c.IsPlatformScope())
+}
+
+func TestAppClaims_IsParentScope_False(t *testing.T) {
+	for _, scope := range []string{"", ScopeTenant, ScopePlatform} {
+		c := &AppClaims{Scope: scope}
+		assert.False(t, c.IsParentScope(), "scope %q", scope)
+	}
+}
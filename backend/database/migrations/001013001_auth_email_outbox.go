@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	authEmailOutboxVersion     = "1.13.1"
+	authEmailOutboxDescription = "Create auth.email_outbox table for durable email delivery"
+)
+
+func init() {
+	MigrationRegistry[authEmailOutboxVersion] = &Migration{
+		Version:     authEmailOutboxVersion,
+		Description: authEmailOutboxDescription,
+		DependsOn:   []string{SchemasVersion}, // Requires the auth schema
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createAuthEmailOutbox(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropAuthEmailOutbox(ctx, db)
+		},
+	)
+}
+
+func createAuthEmailOutbox(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.1: Creating auth.email_outbox table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS auth.email_outbox (
+			id              BIGSERIAL PRIMARY KEY,
+			delivery_type   VARCHAR(50) NOT NULL,
+			reference_id    BIGINT NOT NULL DEFAULT 0,
+			base_retry      INTEGER NOT NULL DEFAULT 0,
+			recipient       TEXT NOT NULL,
+			payload         JSONB NOT NULL,
+			status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+			attempts        INTEGER NOT NULL DEFAULT 0,
+			max_attempts    INTEGER NOT NULL,
+			backoff_seconds INTEGER[],
+			next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			locked_until    TIMESTAMPTZ,
+			last_error      TEXT,
+			sent_at         TIMESTAMPTZ,
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_email_outbox_status CHECK (status IN ('pending','sent','failed')),
+			CONSTRAINT chk_email_outbox_attempts CHECK (attempts >= 0 AND max_attempts > 0)
+		);
+
+		-- Worker claim query: due pending rows in order
+		CREATE INDEX IF NOT EXISTS idx_email_outbox_due
+			ON auth.email_outbox(next_attempt_at)
+			WHERE status = 'pending';
+
+		-- Delivery status per type (admin stats) and lookups per referenced record
+		CREATE INDEX IF NOT EXISTS idx_email_outbox_type_status ON auth.email_outbox(delivery_type, status);
+		CREATE INDEX IF NOT EXISTS idx_email_outbox_reference ON auth.email_outbox(delivery_type, reference_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating email_outbox table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.1: Successfully created auth.email_outbox table")
+	return tx.Commit()
+}
+
+func dropAuthEmailOutbox(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.1: Dropping auth.email_outbox table...")
+
+	_, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS auth.email_outbox CASCADE;`)
+	if err != nil {
+		return fmt.Errorf("error dropping email_outbox table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.1: Successfully rolled back")
+	return nil
+}
@@ -0,0 +1,97 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	pickupExceptionsGuardianAuthorVersion     = "1.13.2"
+	pickupExceptionsGuardianAuthorDescription = "Allow guardians to author pickup exceptions from the parent portal"
+)
+
+func init() {
+	MigrationRegistry[pickupExceptionsGuardianAuthorVersion] = &Migration{
+		Version:     pickupExceptionsGuardianAuthorVersion,
+		Description: pickupExceptionsGuardianAuthorDescription,
+		DependsOn:   []string{"1.0.1"}, // Depends on schema creation (pickup schedules at 1.8.1 runs before by file order)
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return addPickupExceptionGuardianAuthor(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return rollbackPickupExceptionGuardianAuthor(ctx, db)
+		},
+	)
+}
+
+func addPickupExceptionGuardianAuthor(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.2: Adding guardian author to schedule.student_pickup_exceptions...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		-- Cascade: an exception without its author would violate chk_pickup_exception_author
+		ALTER TABLE schedule.student_pickup_exceptions
+			ADD COLUMN IF NOT EXISTS created_by_guardian_id BIGINT
+				REFERENCES users.guardian_profiles(id) ON DELETE CASCADE,
+			ALTER COLUMN created_by DROP NOT NULL;
+
+		-- Every exception is authored by either a staff member or a guardian
+		ALTER TABLE schedule.student_pickup_exceptions
+			DROP CONSTRAINT IF EXISTS chk_pickup_exception_author;
+		ALTER TABLE schedule.student_pickup_exceptions
+			ADD CONSTRAINT chk_pickup_exception_author
+			CHECK (created_by IS NOT NULL OR created_by_guardian_id IS NOT NULL);
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding guardian author to student_pickup_exceptions: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.2: Successfully added guardian author to student_pickup_exceptions")
+	return tx.Commit()
+}
+
+func rollbackPickupExceptionGuardianAuthor(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.2: Removing guardian author from schedule.student_pickup_exceptions...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Guardian-authored exceptions cannot satisfy the staff NOT NULL constraint
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM schedule.student_pickup_exceptions WHERE created_by IS NULL;
+
+		ALTER TABLE schedule.student_pickup_exceptions
+			DROP CONSTRAINT IF EXISTS chk_pickup_exception_author,
+			DROP COLUMN IF EXISTS created_by_guardian_id,
+			ALTER COLUMN created_by SET NOT NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("error removing guardian author from student_pickup_exceptions: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.2: Successfully rolled back")
+	return tx.Commit()
+}
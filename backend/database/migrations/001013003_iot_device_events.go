@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	iotDeviceEventsVersion     = "1.13.3"
+	iotDeviceEventsDescription = "Create iot.device_events table for device status history"
+)
+
+func init() {
+	MigrationRegistry[iotDeviceEventsVersion] = &Migration{
+		Version:     iotDeviceEventsVersion,
+		Description: iotDeviceEventsDescription,
+		DependsOn:   []string{SchemasVersion}, // Requires the iot schema; iot.devices exists since 1.3.9
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createIoTDeviceEvents(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropIoTDeviceEvents(ctx, db)
+		},
+	)
+}
+
+func createIoTDeviceEvents(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.3: Creating iot.device_events table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS iot.device_events (
+			id          BIGSERIAL PRIMARY KEY,
+			device_id   BIGINT NOT NULL REFERENCES iot.devices(id) ON DELETE CASCADE,
+			from_status device_status NOT NULL,
+			to_status   device_status NOT NULL,
+			reason      VARCHAR(50) NOT NULL,
+			last_seen   TIMESTAMPTZ,
+			occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_device_events_transition CHECK (from_status <> to_status)
+		);
+
+		-- Uptime and history queries per device
+		CREATE INDEX IF NOT EXISTS idx_device_events_device_occurred
+			ON iot.device_events(device_id, occurred_at);
+
+		-- Retention cleanup
+		CREATE INDEX IF NOT EXISTS idx_device_events_occurred ON iot.device_events(occurred_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating device_events table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.3: Successfully created iot.device_events table")
+	return tx.Commit()
+}
+
+func dropIoTDeviceEvents(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.3: Dropping iot.device_events table...")
+
+	_, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS iot.device_events CASCADE;`)
+	if err != nil {
+		return fmt.Errorf("error dropping device_events table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.3: Successfully rolled back")
+	return nil
+}
@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	iotDeviceProvisioningVersion     = "1.13.4"
+	iotDeviceProvisioningDescription = "Create device enrollment codes, API key grace period and device key audit log"
+)
+
+func init() {
+	MigrationRegistry[iotDeviceProvisioningVersion] = &Migration{
+		Version:     iotDeviceProvisioningVersion,
+		Description: iotDeviceProvisioningDescription,
+		DependsOn:   []string{SchemasVersion}, // Requires the iot and audit schemas; iot.devices exists since 1.3.9
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createIoTDeviceProvisioning(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropIoTDeviceProvisioning(ctx, db)
+		},
+	)
+}
+
+func createIoTDeviceProvisioning(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.4: Creating device provisioning tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// The previous key stays valid until previous_api_key_expires_at after a rotation
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE iot.devices
+			ADD COLUMN IF NOT EXISTS previous_api_key VARCHAR(255) UNIQUE,
+			ADD COLUMN IF NOT EXISTS previous_api_key_expires_at TIMESTAMPTZ;
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding previous API key columns to iot.devices: %w", err)
+	}
+
+	// Only the SHA-256 hash of an enrollment code is stored
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS iot.device_enrollments (
+			id                    BIGSERIAL PRIMARY KEY,
+			code_hash             VARCHAR(64) NOT NULL UNIQUE,
+			device_id             TEXT NOT NULL,
+			device_type           TEXT NOT NULL,
+			name                  TEXT,
+			created_by_account_id BIGINT REFERENCES auth.accounts(id) ON DELETE SET NULL,
+			expires_at            TIMESTAMPTZ NOT NULL,
+			used_at               TIMESTAMPTZ,
+			enrolled_device_id    BIGINT REFERENCES iot.devices(id) ON DELETE SET NULL,
+			created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+
+		-- Pending enrollment listing
+		CREATE INDEX IF NOT EXISTS idx_device_enrollments_pending
+			ON iot.device_enrollments(expires_at) WHERE used_at IS NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating device_enrollments table: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS audit.device_key_events (
+			id                BIGSERIAL PRIMARY KEY,
+			device_id         BIGINT REFERENCES iot.devices(id) ON DELETE SET NULL,
+			device_identifier TEXT NOT NULL,
+			event_type        VARCHAR(50) NOT NULL,
+			account_id        BIGINT REFERENCES auth.accounts(id) ON DELETE SET NULL,
+			ip_address        TEXT,
+			metadata          JSONB DEFAULT '{}',
+			created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_device_key_events_device
+			ON audit.device_key_events(device_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_device_key_events_created
+			ON audit.device_key_events(created_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating audit.device_key_events table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.4: Successfully created device provisioning tables")
+	return tx.Commit()
+}
+
+func dropIoTDeviceProvisioning(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.4: Dropping device provisioning tables...")
+
+	_, err := db.ExecContext(ctx, `
+		DROP TABLE IF EXISTS audit.device_key_events CASCADE;
+		DROP TABLE IF EXISTS iot.device_enrollments CASCADE;
+		ALTER TABLE iot.devices
+			DROP COLUMN IF EXISTS previous_api_key_expires_at,
+			DROP COLUMN IF EXISTS previous_api_key;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping device provisioning tables: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.4: Successfully rolled back")
+	return nil
+}
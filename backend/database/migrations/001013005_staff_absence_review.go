@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	staffAbsenceReviewVersion     = "1.13.5"
+	staffAbsenceReviewDescription = "Add staff absence decline reason, review queue index and time_tracking:manage permission"
+)
+
+func init() {
+	MigrationRegistry[staffAbsenceReviewVersion] = &Migration{
+		Version:     staffAbsenceReviewVersion,
+		Description: staffAbsenceReviewDescription,
+		DependsOn:   []string{"1.10.7", "1.10.2"}, // Depends on active.staff_absences and the time tracking permissions
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return addStaffAbsenceReview(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return removeStaffAbsenceReview(ctx, db)
+		},
+	)
+}
+
+func addStaffAbsenceReview(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.5: Adding staff absence review...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// approved_by/approved_at record the reviewer for both decisions; declines also keep a reason
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE active.staff_absences
+			ADD COLUMN IF NOT EXISTS decline_reason TEXT;
+
+		-- Review queue
+		CREATE INDEX IF NOT EXISTS idx_staff_absences_reported
+			ON active.staff_absences(date_start) WHERE status = 'reported';
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding decline reason to active.staff_absences: %w", err)
+	}
+
+	// Absences recorded before the review existed were never reviewed but always counted as
+	// taken; approve them so working time exports keep counting them
+	_, err = tx.ExecContext(ctx, `
+		UPDATE active.staff_absences
+		SET status = 'approved', approved_at = COALESCE(approved_at, updated_at)
+		WHERE status = 'reported'
+	`)
+	if err != nil {
+		return fmt.Errorf("error approving existing staff absences: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth.permissions (name, description, resource, action)
+		VALUES
+			('time_tracking:manage', 'Review staff absences and working time', 'time_tracking', 'manage')
+		ON CONFLICT (name) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("error inserting time tracking manage permission: %w", err)
+	}
+
+	// Only the Leitung (admin role) reviews absences by default
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth.role_permissions (role_id, permission_id)
+		SELECT r.id, p.id
+		FROM auth.roles r
+		CROSS JOIN auth.permissions p
+		WHERE p.name = 'time_tracking:manage'
+		  AND r.name = 'admin'
+		ON CONFLICT (role_id, permission_id) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("error granting time tracking manage permission: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.5: Successfully added staff absence review")
+	return tx.Commit()
+}
+
+func removeStaffAbsenceReview(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.5: Removing staff absence review...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM auth.role_permissions
+		WHERE permission_id IN (
+			SELECT id FROM auth.permissions WHERE name = 'time_tracking:manage'
+		);
+		DELETE FROM auth.permissions WHERE name = 'time_tracking:manage';
+	`)
+	if err != nil {
+		return fmt.Errorf("error removing time tracking manage permission: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DROP INDEX IF EXISTS active.idx_staff_absences_reported;
+		ALTER TABLE active.staff_absences DROP COLUMN IF EXISTS decline_reason;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping decline reason column: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.5: Successfully rolled back")
+	return tx.Commit()
+}
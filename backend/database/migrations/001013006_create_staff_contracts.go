@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	staffContractsVersion     = "1.13.6"
+	staffContractsDescription = "Create active.staff_contracts table for contracted weekly hours"
+)
+
+func init() {
+	MigrationRegistry[staffContractsVersion] = &Migration{
+		Version:     staffContractsVersion,
+		Description: staffContractsDescription,
+		DependsOn:   []string{"1.10.7"}, // Depends on staff_absences (time tracking tables)
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createStaffContracts(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropStaffContracts(ctx, db)
+		},
+	)
+}
+
+func createStaffContracts(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.6: Creating active.staff_contracts table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// One row per change of contracted hours; the latest valid_from on or before a date applies
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS active.staff_contracts (
+			id             BIGSERIAL PRIMARY KEY,
+			staff_id       BIGINT NOT NULL REFERENCES users.staff(id) ON DELETE CASCADE,
+			weekly_minutes INTEGER NOT NULL,
+			valid_from     DATE NOT NULL,
+			note           TEXT,
+			created_by     BIGINT NOT NULL REFERENCES users.staff(id),
+			created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_sc_weekly_minutes CHECK (weekly_minutes BETWEEN 0 AND 2880),
+			CONSTRAINT uq_sc_staff_valid_from UNIQUE (staff_id, valid_from)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_sc_valid_from ON active.staff_contracts(valid_from);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating staff_contracts table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.6: Successfully created active.staff_contracts table")
+	return tx.Commit()
+}
+
+func dropStaffContracts(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.6: Dropping active.staff_contracts table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS active.staff_contracts CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping staff_contracts table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.6: Successfully rolled back")
+	return tx.Commit()
+}
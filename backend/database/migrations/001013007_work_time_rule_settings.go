@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	workTimeRuleSettingsVersion     = "1.13.7"
+	workTimeRuleSettingsDescription = "Seed ArbZG working time rule settings"
+)
+
+func init() {
+	MigrationRegistry[workTimeRuleSettingsVersion] = &Migration{
+		Version:     workTimeRuleSettingsVersion,
+		Description: workTimeRuleSettingsDescription,
+		DependsOn:   []string{"1.6.1"}, // Depends on config.settings
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return seedWorkTimeRuleSettings(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return removeWorkTimeRuleSettings(ctx, db)
+		},
+	)
+}
+
+func seedWorkTimeRuleSettings(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.7: Seeding working time rule settings...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Statutory ArbZG thresholds; existing values are kept
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO config.settings (key, value, category, description, requires_restart, requires_db_reset)
+		VALUES
+			('arbzg_break_threshold_minutes', '360', 'time_tracking', 'Working minutes after which a break is required', FALSE, FALSE),
+			('arbzg_break_minutes', '30', 'time_tracking', 'Required break minutes after the break threshold', FALSE, FALSE),
+			('arbzg_long_break_threshold_minutes', '540', 'time_tracking', 'Working minutes after which the longer break is required', FALSE, FALSE),
+			('arbzg_long_break_minutes', '45', 'time_tracking', 'Required break minutes after the long break threshold', FALSE, FALSE),
+			('arbzg_max_daily_minutes', '600', 'time_tracking', 'Maximum daily working minutes', FALSE, FALSE),
+			('arbzg_min_rest_minutes', '660', 'time_tracking', 'Minimum rest minutes between two shifts', FALSE, FALSE)
+		ON CONFLICT (key) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("error inserting working time rule settings: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.7: Successfully seeded working time rule settings")
+	return tx.Commit()
+}
+
+func removeWorkTimeRuleSettings(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.7: Removing working time rule settings...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM config.settings WHERE key LIKE 'arbzg\_%'
+	`)
+	if err != nil {
+		return fmt.Errorf("error removing working time rule settings: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.7: Successfully rolled back")
+	return tx.Commit()
+}
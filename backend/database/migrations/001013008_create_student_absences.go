@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	studentAbsencesVersion     = "1.13.8"
+	studentAbsencesDescription = "Create active.student_absences table for reported and planned student absences"
+)
+
+func init() {
+	MigrationRegistry[studentAbsencesVersion] = &Migration{
+		Version:     studentAbsencesVersion,
+		Description: studentAbsencesDescription,
+		DependsOn:   []string{"1.3.5", "1.2.3", "1.3.5.1"}, // Depends on users.students, users.staff and users.guardian_profiles
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createStudentAbsences(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropStudentAbsences(ctx, db)
+		},
+	)
+}
+
+func createStudentAbsences(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.8: Creating active.student_absences table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Every absence is reported by either a staff member or a guardian
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS active.student_absences (
+			id                      BIGSERIAL PRIMARY KEY,
+			student_id              BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			absence_type            TEXT NOT NULL,
+			date_start              DATE NOT NULL,
+			date_end                DATE NOT NULL,
+			note                    TEXT,
+			guardian_note           TEXT,
+			reported_by             BIGINT REFERENCES users.staff(id) ON DELETE SET NULL,
+			reported_by_guardian_id BIGINT REFERENCES users.guardian_profiles(id) ON DELETE SET NULL,
+			created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_student_absence_type CHECK (absence_type IN ('sick', 'excused', 'trip', 'vacation')),
+			CONSTRAINT chk_student_absence_dates CHECK (date_start <= date_end)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_student_absences_student_dates ON active.student_absences(student_id, date_start, date_end);
+		CREATE INDEX IF NOT EXISTS idx_student_absences_dates ON active.student_absences(date_start, date_end);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating student_absences table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.8: Successfully created active.student_absences table")
+	return tx.Commit()
+}
+
+func dropStudentAbsences(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.8: Dropping active.student_absences table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS active.student_absences CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping student_absences table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.8: Successfully rolled back")
+	return tx.Commit()
+}
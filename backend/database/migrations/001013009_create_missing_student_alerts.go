@@ -0,0 +1,99 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	missingStudentAlertsVersion     = "1.13.9"
+	missingStudentAlertsDescription = "Create active.missing_student_alerts table for expected students who did not check in"
+)
+
+func init() {
+	MigrationRegistry[missingStudentAlertsVersion] = &Migration{
+		Version:     missingStudentAlertsVersion,
+		Description: missingStudentAlertsDescription,
+		DependsOn:   []string{"1.3.5", "1.2.7", "1.2.3"}, // Depends on users.students, education.groups and users.staff
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createMissingStudentAlerts(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropMissingStudentAlerts(ctx, db)
+		},
+	)
+}
+
+func createMissingStudentAlerts(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.9: Creating active.missing_student_alerts table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// One alert per student and day; resolved alerts keep the resolution log
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS active.missing_student_alerts (
+			id              BIGSERIAL PRIMARY KEY,
+			student_id      BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			group_id        BIGINT REFERENCES education.groups(id) ON DELETE SET NULL,
+			alert_date      DATE NOT NULL,
+			expected_by     TIMESTAMPTZ NOT NULL,
+			status          TEXT NOT NULL DEFAULT 'open',
+			resolution      TEXT,
+			resolution_note TEXT,
+			resolved_by     BIGINT REFERENCES users.staff(id) ON DELETE SET NULL,
+			resolved_at     TIMESTAMPTZ,
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_missing_alert_status CHECK (status IN ('open', 'resolved')),
+			CONSTRAINT chk_missing_alert_resolution CHECK (resolution IS NULL OR resolution = '' OR resolution IN ('found', 'sick', 'went_home')),
+			CONSTRAINT uq_missing_alert_student_date UNIQUE (student_id, alert_date)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_missing_student_alerts_date ON active.missing_student_alerts(alert_date);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating missing_student_alerts table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.9: Successfully created active.missing_student_alerts table")
+	return tx.Commit()
+}
+
+func dropMissingStudentAlerts(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.9: Dropping active.missing_student_alerts table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS active.missing_student_alerts CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping missing_student_alerts table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.9: Successfully rolled back")
+	return tx.Commit()
+}
@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	studentPickupPersonsVersion     = "1.13.10"
+	studentPickupPersonsDescription = "Create users.student_pickup_persons table and record who picked a student up on active.attendance"
+)
+
+func init() {
+	MigrationRegistry[studentPickupPersonsVersion] = &Migration{
+		Version:     studentPickupPersonsVersion,
+		Description: studentPickupPersonsDescription,
+		DependsOn:   []string{"1.3.5", "1.3.5.1", "1.2.3", "1.6.5"}, // Depends on users.students, users.guardian_profiles, users.staff and active.attendance
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createStudentPickupPersons(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropStudentPickupPersons(ctx, db)
+		},
+	)
+}
+
+func createStudentPickupPersons(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.10: Creating users.student_pickup_persons table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Named persons besides the guardians; is_authorized = false flags persons who must not collect the child
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS users.student_pickup_persons (
+			id            BIGSERIAL PRIMARY KEY,
+			student_id    BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			first_name    TEXT NOT NULL,
+			last_name     TEXT NOT NULL,
+			relationship  TEXT,
+			phone         TEXT,
+			is_authorized BOOLEAN NOT NULL DEFAULT TRUE,
+			valid_from    DATE,
+			valid_until   DATE,
+			notes         TEXT,
+			created_by    BIGINT NOT NULL REFERENCES users.staff(id),
+			created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_pickup_person_validity CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_until >= valid_from)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_student_pickup_persons_student ON users.student_pickup_persons(student_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating student_pickup_persons table: %w", err)
+	}
+
+	// Who collected the student at daily checkout; the name is kept even if the person is removed later
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE active.attendance
+			ADD COLUMN IF NOT EXISTS picked_up_by_guardian_id BIGINT REFERENCES users.guardian_profiles(id) ON DELETE SET NULL,
+			ADD COLUMN IF NOT EXISTS picked_up_by_person_id BIGINT REFERENCES users.student_pickup_persons(id) ON DELETE SET NULL,
+			ADD COLUMN IF NOT EXISTS picked_up_by_name TEXT;
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding pickup columns to attendance: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.10: Successfully created users.student_pickup_persons table")
+	return tx.Commit()
+}
+
+func dropStudentPickupPersons(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.10: Dropping users.student_pickup_persons table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE active.attendance
+			DROP COLUMN IF EXISTS picked_up_by_name,
+			DROP COLUMN IF EXISTS picked_up_by_person_id,
+			DROP COLUMN IF EXISTS picked_up_by_guardian_id;
+
+		DROP TABLE IF EXISTS users.student_pickup_persons CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping student_pickup_persons table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.10: Successfully rolled back")
+	return tx.Commit()
+}
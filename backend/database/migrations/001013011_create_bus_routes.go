@@ -0,0 +1,134 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	busRoutesVersion     = "1.13.11"
+	busRoutesDescription = "Create schedule.bus_routes with weekday departures and student assignments, record bus departures on active.attendance"
+)
+
+func init() {
+	MigrationRegistry[busRoutesVersion] = &Migration{
+		Version:     busRoutesVersion,
+		Description: busRoutesDescription,
+		DependsOn:   []string{"1.3.5", "1.2.3", "1.6.5"}, // Depends on users.students, users.staff and active.attendance
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createBusRoutes(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropBusRoutes(ctx, db)
+		},
+	)
+}
+
+func createBusRoutes(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.11: Creating schedule.bus_routes tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schedule.bus_routes (
+			id          BIGSERIAL PRIMARY KEY,
+			name        TEXT NOT NULL,
+			description TEXT,
+			is_active   BOOLEAN NOT NULL DEFAULT TRUE,
+			created_by  BIGINT NOT NULL REFERENCES users.staff(id),
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_bus_routes_name UNIQUE (name)
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating bus_routes table: %w", err)
+	}
+
+	// One departure per school day; days without a row have no bus
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schedule.bus_route_departures (
+			id             BIGSERIAL PRIMARY KEY,
+			bus_route_id   BIGINT NOT NULL REFERENCES schedule.bus_routes(id) ON DELETE CASCADE,
+			weekday        INTEGER NOT NULL CHECK (weekday BETWEEN 1 AND 5),
+			departure_time TIME NOT NULL,
+			created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_bus_route_departures_weekday UNIQUE (bus_route_id, weekday)
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating bus_route_departures table: %w", err)
+	}
+
+	// A student rides at most one route
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schedule.bus_route_students (
+			id           BIGSERIAL PRIMARY KEY,
+			bus_route_id BIGINT NOT NULL REFERENCES schedule.bus_routes(id) ON DELETE CASCADE,
+			student_id   BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_bus_route_students_student UNIQUE (student_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_bus_route_students_route ON schedule.bus_route_students(bus_route_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating bus_route_students table: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE active.attendance
+			ADD COLUMN IF NOT EXISTS departed_by_bus_route_id BIGINT REFERENCES schedule.bus_routes(id) ON DELETE SET NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding bus route column to attendance: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.11: Successfully created schedule.bus_routes tables")
+	return tx.Commit()
+}
+
+func dropBusRoutes(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.11: Dropping schedule.bus_routes tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE active.attendance DROP COLUMN IF EXISTS departed_by_bus_route_id;
+
+		DROP TABLE IF EXISTS schedule.bus_route_students CASCADE;
+		DROP TABLE IF EXISTS schedule.bus_route_departures CASCADE;
+		DROP TABLE IF EXISTS schedule.bus_routes CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping bus_routes tables: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.11: Successfully rolled back")
+	return tx.Commit()
+}
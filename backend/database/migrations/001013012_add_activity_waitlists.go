@@ -0,0 +1,135 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	activityWaitlistsVersion     = "1.13.12"
+	activityWaitlistsDescription = "Add waitlist status to activity enrollments, link activity groups to dateframes and add dateframe enrollment windows"
+)
+
+func init() {
+	MigrationRegistry[activityWaitlistsVersion] = &Migration{
+		Version:     activityWaitlistsVersion,
+		Description: activityWaitlistsDescription,
+		DependsOn:   []string{"1.1.3", "1.3.2", "1.3.8"}, // Depends on schedule.dateframes, activities.groups and activities.student_enrollments
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return addActivityWaitlists(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropActivityWaitlists(ctx, db)
+		},
+	)
+}
+
+func addActivityWaitlists(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.12: Adding activity waitlists and enrollment windows...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE schedule.dateframes
+			ADD COLUMN IF NOT EXISTS enrollment_opens_at TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS enrollment_closes_at TIMESTAMPTZ;
+
+		ALTER TABLE schedule.dateframes
+			DROP CONSTRAINT IF EXISTS chk_dateframes_enrollment_window;
+		ALTER TABLE schedule.dateframes
+			ADD CONSTRAINT chk_dateframes_enrollment_window
+			CHECK (enrollment_opens_at IS NULL OR enrollment_closes_at IS NULL OR enrollment_closes_at > enrollment_opens_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding enrollment window to dateframes: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE activities.groups
+			ADD COLUMN IF NOT EXISTS dateframe_id BIGINT REFERENCES schedule.dateframes(id) ON DELETE SET NULL;
+
+		CREATE INDEX IF NOT EXISTS idx_activity_groups_dateframe ON activities.groups(dateframe_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding dateframe to activity groups: %w", err)
+	}
+
+	// Existing enrollments keep their place; only waitlisted rows carry a position
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE activities.student_enrollments
+			ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'enrolled',
+			ADD COLUMN IF NOT EXISTS waitlist_position INTEGER;
+
+		ALTER TABLE activities.student_enrollments
+			DROP CONSTRAINT IF EXISTS chk_student_enrollments_status;
+		ALTER TABLE activities.student_enrollments
+			ADD CONSTRAINT chk_student_enrollments_status CHECK (
+				(status = 'enrolled' AND waitlist_position IS NULL) OR
+				(status = 'waitlisted' AND waitlist_position > 0)
+			);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_student_enrollments_waitlist_position
+			ON activities.student_enrollments(activity_group_id, waitlist_position)
+			WHERE waitlist_position IS NOT NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding waitlist to student enrollments: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.12: Successfully added activity waitlists and enrollment windows")
+	return tx.Commit()
+}
+
+func dropActivityWaitlists(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.12: Removing activity waitlists and enrollment windows...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Waitlisted rows never held a place; drop them instead of turning them into enrollments
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM activities.student_enrollments WHERE status = 'waitlisted';
+
+		DROP INDEX IF EXISTS activities.idx_student_enrollments_waitlist_position;
+		ALTER TABLE activities.student_enrollments
+			DROP CONSTRAINT IF EXISTS chk_student_enrollments_status,
+			DROP COLUMN IF EXISTS waitlist_position,
+			DROP COLUMN IF EXISTS status;
+
+		DROP INDEX IF EXISTS activities.idx_activity_groups_dateframe;
+		ALTER TABLE activities.groups DROP COLUMN IF EXISTS dateframe_id;
+
+		ALTER TABLE schedule.dateframes
+			DROP CONSTRAINT IF EXISTS chk_dateframes_enrollment_window,
+			DROP COLUMN IF EXISTS enrollment_closes_at,
+			DROP COLUMN IF EXISTS enrollment_opens_at;
+	`)
+	if err != nil {
+		return fmt.Errorf("error removing activity waitlists: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.12: Successfully rolled back")
+	return tx.Commit()
+}
@@ -0,0 +1,138 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	activityAllocationsVersion     = "1.13.13"
+	activityAllocationsDescription = "Create activities.allocations with ranked student preferences and recorded assignments (AG lottery)"
+)
+
+func init() {
+	MigrationRegistry[activityAllocationsVersion] = &Migration{
+		Version:     activityAllocationsVersion,
+		Description: activityAllocationsDescription,
+		DependsOn:   []string{"1.1.3", "1.3.2", "1.3.5"}, // Depends on schedule.dateframes, activities.groups and users.students
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createActivityAllocations(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropActivityAllocations(ctx, db)
+		},
+	)
+}
+
+func createActivityAllocations(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.13: Creating activities.allocations tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS activities.allocations (
+			id                          BIGSERIAL PRIMARY KEY,
+			dateframe_id                BIGINT NOT NULL REFERENCES schedule.dateframes(id) ON DELETE CASCADE,
+			name                        TEXT NOT NULL,
+			status                      TEXT NOT NULL DEFAULT 'draft',
+			seed                        BIGINT NOT NULL,
+			max_assignments_per_student INTEGER NOT NULL DEFAULT 1,
+			notes                       TEXT,
+			created_by                  BIGINT NOT NULL,
+			applied_at                  TIMESTAMPTZ,
+			applied_by                  BIGINT,
+			created_at                  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at                  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT chk_allocations_status CHECK (status IN ('draft', 'applied')),
+			CONSTRAINT chk_allocations_max_assignments CHECK (max_assignments_per_student BETWEEN 1 AND 3)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_allocations_dateframe ON activities.allocations(dateframe_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating allocations table: %w", err)
+	}
+
+	// Up to three ranked wishes per student, each AG at most once
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS activities.allocation_preferences (
+			id                BIGSERIAL PRIMARY KEY,
+			allocation_id     BIGINT NOT NULL REFERENCES activities.allocations(id) ON DELETE CASCADE,
+			student_id        BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			activity_group_id BIGINT NOT NULL REFERENCES activities.groups(id) ON DELETE CASCADE,
+			rank              INTEGER NOT NULL CHECK (rank BETWEEN 1 AND 3),
+			created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_allocation_preferences_rank UNIQUE (allocation_id, student_id, rank),
+			CONSTRAINT uq_allocation_preferences_group UNIQUE (allocation_id, student_id, activity_group_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_allocation_preferences_allocation ON activities.allocation_preferences(allocation_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating allocation_preferences table: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS activities.allocation_assignments (
+			id                BIGSERIAL PRIMARY KEY,
+			allocation_id     BIGINT NOT NULL REFERENCES activities.allocations(id) ON DELETE CASCADE,
+			student_id        BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			activity_group_id BIGINT NOT NULL REFERENCES activities.groups(id) ON DELETE CASCADE,
+			rank              INTEGER NOT NULL,
+			round             INTEGER NOT NULL,
+			created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_allocation_assignments_group UNIQUE (allocation_id, student_id, activity_group_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_allocation_assignments_allocation ON activities.allocation_assignments(allocation_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating allocation_assignments table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.13: Successfully created activities.allocations tables")
+	return tx.Commit()
+}
+
+func dropActivityAllocations(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.13: Dropping activities.allocations tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS activities.allocation_assignments CASCADE;
+		DROP TABLE IF EXISTS activities.allocation_preferences CASCADE;
+		DROP TABLE IF EXISTS activities.allocations CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping allocations tables: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.13: Successfully rolled back")
+	return tx.Commit()
+}
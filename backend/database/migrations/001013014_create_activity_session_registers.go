@@ -0,0 +1,115 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	activitySessionRegistersVersion     = "1.13.14"
+	activitySessionRegistersDescription = "Create activities.session_registers and entries (per-session attendance derived from visits)"
+)
+
+func init() {
+	MigrationRegistry[activitySessionRegistersVersion] = &Migration{
+		Version:     activitySessionRegistersVersion,
+		Description: activitySessionRegistersDescription,
+		DependsOn:   []string{"1.3.2", "1.3.3", "1.3.5", "1.2.3"}, // Depends on activities.groups, activities.schedules, users.students and users.staff
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createActivitySessionRegisters(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropActivitySessionRegisters(ctx, db)
+		},
+	)
+}
+
+func createActivitySessionRegisters(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.14: Creating activities.session_registers tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// One register per activity group and day
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS activities.session_registers (
+			id                BIGSERIAL PRIMARY KEY,
+			activity_group_id BIGINT NOT NULL REFERENCES activities.groups(id) ON DELETE CASCADE,
+			session_date      DATE NOT NULL,
+			schedule_id       BIGINT REFERENCES activities.schedules(id) ON DELETE SET NULL,
+			derived_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_session_registers_group_date UNIQUE (activity_group_id, session_date)
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating session_registers table: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS activities.session_register_entries (
+			id              BIGSERIAL PRIMARY KEY,
+			register_id     BIGINT NOT NULL REFERENCES activities.session_registers(id) ON DELETE CASCADE,
+			student_id      BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			status          TEXT NOT NULL,
+			first_seen_at   TIMESTAMPTZ,
+			minutes_present INTEGER NOT NULL DEFAULT 0,
+			note            TEXT,
+			excused_by      BIGINT REFERENCES users.staff(id) ON DELETE SET NULL,
+			excused_at      TIMESTAMPTZ,
+			created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_session_register_entries_student UNIQUE (register_id, student_id),
+			CONSTRAINT chk_session_register_entries_status CHECK (status IN ('PRESENT', 'ABSENT', 'EXCUSED', 'UNKNOWN')),
+			CONSTRAINT chk_session_register_entries_minutes CHECK (minutes_present >= 0)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_session_register_entries_student ON activities.session_register_entries(student_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating session_register_entries table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.14: Successfully created activities.session_registers tables")
+	return tx.Commit()
+}
+
+func dropActivitySessionRegisters(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.14: Dropping activities.session_registers tables...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS activities.session_register_entries CASCADE;
+		DROP TABLE IF EXISTS activities.session_registers CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping session register tables: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.14: Successfully rolled back")
+	return tx.Commit()
+}
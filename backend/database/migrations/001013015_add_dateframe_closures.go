@@ -0,0 +1,101 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	dateframeClosuresVersion     = "1.13.15"
+	dateframeClosuresDescription = "Add kind (term, holiday, closure) and iCalendar UID to dateframes"
+)
+
+func init() {
+	MigrationRegistry[dateframeClosuresVersion] = &Migration{
+		Version:     dateframeClosuresVersion,
+		Description: dateframeClosuresDescription,
+		DependsOn:   []string{"1.1.3"}, // Depends on schedule.dateframes
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return addDateframeClosures(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropDateframeClosures(ctx, db)
+		},
+	)
+}
+
+func addDateframeClosures(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.15: Adding holiday and closure kinds to dateframes...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Existing dateframes are school terms
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE schedule.dateframes
+			ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'term',
+			ADD COLUMN IF NOT EXISTS external_uid TEXT;
+
+		ALTER TABLE schedule.dateframes
+			DROP CONSTRAINT IF EXISTS chk_dateframes_kind;
+		ALTER TABLE schedule.dateframes
+			ADD CONSTRAINT chk_dateframes_kind CHECK (kind IN ('term', 'holiday', 'closure'));
+
+		CREATE INDEX IF NOT EXISTS idx_dateframes_kind_dates ON schedule.dateframes(kind, start_date, end_date);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_dateframes_external_uid
+			ON schedule.dateframes(external_uid)
+			WHERE external_uid IS NOT NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("error adding kind to dateframes: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.15: Successfully added holiday and closure kinds to dateframes")
+	return tx.Commit()
+}
+
+func dropDateframeClosures(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.15: Removing holiday and closure kinds from dateframes...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Holidays and closures would turn into terms without the kind column
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM schedule.dateframes WHERE kind IN ('holiday', 'closure');
+
+		DROP INDEX IF EXISTS schedule.idx_dateframes_external_uid;
+		DROP INDEX IF EXISTS schedule.idx_dateframes_kind_dates;
+		ALTER TABLE schedule.dateframes
+			DROP CONSTRAINT IF EXISTS chk_dateframes_kind,
+			DROP COLUMN IF EXISTS external_uid,
+			DROP COLUMN IF EXISTS kind;
+	`)
+	if err != nil {
+		return fmt.Errorf("error removing kind from dateframes: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.15: Successfully rolled back")
+	return tx.Commit()
+}
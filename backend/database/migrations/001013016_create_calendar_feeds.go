@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	calendarFeedsVersion     = "1.13.16"
+	calendarFeedsDescription = "Create schedule.calendar_feeds for tokenized iCalendar subscriptions of supervision plans and activity groups"
+)
+
+func init() {
+	MigrationRegistry[calendarFeedsVersion] = &Migration{
+		Version:     calendarFeedsVersion,
+		Description: calendarFeedsDescription,
+		DependsOn:   []string{"1.2.3", "1.3.2"}, // Depends on users.staff and activities.groups
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createCalendarFeeds(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropCalendarFeeds(ctx, db)
+		},
+	)
+}
+
+func createCalendarFeeds(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.16: Creating schedule.calendar_feeds table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// Only the SHA-256 of the subscription token is stored
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schedule.calendar_feeds (
+			id                BIGSERIAL PRIMARY KEY,
+			staff_id          BIGINT NOT NULL REFERENCES users.staff(id) ON DELETE CASCADE,
+			activity_group_id BIGINT REFERENCES activities.groups(id) ON DELETE CASCADE,
+			name              TEXT NOT NULL,
+			token_hash        TEXT NOT NULL,
+			last_accessed_at  TIMESTAMPTZ,
+			revoked_at        TIMESTAMPTZ,
+			created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_calendar_feeds_token_hash UNIQUE (token_hash)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_calendar_feeds_staff ON schedule.calendar_feeds(staff_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating calendar_feeds table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.16: Successfully created schedule.calendar_feeds table")
+	return tx.Commit()
+}
+
+func dropCalendarFeeds(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.16: Dropping schedule.calendar_feeds table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS schedule.calendar_feeds CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping calendar_feeds table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.16: Successfully rolled back")
+	return tx.Commit()
+}
@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	dispatchedNotificationsVersion     = "1.13.17"
+	dispatchedNotificationsDescription = "Create active.dispatched_notifications so scheduled pickup and bus notifications are sent once per student and day"
+)
+
+func init() {
+	MigrationRegistry[dispatchedNotificationsVersion] = &Migration{
+		Version:     dispatchedNotificationsVersion,
+		Description: dispatchedNotificationsDescription,
+		DependsOn:   []string{"1.3.5"}, // Depends on users.students
+	}
+
+	Migrations.MustRegister(
+		func(ctx context.Context, db *bun.DB) error {
+			return createDispatchedNotifications(ctx, db)
+		},
+		func(ctx context.Context, db *bun.DB) error {
+			return dropDispatchedNotifications(ctx, db)
+		},
+	)
+}
+
+func createDispatchedNotifications(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Migration 1.13.17: Creating active.dispatched_notifications table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	// One row per student, event type and day; the unique constraint deduplicates across replicas and restarts
+	_, err = tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS active.dispatched_notifications (
+			id            BIGSERIAL PRIMARY KEY,
+			student_id    BIGINT NOT NULL REFERENCES users.students(id) ON DELETE CASCADE,
+			event_type    TEXT NOT NULL,
+			dispatch_date DATE NOT NULL,
+			created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			CONSTRAINT uq_dispatched_notification_student_event_date UNIQUE (student_id, event_type, dispatch_date)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_dispatched_notifications_date ON active.dispatched_notifications(dispatch_date);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating dispatched_notifications table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.17: Successfully created active.dispatched_notifications table")
+	return tx.Commit()
+}
+
+func dropDispatchedNotifications(ctx context.Context, db *bun.DB) error {
+	fmt.Println("Rolling back migration 1.13.17: Dropping active.dispatched_notifications table...")
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		DROP TABLE IF EXISTS active.dispatched_notifications CASCADE;
+	`)
+	if err != nil {
+		return fmt.Errorf("error dropping dispatched_notifications table: %w", err)
+	}
+
+	fmt.Println("Migration 1.13.17: Successfully rolled back")
+	return tx.Commit()
+}
@@ -1,8 +1,10 @@
 package migrations
 
 import (
+	"cmp"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/uptrace/bun/migrate"
@@ -23,12 +25,32 @@ func RegisteredMigrations() []*Migration {
 
 	// Sort migrations by version (semantically)
 	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].Version < migrations[j].Version
+		return compareVersions(migrations[i].Version, migrations[j].Version) < 0
 	})
 
 	return migrations
 }
 
+// compareVersions compares dotted migration versions segment by segment, numerically,
+// so that 1.13.1 sorts after 1.6.1 and 1.3.5.1 after 1.3.5
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr != nil || bErr != nil {
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if an != bn {
+			return cmp.Compare(an, bn)
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
 // ValidateMigrations validates migration dependencies and ordering.
 // This is a pure in-memory check against the registered migration graph — no database needed.
 func ValidateMigrations() error {
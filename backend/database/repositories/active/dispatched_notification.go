@@ -0,0 +1,58 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/active"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const tableActiveDispatchedNotifications = "active.dispatched_notifications"
+
+// DispatchedNotificationRepository implements active.DispatchedNotificationRepository
+type DispatchedNotificationRepository struct {
+	db *bun.DB
+}
+
+// NewDispatchedNotificationRepository creates a new DispatchedNotificationRepository
+func NewDispatchedNotificationRepository(db *bun.DB) active.DispatchedNotificationRepository {
+	return &DispatchedNotificationRepository{db: db}
+}
+
+// MarkDispatched inserts the notification unless it was already recorded for the student,
+// event type and day. The unique constraint makes concurrent dispatchers agree on one winner.
+func (r *DispatchedNotificationRepository) MarkDispatched(ctx context.Context, studentID int64, eventType string, date time.Time) (bool, error) {
+	notification := &active.DispatchedNotification{
+		StudentID:    studentID,
+		EventType:    eventType,
+		DispatchDate: date,
+	}
+	if err := notification.Validate(); err != nil {
+		return false, fmt.Errorf("invalid dispatched notification: %w", err)
+	}
+
+	res, err := r.db.NewInsert().
+		Model(notification).
+		ModelTableExpr(tableActiveDispatchedNotifications).
+		Column("student_id", "event_type", "dispatch_date").
+		On("CONFLICT (student_id, event_type, dispatch_date) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, &modelBase.DatabaseError{
+			Op:  "mark notification dispatched",
+			Err: err,
+		}
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, &modelBase.DatabaseError{
+			Op:  "mark notification dispatched",
+			Err: err,
+		}
+	}
+	return inserted > 0, nil
+}
@@ -0,0 +1,42 @@
+package active_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchedNotificationRepository_OncePerStudentEventAndDay(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).DispatchedNotification
+	ctx := context.Background()
+
+	// Dispatch records are removed with the student (ON DELETE CASCADE)
+	student := testpkg.CreateTestStudent(t, db, "Pickup", "Dispatch", "3a")
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID)
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	isNew, err := repo.MarkDispatched(ctx, student.ID, "pickup_due", day)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	isNew, err = repo.MarkDispatched(ctx, student.ID, "pickup_due", day)
+	require.NoError(t, err)
+	assert.False(t, isNew, "same event on the same day is recorded once")
+
+	isNew, err = repo.MarkDispatched(ctx, student.ID, "pickup_overdue", day)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	isNew, err = repo.MarkDispatched(ctx, student.ID, "pickup_due", day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.True(t, isNew)
+}
@@ -94,6 +94,26 @@ func (r *GroupRepository) FindByTimeRange(ctx context.Context, start, end time.T
 	return groups, nil
 }
 
+// FindEndedBetween finds all sessions whose end time lies in [from, to)
+func (r *GroupRepository) FindEndedBetween(ctx context.Context, from, to time.Time) ([]*active.Group, error) {
+	var groups []*active.Group
+	err := r.db.NewSelect().
+		Model(&groups).
+		ModelTableExpr(`active.groups AS "group"`).
+		Where(`"group".end_time >= ? AND "group".end_time < ?`, from, to).
+		OrderExpr(`"group".end_time ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find ended between",
+			Err: err,
+		}
+	}
+
+	return groups, nil
+}
+
 // EndSession marks a group session as ended at the current time
 func (r *GroupRepository) EndSession(ctx context.Context, id int64) error {
 	_, err := r.db.NewUpdate().
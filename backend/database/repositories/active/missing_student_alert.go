@@ -0,0 +1,123 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const (
+	tableActiveMissingStudentAlerts         = "active.missing_student_alerts"
+	tableExprActiveMissingStudentAlertsAsMA = `active.missing_student_alerts AS "missing_student_alert"`
+)
+
+// MissingStudentAlertRepository implements active.MissingStudentAlertRepository
+type MissingStudentAlertRepository struct {
+	*base.Repository[*active.MissingStudentAlert]
+	db *bun.DB
+}
+
+// NewMissingStudentAlertRepository creates a new MissingStudentAlertRepository
+func NewMissingStudentAlertRepository(db *bun.DB) active.MissingStudentAlertRepository {
+	return &MissingStudentAlertRepository{
+		Repository: base.NewRepository[*active.MissingStudentAlert](db, tableActiveMissingStudentAlerts, "MissingStudentAlert"),
+		db:         db,
+	}
+}
+
+// Create overrides base Create to handle validation
+func (r *MissingStudentAlertRepository) Create(ctx context.Context, alert *active.MissingStudentAlert) error {
+	if alert == nil {
+		return fmt.Errorf("missing student alert cannot be nil")
+	}
+
+	if err := alert.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, alert)
+}
+
+// Update overrides base Update to handle validation
+func (r *MissingStudentAlertRepository) Update(ctx context.Context, alert *active.MissingStudentAlert) error {
+	if alert == nil {
+		return fmt.Errorf("missing student alert cannot be nil")
+	}
+
+	if err := alert.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Update(ctx, alert)
+}
+
+// List overrides base List to use QueryOptions
+func (r *MissingStudentAlertRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*active.MissingStudentAlert, error) {
+	var alerts []*active.MissingStudentAlert
+	query := r.db.NewSelect().
+		Model(&alerts).
+		ModelTableExpr(tableExprActiveMissingStudentAlertsAsMA)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return alerts, nil
+}
+
+// GetByDate returns all alerts raised on the given date with student and person, oldest first
+func (r *MissingStudentAlertRepository) GetByDate(ctx context.Context, date time.Time) ([]*active.MissingStudentAlert, error) {
+	var alerts []*active.MissingStudentAlert
+	err := r.db.NewSelect().
+		Model(&alerts).
+		ModelTableExpr(tableExprActiveMissingStudentAlertsAsMA).
+		Relation("Student").
+		Relation("Student.Person").
+		Where(`"missing_student_alert".alert_date = ?`, date).
+		OrderExpr(`"missing_student_alert".created_at ASC`).
+		OrderExpr(`"missing_student_alert".id ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get missing student alerts by date",
+			Err: err,
+		}
+	}
+
+	return alerts, nil
+}
+
+// GetByStudentID returns the alert history of a student, most recent first
+func (r *MissingStudentAlertRepository) GetByStudentID(ctx context.Context, studentID int64) ([]*active.MissingStudentAlert, error) {
+	var alerts []*active.MissingStudentAlert
+	err := r.db.NewSelect().
+		Model(&alerts).
+		ModelTableExpr(tableExprActiveMissingStudentAlertsAsMA).
+		Where(`"missing_student_alert".student_id = ?`, studentID).
+		OrderExpr(`"missing_student_alert".alert_date DESC`).
+		OrderExpr(`"missing_student_alert".id DESC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get missing student alerts by student",
+			Err: err,
+		}
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,59 @@
+package active_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingStudentAlertRepository_OnePerStudentAndDay(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).MissingStudentAlert
+	ctx := context.Background()
+
+	student := testpkg.CreateTestStudent(t, db, "Missing", "Student", "2c")
+	staff := testpkg.CreateTestStaff(t, db, "Missing", "Resolver")
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, staff.ID)
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	alert := &active.MissingStudentAlert{
+		StudentID:  student.ID,
+		AlertDate:  day,
+		ExpectedBy: time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC),
+		Status:     active.MissingAlertStatusOpen,
+	}
+	require.NoError(t, repo.Create(ctx, alert))
+	defer testpkg.CleanupTableRecords(t, db, "active.missing_student_alerts", alert.ID)
+
+	t.Run("second alert on the same day is rejected", func(t *testing.T) {
+		duplicate := *alert
+		duplicate.ID = 0
+		assert.Error(t, repo.Create(ctx, &duplicate))
+	})
+
+	t.Run("resolution is stored and listed by date", func(t *testing.T) {
+		require.NoError(t, alert.Resolve(active.MissingAlertResolutionWentHome, staff.ID, "Mutter hat angerufen", time.Now()))
+		require.NoError(t, repo.Update(ctx, alert))
+
+		alerts, err := repo.GetByDate(ctx, day)
+		require.NoError(t, err)
+
+		var found *active.MissingStudentAlert
+		for _, a := range alerts {
+			if a.ID == alert.ID {
+				found = a
+			}
+		}
+		require.NotNil(t, found)
+		assert.Equal(t, active.MissingAlertResolutionWentHome, found.Resolution)
+		require.NotNil(t, found.Student)
+	})
+}
@@ -170,3 +170,50 @@ func (r *StaffAbsenceRepository) GetByDateRange(ctx context.Context, from, to ti
 
 	return absences, nil
 }
+
+// GetByStatus returns all absences with the given status, earliest start first
+func (r *StaffAbsenceRepository) GetByStatus(ctx context.Context, status string) ([]*active.StaffAbsence, error) {
+	var absences []*active.StaffAbsence
+	err := r.db.NewSelect().
+		Model(&absences).
+		ModelTableExpr(tableExprActiveStaffAbsencesAsStaffAbsence).
+		Where(`"staff_absence".status = ?`, status).
+		OrderExpr(`"staff_absence".date_start ASC`).
+		OrderExpr(`"staff_absence".id ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get absences by status",
+			Err: err,
+		}
+	}
+
+	return absences, nil
+}
+
+// UpdateReview stores the review decision only while the absence is still reported
+func (r *StaffAbsenceRepository) UpdateReview(ctx context.Context, absence *active.StaffAbsence) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model(absence).
+		ModelTableExpr(tableExprActiveStaffAbsencesAsStaffAbsence).
+		Column("status", "approved_by", "approved_at", "decline_reason", "updated_at").
+		Where(`"staff_absence".id = ?`, absence.ID).
+		Where(`"staff_absence".status = ?`, active.AbsenceStatusReported).
+		Exec(ctx)
+	if err != nil {
+		return false, &modelBase.DatabaseError{
+			Op:  "update absence review",
+			Err: err,
+		}
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, &modelBase.DatabaseError{
+			Op:  "update absence review",
+			Err: err,
+		}
+	}
+	return rows > 0, nil
+}
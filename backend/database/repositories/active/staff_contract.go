@@ -0,0 +1,132 @@
+package active
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const (
+	tableActiveStaffContracts                    = "active.staff_contracts"
+	tableExprActiveStaffContractsAsStaffContract = `active.staff_contracts AS "staff_contract"`
+)
+
+// StaffContractRepository implements active.StaffContractRepository
+type StaffContractRepository struct {
+	*base.Repository[*active.StaffContract]
+	db *bun.DB
+}
+
+// NewStaffContractRepository creates a new StaffContractRepository
+func NewStaffContractRepository(db *bun.DB) active.StaffContractRepository {
+	return &StaffContractRepository{
+		Repository: base.NewRepository[*active.StaffContract](db, tableActiveStaffContracts, "StaffContract"),
+		db:         db,
+	}
+}
+
+// Create overrides base Create to handle validation
+func (r *StaffContractRepository) Create(ctx context.Context, contract *active.StaffContract) error {
+	if contract == nil {
+		return fmt.Errorf("staff contract cannot be nil")
+	}
+
+	if err := contract.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, contract)
+}
+
+// List overrides base List to use QueryOptions
+func (r *StaffContractRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*active.StaffContract, error) {
+	var contracts []*active.StaffContract
+	query := r.db.NewSelect().
+		Model(&contracts).
+		ModelTableExpr(tableExprActiveStaffContractsAsStaffContract)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return contracts, nil
+}
+
+// GetByStaffID returns all contract entries for a staff member, oldest first
+func (r *StaffContractRepository) GetByStaffID(ctx context.Context, staffID int64) ([]*active.StaffContract, error) {
+	var contracts []*active.StaffContract
+	err := r.db.NewSelect().
+		Model(&contracts).
+		ModelTableExpr(tableExprActiveStaffContractsAsStaffContract).
+		Where(`"staff_contract".staff_id = ?`, staffID).
+		OrderExpr(`"staff_contract".valid_from ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get contracts by staff ID",
+			Err: err,
+		}
+	}
+
+	return contracts, nil
+}
+
+// GetByStaffAndValidFrom returns the entry for a staff member starting on the given date, or nil
+func (r *StaffContractRepository) GetByStaffAndValidFrom(ctx context.Context, staffID int64, validFrom time.Time) (*active.StaffContract, error) {
+	contract := new(active.StaffContract)
+	err := r.db.NewSelect().
+		Model(contract).
+		ModelTableExpr(tableExprActiveStaffContractsAsStaffContract).
+		Where(`"staff_contract".staff_id = ?`, staffID).
+		Where(`"staff_contract".valid_from = ?`, validFrom.Format(dateFormatISO)).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, &modelBase.DatabaseError{
+			Op:  "get contract by staff and valid_from",
+			Err: err,
+		}
+	}
+
+	return contract, nil
+}
+
+// ListValidUntil returns all contract entries starting on or before the given date, ordered by staff and start
+func (r *StaffContractRepository) ListValidUntil(ctx context.Context, until time.Time) ([]*active.StaffContract, error) {
+	var contracts []*active.StaffContract
+	err := r.db.NewSelect().
+		Model(&contracts).
+		ModelTableExpr(tableExprActiveStaffContractsAsStaffContract).
+		Where(`"staff_contract".valid_from <= ?`, until.Format(dateFormatISO)).
+		OrderExpr(`"staff_contract".staff_id ASC`).
+		OrderExpr(`"staff_contract".valid_from ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list contracts valid until",
+			Err: err,
+		}
+	}
+
+	return contracts, nil
+}
@@ -0,0 +1,72 @@
+package active_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaffContractRepository_EffectiveDatedEntries(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).StaffContract
+	ctx := context.Background()
+
+	staff := testpkg.CreateTestStaff(t, db, "Contract", "Staff")
+	defer testpkg.CleanupActivityFixtures(t, db, 0, staff.ID)
+
+	jan := &active.StaffContract{
+		StaffID:       staff.ID,
+		WeeklyMinutes: 39 * 60,
+		ValidFrom:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBy:     staff.ID,
+	}
+	apr := &active.StaffContract{
+		StaffID:       staff.ID,
+		WeeklyMinutes: 30 * 60,
+		ValidFrom:     time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBy:     staff.ID,
+	}
+	require.NoError(t, repo.Create(ctx, apr))
+	require.NoError(t, repo.Create(ctx, jan))
+	defer testpkg.CleanupTableRecords(t, db, "active.staff_contracts", jan.ID, apr.ID)
+
+	t.Run("GetByStaffID returns entries oldest first", func(t *testing.T) {
+		contracts, err := repo.GetByStaffID(ctx, staff.ID)
+		require.NoError(t, err)
+		require.Len(t, contracts, 2)
+		assert.Equal(t, jan.ID, contracts[0].ID)
+		assert.Equal(t, apr.ID, contracts[1].ID)
+	})
+
+	t.Run("GetByStaffAndValidFrom finds exact start date", func(t *testing.T) {
+		found, err := repo.GetByStaffAndValidFrom(ctx, staff.ID, apr.ValidFrom)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, apr.ID, found.ID)
+
+		missing, err := repo.GetByStaffAndValidFrom(ctx, staff.ID, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("ListValidUntil excludes future entries", func(t *testing.T) {
+		contracts, err := repo.ListValidUntil(ctx, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		var ids []int64
+		for _, c := range contracts {
+			if c.StaffID == staff.ID {
+				ids = append(ids, c.ID)
+			}
+		}
+		assert.Equal(t, []int64{jan.ID}, ids)
+	})
+}
@@ -0,0 +1,130 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const (
+	tableActiveStudentAbsences                     = "active.student_absences"
+	tableExprActiveStudentAbsencesAsStudentAbsence = `active.student_absences AS "student_absence"`
+)
+
+// StudentAbsenceRepository implements active.StudentAbsenceRepository
+type StudentAbsenceRepository struct {
+	*base.Repository[*active.StudentAbsence]
+	db *bun.DB
+}
+
+// NewStudentAbsenceRepository creates a new StudentAbsenceRepository
+func NewStudentAbsenceRepository(db *bun.DB) active.StudentAbsenceRepository {
+	return &StudentAbsenceRepository{
+		Repository: base.NewRepository[*active.StudentAbsence](db, tableActiveStudentAbsences, "StudentAbsence"),
+		db:         db,
+	}
+}
+
+// Create overrides base Create to handle validation
+func (r *StudentAbsenceRepository) Create(ctx context.Context, absence *active.StudentAbsence) error {
+	if absence == nil {
+		return fmt.Errorf("student absence cannot be nil")
+	}
+
+	if err := absence.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, absence)
+}
+
+// List overrides base List to use QueryOptions
+func (r *StudentAbsenceRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*active.StudentAbsence, error) {
+	var absences []*active.StudentAbsence
+	query := r.db.NewSelect().
+		Model(&absences).
+		ModelTableExpr(tableExprActiveStudentAbsencesAsStudentAbsence)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return absences, nil
+}
+
+// GetByStudentID returns all absences of a student, most recent first
+func (r *StudentAbsenceRepository) GetByStudentID(ctx context.Context, studentID int64) ([]*active.StudentAbsence, error) {
+	var absences []*active.StudentAbsence
+	err := r.db.NewSelect().
+		Model(&absences).
+		ModelTableExpr(tableExprActiveStudentAbsencesAsStudentAbsence).
+		Where(`"student_absence".student_id = ?`, studentID).
+		OrderExpr(`"student_absence".date_start DESC`).
+		OrderExpr(`"student_absence".id DESC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get absences by student",
+			Err: err,
+		}
+	}
+
+	return absences, nil
+}
+
+// GetByStudentAndDateRange returns absences for a student overlapping the given date range
+func (r *StudentAbsenceRepository) GetByStudentAndDateRange(ctx context.Context, studentID int64, from, to time.Time) ([]*active.StudentAbsence, error) {
+	var absences []*active.StudentAbsence
+	err := r.db.NewSelect().
+		Model(&absences).
+		ModelTableExpr(tableExprActiveStudentAbsencesAsStudentAbsence).
+		Where(`"student_absence".student_id = ?`, studentID).
+		Where(`"student_absence".date_start <= ?`, to).
+		Where(`"student_absence".date_end >= ?`, from).
+		OrderExpr(`"student_absence".date_start ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get absences by student and date range",
+			Err: err,
+		}
+	}
+
+	return absences, nil
+}
+
+// GetByDate returns all student absences covering the given date
+func (r *StudentAbsenceRepository) GetByDate(ctx context.Context, date time.Time) ([]*active.StudentAbsence, error) {
+	var absences []*active.StudentAbsence
+	err := r.db.NewSelect().
+		Model(&absences).
+		ModelTableExpr(tableExprActiveStudentAbsencesAsStudentAbsence).
+		Where(`"student_absence".date_start <= ?`, date).
+		Where(`"student_absence".date_end >= ?`, date).
+		OrderExpr(`"student_absence".student_id ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get absences by date",
+			Err: err,
+		}
+	}
+
+	return absences, nil
+}
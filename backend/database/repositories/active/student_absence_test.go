@@ -0,0 +1,72 @@
+package active_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentAbsenceRepository_DateQueries(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).StudentAbsence
+	ctx := context.Background()
+
+	student := testpkg.CreateTestStudent(t, db, "Absence", "Student", "3a")
+	staff := testpkg.CreateTestStaff(t, db, "Absence", "Reporter")
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, staff.ID)
+
+	trip := &active.StudentAbsence{
+		StudentID:   student.ID,
+		AbsenceType: active.StudentAbsenceTypeTrip,
+		DateStart:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		DateEnd:     time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		ReportedBy:  staff.ID,
+	}
+	sick := &active.StudentAbsence{
+		StudentID:   student.ID,
+		AbsenceType: active.StudentAbsenceTypeSick,
+		DateStart:   time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		DateEnd:     time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		ReportedBy:  staff.ID,
+	}
+	require.NoError(t, repo.Create(ctx, trip))
+	require.NoError(t, repo.Create(ctx, sick))
+	defer testpkg.CleanupTableRecords(t, db, "active.student_absences", trip.ID, sick.ID)
+
+	t.Run("GetByStudentID returns most recent first", func(t *testing.T) {
+		absences, err := repo.GetByStudentID(ctx, student.ID)
+		require.NoError(t, err)
+		require.Len(t, absences, 2)
+		assert.Equal(t, sick.ID, absences[0].ID)
+		assert.Equal(t, trip.ID, absences[1].ID)
+	})
+
+	t.Run("GetByStudentAndDateRange finds overlaps", func(t *testing.T) {
+		absences, err := repo.GetByStudentAndDateRange(ctx, student.ID,
+			time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, absences, 1)
+		assert.Equal(t, trip.ID, absences[0].ID)
+	})
+
+	t.Run("GetByDate returns absences covering the day", func(t *testing.T) {
+		absences, err := repo.GetByDate(ctx, time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		var ids []int64
+		for _, a := range absences {
+			if a.StudentID == student.ID {
+				ids = append(ids, a.ID)
+			}
+		}
+		assert.Equal(t, []int64{trip.ID}, ids)
+	})
+}
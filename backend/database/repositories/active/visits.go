@@ -89,6 +89,27 @@ func (r *VisitRepository) FindByTimeRange(ctx context.Context, start, end time.T
 	return visits, nil
 }
 
+// FindByStudentAndTimeRange finds a student's visits active during a specific time range, ordered by entry time
+func (r *VisitRepository) FindByStudentAndTimeRange(ctx context.Context, studentID int64, start, end time.Time) ([]*active.Visit, error) {
+	var visits []*active.Visit
+	err := r.db.NewSelect().
+		Model(&visits).
+		ModelTableExpr(tableExprActiveVisitsAsVisit).
+		Where(`"visit".student_id = ?`, studentID).
+		Where(`"visit".entry_time <= ? AND ("visit".exit_time IS NULL OR "visit".exit_time >= ?)`, end, start).
+		Order(`"visit".entry_time ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by student and time range",
+			Err: err,
+		}
+	}
+
+	return visits, nil
+}
+
 // EndVisit marks a visit as ended at the current time
 func (r *VisitRepository) EndVisit(ctx context.Context, id int64) error {
 	_, err := r.db.NewUpdate().
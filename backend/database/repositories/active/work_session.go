@@ -111,6 +111,28 @@ func (r *WorkSessionRepository) GetHistoryByStaffID(ctx context.Context, staffID
 	return sessions, nil
 }
 
+// GetByDateRange returns the work sessions of all staff in a date range
+func (r *WorkSessionRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]*active.WorkSession, error) {
+	var sessions []*active.WorkSession
+	err := r.db.NewSelect().
+		Model(&sessions).
+		ModelTableExpr(tableExprActiveWorkSessionsAsSession).
+		Where(`"work_session".date >= ?`, from.Format(dateFormatISO)).
+		Where(`"work_session".date <= ?`, to.Format(dateFormatISO)).
+		OrderExpr(`"work_session".staff_id ASC`).
+		OrderExpr(`"work_session".date ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get sessions by date range",
+			Err: err,
+		}
+	}
+
+	return sessions, nil
+}
+
 // GetOpenSessions returns all sessions without check-out before a given date
 func (r *WorkSessionRepository) GetOpenSessions(ctx context.Context, beforeDate time.Time) ([]*active.WorkSession, error) {
 	var sessions []*active.WorkSession
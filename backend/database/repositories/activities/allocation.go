@@ -0,0 +1,248 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// Table names for allocation repositories
+const (
+	tableActivitiesAllocations           = "activities.allocations"
+	tableActivitiesAllocationPreferences = "activities.allocation_preferences"
+	tableActivitiesAllocationAssignments = "activities.allocation_assignments"
+	whereAllocationID                    = "allocation_id = ?"
+)
+
+// errAllocationNil is returned when a nil allocation is passed to a repository method
+var errAllocationNil = fmt.Errorf("allocation cannot be nil")
+
+// AllocationRepository implements activities.AllocationRepository interface
+type AllocationRepository struct {
+	*base.Repository[*activities.Allocation]
+	db bun.IDB
+}
+
+// NewAllocationRepository creates a new AllocationRepository
+func NewAllocationRepository(db *bun.DB) activities.AllocationRepository {
+	return &AllocationRepository{
+		Repository: base.NewRepository[*activities.Allocation](db, tableActivitiesAllocations, "Allocation"),
+		db:         db,
+	}
+}
+
+// WithTx returns a repository that runs all operations in the provided transaction
+func (r *AllocationRepository) WithTx(tx bun.Tx) interface{} {
+	return &AllocationRepository{
+		Repository: r.Repository.WithTx(tx),
+		db:         tx,
+	}
+}
+
+// FindByIDForUpdate loads an allocation and locks its row until the surrounding transaction ends
+func (r *AllocationRepository) FindByIDForUpdate(ctx context.Context, id int64) (*activities.Allocation, error) {
+	allocation := new(activities.Allocation)
+	err := r.db.NewSelect().
+		Model(allocation).
+		ModelTableExpr(`activities.allocations AS "allocation"`).
+		Where(`"allocation".id = ?`, id).
+		For("UPDATE").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find allocation for update",
+			Err: err,
+		}
+	}
+
+	return allocation, nil
+}
+
+// Create overrides the base Create method to handle validation
+func (r *AllocationRepository) Create(ctx context.Context, allocation *activities.Allocation) error {
+	if allocation == nil {
+		return errAllocationNil
+	}
+
+	if err := allocation.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, allocation)
+}
+
+// Update updates an allocation
+func (r *AllocationRepository) Update(ctx context.Context, allocation *activities.Allocation) error {
+	if allocation == nil {
+		return errAllocationNil
+	}
+
+	if err := allocation.Validate(); err != nil {
+		return err
+	}
+
+	_, err := r.db.NewUpdate().
+		Model(allocation).
+		ModelTableExpr(`activities.allocations AS "allocation"`).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "update allocation",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// List retrieves allocations matching the provided query options, newest first by default
+func (r *AllocationRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*activities.Allocation, error) {
+	var allocations []*activities.Allocation
+	query := r.db.NewSelect().
+		Model(&allocations).
+		ModelTableExpr(`activities.allocations AS "allocation"`)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	} else {
+		query = query.OrderExpr(`"allocation".created_at DESC`)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return allocations, nil
+}
+
+// FindByDateframeID finds all allocations of a term, newest first
+func (r *AllocationRepository) FindByDateframeID(ctx context.Context, dateframeID int64) ([]*activities.Allocation, error) {
+	var allocations []*activities.Allocation
+	err := r.db.NewSelect().
+		Model(&allocations).
+		ModelTableExpr(`activities.allocations AS "allocation"`).
+		Where(`"allocation".dateframe_id = ?`, dateframeID).
+		OrderExpr(`"allocation".created_at DESC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find allocations by dateframe id",
+			Err: err,
+		}
+	}
+
+	return allocations, nil
+}
+
+// FindPreferences returns all preferences of an allocation, ordered by student and rank
+func (r *AllocationRepository) FindPreferences(ctx context.Context, allocationID int64) ([]*activities.AllocationPreference, error) {
+	var preferences []*activities.AllocationPreference
+	err := r.db.NewSelect().
+		Model(&preferences).
+		ModelTableExpr(`activities.allocation_preferences AS "allocation_preference"`).
+		Where(`"allocation_preference".allocation_id = ?`, allocationID).
+		OrderExpr(`"allocation_preference".student_id ASC, "allocation_preference".rank ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find allocation preferences",
+			Err: err,
+		}
+	}
+
+	return preferences, nil
+}
+
+// ReplaceStudentPreferences replaces the ranked wishes of one student
+func (r *AllocationRepository) ReplaceStudentPreferences(ctx context.Context, allocationID, studentID int64, preferences []*activities.AllocationPreference) error {
+	for _, p := range preferences {
+		p.AllocationID = allocationID
+		p.StudentID = studentID
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model((*activities.AllocationPreference)(nil)).
+			ModelTableExpr(tableActivitiesAllocationPreferences).
+			Where(whereAllocationID, allocationID).
+			Where("student_id = ?", studentID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(preferences) == 0 {
+			return nil
+		}
+
+		_, err := tx.NewInsert().
+			Model(&preferences).
+			ModelTableExpr(tableActivitiesAllocationPreferences).
+			Returning("id").
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "replace student preferences",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// CreateAssignments records the places granted when an allocation is applied
+func (r *AllocationRepository) CreateAssignments(ctx context.Context, assignments []*activities.AllocationAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&assignments).
+		ModelTableExpr(tableActivitiesAllocationAssignments).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "create allocation assignments",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// FindAssignments returns the recorded assignments of an allocation
+func (r *AllocationRepository) FindAssignments(ctx context.Context, allocationID int64) ([]*activities.AllocationAssignment, error) {
+	var assignments []*activities.AllocationAssignment
+	err := r.db.NewSelect().
+		Model(&assignments).
+		ModelTableExpr(`activities.allocation_assignments AS "allocation_assignment"`).
+		Where(`"allocation_assignment".allocation_id = ?`, allocationID).
+		OrderExpr(`"allocation_assignment".round ASC, "allocation_assignment".student_id ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find allocation assignments",
+			Err: err,
+		}
+	}
+
+	return assignments, nil
+}
@@ -23,7 +23,7 @@ const (
 // GroupRepository implements activities.GroupRepository interface
 type GroupRepository struct {
 	*base.Repository[*activities.Group]
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewGroupRepository creates a new GroupRepository
@@ -34,6 +34,34 @@ func NewGroupRepository(db *bun.DB) activities.GroupRepository {
 	}
 }
 
+// WithTx returns a repository that runs all operations in the provided transaction
+func (r *GroupRepository) WithTx(tx bun.Tx) interface{} {
+	return &GroupRepository{
+		Repository: r.Repository.WithTx(tx),
+		db:         tx,
+	}
+}
+
+// FindByIDForUpdate loads a group and locks its row until the surrounding transaction ends
+func (r *GroupRepository) FindByIDForUpdate(ctx context.Context, id int64) (*activities.Group, error) {
+	group := new(activities.Group)
+	err := r.db.NewSelect().
+		Model(group).
+		ModelTableExpr(tableExprActivitiesGroupsAsGrp).
+		Where(`"group".id = ?`, id).
+		For("UPDATE").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find group for update",
+			Err: err,
+		}
+	}
+
+	return group, nil
+}
+
 // FindByCategory finds all groups in a specific category
 func (r *GroupRepository) FindByCategory(ctx context.Context, categoryID int64) ([]*activities.Group, error) {
 	var groups []*activities.Group
@@ -112,6 +140,7 @@ func (r *GroupRepository) FindWithEnrollmentCounts(ctx context.Context) ([]*acti
 		Column("activity_group_id").
 		ColumnExpr("COUNT(*) AS count").
 		Where("activity_group_id IN (?)", bun.In(groupIDs)).
+		Where("status = ?", activities.EnrollmentStatusEnrolled).
 		Group("activity_group_id").
 		Scan(ctx, &counts)
 
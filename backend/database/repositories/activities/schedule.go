@@ -53,6 +53,32 @@ func (r *ScheduleRepository) FindByGroupID(ctx context.Context, groupID int64) (
 	return schedules, nil
 }
 
+// FindByGroupIDs finds all schedules for multiple groups
+func (r *ScheduleRepository) FindByGroupIDs(ctx context.Context, groupIDs []int64) ([]*activities.Schedule, error) {
+	if len(groupIDs) == 0 {
+		return []*activities.Schedule{}, nil
+	}
+
+	var schedules []*activities.Schedule
+	err := r.db.NewSelect().
+		Model(&schedules).
+		ModelTableExpr(tableExprActivitiesSchedulesAsSch).
+		Where("activity_group_id IN (?)", bun.In(groupIDs)).
+		Order("activity_group_id").
+		Order("weekday").
+		Order("timeframe_id").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by group IDs",
+			Err: err,
+		}
+	}
+
+	return schedules, nil
+}
+
 // FindByWeekday finds all schedules for a specific weekday
 func (r *ScheduleRepository) FindByWeekday(ctx context.Context, weekday string) ([]*activities.Schedule, error) {
 	var schedules []*activities.Schedule
@@ -0,0 +1,228 @@
+package activities
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// Table names for session register repositories
+const (
+	tableActivitiesSessionRegisters       = "activities.session_registers"
+	tableActivitiesSessionRegisterEntries = "activities.session_register_entries"
+	dateFormatISO                         = "2006-01-02"
+)
+
+// errSessionRegisterNil is returned when a nil register is passed to a repository method
+var errSessionRegisterNil = fmt.Errorf("session register cannot be nil")
+
+// SessionRegisterRepository implements activities.SessionRegisterRepository interface
+type SessionRegisterRepository struct {
+	*base.Repository[*activities.SessionRegister]
+	db bun.IDB
+}
+
+// NewSessionRegisterRepository creates a new SessionRegisterRepository
+func NewSessionRegisterRepository(db *bun.DB) activities.SessionRegisterRepository {
+	return &SessionRegisterRepository{
+		Repository: base.NewRepository[*activities.SessionRegister](db, tableActivitiesSessionRegisters, "SessionRegister"),
+		db:         db,
+	}
+}
+
+// WithTx returns a repository that runs all operations in the provided transaction
+func (r *SessionRegisterRepository) WithTx(tx bun.Tx) interface{} {
+	return &SessionRegisterRepository{
+		Repository: r.Repository.WithTx(tx),
+		db:         tx,
+	}
+}
+
+// Create inserts a register
+func (r *SessionRegisterRepository) Create(ctx context.Context, register *activities.SessionRegister) error {
+	if register == nil {
+		return errSessionRegisterNil
+	}
+
+	if err := register.Validate(); err != nil {
+		return err
+	}
+
+	_, err := r.db.NewInsert().
+		Model(register).
+		ModelTableExpr(tableActivitiesSessionRegisters).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "create session register",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// Update updates a register
+func (r *SessionRegisterRepository) Update(ctx context.Context, register *activities.SessionRegister) error {
+	if register == nil {
+		return errSessionRegisterNil
+	}
+
+	if err := register.Validate(); err != nil {
+		return err
+	}
+
+	_, err := r.db.NewUpdate().
+		Model(register).
+		ModelTableExpr(`activities.session_registers AS "session_register"`).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "update session register",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// List retrieves registers matching the provided query options, newest first by default
+func (r *SessionRegisterRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*activities.SessionRegister, error) {
+	var registers []*activities.SessionRegister
+	query := r.db.NewSelect().
+		Model(&registers).
+		ModelTableExpr(`activities.session_registers AS "session_register"`)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	} else {
+		query = query.OrderExpr(`"session_register".session_date DESC`)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return registers, nil
+}
+
+// FindByGroupAndDate finds the register of an activity group on a day; nil when none exists
+func (r *SessionRegisterRepository) FindByGroupAndDate(ctx context.Context, activityGroupID int64, date time.Time) (*activities.SessionRegister, error) {
+	register := new(activities.SessionRegister)
+	err := r.db.NewSelect().
+		Model(register).
+		ModelTableExpr(`activities.session_registers AS "session_register"`).
+		Where(`"session_register".activity_group_id = ?`, activityGroupID).
+		Where(`"session_register".session_date = ?`, date.Format(dateFormatISO)).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &modelBase.DatabaseError{
+			Op:  "find session register by group and date",
+			Err: err,
+		}
+	}
+
+	return register, nil
+}
+
+// FindByGroupAndDateRange finds the registers of an activity group between from and to (inclusive)
+// with their entries, ordered by date
+func (r *SessionRegisterRepository) FindByGroupAndDateRange(ctx context.Context, activityGroupID int64, from, to time.Time) ([]*activities.SessionRegister, error) {
+	var registers []*activities.SessionRegister
+	err := r.db.NewSelect().
+		Model(&registers).
+		ModelTableExpr(`activities.session_registers AS "session_register"`).
+		Where(`"session_register".activity_group_id = ?`, activityGroupID).
+		Where(`"session_register".session_date >= ?`, from.Format(dateFormatISO)).
+		Where(`"session_register".session_date <= ?`, to.Format(dateFormatISO)).
+		OrderExpr(`"session_register".session_date ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find session registers by date range",
+			Err: err,
+		}
+	}
+
+	for _, register := range registers {
+		entries, err := r.FindEntries(ctx, register.ID)
+		if err != nil {
+			return nil, err
+		}
+		register.Entries = entries
+	}
+
+	return registers, nil
+}
+
+// FindEntries returns the entries of a register, ordered by student
+func (r *SessionRegisterRepository) FindEntries(ctx context.Context, registerID int64) ([]*activities.SessionRegisterEntry, error) {
+	var entries []*activities.SessionRegisterEntry
+	err := r.db.NewSelect().
+		Model(&entries).
+		ModelTableExpr(`activities.session_register_entries AS "session_register_entry"`).
+		Where(`"session_register_entry".register_id = ?`, registerID).
+		OrderExpr(`"session_register_entry".student_id ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find session register entries",
+			Err: err,
+		}
+	}
+
+	return entries, nil
+}
+
+// SaveEntries inserts entries or updates the existing entry of the same register and student
+func (r *SessionRegisterRepository) SaveEntries(ctx context.Context, entries []*activities.SessionRegisterEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&entries).
+		ModelTableExpr(tableActivitiesSessionRegisterEntries).
+		On("CONFLICT (register_id, student_id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("first_seen_at = EXCLUDED.first_seen_at").
+		Set("minutes_present = EXCLUDED.minutes_present").
+		Set("note = EXCLUDED.note").
+		Set("excused_by = EXCLUDED.excused_by").
+		Set("excused_at = EXCLUDED.excused_at").
+		Set("updated_at = NOW()").
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "save session register entries",
+			Err: err,
+		}
+	}
+
+	return nil
+}
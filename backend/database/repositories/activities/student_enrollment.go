@@ -22,7 +22,7 @@ const (
 // StudentEnrollmentRepository implements activities.StudentEnrollmentRepository interface
 type StudentEnrollmentRepository struct {
 	*base.Repository[*activities.StudentEnrollment]
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewStudentEnrollmentRepository creates a new StudentEnrollmentRepository
@@ -33,6 +33,14 @@ func NewStudentEnrollmentRepository(db *bun.DB) activities.StudentEnrollmentRepo
 	}
 }
 
+// WithTx returns a repository that runs all operations in the provided transaction
+func (r *StudentEnrollmentRepository) WithTx(tx bun.Tx) interface{} {
+	return &StudentEnrollmentRepository{
+		Repository: r.Repository.WithTx(tx),
+		db:         tx,
+	}
+}
+
 // FindByStudentID finds all enrollments for a specific student
 func (r *StudentEnrollmentRepository) FindByStudentID(ctx context.Context, studentID int64) ([]*activities.StudentEnrollment, error) {
 	enrollments := make([]*activities.StudentEnrollment, 0)
@@ -77,6 +85,8 @@ func (r *StudentEnrollmentRepository) FindByGroupID(ctx context.Context, groupID
 		ColumnExpr(`"student_enrollment".activity_group_id AS "student_enrollment__activity_group_id"`).
 		ColumnExpr(`"student_enrollment".enrollment_date AS "student_enrollment__enrollment_date"`).
 		ColumnExpr(`"student_enrollment".attendance_status AS "student_enrollment__attendance_status"`).
+		ColumnExpr(`"student_enrollment".status AS "student_enrollment__status"`).
+		ColumnExpr(`"student_enrollment".waitlist_position AS "student_enrollment__waitlist_position"`).
 		ColumnExpr(`"student".id AS "student__id"`).
 		ColumnExpr(`"student".created_at AS "student__created_at"`).
 		ColumnExpr(`"student".updated_at AS "student__updated_at"`).
@@ -122,12 +132,13 @@ func (r *StudentEnrollmentRepository) FindByGroupID(ctx context.Context, groupID
 	return enrollments, nil
 }
 
-// CountByGroupID counts the number of students enrolled in a specific group
+// CountByGroupID counts the number of students enrolled in a specific group (waitlisted students excluded)
 func (r *StudentEnrollmentRepository) CountByGroupID(ctx context.Context, groupID int64) (int, error) {
 	count, err := r.db.NewSelect().
 		Model((*activities.StudentEnrollment)(nil)).
 		ModelTableExpr(tableExprActivitiesEnrollmentsAsEnrollment).
 		Where("activity_group_id = ?", groupID).
+		Where("status = ?", activities.EnrollmentStatusEnrolled).
 		Count(ctx)
 
 	if err != nil {
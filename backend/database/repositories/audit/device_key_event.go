@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moto-nrw/project-phoenix/models/audit"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const tableDeviceKeyEvents = "audit.device_key_events"
+
+// DeviceKeyEventRepository implements audit.DeviceKeyEventRepository interface
+type DeviceKeyEventRepository struct {
+	db *bun.DB
+}
+
+// NewDeviceKeyEventRepository creates a new DeviceKeyEventRepository
+func NewDeviceKeyEventRepository(db *bun.DB) audit.DeviceKeyEventRepository {
+	return &DeviceKeyEventRepository{db: db}
+}
+
+// Create inserts a device key audit record, inside the context transaction if there is one
+func (r *DeviceKeyEventRepository) Create(ctx context.Context, event *audit.DeviceKeyEvent) error {
+	if event == nil {
+		return &modelBase.DatabaseError{
+			Op:  "create",
+			Err: errors.New("event cannot be nil"),
+		}
+	}
+
+	if err := event.Validate(); err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "validate",
+			Err: err,
+		}
+	}
+
+	var db bun.IDB = r.db
+	if tx, ok := modelBase.TxFromContext(ctx); ok && tx != nil {
+		db = tx
+	}
+
+	_, err := db.NewInsert().
+		Model(event).
+		ModelTableExpr(tableDeviceKeyEvents).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "create",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// FindByDeviceID returns the most recent key events of a device
+func (r *DeviceKeyEventRepository) FindByDeviceID(ctx context.Context, deviceID int64, limit int) ([]*audit.DeviceKeyEvent, error) {
+	var events []*audit.DeviceKeyEvent
+	query := r.db.NewSelect().
+		Model(&events).
+		ModelTableExpr(`audit.device_key_events AS "device_key_event"`).
+		Where(`"device_key_event".device_id = ?`, deviceID).
+		Order(orderByCreatedAtDesc)
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by device ID",
+			Err: err,
+		}
+	}
+
+	return events, nil
+}
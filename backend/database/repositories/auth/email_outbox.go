@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/email"
+	modelAuth "github.com/moto-nrw/project-phoenix/models/auth"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const emailOutboxTable = "auth.email_outbox"
+
+// EmailOutboxRepository persists outbound emails for the durable dispatcher.
+type EmailOutboxRepository struct {
+	db *bun.DB
+}
+
+// NewEmailOutboxRepository creates a new email outbox repository.
+func NewEmailOutboxRepository(db *bun.DB) modelAuth.EmailOutboxRepository {
+	return &EmailOutboxRepository{db: db}
+}
+
+// Enqueue stores a new message that becomes due immediately.
+func (r *EmailOutboxRepository) Enqueue(ctx context.Context, entry *email.OutboxEntry) error {
+	if entry == nil {
+		return fmt.Errorf("outbox entry cannot be nil")
+	}
+
+	payload, err := email.EncodeMessage(entry.Message)
+	if err != nil {
+		return err
+	}
+
+	record := &modelAuth.EmailOutbox{
+		DeliveryType:  entry.Metadata.Type,
+		ReferenceID:   entry.Metadata.ReferenceID,
+		BaseRetry:     entry.Metadata.BaseRetry,
+		Recipient:     entry.Metadata.Recipient,
+		Payload:       payload,
+		Status:        modelAuth.EmailOutboxStatusPending,
+		MaxAttempts:   entry.MaxAttempts,
+		NextAttemptAt: time.Now(),
+	}
+	if record.Recipient == "" {
+		record.Recipient = entry.Message.To.Address
+	}
+	record.SetBackoff(entry.Backoff)
+
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := r.db.NewInsert().
+		Model(record).
+		ModelTableExpr(emailOutboxTable).
+		Returning("id").
+		Exec(ctx); err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "enqueue email",
+			Err: err,
+		}
+	}
+
+	entry.ID = record.ID
+	return nil
+}
+
+// ClaimDue locks up to limit due messages and increments their attempt counter.
+// FOR UPDATE SKIP LOCKED lets several replicas poll the outbox without sending twice.
+func (r *EmailOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*email.OutboxEntry, error) {
+	var records []*modelAuth.EmailOutbox
+	query := `
+		UPDATE auth.email_outbox AS outbox
+		SET attempts = outbox.attempts + 1,
+			locked_until = NOW() + make_interval(secs => ?),
+			updated_at = NOW()
+		WHERE outbox.id IN (
+			SELECT id FROM auth.email_outbox
+			WHERE status = 'pending'
+				AND next_attempt_at <= NOW()
+				AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING outbox.*
+	`
+
+	if err := r.db.NewRaw(query, lease.Seconds(), limit).Scan(ctx, &records); err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "claim due emails",
+			Err: err,
+		}
+	}
+
+	entries := make([]*email.OutboxEntry, 0, len(records))
+	for _, record := range records {
+		message, err := email.DecodeMessage(record.Payload)
+		if err != nil {
+			// A payload that cannot be decoded will never succeed; fail it permanently.
+			if markErr := r.MarkFailed(ctx, record.ID, email.ErrorText(err), nil); markErr != nil {
+				return nil, markErr
+			}
+			continue
+		}
+
+		entries = append(entries, &email.OutboxEntry{
+			ID:      record.ID,
+			Message: message,
+			Metadata: email.DeliveryMetadata{
+				Type:        record.DeliveryType,
+				ReferenceID: record.ReferenceID,
+				Recipient:   record.Recipient,
+				BaseRetry:   record.BaseRetry,
+			},
+			Attempts:    record.Attempts,
+			MaxAttempts: record.MaxAttempts,
+			Backoff:     record.Backoff(),
+		})
+	}
+
+	return entries, nil
+}
+
+// MarkSent records a successful delivery.
+func (r *EmailOutboxRepository) MarkSent(ctx context.Context, id int64, sentAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*modelAuth.EmailOutbox)(nil)).
+		ModelTableExpr(emailOutboxTable).
+		Set("status = ?", modelAuth.EmailOutboxStatusSent).
+		Set("sent_at = ?", sentAt).
+		Set("locked_until = NULL").
+		Set("last_error = NULL").
+		Set("updated_at = NOW()").
+		Where(whereID, id).
+		Exec(ctx)
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "mark email sent",
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt; a nil retryAt marks the message as permanently failed.
+func (r *EmailOutboxRepository) MarkFailed(ctx context.Context, id int64, errText string, retryAt *time.Time) error {
+	update := r.db.NewUpdate().
+		Model((*modelAuth.EmailOutbox)(nil)).
+		ModelTableExpr(emailOutboxTable).
+		Set("last_error = ?", errText).
+		Set("locked_until = NULL").
+		Set("updated_at = NOW()").
+		Where(whereID, id)
+
+	if retryAt != nil {
+		update = update.Set("next_attempt_at = ?", *retryAt)
+	} else {
+		update = update.Set("status = ?", modelAuth.EmailOutboxStatusFailed)
+	}
+
+	if _, err := update.Exec(ctx); err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "mark email failed",
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// GetDeliveryStats returns row counts grouped by delivery type and status.
+func (r *EmailOutboxRepository) GetDeliveryStats(ctx context.Context) ([]*modelAuth.EmailDeliveryStats, error) {
+	var stats []*modelAuth.EmailDeliveryStats
+	err := r.db.NewSelect().
+		TableExpr(emailOutboxTable).
+		ColumnExpr("delivery_type").
+		ColumnExpr("status").
+		ColumnExpr("COUNT(*) AS count").
+		ColumnExpr("MIN(created_at) AS oldest_created_at").
+		ColumnExpr("MAX(updated_at) FILTER (WHERE attempts > 0) AS last_attempt_at").
+		GroupExpr("delivery_type, status").
+		OrderExpr("delivery_type ASC, status ASC").
+		Scan(ctx, &stats)
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "get email delivery stats",
+			Err: err,
+		}
+	}
+	return stats, nil
+}
+
+// DeleteFinishedBefore removes sent and permanently failed rows last updated before cutoff.
+func (r *EmailOutboxRepository) DeleteFinishedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	res, err := r.db.NewDelete().
+		TableExpr(emailOutboxTable).
+		Where("status IN (?)", bun.In([]string{modelAuth.EmailOutboxStatusSent, modelAuth.EmailOutboxStatusFailed})).
+		Where("updated_at < ?", cutoff).
+		Exec(ctx)
+	if err != nil {
+		return 0, &modelBase.DatabaseError{
+			Op:  "cleanup email outbox",
+			Err: err,
+		}
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rows affected for email outbox cleanup: %w", err)
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,98 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories"
+	"github.com/moto-nrw/project-phoenix/email"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestOutboxEntry builds an outbox entry with a unique delivery type so tests do not interfere.
+func newTestOutboxEntry(deliveryType string) *email.OutboxEntry {
+	return &email.OutboxEntry{
+		Message: email.Message{
+			To:       email.NewEmail("", "outbox-test@example.com"),
+			Subject:  "Outbox test",
+			Template: "invitation.html",
+			Content:  map[string]any{"Name": "Test"},
+		},
+		Metadata:    email.DeliveryMetadata{Type: deliveryType, ReferenceID: 99, BaseRetry: 20},
+		MaxAttempts: 3,
+		Backoff:     []time.Duration{time.Minute},
+	}
+}
+
+func TestEmailOutboxRepository_EnqueueAndClaim(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).EmailOutbox
+	ctx := context.Background()
+
+	entry := newTestOutboxEntry("outbox_test_claim")
+	require.NoError(t, repo.Enqueue(ctx, entry))
+	require.NotZero(t, entry.ID)
+	defer testpkg.CleanupTableRecords(t, db, "auth.email_outbox", entry.ID)
+
+	claimed, err := repo.ClaimDue(ctx, 100, time.Minute)
+	require.NoError(t, err)
+
+	var found *email.OutboxEntry
+	for _, c := range claimed {
+		if c.ID == entry.ID {
+			found = c
+		}
+	}
+	require.NotNil(t, found, "enqueued entry should be claimed")
+	assert.Equal(t, 1, found.Attempts)
+	assert.Equal(t, "outbox-test@example.com", found.Metadata.Recipient)
+	assert.Equal(t, 20, found.Metadata.BaseRetry)
+	assert.Equal(t, "Outbox test", found.Message.Subject)
+	assert.Equal(t, []time.Duration{time.Minute}, found.Backoff)
+
+	// The lease hides the entry from a second worker
+	again, err := repo.ClaimDue(ctx, 100, time.Minute)
+	require.NoError(t, err)
+	for _, c := range again {
+		assert.NotEqual(t, entry.ID, c.ID)
+	}
+}
+
+func TestEmailOutboxRepository_MarkSentAndStats(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).EmailOutbox
+	ctx := context.Background()
+
+	sent := newTestOutboxEntry("outbox_test_stats")
+	failed := newTestOutboxEntry("outbox_test_stats")
+	require.NoError(t, repo.Enqueue(ctx, sent))
+	require.NoError(t, repo.Enqueue(ctx, failed))
+	defer testpkg.CleanupTableRecords(t, db, "auth.email_outbox", sent.ID, failed.ID)
+
+	require.NoError(t, repo.MarkSent(ctx, sent.ID, time.Now()))
+	require.NoError(t, repo.MarkFailed(ctx, failed.ID, "mailbox unavailable", nil))
+
+	stats, err := repo.GetDeliveryStats(ctx)
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for _, s := range stats {
+		if s.DeliveryType == "outbox_test_stats" {
+			counts[s.Status] = s.Count
+		}
+	}
+	assert.Equal(t, 1, counts["sent"])
+	assert.Equal(t, 1, counts["failed"])
+
+	// Finished rows are removed once older than the cutoff
+	deleted, err := repo.DeleteFinishedBefore(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, 2)
+}
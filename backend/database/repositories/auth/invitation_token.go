@@ -287,7 +287,7 @@ func (r *InvitationTokenRepository) UpdateDeliveryResult(ctx context.Context, id
 	}
 
 	if emailError != nil {
-		update = update.Set(`email_error = ?`, truncateError(*emailError))
+		update = update.Set(`email_error = ?`, *emailError)
 	} else {
 		update = update.Set(`email_error = NULL`)
 	}
@@ -121,7 +121,7 @@ func (r *PasswordResetTokenRepository) UpdateDeliveryResult(ctx context.Context,
 	}
 
 	if emailError != nil {
-		update = update.Set("email_error = ?", truncateError(*emailError))
+		update = update.Set("email_error = ?", *emailError)
 	} else {
 		update = update.Set("email_error = NULL")
 	}
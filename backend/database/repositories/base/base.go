@@ -16,6 +16,8 @@ type Repository[T modelBase.Entity] struct {
 	DB         *bun.DB
 	TableName  string
 	EntityName string
+
+	tx *bun.Tx // Set by WithTx; the CRUD operations then run in this transaction
 }
 
 // NewRepository creates a new base repository instance
@@ -27,6 +29,24 @@ func NewRepository[T modelBase.Entity](db *bun.DB, tableName, entityName string)
 	}
 }
 
+// WithTx returns a copy of the repository whose operations run in the provided transaction
+func (r *Repository[T]) WithTx(tx bun.Tx) *Repository[T] {
+	return &Repository[T]{
+		DB:         r.DB,
+		TableName:  r.TableName,
+		EntityName: r.EntityName,
+		tx:         &tx,
+	}
+}
+
+// IDB returns the transaction set by WithTx, or the database
+func (r *Repository[T]) IDB() bun.IDB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.DB
+}
+
 // Create inserts a new entity into the database
 func (r *Repository[T]) Create(ctx context.Context, entity T) error {
 	// Check if entity is nil using reflection
@@ -42,7 +62,7 @@ func (r *Repository[T]) Create(ctx context.Context, entity T) error {
 	}
 
 	// Explicitly set the table name with schema
-	_, err := r.DB.NewInsert().
+	_, err := r.IDB().NewInsert().
 		Model(entity).
 		ModelTableExpr(r.TableName).
 		Exec(ctx)
@@ -75,7 +95,7 @@ func (r *Repository[T]) FindByID(ctx context.Context, id interface{}) (T, error)
 	entityName := toSnakeCase(strings.TrimPrefix(r.EntityName, "*"))
 	tableExpr := fmt.Sprintf(`%s AS "%s"`, r.TableName, entityName)
 
-	err := r.DB.NewSelect().
+	err := r.IDB().NewSelect().
 		Model(entityVal).
 		ModelTableExpr(tableExpr).
 		Where(fmt.Sprintf(`"%s".id = ?`, entityName), id).
@@ -109,7 +129,7 @@ func (r *Repository[T]) Update(ctx context.Context, entity T) error {
 	entityName := toSnakeCase(strings.TrimPrefix(r.EntityName, "*"))
 	tableExpr := fmt.Sprintf(`%s AS "%s"`, r.TableName, entityName)
 
-	_, err := r.DB.NewUpdate().
+	_, err := r.IDB().NewUpdate().
 		Model(entity).
 		ModelTableExpr(tableExpr).
 		WherePK().
@@ -141,7 +161,7 @@ func (r *Repository[T]) Delete(ctx context.Context, id interface{}) error {
 	entityName := toSnakeCase(strings.TrimPrefix(r.EntityName, "*"))
 	tableExpr := fmt.Sprintf(`%s AS "%s"`, r.TableName, entityName)
 
-	_, err := r.DB.NewDelete().
+	_, err := r.IDB().NewDelete().
 		Model(entityVal).
 		ModelTableExpr(tableExpr).
 		Where(fmt.Sprintf(`"%s".id = ?`, entityName), id).
@@ -165,7 +185,7 @@ func (r *Repository[T]) List(ctx context.Context, filters map[string]interface{}
 	entityName := toSnakeCase(strings.TrimPrefix(r.EntityName, "*"))
 	tableExpr := fmt.Sprintf(`%s AS "%s"`, r.TableName, entityName)
 
-	query := r.DB.NewSelect().
+	query := r.IDB().NewSelect().
 		Model(&entities).
 		ModelTableExpr(tableExpr)
 
@@ -204,7 +224,7 @@ func (r *Repository[T]) Count(ctx context.Context, filters map[string]interface{
 	entityName := strings.ToLower(strings.TrimPrefix(r.EntityName, "*"))
 	tableExpr := fmt.Sprintf(`%s AS "%s"`, r.TableName, entityName)
 
-	query := r.DB.NewSelect().
+	query := r.IDB().NewSelect().
 		Model(entityVal).
 		ModelTableExpr(tableExpr).
 		Column("id")
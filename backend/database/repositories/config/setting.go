@@ -84,6 +84,35 @@ func (r *SettingRepository) FindByKey(ctx context.Context, key string) (*config.
 	return setting, nil
 }
 
+// FindByKeys retrieves the settings with the given keys; keys without a setting are left out
+func (r *SettingRepository) FindByKeys(ctx context.Context, keys []string) ([]*config.Setting, error) {
+	if len(keys) == 0 {
+		return []*config.Setting{}, nil
+	}
+
+	// Normalize keys to follow the project convention
+	normalized := make([]string, 0, len(keys))
+	for _, key := range keys {
+		normalized = append(normalized, strings.ToLower(strings.ReplaceAll(key, " ", "_")))
+	}
+
+	var settings []*config.Setting
+	err := r.db.NewSelect().
+		Model(&settings).
+		ModelTableExpr(tableConfigSettingsAlias).
+		Where("key IN (?)", bun.In(normalized)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by keys",
+			Err: err,
+		}
+	}
+
+	return settings, nil
+}
+
 // FindByCategory retrieves settings by their category
 func (r *SettingRepository) FindByCategory(ctx context.Context, category string) ([]*config.Setting, error) {
 	// Normalize category to follow the project convention
@@ -22,7 +22,7 @@ const dateRangeContainsCondition = "start_date <= ? AND end_date >= ?"
 // GroupSubstitutionRepository implements education.GroupSubstitutionRepository interface
 type GroupSubstitutionRepository struct {
 	*base.Repository[*education.GroupSubstitution]
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewGroupSubstitutionRepository creates a new GroupSubstitutionRepository
@@ -33,6 +33,11 @@ func NewGroupSubstitutionRepository(db *bun.DB) education.GroupSubstitutionRepos
 	}
 }
 
+// WithTx returns a repository that runs all operations in the provided transaction
+func (r *GroupSubstitutionRepository) WithTx(tx bun.Tx) interface{} {
+	return &GroupSubstitutionRepository{Repository: r.Repository.WithTx(tx), db: tx}
+}
+
 // FindByGroup retrieves all substitutions for a specific group
 func (r *GroupSubstitutionRepository) FindByGroup(ctx context.Context, groupID int64) ([]*education.GroupSubstitution, error) {
 	var substitutions []*education.GroupSubstitution
@@ -168,6 +173,25 @@ func (r *GroupSubstitutionRepository) FindOverlapping(ctx context.Context, staff
 	return substitutions, nil
 }
 
+// FindOverlappingRange retrieves all substitutions overlapping the date range
+func (r *GroupSubstitutionRepository) FindOverlappingRange(ctx context.Context, startDate time.Time, endDate time.Time) ([]*education.GroupSubstitution, error) {
+	var substitutions []*education.GroupSubstitution
+	err := r.db.NewSelect().
+		Model(&substitutions).
+		ModelTableExpr(tableGroupSubstitution).
+		Where(dateRangeContainsCondition, endDate, startDate).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find overlapping range",
+			Err: err,
+		}
+	}
+
+	return substitutions, nil
+}
+
 // Create overrides the base Create method to handle validation
 func (r *GroupSubstitutionRepository) Create(ctx context.Context, substitution *education.GroupSubstitution) error {
 	if substitution == nil {
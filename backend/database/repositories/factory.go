@@ -47,6 +47,7 @@ type Factory struct {
 	PasswordResetRateLimit authModels.PasswordResetRateLimitRepository
 	InvitationToken        authModels.InvitationTokenRepository
 	GuardianInvitation     authModels.GuardianInvitationRepository
+	EmailOutbox            authModels.EmailOutboxRepository
 
 	// Users domain
 	Person              userModels.PersonRepository
@@ -61,6 +62,7 @@ type Factory struct {
 	GuardianProfile     userModels.GuardianProfileRepository
 	GuardianPhoneNumber userModels.GuardianPhoneNumberRepository
 	PrivacyConsent      userModels.PrivacyConsentRepository
+	StudentPickupPerson userModels.StudentPickupPersonRepository
 
 	// Facilities domain
 	Room facilityModels.RoomRepository
@@ -78,6 +80,8 @@ type Factory struct {
 	StudentPickupSchedule  scheduleModels.StudentPickupScheduleRepository
 	StudentPickupException scheduleModels.StudentPickupExceptionRepository
 	StudentPickupNote      scheduleModels.StudentPickupNoteRepository
+	BusRoute               scheduleModels.BusRouteRepository
+	CalendarFeed           scheduleModels.CalendarFeedRepository
 
 	// Activities domain
 	ActivityGroup      activitiesModels.GroupRepository
@@ -85,23 +89,31 @@ type Factory struct {
 	ActivitySchedule   activitiesModels.ScheduleRepository
 	ActivitySupervisor activitiesModels.SupervisorPlannedRepository
 	StudentEnrollment  activitiesModels.StudentEnrollmentRepository
+	ActivityAllocation activitiesModels.AllocationRepository
+	SessionRegister    activitiesModels.SessionRegisterRepository
 
 	// Active domain
-	ActiveGroup      activeModels.GroupRepository
-	ActiveVisit      activeModels.VisitRepository
-	GroupSupervisor  activeModels.GroupSupervisorRepository
-	CombinedGroup    activeModels.CombinedGroupRepository
-	GroupMapping     activeModels.GroupMappingRepository
-	Attendance       activeModels.AttendanceRepository
-	WorkSession      activeModels.WorkSessionRepository
-	WorkSessionBreak activeModels.WorkSessionBreakRepository
-	StaffAbsence     activeModels.StaffAbsenceRepository
+	ActiveGroup            activeModels.GroupRepository
+	ActiveVisit            activeModels.VisitRepository
+	GroupSupervisor        activeModels.GroupSupervisorRepository
+	CombinedGroup          activeModels.CombinedGroupRepository
+	GroupMapping           activeModels.GroupMappingRepository
+	Attendance             activeModels.AttendanceRepository
+	WorkSession            activeModels.WorkSessionRepository
+	WorkSessionBreak       activeModels.WorkSessionBreakRepository
+	StaffAbsence           activeModels.StaffAbsenceRepository
+	StaffContract          activeModels.StaffContractRepository
+	StudentAbsence         activeModels.StudentAbsenceRepository
+	MissingStudentAlert    activeModels.MissingStudentAlertRepository
+	DispatchedNotification activeModels.DispatchedNotificationRepository
 
 	// Feedback domain
 	FeedbackEntry feedbackModels.EntryRepository
 
 	// IoT domain
-	Device iotModels.DeviceRepository
+	Device           iotModels.DeviceRepository
+	DeviceEvent      iotModels.DeviceEventRepository
+	DeviceEnrollment iotModels.DeviceEnrollmentRepository
 
 	// Config domain
 	Setting configModels.SettingRepository
@@ -118,6 +130,7 @@ type Factory struct {
 	AuthEvent       auditModels.AuthEventRepository
 	DataImport      auditModels.DataImportRepository
 	WorkSessionEdit auditModels.WorkSessionEditRepository
+	DeviceKeyEvent  auditModels.DeviceKeyEventRepository
 
 	// Platform domain (operator dashboard)
 	Operator         platformModels.OperatorRepository
@@ -142,6 +155,7 @@ func NewFactory(db *bun.DB) *Factory {
 		PasswordResetRateLimit: auth.NewPasswordResetRateLimitRepository(db),
 		InvitationToken:        auth.NewInvitationTokenRepository(db),
 		GuardianInvitation:     auth.NewGuardianInvitationRepository(db),
+		EmailOutbox:            auth.NewEmailOutboxRepository(db),
 
 		// Users repositories
 		Person:              users.NewPersonRepository(db),
@@ -156,6 +170,7 @@ func NewFactory(db *bun.DB) *Factory {
 		GuardianProfile:     users.NewGuardianProfileRepository(db),
 		GuardianPhoneNumber: users.NewGuardianPhoneNumberRepository(db),
 		PrivacyConsent:      users.NewPrivacyConsentRepository(db),
+		StudentPickupPerson: users.NewStudentPickupPersonRepository(db),
 
 		// Facilities repositories
 		Room: facilities.NewRoomRepository(db),
@@ -173,6 +188,8 @@ func NewFactory(db *bun.DB) *Factory {
 		StudentPickupSchedule:  schedule.NewStudentPickupScheduleRepository(db),
 		StudentPickupException: schedule.NewStudentPickupExceptionRepository(db),
 		StudentPickupNote:      schedule.NewStudentPickupNoteRepository(db),
+		BusRoute:               schedule.NewBusRouteRepository(db),
+		CalendarFeed:           schedule.NewCalendarFeedRepository(db),
 
 		// Activities repositories
 		ActivityGroup:      activities.NewGroupRepository(db),
@@ -180,23 +197,31 @@ func NewFactory(db *bun.DB) *Factory {
 		ActivitySchedule:   activities.NewScheduleRepository(db),
 		ActivitySupervisor: activities.NewSupervisorPlannedRepository(db),
 		StudentEnrollment:  activities.NewStudentEnrollmentRepository(db),
+		ActivityAllocation: activities.NewAllocationRepository(db),
+		SessionRegister:    activities.NewSessionRegisterRepository(db),
 
 		// Active repositories
-		ActiveGroup:      active.NewGroupRepository(db),
-		ActiveVisit:      active.NewVisitRepository(db),
-		GroupSupervisor:  active.NewGroupSupervisorRepository(db),
-		CombinedGroup:    active.NewCombinedGroupRepository(db),
-		GroupMapping:     active.NewGroupMappingRepository(db),
-		Attendance:       active.NewAttendanceRepository(db),
-		WorkSession:      active.NewWorkSessionRepository(db),
-		WorkSessionBreak: active.NewWorkSessionBreakRepository(db),
-		StaffAbsence:     active.NewStaffAbsenceRepository(db),
+		ActiveGroup:            active.NewGroupRepository(db),
+		ActiveVisit:            active.NewVisitRepository(db),
+		GroupSupervisor:        active.NewGroupSupervisorRepository(db),
+		CombinedGroup:          active.NewCombinedGroupRepository(db),
+		GroupMapping:           active.NewGroupMappingRepository(db),
+		Attendance:             active.NewAttendanceRepository(db),
+		WorkSession:            active.NewWorkSessionRepository(db),
+		WorkSessionBreak:       active.NewWorkSessionBreakRepository(db),
+		StaffAbsence:           active.NewStaffAbsenceRepository(db),
+		StaffContract:          active.NewStaffContractRepository(db),
+		StudentAbsence:         active.NewStudentAbsenceRepository(db),
+		MissingStudentAlert:    active.NewMissingStudentAlertRepository(db),
+		DispatchedNotification: active.NewDispatchedNotificationRepository(db),
 
 		// Feedback repositories
 		FeedbackEntry: feedback.NewEntryRepository(db),
 
 		// IoT repositories
-		Device: iot.NewDeviceRepository(db),
+		Device:           iot.NewDeviceRepository(db),
+		DeviceEvent:      iot.NewDeviceEventRepository(db),
+		DeviceEnrollment: iot.NewDeviceEnrollmentRepository(db),
 
 		// Config repositories
 		Setting: config.NewSettingRepository(db),
@@ -213,6 +238,7 @@ func NewFactory(db *bun.DB) *Factory {
 		AuthEvent:       audit.NewAuthEventRepository(db),
 		DataImport:      audit.NewDataImportRepository(db),
 		WorkSessionEdit: audit.NewWorkSessionEditRepository(db),
+		DeviceKeyEvent:  audit.NewDeviceKeyEventRepository(db),
 
 		// Platform repositories
 		Operator:         platformRepo.NewOperatorRepository(db),
@@ -30,6 +30,7 @@ func TestNewFactory(t *testing.T) {
 		assert.NotNil(t, factory.PasswordResetRateLimit)
 		assert.NotNil(t, factory.InvitationToken)
 		assert.NotNil(t, factory.GuardianInvitation)
+		assert.NotNil(t, factory.EmailOutbox)
 	})
 
 	// Verify users repositories are initialized
@@ -51,13 +51,17 @@ func (r *DeviceRepository) FindByDeviceID(ctx context.Context, deviceID string)
 	return device, nil
 }
 
-// FindByAPIKey retrieves a device by its API key
+// FindByAPIKey retrieves a device by its API key.
+// A rotated-out key still matches until its grace period has expired.
 func (r *DeviceRepository) FindByAPIKey(ctx context.Context, apiKey string) (*iot.Device, error) {
 	device := new(iot.Device)
 	err := r.db.NewSelect().
 		Model(device).
 		ModelTableExpr(`iot.devices AS "device"`).
-		Where("api_key = ?", apiKey).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("api_key = ?", apiKey).
+				WhereOr("previous_api_key = ? AND previous_api_key_expires_at > NOW()", apiKey)
+		}).
 		Scan(ctx)
 
 	if err != nil {
@@ -165,6 +169,63 @@ func (r *DeviceRepository) UpdateStatus(ctx context.Context, deviceID string, st
 	return nil
 }
 
+// MarkOfflineIfStale sets an active device offline only if it has not been seen since cutoff
+func (r *DeviceRepository) MarkOfflineIfStale(ctx context.Context, deviceID string, cutoff time.Time) (bool, error) {
+	result, err := r.db.NewUpdate().
+		Model((*iot.Device)(nil)).
+		ModelTableExpr(tableIoTDevices).
+		Set(whereStatusEqual, iot.DeviceStatusOffline).
+		Where(whereDeviceIDEqual, deviceID).
+		Where(whereStatusEqual, iot.DeviceStatusActive).
+		Where("(last_seen < ? OR (last_seen IS NULL AND created_at < ?))", cutoff, cutoff).
+		Exec(ctx)
+
+	if err != nil {
+		return false, &modelBase.DatabaseError{
+			Op:  "mark offline if stale",
+			Err: err,
+		}
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, &modelBase.DatabaseError{
+			Op:  "mark offline if stale",
+			Err: err,
+		}
+	}
+
+	return rows > 0, nil
+}
+
+// UpdateAPIKeys replaces the device's current and previous API keys in one statement.
+// Runs inside the context transaction when there is one.
+func (r *DeviceRepository) UpdateAPIKeys(ctx context.Context, id int64, apiKey, previousAPIKey *string, previousExpiresAt *time.Time) error {
+	var db bun.IDB = r.db
+	if tx, ok := modelBase.TxFromContext(ctx); ok && tx != nil {
+		db = tx
+	}
+
+	_, err := db.NewUpdate().
+		Model((*iot.Device)(nil)).
+		ModelTableExpr(tableIoTDevices).
+		Set("api_key = ?", apiKey).
+		Set("previous_api_key = ?", previousAPIKey).
+		Set("previous_api_key_expires_at = ?", previousExpiresAt).
+		Set("updated_at = NOW()").
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "update API keys",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
 // FindActiveDevices retrieves all active devices
 func (r *DeviceRepository) FindActiveDevices(ctx context.Context) ([]*iot.Device, error) {
 	var devices []*iot.Device
@@ -268,6 +329,20 @@ func (r *DeviceRepository) Create(ctx context.Context, device *iot.Device) error
 		return err
 	}
 
+	// Enrollment creates devices inside a transaction
+	if tx, ok := modelBase.TxFromContext(ctx); ok && tx != nil {
+		if _, err := tx.NewInsert().
+			Model(device).
+			ModelTableExpr(tableIoTDevices).
+			Exec(ctx); err != nil {
+			return &modelBase.DatabaseError{
+				Op:  "create",
+				Err: err,
+			}
+		}
+		return nil
+	}
+
 	// Use the base Create method
 	return r.Repository.Create(ctx, device)
 }
@@ -0,0 +1,121 @@
+package iot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/uptrace/bun"
+)
+
+const tableIoTDeviceEnrollments = "iot.device_enrollments"
+
+// DeviceEnrollmentRepository implements iot.DeviceEnrollmentRepository interface
+type DeviceEnrollmentRepository struct {
+	db *bun.DB
+}
+
+// NewDeviceEnrollmentRepository creates a new DeviceEnrollmentRepository
+func NewDeviceEnrollmentRepository(db *bun.DB) iot.DeviceEnrollmentRepository {
+	return &DeviceEnrollmentRepository{db: db}
+}
+
+// getDB returns the context transaction if there is one
+func (r *DeviceEnrollmentRepository) getDB(ctx context.Context) bun.IDB {
+	if tx, ok := modelBase.TxFromContext(ctx); ok && tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+// Create stores a new enrollment code
+func (r *DeviceEnrollmentRepository) Create(ctx context.Context, enrollment *iot.DeviceEnrollment) error {
+	if err := enrollment.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := r.getDB(ctx).NewInsert().
+		Model(enrollment).
+		ModelTableExpr(tableIoTDeviceEnrollments).
+		Returning("id, created_at, updated_at").
+		Exec(ctx); err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "create device enrollment",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// FindPending retrieves unused, unexpired enrollment codes, newest first
+func (r *DeviceEnrollmentRepository) FindPending(ctx context.Context, now time.Time) ([]*iot.DeviceEnrollment, error) {
+	var enrollments []*iot.DeviceEnrollment
+	err := r.db.NewSelect().
+		Model(&enrollments).
+		ModelTableExpr(`iot.device_enrollments AS "device_enrollment"`).
+		Where("used_at IS NULL").
+		Where("expires_at > ?", now).
+		Order("created_at DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find pending device enrollments",
+			Err: err,
+		}
+	}
+
+	return enrollments, nil
+}
+
+// Claim marks a pending code as used in a single conditional update, so a code can only be
+// exchanged once even under concurrent requests
+func (r *DeviceEnrollmentRepository) Claim(ctx context.Context, codeHash string, now time.Time) (*iot.DeviceEnrollment, error) {
+	enrollment := new(iot.DeviceEnrollment)
+	err := r.getDB(ctx).NewUpdate().
+		Model(enrollment).
+		ModelTableExpr(tableIoTDeviceEnrollments).
+		Set("used_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("code_hash = ?", codeHash).
+		Where("used_at IS NULL").
+		Where("expires_at > ?", now).
+		Returning("*").
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "claim device enrollment",
+			Err: err,
+		}
+	}
+
+	return enrollment, nil
+}
+
+// SetEnrolledDevice links a claimed code to the device it produced
+func (r *DeviceEnrollmentRepository) SetEnrolledDevice(ctx context.Context, id, deviceID int64) error {
+	_, err := r.getDB(ctx).NewUpdate().
+		Model((*iot.DeviceEnrollment)(nil)).
+		ModelTableExpr(tableIoTDeviceEnrollments).
+		Set("enrolled_device_id = ?", deviceID).
+		Set("updated_at = NOW()").
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "set enrolled device",
+			Err: err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package iot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/uptrace/bun"
+)
+
+const tableIoTDeviceEvents = "iot.device_events"
+
+// DeviceEventRepository implements iot.DeviceEventRepository interface
+type DeviceEventRepository struct {
+	db *bun.DB
+}
+
+// NewDeviceEventRepository creates a new DeviceEventRepository
+func NewDeviceEventRepository(db *bun.DB) iot.DeviceEventRepository {
+	return &DeviceEventRepository{db: db}
+}
+
+// Create records a device status transition
+func (r *DeviceEventRepository) Create(ctx context.Context, event *iot.DeviceEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := r.db.NewInsert().
+		Model(event).
+		ModelTableExpr(tableIoTDeviceEvents).
+		Returning("id").
+		Exec(ctx); err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "create device event",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// FindByDeviceID retrieves a device's events since the given time, oldest first
+func (r *DeviceEventRepository) FindByDeviceID(ctx context.Context, deviceID int64, since time.Time) ([]*iot.DeviceEvent, error) {
+	var events []*iot.DeviceEvent
+	err := r.db.NewSelect().
+		Model(&events).
+		ModelTableExpr(`iot.device_events AS "device_event"`).
+		Where("device_id = ?", deviceID).
+		Where("occurred_at >= ?", since).
+		Order("occurred_at ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find device events",
+			Err: err,
+		}
+	}
+
+	return events, nil
+}
+
+// FindLatestBefore retrieves the device's last event before the given time
+func (r *DeviceEventRepository) FindLatestBefore(ctx context.Context, deviceID int64, before time.Time) (*iot.DeviceEvent, error) {
+	event := new(iot.DeviceEvent)
+	err := r.db.NewSelect().
+		Model(event).
+		ModelTableExpr(`iot.device_events AS "device_event"`).
+		Where("device_id = ?", deviceID).
+		Where("occurred_at < ?", before).
+		Order("occurred_at DESC").
+		Limit(1).
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find latest device event",
+			Err: err,
+		}
+	}
+
+	return event, nil
+}
+
+// DeleteOlderThan purges events that occurred before the cutoff
+func (r *DeviceEventRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error) {
+	result, err := r.db.NewDelete().
+		Model((*iot.DeviceEvent)(nil)).
+		ModelTableExpr(tableIoTDeviceEvents).
+		Where("occurred_at < ?", cutoff).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, &modelBase.DatabaseError{
+			Op:  "delete old device events",
+			Err: err,
+		}
+	}
+
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
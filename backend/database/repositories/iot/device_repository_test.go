@@ -457,6 +457,42 @@ func TestDeviceRepository_UpdateStatus(t *testing.T) {
 	})
 }
 
+func TestDeviceRepository_MarkOfflineIfStale(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).Device
+	ctx := context.Background()
+
+	t.Run("marks a stale device offline", func(t *testing.T) {
+		device := testpkg.CreateTestDevice(t, db, "stale")
+		defer testpkg.CleanupActivityFixtures(t, db, 0, 0, device.ID, 0, 0)
+		require.NoError(t, repo.UpdateLastSeen(ctx, device.DeviceID, time.Now().Add(-time.Hour)))
+
+		updated, err := repo.MarkOfflineIfStale(ctx, device.DeviceID, time.Now().Add(-5*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		found, err := repo.FindByID(ctx, device.ID)
+		require.NoError(t, err)
+		assert.Equal(t, iot.DeviceStatusOffline, found.Status)
+	})
+
+	t.Run("keeps a device that sent a heartbeat", func(t *testing.T) {
+		device := testpkg.CreateTestDevice(t, db, "fresh")
+		defer testpkg.CleanupActivityFixtures(t, db, 0, 0, device.ID, 0, 0)
+		require.NoError(t, repo.UpdateLastSeen(ctx, device.DeviceID, time.Now()))
+
+		updated, err := repo.MarkOfflineIfStale(ctx, device.DeviceID, time.Now().Add(-5*time.Minute))
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		found, err := repo.FindByID(ctx, device.ID)
+		require.NoError(t, err)
+		assert.Equal(t, iot.DeviceStatusActive, found.Status)
+	})
+}
+
 // ============================================================================
 // Specialized Query Tests
 // ============================================================================
@@ -0,0 +1,223 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/uptrace/bun"
+)
+
+// Table names for bus route repositories.
+const (
+	tableBusRoutes          = "schedule.bus_routes"
+	tableBusRouteDepartures = "schedule.bus_route_departures"
+	tableBusRouteStudents   = "schedule.bus_route_students"
+)
+
+// errBusRouteNil is returned when a nil bus route is passed to a repository method.
+var errBusRouteNil = fmt.Errorf("bus route cannot be nil")
+
+// BusRouteRepository implements schedule.BusRouteRepository interface
+type BusRouteRepository struct {
+	*base.Repository[*schedule.BusRoute]
+	db bun.IDB
+}
+
+// NewBusRouteRepository creates a new BusRouteRepository
+func NewBusRouteRepository(db *bun.DB) schedule.BusRouteRepository {
+	return &BusRouteRepository{
+		Repository: base.NewRepository[*schedule.BusRoute](db, tableBusRoutes, "BusRoute"),
+		db:         db,
+	}
+}
+
+// WithTx returns a repository that runs all operations in the provided transaction
+func (r *BusRouteRepository) WithTx(tx bun.Tx) interface{} {
+	return &BusRouteRepository{Repository: r.Repository.WithTx(tx), db: tx}
+}
+
+// Create overrides the base Create method to handle validation
+func (r *BusRouteRepository) Create(ctx context.Context, route *schedule.BusRoute) error {
+	if route == nil {
+		return errBusRouteNil
+	}
+
+	if err := route.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, route)
+}
+
+// Update overrides the base Update method to handle validation
+func (r *BusRouteRepository) Update(ctx context.Context, route *schedule.BusRoute) error {
+	if route == nil {
+		return errBusRouteNil
+	}
+
+	if err := route.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Update(ctx, route)
+}
+
+// List retrieves bus routes matching the provided query options, ordered by name by default
+func (r *BusRouteRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*schedule.BusRoute, error) {
+	var routes []*schedule.BusRoute
+	query := r.db.NewSelect().
+		Model(&routes).
+		ModelTableExpr(`schedule.bus_routes AS "bus_route"`)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	} else {
+		query = query.OrderExpr(`"bus_route".name ASC`)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return routes, nil
+}
+
+// FindDeparturesByRouteIDs returns the departures of the given routes, ordered by weekday
+func (r *BusRouteRepository) FindDeparturesByRouteIDs(ctx context.Context, routeIDs []int64) ([]*schedule.BusRouteDeparture, error) {
+	if len(routeIDs) == 0 {
+		return []*schedule.BusRouteDeparture{}, nil
+	}
+
+	var departures []*schedule.BusRouteDeparture
+	err := r.db.NewSelect().
+		Model(&departures).
+		ModelTableExpr(`schedule.bus_route_departures AS "bus_route_departure"`).
+		Where(`"bus_route_departure".bus_route_id IN (?)`, bun.In(routeIDs)).
+		OrderExpr(`"bus_route_departure".weekday ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find departures by route ids",
+			Err: err,
+		}
+	}
+
+	return departures, nil
+}
+
+// ReplaceDepartures replaces all departures of a route
+func (r *BusRouteRepository) ReplaceDepartures(ctx context.Context, routeID int64, departures []*schedule.BusRouteDeparture) error {
+	for _, d := range departures {
+		d.BusRouteID = routeID
+		if err := d.Validate(); err != nil {
+			return err
+		}
+	}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model((*schedule.BusRouteDeparture)(nil)).
+			ModelTableExpr(tableBusRouteDepartures).
+			Where("bus_route_id = ?", routeID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(departures) == 0 {
+			return nil
+		}
+
+		_, err := tx.NewInsert().
+			Model(&departures).
+			ModelTableExpr(tableBusRouteDepartures).
+			Returning("id").
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "replace departures",
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// FindStudentsByRouteIDs returns the student assignments of the given routes
+func (r *BusRouteRepository) FindStudentsByRouteIDs(ctx context.Context, routeIDs []int64) ([]*schedule.BusRouteStudent, error) {
+	if len(routeIDs) == 0 {
+		return []*schedule.BusRouteStudent{}, nil
+	}
+
+	var assignments []*schedule.BusRouteStudent
+	err := r.db.NewSelect().
+		Model(&assignments).
+		ModelTableExpr(`schedule.bus_route_students AS "bus_route_student"`).
+		Where(`"bus_route_student".bus_route_id IN (?)`, bun.In(routeIDs)).
+		OrderExpr(`"bus_route_student".student_id ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find students by route ids",
+			Err: err,
+		}
+	}
+
+	return assignments, nil
+}
+
+// ReplaceStudents replaces the students of a route; students assigned to another route are moved
+func (r *BusRouteRepository) ReplaceStudents(ctx context.Context, routeID int64, studentIDs []int64) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model((*schedule.BusRouteStudent)(nil)).
+			ModelTableExpr(tableBusRouteStudents).
+			Where("bus_route_id = ?", routeID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(studentIDs) == 0 {
+			return nil
+		}
+
+		// A student rides at most one route
+		if _, err := tx.NewDelete().
+			Model((*schedule.BusRouteStudent)(nil)).
+			ModelTableExpr(tableBusRouteStudents).
+			Where("student_id IN (?)", bun.In(studentIDs)).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		assignments := make([]*schedule.BusRouteStudent, 0, len(studentIDs))
+		for _, studentID := range studentIDs {
+			assignments = append(assignments, &schedule.BusRouteStudent{BusRouteID: routeID, StudentID: studentID})
+		}
+		_, err := tx.NewInsert().
+			Model(&assignments).
+			ModelTableExpr(tableBusRouteStudents).
+			Returning("id").
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "replace students",
+			Err: err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package schedule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	scheduleRepo "github.com/moto-nrw/project-phoenix/database/repositories/schedule"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusRouteRepository_DeparturesAndStudents(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := scheduleRepo.NewBusRouteRepository(db)
+	ctx := context.Background()
+
+	student := testpkg.CreateTestStudent(t, db, "Bus", "Kind", "2b")
+	staff := testpkg.CreateTestStaff(t, db, "Bus", "Planer")
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, staff.ID)
+
+	north := &scheduleModels.BusRoute{Name: "Linie 12 Nord", IsActive: true, CreatedBy: staff.ID}
+	require.NoError(t, repo.Create(ctx, north))
+	defer testpkg.CleanupTableRecords(t, db, "schedule.bus_routes", north.ID)
+
+	south := &scheduleModels.BusRoute{Name: "Linie 14 Süd", IsActive: true, CreatedBy: staff.ID}
+	require.NoError(t, repo.Create(ctx, south))
+	defer testpkg.CleanupTableRecords(t, db, "schedule.bus_routes", south.ID)
+
+	t.Run("replaces departures", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceDepartures(ctx, north.ID, []*scheduleModels.BusRouteDeparture{
+			{Weekday: scheduleModels.WeekdayMonday, DepartureTime: time.Date(2024, 1, 1, 15, 40, 0, 0, time.UTC)},
+			{Weekday: scheduleModels.WeekdayFriday, DepartureTime: time.Date(2024, 1, 1, 13, 10, 0, 0, time.UTC)},
+		}))
+		require.NoError(t, repo.ReplaceDepartures(ctx, north.ID, []*scheduleModels.BusRouteDeparture{
+			{Weekday: scheduleModels.WeekdayTuesday, DepartureTime: time.Date(2024, 1, 1, 15, 45, 0, 0, time.UTC)},
+		}))
+
+		departures, err := repo.FindDeparturesByRouteIDs(ctx, []int64{north.ID})
+		require.NoError(t, err)
+		require.Len(t, departures, 1)
+		assert.Equal(t, scheduleModels.WeekdayTuesday, departures[0].Weekday)
+		assert.Equal(t, 45, departures[0].DepartureTime.Minute())
+	})
+
+	t.Run("moves a student to the new route", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceStudents(ctx, north.ID, []int64{student.ID}))
+		require.NoError(t, repo.ReplaceStudents(ctx, south.ID, []int64{student.ID}))
+
+		assignments, err := repo.FindStudentsByRouteIDs(ctx, []int64{north.ID, south.ID})
+		require.NoError(t, err)
+		require.Len(t, assignments, 1)
+		assert.Equal(t, south.ID, assignments[0].BusRouteID)
+	})
+}
@@ -0,0 +1,144 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/uptrace/bun"
+)
+
+// Table names for calendar feed repositories.
+const (
+	tableCalendarFeeds          = "schedule.calendar_feeds"
+	tableExprCalendarFeedsAsCal = `schedule.calendar_feeds AS "calendar_feed"`
+)
+
+// errCalendarFeedNil is returned when a nil calendar feed is passed to a repository method.
+var errCalendarFeedNil = fmt.Errorf("calendar feed cannot be nil")
+
+// CalendarFeedRepository implements schedule.CalendarFeedRepository interface
+type CalendarFeedRepository struct {
+	*base.Repository[*schedule.CalendarFeed]
+	db *bun.DB
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository
+func NewCalendarFeedRepository(db *bun.DB) schedule.CalendarFeedRepository {
+	return &CalendarFeedRepository{
+		Repository: base.NewRepository[*schedule.CalendarFeed](db, tableCalendarFeeds, "CalendarFeed"),
+		db:         db,
+	}
+}
+
+// Create overrides the base Create method to handle validation
+func (r *CalendarFeedRepository) Create(ctx context.Context, feed *schedule.CalendarFeed) error {
+	if feed == nil {
+		return errCalendarFeedNil
+	}
+
+	if err := feed.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, feed)
+}
+
+// Update overrides the base Update method to handle validation
+func (r *CalendarFeedRepository) Update(ctx context.Context, feed *schedule.CalendarFeed) error {
+	if feed == nil {
+		return errCalendarFeedNil
+	}
+
+	if err := feed.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Update(ctx, feed)
+}
+
+// List retrieves calendar feeds matching the provided query options
+func (r *CalendarFeedRepository) List(ctx context.Context, options *modelBase.QueryOptions) ([]*schedule.CalendarFeed, error) {
+	var feeds []*schedule.CalendarFeed
+	query := r.db.NewSelect().
+		Model(&feeds).
+		ModelTableExpr(tableExprCalendarFeedsAsCal)
+
+	if options != nil {
+		query = options.ApplyToQuery(query)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "list",
+			Err: err,
+		}
+	}
+
+	return feeds, nil
+}
+
+// FindByTokenHash finds a feed by the hash of its token; returns nil if none exists
+func (r *CalendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*schedule.CalendarFeed, error) {
+	feed := new(schedule.CalendarFeed)
+	err := r.db.NewSelect().
+		Model(feed).
+		ModelTableExpr(tableExprCalendarFeedsAsCal).
+		Where(`"calendar_feed".token_hash = ?`, tokenHash).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by token hash",
+			Err: err,
+		}
+	}
+
+	return feed, nil
+}
+
+// FindByStaffID returns the feeds owned by a staff member, newest first
+func (r *CalendarFeedRepository) FindByStaffID(ctx context.Context, staffID int64) ([]*schedule.CalendarFeed, error) {
+	var feeds []*schedule.CalendarFeed
+	err := r.db.NewSelect().
+		Model(&feeds).
+		ModelTableExpr(tableExprCalendarFeedsAsCal).
+		Where(`"calendar_feed".staff_id = ?`, staffID).
+		OrderExpr(`"calendar_feed".created_at DESC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by staff ID",
+			Err: err,
+		}
+	}
+
+	return feeds, nil
+}
+
+// TouchLastAccessed records when a calendar app last fetched the feed
+func (r *CalendarFeedRepository) TouchLastAccessed(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.NewUpdate().
+		Table(tableCalendarFeeds).
+		Set("last_accessed_at = ?", at).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	if err != nil {
+		return &modelBase.DatabaseError{
+			Op:  "touch last accessed",
+			Err: err,
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package schedule
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -97,6 +99,54 @@ func (r *DateframeRepository) FindOverlapping(ctx context.Context, startDate, en
 	return dateframes, nil
 }
 
+// FindClosures finds all holiday and closure dateframes overlapping the given date range, ordered by start date.
+// Closures are calendar dates stored as midnight UTC, so the range is compared by calendar date.
+func (r *DateframeRepository) FindClosures(ctx context.Context, startDate, endDate time.Time) ([]*schedule.Dateframe, error) {
+	var dateframes []*schedule.Dateframe
+
+	normalizedStartDate := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	normalizedEndDate := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	err := r.db.NewSelect().
+		Model(&dateframes).
+		ModelTableExpr(tableExprDateframesAsDF).
+		Where("kind IN (?)", bun.In([]string{schedule.DateframeKindHoliday, schedule.DateframeKindClosure})).
+		Where("start_date <= ? AND end_date >= ?", normalizedEndDate, normalizedStartDate).
+		OrderExpr("start_date ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find closures",
+			Err: err,
+		}
+	}
+
+	return dateframes, nil
+}
+
+// FindByExternalUID finds an imported dateframe by its iCalendar UID; returns nil if none exists
+func (r *DateframeRepository) FindByExternalUID(ctx context.Context, uid string) (*schedule.Dateframe, error) {
+	dateframe := new(schedule.Dateframe)
+	err := r.db.NewSelect().
+		Model(dateframe).
+		ModelTableExpr(tableExprDateframesAsDF).
+		Where("external_uid = ?", uid).
+		Scan(ctx)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by external uid",
+			Err: err,
+		}
+	}
+
+	return dateframe, nil
+}
+
 // Create overrides the base Create method to handle validation
 func (r *DateframeRepository) Create(ctx context.Context, dateframe *schedule.Dateframe) error {
 	if dateframe == nil {
@@ -4,6 +4,7 @@ package users
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/moto-nrw/project-phoenix/database/repositories/base"
 	modelBase "github.com/moto-nrw/project-phoenix/models/base"
@@ -466,6 +467,30 @@ func (r *StudentRepository) FindAllWithGroups(ctx context.Context) ([]*users.Stu
 	return mapStudentGroupResults(results), nil
 }
 
+// FindByIDsWithGroups retrieves the given students with their group names.
+// Uses LEFT JOIN on groups so students without a group assignment are included.
+func (r *StudentRepository) FindByIDsWithGroups(ctx context.Context, ids []int64) ([]*users.StudentWithGroupInfo, error) {
+	if len(ids) == 0 {
+		return []*users.StudentWithGroupInfo{}, nil
+	}
+
+	var results []*studentWithPersonAndGroup
+	err := r.newStudentWithGroupQuery(&results).
+		ColumnExpr(`COALESCE("group".name, '') AS "group_name"`).
+		Join(`LEFT JOIN education.groups AS "group" ON "group".id = "student".group_id`).
+		Where(`"student".id IN (?)`, bun.In(ids)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by IDs with groups",
+			Err: err,
+		}
+	}
+
+	return mapStudentGroupResults(results), nil
+}
+
 // FindByNameAndClass retrieves students by first name, last name, and school class (for import duplicate detection)
 func (r *StudentRepository) FindByNameAndClass(ctx context.Context, firstName, lastName, schoolClass string) ([]*users.Student, error) {
 	var students []*users.Student
@@ -487,3 +512,25 @@ func (r *StudentRepository) FindByNameAndClass(ctx context.Context, firstName, l
 
 	return students, nil
 }
+
+// FindByNameAndBirthday retrieves students by first name, last name, and birthday (case-insensitive names)
+func (r *StudentRepository) FindByNameAndBirthday(ctx context.Context, firstName, lastName string, birthday time.Time) ([]*users.Student, error) {
+	var students []*users.Student
+	err := r.db.NewSelect().
+		Model(&students).
+		ModelTableExpr(`users.students AS "student"`).
+		Join(`INNER JOIN users.persons AS "person" ON "person".id = "student".person_id`).
+		Where(`LOWER("person".first_name) = LOWER(?)`, firstName).
+		Where(`LOWER("person".last_name) = LOWER(?)`, lastName).
+		Where(`"person".birthday = ?`, birthday.Format("2006-01-02")).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find by name and birthday",
+			Err: err,
+		}
+	}
+
+	return students, nil
+}
@@ -0,0 +1,72 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories/base"
+	modelBase "github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/uptrace/bun"
+)
+
+// StudentPickupPersonRepository implements users.StudentPickupPersonRepository interface
+type StudentPickupPersonRepository struct {
+	*base.Repository[*users.StudentPickupPerson]
+	db *bun.DB
+}
+
+// NewStudentPickupPersonRepository creates a new StudentPickupPersonRepository
+func NewStudentPickupPersonRepository(db *bun.DB) users.StudentPickupPersonRepository {
+	return &StudentPickupPersonRepository{
+		Repository: base.NewRepository[*users.StudentPickupPerson](db, "users.student_pickup_persons", "StudentPickupPerson"),
+		db:         db,
+	}
+}
+
+// Create overrides base Create to handle validation
+func (r *StudentPickupPersonRepository) Create(ctx context.Context, person *users.StudentPickupPerson) error {
+	if person == nil {
+		return fmt.Errorf("pickup person cannot be nil")
+	}
+
+	if err := person.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Create(ctx, person)
+}
+
+// Update overrides base Update to handle validation
+func (r *StudentPickupPersonRepository) Update(ctx context.Context, person *users.StudentPickupPerson) error {
+	if person == nil {
+		return fmt.Errorf("pickup person cannot be nil")
+	}
+
+	if err := person.Validate(); err != nil {
+		return err
+	}
+
+	return r.Repository.Update(ctx, person)
+}
+
+// FindByStudentID retrieves all pickup persons of a student ordered by name
+func (r *StudentPickupPersonRepository) FindByStudentID(ctx context.Context, studentID int64) ([]*users.StudentPickupPerson, error) {
+	var persons []*users.StudentPickupPerson
+	err := r.db.NewSelect().
+		Model(&persons).
+		ModelTableExpr(`users.student_pickup_persons AS "student_pickup_person"`).
+		Where(`"student_pickup_person".student_id = ?`, studentID).
+		OrderExpr(`"student_pickup_person".last_name ASC`).
+		OrderExpr(`"student_pickup_person".first_name ASC`).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, &modelBase.DatabaseError{
+			Op:  "find pickup persons by student ID",
+			Err: err,
+		}
+	}
+
+	return persons, nil
+}
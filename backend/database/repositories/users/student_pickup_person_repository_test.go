@@ -0,0 +1,59 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/database/repositories"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentPickupPersonRepository_CreateAndFindByStudent(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	repo := repositories.NewFactory(db).StudentPickupPerson
+	ctx := context.Background()
+
+	student := testpkg.CreateTestStudent(t, db, "Pickup", "Student", "3a")
+	staff := testpkg.CreateTestStaff(t, db, "Pickup", "Creator")
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, staff.ID)
+
+	until := time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+	grandma := &users.StudentPickupPerson{
+		StudentID:    student.ID,
+		FirstName:    "Erika",
+		LastName:     "Zimmer",
+		Relationship: "Oma",
+		IsAuthorized: true,
+		ValidUntil:   &until,
+		CreatedBy:    staff.ID,
+	}
+	require.NoError(t, repo.Create(ctx, grandma))
+	defer testpkg.CleanupTableRecords(t, db, "users.student_pickup_persons", grandma.ID)
+
+	banned := &users.StudentPickupPerson{
+		StudentID:    student.ID,
+		FirstName:    "Klaus",
+		LastName:     "Adler",
+		IsAuthorized: false,
+		CreatedBy:    staff.ID,
+	}
+	require.NoError(t, repo.Create(ctx, banned))
+	defer testpkg.CleanupTableRecords(t, db, "users.student_pickup_persons", banned.ID)
+
+	persons, err := repo.FindByStudentID(ctx, student.ID)
+	require.NoError(t, err)
+	require.Len(t, persons, 2)
+
+	// Ordered by last name
+	assert.Equal(t, banned.ID, persons[0].ID)
+	assert.False(t, persons[0].IsAuthorized)
+	assert.Equal(t, grandma.ID, persons[1].ID)
+	require.NotNil(t, persons[1].ValidUntil)
+	assert.Equal(t, "2026-07-31", persons[1].ValidUntil.Format("2006-01-02"))
+}
@@ -829,6 +829,35 @@ func TestStudentRepository_FindByNameAndClass(t *testing.T) {
 	})
 }
 
+func TestStudentRepository_FindByNameAndBirthday(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	factory := repositories.NewFactory(db)
+	repo := factory.Student
+	ctx := context.Background()
+
+	t.Run("finds student after class change", func(t *testing.T) {
+		student := testpkg.CreateTestStudent(t, db, "Lena", "Birthday", "1A")
+		defer cleanupStudentRecords(t, db, student.ID)
+
+		birthday := time.Date(2016, 3, 14, 0, 0, 0, 0, time.UTC)
+		person, err := factory.Person.FindByID(ctx, student.PersonID)
+		require.NoError(t, err)
+		person.Birthday = &birthday
+		require.NoError(t, factory.Person.Update(ctx, person))
+
+		students, err := repo.FindByNameAndBirthday(ctx, "LENA", "birthday", birthday)
+		require.NoError(t, err)
+		require.Len(t, students, 1)
+		assert.Equal(t, student.ID, students[0].ID)
+
+		students, err = repo.FindByNameAndBirthday(ctx, "Lena", "Birthday", birthday.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		assert.Empty(t, students)
+	})
+}
+
 // NOTE: FindWithPerson, FindByGuardianEmail, and FindByGuardianPhone exist in the
 // implementation but are not exposed in the StudentRepository interface, so they
 // cannot be tested through the interface.
@@ -3,6 +3,7 @@ package email
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
 )
 
@@ -28,6 +29,9 @@ type DeliveryMetadata struct {
 	Token string
 	// Recipient holds the destination email address for logging.
 	Recipient string
+	// BaseRetry is the number of attempts already recorded for ReferenceID before this delivery;
+	// result handlers add DeliveryResult.Attempt to it.
+	BaseRetry int
 }
 
 // DeliveryResult captures the outcome of an email attempt.
@@ -42,6 +46,7 @@ type DeliveryResult struct {
 }
 
 // DeliveryCallback receives delivery results from the dispatcher.
+// Requests without a callback fall back to the handler registered for their type.
 type DeliveryCallback func(ctx context.Context, result DeliveryResult)
 
 // DeliveryRequest defines a new email to be dispatched.
@@ -54,11 +59,16 @@ type DeliveryRequest struct {
 }
 
 // Dispatcher manages asynchronous email delivery with retry behaviour.
+// Without an outbox, retries are kept in memory; see UseOutbox for durable delivery.
 type Dispatcher struct {
 	mailer         Mailer
 	logger         *slog.Logger
 	defaultRetry   int
 	defaultBackoff []time.Duration
+
+	mu       sync.RWMutex
+	outbox   OutboxStore
+	handlers map[string]DeliveryCallback
 }
 
 // getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
@@ -101,6 +111,11 @@ func (d *Dispatcher) SetDefaults(maxAttempts int, backoff []time.Duration) {
 // Dispatch sends an email asynchronously; results are communicated via callback.
 // The message is copied before async delivery to avoid races if caller mutates the request.
 // Pass context.Background() if no specific context is needed for callbacks.
+//
+// When an outbox is configured the message is persisted instead and delivered by
+// ProcessOutbox. Per-request callbacks cannot be persisted, so outbox results are
+// reported to the handler registered for the delivery type. If persisting fails,
+// delivery falls back to the in-memory retry loop so the message is not lost.
 func (d *Dispatcher) Dispatch(ctx context.Context, req DeliveryRequest) {
 	if d.mailer == nil {
 		return
@@ -111,6 +126,19 @@ func (d *Dispatcher) Dispatch(ctx context.Context, req DeliveryRequest) {
 	messageCopy := req.Message
 
 	cfg := d.resolveConfigWithMessage(req, messageCopy)
+
+	if store := d.outboxStore(); store != nil {
+		err := d.enqueue(ctx, store, cfg)
+		if err == nil {
+			return
+		}
+		d.getLogger().Error("failed to enqueue email in outbox, delivering in memory",
+			"type", cfg.metadata.Type,
+			"reference_id", cfg.metadata.ReferenceID,
+			"error", err,
+		)
+	}
+
 	go d.deliverWithRetry(ctx, cfg)
 }
 
@@ -175,12 +203,16 @@ func (d *Dispatcher) tryDelivery(ctx context.Context, cfg dispatchConfig, attemp
 	return false
 }
 
-// invokeCallback safely calls the callback if present
+// invokeCallback safely calls the request callback, or the handler registered for its type
 func (d *Dispatcher) invokeCallback(ctx context.Context, cfg dispatchConfig, attempt int, status DeliveryStatus, err error, final bool) {
-	if cfg.callback == nil {
+	callback := cfg.callback
+	if callback == nil {
+		callback = d.handlerFor(cfg.metadata.Type)
+	}
+	if callback == nil {
 		return
 	}
-	cfg.callback(ctx, DeliveryResult{
+	callback(ctx, DeliveryResult{
 		Metadata:   cfg.metadata,
 		Attempt:    attempt,
 		MaxAttempt: cfg.maxAttempts,
@@ -0,0 +1,269 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxErrorTextLength caps stored delivery errors so SMTP responses cannot bloat the database.
+const maxErrorTextLength = 1024
+
+// defaultOutboxBatchSize limits how many due messages a single ProcessOutbox run claims.
+const defaultOutboxBatchSize = 25
+
+// defaultOutboxLease is how long a claimed message stays invisible to other workers.
+// If a worker crashes mid-send the lease expires and the message becomes due again.
+const defaultOutboxLease = 5 * time.Minute
+
+// outboxRetention is how long sent and failed messages are kept for diagnostics.
+const outboxRetention = 30 * 24 * time.Hour
+
+// OutboxEntry is a queued email as persisted by an OutboxStore.
+type OutboxEntry struct {
+	ID          int64
+	Message     Message
+	Metadata    DeliveryMetadata
+	Attempts    int
+	MaxAttempts int
+	Backoff     []time.Duration
+}
+
+// OutboxStore persists outbound emails so pending deliveries survive restarts.
+type OutboxStore interface {
+	// Enqueue stores a new message that becomes due immediately.
+	Enqueue(ctx context.Context, entry *OutboxEntry) error
+	// ClaimDue locks up to limit due messages for the given lease and increments their attempt counter.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*OutboxEntry, error)
+	// MarkSent records a successful delivery.
+	MarkSent(ctx context.Context, id int64, sentAt time.Time) error
+	// MarkFailed records a failed attempt; a nil retryAt marks the message as permanently failed.
+	MarkFailed(ctx context.Context, id int64, errText string, retryAt *time.Time) error
+	// DeleteFinishedBefore removes sent and permanently failed messages last updated before cutoff.
+	DeleteFinishedBefore(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// outboxPayload is the serialized form of a Message stored in the outbox.
+type outboxPayload struct {
+	From     Email           `json:"from"`
+	To       Email           `json:"to"`
+	Subject  string          `json:"subject"`
+	Template string          `json:"template"`
+	Content  json.RawMessage `json:"content,omitempty"`
+}
+
+// EncodeMessage serializes a message for persistence in the outbox.
+func EncodeMessage(m Message) ([]byte, error) {
+	content, err := json.Marshal(m.Content)
+	if err != nil {
+		return nil, fmt.Errorf("encode email content: %w", err)
+	}
+	return json.Marshal(outboxPayload{
+		From:     m.From,
+		To:       m.To,
+		Subject:  m.Subject,
+		Template: m.Template,
+		Content:  content,
+	})
+}
+
+// DecodeMessage restores a message persisted with EncodeMessage.
+// Template content is decoded into a map so templates keep resolving fields by name.
+func DecodeMessage(data []byte) (Message, error) {
+	var payload outboxPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return Message{}, fmt.Errorf("decode email payload: %w", err)
+	}
+
+	var content map[string]any
+	if len(payload.Content) > 0 && string(payload.Content) != "null" {
+		if err := json.Unmarshal(payload.Content, &content); err != nil {
+			return Message{}, fmt.Errorf("decode email content: %w", err)
+		}
+	}
+
+	return Message{
+		From:     payload.From,
+		To:       payload.To,
+		Subject:  payload.Subject,
+		Template: payload.Template,
+		Content:  content,
+	}, nil
+}
+
+// ErrorText converts a delivery error into a trimmed string suitable for storage.
+func ErrorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := strings.TrimSpace(err.Error())
+	if len(msg) <= maxErrorTextLength {
+		return msg
+	}
+	// Cut on a rune boundary so the stored text stays valid UTF-8
+	cut := maxErrorTextLength
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut]
+}
+
+// UseOutbox switches the dispatcher to durable delivery through the given store.
+// Messages are then only sent by ProcessOutbox, and results are reported to the
+// handlers registered for their DeliveryMetadata.Type.
+func (d *Dispatcher) UseOutbox(store OutboxStore) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.outbox = store
+}
+
+// RegisterHandler registers the result handler for a delivery type.
+// Handlers replace per-request callbacks for durable delivery because closures
+// cannot be persisted across restarts.
+func (d *Dispatcher) RegisterHandler(deliveryType string, handler DeliveryCallback) {
+	if d == nil || handler == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.handlers == nil {
+		d.handlers = make(map[string]DeliveryCallback)
+	}
+	d.handlers[deliveryType] = handler
+}
+
+// handlerFor returns the registered handler for the given delivery type, if any.
+func (d *Dispatcher) handlerFor(deliveryType string) DeliveryCallback {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.handlers[deliveryType]
+}
+
+// outboxStore returns the configured outbox store, if any.
+func (d *Dispatcher) outboxStore() OutboxStore {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.outbox
+}
+
+// enqueue persists the request in the outbox.
+func (d *Dispatcher) enqueue(ctx context.Context, store OutboxStore, cfg dispatchConfig) error {
+	return store.Enqueue(ctx, &OutboxEntry{
+		Message:     cfg.message,
+		Metadata:    cfg.metadata,
+		MaxAttempts: cfg.maxAttempts,
+		Backoff:     cfg.backoff,
+	})
+}
+
+// ProcessOutbox claims due messages from the outbox and attempts to send them.
+// It returns the number of messages that were delivered successfully.
+func (d *Dispatcher) ProcessOutbox(ctx context.Context) (int, error) {
+	store := d.outboxStore()
+	if store == nil {
+		return 0, errors.New("email outbox not configured")
+	}
+	if d.mailer == nil {
+		return 0, nil
+	}
+
+	entries, err := store.ClaimDue(ctx, defaultOutboxBatchSize, defaultOutboxLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim due emails: %w", err)
+	}
+
+	sent := 0
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		if d.deliverOutboxEntry(ctx, store, entry) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// CleanupOutbox removes finished outbox messages older than the retention period.
+func (d *Dispatcher) CleanupOutbox(ctx context.Context) (int, error) {
+	store := d.outboxStore()
+	if store == nil {
+		return 0, nil
+	}
+	return store.DeleteFinishedBefore(ctx, time.Now().Add(-outboxRetention))
+}
+
+// deliverOutboxEntry performs one attempt for a claimed entry and records the outcome.
+func (d *Dispatcher) deliverOutboxEntry(ctx context.Context, store OutboxStore, entry *OutboxEntry) bool {
+	cfg := dispatchConfig{
+		message:     entry.Message,
+		metadata:    entry.Metadata,
+		maxAttempts: entry.MaxAttempts,
+		backoff:     entry.Backoff,
+	}
+	if cfg.maxAttempts <= 0 {
+		cfg.maxAttempts = d.defaultRetry
+	}
+	if len(cfg.backoff) == 0 {
+		cfg.backoff = d.defaultBackoff
+	}
+
+	// A worker may have crashed after claiming the final attempt; give up instead of looping.
+	if entry.Attempts > cfg.maxAttempts {
+		err := errors.New("maximum delivery attempts exceeded")
+		d.recordOutboxFailure(ctx, store, entry.ID, err, nil)
+		d.invokeCallback(ctx, cfg, cfg.maxAttempts, DeliveryStatusFailed, err, true)
+		return false
+	}
+
+	sendErr := d.mailer.Send(cfg.message)
+	if sendErr == nil {
+		now := time.Now()
+		if err := store.MarkSent(ctx, entry.ID, now); err != nil {
+			d.getLogger().Error("failed to mark outbox email as sent",
+				"outbox_id", entry.ID,
+				"type", cfg.metadata.Type,
+				"error", err,
+			)
+		}
+		d.invokeCallback(ctx, cfg, entry.Attempts, DeliveryStatusSent, nil, true)
+		return true
+	}
+
+	final := entry.Attempts >= cfg.maxAttempts
+	d.getLogger().Warn("email send attempt failed",
+		"type", cfg.metadata.Type,
+		"reference_id", cfg.metadata.ReferenceID,
+		"recipient", cfg.metadata.Recipient,
+		"outbox_id", entry.ID,
+		"attempt", entry.Attempts,
+		"max_attempts", cfg.maxAttempts,
+		"error", sendErr,
+	)
+
+	var retryAt *time.Time
+	if !final {
+		next := time.Now().Add(backoffDuration(cfg.backoff, entry.Attempts))
+		retryAt = &next
+	}
+	d.recordOutboxFailure(ctx, store, entry.ID, sendErr, retryAt)
+	d.invokeCallback(ctx, cfg, entry.Attempts, DeliveryStatusFailed, sendErr, final)
+	return false
+}
+
+// recordOutboxFailure stores a failed attempt, logging if the store update itself fails.
+func (d *Dispatcher) recordOutboxFailure(ctx context.Context, store OutboxStore, id int64, sendErr error, retryAt *time.Time) {
+	if err := store.MarkFailed(ctx, id, ErrorText(sendErr), retryAt); err != nil {
+		d.getLogger().Error("failed to record outbox email failure",
+			"outbox_id", id,
+			"error", err,
+		)
+	}
+}
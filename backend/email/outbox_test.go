@@ -0,0 +1,343 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dispatchedRef is the reference ID attached to test deliveries.
+const dispatchedRef int64 = 42
+
+// =============================================================================
+// Fake Outbox Store
+// =============================================================================
+
+type failedMark struct {
+	errText string
+	retryAt *time.Time
+}
+
+type fakeOutboxStore struct {
+	mu         sync.Mutex
+	nextID     int64
+	entries    map[int64]*OutboxEntry
+	sent       map[int64]time.Time
+	failed     map[int64]failedMark
+	enqueueErr error
+	claimErr   error
+	cutoff     time.Time
+}
+
+func newFakeOutboxStore() *fakeOutboxStore {
+	return &fakeOutboxStore{
+		entries: make(map[int64]*OutboxEntry),
+		sent:    make(map[int64]time.Time),
+		failed:  make(map[int64]failedMark),
+	}
+}
+
+func (s *fakeOutboxStore) Enqueue(_ context.Context, entry *OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enqueueErr != nil {
+		return s.enqueueErr
+	}
+	s.nextID++
+	entry.ID = s.nextID
+	copied := *entry
+	s.entries[entry.ID] = &copied
+	return nil
+}
+
+func (s *fakeOutboxStore) ClaimDue(_ context.Context, limit int, _ time.Duration) ([]*OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	var claimed []*OutboxEntry
+	for id := int64(1); id <= s.nextID && len(claimed) < limit; id++ {
+		entry, ok := s.entries[id]
+		if !ok {
+			continue
+		}
+		entry.Attempts++
+		copied := *entry
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (s *fakeOutboxStore) MarkSent(_ context.Context, id int64, sentAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[id] = sentAt
+	delete(s.entries, id)
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkFailed(_ context.Context, id int64, errText string, retryAt *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed[id] = failedMark{errText: errText, retryAt: retryAt}
+	if retryAt == nil {
+		delete(s.entries, id)
+	}
+	return nil
+}
+
+func (s *fakeOutboxStore) DeleteFinishedBefore(_ context.Context, cutoff time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cutoff = cutoff
+	return len(s.sent) + len(s.failed), nil
+}
+
+// =============================================================================
+// Dispatch With Outbox Tests
+// =============================================================================
+
+func TestDispatcher_Dispatch_WithOutbox_Enqueues(t *testing.T) {
+	mailer := newMockMailer()
+	dispatcher := NewDispatcher(mailer, slog.Default())
+	store := newFakeOutboxStore()
+	dispatcher.UseOutbox(store)
+
+	dispatcher.Dispatch(context.Background(), DeliveryRequest{
+		Message:  Message{To: NewEmail("", "user@example.com"), Subject: "Hello", Template: "invitation.html"},
+		Metadata: DeliveryMetadata{Type: "invitation", ReferenceID: dispatchedRef, Recipient: "user@example.com", BaseRetry: 20},
+	})
+
+	// Nothing is sent until the outbox is processed
+	assert.Empty(t, mailer.getSentMessages())
+	require.Len(t, store.entries, 1)
+	entry := store.entries[1]
+	assert.Equal(t, "invitation", entry.Metadata.Type)
+	assert.Equal(t, dispatchedRef, entry.Metadata.ReferenceID)
+	assert.Equal(t, 20, entry.Metadata.BaseRetry)
+	assert.Equal(t, 3, entry.MaxAttempts)
+	assert.Equal(t, dispatcher.defaultBackoff, entry.Backoff)
+}
+
+func TestDispatcher_Dispatch_WithOutbox_FallsBackOnEnqueueError(t *testing.T) {
+	mailer := newMockMailer()
+	dispatcher := NewDispatcher(mailer, slog.Default())
+	store := newFakeOutboxStore()
+	store.enqueueErr = errors.New("database unavailable")
+	dispatcher.UseOutbox(store)
+
+	tracker := newCallbackTracker()
+	dispatcher.Dispatch(context.Background(), DeliveryRequest{
+		Message:  Message{To: NewEmail("", "user@example.com"), Subject: "Hello"},
+		Metadata: DeliveryMetadata{Type: "invitation"},
+		Callback: tracker.callback,
+	})
+
+	require.True(t, tracker.waitForResults(1, time.Second))
+	assert.Equal(t, DeliveryStatusSent, tracker.getResults()[0].Status)
+	assert.Len(t, mailer.getSentMessages(), 1)
+}
+
+// =============================================================================
+// ProcessOutbox Tests
+// =============================================================================
+
+func TestDispatcher_ProcessOutbox_NotConfigured(t *testing.T) {
+	dispatcher := NewDispatcher(newMockMailer(), slog.Default())
+
+	_, err := dispatcher.ProcessOutbox(context.Background())
+	assert.Error(t, err)
+}
+
+func TestDispatcher_ProcessOutbox_SendsAndNotifiesHandler(t *testing.T) {
+	mailer := newMockMailer()
+	dispatcher := NewDispatcher(mailer, slog.Default())
+	store := newFakeOutboxStore()
+	dispatcher.UseOutbox(store)
+
+	tracker := newCallbackTracker()
+	dispatcher.RegisterHandler("password_reset", tracker.callback)
+
+	dispatcher.Dispatch(context.Background(), DeliveryRequest{
+		Message:  Message{To: NewEmail("", "user@example.com"), Subject: "Reset"},
+		Metadata: DeliveryMetadata{Type: "password_reset", ReferenceID: dispatchedRef},
+	})
+
+	sent, err := dispatcher.ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, mailer.getSentMessages(), 1)
+	assert.Len(t, store.sent, 1)
+
+	results := tracker.getResults()
+	require.Len(t, results, 1)
+	assert.Equal(t, DeliveryStatusSent, results[0].Status)
+	assert.Equal(t, dispatchedRef, results[0].Metadata.ReferenceID)
+	assert.Equal(t, 1, results[0].Attempt)
+	assert.True(t, results[0].Final)
+}
+
+func TestDispatcher_ProcessOutbox_SchedulesRetry(t *testing.T) {
+	mailer := newMockMailer()
+	mailer.sendError = errors.New("smtp unavailable")
+	mailer.alwaysFail = true
+	dispatcher := NewDispatcher(mailer, slog.Default())
+	store := newFakeOutboxStore()
+	dispatcher.UseOutbox(store)
+
+	tracker := newCallbackTracker()
+	dispatcher.RegisterHandler("invitation", tracker.callback)
+
+	dispatcher.Dispatch(context.Background(), DeliveryRequest{
+		Message:       Message{To: NewEmail("", "user@example.com")},
+		Metadata:      DeliveryMetadata{Type: "invitation"},
+		MaxAttempts:   2,
+		BackoffPolicy: []time.Duration{time.Hour},
+	})
+
+	before := time.Now()
+	sent, err := dispatcher.ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	mark := store.failed[1]
+	require.NotNil(t, mark.retryAt)
+	assert.WithinDuration(t, before.Add(time.Hour), *mark.retryAt, time.Minute)
+	assert.Equal(t, "smtp unavailable", mark.errText)
+
+	results := tracker.getResults()
+	require.Len(t, results, 1)
+	assert.Equal(t, DeliveryStatusFailed, results[0].Status)
+	assert.False(t, results[0].Final)
+}
+
+func TestDispatcher_ProcessOutbox_FinalFailure(t *testing.T) {
+	mailer := newMockMailer()
+	mailer.sendError = errors.New("mailbox unavailable")
+	mailer.alwaysFail = true
+	dispatcher := NewDispatcher(mailer, slog.Default())
+	store := newFakeOutboxStore()
+	dispatcher.UseOutbox(store)
+
+	tracker := newCallbackTracker()
+	dispatcher.RegisterHandler("invitation", tracker.callback)
+
+	dispatcher.Dispatch(context.Background(), DeliveryRequest{
+		Message:       Message{To: NewEmail("", "user@example.com")},
+		Metadata:      DeliveryMetadata{Type: "invitation"},
+		MaxAttempts:   2,
+		BackoffPolicy: []time.Duration{time.Millisecond},
+	})
+
+	for range 2 {
+		_, err := dispatcher.ProcessOutbox(context.Background())
+		require.NoError(t, err)
+	}
+
+	mark := store.failed[1]
+	assert.Nil(t, mark.retryAt, "final attempt should fail permanently")
+	assert.Empty(t, store.entries)
+
+	results := tracker.getResults()
+	require.Len(t, results, 2)
+	assert.Equal(t, 2, results[1].Attempt)
+	assert.True(t, results[1].Final)
+}
+
+func TestDispatcher_ProcessOutbox_AttemptsExceeded(t *testing.T) {
+	mailer := newMockMailer()
+	dispatcher := NewDispatcher(mailer, slog.Default())
+	store := newFakeOutboxStore()
+	dispatcher.UseOutbox(store)
+
+	// Simulates a worker that crashed after claiming the last attempt
+	store.nextID = 1
+	store.entries[1] = &OutboxEntry{ID: 1, Metadata: DeliveryMetadata{Type: "invitation"}, Attempts: 3, MaxAttempts: 3}
+
+	sent, err := dispatcher.ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, mailer.getSentMessages())
+	assert.Nil(t, store.failed[1].retryAt)
+}
+
+func TestDispatcher_ProcessOutbox_ClaimError(t *testing.T) {
+	dispatcher := NewDispatcher(newMockMailer(), slog.Default())
+	store := newFakeOutboxStore()
+	store.claimErr = errors.New("connection refused")
+	dispatcher.UseOutbox(store)
+
+	_, err := dispatcher.ProcessOutbox(context.Background())
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestDispatcher_CleanupOutbox(t *testing.T) {
+	dispatcher := NewDispatcher(newMockMailer(), slog.Default())
+
+	count, err := dispatcher.CleanupOutbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	store := newFakeOutboxStore()
+	dispatcher.UseOutbox(store)
+	_, err = dispatcher.CleanupOutbox(context.Background())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-outboxRetention), store.cutoff, time.Minute)
+}
+
+// =============================================================================
+// Serialization Tests
+// =============================================================================
+
+func TestEncodeDecodeMessage_RoundTrip(t *testing.T) {
+	original := Message{
+		From:     NewEmail("moto", "noreply@example.com"),
+		To:       NewEmail("Jane", "jane@example.com"),
+		Subject:  "Invitation",
+		Template: "invitation.html",
+		Content: map[string]any{
+			"InvitationURL": "https://example.com/invite/abc",
+			"ExpiryHours":   48,
+		},
+	}
+
+	data, err := EncodeMessage(original)
+	require.NoError(t, err)
+
+	decoded, err := DecodeMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, original.From, decoded.From)
+	assert.Equal(t, original.To, decoded.To)
+	assert.Equal(t, original.Subject, decoded.Subject)
+	assert.Equal(t, original.Template, decoded.Template)
+
+	content, ok := decoded.Content.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "https://example.com/invite/abc", content["InvitationURL"])
+	assert.InDelta(t, 48, content["ExpiryHours"], 0)
+}
+
+func TestDecodeMessage_InvalidPayload(t *testing.T) {
+	_, err := DecodeMessage([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestErrorText(t *testing.T) {
+	assert.Equal(t, "", ErrorText(nil))
+	assert.Equal(t, "boom", ErrorText(errors.New("  boom \n")))
+	assert.Len(t, ErrorText(errors.New(strings.Repeat("x", 5000))), maxErrorTextLength)
+
+	// A multi-byte rune straddling the limit is dropped, not split
+	umlauts := ErrorText(errors.New("x" + strings.Repeat("ä", maxErrorTextLength)))
+	assert.True(t, utf8.ValidString(umlauts))
+	assert.Len(t, umlauts, maxErrorTextLength-1)
+}
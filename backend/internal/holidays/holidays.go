@@ -0,0 +1,94 @@
+// Package holidays provides the statutory public holidays of North Rhine-Westphalia.
+// The app is only used in NRW, so no other federal states are supported.
+package holidays
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday is a public holiday; Date is midnight UTC to match DATE columns.
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
+// NRW returns the statutory public holidays of North Rhine-Westphalia for a year, sorted by date.
+func NRW(year int) []Holiday {
+	easter := easterSunday(year)
+	fixed := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	result := []Holiday{
+		{Date: fixed(time.January, 1), Name: "Neujahr"},
+		{Date: easter.AddDate(0, 0, -2), Name: "Karfreitag"},
+		{Date: easter.AddDate(0, 0, 1), Name: "Ostermontag"},
+		{Date: fixed(time.May, 1), Name: "Tag der Arbeit"},
+		{Date: easter.AddDate(0, 0, 39), Name: "Christi Himmelfahrt"},
+		{Date: easter.AddDate(0, 0, 50), Name: "Pfingstmontag"},
+		{Date: easter.AddDate(0, 0, 60), Name: "Fronleichnam"},
+		{Date: fixed(time.October, 3), Name: "Tag der Deutschen Einheit"},
+		{Date: fixed(time.November, 1), Name: "Allerheiligen"},
+		{Date: fixed(time.December, 25), Name: "1. Weihnachtstag"},
+		{Date: fixed(time.December, 26), Name: "2. Weihnachtstag"},
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result
+}
+
+// InRange returns the NRW public holidays between from and to (inclusive), keyed by date.
+// The keys are midnight UTC dates.
+func InRange(from, to time.Time) map[time.Time]string {
+	from = dateOnly(from)
+	to = dateOnly(to)
+
+	result := make(map[time.Time]string)
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, h := range NRW(year) {
+			if h.Date.Before(from) || h.Date.After(to) {
+				continue
+			}
+			result[h.Date] = h.Name
+		}
+	}
+	return result
+}
+
+// IsNRWHoliday reports whether the given date is a public holiday in NRW and returns its name.
+func IsNRWHoliday(date time.Time) (string, bool) {
+	d := dateOnly(date)
+	for _, h := range NRW(d.Year()) {
+		if h.Date.Equal(d) {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// dateOnly strips the time of day, keeping the calendar date as midnight UTC
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// easterSunday computes Easter Sunday in the Gregorian calendar (anonymous Gregorian algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := ((h + l - 7*m + 114) % 31) + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,61 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasterSunday(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{2024, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{2025, time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)},
+		{2026, time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{2027, time.Date(2027, 3, 28, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, easterSunday(tt.year), "year %d", tt.year)
+	}
+}
+
+func TestNRW(t *testing.T) {
+	result := NRW(2026)
+	require.Len(t, result, 11)
+
+	assert.Equal(t, "Neujahr", result[0].Name)
+	assert.Equal(t, "2. Weihnachtstag", result[len(result)-1].Name)
+
+	for i := 1; i < len(result); i++ {
+		assert.True(t, result[i-1].Date.Before(result[i].Date), "holidays must be sorted")
+	}
+}
+
+func TestIsNRWHoliday(t *testing.T) {
+	name, ok := IsNRWHoliday(time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "Fronleichnam", name)
+
+	// Time of day is ignored
+	_, ok = IsNRWHoliday(time.Date(2026, 10, 3, 15, 30, 0, 0, time.UTC))
+	assert.True(t, ok)
+
+	_, ok = IsNRWHoliday(time.Date(2026, 6, 5, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestInRange(t *testing.T) {
+	result := InRange(
+		time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, 1, 10, 0, 0, 0, 0, time.UTC),
+	)
+
+	assert.Len(t, result, 3)
+	assert.Equal(t, "1. Weihnachtstag", result[time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)])
+	assert.Equal(t, "Neujahr", result[time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)])
+}
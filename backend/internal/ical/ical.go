@@ -0,0 +1,201 @@
+// Package ical reads and writes iCalendar (.ics, RFC 5545) files.
+//
+// Parse reads all-day events, such as the NRW school holiday calendars published by the
+// ministry or schulferien.org; only the properties needed for holiday periods are parsed and
+// times of day are dropped. Calendar.Encode writes the subscription feeds for calendar apps.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+)
+
+// Event is a calendar event reduced to whole days. Start and End are inclusive calendar dates
+// as midnight UTC; the exclusive DTEND of all-day events is converted to the last day.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+const (
+	dateLayout          = "20060102"
+	localDateTimeLayout = "20060102T150405"
+	utcDateTimeLayout   = "20060102T150405Z"
+)
+
+// ErrNotCalendar is returned if the input contains no VCALENDAR
+var ErrNotCalendar = errors.New("input is not an iCalendar file")
+
+// Parse reads all VEVENTs of an iCalendar stream
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events     []Event
+		current    *Event
+		endValue   string
+		isCalendar bool
+	)
+	for i, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			isCalendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+			endValue = ""
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				continue
+			}
+			if err := finishEvent(current, endValue); err != nil {
+				return nil, fmt.Errorf("event ending at line %d: %w", i+1, err)
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescape(value)
+		case name == "DESCRIPTION":
+			current.Description = unescape(value)
+		case name == "DTSTART":
+			start, _, err := parseDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			current.Start = start
+		case name == "DTEND":
+			endValue = line
+		}
+	}
+
+	if !isCalendar {
+		return nil, ErrNotCalendar
+	}
+	return events, nil
+}
+
+// finishEvent resolves the inclusive end date and validates the event
+func finishEvent(e *Event, endLine string) error {
+	if e.Start.IsZero() {
+		return errors.New("missing DTSTART")
+	}
+
+	e.End = e.Start
+	if endLine != "" {
+		_, params, value, _ := splitProperty(endLine)
+		end, allDay, err := parseDate(value, params)
+		if err != nil {
+			return err
+		}
+		// DTEND is exclusive: all-day events and events ending at midnight end the day before
+		if allDay || isMidnight(value) {
+			end = end.AddDate(0, 0, -1)
+		}
+		if end.After(e.Start) {
+			e.End = end
+		}
+	}
+
+	return nil
+}
+
+// unfold joins continuation lines (starting with a space or tab) with their predecessor
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// splitProperty splits "NAME;PARAM=X:VALUE" into its upper-cased name, parameters and value
+func splitProperty(line string) (string, map[string]string, string, bool) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if key, val, ok := strings.Cut(p, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(line[colon+1:]), true
+}
+
+// parseDate reads a DATE or DATE-TIME value and returns its calendar date; allDay is true for DATE values.
+// UTC DATE-TIMEs are dated in Europe/Berlin, so 20261016T230000Z falls on 17 October.
+func parseDate(value string, params map[string]string) (time.Time, bool, error) {
+	value, err := berlinLocal(value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if len(value) < 8 {
+		return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+	}
+
+	date, err := time.Parse(dateLayout, value[:8])
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+	}
+
+	allDay := strings.EqualFold(params["VALUE"], "DATE") || len(value) == 8
+	return date, allDay, nil
+}
+
+// isMidnight reports whether a DATE-TIME value is at 00:00:00 Berlin time
+func isMidnight(value string) bool {
+	t, err := berlinLocal(value)
+	return err == nil && len(t) == 15 && t[9:] == "000000"
+}
+
+// berlinLocal rewrites a UTC DATE-TIME ("…Z") as the floating Europe/Berlin DATE-TIME; other values
+// are returned unchanged
+func berlinLocal(value string) (string, error) {
+	if !strings.HasSuffix(value, "Z") {
+		return value, nil
+	}
+
+	t, err := time.Parse(utcDateTimeLayout, value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q", value)
+	}
+	return t.In(timezone.Berlin).Format(localDateTimeLayout), nil
+}
+
+// unescape resolves the RFC 5545 TEXT escapes
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
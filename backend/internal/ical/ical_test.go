@@ -0,0 +1,96 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nrwHolidays = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Schulferien NRW//DE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:herbstferien-2026-nw@schulferien\r\n" +
+	"SUMMARY:Herbstferien 2026 Nordrhein-Westfalen\r\n" +
+	"DTSTART;VALUE=DATE:20261017\r\n" +
+	"DTEND;VALUE=DATE:20261101\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:weihnachtsferien-2026-nw@schul\r\n" +
+	" ferien\r\n" +
+	"SUMMARY:Weihnachtsferien\\, NRW\r\n" +
+	"DTSTART:20261222T230000Z\r\n" +
+	"DTEND:20270106T230000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:brueckentag-2027\r\n" +
+	"SUMMARY:Beweglicher Ferientag\r\n" +
+	"DTSTART;VALUE=DATE:20270205\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(nrwHolidays))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "herbstferien-2026-nw@schulferien", events[0].UID)
+	assert.Equal(t, date(2026, 10, 17), events[0].Start)
+	assert.Equal(t, date(2026, 10, 31), events[0].End, "DTEND of all-day events is exclusive")
+
+	assert.Equal(t, "weihnachtsferien-2026-nw@schulferien", events[1].UID, "folded lines are joined")
+	assert.Equal(t, "Weihnachtsferien, NRW", events[1].Summary)
+	assert.Equal(t, date(2026, 12, 23), events[1].Start)
+	assert.Equal(t, date(2027, 1, 6), events[1].End)
+
+	assert.Equal(t, date(2027, 2, 5), events[2].Start)
+	assert.Equal(t, events[2].Start, events[2].End, "events without DTEND last one day")
+}
+
+func TestParse_UTCTimesAreDatedInBerlin(t *testing.T) {
+	calendar := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:elternabend\r\n" +
+		"DTSTART:20261016T223000Z\r\n" +
+		"DTEND:20261016T233000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:sommerfest\r\n" +
+		"DTSTART:20270703T080000Z\r\n" +
+		"DTEND:20270703T220000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := Parse(strings.NewReader(calendar))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// 22:30 UTC is already past midnight in Berlin (CEST)
+	assert.Equal(t, date(2026, 10, 17), events[0].Start)
+	assert.Equal(t, date(2026, 10, 17), events[0].End)
+
+	// 22:00 UTC is midnight in Berlin, so the exclusive end stays on 3 July
+	assert.Equal(t, date(2027, 7, 3), events[1].Start)
+	assert.Equal(t, date(2027, 7, 3), events[1].End)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse(strings.NewReader("Name;Start;End\nHerbstferien;2026-10-17;2026-10-31\n"))
+	assert.ErrorIs(t, err, ErrNotCalendar)
+
+	_, err = Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nDTSTART:2026-10-17\nEND:VEVENT\nEND:VCALENDAR\n"))
+	assert.Error(t, err)
+
+	_, err = Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nDTSTART:2026-10-17T10:00Z\nEND:VEVENT\nEND:VCALENDAR\n"))
+	assert.Error(t, err, "malformed UTC DATE-TIME")
+
+	_, err = Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nEND:VEVENT\nEND:VCALENDAR\n"))
+	assert.Error(t, err, "missing DTSTART")
+}
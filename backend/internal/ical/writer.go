@@ -0,0 +1,158 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+)
+
+// TZID is the time zone all timed events are written in
+const TZID = "Europe/Berlin"
+
+// maxLineOctets is the RFC 5545 line length limit, excluding the CRLF
+const maxLineOctets = 75
+
+// Calendar is an iCalendar document to be written with Encode
+type Calendar struct {
+	Name   string    // X-WR-CALNAME shown by calendar apps
+	Stamp  time.Time // DTSTAMP of all events, usually the time of the request
+	Events []VEvent
+}
+
+// VEvent is a single or recurring event. Timed events are written as wall-clock time in
+// Europe/Berlin; all-day events use the calendar dates of Start and the inclusive End.
+type VEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	RRule       string      // RFC 5545 recurrence rule without the "RRULE:" prefix
+	ExDates     []time.Time // Occurrences excluded from the recurrence, matched by date
+}
+
+// berlinTimezone describes Europe/Berlin so clients without a tz database resolve TZID
+var berlinTimezone = []string{
+	"BEGIN:VTIMEZONE",
+	"TZID:" + TZID,
+	"BEGIN:DAYLIGHT",
+	"TZOFFSETFROM:+0100",
+	"TZOFFSETTO:+0200",
+	"TZNAME:CEST",
+	"DTSTART:19700329T020000",
+	"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU",
+	"END:DAYLIGHT",
+	"BEGIN:STANDARD",
+	"TZOFFSETFROM:+0200",
+	"TZOFFSETTO:+0100",
+	"TZNAME:CET",
+	"DTSTART:19701025T030000",
+	"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU",
+	"END:STANDARD",
+	"END:VTIMEZONE",
+}
+
+// Encode writes the calendar as an RFC 5545 stream with CRLF line endings and folded lines
+func (c *Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	write := func(line string) {
+		writeFolded(bw, line)
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//moto//Project Phoenix//DE")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	if c.Name != "" {
+		write("X-WR-CALNAME:" + escape(c.Name))
+	}
+	write("X-WR-TIMEZONE:" + TZID)
+	for _, line := range berlinTimezone {
+		write(line)
+	}
+
+	stamp := c.Stamp.UTC().Format(utcDateTimeLayout)
+	for _, e := range c.Events {
+		write("BEGIN:VEVENT")
+		write("UID:" + e.UID)
+		write("DTSTAMP:" + stamp)
+		if e.AllDay {
+			end := e.End
+			if end.Before(e.Start) {
+				end = e.Start
+			}
+			// DTEND of all-day events is exclusive
+			write("DTSTART;VALUE=DATE:" + e.Start.Format(dateLayout))
+			write("DTEND;VALUE=DATE:" + end.AddDate(0, 0, 1).Format(dateLayout))
+		} else {
+			write("DTSTART;TZID=" + TZID + ":" + localTime(e.Start))
+			if !e.End.IsZero() {
+				write("DTEND;TZID=" + TZID + ":" + localTime(e.End))
+			}
+		}
+		if e.RRule != "" {
+			write("RRULE:" + e.RRule)
+		}
+		for _, ex := range e.ExDates {
+			write(exDate(e, ex))
+		}
+		write("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			write("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.Location != "" {
+			write("LOCATION:" + escape(e.Location))
+		}
+		write("TRANSP:OPAQUE")
+		write("END:VEVENT")
+	}
+
+	write("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// exDate formats an excluded occurrence with the same value type as DTSTART
+func exDate(e VEvent, day time.Time) string {
+	if e.AllDay {
+		return "EXDATE;VALUE=DATE:" + day.Format(dateLayout)
+	}
+	start := e.Start.In(timezone.Berlin)
+	occurrence := time.Date(day.Year(), day.Month(), day.Day(),
+		start.Hour(), start.Minute(), start.Second(), 0, timezone.Berlin)
+	return "EXDATE;TZID=" + TZID + ":" + localTime(occurrence)
+}
+
+// localTime formats t as wall-clock time in Europe/Berlin
+func localTime(t time.Time) string {
+	return t.In(timezone.Berlin).Format(localDateTimeLayout)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without splitting UTF-8 characters
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(line[:cut])
+		_, _ = w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	_, _ = w.WriteString(line)
+	_, _ = w.WriteString("\r\n")
+}
+
+// escape applies the RFC 5545 TEXT escapes; it is the inverse of unescape
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,89 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar_Encode(t *testing.T) {
+	cal := &Calendar{
+		Name:  "Fußball-AG",
+		Stamp: time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC),
+		Events: []VEvent{
+			{
+				UID:      "activity-schedule-40@phoenix",
+				Summary:  "Fußball, Jahrgang 3; Halle",
+				Location: "Turnhalle",
+				Start:    time.Date(2026, 8, 26, 14, 0, 0, 0, timezone.Berlin),
+				End:      time.Date(2026, 8, 26, 15, 30, 0, 0, timezone.Berlin),
+				RRule:    "FREQ=WEEKLY;INTERVAL=1;BYDAY=WE;UNTIL=20270630T235959Z",
+				ExDates:  []time.Time{date(2026, 10, 21)},
+			},
+			{
+				UID:     "substitution-12@phoenix",
+				Summary: "Vertretung",
+				Start:   date(2026, 10, 19),
+				End:     date(2026, 10, 20),
+				AllDay:  true,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, cal.Encode(&buf))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-CALNAME:Fußball-AG\r\n")
+	assert.Contains(t, out, "TZID:Europe/Berlin\r\n")
+	assert.Contains(t, out, "DTSTAMP:20261016T083000Z\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=Europe/Berlin:20260826T140000\r\n")
+	assert.Contains(t, out, "DTEND;TZID=Europe/Berlin:20260826T153000\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=WE;UNTIL=20270630T235959Z\r\n")
+	assert.Contains(t, out, "EXDATE;TZID=Europe/Berlin:20261021T140000\r\n")
+	assert.Contains(t, out, "SUMMARY:Fußball\\, Jahrgang 3\\; Halle\r\n")
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20261019\r\n")
+	assert.Contains(t, out, "DTEND;VALUE=DATE:20261021\r\n")
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VEVENT"))
+}
+
+func TestCalendar_EncodeFoldsLongLines(t *testing.T) {
+	cal := &Calendar{
+		Stamp: time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC),
+		Events: []VEvent{{
+			UID:         "long@phoenix",
+			Summary:     "AG",
+			Description: strings.Repeat("Größenübergänge ", 20),
+			Start:       date(2026, 10, 19),
+			AllDay:      true,
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, cal.Encode(&buf))
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, strings.ToValidUTF8(line, "") == line, "line splits a UTF-8 character: %q", line)
+	}
+
+	// The folded output reads back through the parser
+	events, err := Parse(&buf)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, strings.TrimSpace(strings.Repeat("Größenübergänge ", 20)), events[0].Description)
+	assert.Equal(t, date(2026, 10, 19), events[0].End)
+}
+
+func TestEscape(t *testing.T) {
+	value := "Raum 1, Gebäude A; Hinweis\\Text\nZeile 2"
+	assert.Equal(t, `Raum 1\, Gebäude A\; Hinweis\\Text\nZeile 2`, escape(value))
+	assert.Equal(t, value, unescape(escape(value)))
+}
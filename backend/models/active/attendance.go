@@ -17,6 +17,14 @@ type Attendance struct {
 	CheckedInBy  int64      `bun:"checked_in_by,notnull" json:"checked_in_by"`
 	CheckedOutBy *int64     `bun:"checked_out_by" json:"checked_out_by,omitempty"`
 	DeviceID     int64      `bun:"device_id,notnull" json:"device_id"`
+
+	// Who collected the student at checkout (guardian or extra pickup person), name kept as snapshot
+	PickedUpByGuardianID *int64  `bun:"picked_up_by_guardian_id" json:"picked_up_by_guardian_id,omitempty"`
+	PickedUpByPersonID   *int64  `bun:"picked_up_by_person_id" json:"picked_up_by_person_id,omitempty"`
+	PickedUpByName       *string `bun:"picked_up_by_name" json:"picked_up_by_name,omitempty"`
+
+	// Bus route the student left with when checked out by the bulk bus checkout
+	DepartedByBusRouteID *int64 `bun:"departed_by_bus_route_id" json:"departed_by_bus_route_id,omitempty"`
 }
 
 // BeforeAppendModel is commented out to let the repository control the table expression
@@ -0,0 +1,57 @@
+package active
+
+import (
+	"errors"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+const tableActiveDispatchedNotifications = "active.dispatched_notifications"
+
+// DispatchedNotification records that a scheduled notification (pickup due, pickup overdue,
+// bus departure due) was sent for a student. At most one exists per student, event type and
+// day, so restarts and multiple replicas never send the same notification twice.
+type DispatchedNotification struct {
+	base.Model   `bun:"schema:active,table:dispatched_notifications"`
+	StudentID    int64     `bun:"student_id,notnull" json:"student_id"`
+	EventType    string    `bun:"event_type,notnull" json:"event_type"`
+	DispatchDate time.Time `bun:"dispatch_date,notnull,type:date" json:"dispatch_date"`
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (n *DispatchedNotification) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableActiveDispatchedNotifications)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableActiveDispatchedNotifications)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableActiveDispatchedNotifications)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableActiveDispatchedNotifications)
+	}
+	return nil
+}
+
+func (n *DispatchedNotification) GetID() any              { return n.ID }
+func (n *DispatchedNotification) GetCreatedAt() time.Time { return n.CreatedAt }
+func (n *DispatchedNotification) GetUpdatedAt() time.Time { return n.UpdatedAt }
+func (n *DispatchedNotification) TableName() string       { return tableActiveDispatchedNotifications }
+
+// Validate validates the dispatched notification
+func (n *DispatchedNotification) Validate() error {
+	if n.StudentID <= 0 {
+		return errors.New("student ID is required")
+	}
+	if n.EventType == "" {
+		return errors.New("event_type is required")
+	}
+	if n.DispatchDate.IsZero() {
+		return errors.New("dispatch_date is required")
+	}
+	return nil
+}
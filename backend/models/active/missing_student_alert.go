@@ -0,0 +1,126 @@
+package active
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/uptrace/bun"
+)
+
+const tableActiveMissingStudentAlerts = "active.missing_student_alerts"
+
+// MissingStudentAlert status constants
+const (
+	MissingAlertStatusOpen     = "open"
+	MissingAlertStatusResolved = "resolved"
+)
+
+// MissingStudentAlert resolution constants
+const (
+	MissingAlertResolutionFound    = "found"     // Student turned up or was located on the premises
+	MissingAlertResolutionSick     = "sick"      // Student is sick and was not reported
+	MissingAlertResolutionWentHome = "went_home" // Student went home directly after school
+)
+
+// ValidMissingAlertResolutions lists all valid resolutions of a missing student alert
+var ValidMissingAlertResolutions = []string{
+	MissingAlertResolutionFound,
+	MissingAlertResolutionSick,
+	MissingAlertResolutionWentHome,
+}
+
+// MissingStudentAlert is raised when a student expected in OGS has not checked in
+// by the end of the grace period. At most one alert exists per student and day.
+type MissingStudentAlert struct {
+	base.Model     `bun:"schema:active,table:missing_student_alerts"`
+	StudentID      int64      `bun:"student_id,notnull" json:"student_id"`
+	GroupID        *int64     `bun:"group_id" json:"group_id,omitempty"` // Education group of the student
+	AlertDate      time.Time  `bun:"alert_date,notnull,type:date" json:"alert_date"`
+	ExpectedBy     time.Time  `bun:"expected_by,notnull" json:"expected_by"` // End of the grace period
+	Status         string     `bun:"status,notnull" json:"status"`
+	Resolution     string     `bun:"resolution" json:"resolution,omitempty"`
+	ResolutionNote string     `bun:"resolution_note" json:"resolution_note,omitempty"`
+	ResolvedBy     *int64     `bun:"resolved_by" json:"resolved_by,omitempty"` // Staff who resolved the alert
+	ResolvedAt     *time.Time `bun:"resolved_at" json:"resolved_at,omitempty"`
+
+	Student *users.Student `bun:"rel:belongs-to,join:student_id=id" json:"student,omitempty"`
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (a *MissingStudentAlert) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableActiveMissingStudentAlerts)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableActiveMissingStudentAlerts)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableActiveMissingStudentAlerts)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableActiveMissingStudentAlerts)
+	}
+	return nil
+}
+
+func (a *MissingStudentAlert) GetID() any              { return a.ID }
+func (a *MissingStudentAlert) GetCreatedAt() time.Time { return a.CreatedAt }
+func (a *MissingStudentAlert) GetUpdatedAt() time.Time { return a.UpdatedAt }
+func (a *MissingStudentAlert) TableName() string       { return tableActiveMissingStudentAlerts }
+
+// Validate validates the missing student alert
+func (a *MissingStudentAlert) Validate() error {
+	if a.StudentID <= 0 {
+		return errors.New("student ID is required")
+	}
+	if a.AlertDate.IsZero() {
+		return errors.New("alert_date is required")
+	}
+	if a.ExpectedBy.IsZero() {
+		return errors.New("expected_by is required")
+	}
+	switch a.Status {
+	case MissingAlertStatusOpen:
+		if a.Resolution != "" {
+			return errors.New("open alerts cannot have a resolution")
+		}
+	case MissingAlertStatusResolved:
+		if !slices.Contains(ValidMissingAlertResolutions, a.Resolution) {
+			return errors.New("invalid resolution")
+		}
+		if a.ResolvedBy == nil || *a.ResolvedBy <= 0 || a.ResolvedAt == nil {
+			return errors.New("resolved alerts require resolved_by and resolved_at")
+		}
+	default:
+		return errors.New("invalid status")
+	}
+	return nil
+}
+
+// IsOpen returns true if the alert has not been resolved yet
+func (a *MissingStudentAlert) IsOpen() bool {
+	return a.Status == MissingAlertStatusOpen
+}
+
+// Resolve records how the alert was cleared and by whom
+func (a *MissingStudentAlert) Resolve(resolution string, staffID int64, note string, at time.Time) error {
+	if !a.IsOpen() {
+		return errors.New("alert is already resolved")
+	}
+	if !slices.Contains(ValidMissingAlertResolutions, resolution) {
+		return errors.New("invalid resolution")
+	}
+	if staffID <= 0 {
+		return errors.New("resolved_by is required")
+	}
+
+	a.Status = MissingAlertStatusResolved
+	a.Resolution = resolution
+	a.ResolutionNote = note
+	a.ResolvedBy = &staffID
+	a.ResolvedAt = &at
+	return nil
+}
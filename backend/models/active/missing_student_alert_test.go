@@ -0,0 +1,110 @@
+package active
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingStudentAlert_Validate(t *testing.T) {
+	openAlert := func() *MissingStudentAlert {
+		return &MissingStudentAlert{
+			StudentID:  10,
+			AlertDate:  time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			ExpectedBy: time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC),
+			Status:     MissingAlertStatusOpen,
+		}
+	}
+
+	t.Run("valid open alert", func(t *testing.T) {
+		assert.NoError(t, openAlert().Validate())
+	})
+
+	t.Run("missing student ID", func(t *testing.T) {
+		a := openAlert()
+		a.StudentID = 0
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "student ID is required")
+	})
+
+	t.Run("missing expected_by", func(t *testing.T) {
+		a := openAlert()
+		a.ExpectedBy = time.Time{}
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected_by is required")
+	})
+
+	t.Run("open alert with resolution", func(t *testing.T) {
+		a := openAlert()
+		a.Resolution = MissingAlertResolutionFound
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "open alerts cannot have a resolution")
+	})
+
+	t.Run("resolved alert without resolver", func(t *testing.T) {
+		a := openAlert()
+		a.Status = MissingAlertStatusResolved
+		a.Resolution = MissingAlertResolutionSick
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resolved alerts require resolved_by and resolved_at")
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		a := openAlert()
+		a.Status = "closed"
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid status")
+	})
+}
+
+func TestMissingStudentAlert_Resolve(t *testing.T) {
+	resolvedAt := time.Date(2026, 3, 4, 12, 45, 0, 0, time.UTC)
+	newAlert := func() *MissingStudentAlert {
+		return &MissingStudentAlert{
+			StudentID:  10,
+			AlertDate:  time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			ExpectedBy: time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC),
+			Status:     MissingAlertStatusOpen,
+		}
+	}
+
+	t.Run("resolves open alert", func(t *testing.T) {
+		a := newAlert()
+		require.NoError(t, a.Resolve(MissingAlertResolutionWentHome, 50, "Vater hat angerufen", resolvedAt))
+
+		assert.False(t, a.IsOpen())
+		assert.Equal(t, MissingAlertResolutionWentHome, a.Resolution)
+		assert.Equal(t, "Vater hat angerufen", a.ResolutionNote)
+		require.NotNil(t, a.ResolvedBy)
+		assert.Equal(t, int64(50), *a.ResolvedBy)
+		assert.Equal(t, &resolvedAt, a.ResolvedAt)
+		assert.NoError(t, a.Validate())
+	})
+
+	t.Run("invalid resolution", func(t *testing.T) {
+		err := newAlert().Resolve("lost", 50, "", resolvedAt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid resolution")
+	})
+
+	t.Run("missing staff", func(t *testing.T) {
+		err := newAlert().Resolve(MissingAlertResolutionFound, 0, "", resolvedAt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resolved_by is required")
+	})
+
+	t.Run("already resolved", func(t *testing.T) {
+		a := newAlert()
+		require.NoError(t, a.Resolve(MissingAlertResolutionFound, 50, "", resolvedAt))
+		err := a.Resolve(MissingAlertResolutionSick, 50, "", resolvedAt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "alert is already resolved")
+	})
+}
@@ -23,6 +23,9 @@ type GroupRepository interface {
 	// EndSession marks a group session as ended at the current time
 	EndSession(ctx context.Context, id int64) error
 
+	// FindEndedBetween finds all sessions whose end time lies in [from, to)
+	FindEndedBetween(ctx context.Context, from, to time.Time) ([]*Group, error)
+
 	// Relations methods
 	FindWithRelations(ctx context.Context, id int64) (*Group, error)
 	FindWithVisits(ctx context.Context, id int64) (*Group, error)
@@ -71,6 +74,12 @@ type VisitRepository interface {
 	// FindByTimeRange finds all visits active during a specific time range
 	FindByTimeRange(ctx context.Context, start, end time.Time) ([]*Visit, error)
 
+	// FindByStudentAndTimeRange finds a student's visits active during a specific time range, ordered by entry time
+	FindByStudentAndTimeRange(ctx context.Context, studentID int64, start, end time.Time) ([]*Visit, error)
+
+	// FindWithActiveGroup finds a visit with its active group loaded
+	FindWithActiveGroup(ctx context.Context, id int64) (*Visit, error)
+
 	// EndVisit marks a visit as ended at the current time
 	EndVisit(ctx context.Context, id int64) error
 
@@ -178,6 +187,9 @@ type WorkSessionRepository interface {
 	// GetHistoryByStaffID returns work sessions for a staff member in a date range
 	GetHistoryByStaffID(ctx context.Context, staffID int64, from, to time.Time) ([]*WorkSession, error)
 
+	// GetByDateRange returns the work sessions of all staff in a date range
+	GetByDateRange(ctx context.Context, from, to time.Time) ([]*WorkSession, error)
+
 	// GetOpenSessions returns all sessions without check-out before a given date
 	GetOpenSessions(ctx context.Context, beforeDate time.Time) ([]*WorkSession, error)
 
@@ -204,11 +216,64 @@ type StaffAbsenceRepository interface {
 	// GetByDateRange returns all absences overlapping the given date range
 	GetByDateRange(ctx context.Context, from, to time.Time) ([]*StaffAbsence, error)
 
+	// GetByStatus returns all absences with the given status, earliest start first
+	GetByStatus(ctx context.Context, status string) ([]*StaffAbsence, error)
+
+	// UpdateReview stores the review decision only while the absence is still reported.
+	// Returns false when it was reviewed or changed concurrently.
+	UpdateReview(ctx context.Context, absence *StaffAbsence) (bool, error)
+
 	// GetTodayAbsenceMap returns a map of staff IDs to their absence type for today
 	// Priority order when multiple absences exist: sick > training > vacation > other
 	GetTodayAbsenceMap(ctx context.Context) (map[int64]string, error)
 }
 
+// StudentAbsenceRepository defines operations for managing student absences
+type StudentAbsenceRepository interface {
+	base.Repository[*StudentAbsence]
+
+	// GetByStudentID returns all absences of a student, most recent first
+	GetByStudentID(ctx context.Context, studentID int64) ([]*StudentAbsence, error)
+
+	// GetByStudentAndDateRange returns absences for a student overlapping the given date range
+	GetByStudentAndDateRange(ctx context.Context, studentID int64, from, to time.Time) ([]*StudentAbsence, error)
+
+	// GetByDate returns all student absences covering the given date
+	GetByDate(ctx context.Context, date time.Time) ([]*StudentAbsence, error)
+}
+
+// MissingStudentAlertRepository defines operations for managing missing student alerts
+type MissingStudentAlertRepository interface {
+	base.Repository[*MissingStudentAlert]
+
+	// GetByDate returns all alerts raised on the given date with student and person, oldest first
+	GetByDate(ctx context.Context, date time.Time) ([]*MissingStudentAlert, error)
+
+	// GetByStudentID returns the alert history of a student, most recent first
+	GetByStudentID(ctx context.Context, studentID int64) ([]*MissingStudentAlert, error)
+}
+
+// DispatchedNotificationRepository records which scheduled notifications were sent
+type DispatchedNotificationRepository interface {
+	// MarkDispatched records the notification for the student and day. It returns false
+	// when the notification was already recorded, so callers send each one only once.
+	MarkDispatched(ctx context.Context, studentID int64, eventType string, date time.Time) (bool, error)
+}
+
+// StaffContractRepository defines operations for managing contracted working hours
+type StaffContractRepository interface {
+	base.Repository[*StaffContract]
+
+	// GetByStaffID returns all contract entries for a staff member, oldest first
+	GetByStaffID(ctx context.Context, staffID int64) ([]*StaffContract, error)
+
+	// GetByStaffAndValidFrom returns the entry for a staff member starting on the given date, or nil
+	GetByStaffAndValidFrom(ctx context.Context, staffID int64, validFrom time.Time) (*StaffContract, error)
+
+	// ListValidUntil returns all contract entries starting on or before the given date, ordered by staff and start
+	ListValidUntil(ctx context.Context, until time.Time) ([]*StaffContract, error)
+}
+
 // WorkSessionBreakRepository defines operations for managing work session breaks
 type WorkSessionBreakRepository interface {
 	base.Repository[*WorkSessionBreak]
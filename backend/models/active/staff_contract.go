@@ -0,0 +1,95 @@
+package active
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/uptrace/bun"
+)
+
+const tableActiveStaffContracts = "active.staff_contracts"
+
+// MaxWeeklyContractMinutes is the upper bound for contracted hours (48h, §3 ArbZG averaged week)
+const MaxWeeklyContractMinutes = 48 * 60
+
+// contractWorkDaysPerWeek spreads the weekly hours evenly over Monday to Friday
+const contractWorkDaysPerWeek = 5
+
+// StaffContract records a staff member's contracted weekly hours from a given date on.
+// A new row is added for every change; the row with the latest ValidFrom on or before
+// a date is the one in effect for that date.
+type StaffContract struct {
+	base.Model    `bun:"schema:active,table:staff_contracts"`
+	StaffID       int64     `bun:"staff_id,notnull" json:"staff_id"`
+	WeeklyMinutes int       `bun:"weekly_minutes,notnull" json:"weekly_minutes"`
+	ValidFrom     time.Time `bun:"valid_from,notnull,type:date" json:"valid_from"`
+	Note          string    `bun:"note" json:"note,omitempty"`
+	CreatedBy     int64     `bun:"created_by,notnull" json:"created_by"`
+
+	Staff *users.Staff `bun:"rel:belongs-to,join:staff_id=id" json:"staff,omitempty"`
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (sc *StaffContract) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableActiveStaffContracts)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableActiveStaffContracts)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableActiveStaffContracts)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableActiveStaffContracts)
+	}
+	return nil
+}
+
+func (sc *StaffContract) GetID() any              { return sc.ID }
+func (sc *StaffContract) GetCreatedAt() time.Time { return sc.CreatedAt }
+func (sc *StaffContract) GetUpdatedAt() time.Time { return sc.UpdatedAt }
+func (sc *StaffContract) TableName() string       { return tableActiveStaffContracts }
+
+// Validate validates the contract record
+func (sc *StaffContract) Validate() error {
+	if sc.StaffID <= 0 {
+		return errors.New("staff ID is required")
+	}
+	if sc.WeeklyMinutes < 0 {
+		return errors.New("weekly hours cannot be negative")
+	}
+	if sc.WeeklyMinutes > MaxWeeklyContractMinutes {
+		return errors.New("weekly hours cannot exceed 48")
+	}
+	if sc.ValidFrom.IsZero() {
+		return errors.New("valid_from is required")
+	}
+	if sc.CreatedBy <= 0 {
+		return errors.New("created_by is required")
+	}
+	return nil
+}
+
+// DailyTargetMinutes returns the target working time for a single weekday
+func (sc *StaffContract) DailyTargetMinutes() int {
+	return int(math.Round(float64(sc.WeeklyMinutes) / contractWorkDaysPerWeek))
+}
+
+// EffectiveContract returns the contract in effect on the given date, or nil.
+// Contracts must belong to the same staff member; their order does not matter.
+func EffectiveContract(contracts []*StaffContract, date time.Time) *StaffContract {
+	var effective *StaffContract
+	for _, c := range contracts {
+		if c.ValidFrom.After(date) {
+			continue
+		}
+		if effective == nil || c.ValidFrom.After(effective.ValidFrom) {
+			effective = c
+		}
+	}
+	return effective
+}
@@ -0,0 +1,87 @@
+package active
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaffContract_Validate(t *testing.T) {
+	validContract := func() *StaffContract {
+		return &StaffContract{
+			StaffID:       1,
+			WeeklyMinutes: 39 * 60,
+			ValidFrom:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedBy:     1,
+		}
+	}
+
+	t.Run("valid contract", func(t *testing.T) {
+		assert.NoError(t, validContract().Validate())
+	})
+
+	t.Run("zero hours allowed", func(t *testing.T) {
+		c := validContract()
+		c.WeeklyMinutes = 0
+		assert.NoError(t, c.Validate())
+	})
+
+	t.Run("missing staff ID", func(t *testing.T) {
+		c := validContract()
+		c.StaffID = 0
+		err := c.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "staff ID is required")
+	})
+
+	t.Run("negative hours", func(t *testing.T) {
+		c := validContract()
+		c.WeeklyMinutes = -60
+		err := c.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be negative")
+	})
+
+	t.Run("more than 48 hours", func(t *testing.T) {
+		c := validContract()
+		c.WeeklyMinutes = MaxWeeklyContractMinutes + 1
+		err := c.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot exceed 48")
+	})
+
+	t.Run("missing valid_from", func(t *testing.T) {
+		c := validContract()
+		c.ValidFrom = time.Time{}
+		err := c.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "valid_from is required")
+	})
+
+	t.Run("missing created_by", func(t *testing.T) {
+		c := validContract()
+		c.CreatedBy = 0
+		err := c.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "created_by is required")
+	})
+}
+
+func TestStaffContract_DailyTargetMinutes(t *testing.T) {
+	assert.Equal(t, 468, (&StaffContract{WeeklyMinutes: 39 * 60}).DailyTargetMinutes())
+	assert.Equal(t, 234, (&StaffContract{WeeklyMinutes: 1170}).DailyTargetMinutes())
+	assert.Equal(t, 0, (&StaffContract{}).DailyTargetMinutes())
+}
+
+func TestEffectiveContract(t *testing.T) {
+	jan := &StaffContract{WeeklyMinutes: 39 * 60, ValidFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	apr := &StaffContract{WeeklyMinutes: 30 * 60, ValidFrom: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}
+	contracts := []*StaffContract{apr, jan}
+
+	assert.Nil(t, EffectiveContract(contracts, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Same(t, jan, EffectiveContract(contracts, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Same(t, apr, EffectiveContract(contracts, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, EffectiveContract(nil, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)))
+}
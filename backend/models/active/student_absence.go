@@ -0,0 +1,111 @@
+package active
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/uptrace/bun"
+)
+
+const tableActiveStudentAbsences = "active.student_absences"
+
+// StudentAbsenceType constants
+const (
+	StudentAbsenceTypeSick     = "sick"
+	StudentAbsenceTypeExcused  = "excused"
+	StudentAbsenceTypeTrip     = "trip"
+	StudentAbsenceTypeVacation = "vacation"
+)
+
+// ValidStudentAbsenceTypes lists all valid student absence types
+var ValidStudentAbsenceTypes = []string{
+	StudentAbsenceTypeSick,
+	StudentAbsenceTypeExcused,
+	StudentAbsenceTypeTrip,
+	StudentAbsenceTypeVacation,
+}
+
+// StudentAbsence represents a reported or planned absence of a student (sick, class trip, etc.)
+type StudentAbsence struct {
+	base.Model   `bun:"schema:active,table:student_absences"`
+	StudentID    int64     `bun:"student_id,notnull" json:"student_id"`
+	AbsenceType  string    `bun:"absence_type,notnull" json:"absence_type"`
+	DateStart    time.Time `bun:"date_start,notnull,type:date" json:"date_start"`
+	DateEnd      time.Time `bun:"date_end,notnull,type:date" json:"date_end"`
+	Note         string    `bun:"note" json:"note,omitempty"`
+	GuardianNote string    `bun:"guardian_note" json:"guardian_note,omitempty"`
+	ReportedBy   int64     `bun:"reported_by,nullzero" json:"reported_by,omitempty"` // Staff author; 0 when reported by a guardian
+
+	// ReportedByGuardianID is set when the absence was reported through the parent portal
+	ReportedByGuardianID *int64 `bun:"reported_by_guardian_id" json:"reported_by_guardian_id,omitempty"`
+
+	Student *users.Student `bun:"rel:belongs-to,join:student_id=id" json:"student,omitempty"`
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (sa *StudentAbsence) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableActiveStudentAbsences)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableActiveStudentAbsences)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableActiveStudentAbsences)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableActiveStudentAbsences)
+	}
+	return nil
+}
+
+func (sa *StudentAbsence) GetID() any              { return sa.ID }
+func (sa *StudentAbsence) GetCreatedAt() time.Time { return sa.CreatedAt }
+func (sa *StudentAbsence) GetUpdatedAt() time.Time { return sa.UpdatedAt }
+func (sa *StudentAbsence) TableName() string       { return tableActiveStudentAbsences }
+
+// Validate validates the student absence record
+func (sa *StudentAbsence) Validate() error {
+	if sa.StudentID <= 0 {
+		return errors.New("student ID is required")
+	}
+	if !slices.Contains(ValidStudentAbsenceTypes, sa.AbsenceType) {
+		return errors.New("invalid absence type")
+	}
+	if sa.DateStart.IsZero() {
+		return errors.New("date_start is required")
+	}
+	if sa.DateEnd.IsZero() {
+		return errors.New("date_end is required")
+	}
+	if sa.DateStart.After(sa.DateEnd) {
+		return errors.New("date_start must be before or equal to date_end")
+	}
+	if sa.ReportedBy <= 0 && !sa.IsGuardianReported() {
+		return errors.New("reported_by is required")
+	}
+	return nil
+}
+
+// IsGuardianReported returns true if the absence was reported by a guardian
+func (sa *StudentAbsence) IsGuardianReported() bool {
+	return sa.ReportedByGuardianID != nil && *sa.ReportedByGuardianID > 0
+}
+
+// CoversDate reports whether the absence includes the given calendar date
+func (sa *StudentAbsence) CoversDate(date time.Time) bool {
+	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return !d.Before(sa.DateStart) && !d.After(sa.DateEnd)
+}
+
+// DurationDays returns the number of days this absence spans
+func (sa *StudentAbsence) DurationDays() int {
+	days := int(sa.DateEnd.Sub(sa.DateStart).Hours()/24) + 1
+	if days < 1 {
+		return 1
+	}
+	return days
+}
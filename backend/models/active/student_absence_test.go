@@ -0,0 +1,87 @@
+package active
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentAbsence_Validate(t *testing.T) {
+	validAbsence := func() *StudentAbsence {
+		return &StudentAbsence{
+			StudentID:   10,
+			AbsenceType: StudentAbsenceTypeTrip,
+			DateStart:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			DateEnd:     time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			ReportedBy:  20,
+		}
+	}
+
+	t.Run("valid absence", func(t *testing.T) {
+		assert.NoError(t, validAbsence().Validate())
+	})
+
+	t.Run("all valid absence types", func(t *testing.T) {
+		for _, at := range ValidStudentAbsenceTypes {
+			a := validAbsence()
+			a.AbsenceType = at
+			assert.NoError(t, a.Validate(), "type %s should be valid", at)
+		}
+	})
+
+	t.Run("missing student ID", func(t *testing.T) {
+		a := validAbsence()
+		a.StudentID = 0
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "student ID is required")
+	})
+
+	t.Run("invalid absence type", func(t *testing.T) {
+		a := validAbsence()
+		a.AbsenceType = "training"
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid absence type")
+	})
+
+	t.Run("start after end", func(t *testing.T) {
+		a := validAbsence()
+		a.DateStart, a.DateEnd = a.DateEnd, a.DateStart
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "date_start must be before or equal to date_end")
+	})
+
+	t.Run("missing reporter", func(t *testing.T) {
+		a := validAbsence()
+		a.ReportedBy = 0
+		err := a.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reported_by is required")
+	})
+
+	t.Run("guardian reporter", func(t *testing.T) {
+		a := validAbsence()
+		a.ReportedBy = 0
+		guardianID := int64(30)
+		a.ReportedByGuardianID = &guardianID
+		assert.NoError(t, a.Validate())
+		assert.True(t, a.IsGuardianReported())
+	})
+}
+
+func TestStudentAbsence_CoversDate(t *testing.T) {
+	a := &StudentAbsence{
+		DateStart: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		DateEnd:   time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.True(t, a.CoversDate(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, a.CoversDate(time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)), "time of day is ignored")
+	assert.False(t, a.CoversDate(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, a.CoversDate(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 3, a.DurationDays())
+}
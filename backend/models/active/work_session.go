@@ -89,11 +89,14 @@ func (ws *WorkSession) CheckOut() {
 
 // NetMinutes calculates net work time in minutes (gross minus breaks)
 func (ws *WorkSession) NetMinutes() int {
-	var end time.Time
+	return ws.NetMinutesAt(time.Now())
+}
+
+// NetMinutesAt calculates net work time in minutes as of the given time; open sessions count up to now
+func (ws *WorkSession) NetMinutesAt(now time.Time) int {
+	end := now
 	if ws.CheckOutTime != nil {
 		end = *ws.CheckOutTime
-	} else {
-		end = time.Now()
 	}
 	gross := int(end.Sub(ws.CheckInTime).Minutes())
 	net := gross - ws.BreakMinutes
@@ -102,21 +105,3 @@ func (ws *WorkSession) NetMinutes() int {
 	}
 	return net
 }
-
-// IsOvertime returns true if net work time exceeds 10 hours (600 minutes)
-func (ws *WorkSession) IsOvertime() bool {
-	return ws.NetMinutes() > 600
-}
-
-// IsBreakCompliant checks if breaks comply with German labor law (§4 ArbZG)
-func (ws *WorkSession) IsBreakCompliant() bool {
-	net := ws.NetMinutes()
-	if net <= 360 { // <= 6h: no break required
-		return true
-	}
-	if net <= 540 { // <= 9h: 30 min break required
-		return ws.BreakMinutes >= 30
-	}
-	// > 9h: 45 min break required
-	return ws.BreakMinutes >= 45
-}
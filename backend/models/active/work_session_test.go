@@ -154,109 +154,14 @@ func TestWorkSession_NetMinutes(t *testing.T) {
 		// Should be approximately 120 minutes (allow 2 min tolerance)
 		assert.InDelta(t, 120, net, 2)
 	})
-}
-
-func TestWorkSession_IsOvertime(t *testing.T) {
-	t.Run("not overtime under 10 hours", func(t *testing.T) {
-		checkIn := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC)
-		ws := &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: 0,
-		}
-		assert.False(t, ws.IsOvertime())
-	})
-
-	t.Run("overtime over 10 hours", func(t *testing.T) {
-		checkIn := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
-		ws := &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: 0,
-		}
-		assert.True(t, ws.IsOvertime()) // 660 min > 600
-	})
-
-	t.Run("exactly 10 hours not overtime", func(t *testing.T) {
-		checkIn := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
-		ws := &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: 0,
-		}
-		assert.False(t, ws.IsOvertime()) // 600 min == 600, not >600
-	})
-}
-
-func TestWorkSession_IsBreakCompliant(t *testing.T) {
-	makeSession := func(hours, breakMin int) *WorkSession {
-		checkIn := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
-		checkOut := checkIn.Add(time.Duration(hours)*time.Hour + time.Duration(breakMin)*time.Minute)
-		return &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: breakMin,
-		}
-	}
-
-	t.Run("under 6h no break needed", func(t *testing.T) {
-		ws := makeSession(5, 0)
-		assert.True(t, ws.IsBreakCompliant())
-	})
-
-	t.Run("exactly 6h no break needed", func(t *testing.T) {
-		ws := makeSession(6, 0)
-		assert.True(t, ws.IsBreakCompliant())
-	})
-
-	t.Run("over 6h needs 30min break - not compliant", func(t *testing.T) {
-		// 7h gross with 15min break = 405min net > 360
-		checkIn := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
-		ws := &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: 15,
-		}
-		assert.False(t, ws.IsBreakCompliant())
-	})
 
-	t.Run("over 6h with 30min break - compliant", func(t *testing.T) {
+	t.Run("active session counts up to the given time", func(t *testing.T) {
 		checkIn := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC)
 		ws := &WorkSession{
 			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: 30,
-		}
-		assert.True(t, ws.IsBreakCompliant())
-	})
-
-	t.Run("over 9h needs 45min break - not compliant", func(t *testing.T) {
-		checkIn := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC)
-		ws := &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
 			BreakMinutes: 30,
 		}
-		// 600min gross - 30min break = 570min net > 540
-		assert.False(t, ws.IsBreakCompliant())
-	})
-
-	t.Run("over 9h with 45min break - compliant", func(t *testing.T) {
-		checkIn := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
-		checkOut := time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC)
-		ws := &WorkSession{
-			CheckInTime:  checkIn,
-			CheckOutTime: &checkOut,
-			BreakMinutes: 45,
-		}
-		// 600min gross - 45min break = 555min net > 540 → needs 45min break
-		assert.True(t, ws.IsBreakCompliant())
+		assert.Equal(t, 420, ws.NetMinutesAt(time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC)))
 	})
 }
 
@@ -0,0 +1,210 @@
+package activities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// Allocation status constants
+const (
+	AllocationStatusDraft   = "draft"   // Collecting preferences; the result is only previewed
+	AllocationStatusApplied = "applied" // Assignments were turned into enrollments
+)
+
+// MaxAllocationPreferences is the number of ranked AG wishes a student can submit
+const MaxAllocationPreferences = 3
+
+// Table name constants for BUN ORM schema qualification
+const (
+	tableActivitiesAllocations           = "activities.allocations"
+	tableActivitiesAllocationPreferences = "activities.allocation_preferences"
+	tableActivitiesAllocationAssignments = "activities.allocation_assignments"
+	tableExprAllocationsAsAllocation     = `activities.allocations AS "allocation"`
+)
+
+// Allocation is one term's AG lottery: students submit ranked wishes, the result is
+// computed reproducibly from Seed and applied as enrollments
+type Allocation struct {
+	base.Model               `bun:"schema:activities,table:allocations"`
+	DateframeID              int64      `bun:"dateframe_id,notnull" json:"dateframe_id"` // Term; only its activity groups can be wished for
+	Name                     string     `bun:"name,notnull" json:"name"`
+	Status                   string     `bun:"status,notnull,default:'draft'" json:"status"`
+	Seed                     int64      `bun:"seed,notnull" json:"seed"`
+	MaxAssignmentsPerStudent int        `bun:"max_assignments_per_student,notnull,default:1" json:"max_assignments_per_student"` // Number of allocation rounds
+	Notes                    *string    `bun:"notes" json:"notes,omitempty"`
+	CreatedBy                int64      `bun:"created_by,notnull" json:"created_by"`
+	AppliedAt                *time.Time `bun:"applied_at" json:"applied_at,omitempty"`
+	AppliedBy                *int64     `bun:"applied_by" json:"applied_by,omitempty"`
+
+	// Relations - populated by the repository
+	Preferences []*AllocationPreference `bun:"rel:has-many,join:id=allocation_id" json:"preferences,omitempty"`
+	Assignments []*AllocationAssignment `bun:"rel:has-many,join:id=allocation_id" json:"assignments,omitempty"`
+}
+
+func (a *Allocation) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableExprAllocationsAsAllocation)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableExprAllocationsAsAllocation)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableExprAllocationsAsAllocation)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (a *Allocation) TableName() string {
+	return tableActivitiesAllocations
+}
+
+// Validate ensures allocation data is valid
+func (a *Allocation) Validate() error {
+	a.Name = strings.TrimSpace(a.Name)
+
+	if a.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if a.DateframeID <= 0 {
+		return errors.New("dateframe ID is required")
+	}
+
+	if a.Status == "" {
+		a.Status = AllocationStatusDraft
+	}
+
+	if a.Status != AllocationStatusDraft && a.Status != AllocationStatusApplied {
+		return errors.New("invalid status: must be draft or applied")
+	}
+
+	if a.MaxAssignmentsPerStudent == 0 {
+		a.MaxAssignmentsPerStudent = 1
+	}
+
+	if a.MaxAssignmentsPerStudent < 1 || a.MaxAssignmentsPerStudent > MaxAllocationPreferences {
+		return errors.New("max assignments per student must be between 1 and 3")
+	}
+
+	if a.CreatedBy <= 0 {
+		return errors.New("created_by is required")
+	}
+
+	return nil
+}
+
+// IsDraft returns true if the allocation is still collecting preferences
+func (a *Allocation) IsDraft() bool {
+	return a.Status == AllocationStatusDraft
+}
+
+// IsApplied returns true if the allocation has been applied
+func (a *Allocation) IsApplied() bool {
+	return a.Status == AllocationStatusApplied
+}
+
+// CanModify returns true if settings and preferences can still be changed (only drafts)
+func (a *Allocation) CanModify() bool {
+	return a.IsDraft()
+}
+
+// GetID returns the entity's ID
+func (a *Allocation) GetID() interface{} {
+	return a.ID
+}
+
+// GetCreatedAt returns the creation timestamp
+func (a *Allocation) GetCreatedAt() time.Time {
+	return a.CreatedAt
+}
+
+// GetUpdatedAt returns the last update timestamp
+func (a *Allocation) GetUpdatedAt() time.Time {
+	return a.UpdatedAt
+}
+
+// AllocationPreference is one ranked AG wish of a student
+type AllocationPreference struct {
+	base.Model      `bun:"schema:activities,table:allocation_preferences"`
+	AllocationID    int64 `bun:"allocation_id,notnull" json:"allocation_id"`
+	StudentID       int64 `bun:"student_id,notnull" json:"student_id"`
+	ActivityGroupID int64 `bun:"activity_group_id,notnull" json:"activity_group_id"`
+	Rank            int   `bun:"rank,notnull" json:"rank"` // 1 = first choice
+}
+
+// TableName returns the database table name
+func (p *AllocationPreference) TableName() string {
+	return tableActivitiesAllocationPreferences
+}
+
+// Validate ensures preference data is valid
+func (p *AllocationPreference) Validate() error {
+	if p.AllocationID <= 0 {
+		return errors.New("allocation ID is required")
+	}
+
+	if p.StudentID <= 0 {
+		return errors.New("student ID is required")
+	}
+
+	if p.ActivityGroupID <= 0 {
+		return errors.New("activity group ID is required")
+	}
+
+	if p.Rank < 1 || p.Rank > MaxAllocationPreferences {
+		return errors.New("rank must be between 1 and 3")
+	}
+
+	return nil
+}
+
+// GetID returns the entity's ID
+func (p *AllocationPreference) GetID() interface{} {
+	return p.ID
+}
+
+// GetCreatedAt returns the creation timestamp
+func (p *AllocationPreference) GetCreatedAt() time.Time {
+	return p.CreatedAt
+}
+
+// GetUpdatedAt returns the last update timestamp
+func (p *AllocationPreference) GetUpdatedAt() time.Time {
+	return p.UpdatedAt
+}
+
+// AllocationAssignment is one place granted by an allocation. Assignments are computed on
+// preview and recorded when the allocation is applied.
+type AllocationAssignment struct {
+	base.Model      `bun:"schema:activities,table:allocation_assignments"`
+	AllocationID    int64 `bun:"allocation_id,notnull" json:"allocation_id"`
+	StudentID       int64 `bun:"student_id,notnull" json:"student_id"`
+	ActivityGroupID int64 `bun:"activity_group_id,notnull" json:"activity_group_id"`
+	Rank            int   `bun:"rank,notnull" json:"rank"`   // Which wish was granted
+	Round           int   `bun:"round,notnull" json:"round"` // Allocation round the place was granted in
+}
+
+// TableName returns the database table name
+func (a *AllocationAssignment) TableName() string {
+	return tableActivitiesAllocationAssignments
+}
+
+// GetID returns the entity's ID
+func (a *AllocationAssignment) GetID() interface{} {
+	return a.ID
+}
+
+// GetCreatedAt returns the creation timestamp
+func (a *AllocationAssignment) GetCreatedAt() time.Time {
+	return a.CreatedAt
+}
+
+// GetUpdatedAt returns the last update timestamp
+func (a *AllocationAssignment) GetUpdatedAt() time.Time {
+	return a.UpdatedAt
+}
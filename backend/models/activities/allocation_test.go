@@ -0,0 +1,50 @@
+package activities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_Validate(t *testing.T) {
+	t.Run("defaults to a one-round draft", func(t *testing.T) {
+		a := &Allocation{DateframeID: 10, Name: "  AG-Wahl Herbst  ", CreatedBy: 90}
+		require.NoError(t, a.Validate())
+		assert.Equal(t, "AG-Wahl Herbst", a.Name)
+		assert.Equal(t, AllocationStatusDraft, a.Status)
+		assert.Equal(t, 1, a.MaxAssignmentsPerStudent)
+		assert.True(t, a.CanModify())
+	})
+
+	tests := []struct {
+		name       string
+		allocation *Allocation
+	}{
+		{"missing name", &Allocation{DateframeID: 10, CreatedBy: 90}},
+		{"missing dateframe", &Allocation{Name: "AG-Wahl", CreatedBy: 90}},
+		{"invalid status", &Allocation{DateframeID: 10, Name: "AG-Wahl", Status: "reverted", CreatedBy: 90}},
+		{"too many rounds", &Allocation{DateframeID: 10, Name: "AG-Wahl", MaxAssignmentsPerStudent: 4, CreatedBy: 90}},
+		{"missing creator", &Allocation{DateframeID: 10, Name: "AG-Wahl"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.allocation.Validate())
+		})
+	}
+
+	applied := &Allocation{DateframeID: 10, Name: "AG-Wahl", Status: AllocationStatusApplied, CreatedBy: 90}
+	require.NoError(t, applied.Validate())
+	assert.False(t, applied.CanModify())
+}
+
+func TestAllocationPreference_Validate(t *testing.T) {
+	p := &AllocationPreference{AllocationID: 10, StudentID: 20, ActivityGroupID: 30, Rank: 3}
+	assert.NoError(t, p.Validate())
+
+	p.Rank = 4
+	assert.Error(t, p.Validate())
+
+	p.Rank = 0
+	assert.Error(t, p.Validate())
+}
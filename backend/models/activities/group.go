@@ -18,6 +18,7 @@ type Group struct {
 	IsOpen          bool   `bun:"is_open,notnull,default:false" json:"is_open"`
 	CategoryID      int64  `bun:"category_id,notnull" json:"category_id"`
 	PlannedRoomID   *int64 `bun:"planned_room_id" json:"planned_room_id,omitempty"`
+	DateframeID     *int64 `bun:"dateframe_id" json:"dateframe_id,omitempty"` // Term the group runs in; its enrollment window applies
 	CreatedBy       int64  `bun:"created_by,notnull" json:"created_by"`
 
 	// Relations - populated when using the ORM's relations
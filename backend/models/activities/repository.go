@@ -22,6 +22,9 @@ type CategoryRepository interface {
 type GroupRepository interface {
 	base.Repository[*Group]
 
+	// FindByIDForUpdate loads a group and locks its row until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id int64) (*Group, error)
+
 	// FindByCategory finds all groups in a specific category
 	FindByCategory(ctx context.Context, categoryID int64) ([]*Group, error)
 
@@ -51,6 +54,9 @@ type ScheduleRepository interface {
 	// FindByGroupID finds all schedules for a specific group
 	FindByGroupID(ctx context.Context, groupID int64) ([]*Schedule, error)
 
+	// FindByGroupIDs finds all schedules for multiple groups
+	FindByGroupIDs(ctx context.Context, groupIDs []int64) ([]*Schedule, error)
+
 	// FindByWeekday finds all schedules for a specific weekday
 	FindByWeekday(ctx context.Context, weekday string) ([]*Schedule, error)
 
@@ -88,7 +94,7 @@ type StudentEnrollmentRepository interface {
 	// FindByGroupID finds all enrollments for a specific group
 	FindByGroupID(ctx context.Context, groupID int64) ([]*StudentEnrollment, error)
 
-	// CountByGroupID counts the number of students enrolled in a specific group
+	// CountByGroupID counts the number of students enrolled in a specific group (waitlisted students excluded)
 	CountByGroupID(ctx context.Context, groupID int64) (int, error)
 
 	// FindByEnrollmentDateRange finds enrollments within a date range
@@ -97,3 +103,44 @@ type StudentEnrollmentRepository interface {
 	// UpdateAttendanceStatus updates the attendance status for a specific enrollment
 	UpdateAttendanceStatus(ctx context.Context, id int64, status *string) error
 }
+
+// AllocationRepository defines operations for managing AG lotteries with their preferences and assignments
+type AllocationRepository interface {
+	base.Repository[*Allocation]
+
+	// FindByIDForUpdate loads an allocation and locks its row until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id int64) (*Allocation, error)
+
+	// FindByDateframeID finds all allocations of a term, newest first
+	FindByDateframeID(ctx context.Context, dateframeID int64) ([]*Allocation, error)
+
+	// FindPreferences returns all preferences of an allocation, ordered by student and rank
+	FindPreferences(ctx context.Context, allocationID int64) ([]*AllocationPreference, error)
+
+	// ReplaceStudentPreferences replaces the ranked wishes of one student
+	ReplaceStudentPreferences(ctx context.Context, allocationID, studentID int64, preferences []*AllocationPreference) error
+
+	// CreateAssignments records the places granted when an allocation is applied
+	CreateAssignments(ctx context.Context, assignments []*AllocationAssignment) error
+
+	// FindAssignments returns the recorded assignments of an allocation
+	FindAssignments(ctx context.Context, allocationID int64) ([]*AllocationAssignment, error)
+}
+
+// SessionRegisterRepository defines operations for managing per-session attendance registers
+type SessionRegisterRepository interface {
+	base.Repository[*SessionRegister]
+
+	// FindByGroupAndDate finds the register of an activity group on a day; nil when none exists
+	FindByGroupAndDate(ctx context.Context, activityGroupID int64, date time.Time) (*SessionRegister, error)
+
+	// FindByGroupAndDateRange finds the registers of an activity group between from and to (inclusive)
+	// with their entries, ordered by date
+	FindByGroupAndDateRange(ctx context.Context, activityGroupID int64, from, to time.Time) ([]*SessionRegister, error)
+
+	// FindEntries returns the entries of a register, ordered by student
+	FindEntries(ctx context.Context, registerID int64) ([]*SessionRegisterEntry, error)
+
+	// SaveEntries inserts entries or updates the existing entry of the same register and student
+	SaveEntries(ctx context.Context, entries []*SessionRegisterEntry) error
+}
@@ -72,6 +72,14 @@ func IsValidWeekday(weekday int) bool {
 	return weekday >= WeekdayMonday && weekday <= WeekdaySunday
 }
 
+// ISOWeekday converts Go's Sunday-based weekday of t to ISO 8601 (Monday = 1, Sunday = 7)
+func ISOWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return WeekdaySunday
+	}
+	return int(t.Weekday())
+}
+
 // Validate ensures schedule data is valid
 func (s *Schedule) Validate() error {
 	if !IsValidWeekday(s.Weekday) {
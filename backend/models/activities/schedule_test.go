@@ -74,6 +74,23 @@ func TestIsValidWeekday(t *testing.T) {
 	}
 }
 
+func TestISOWeekday(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want int
+	}{
+		{time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC), WeekdayMonday},
+		{time.Date(2026, 7, 11, 0, 0, 0, 0, time.UTC), WeekdaySaturday},
+		{time.Date(2026, 7, 12, 0, 0, 0, 0, time.UTC), WeekdaySunday},
+	}
+
+	for _, tt := range tests {
+		if got := ISOWeekday(tt.date); got != tt.want {
+			t.Errorf("ISOWeekday(%s) = %d, want %d", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
 func TestScheduleValidate(t *testing.T) {
 	tests := []struct {
 		name     string
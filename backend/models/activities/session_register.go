@@ -0,0 +1,147 @@
+package activities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// Table name constants for BUN ORM schema qualification
+const (
+	tableActivitiesSessionRegisters       = "activities.session_registers"
+	tableActivitiesSessionRegisterEntries = "activities.session_register_entries"
+	tableExprSessionRegistersAsRegister   = `activities.session_registers AS "session_register"`
+)
+
+// SessionRegister is the attendance register of one activity group on one day. It is derived
+// from RFID visits when the day's session ends; several sessions on the same day share it.
+type SessionRegister struct {
+	base.Model      `bun:"schema:activities,table:session_registers"`
+	ActivityGroupID int64     `bun:"activity_group_id,notnull" json:"activity_group_id"`
+	SessionDate     time.Time `bun:"session_date,notnull,type:date" json:"session_date"`
+	ScheduleID      *int64    `bun:"schedule_id" json:"schedule_id,omitempty"` // Scheduled weekday the session belongs to; nil for off-schedule sessions
+	DerivedAt       time.Time `bun:"derived_at,notnull" json:"derived_at"`
+
+	// Relations - populated by the repository
+	Entries []*SessionRegisterEntry `bun:"rel:has-many,join:id=register_id" json:"entries,omitempty"`
+}
+
+func (r *SessionRegister) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableExprSessionRegistersAsRegister)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableExprSessionRegistersAsRegister)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableExprSessionRegistersAsRegister)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (r *SessionRegister) TableName() string {
+	return tableActivitiesSessionRegisters
+}
+
+// Validate ensures register data is valid
+func (r *SessionRegister) Validate() error {
+	if r.ActivityGroupID <= 0 {
+		return errors.New("activity group ID is required")
+	}
+
+	if r.SessionDate.IsZero() {
+		return errors.New("session date is required")
+	}
+
+	if r.DerivedAt.IsZero() {
+		r.DerivedAt = time.Now()
+	}
+
+	return nil
+}
+
+// GetID returns the entity's ID
+func (r *SessionRegister) GetID() interface{} {
+	return r.ID
+}
+
+// GetCreatedAt returns the creation timestamp
+func (r *SessionRegister) GetCreatedAt() time.Time {
+	return r.CreatedAt
+}
+
+// GetUpdatedAt returns the last update timestamp
+func (r *SessionRegister) GetUpdatedAt() time.Time {
+	return r.UpdatedAt
+}
+
+// SessionRegisterEntry is the attendance of one enrolled student in a session register.
+// Status uses the enrollment attendance constants (PRESENT, ABSENT, EXCUSED, UNKNOWN).
+type SessionRegisterEntry struct {
+	base.Model     `bun:"schema:activities,table:session_register_entries"`
+	RegisterID     int64      `bun:"register_id,notnull" json:"register_id"`
+	StudentID      int64      `bun:"student_id,notnull" json:"student_id"`
+	Status         string     `bun:"status,notnull" json:"status"`
+	FirstSeenAt    *time.Time `bun:"first_seen_at" json:"first_seen_at,omitempty"`   // First RFID entry into the session's room
+	MinutesPresent int        `bun:"minutes_present,notnull" json:"minutes_present"` // Sum of all visits of the day
+	Note           *string    `bun:"note" json:"note,omitempty"`                     // Excuse reason
+	ExcusedBy      *int64     `bun:"excused_by" json:"excused_by,omitempty"`         // Staff member who excused the student; nil for reported absences
+	ExcusedAt      *time.Time `bun:"excused_at" json:"excused_at,omitempty"`
+}
+
+// TableName returns the database table name
+func (e *SessionRegisterEntry) TableName() string {
+	return tableActivitiesSessionRegisterEntries
+}
+
+// Validate ensures entry data is valid
+func (e *SessionRegisterEntry) Validate() error {
+	if e.RegisterID <= 0 {
+		return errors.New("register ID is required")
+	}
+
+	if e.StudentID <= 0 {
+		return errors.New("student ID is required")
+	}
+
+	if !IsValidAttendanceStatus(e.Status) {
+		return errors.New("invalid attendance status")
+	}
+
+	if e.MinutesPresent < 0 {
+		return errors.New("minutes present cannot be negative")
+	}
+
+	return nil
+}
+
+// IsExcused returns true if the student's absence was excused
+func (e *SessionRegisterEntry) IsExcused() bool {
+	return e.Status == AttendanceExcused
+}
+
+// Excuse marks the student as excused; staffID is nil for absences reported in advance
+func (e *SessionRegisterEntry) Excuse(note *string, staffID *int64, at time.Time) {
+	e.Status = AttendanceExcused
+	e.Note = note
+	e.ExcusedBy = staffID
+	e.ExcusedAt = &at
+}
+
+// GetID returns the entity's ID
+func (e *SessionRegisterEntry) GetID() interface{} {
+	return e.ID
+}
+
+// GetCreatedAt returns the creation timestamp
+func (e *SessionRegisterEntry) GetCreatedAt() time.Time {
+	return e.CreatedAt
+}
+
+// GetUpdatedAt returns the last update timestamp
+func (e *SessionRegisterEntry) GetUpdatedAt() time.Time {
+	return e.UpdatedAt
+}
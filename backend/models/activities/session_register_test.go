@@ -0,0 +1,37 @@
+package activities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRegister_Validate(t *testing.T) {
+	register := &SessionRegister{ActivityGroupID: 30, SessionDate: time.Date(2026, 9, 14, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, register.Validate())
+	assert.False(t, register.DerivedAt.IsZero(), "derivation time defaults to now")
+
+	assert.Error(t, (&SessionRegister{SessionDate: register.SessionDate}).Validate())
+	assert.Error(t, (&SessionRegister{ActivityGroupID: 30}).Validate())
+}
+
+func TestSessionRegisterEntry_ValidateAndExcuse(t *testing.T) {
+	entry := &SessionRegisterEntry{RegisterID: 50, StudentID: 20, Status: AttendanceAbsent}
+	require.NoError(t, entry.Validate())
+	assert.False(t, entry.IsExcused())
+
+	note := "Krank"
+	staffID := int64(90)
+	excusedAt := time.Date(2026, 9, 14, 16, 0, 0, 0, time.UTC)
+	entry.Excuse(&note, &staffID, excusedAt)
+	assert.True(t, entry.IsExcused())
+	assert.Equal(t, &note, entry.Note)
+	assert.Equal(t, &staffID, entry.ExcusedBy)
+	assert.Equal(t, excusedAt, *entry.ExcusedAt)
+
+	assert.Error(t, (&SessionRegisterEntry{RegisterID: 50, StudentID: 20, Status: "LATE"}).Validate())
+	assert.Error(t, (&SessionRegisterEntry{RegisterID: 50, StudentID: 20, Status: AttendancePresent, MinutesPresent: -10}).Validate())
+	assert.Error(t, (&SessionRegisterEntry{StudentID: 20, Status: AttendancePresent}).Validate())
+}
@@ -17,6 +17,12 @@ const (
 	AttendanceUnknown = "UNKNOWN"
 )
 
+// Enrollment status constants
+const (
+	EnrollmentStatusEnrolled   = "enrolled"   // Holds one of the group's MaxParticipants places
+	EnrollmentStatusWaitlisted = "waitlisted" // Waiting for a place, ordered by WaitlistPosition
+)
+
 // Table name constants for BUN ORM schema qualification
 const (
 	tableActivitiesStudentEnrollments       = "activities.student_enrollments"
@@ -30,6 +36,8 @@ type StudentEnrollment struct {
 	ActivityGroupID  int64     `bun:"activity_group_id,notnull" json:"activity_group_id"`
 	EnrollmentDate   time.Time `bun:"enrollment_date,notnull" json:"enrollment_date"`
 	AttendanceStatus *string   `bun:"attendance_status" json:"attendance_status,omitempty"`
+	Status           string    `bun:"status,notnull,default:'enrolled'" json:"status"`
+	WaitlistPosition *int      `bun:"waitlist_position" json:"waitlist_position,omitempty"` // 1-based, set only while waitlisted
 
 	// Relations - populated when using the ORM's relations
 	Student       *users.Student `bun:"rel:belongs-to,join:student_id=id" json:"student,omitempty"`
@@ -101,9 +109,43 @@ func (se *StudentEnrollment) Validate() error {
 		return errors.New("invalid attendance status")
 	}
 
+	if se.Status == "" {
+		se.Status = EnrollmentStatusEnrolled
+	}
+
+	switch se.Status {
+	case EnrollmentStatusEnrolled:
+		if se.WaitlistPosition != nil {
+			return errors.New("waitlist position is only allowed for waitlisted enrollments")
+		}
+	case EnrollmentStatusWaitlisted:
+		if se.WaitlistPosition == nil || *se.WaitlistPosition <= 0 {
+			return errors.New("waitlisted enrollments require a positive waitlist position")
+		}
+	default:
+		return errors.New("invalid enrollment status")
+	}
+
 	return nil
 }
 
+// IsWaitlisted returns true if the student is waiting for a place
+func (se *StudentEnrollment) IsWaitlisted() bool {
+	return se.Status == EnrollmentStatusWaitlisted
+}
+
+// Waitlist puts the enrollment on the waitlist at the given position
+func (se *StudentEnrollment) Waitlist(position int) {
+	se.Status = EnrollmentStatusWaitlisted
+	se.WaitlistPosition = &position
+}
+
+// Promote moves a waitlisted enrollment to a regular place
+func (se *StudentEnrollment) Promote() {
+	se.Status = EnrollmentStatusEnrolled
+	se.WaitlistPosition = nil
+}
+
 // MarkPresent marks the student as present
 func (se *StudentEnrollment) MarkPresent() {
 	status := AttendancePresent
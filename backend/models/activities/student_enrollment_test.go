@@ -169,6 +169,46 @@ func TestStudentEnrollmentValidate(t *testing.T) {
 	}
 }
 
+func TestStudentEnrollmentWaitlist(t *testing.T) {
+	studentEnrollment := &StudentEnrollment{
+		StudentID:       1,
+		ActivityGroupID: 1,
+	}
+
+	if err := studentEnrollment.Validate(); err != nil {
+		t.Fatalf("StudentEnrollment.Validate() error = %v", err)
+	}
+	if studentEnrollment.Status != EnrollmentStatusEnrolled {
+		t.Errorf("StudentEnrollment.Validate() status = %v, want %v", studentEnrollment.Status, EnrollmentStatusEnrolled)
+	}
+
+	studentEnrollment.Waitlist(3)
+	if !studentEnrollment.IsWaitlisted() || *studentEnrollment.WaitlistPosition != 3 {
+		t.Errorf("StudentEnrollment.Waitlist() did not set status and position")
+	}
+	if err := studentEnrollment.Validate(); err != nil {
+		t.Errorf("StudentEnrollment.Validate() error = %v for waitlisted enrollment", err)
+	}
+
+	studentEnrollment.Promote()
+	if studentEnrollment.IsWaitlisted() || studentEnrollment.WaitlistPosition != nil {
+		t.Errorf("StudentEnrollment.Promote() did not clear the waitlist")
+	}
+
+	zero := 0
+	invalid := []*StudentEnrollment{
+		{StudentID: 1, ActivityGroupID: 1, Status: EnrollmentStatusWaitlisted},
+		{StudentID: 1, ActivityGroupID: 1, Status: EnrollmentStatusWaitlisted, WaitlistPosition: &zero},
+		{StudentID: 1, ActivityGroupID: 1, Status: EnrollmentStatusEnrolled, WaitlistPosition: &zero},
+		{StudentID: 1, ActivityGroupID: 1, Status: "pending"},
+	}
+	for _, e := range invalid {
+		if err := e.Validate(); err == nil {
+			t.Errorf("StudentEnrollment.Validate() expected error for status %q", e.Status)
+		}
+	}
+}
+
 func TestStudentEnrollmentMarkPresent(t *testing.T) {
 	studentEnrollment := &StudentEnrollment{
 		StudentID:       1,
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// DeviceKeyEvent records the lifecycle of an IoT device API key for security auditing
+type DeviceKeyEvent struct {
+	ID               int64                  `bun:"id,pk,autoincrement" json:"id"`
+	DeviceID         *int64                 `bun:"device_id" json:"device_id,omitempty"` // nil for codes not yet enrolled
+	DeviceIdentifier string                 `bun:"device_identifier,notnull" json:"device_identifier"`
+	EventType        string                 `bun:"event_type,notnull" json:"event_type"`
+	AccountID        *int64                 `bun:"account_id" json:"account_id,omitempty"` // nil when the device itself acted
+	IPAddress        string                 `bun:"ip_address" json:"ip_address,omitempty"`
+	Metadata         map[string]interface{} `bun:"metadata,type:jsonb" json:"metadata,omitempty"`
+	CreatedAt        time.Time              `bun:"created_at,notnull,default:now()" json:"created_at"`
+}
+
+// Device key event type constants
+const (
+	DeviceKeyEventEnrollmentCodeCreated = "enrollment_code_created"
+	DeviceKeyEventDeviceEnrolled        = "device_enrolled"
+	DeviceKeyEventAPIKeyRotated         = "api_key_rotated"
+	DeviceKeyEventAPIKeyRevoked         = "api_key_revoked"
+)
+
+// TableName returns the database table name
+func (e *DeviceKeyEvent) TableName() string {
+	return "audit.device_key_events"
+}
+
+// Validate ensures the device key event is valid
+func (e *DeviceKeyEvent) Validate() error {
+	if e.DeviceIdentifier == "" {
+		return errors.New("device identifier is required")
+	}
+
+	switch e.EventType {
+	case DeviceKeyEventEnrollmentCodeCreated, DeviceKeyEventDeviceEnrolled,
+		DeviceKeyEventAPIKeyRotated, DeviceKeyEventAPIKeyRevoked:
+		// Valid types
+	case "":
+		return errors.New("event type is required")
+	default:
+		return errors.New("invalid event type")
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// GetID implements the base.Entity interface
+func (e *DeviceKeyEvent) GetID() interface{} {
+	return e.ID
+}
+
+// GetCreatedAt implements the base.Entity interface
+func (e *DeviceKeyEvent) GetCreatedAt() time.Time {
+	return e.CreatedAt
+}
+
+// GetUpdatedAt implements the base.Entity interface
+func (e *DeviceKeyEvent) GetUpdatedAt() time.Time {
+	return e.CreatedAt
+}
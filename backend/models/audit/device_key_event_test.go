@@ -0,0 +1,47 @@
+package audit
+
+import "testing"
+
+func TestDeviceKeyEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *DeviceKeyEvent
+		wantErr bool
+	}{
+		{
+			name:  "valid rotation",
+			event: &DeviceKeyEvent{DeviceIdentifier: "reader-1", EventType: DeviceKeyEventAPIKeyRotated},
+		},
+		{
+			name:  "valid enrollment code without device row",
+			event: &DeviceKeyEvent{DeviceIdentifier: "reader-2", EventType: DeviceKeyEventEnrollmentCodeCreated},
+		},
+		{
+			name:    "missing device identifier",
+			event:   &DeviceKeyEvent{EventType: DeviceKeyEventAPIKeyRevoked},
+			wantErr: true,
+		},
+		{
+			name:    "missing event type",
+			event:   &DeviceKeyEvent{DeviceIdentifier: "reader-1"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown event type",
+			event:   &DeviceKeyEvent{DeviceIdentifier: "reader-1", EventType: "api_key_leaked"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.event.CreatedAt.IsZero() {
+				t.Error("Validate() should default CreatedAt")
+			}
+		})
+	}
+}
@@ -40,3 +40,9 @@ type DataImportRepository interface {
 	FindRecent(ctx context.Context, limit int) ([]*DataImport, error)
 	List(ctx context.Context, filters map[string]interface{}) ([]*DataImport, error)
 }
+
+// DeviceKeyEventRepository defines operations for managing device API key audit records
+type DeviceKeyEventRepository interface {
+	Create(ctx context.Context, event *DeviceKeyEvent) error
+	FindByDeviceID(ctx context.Context, deviceID int64, limit int) ([]*DeviceKeyEvent, error)
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// tableAuthEmailOutbox is the schema-qualified table name for the email outbox
+const tableAuthEmailOutbox = "auth.email_outbox"
+
+// EmailOutbox status values
+const (
+	EmailOutboxStatusPending = "pending"
+	EmailOutboxStatusSent    = "sent"
+	EmailOutboxStatusFailed  = "failed"
+)
+
+// validEmailOutboxStatuses lists all valid outbox statuses
+var validEmailOutboxStatuses = []string{
+	EmailOutboxStatusPending,
+	EmailOutboxStatusSent,
+	EmailOutboxStatusFailed,
+}
+
+// EmailOutbox represents a persisted outbound email awaiting or past delivery
+type EmailOutbox struct {
+	base.Model     `bun:"schema:auth,table:email_outbox"`
+	DeliveryType   string          `bun:"delivery_type,notnull" json:"delivery_type"`
+	ReferenceID    int64           `bun:"reference_id,notnull,default:0" json:"reference_id"`
+	BaseRetry      int             `bun:"base_retry,notnull,default:0" json:"base_retry"`
+	Recipient      string          `bun:"recipient,notnull" json:"recipient"`
+	Payload        json.RawMessage `bun:"payload,type:jsonb,notnull" json:"-"`
+	Status         string          `bun:"status,notnull,default:'pending'" json:"status"`
+	Attempts       int             `bun:"attempts,notnull,default:0" json:"attempts"`
+	MaxAttempts    int             `bun:"max_attempts,notnull" json:"max_attempts"`
+	BackoffSeconds []int           `bun:"backoff_seconds,array" json:"backoff_seconds,omitempty"`
+	NextAttemptAt  time.Time       `bun:"next_attempt_at,notnull" json:"next_attempt_at"`
+	LockedUntil    *time.Time      `bun:"locked_until" json:"locked_until,omitempty"`
+	LastError      *string         `bun:"last_error" json:"last_error,omitempty"`
+	SentAt         *time.Time      `bun:"sent_at" json:"sent_at,omitempty"`
+}
+
+// TableName returns the database table name
+func (e *EmailOutbox) TableName() string {
+	return tableAuthEmailOutbox
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (e *EmailOutbox) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableAuthEmailOutbox)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableAuthEmailOutbox)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableAuthEmailOutbox)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableAuthEmailOutbox)
+	}
+	return nil
+}
+
+// Validate ensures the outbox entry contains the required fields
+func (e *EmailOutbox) Validate() error {
+	if e.DeliveryType == "" {
+		return errors.New("delivery type is required")
+	}
+	if e.Recipient == "" {
+		return errors.New("recipient is required")
+	}
+	if len(e.Payload) == 0 {
+		return errors.New("payload is required")
+	}
+	if e.MaxAttempts <= 0 {
+		return errors.New("max attempts must be positive")
+	}
+	if e.Attempts < 0 {
+		return errors.New("attempts cannot be negative")
+	}
+	if !slices.Contains(validEmailOutboxStatuses, e.Status) {
+		return errors.New("invalid outbox status")
+	}
+	return nil
+}
+
+// Backoff returns the retry backoff policy as durations
+func (e *EmailOutbox) Backoff() []time.Duration {
+	if len(e.BackoffSeconds) == 0 {
+		return nil
+	}
+	backoff := make([]time.Duration, len(e.BackoffSeconds))
+	for i, seconds := range e.BackoffSeconds {
+		backoff[i] = time.Duration(seconds) * time.Second
+	}
+	return backoff
+}
+
+// SetBackoff stores the retry backoff policy with second precision
+func (e *EmailOutbox) SetBackoff(backoff []time.Duration) {
+	if len(backoff) == 0 {
+		e.BackoffSeconds = nil
+		return
+	}
+	e.BackoffSeconds = make([]int, len(backoff))
+	for i, d := range backoff {
+		e.BackoffSeconds[i] = int(d / time.Second)
+	}
+}
+
+// EmailDeliveryStats summarizes outbox rows for one delivery type and status
+type EmailDeliveryStats struct {
+	DeliveryType  string     `bun:"delivery_type" json:"delivery_type"`
+	Status        string     `bun:"status" json:"status"`
+	Count         int        `bun:"count" json:"count"`
+	OldestCreated *time.Time `bun:"oldest_created_at" json:"oldest_created_at,omitempty"`
+	LastAttemptAt *time.Time `bun:"last_attempt_at" json:"last_attempt_at,omitempty"`
+}
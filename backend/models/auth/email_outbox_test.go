@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailOutbox_Validate(t *testing.T) {
+	valid := func() *EmailOutbox {
+		return &EmailOutbox{
+			DeliveryType: "invitation",
+			Recipient:    "user@example.com",
+			Payload:      json.RawMessage(`{"subject":"Hello"}`),
+			Status:       EmailOutboxStatusPending,
+			MaxAttempts:  3,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(e *EmailOutbox)
+		wantErr bool
+	}{
+		{name: "valid pending entry", mutate: func(_ *EmailOutbox) {}},
+		{name: "valid sent entry", mutate: func(e *EmailOutbox) { e.Status = EmailOutboxStatusSent }},
+		{name: "missing delivery type", mutate: func(e *EmailOutbox) { e.DeliveryType = "" }, wantErr: true},
+		{name: "missing recipient", mutate: func(e *EmailOutbox) { e.Recipient = "" }, wantErr: true},
+		{name: "missing payload", mutate: func(e *EmailOutbox) { e.Payload = nil }, wantErr: true},
+		{name: "zero max attempts", mutate: func(e *EmailOutbox) { e.MaxAttempts = 0 }, wantErr: true},
+		{name: "negative attempts", mutate: func(e *EmailOutbox) { e.Attempts = -1 }, wantErr: true},
+		{name: "unknown status", mutate: func(e *EmailOutbox) { e.Status = "queued" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := valid()
+			tt.mutate(entry)
+			err := entry.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEmailOutbox_Backoff(t *testing.T) {
+	entry := &EmailOutbox{}
+	assert.Nil(t, entry.Backoff())
+
+	entry.SetBackoff([]time.Duration{time.Minute, 5 * time.Minute})
+	assert.Equal(t, []int{60, 300}, entry.BackoffSeconds)
+	assert.Equal(t, []time.Duration{time.Minute, 5 * time.Minute}, entry.Backoff())
+
+	entry.SetBackoff(nil)
+	assert.Nil(t, entry.BackoffSeconds)
+}
+
+func TestEmailOutbox_TableName(t *testing.T) {
+	assert.Equal(t, "auth.email_outbox", (&EmailOutbox{}).TableName())
+}
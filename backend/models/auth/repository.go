@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"time"
+
+	"github.com/moto-nrw/project-phoenix/email"
 )
 
 // AccountRepository defines operations for managing accounts
@@ -163,6 +165,15 @@ type PasswordResetRateLimitRepository interface {
 	CleanupExpired(ctx context.Context) (int, error)
 }
 
+// EmailOutboxRepository defines operations for the durable email outbox.
+// It satisfies email.OutboxStore so the dispatcher can persist messages directly.
+type EmailOutboxRepository interface {
+	email.OutboxStore
+
+	// GetDeliveryStats returns row counts grouped by delivery type and status
+	GetDeliveryStats(ctx context.Context) ([]*EmailDeliveryStats, error)
+}
+
 // InvitationTokenRepository defines operations for managing invitation tokens.
 type InvitationTokenRepository interface {
 	Create(ctx context.Context, token *InvitationToken) error
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Setting key prefixes for the missing student alert rules (category "attendance").
+// The weekday name is appended, e.g. "missing_alert_arrival_monday".
+const (
+	SettingMissingAlertArrivalPrefix = "missing_alert_arrival_"
+	SettingMissingAlertGracePrefix   = "missing_alert_grace_minutes_"
+)
+
+// MissingAlertWeekdayKeys maps ISO weekdays (Monday = 1) to the suffix of their setting keys
+var MissingAlertWeekdayKeys = map[int]string{
+	1: "monday",
+	2: "tuesday",
+	3: "wednesday",
+	4: "thursday",
+	5: "friday",
+}
+
+// MissingAlertDayRule defines when students are expected to arrive on one weekday
+type MissingAlertDayRule struct {
+	ExpectedArrival string `json:"expected_arrival"` // HH:MM, usually the end of the last lesson
+	GraceMinutes    int    `json:"grace_minutes"`    // Minutes after ExpectedArrival before an alert is raised
+}
+
+// MissingAlertRules holds the per-weekday arrival times after which students who have
+// not checked in are reported as missing
+type MissingAlertRules struct {
+	Days map[int]*MissingAlertDayRule `json:"days"` // Keyed by ISO weekday (Monday = 1)
+}
+
+// NewDefaultMissingAlertRules creates rules expecting students at 12:00 with 30 minutes grace on school days
+func NewDefaultMissingAlertRules() *MissingAlertRules {
+	days := make(map[int]*MissingAlertDayRule, len(MissingAlertWeekdayKeys))
+	for weekday := range MissingAlertWeekdayKeys {
+		days[weekday] = &MissingAlertDayRule{ExpectedArrival: "12:00", GraceMinutes: 30}
+	}
+	return &MissingAlertRules{Days: days}
+}
+
+// Validate ensures every weekday rule has a valid arrival time and grace period
+func (r *MissingAlertRules) Validate() error {
+	for weekday, day := range r.Days {
+		if _, ok := MissingAlertWeekdayKeys[weekday]; !ok {
+			return fmt.Errorf("invalid weekday %d", weekday)
+		}
+		if day == nil {
+			return fmt.Errorf("rule for %s is missing", MissingAlertWeekdayKeys[weekday])
+		}
+		if _, err := time.Parse("15:04", day.ExpectedArrival); err != nil {
+			return fmt.Errorf("expected arrival for %s must be in HH:MM format", MissingAlertWeekdayKeys[weekday])
+		}
+		if day.GraceMinutes < 0 || day.GraceMinutes > 240 {
+			return errors.New("grace minutes must be between 0 and 240")
+		}
+	}
+	return nil
+}
+
+// Deadline returns the time on the given day after which missing students are reported,
+// or false when no rule applies (weekends)
+func (r *MissingAlertRules) Deadline(day time.Time) (time.Time, bool) {
+	weekday := int(day.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	rule, ok := r.Days[weekday]
+	if !ok || rule == nil {
+		return time.Time{}, false
+	}
+	arrival, err := time.Parse("15:04", rule.ExpectedArrival)
+	if err != nil {
+		return time.Time{}, false
+	}
+	deadline := time.Date(day.Year(), day.Month(), day.Day(), arrival.Hour(), arrival.Minute(), 0, 0, day.Location())
+	return deadline.Add(time.Duration(rule.GraceMinutes) * time.Minute), true
+}
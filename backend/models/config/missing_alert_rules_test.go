@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingAlertRules_Validate(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		assert.NoError(t, NewDefaultMissingAlertRules().Validate())
+	})
+
+	tests := []struct {
+		name     string
+		modify   func(r *MissingAlertRules)
+		errorMsg string
+	}{
+		{"invalid arrival", func(r *MissingAlertRules) { r.Days[1].ExpectedArrival = "12 Uhr" }, "expected arrival for monday"},
+		{"negative grace", func(r *MissingAlertRules) { r.Days[3].GraceMinutes = -5 }, "grace minutes must be between"},
+		{"grace too long", func(r *MissingAlertRules) { r.Days[5].GraceMinutes = 300 }, "grace minutes must be between"},
+		{"weekend rule", func(r *MissingAlertRules) { r.Days[6] = &MissingAlertDayRule{ExpectedArrival: "10:00"} }, "invalid weekday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := NewDefaultMissingAlertRules()
+			tt.modify(rules)
+			err := rules.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestMissingAlertRules_Deadline(t *testing.T) {
+	rules := NewDefaultMissingAlertRules()
+	rules.Days[3] = &MissingAlertDayRule{ExpectedArrival: "11:45", GraceMinutes: 20}
+
+	t.Run("uses the weekday rule", func(t *testing.T) {
+		wednesday := time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)
+		deadline, ok := rules.Deadline(wednesday)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 4, 12, 5, 0, 0, time.UTC), deadline)
+	})
+
+	t.Run("default rule on monday", func(t *testing.T) {
+		monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+		deadline, ok := rules.Deadline(monday)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 2, 12, 30, 0, 0, time.UTC), deadline)
+	})
+
+	t.Run("no rule on weekends", func(t *testing.T) {
+		sunday := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+		_, ok := rules.Deadline(sunday)
+		assert.False(t, ok)
+	})
+}
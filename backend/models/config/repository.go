@@ -12,6 +12,7 @@ type SettingRepository interface {
 	Delete(ctx context.Context, id interface{}) error
 	List(ctx context.Context, filters map[string]interface{}) ([]*Setting, error)
 	FindByKey(ctx context.Context, key string) (*Setting, error)
+	FindByKeys(ctx context.Context, keys []string) ([]*Setting, error)
 	FindByCategory(ctx context.Context, category string) ([]*Setting, error)
 	FindByKeyAndCategory(ctx context.Context, key string, category string) (*Setting, error)
 	UpdateValue(ctx context.Context, key string, value string) error
@@ -0,0 +1,71 @@
+package config
+
+import "errors"
+
+// Setting keys for the working time rules (category "time_tracking")
+const (
+	SettingBreakThresholdMinutes     = "arbzg_break_threshold_minutes"
+	SettingBreakMinutes              = "arbzg_break_minutes"
+	SettingLongBreakThresholdMinutes = "arbzg_long_break_threshold_minutes"
+	SettingLongBreakMinutes          = "arbzg_long_break_minutes"
+	SettingMaxDailyMinutes           = "arbzg_max_daily_minutes"
+	SettingMinRestMinutes            = "arbzg_min_rest_minutes"
+)
+
+// WorkTimeRules holds the thresholds of the German Working Hours Act (ArbZG)
+// that work sessions are checked against
+type WorkTimeRules struct {
+	BreakThresholdMinutes     int `json:"break_threshold_minutes"`      // Working time after which a break is required (§4)
+	BreakMinutes              int `json:"break_minutes"`                // Required break after BreakThresholdMinutes
+	LongBreakThresholdMinutes int `json:"long_break_threshold_minutes"` // Working time after which the longer break is required (§4)
+	LongBreakMinutes          int `json:"long_break_minutes"`           // Required break after LongBreakThresholdMinutes
+	MaxDailyMinutes           int `json:"max_daily_minutes"`            // Maximum daily working time (§3)
+	MinRestMinutes            int `json:"min_rest_minutes"`             // Minimum uninterrupted rest between shifts (§5)
+}
+
+// NewDefaultWorkTimeRules creates the statutory ArbZG thresholds
+func NewDefaultWorkTimeRules() *WorkTimeRules {
+	return &WorkTimeRules{
+		BreakThresholdMinutes:     360, // 6 hours
+		BreakMinutes:              30,
+		LongBreakThresholdMinutes: 540, // 9 hours
+		LongBreakMinutes:          45,
+		MaxDailyMinutes:           600, // 10 hours
+		MinRestMinutes:            660, // 11 hours
+	}
+}
+
+// Validate ensures the working time rules are consistent
+func (r *WorkTimeRules) Validate() error {
+	if r.BreakThresholdMinutes <= 0 || r.LongBreakThresholdMinutes <= 0 {
+		return errors.New("break thresholds must be positive")
+	}
+	if r.BreakMinutes < 0 || r.LongBreakMinutes < 0 {
+		return errors.New("break minutes cannot be negative")
+	}
+	if r.LongBreakThresholdMinutes <= r.BreakThresholdMinutes {
+		return errors.New("long break threshold must be greater than break threshold")
+	}
+	if r.LongBreakMinutes < r.BreakMinutes {
+		return errors.New("long break cannot be shorter than break")
+	}
+	if r.MaxDailyMinutes <= 0 || r.MaxDailyMinutes > 24*60 {
+		return errors.New("max daily minutes must be between 1 and 1440")
+	}
+	if r.MinRestMinutes < 0 || r.MinRestMinutes > 24*60 {
+		return errors.New("min rest minutes must be between 0 and 1440")
+	}
+	return nil
+}
+
+// RequiredBreakMinutes returns the break required for the given net working time
+func (r *WorkTimeRules) RequiredBreakMinutes(netMinutes int) int {
+	switch {
+	case netMinutes > r.LongBreakThresholdMinutes:
+		return r.LongBreakMinutes
+	case netMinutes > r.BreakThresholdMinutes:
+		return r.BreakMinutes
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkTimeRules_Validate(t *testing.T) {
+	t.Run("defaults are valid", func(t *testing.T) {
+		assert.NoError(t, NewDefaultWorkTimeRules().Validate())
+	})
+
+	tests := []struct {
+		name     string
+		modify   func(r *WorkTimeRules)
+		errorMsg string
+	}{
+		{"zero threshold", func(r *WorkTimeRules) { r.BreakThresholdMinutes = 0 }, "break thresholds must be positive"},
+		{"negative break", func(r *WorkTimeRules) { r.BreakMinutes = -10 }, "break minutes cannot be negative"},
+		{"thresholds out of order", func(r *WorkTimeRules) { r.LongBreakThresholdMinutes = 300 }, "long break threshold must be greater"},
+		{"long break shorter", func(r *WorkTimeRules) { r.LongBreakMinutes = 15 }, "long break cannot be shorter"},
+		{"max daily too high", func(r *WorkTimeRules) { r.MaxDailyMinutes = 1500 }, "max daily minutes"},
+		{"rest negative", func(r *WorkTimeRules) { r.MinRestMinutes = -60 }, "min rest minutes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := NewDefaultWorkTimeRules()
+			tt.modify(rules)
+			err := rules.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestWorkTimeRules_RequiredBreakMinutes(t *testing.T) {
+	rules := NewDefaultWorkTimeRules()
+
+	assert.Equal(t, 0, rules.RequiredBreakMinutes(360))
+	assert.Equal(t, 30, rules.RequiredBreakMinutes(361))
+	assert.Equal(t, 30, rules.RequiredBreakMinutes(540))
+	assert.Equal(t, 45, rules.RequiredBreakMinutes(541))
+}
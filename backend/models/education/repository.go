@@ -48,6 +48,7 @@ type GroupSubstitutionRepository interface {
 	FindActiveBySubstitute(ctx context.Context, substituteStaffID int64, date time.Time) ([]*GroupSubstitution, error)
 	FindActiveByGroup(ctx context.Context, groupID int64, date time.Time) ([]*GroupSubstitution, error)
 	FindOverlapping(ctx context.Context, staffID int64, startDate time.Time, endDate time.Time) ([]*GroupSubstitution, error)
+	FindOverlappingRange(ctx context.Context, startDate time.Time, endDate time.Time) ([]*GroupSubstitution, error)
 
 	// Methods with related data loading
 	FindByIDWithRelations(ctx context.Context, id int64) (*GroupSubstitution, error)
@@ -14,8 +14,8 @@ type StudentImportRow struct {
 	ExtraInfo       string `json:"extra_info,omitempty"`
 	SupervisorNotes string `json:"supervisor_notes,omitempty"`
 	HealthInfo      string `json:"health_info,omitempty"`
-	PickupStatus    string `json:"pickup_status,omitempty"` // "Geht alleine nach Hause" or "Wird abgeholt"
-	BusPermission   bool   `json:"bus_permission"`
+	PickupStatus    string `json:"pickup_status,omitempty"`  // "Geht alleine nach Hause" or "Wird abgeholt"
+	BusPermission   *bool  `json:"bus_permission,omitempty"` // nil when the column is missing or the cell is blank
 
 	// Multiple guardians (extensible: Erz1, Erz2, Erz3, ...)
 	Guardians []GuardianImportData `json:"guardians,omitempty"`
@@ -38,15 +38,16 @@ type PhoneImportData struct {
 
 // GuardianImportData represents guardian information from CSV
 type GuardianImportData struct {
-	FirstName          string `json:"first_name,omitempty"`
-	LastName           string `json:"last_name,omitempty"`
-	Email              string `json:"email,omitempty"`
-	Phone              string `json:"phone,omitempty"`             // Deprecated: prefer PhoneNumbers
-	MobilePhone        string `json:"mobile_phone,omitempty"`      // Deprecated: prefer PhoneNumbers
-	RelationshipType   string `json:"relationship_type,omitempty"` // "Mutter", "Vater", "Oma", etc.
-	IsPrimary          bool   `json:"is_primary"`
-	IsEmergencyContact bool   `json:"is_emergency_contact"`
-	CanPickup          bool   `json:"can_pickup"`
+	FirstName        string `json:"first_name,omitempty"`
+	LastName         string `json:"last_name,omitempty"`
+	Email            string `json:"email,omitempty"`
+	Phone            string `json:"phone,omitempty"`             // Deprecated: prefer PhoneNumbers
+	MobilePhone      string `json:"mobile_phone,omitempty"`      // Deprecated: prefer PhoneNumbers
+	RelationshipType string `json:"relationship_type,omitempty"` // "Mutter", "Vater", "Oma", etc.
+	// Relationship flags are nil when the column is missing or the cell is blank
+	IsPrimary          *bool `json:"is_primary,omitempty"`
+	IsEmergencyContact *bool `json:"is_emergency_contact,omitempty"`
+	CanPickup          *bool `json:"can_pickup,omitempty"`
 	// PhoneNumbers contains flexible phone number data (from CSV columns like Erz{N}.Dienstlich)
 	PhoneNumbers []PhoneImportData `json:"phone_numbers,omitempty"`
 }
@@ -19,7 +19,7 @@ func TestStudentImportRow_DefaultValues(t *testing.T) {
 	assert.Empty(t, row.TagID)
 	assert.Empty(t, row.SchoolClass)
 	assert.Empty(t, row.GroupName)
-	assert.False(t, row.BusPermission)
+	assert.Nil(t, row.BusPermission, "not provided")
 	assert.Empty(t, row.Guardians)
 	assert.False(t, row.PrivacyAccepted)
 	assert.Zero(t, row.DataRetentionDays)
@@ -28,6 +28,7 @@ func TestStudentImportRow_DefaultValues(t *testing.T) {
 
 func TestStudentImportRow_WithValues(t *testing.T) {
 	groupID := int64(5)
+	yes := true
 	row := StudentImportRow{
 		FirstName:       "Max",
 		LastName:        "Mustermann",
@@ -39,9 +40,9 @@ func TestStudentImportRow_WithValues(t *testing.T) {
 		SupervisorNotes: "Pünktlich",
 		HealthInfo:      "Keine",
 		PickupStatus:    "Geht alleine nach Hause",
-		BusPermission:   true,
+		BusPermission:   &yes,
 		Guardians: []GuardianImportData{
-			{FirstName: "Anna", LastName: "Mustermann", RelationshipType: "Mutter", IsPrimary: true},
+			{FirstName: "Anna", LastName: "Mustermann", RelationshipType: "Mutter", IsPrimary: &yes},
 		},
 		PrivacyAccepted:   true,
 		DataRetentionDays: 30,
@@ -52,7 +53,7 @@ func TestStudentImportRow_WithValues(t *testing.T) {
 	assert.Equal(t, "2015-03-15", row.Birthday)
 	assert.Equal(t, "RFID-001", row.TagID)
 	assert.Equal(t, "1a", row.SchoolClass)
-	assert.True(t, row.BusPermission)
+	assert.True(t, *row.BusPermission)
 	assert.Len(t, row.Guardians, 1)
 	assert.True(t, row.PrivacyAccepted)
 	assert.Equal(t, 30, row.DataRetentionDays)
@@ -82,6 +83,7 @@ func TestPhoneImportData_Fields(t *testing.T) {
 // =============================================================================
 
 func TestGuardianImportData_Fields(t *testing.T) {
+	yes := true
 	guardian := GuardianImportData{
 		FirstName:          "Anna",
 		LastName:           "Mustermann",
@@ -89,9 +91,9 @@ func TestGuardianImportData_Fields(t *testing.T) {
 		Phone:              "+49 123 456",
 		MobilePhone:        "+49 171 456",
 		RelationshipType:   "Mutter",
-		IsPrimary:          true,
-		IsEmergencyContact: true,
-		CanPickup:          true,
+		IsPrimary:          &yes,
+		IsEmergencyContact: &yes,
+		CanPickup:          &yes,
 		PhoneNumbers: []PhoneImportData{
 			{PhoneNumber: "+49 123 456", PhoneType: "home", IsPrimary: true},
 		},
@@ -100,9 +102,9 @@ func TestGuardianImportData_Fields(t *testing.T) {
 	assert.Equal(t, "Anna", guardian.FirstName)
 	assert.Equal(t, "anna@example.com", guardian.Email)
 	assert.Equal(t, "Mutter", guardian.RelationshipType)
-	assert.True(t, guardian.IsPrimary)
-	assert.True(t, guardian.IsEmergencyContact)
-	assert.True(t, guardian.CanPickup)
+	assert.True(t, *guardian.IsPrimary)
+	assert.True(t, *guardian.IsEmergencyContact)
+	assert.True(t, *guardian.CanPickup)
 	assert.Len(t, guardian.PhoneNumbers, 1)
 }
 
@@ -114,6 +116,6 @@ func TestGuardianImportData_MinimalFields(t *testing.T) {
 
 	assert.Equal(t, "Peter", guardian.FirstName)
 	assert.Empty(t, guardian.Email)
-	assert.False(t, guardian.IsPrimary)
+	assert.Nil(t, guardian.IsPrimary)
 	assert.Empty(t, guardian.PhoneNumbers)
 }
@@ -26,6 +26,13 @@ type ImportConfig[T any] interface {
 	EntityName() string
 }
 
+// ImportDiffer is implemented by configs that can describe what an update changes.
+// The import service uses it to report per-row diffs and to skip unchanged rows.
+type ImportDiffer[T any] interface {
+	// Diff returns the field changes that updating the entity with the row would apply
+	Diff(ctx context.Context, id int64, row T) ([]FieldChange, error)
+}
+
 // ImportMode defines how to handle existing records
 type ImportMode string
 
@@ -66,9 +73,24 @@ type ImportResult[T any] struct {
 	WarningCount int
 	Errors       []ImportError[T]
 	BulkActions  []BulkAction // Suggested bulk corrections
+	Diffs        []RowDiff    // Field changes for updated rows (planned changes in dry-run)
 	DryRun       bool
 }
 
+// RowDiff lists the changes applied to one existing entity
+type RowDiff struct {
+	RowNumber int           `json:"row_number"`
+	EntityID  int64         `json:"entity_id"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// FieldChange describes a single field going from its stored value to the imported value
+type FieldChange struct {
+	Field    string `json:"field"`     // e.g., "school_class", "guardian_1_phone"
+	OldValue string `json:"old_value"` // Empty when the value is added
+	NewValue string `json:"new_value"`
+}
+
 // ImportError captures per-row failures
 type ImportError[T any] struct {
 	RowNumber int // CSV row number (1-indexed, excludes header)
@@ -34,6 +34,10 @@ type Device struct {
 	LastSeen       *time.Time   `bun:"last_seen" json:"last_seen,omitempty"` // Used as last_activity for health monitoring
 	RegisteredByID *int64       `bun:"registered_by_id" json:"registered_by_id,omitempty"`
 
+	// Key rotation: the previous key keeps working until PreviousAPIKeyExpiresAt
+	PreviousAPIKey          *string    `bun:"previous_api_key,unique" json:"-"`
+	PreviousAPIKeyExpiresAt *time.Time `bun:"previous_api_key_expires_at" json:"previous_api_key_expires_at,omitempty"`
+
 	// Relations
 	RegisteredBy *users.Person `bun:"-" json:"registered_by,omitempty"`
 }
@@ -151,3 +155,9 @@ func (m *Device) GetUpdatedAt() time.Time {
 func (d *Device) HasAPIKey() bool {
 	return d.APIKey != nil && *d.APIKey != ""
 }
+
+// HasValidPreviousAPIKey returns true if a rotated-out key is still inside its grace period
+func (d *Device) HasValidPreviousAPIKey(now time.Time) bool {
+	return d.PreviousAPIKey != nil && *d.PreviousAPIKey != "" &&
+		d.PreviousAPIKeyExpiresAt != nil && now.Before(*d.PreviousAPIKeyExpiresAt)
+}
@@ -0,0 +1,83 @@
+package iot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// tableIoTDeviceEnrollments is the schema-qualified table name for enrollment codes
+const tableIoTDeviceEnrollments = "iot.device_enrollments"
+
+// DeviceEnrollment is a one-time code that a new device exchanges for its API key.
+// Only the SHA-256 hash of the code is stored.
+type DeviceEnrollment struct {
+	base.Model         `bun:"schema:iot,table:device_enrollments"`
+	CodeHash           string     `bun:"code_hash,notnull,unique" json:"-"`
+	DeviceID           string     `bun:"device_id,notnull" json:"device_id"`
+	DeviceType         string     `bun:"device_type,notnull" json:"device_type"`
+	Name               *string    `bun:"name" json:"name,omitempty"`
+	CreatedByAccountID *int64     `bun:"created_by_account_id" json:"created_by_account_id,omitempty"`
+	ExpiresAt          time.Time  `bun:"expires_at,notnull" json:"expires_at"`
+	UsedAt             *time.Time `bun:"used_at" json:"used_at,omitempty"`
+	EnrolledDeviceID   *int64     `bun:"enrolled_device_id" json:"enrolled_device_id,omitempty"`
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (e *DeviceEnrollment) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableIoTDeviceEnrollments)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableIoTDeviceEnrollments)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(tableIoTDeviceEnrollments)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (e *DeviceEnrollment) TableName() string {
+	return tableIoTDeviceEnrollments
+}
+
+// Validate ensures the enrollment data is valid
+func (e *DeviceEnrollment) Validate() error {
+	if e.CodeHash == "" {
+		return errors.New("code hash is required")
+	}
+	if e.DeviceID == "" {
+		return errors.New("device ID is required")
+	}
+	if e.DeviceType == "" {
+		return errors.New("device type is required")
+	}
+	if e.ExpiresAt.IsZero() {
+		return errors.New("expiry is required")
+	}
+	return nil
+}
+
+// IsPending reports whether the code is unused and not yet expired
+func (e *DeviceEnrollment) IsPending(now time.Time) bool {
+	return e.UsedAt == nil && now.Before(e.ExpiresAt)
+}
+
+// NormalizeEnrollmentCode upper-cases a code and strips separators, so "abcd-efgh"
+// and "ABCDEFGH" are the same code
+func NormalizeEnrollmentCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// HashEnrollmentCode returns the hex SHA-256 of the normalized code
+func HashEnrollmentCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeEnrollmentCode(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package iot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashEnrollmentCode_Normalizes(t *testing.T) {
+	want := HashEnrollmentCode("ABCD-EFGH")
+	for _, code := range []string{"abcd-efgh", "ABCDEFGH", " abcd efgh "} {
+		if got := HashEnrollmentCode(code); got != want {
+			t.Errorf("HashEnrollmentCode(%q) differs from canonical form", code)
+		}
+	}
+	if HashEnrollmentCode("ABCD-EFGJ") == want {
+		t.Error("different codes must not share a hash")
+	}
+	if len(want) != 64 {
+		t.Errorf("expected hex SHA-256, got %d chars", len(want))
+	}
+}
+
+func TestDeviceEnrollment_IsPending(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		enrollment DeviceEnrollment
+		want       bool
+	}{
+		{"Unused and valid", DeviceEnrollment{ExpiresAt: now.Add(time.Minute)}, true},
+		{"Expired", DeviceEnrollment{ExpiresAt: now.Add(-time.Second)}, false},
+		{"Already used", DeviceEnrollment{ExpiresAt: now.Add(time.Minute), UsedAt: &used}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.enrollment.IsPending(now); got != tt.want {
+				t.Errorf("IsPending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceEnrollment_Validate(t *testing.T) {
+	valid := DeviceEnrollment{CodeHash: "hash", DeviceID: "reader-1", DeviceType: "rfid_reader", ExpiresAt: time.Now()}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	missingType := valid
+	missingType.DeviceType = ""
+	if err := missingType.Validate(); err == nil {
+		t.Error("expected error for missing device type")
+	}
+
+	missingExpiry := valid
+	missingExpiry.ExpiresAt = time.Time{}
+	if err := missingExpiry.Validate(); err == nil {
+		t.Error("expected error for missing expiry")
+	}
+}
+
+func TestDevice_HasValidPreviousAPIKey(t *testing.T) {
+	now := time.Now()
+	key := "dev_old"
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	if !(&Device{PreviousAPIKey: &key, PreviousAPIKeyExpiresAt: &future}).HasValidPreviousAPIKey(now) {
+		t.Error("key inside grace period should be valid")
+	}
+	if (&Device{PreviousAPIKey: &key, PreviousAPIKeyExpiresAt: &past}).HasValidPreviousAPIKey(now) {
+		t.Error("expired grace period should not be valid")
+	}
+	if (&Device{}).HasValidPreviousAPIKey(now) {
+		t.Error("device without previous key should not be valid")
+	}
+}
@@ -0,0 +1,117 @@
+package iot
+
+import (
+	"errors"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// tableIoTDeviceEvents is the schema-qualified table name for device status history
+const tableIoTDeviceEvents = "iot.device_events"
+
+// DeviceTypeVirtual marks devices that never send heartbeats (e.g. the web check-in device)
+const DeviceTypeVirtual = "virtual"
+
+// Reasons for a device status transition
+const (
+	DeviceEventReasonHeartbeatTimeout = "heartbeat_timeout"
+	DeviceEventReasonHeartbeatResumed = "heartbeat_resumed"
+	DeviceEventReasonManual           = "manual"
+)
+
+// DeviceEvent records a status transition of an IoT device
+type DeviceEvent struct {
+	base.Model `bun:"schema:iot,table:device_events"`
+	DeviceID   int64        `bun:"device_id,notnull" json:"device_id"`
+	FromStatus DeviceStatus `bun:"from_status,notnull" json:"from_status"`
+	ToStatus   DeviceStatus `bun:"to_status,notnull" json:"to_status"`
+	Reason     string       `bun:"reason,notnull" json:"reason"`
+	LastSeen   *time.Time   `bun:"last_seen" json:"last_seen,omitempty"`
+	OccurredAt time.Time    `bun:"occurred_at,notnull" json:"occurred_at"`
+}
+
+// NewDeviceEvent creates a transition event for the device's current status
+func NewDeviceEvent(device *Device, toStatus DeviceStatus, reason string) *DeviceEvent {
+	return &DeviceEvent{
+		DeviceID:   device.ID,
+		FromStatus: device.Status,
+		ToStatus:   toStatus,
+		Reason:     reason,
+		LastSeen:   device.LastSeen,
+		OccurredAt: time.Now(),
+	}
+}
+
+// BeforeAppendModel implements the model hook for schema-qualified queries
+func (e *DeviceEvent) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(tableIoTDeviceEvents)
+	}
+	if q, ok := query.(*bun.InsertQuery); ok {
+		q.ModelTableExpr(tableIoTDeviceEvents)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(tableIoTDeviceEvents)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (e *DeviceEvent) TableName() string {
+	return tableIoTDeviceEvents
+}
+
+// Validate ensures the event data is valid
+func (e *DeviceEvent) Validate() error {
+	if e.DeviceID <= 0 {
+		return errors.New("device ID is required")
+	}
+	if !isValidDeviceStatus(e.FromStatus) || !isValidDeviceStatus(e.ToStatus) {
+		return errors.New("invalid device status")
+	}
+	if e.FromStatus == e.ToStatus {
+		return errors.New("event must change the device status")
+	}
+	if e.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if e.OccurredAt.IsZero() {
+		return errors.New("occurred at is required")
+	}
+	return nil
+}
+
+// CalculateUptime returns the share (0-100) of [from, to) the device was not offline.
+// initial is the device status at from; events must be sorted by OccurredAt.
+func CalculateUptime(initial DeviceStatus, events []*DeviceEvent, from, to time.Time) float64 {
+	total := to.Sub(from)
+	if total <= 0 {
+		return 0
+	}
+
+	var offline time.Duration
+	status := initial
+	cursor := from
+	for _, event := range events {
+		at := event.OccurredAt
+		if at.Before(from) {
+			status = event.ToStatus
+			continue
+		}
+		if !at.Before(to) {
+			break
+		}
+		if status == DeviceStatusOffline {
+			offline += at.Sub(cursor)
+		}
+		status = event.ToStatus
+		cursor = at
+	}
+	if status == DeviceStatusOffline {
+		offline += to.Sub(cursor)
+	}
+
+	return float64(total-offline) / float64(total) * 100
+}
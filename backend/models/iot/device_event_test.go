@@ -0,0 +1,120 @@
+package iot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeviceEvent_Validate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		event   DeviceEvent
+		wantErr bool
+	}{
+		{
+			name:  "Valid event",
+			event: DeviceEvent{DeviceID: 1, FromStatus: DeviceStatusActive, ToStatus: DeviceStatusOffline, Reason: DeviceEventReasonHeartbeatTimeout, OccurredAt: now},
+		},
+		{
+			name:    "Missing device",
+			event:   DeviceEvent{FromStatus: DeviceStatusActive, ToStatus: DeviceStatusOffline, Reason: DeviceEventReasonHeartbeatTimeout, OccurredAt: now},
+			wantErr: true,
+		},
+		{
+			name:    "No status change",
+			event:   DeviceEvent{DeviceID: 1, FromStatus: DeviceStatusOffline, ToStatus: DeviceStatusOffline, Reason: DeviceEventReasonManual, OccurredAt: now},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid status",
+			event:   DeviceEvent{DeviceID: 1, FromStatus: DeviceStatusActive, ToStatus: "broken", Reason: DeviceEventReasonManual, OccurredAt: now},
+			wantErr: true,
+		},
+		{
+			name:    "Missing reason",
+			event:   DeviceEvent{DeviceID: 1, FromStatus: DeviceStatusActive, ToStatus: DeviceStatusOffline, OccurredAt: now},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeviceEvent.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewDeviceEvent(t *testing.T) {
+	lastSeen := time.Now().Add(-10 * time.Minute)
+	device := &Device{DeviceID: "dev-001", Status: DeviceStatusActive, LastSeen: &lastSeen}
+	device.ID = 7
+
+	event := NewDeviceEvent(device, DeviceStatusOffline, DeviceEventReasonHeartbeatTimeout)
+
+	if event.DeviceID != device.ID || event.FromStatus != DeviceStatusActive || event.ToStatus != DeviceStatusOffline {
+		t.Errorf("NewDeviceEvent() = %+v, unexpected transition", event)
+	}
+	if event.LastSeen != &lastSeen {
+		t.Error("NewDeviceEvent() should keep the device's last seen time")
+	}
+}
+
+func TestCalculateUptime(t *testing.T) {
+	from := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	at := func(hours int) time.Time { return from.Add(time.Duration(hours) * time.Hour) }
+	transition := func(when time.Time, toStatus DeviceStatus) *DeviceEvent {
+		return &DeviceEvent{OccurredAt: when, ToStatus: toStatus}
+	}
+
+	tests := []struct {
+		name    string
+		initial DeviceStatus
+		events  []*DeviceEvent
+		want    float64
+	}{
+		{name: "Always online", initial: DeviceStatusActive, want: 100},
+		{name: "Always offline", initial: DeviceStatusOffline, want: 0},
+		{
+			name:    "Offline for two hours",
+			initial: DeviceStatusActive,
+			events:  []*DeviceEvent{transition(at(2), DeviceStatusOffline), transition(at(4), DeviceStatusActive)},
+			want:    80,
+		},
+		{
+			name:    "Offline until the end of the window",
+			initial: DeviceStatusActive,
+			events:  []*DeviceEvent{transition(at(5), DeviceStatusOffline)},
+			want:    50,
+		},
+		{
+			name:    "Recovered after starting offline",
+			initial: DeviceStatusOffline,
+			events:  []*DeviceEvent{transition(at(1), DeviceStatusActive)},
+			want:    90,
+		},
+		{
+			name:    "Maintenance counts as online",
+			initial: DeviceStatusActive,
+			events:  []*DeviceEvent{transition(at(3), DeviceStatusMaintenance)},
+			want:    100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateUptime(tt.initial, tt.events, from, to)
+			if got != tt.want {
+				t.Errorf("CalculateUptime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := CalculateUptime(DeviceStatusActive, nil, to, from); got != 0 {
+		t.Errorf("CalculateUptime() with empty window = %v, want 0", got)
+	}
+}
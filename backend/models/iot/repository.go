@@ -22,6 +22,11 @@ type DeviceRepository interface {
 	FindByRegisteredBy(ctx context.Context, personID int64) ([]*Device, error)
 	UpdateLastSeen(ctx context.Context, deviceID string, lastSeen time.Time) error
 	UpdateStatus(ctx context.Context, deviceID string, status DeviceStatus) error
+	// MarkOfflineIfStale sets an active device offline only if it has not been seen since cutoff.
+	// Returns false when a heartbeat arrived in the meantime or the device is no longer active.
+	MarkOfflineIfStale(ctx context.Context, deviceID string, cutoff time.Time) (bool, error)
+	// UpdateAPIKeys sets the current key and the rotated-out key with its grace period expiry
+	UpdateAPIKeys(ctx context.Context, id int64, apiKey, previousAPIKey *string, previousExpiresAt *time.Time) error
 
 	// Specialized queries
 	FindActiveDevices(ctx context.Context) ([]*Device, error)
@@ -29,3 +34,23 @@ type DeviceRepository interface {
 	FindOfflineDevices(ctx context.Context, offlineSince time.Duration) ([]*Device, error)
 	CountDevicesByType(ctx context.Context) (map[string]int, error)
 }
+
+// DeviceEventRepository defines operations for the device status history
+type DeviceEventRepository interface {
+	Create(ctx context.Context, event *DeviceEvent) error
+	// FindByDeviceID returns the device's events since the given time, oldest first
+	FindByDeviceID(ctx context.Context, deviceID int64, since time.Time) ([]*DeviceEvent, error)
+	// FindLatestBefore returns the last event before the given time, or nil if there is none
+	FindLatestBefore(ctx context.Context, deviceID int64, before time.Time) (*DeviceEvent, error)
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// DeviceEnrollmentRepository defines operations for one-time device enrollment codes
+type DeviceEnrollmentRepository interface {
+	Create(ctx context.Context, enrollment *DeviceEnrollment) error
+	// FindPending returns unused codes that have not expired at now, newest first
+	FindPending(ctx context.Context, now time.Time) ([]*DeviceEnrollment, error)
+	// Claim marks a pending code as used and returns it, or nil if no pending code has this hash
+	Claim(ctx context.Context, codeHash string, now time.Time) (*DeviceEnrollment, error)
+	SetEnrolledDevice(ctx context.Context, id, deviceID int64) error
+}
@@ -0,0 +1,198 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// BusRoute represents a school bus line that bus children ("Buskinder") leave with
+type BusRoute struct {
+	base.Model `bun:"schema:schedule,table:bus_routes"`
+
+	Name        string  `bun:"name,notnull" json:"name"`
+	Description *string `bun:"description" json:"description,omitempty"`
+	IsActive    bool    `bun:"is_active,notnull" json:"is_active"`
+	CreatedBy   int64   `bun:"created_by,notnull" json:"created_by"`
+
+	// Loaded by the service, not stored on the route row
+	Departures []*BusRouteDeparture `bun:"-" json:"departures,omitempty"`
+	StudentIDs []int64              `bun:"-" json:"student_ids,omitempty"`
+}
+
+func (r *BusRoute) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(`schedule.bus_routes AS "bus_route"`)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(`schedule.bus_routes AS "bus_route"`)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(`schedule.bus_routes AS "bus_route"`)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (r *BusRoute) TableName() string {
+	return "schedule.bus_routes"
+}
+
+// Validate ensures bus route data is valid
+func (r *BusRoute) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Name) > 100 {
+		return errors.New("name cannot exceed 100 characters")
+	}
+	if r.CreatedBy <= 0 {
+		return errors.New(errMsgCreatedByRequired)
+	}
+	return nil
+}
+
+// DepartureOn returns the departure of the route on the given weekday, nil if the bus does not run
+func (r *BusRoute) DepartureOn(weekday int) *BusRouteDeparture {
+	for _, d := range r.Departures {
+		if d.Weekday == weekday {
+			return d
+		}
+	}
+	return nil
+}
+
+// GetID implements the Entity interface
+func (r *BusRoute) GetID() any {
+	return r.ID
+}
+
+// GetCreatedAt implements the Entity interface
+func (r *BusRoute) GetCreatedAt() time.Time {
+	return r.CreatedAt
+}
+
+// GetUpdatedAt implements the Entity interface
+func (r *BusRoute) GetUpdatedAt() time.Time {
+	return r.UpdatedAt
+}
+
+// BusRouteDeparture is the departure time of a bus route on one weekday
+type BusRouteDeparture struct {
+	base.Model `bun:"schema:schedule,table:bus_route_departures"`
+
+	BusRouteID    int64     `bun:"bus_route_id,notnull" json:"bus_route_id"`
+	Weekday       int       `bun:"weekday,notnull" json:"weekday"`
+	DepartureTime time.Time `bun:"departure_time,notnull" json:"departure_time"`
+}
+
+func (d *BusRouteDeparture) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(`schedule.bus_route_departures AS "bus_route_departure"`)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(`schedule.bus_route_departures AS "bus_route_departure"`)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(`schedule.bus_route_departures AS "bus_route_departure"`)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (d *BusRouteDeparture) TableName() string {
+	return "schedule.bus_route_departures"
+}
+
+// Validate ensures departure data is valid
+func (d *BusRouteDeparture) Validate() error {
+	if d.Weekday < WeekdayMonday || d.Weekday > WeekdayFriday {
+		return errors.New("weekday must be between 1 (Monday) and 5 (Friday)")
+	}
+	if d.DepartureTime.IsZero() {
+		return errors.New("departure_time is required")
+	}
+	return nil
+}
+
+// GetWeekdayName returns the German name for this departure's weekday
+func (d *BusRouteDeparture) GetWeekdayName() string {
+	if name, ok := WeekdayNames[d.Weekday]; ok {
+		return name
+	}
+	return ""
+}
+
+// GetID implements the Entity interface
+func (d *BusRouteDeparture) GetID() any {
+	return d.ID
+}
+
+// GetCreatedAt implements the Entity interface
+func (d *BusRouteDeparture) GetCreatedAt() time.Time {
+	return d.CreatedAt
+}
+
+// GetUpdatedAt implements the Entity interface
+func (d *BusRouteDeparture) GetUpdatedAt() time.Time {
+	return d.UpdatedAt
+}
+
+// BusRouteStudent assigns a bus child to a route; a student rides at most one route
+type BusRouteStudent struct {
+	base.Model `bun:"schema:schedule,table:bus_route_students"`
+
+	BusRouteID int64 `bun:"bus_route_id,notnull" json:"bus_route_id"`
+	StudentID  int64 `bun:"student_id,notnull" json:"student_id"`
+}
+
+func (s *BusRouteStudent) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(`schedule.bus_route_students AS "bus_route_student"`)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(`schedule.bus_route_students AS "bus_route_student"`)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (s *BusRouteStudent) TableName() string {
+	return "schedule.bus_route_students"
+}
+
+// GetID implements the Entity interface
+func (s *BusRouteStudent) GetID() any {
+	return s.ID
+}
+
+// GetCreatedAt implements the Entity interface
+func (s *BusRouteStudent) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt implements the Entity interface
+func (s *BusRouteStudent) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// BusRouteRepository defines operations for managing bus routes with their departures and assigned students
+type BusRouteRepository interface {
+	base.Repository[*BusRoute]
+
+	// FindDeparturesByRouteIDs returns the departures of the given routes, ordered by weekday
+	FindDeparturesByRouteIDs(ctx context.Context, routeIDs []int64) ([]*BusRouteDeparture, error)
+
+	// ReplaceDepartures replaces all departures of a route
+	ReplaceDepartures(ctx context.Context, routeID int64, departures []*BusRouteDeparture) error
+
+	// FindStudentsByRouteIDs returns the student assignments of the given routes
+	FindStudentsByRouteIDs(ctx context.Context, routeIDs []int64) ([]*BusRouteStudent, error)
+
+	// ReplaceStudents replaces the students of a route; students assigned to another route are moved
+	ReplaceStudents(ctx context.Context, routeID int64, studentIDs []int64) error
+}
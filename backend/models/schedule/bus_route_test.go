@@ -0,0 +1,72 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusRoute_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   *BusRoute
+		wantErr string
+	}{
+		{
+			name:  "valid route",
+			route: &BusRoute{Name: "Linie 12 Nord", IsActive: true, CreatedBy: 10},
+		},
+		{
+			name:    "blank name",
+			route:   &BusRoute{Name: "   ", CreatedBy: 10},
+			wantErr: "name is required",
+		},
+		{
+			name:    "name too long",
+			route:   &BusRoute{Name: strings.Repeat("x", 101), CreatedBy: 10},
+			wantErr: "name cannot exceed 100 characters",
+		},
+		{
+			name:    "missing created_by",
+			route:   &BusRoute{Name: "Linie 12 Nord"},
+			wantErr: errMsgCreatedByRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.route.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBusRouteDeparture_Validate(t *testing.T) {
+	departure := time.Date(2024, 1, 1, 15, 40, 0, 0, time.UTC)
+
+	assert.NoError(t, (&BusRouteDeparture{Weekday: WeekdayFriday, DepartureTime: departure}).Validate())
+	assert.Error(t, (&BusRouteDeparture{Weekday: WeekdaySaturday, DepartureTime: departure}).Validate())
+	assert.Error(t, (&BusRouteDeparture{Weekday: WeekdayMonday}).Validate())
+}
+
+func TestBusRoute_DepartureOn(t *testing.T) {
+	route := &BusRoute{Departures: []*BusRouteDeparture{
+		{Weekday: WeekdayMonday, DepartureTime: time.Date(2024, 1, 1, 15, 40, 0, 0, time.UTC)},
+		{Weekday: WeekdayFriday, DepartureTime: time.Date(2024, 1, 1, 13, 10, 0, 0, time.UTC)},
+	}}
+
+	friday := route.DepartureOn(WeekdayFriday)
+	require.NotNil(t, friday)
+	assert.Equal(t, 13, friday.DepartureTime.Hour())
+	assert.Equal(t, "Freitag", friday.GetWeekdayName())
+
+	assert.Nil(t, route.DepartureOn(WeekdayWednesday))
+}
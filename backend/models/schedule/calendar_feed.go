@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// CalendarFeed is a tokenized, read-only iCalendar subscription. Without an activity group it
+// publishes the owner's supervision plan (planned supervisions and substitutions), otherwise the
+// sessions of the activity group. Only the SHA-256 of the token is stored; the token itself is
+// shown once when the feed is created.
+type CalendarFeed struct {
+	base.Model `bun:"schema:schedule,table:calendar_feeds"`
+
+	StaffID         int64      `bun:"staff_id,notnull" json:"staff_id"`                     // Owner of the subscription
+	ActivityGroupID *int64     `bun:"activity_group_id" json:"activity_group_id,omitempty"` // nil publishes the owner's supervision plan
+	Name            string     `bun:"name,notnull" json:"name"`                             // Label shown in the owner's feed list
+	TokenHash       string     `bun:"token_hash,notnull" json:"-"`                          // Hex SHA-256 of the token
+	LastAccessedAt  *time.Time `bun:"last_accessed_at" json:"last_accessed_at,omitempty"`   // Last fetch by a calendar app
+	RevokedAt       *time.Time `bun:"revoked_at" json:"revoked_at,omitempty"`               // Revoked feeds answer 404
+}
+
+func (f *CalendarFeed) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(`schedule.calendar_feeds AS "calendar_feed"`)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(`schedule.calendar_feeds AS "calendar_feed"`)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(`schedule.calendar_feeds AS "calendar_feed"`)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (f *CalendarFeed) TableName() string {
+	return "schedule.calendar_feeds"
+}
+
+// Validate ensures calendar feed data is valid
+func (f *CalendarFeed) Validate() error {
+	if f.StaffID <= 0 {
+		return errors.New("staff ID is required")
+	}
+	if f.ActivityGroupID != nil && *f.ActivityGroupID <= 0 {
+		return errors.New("invalid activity group ID")
+	}
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(f.Name) > 100 {
+		return errors.New("name cannot exceed 100 characters")
+	}
+	if f.TokenHash == "" {
+		return errors.New("token hash is required")
+	}
+	return nil
+}
+
+// IsStaffFeed returns true if the feed publishes the owner's supervision plan
+func (f *CalendarFeed) IsStaffFeed() bool {
+	return f.ActivityGroupID == nil
+}
+
+// IsRevoked returns true if the feed can no longer be fetched
+func (f *CalendarFeed) IsRevoked() bool {
+	return f.RevokedAt != nil
+}
+
+// Revoke disables the feed; calendar apps subscribed to it stop receiving updates
+func (f *CalendarFeed) Revoke(at time.Time) {
+	f.RevokedAt = &at
+}
+
+// HashFeedToken returns the hex SHA-256 of a feed token
+func HashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetID implements the Entity interface
+func (f *CalendarFeed) GetID() any {
+	return f.ID
+}
+
+// GetCreatedAt implements the Entity interface
+func (f *CalendarFeed) GetCreatedAt() time.Time {
+	return f.CreatedAt
+}
+
+// GetUpdatedAt implements the Entity interface
+func (f *CalendarFeed) GetUpdatedAt() time.Time {
+	return f.UpdatedAt
+}
+
+// CalendarFeedRepository defines operations for managing calendar feed subscriptions
+type CalendarFeedRepository interface {
+	base.Repository[*CalendarFeed]
+
+	// FindByTokenHash finds a feed by the hash of its token; returns nil if none exists
+	FindByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeed, error)
+
+	// FindByStaffID returns the feeds owned by a staff member, newest first
+	FindByStaffID(ctx context.Context, staffID int64) ([]*CalendarFeed, error)
+
+	// TouchLastAccessed records when a calendar app last fetched the feed
+	TouchLastAccessed(ctx context.Context, id int64, at time.Time) error
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeed_Validate(t *testing.T) {
+	groupID := int64(30)
+	invalidGroupID := int64(0)
+	hash := HashFeedToken("cal_token")
+
+	tests := []struct {
+		name    string
+		feed    *CalendarFeed
+		wantErr string
+	}{
+		{
+			name: "staff feed",
+			feed: &CalendarFeed{StaffID: 10, Name: "Mein Aufsichtsplan", TokenHash: hash},
+		},
+		{
+			name: "activity group feed",
+			feed: &CalendarFeed{StaffID: 10, ActivityGroupID: &groupID, Name: "Fußball-AG", TokenHash: hash},
+		},
+		{
+			name:    "missing owner",
+			feed:    &CalendarFeed{Name: "Mein Aufsichtsplan", TokenHash: hash},
+			wantErr: "staff ID is required",
+		},
+		{
+			name:    "invalid activity group",
+			feed:    &CalendarFeed{StaffID: 10, ActivityGroupID: &invalidGroupID, Name: "AG", TokenHash: hash},
+			wantErr: "invalid activity group ID",
+		},
+		{
+			name:    "blank name",
+			feed:    &CalendarFeed{StaffID: 10, Name: "  ", TokenHash: hash},
+			wantErr: "name is required",
+		},
+		{
+			name:    "missing token",
+			feed:    &CalendarFeed{StaffID: 10, Name: "Mein Aufsichtsplan"},
+			wantErr: "token hash is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.feed.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestCalendarFeed_Revoke(t *testing.T) {
+	feed := &CalendarFeed{StaffID: 10, Name: "Mein Aufsichtsplan", TokenHash: HashFeedToken("cal_token")}
+	assert.True(t, feed.IsStaffFeed())
+	assert.False(t, feed.IsRevoked())
+
+	feed.Revoke(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	assert.True(t, feed.IsRevoked())
+}
+
+func TestHashFeedToken(t *testing.T) {
+	assert.Len(t, HashFeedToken("cal_token"), 64)
+	assert.Equal(t, HashFeedToken("cal_token"), HashFeedToken(" cal_token "))
+	assert.NotEqual(t, HashFeedToken("cal_token"), HashFeedToken("cal_other"))
+}
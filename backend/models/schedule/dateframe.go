@@ -1,9 +1,12 @@
 package schedule
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/moto-nrw/project-phoenix/internal/holidays"
 	"github.com/moto-nrw/project-phoenix/models/base"
 	"github.com/uptrace/bun"
 )
@@ -11,6 +14,13 @@ import (
 // tableScheduleDateframes is the schema-qualified table name for dateframes
 const tableScheduleDateframes = "schedule.dateframes"
 
+// Dateframe kinds
+const (
+	DateframeKindTerm    = "term"    // School term or planning period (default)
+	DateframeKindHoliday = "holiday" // School holidays (Schulferien); no regular school days
+	DateframeKindClosure = "closure" // Single closure days (Schließtage, bewegliche Ferientage)
+)
+
 // Dateframe represents a date range for scheduling
 type Dateframe struct {
 	base.Model  `bun:"schema:schedule,table:dateframes"`
@@ -18,6 +28,12 @@ type Dateframe struct {
 	EndDate     time.Time `bun:"end_date,notnull" json:"end_date"`
 	Name        string    `bun:"name" json:"name,omitempty"`
 	Description string    `bun:"description" json:"description,omitempty"`
+	Kind        string    `bun:"kind,notnull,default:'term'" json:"kind"`
+	ExternalUID *string   `bun:"external_uid" json:"external_uid,omitempty"` // iCalendar UID of imported holidays; re-imports update instead of duplicating
+
+	// Optional enrollment window for activity groups running in this dateframe; nil bounds are open
+	EnrollmentOpensAt  *time.Time `bun:"enrollment_opens_at" json:"enrollment_opens_at,omitempty"`
+	EnrollmentClosesAt *time.Time `bun:"enrollment_closes_at" json:"enrollment_closes_at,omitempty"`
 }
 
 func (d *Dateframe) BeforeAppendModel(query any) error {
@@ -52,9 +68,101 @@ func (d *Dateframe) Validate() error {
 		return errors.New("end date must be on or after start date")
 	}
 
+	if d.EnrollmentOpensAt != nil && d.EnrollmentClosesAt != nil && !d.EnrollmentClosesAt.After(*d.EnrollmentOpensAt) {
+		return errors.New("enrollment window must close after it opens")
+	}
+
+	if d.Kind == "" {
+		d.Kind = DateframeKindTerm
+	}
+	if !IsValidDateframeKind(d.Kind) {
+		return errors.New("invalid dateframe kind")
+	}
+
+	return nil
+}
+
+// IsValidDateframeKind checks if the kind is a known dateframe kind
+func IsValidDateframeKind(kind string) bool {
+	switch kind {
+	case DateframeKindTerm, DateframeKindHoliday, DateframeKindClosure:
+		return true
+	}
+	return false
+}
+
+// IsClosure returns true for holidays and closure days, on which no regular school day takes place
+func (d *Dateframe) IsClosure() bool {
+	return d.Kind == DateframeKindHoliday || d.Kind == DateframeKindClosure
+}
+
+// CoversDate checks if the calendar date of day (in day's location) lies within the dateframe.
+// Start and end dates are calendar dates stored as midnight UTC.
+func (d *Dateframe) CoversDate(day time.Time) bool {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	start := d.StartDate.UTC()
+	end := d.EndDate.UTC()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return !date.Before(start) && !date.After(end)
+}
+
+// ClosureOn returns the first holiday or closure dateframe covering the calendar date of day, or nil
+func ClosureOn(dateframes []*Dateframe, day time.Time) *Dateframe {
+	for _, d := range dateframes {
+		if d.IsClosure() && d.CoversDate(day) {
+			return d
+		}
+	}
 	return nil
 }
 
+// ClosureDays expands the holiday and closure dateframes into the days between from and to
+// (inclusive) they cover, keyed by midnight UTC like holidays.InRange, with the dateframe name
+func ClosureDays(dateframes []*Dateframe, from, to time.Time) map[time.Time]string {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	result := make(map[time.Time]string)
+	for _, d := range dateframes {
+		if !d.IsClosure() {
+			continue
+		}
+		start := d.StartDate.UTC()
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		if start.Before(from) {
+			start = from
+		}
+		for day := start; !day.After(to) && d.CoversDate(day); day = day.AddDate(0, 0, 1) {
+			if _, ok := result[day]; !ok {
+				result[day] = d.Name
+			}
+		}
+	}
+	return result
+}
+
+// SchoolClosureDays returns the days between from and to (inclusive) without regular school: NRW
+// public holidays plus the holiday and closure dateframes, keyed by midnight UTC with their name.
+// Without a dateframe repository only the public holidays are returned.
+func SchoolClosureDays(ctx context.Context, repo DateframeRepository, from, to time.Time) (map[time.Time]string, error) {
+	days := holidays.InRange(from, to)
+	if repo == nil {
+		return days, nil
+	}
+
+	closures, err := repo.FindClosures(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closures: %w", err)
+	}
+	for day, name := range ClosureDays(closures, from, to) {
+		if _, ok := days[day]; !ok {
+			days[day] = name
+		}
+	}
+	return days, nil
+}
+
 // Duration returns the duration of the dateframe
 func (d *Dateframe) Duration() time.Duration {
 	return d.EndDate.Sub(d.StartDate)
@@ -77,6 +185,17 @@ func (d *Dateframe) Contains(checkDate time.Time) bool {
 		(normalizedCheck.Equal(normalizedEnd) || normalizedCheck.Before(normalizedEnd))
 }
 
+// IsEnrollmentOpen checks if enrollments are accepted at the given time
+func (d *Dateframe) IsEnrollmentOpen(at time.Time) bool {
+	if d.EnrollmentOpensAt != nil && at.Before(*d.EnrollmentOpensAt) {
+		return false
+	}
+	if d.EnrollmentClosesAt != nil && !at.Before(*d.EnrollmentClosesAt) {
+		return false
+	}
+	return true
+}
+
 // Overlaps checks if this dateframe overlaps with another dateframe
 func (d *Dateframe) Overlaps(other *Dateframe) bool {
 	return (other.StartDate.Before(d.EndDate) || other.StartDate.Equal(d.EndDate)) &&
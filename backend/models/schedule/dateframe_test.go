@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -228,6 +229,38 @@ func TestDateframe_Contains(t *testing.T) {
 	}
 }
 
+func TestDateframe_IsEnrollmentOpen(t *testing.T) {
+	opens := time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC)
+	closes := time.Date(2024, 1, 12, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dateframe *Dateframe
+		at        time.Time
+		expected  bool
+	}{
+		{"no window", &Dateframe{}, opens, true},
+		{"at opening", &Dateframe{EnrollmentOpensAt: &opens, EnrollmentClosesAt: &closes}, opens, true},
+		{"before opening", &Dateframe{EnrollmentOpensAt: &opens, EnrollmentClosesAt: &closes}, opens.Add(-time.Minute), false},
+		{"at closing", &Dateframe{EnrollmentOpensAt: &opens, EnrollmentClosesAt: &closes}, closes, false},
+		{"open-ended", &Dateframe{EnrollmentOpensAt: &opens}, closes.AddDate(1, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dateframe.IsEnrollmentOpen(tt.at); got != tt.expected {
+				t.Errorf("Dateframe.IsEnrollmentOpen() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	inverted := &Dateframe{StartDate: start, EndDate: start, EnrollmentOpensAt: &closes, EnrollmentClosesAt: &opens}
+	if err := inverted.Validate(); err == nil {
+		t.Errorf("Dateframe.Validate() expected error for enrollment window closing before it opens")
+	}
+}
+
 func TestDateframe_Overlaps(t *testing.T) {
 	// Base dateframe: Jan 15-20, 2024
 	base := &Dateframe{
@@ -392,3 +425,165 @@ func TestDateframe_GetUpdatedAt(t *testing.T) {
 		t.Errorf("GetUpdatedAt() = %v, want %v", got, now)
 	}
 }
+
+func TestDateframe_ValidateKind(t *testing.T) {
+	now := time.Now()
+
+	df := &Dateframe{StartDate: now, EndDate: now}
+	if err := df.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if df.Kind != DateframeKindTerm {
+		t.Errorf("Kind = %q, want %q", df.Kind, DateframeKindTerm)
+	}
+
+	df.Kind = "vacation"
+	if err := df.Validate(); err == nil {
+		t.Error("Validate() expected error for unknown kind")
+	}
+}
+
+func TestDateframe_CoversDate(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	autumn := &Dateframe{
+		StartDate: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+		Kind:      DateframeKindHoliday,
+	}
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"first day", time.Date(2026, 10, 17, 0, 0, 0, 0, berlin), true},
+		{"last day late evening in Berlin", time.Date(2026, 10, 31, 23, 30, 0, 0, berlin), true},
+		{"day before", time.Date(2026, 10, 16, 23, 59, 0, 0, berlin), false},
+		{"day after", time.Date(2026, 11, 1, 0, 0, 0, 0, berlin), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autumn.CoversDate(tt.day); got != tt.want {
+				t.Errorf("CoversDate(%v) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosureOn(t *testing.T) {
+	day := time.Date(2026, 12, 23, 0, 0, 0, 0, time.UTC)
+	term := &Dateframe{
+		StartDate: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+		Kind:      DateframeKindTerm,
+	}
+	christmas := &Dateframe{
+		StartDate: time.Date(2026, 12, 23, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2027, 1, 6, 0, 0, 0, 0, time.UTC),
+		Kind:      DateframeKindHoliday,
+		Name:      "Weihnachtsferien",
+	}
+
+	if got := ClosureOn([]*Dateframe{term, christmas}, day); got != christmas {
+		t.Errorf("ClosureOn() = %v, want the christmas holidays", got)
+	}
+	if got := ClosureOn([]*Dateframe{term}, day); got != nil {
+		t.Errorf("ClosureOn() = %v, want nil for terms", got)
+	}
+}
+
+func TestClosureDays(t *testing.T) {
+	autumn := &Dateframe{
+		StartDate: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+		Kind:      DateframeKindHoliday,
+		Name:      "Herbstferien",
+	}
+	teamDay := &Dateframe{
+		StartDate: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		Kind:      DateframeKindClosure,
+		Name:      "Pädagogischer Tag",
+	}
+	term := &Dateframe{
+		StartDate: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	days := ClosureDays([]*Dateframe{term, autumn, teamDay},
+		time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 6, 0, 0, 0, 0, time.UTC))
+
+	if len(days) != 7 {
+		t.Fatalf("ClosureDays() returned %d days, want 7 (Oct 26-31 and Nov 2)", len(days))
+	}
+	if got := days[time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC)]; got != "Herbstferien" {
+		t.Errorf("Oct 26 = %q, want Herbstferien", got)
+	}
+	if got := days[time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)]; got != "Pädagogischer Tag" {
+		t.Errorf("Nov 2 = %q, want Pädagogischer Tag", got)
+	}
+	if _, ok := days[time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)]; ok {
+		t.Error("Nov 1 is not a closure day")
+	}
+}
+
+// closureRepo returns fixed closures for any range
+type closureRepo struct {
+	DateframeRepository
+	closures []*Dateframe
+}
+
+func (r *closureRepo) FindClosures(_ context.Context, _, _ time.Time) ([]*Dateframe, error) {
+	return r.closures, nil
+}
+
+func TestSchoolClosureDays(t *testing.T) {
+	repo := &closureRepo{closures: []*Dateframe{
+		{
+			StartDate: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+			Kind:      DateframeKindHoliday,
+			Name:      "Herbstferien",
+		},
+		{
+			StartDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+			Kind:      DateframeKindClosure,
+			Name:      "Brückentage",
+		},
+	}}
+	from := time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	days, err := SchoolClosureDays(context.Background(), repo, from, to)
+	if err != nil {
+		t.Fatalf("SchoolClosureDays() error = %v", err)
+	}
+	want := map[time.Time]string{
+		time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC): "Herbstferien",
+		time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC): "Herbstferien",
+		time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC):  "Allerheiligen", // Public holidays take precedence
+		time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC):  "Brückentage",
+	}
+	if len(days) != len(want) {
+		t.Fatalf("SchoolClosureDays() returned %d days, want %d", len(days), len(want))
+	}
+	for day, name := range want {
+		if days[day] != name {
+			t.Errorf("%s = %q, want %q", day.Format("2006-01-02"), days[day], name)
+		}
+	}
+
+	// Without a repository only the public holidays are returned
+	days, err = SchoolClosureDays(context.Background(), nil, from, to)
+	if err != nil {
+		t.Fatalf("SchoolClosureDays() error = %v", err)
+	}
+	if len(days) != 1 {
+		t.Errorf("SchoolClosureDays() without repository returned %d days, want 1", len(days))
+	}
+}
@@ -121,7 +121,10 @@ type StudentPickupException struct {
 	ExceptionDate time.Time  `bun:"exception_date,notnull" json:"exception_date"`
 	PickupTime    *time.Time `bun:"pickup_time" json:"pickup_time,omitempty"`
 	Reason        *string    `bun:"reason" json:"reason,omitempty"`
-	CreatedBy     int64      `bun:"created_by,notnull" json:"created_by"`
+	CreatedBy     int64      `bun:"created_by,nullzero" json:"created_by"` // Staff author; 0 when entered by a guardian
+
+	// CreatedByGuardianID is set when the exception was entered through the parent portal
+	CreatedByGuardianID *int64 `bun:"created_by_guardian_id" json:"created_by_guardian_id,omitempty"`
 }
 
 func (e *StudentPickupException) BeforeAppendModel(query any) error {
@@ -153,12 +156,17 @@ func (e *StudentPickupException) Validate() error {
 	if e.Reason != nil && len(*e.Reason) > 255 {
 		return errors.New("reason cannot exceed 255 characters")
 	}
-	if e.CreatedBy <= 0 {
+	if e.CreatedBy <= 0 && !e.IsGuardianAuthored() {
 		return errors.New(errMsgCreatedByRequired)
 	}
 	return nil
 }
 
+// IsGuardianAuthored returns true if the exception was entered by a guardian
+func (e *StudentPickupException) IsGuardianAuthored() bool {
+	return e.CreatedByGuardianID != nil && *e.CreatedByGuardianID > 0
+}
+
 // IsAbsent returns true if this exception indicates the student will be absent (no pickup)
 func (e *StudentPickupException) IsAbsent() bool {
 	return e.PickupTime == nil
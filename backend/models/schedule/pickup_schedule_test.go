@@ -328,6 +328,18 @@ func TestStudentPickupException_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "created_by is required",
 		},
+		{
+			name: "guardian authored without staff author",
+			setup: func() *StudentPickupException {
+				var guardianID int64 = 3
+				return &StudentPickupException{
+					StudentID:           1,
+					ExceptionDate:       validDate,
+					CreatedByGuardianID: &guardianID,
+				}
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
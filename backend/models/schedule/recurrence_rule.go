@@ -2,6 +2,7 @@ package schedule
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"time"
 
@@ -196,6 +197,45 @@ func (r *RecurrenceRule) Clone() *RecurrenceRule {
 	return clone
 }
 
+// RRule formats the rule as an RFC 5545 RRULE value (without the "RRULE:" prefix), e.g.
+// "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE;UNTIL=20270131T235959Z". RFC 5545 requires UNTIL to match
+// the value type of DTSTART: a DATE for all-day events, a UTC DATE-TIME otherwise.
+func (r *RecurrenceRule) RRule(allDay bool) string {
+	parts := []string{
+		"FREQ=" + strings.ToUpper(r.Frequency),
+		"INTERVAL=" + strconv.Itoa(max(r.IntervalCount, 1)),
+	}
+
+	if len(r.Weekdays) > 0 {
+		days := make([]string, 0, len(r.Weekdays))
+		for _, day := range r.Weekdays {
+			if len(day) >= 2 {
+				days = append(days, strings.ToUpper(day[:2])) // MON -> MO
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.MonthDays) > 0 {
+		days := make([]string, 0, len(r.MonthDays))
+		for _, day := range r.MonthDays {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	switch {
+	case r.EndDate != nil && allDay:
+		parts = append(parts, "UNTIL="+r.EndDate.Format("20060102"))
+	case r.EndDate != nil:
+		parts = append(parts, "UNTIL="+r.EndDate.Format("20060102")+"T235959Z")
+	case r.Count != nil:
+		parts = append(parts, "COUNT="+strconv.Itoa(*r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
 // GetID implements the Entity interface
 func (r *RecurrenceRule) GetID() interface{} {
 	return r.ID
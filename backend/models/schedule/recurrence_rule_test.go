@@ -254,6 +254,49 @@ func TestRecurrenceRule_IsFinite(t *testing.T) {
 	}
 }
 
+func TestRecurrenceRule_RRule(t *testing.T) {
+	count10 := 10
+	termEnd := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		rule   *RecurrenceRule
+		allDay bool
+		want   string
+	}{
+		{
+			name:   "weekly until term end",
+			rule:   &RecurrenceRule{Frequency: FrequencyWeekly, IntervalCount: 1, Weekdays: []string{"MON", "WED"}, EndDate: &termEnd},
+			allDay: false,
+			want:   "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE;UNTIL=20270131T235959Z",
+		},
+		{
+			name:   "all-day events use a DATE until",
+			rule:   &RecurrenceRule{Frequency: FrequencyWeekly, IntervalCount: 2, Weekdays: []string{"FRI"}, EndDate: &termEnd},
+			allDay: true,
+			want:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;UNTIL=20270131",
+		},
+		{
+			name: "monthly with count",
+			rule: &RecurrenceRule{Frequency: FrequencyMonthly, IntervalCount: 1, MonthDays: []int{1, 15}, Count: &count10},
+			want: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=1,15;COUNT=10",
+		},
+		{
+			name: "daily without end",
+			rule: &RecurrenceRule{Frequency: FrequencyDaily},
+			want: "FREQ=DAILY;INTERVAL=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.RRule(tt.allDay); got != tt.want {
+				t.Errorf("RecurrenceRule.RRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRecurrenceRule_IsWeekdayBased(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -19,6 +19,12 @@ type DateframeRepository interface {
 
 	// FindOverlapping finds all dateframes that overlap with the given date range
 	FindOverlapping(ctx context.Context, startDate, endDate time.Time) ([]*Dateframe, error)
+
+	// FindClosures finds all holiday and closure dateframes overlapping the given date range
+	FindClosures(ctx context.Context, startDate, endDate time.Time) ([]*Dateframe, error)
+
+	// FindByExternalUID finds an imported dateframe by its iCalendar UID; returns nil if none exists
+	FindByExternalUID(ctx context.Context, uid string) (*Dateframe, error)
 }
 
 // TimeframeRepository defines operations for managing time frames
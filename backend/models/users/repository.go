@@ -126,8 +126,14 @@ type StudentRepository interface {
 	// FindAllWithGroups retrieves all students with their group names (LEFT JOIN for students without groups)
 	FindAllWithGroups(ctx context.Context) ([]*StudentWithGroupInfo, error)
 
+	// FindByIDsWithGroups retrieves the given students with their group names (LEFT JOIN for students without groups)
+	FindByIDsWithGroups(ctx context.Context, ids []int64) ([]*StudentWithGroupInfo, error)
+
 	// FindByNameAndClass retrieves students by first name, last name, and school class (for import duplicate detection)
 	FindByNameAndClass(ctx context.Context, firstName, lastName, schoolClass string) ([]*Student, error)
+
+	// FindByNameAndBirthday retrieves students by first name, last name, and birthday (for import matching across class changes)
+	FindByNameAndBirthday(ctx context.Context, firstName, lastName string, birthday time.Time) ([]*Student, error)
 }
 
 // StaffRepository defines operations for managing staff members
@@ -484,3 +490,21 @@ type GuardianPhoneNumberRepository interface {
 	// GetNextPriority returns the next priority value for a guardian's phone numbers
 	GetNextPriority(ctx context.Context, guardianProfileID int64) (int, error)
 }
+
+// StudentPickupPersonRepository defines operations for managing a student's extra pickup persons
+type StudentPickupPersonRepository interface {
+	// Create inserts a new pickup person into the database
+	Create(ctx context.Context, person *StudentPickupPerson) error
+
+	// FindByID retrieves a pickup person by their ID
+	FindByID(ctx context.Context, id interface{}) (*StudentPickupPerson, error)
+
+	// FindByStudentID retrieves all pickup persons of a student ordered by name
+	FindByStudentID(ctx context.Context, studentID int64) ([]*StudentPickupPerson, error)
+
+	// Update updates an existing pickup person
+	Update(ctx context.Context, person *StudentPickupPerson) error
+
+	// Delete removes a pickup person
+	Delete(ctx context.Context, id interface{}) error
+}
@@ -0,0 +1,131 @@
+package users
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// tableStudentPickupPersons is the schema-qualified table name
+const tableStudentPickupPersons = "users.student_pickup_persons"
+
+// StudentPickupPerson is a named person besides the guardians who may collect a student,
+// e.g. grandparents, neighbours or the parents of a friend. A person with IsAuthorized
+// false is listed so that staff are warned when they are about to hand the child over.
+type StudentPickupPerson struct {
+	base.Model   `bun:"schema:users,table:student_pickup_persons"`
+	StudentID    int64      `bun:"student_id,notnull" json:"student_id"`
+	FirstName    string     `bun:"first_name,notnull" json:"first_name"`
+	LastName     string     `bun:"last_name,notnull" json:"last_name"`
+	Relationship string     `bun:"relationship" json:"relationship,omitempty"` // Free text, e.g. "Oma", "Nachbarin"
+	Phone        *string    `bun:"phone" json:"phone,omitempty"`
+	IsAuthorized bool       `bun:"is_authorized,notnull" json:"is_authorized"`
+	ValidFrom    *time.Time `bun:"valid_from,type:date" json:"valid_from,omitempty"`   // nil = no start restriction
+	ValidUntil   *time.Time `bun:"valid_until,type:date" json:"valid_until,omitempty"` // nil = open-ended
+	Notes        *string    `bun:"notes" json:"notes,omitempty"`
+	CreatedBy    int64      `bun:"created_by,notnull" json:"created_by"` // Staff who added the person
+
+	// Relations (not stored in database)
+	Student *Student `bun:"rel:belongs-to,join:student_id=id" json:"student,omitempty"`
+}
+
+// BeforeAppendModel sets the correct table expression for BUN queries
+func (p *StudentPickupPerson) BeforeAppendModel(query any) error {
+	if q, ok := query.(*bun.SelectQuery); ok {
+		q.ModelTableExpr(`users.student_pickup_persons AS "student_pickup_person"`)
+	}
+	if q, ok := query.(*bun.UpdateQuery); ok {
+		q.ModelTableExpr(`users.student_pickup_persons AS "student_pickup_person"`)
+	}
+	if q, ok := query.(*bun.DeleteQuery); ok {
+		q.ModelTableExpr(`users.student_pickup_persons AS "student_pickup_person"`)
+	}
+	return nil
+}
+
+// TableName returns the database table name
+func (p *StudentPickupPerson) TableName() string {
+	return tableStudentPickupPersons
+}
+
+// Validate ensures pickup person data is valid
+func (p *StudentPickupPerson) Validate() error {
+	if p.StudentID <= 0 {
+		return errors.New("student ID is required")
+	}
+
+	p.FirstName = strings.TrimSpace(p.FirstName)
+	p.LastName = strings.TrimSpace(p.LastName)
+	if p.FirstName == "" || p.LastName == "" {
+		return errors.New("first and last name are required")
+	}
+
+	p.Relationship = strings.TrimSpace(p.Relationship)
+	if len(p.Relationship) > 100 {
+		return errors.New("relationship cannot exceed 100 characters")
+	}
+
+	if p.Phone != nil {
+		trimmed := strings.TrimSpace(*p.Phone)
+		if trimmed == "" {
+			p.Phone = nil
+		} else {
+			p.Phone = &trimmed
+		}
+	}
+
+	if p.Notes != nil && len(*p.Notes) > 500 {
+		return errors.New("notes cannot exceed 500 characters")
+	}
+
+	if p.ValidFrom != nil && p.ValidUntil != nil && p.ValidUntil.Before(*p.ValidFrom) {
+		return errors.New("valid until cannot be before valid from")
+	}
+
+	if p.CreatedBy <= 0 {
+		return errors.New("created by is required")
+	}
+
+	return nil
+}
+
+// FullName returns the first and last name of the pickup person
+func (p *StudentPickupPerson) FullName() string {
+	return p.FirstName + " " + p.LastName
+}
+
+// IsValidOn reports whether the date lies within the validity period (dates inclusive)
+func (p *StudentPickupPerson) IsValidOn(date time.Time) bool {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if p.ValidFrom != nil {
+		from := time.Date(p.ValidFrom.Year(), p.ValidFrom.Month(), p.ValidFrom.Day(), 0, 0, 0, 0, time.UTC)
+		if day.Before(from) {
+			return false
+		}
+	}
+	if p.ValidUntil != nil {
+		until := time.Date(p.ValidUntil.Year(), p.ValidUntil.Month(), p.ValidUntil.Day(), 0, 0, 0, 0, time.UTC)
+		if day.After(until) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetID returns the entity's ID
+func (p *StudentPickupPerson) GetID() interface{} {
+	return p.ID
+}
+
+// GetCreatedAt returns the creation timestamp
+func (p *StudentPickupPerson) GetCreatedAt() time.Time {
+	return p.CreatedAt
+}
+
+// GetUpdatedAt returns the last update timestamp
+func (p *StudentPickupPerson) GetUpdatedAt() time.Time {
+	return p.UpdatedAt
+}
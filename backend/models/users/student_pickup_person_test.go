@@ -0,0 +1,71 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStudentPickupPerson_Validate(t *testing.T) {
+	from := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		person  *StudentPickupPerson
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			person: &StudentPickupPerson{StudentID: 10, FirstName: " Erika ", LastName: "Muster", IsAuthorized: true, CreatedBy: 20},
+		},
+		{
+			name:    "missing student",
+			person:  &StudentPickupPerson{FirstName: "Erika", LastName: "Muster", CreatedBy: 20},
+			wantErr: "student ID is required",
+		},
+		{
+			name:    "missing last name",
+			person:  &StudentPickupPerson{StudentID: 10, FirstName: "Erika", CreatedBy: 20},
+			wantErr: "first and last name are required",
+		},
+		{
+			name:    "validity reversed",
+			person:  &StudentPickupPerson{StudentID: 10, FirstName: "Erika", LastName: "Muster", ValidFrom: &from, ValidUntil: &until, CreatedBy: 20},
+			wantErr: "valid until cannot be before valid from",
+		},
+		{
+			name:    "missing creator",
+			person:  &StudentPickupPerson{StudentID: 10, FirstName: "Erika", LastName: "Muster"},
+			wantErr: "created by is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.person.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestStudentPickupPerson_IsValidOn(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	p := &StudentPickupPerson{ValidFrom: &from, ValidUntil: &until}
+
+	assert.False(t, p.IsValidOn(time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)))
+	assert.True(t, p.IsValidOn(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, p.IsValidOn(time.Date(2026, 3, 6, 17, 0, 0, 0, time.UTC)))
+	assert.False(t, p.IsValidOn(time.Date(2026, 3, 7, 8, 0, 0, 0, time.UTC)))
+
+	openEnded := &StudentPickupPerson{}
+	assert.True(t, openEnded.IsValidOn(time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)))
+}
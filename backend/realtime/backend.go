@@ -0,0 +1,15 @@
+package realtime
+
+import "context"
+
+// BroadcastBackend fans events out between Hub instances.
+// Without a backend a Hub only reaches clients connected to the same process,
+// which breaks as soon as several server replicas run behind a load balancer.
+type BroadcastBackend interface {
+	// Publish forwards an event that was dispatched locally to all other instances.
+	Publish(ctx context.Context, topics []string, event Event) error
+
+	// Listen blocks until ctx is canceled and calls deliver for every event
+	// published by another instance. Events published by this instance are not delivered.
+	Listen(ctx context.Context, deliver func(topics []string, event Event)) error
+}
@@ -6,4 +6,8 @@ type Broadcaster interface {
 	// BroadcastToGroup sends an event to all clients subscribed to the given active group ID.
 	// This is a fire-and-forget operation - errors are logged but don't affect service execution.
 	BroadcastToGroup(activeGroupID string, event Event) error
+
+	// BroadcastToTopics sends an event to all clients subscribed to any of the topics
+	// (see topics.go). Clients subscribed to several of them receive the event once.
+	BroadcastToTopics(topics []string, event Event) error
 }
@@ -17,10 +17,27 @@ const (
 	EventActivityStart  EventType = "activity_start"
 	EventActivityEnd    EventType = "activity_end"
 	EventActivityUpdate EventType = "activity_update"
+
+	// Student status events
+	EventStudentSick      EventType = "student_sick"      // Sick flag set or cleared
+	EventPickupException  EventType = "pickup_exception"  // Pickup exception for today created, changed or removed
+	EventPickupDue        EventType = "pickup_due"        // Pickup time reached; the student should leave now
+	EventPickupOverdue    EventType = "pickup_overdue"    // Pickup time passed without checkout (escalation)
+	EventSupervisorChange EventType = "supervisor_change" // Supervisor team of an active group changed
+	EventStudentMissing   EventType = "student_missing"   // Expected student has not checked in, or the alert was resolved
+	EventBusDepartureDue  EventType = "bus_departure_due" // Bus of a checked-in bus child departs soon; the student should leave for the bus
+
+	// Device events
+	EventDeviceOffline EventType = "device_offline" // RFID device stopped reporting
+	EventDeviceOnline  EventType = "device_online"  // RFID device reports again after being offline
+
+	// Stream control events
+	EventResyncRequired EventType = "resync_required" // Missed events can't be replayed; client must refetch
 )
 
 // Event represents a Server-Sent Event that will be broadcast to clients
 type Event struct {
+	ID            string    `json:"id,omitempty"` // Assigned by the Hub ("<epoch>-<sequence>")
 	Type          EventType `json:"type"`
 	ActiveGroupID string    `json:"active_group_id"`
 	Data          EventData `json:"data"`
@@ -42,6 +59,29 @@ type EventData struct {
 	RoomName      *string   `json:"room_name,omitempty"`
 	SupervisorIDs *[]string `json:"supervisor_ids,omitempty"`
 
+	// Sickness fields (for student_sick events)
+	Sick *bool `json:"sick,omitempty"`
+
+	// Pickup fields (for pickup_exception/pickup_due/pickup_overdue events; the reason is never included)
+	PickupDate *string `json:"pickup_date,omitempty"` // "2006-01-02"
+	PickupTime *string `json:"pickup_time,omitempty"` // "15:04", nil when the exception was removed or has no time
+
+	// Bus fields (for bus_departure_due events)
+	BusRouteID    *string `json:"bus_route_id,omitempty"`
+	BusRouteName  *string `json:"bus_route_name,omitempty"`
+	DepartureTime *string `json:"departure_time,omitempty"` // "15:04"
+
+	// Missing student fields (for student_missing events)
+	AlertID     *string    `json:"alert_id,omitempty"`
+	AlertStatus *string    `json:"alert_status,omitempty"` // "open" or "resolved"
+	Resolution  *string    `json:"resolution,omitempty"`   // "found", "sick" or "went_home" once resolved
+	ExpectedBy  *time.Time `json:"expected_by,omitempty"`
+
+	// Device fields (for device_offline/device_online events)
+	DeviceID   *string    `json:"device_id,omitempty"`
+	DeviceName *string    `json:"device_name,omitempty"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"`
+
 	// Source tracking
 	Source *string `json:"source,omitempty"` // "rfid", "manual", "automated"
 }
@@ -1,15 +1,34 @@
 package realtime
 
 import (
+	"context"
 	"log/slog"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 )
 
+const (
+	// publishTimeout bounds how long publishing a single event to the backend may take
+	publishTimeout = 2 * time.Second
+
+	// publishQueueSize is the number of events waiting for the backend before new ones are dropped
+	publishQueueSize = 1024
+)
+
+// publication is an event waiting to be published to the other instances
+type publication struct {
+	topics []string
+	event  Event
+}
+
 // Client represents a single SSE client connection
 type Client struct {
 	Channel          chan Event      // Channel to send events to this client
 	UserID           int64           // User ID for audit logging
 	SubscribedGroups map[string]bool // active_group_id -> subscribed
+	Resync           chan struct{}   // Signaled when events had to be dropped (optional, buffer 1)
 }
 
 // Hub manages SSE client connections and broadcasts events
@@ -18,6 +37,18 @@ type Hub struct {
 	groupClients map[string][]*Client // active_group_id -> subscribers
 	mu           sync.RWMutex
 	logger       *slog.Logger
+	backend      BroadcastBackend // Optional fan-out to other server instances
+	outbox       chan publication // Events waiting for the backend; drained by Run
+
+	// Event log for Last-Event-ID replay; logMu serializes ID assignment and dispatch
+	// so clients receive events in ID order. Lock order: logMu before mu.
+	logMu            sync.Mutex
+	epoch            string // Changes on every start, so IDs from before a restart force a resync
+	seq              uint64
+	logs             map[string]*groupLog
+	prunedUpTo       uint64 // Highest sequence number of logs removed entirely
+	replayBufferSize int
+	replayMaxAge     time.Duration
 }
 
 // getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
@@ -34,7 +65,37 @@ func NewHub(logger *slog.Logger) *Hub {
 		clients:      make(map[*Client]bool),
 		groupClients: make(map[string][]*Client),
 		logger:       logger,
+
+		epoch:            strconv.FormatInt(time.Now().UnixNano(), 36),
+		logs:             make(map[string]*groupLog),
+		replayBufferSize: DefaultReplayBufferSize,
+		replayMaxAge:     DefaultReplayMaxAge,
+	}
+}
+
+// SetBackend configures the backend used to share events with other server instances.
+// Must be called before Run and before events are broadcast.
+func (h *Hub) SetBackend(backend BroadcastBackend) {
+	h.backend = backend
+	h.outbox = make(chan publication, publishQueueSize)
+}
+
+// Run publishes locally broadcast events to other instances and delivers events published by
+// other instances to local clients until ctx is canceled.
+// Returns immediately when no backend is configured.
+func (h *Hub) Run(ctx context.Context) error {
+	if h.backend == nil {
+		return nil
 	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go h.publishLoop(ctx)
+
+	h.getLogger().Info("SSE broadcast backend listening")
+	return h.backend.Listen(ctx, func(topics []string, event Event) {
+		h.deliverLocal(topics, event)
+	})
 }
 
 // Register adds a client to the hub and subscribes them to specified active groups
@@ -57,6 +118,58 @@ func (h *Hub) Register(client *Client, activeGroupIDs []string) {
 	)
 }
 
+// Resume registers a client and returns the events it missed since lastEventID.
+// resyncRequired is true when the missed events can no longer be replayed
+// (buffer overflow, events too old, or an ID from before a restart or from another instance).
+func (h *Hub) Resume(client *Client, activeGroupIDs []string, lastEventID string) (replay []Event, resyncRequired bool) {
+	h.logMu.Lock()
+	defer h.logMu.Unlock()
+
+	// Registering under logMu guarantees no event is both replayed and delivered
+	h.Register(client, activeGroupIDs)
+
+	if lastEventID == "" {
+		return nil, false
+	}
+
+	epoch, seq, ok := parseEventID(lastEventID)
+	if !ok || epoch != h.epoch || seq > h.seq {
+		return nil, true
+	}
+
+	cutoff := time.Now().Add(-h.replayMaxAge)
+	var missed []loggedEvent
+	for _, groupID := range activeGroupIDs {
+		log := h.logs[groupID]
+		if log == nil {
+			if seq < h.prunedUpTo {
+				return nil, true // Group log was pruned; can't tell what was missed
+			}
+			continue
+		}
+
+		log.pruneBefore(cutoff)
+		events, ok := log.since(seq)
+		if !ok {
+			return nil, true
+		}
+		missed = append(missed, events...)
+	}
+
+	// An event broadcast to several topics is logged once per topic; replay it once
+	sort.Slice(missed, func(i, j int) bool { return missed[i].seq < missed[j].seq })
+	replay = make([]Event, 0, len(missed))
+	var lastSeq uint64
+	for _, entry := range missed {
+		if entry.seq == lastSeq {
+			continue
+		}
+		lastSeq = entry.seq
+		replay = append(replay, entry.event)
+	}
+	return replay, false
+}
+
 // Unregister removes a client from the hub and all group subscriptions
 func (h *Hub) Unregister(client *Client) {
 	h.mu.Lock()
@@ -93,20 +206,117 @@ func (h *Hub) Unregister(client *Client) {
 	)
 }
 
-// BroadcastToGroup sends an event to all clients subscribed to the specified active group
+// BroadcastToGroup sends an event to all clients subscribed to the specified active group,
+// on this instance and (with a backend) on all other instances.
 // This is a fire-and-forget operation - errors don't affect service execution
 func (h *Hub) BroadcastToGroup(activeGroupID string, event Event) error {
+	return h.BroadcastToTopics([]string{activeGroupID}, event)
+}
+
+// BroadcastToTopics sends an event to all clients subscribed to any of the topics.
+// Each client receives the event once, even when subscribed to several of the topics.
+// Publishing to other instances happens in the background, so callers never wait for the backend.
+func (h *Hub) BroadcastToTopics(topics []string, event Event) error {
+	event = h.deliverLocal(topics, event)
+
+	if h.backend == nil {
+		return nil
+	}
+
+	select {
+	case h.outbox <- publication{topics: topics, event: event}:
+	default:
+		// Local clients already got the event; only other instances miss it
+		h.getLogger().Warn("SSE publish queue full, event not sent to other instances",
+			slog.Any("topics", topics),
+			slog.String("event_type", string(event.Type)),
+		)
+	}
+
+	return nil
+}
+
+// publishLoop forwards queued events to the backend in broadcast order until ctx is canceled
+func (h *Hub) publishLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p := <-h.outbox:
+			h.publish(ctx, p)
+		}
+	}
+}
+
+// publish sends one event to the other instances, logging failures
+func (h *Hub) publish(ctx context.Context, p publication) {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if err := h.backend.Publish(ctx, p.topics, p.event); err != nil {
+		// Local clients already got the event; only other instances miss it
+		h.getLogger().Warn("failed to publish SSE event to other instances",
+			slog.Any("topics", p.topics),
+			slog.String("event_type", string(p.event.Type)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// deliverLocal assigns the next event ID, records the event for replay and dispatches it locally
+func (h *Hub) deliverLocal(topics []string, event Event) Event {
+	h.logMu.Lock()
+	defer h.logMu.Unlock()
+
+	h.seq++
+	event.ID = formatEventID(h.epoch, h.seq)
+
+	if IsReplayable(event.Type) {
+		for _, topic := range topics {
+			log := h.logs[topic]
+			if log == nil {
+				log = &groupLog{}
+				h.logs[topic] = log
+			}
+			log.append(loggedEvent{seq: h.seq, event: event}, h.replayBufferSize)
+		}
+	}
+
+	if h.seq%pruneEvery == 0 {
+		h.pruneLogs()
+	}
+
+	h.dispatch(topics, event)
+	return event
+}
+
+// pruneLogs trims expired events and removes empty group logs (caller holds logMu)
+func (h *Hub) pruneLogs() {
+	cutoff := time.Now().Add(-h.replayMaxAge)
+	for groupID, log := range h.logs {
+		log.pruneBefore(cutoff)
+		if len(log.events) > 0 {
+			continue
+		}
+		if log.evictedUpTo > h.prunedUpTo {
+			h.prunedUpTo = log.evictedUpTo
+		}
+		delete(h.logs, groupID)
+	}
+}
+
+// dispatch sends an event to the clients connected to this instance
+func (h *Hub) dispatch(topics []string, event Event) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	clients := h.groupClients[activeGroupID]
+	clients := h.subscribersOf(topics)
 	if len(clients) == 0 {
-		// No subscribers for this group - not an error
-		h.getLogger().Debug("no SSE subscribers for group",
-			slog.String("active_group_id", activeGroupID),
+		// No subscribers for these topics - not an error
+		h.getLogger().Debug("no SSE subscribers for topics",
+			slog.Any("topics", topics),
 			slog.String("event_type", string(event.Type)),
 		)
-		return nil
+		return
 	}
 
 	// Send event to all subscribed clients
@@ -116,23 +326,44 @@ func (h *Hub) BroadcastToGroup(activeGroupID string, event Event) error {
 		case client.Channel <- event:
 			successCount++
 		default:
-			// Client's channel is full - skip this client
+			// Client's channel is full - skip this client and ask it to resync
+			select {
+			case client.Resync <- struct{}{}:
+			default:
+			}
 			h.getLogger().Warn("SSE client channel full, skipping event",
 				slog.Int64("user_id", client.UserID),
-				slog.String("active_group_id", activeGroupID),
+				slog.Any("topics", topics),
 				slog.String("event_type", string(event.Type)),
 			)
 		}
 	}
 
 	h.getLogger().Debug("SSE event broadcast",
-		slog.String("active_group_id", activeGroupID),
+		slog.Any("topics", topics),
 		slog.String("event_type", string(event.Type)),
 		slog.Int("recipient_count", len(clients)),
 		slog.Int("successful", successCount),
 	)
+}
 
-	return nil
+// subscribersOf returns the distinct clients subscribed to any of the topics (caller holds mu)
+func (h *Hub) subscribersOf(topics []string) []*Client {
+	if len(topics) == 1 {
+		return h.groupClients[topics[0]]
+	}
+
+	seen := make(map[*Client]bool)
+	var clients []*Client
+	for _, topic := range topics {
+		for _, client := range h.groupClients[topic] {
+			if !seen[client] {
+				seen[client] = true
+				clients = append(clients, client)
+			}
+		}
+	}
+	return clients
 }
 
 // GetClientCount returns the total number of connected clients (for monitoring)
@@ -1,7 +1,10 @@
 package realtime
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"sync"
 	"testing"
 	"time"
 )
@@ -454,7 +457,423 @@ func TestHubGetGroupSubscriberCount(t *testing.T) {
 	}
 }
 
+// fakeBackend records published events and lets tests inject events from other instances
+type fakeBackend struct {
+	mu         sync.Mutex
+	published  []Event
+	publishErr error
+	listening  chan func(topics []string, event Event)
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{listening: make(chan func([]string, Event), 1)}
+}
+
+func (b *fakeBackend) Publish(_ context.Context, _ []string, event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published = append(b.published, event)
+	return b.publishErr
+}
+
+func (b *fakeBackend) Listen(ctx context.Context, deliver func(topics []string, event Event)) error {
+	b.listening <- deliver
+	<-ctx.Done()
+	return nil
+}
+
+func (b *fakeBackend) publishedCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.published)
+}
+
+// TestHubBroadcastPublishesToBackend verifies local dispatch plus publishing to other instances
+func TestHubBroadcastPublishesToBackend(t *testing.T) {
+	hub := NewHub(slog.Default())
+	backend := newFakeBackend()
+	hub.SetBackend(backend)
+
+	client := &Client{
+		Channel:          make(chan Event, 10),
+		UserID:           1,
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(client, []string{"group_1"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = hub.Run(ctx) }()
+	<-backend.listening
+
+	event := NewEvent(EventStudentCheckIn, "group_1", EventData{StudentID: strPtr("1")})
+	if err := hub.BroadcastToGroup("group_1", event); err != nil {
+		t.Fatalf("BroadcastToGroup() error = %v", err)
+	}
+
+	select {
+	case <-client.Channel:
+	default:
+		t.Error("Local client should receive the event without a round trip through the backend")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for backend.publishedCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := backend.publishedCount(); got != 1 {
+		t.Errorf("Backend received %d events, want 1", got)
+	}
+}
+
+// TestHubBroadcastDoesNotWaitForBackend verifies a slow or stopped backend never blocks callers
+func TestHubBroadcastDoesNotWaitForBackend(t *testing.T) {
+	hub := NewHub(slog.Default())
+	backend := newFakeBackend()
+	hub.SetBackend(backend)
+
+	// Run is not started, so nothing drains the queue: overflowing events are dropped
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < publishQueueSize+10; i++ {
+			_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{}))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("BroadcastToGroup() blocked on the backend")
+	}
+	if got := backend.publishedCount(); got != 0 {
+		t.Errorf("Backend received %d events before Run, want 0", got)
+	}
+}
+
+// TestHubBroadcastIgnoresPublishErrors verifies backend failures don't reach callers
+func TestHubBroadcastIgnoresPublishErrors(t *testing.T) {
+	hub := NewHub(slog.Default())
+	backend := newFakeBackend()
+	backend.publishErr = errors.New("connection refused")
+	hub.SetBackend(backend)
+
+	client := &Client{
+		Channel:          make(chan Event, 10),
+		UserID:           1,
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(client, []string{"group_1"})
+
+	event := NewEvent(EventStudentCheckOut, "group_1", EventData{})
+	if err := hub.BroadcastToGroup("group_1", event); err != nil {
+		t.Errorf("BroadcastToGroup() should ignore backend errors, got %v", err)
+	}
+
+	select {
+	case <-client.Channel:
+	default:
+		t.Error("Local client should still receive the event")
+	}
+}
+
+// TestHubRunDeliversRemoteEvents verifies events from other instances reach local clients only
+func TestHubRunDeliversRemoteEvents(t *testing.T) {
+	hub := NewHub(slog.Default())
+	backend := newFakeBackend()
+	hub.SetBackend(backend)
+
+	client := &Client{
+		Channel:          make(chan Event, 10),
+		UserID:           1,
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(client, []string{"group_1"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- hub.Run(ctx) }()
+
+	deliver := <-backend.listening
+	deliver([]string{"group_1"}, NewEvent(EventActivityStart, "group_1", EventData{}))
+
+	select {
+	case received := <-client.Channel:
+		if received.Type != EventActivityStart {
+			t.Errorf("Received event type = %v, want %v", received.Type, EventActivityStart)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for remote event")
+	}
+
+	// Remote events must not be published again
+	if got := backend.publishedCount(); got != 0 {
+		t.Errorf("Remote event was republished %d times", got)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
+
+// TestHubRunWithoutBackend verifies Run returns immediately for single-instance setups
+func TestHubRunWithoutBackend(t *testing.T) {
+	hub := NewHub(slog.Default())
+	if err := hub.Run(context.Background()); err != nil {
+		t.Errorf("Run() without backend error = %v", err)
+	}
+}
+
+// TestHubAssignsMonotonicEventIDs verifies every broadcast gets a new, increasing ID
+func TestHubAssignsMonotonicEventIDs(t *testing.T) {
+	hub := NewHub(slog.Default())
+	client := &Client{
+		Channel:          make(chan Event, 10),
+		UserID:           1,
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(client, []string{"group_1", "group_2"})
+
+	_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{}))
+	_ = hub.BroadcastToGroup("group_2", NewEvent(EventStudentCheckOut, "group_2", EventData{}))
+
+	first, second := <-client.Channel, <-client.Channel
+	_, firstSeq, ok1 := parseEventID(first.ID)
+	_, secondSeq, ok2 := parseEventID(second.ID)
+	if !ok1 || !ok2 {
+		t.Fatalf("Event IDs %q and %q should be parseable", first.ID, second.ID)
+	}
+	if secondSeq <= firstSeq {
+		t.Errorf("Event IDs not increasing: %q then %q", first.ID, second.ID)
+	}
+}
+
+// TestHubResumeReplaysMissedEvents verifies replay of events after Last-Event-ID across groups
+func TestHubResumeReplaysMissedEvents(t *testing.T) {
+	hub := NewHub(slog.Default())
+	first := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	hub.Register(first, []string{"group_1"})
+
+	_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{StudentID: strPtr("1")}))
+	lastSeen := (<-first.Channel).ID
+	hub.Unregister(first)
+
+	// Missed while disconnected
+	_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{StudentID: strPtr("2")}))
+	_ = hub.BroadcastToGroup("group_3", NewEvent(EventStudentCheckIn, "group_3", EventData{StudentID: strPtr("3")}))
+	_ = hub.BroadcastToGroup("group_2", NewEvent(EventActivityEnd, "group_2", EventData{}))
+
+	resumed := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	replay, resync := hub.Resume(resumed, []string{"group_1", "group_2"}, lastSeen)
+
+	if resync {
+		t.Fatal("Resume() should replay, not require resync")
+	}
+	if len(replay) != 2 {
+		t.Fatalf("Resume() replayed %d events, want 2", len(replay))
+	}
+	if replay[0].Data.StudentID == nil || *replay[0].Data.StudentID != "2" {
+		t.Error("First replayed event should be the group_1 check-in")
+	}
+	if replay[1].Type != EventActivityEnd {
+		t.Errorf("Second replayed event type = %v, want %v", replay[1].Type, EventActivityEnd)
+	}
+	if hub.GetGroupSubscriberCount("group_2") != 1 {
+		t.Error("Resume() should register the client")
+	}
+}
+
+// TestHubResumeWithoutLastEventID verifies a fresh connection gets no replay
+func TestHubResumeWithoutLastEventID(t *testing.T) {
+	hub := NewHub(slog.Default())
+	_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{}))
+
+	client := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	replay, resync := hub.Resume(client, []string{"group_1"}, "")
+
+	if resync || len(replay) != 0 {
+		t.Errorf("Resume() without ID = (%d events, resync %v), want (0, false)", len(replay), resync)
+	}
+}
+
+// TestHubResumeRequiresResync verifies lost events are reported instead of silently skipped
+func TestHubResumeRequiresResync(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastEventID func(hub *Hub, firstID string) string
+	}{
+		{
+			name:        "events evicted from full buffer",
+			lastEventID: func(_ *Hub, firstID string) string { return firstID },
+		},
+		{
+			name:        "ID from before a restart",
+			lastEventID: func(_ *Hub, _ string) string { return "oldepoch-1" },
+		},
+		{
+			name:        "ID from the future",
+			lastEventID: func(hub *Hub, _ string) string { return formatEventID(hub.epoch, hub.seq+10) },
+		},
+		{
+			name:        "malformed ID",
+			lastEventID: func(_ *Hub, _ string) string { return "garbage" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(slog.Default())
+			hub.replayBufferSize = 2
+			watcher := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+			hub.Register(watcher, []string{"group_1"})
+
+			for i := 0; i < 4; i++ {
+				_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{}))
+			}
+			firstID := (<-watcher.Channel).ID
+
+			client := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+			replay, resync := hub.Resume(client, []string{"group_1"}, tt.lastEventID(hub, firstID))
+
+			if !resync {
+				t.Errorf("Resume() should require resync, replayed %d events", len(replay))
+			}
+		})
+	}
+}
+
+// TestHubResumeExpiredEvents verifies events older than the max age require a resync
+func TestHubResumeExpiredEvents(t *testing.T) {
+	hub := NewHub(slog.Default())
+	hub.replayMaxAge = time.Minute
+	watcher := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	hub.Register(watcher, []string{"group_1"})
+
+	seen := NewEvent(EventStudentCheckIn, "group_1", EventData{})
+	seen.Timestamp = time.Now().Add(-3 * time.Minute)
+	_ = hub.BroadcastToGroup("group_1", seen)
+	lastSeen := (<-watcher.Channel).ID
+
+	missed := NewEvent(EventStudentCheckOut, "group_1", EventData{})
+	missed.Timestamp = time.Now().Add(-2 * time.Minute)
+	_ = hub.BroadcastToGroup("group_1", missed)
+
+	client := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	if _, resync := hub.Resume(client, []string{"group_1"}, lastSeen); !resync {
+		t.Error("Resume() should require resync when missed events expired")
+	}
+}
+
+// TestHubDoesNotReplayControlEvents verifies only replayable event types are logged
+func TestHubDoesNotReplayControlEvents(t *testing.T) {
+	hub := NewHub(slog.Default())
+	watcher := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	hub.Register(watcher, []string{"group_1"})
+
+	_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{}))
+	lastSeen := (<-watcher.Channel).ID
+	_ = hub.BroadcastToGroup("group_1", NewEvent(EventResyncRequired, "group_1", EventData{}))
+
+	client := &Client{Channel: make(chan Event, 10), SubscribedGroups: make(map[string]bool)}
+	replay, resync := hub.Resume(client, []string{"group_1"}, lastSeen)
+
+	if resync || len(replay) != 0 {
+		t.Errorf("Resume() = (%d events, resync %v), want (0, false)", len(replay), resync)
+	}
+}
+
+// TestHubSignalsResyncOnFullChannel verifies a skipped client is told to resync
+func TestHubSignalsResyncOnFullChannel(t *testing.T) {
+	hub := NewHub(slog.Default())
+	client := &Client{
+		Channel:          make(chan Event, 1),
+		SubscribedGroups: make(map[string]bool),
+		Resync:           make(chan struct{}, 1),
+	}
+	hub.Register(client, []string{"group_1"})
+
+	for i := 0; i < 3; i++ {
+		_ = hub.BroadcastToGroup("group_1", NewEvent(EventStudentCheckIn, "group_1", EventData{}))
+	}
+
+	select {
+	case <-client.Resync:
+	default:
+		t.Error("Expected resync signal after dropped events")
+	}
+}
+
 // Helper function to create string pointers
 func strPtr(s string) *string {
 	return &s
 }
+
+// TestHubBroadcastToTopicsDeliversOnce verifies a client subscribed to several target topics gets the event once
+func TestHubBroadcastToTopicsDeliversOnce(t *testing.T) {
+	hub := NewHub(slog.Default())
+
+	roomClient := &Client{
+		Channel:          make(chan Event, 10),
+		UserID:           1,
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(roomClient, []string{RoomTopic(3), StaffTopic(7)})
+
+	otherClient := &Client{
+		Channel:          make(chan Event, 10),
+		UserID:           2,
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(otherClient, []string{RoomTopic(4)})
+
+	event := NewEvent(EventStudentCheckIn, "42", EventData{})
+	if err := hub.BroadcastToTopics([]string{ActiveGroupTopic(42), RoomTopic(3), StaffTopic(7)}, event); err != nil {
+		t.Fatalf("BroadcastToTopics() error = %v", err)
+	}
+
+	if got := len(roomClient.Channel); got != 1 {
+		t.Errorf("room client received %d events, want 1", got)
+	}
+	if got := len(otherClient.Channel); got != 0 {
+		t.Errorf("other client received %d events, want 0", got)
+	}
+}
+
+// TestParseTopic verifies topic kinds and IDs are recognized
+func TestParseTopic(t *testing.T) {
+	tests := []struct {
+		topic    string
+		wantKind TopicKind
+		wantID   int64
+		wantErr  bool
+	}{
+		{topic: "42", wantKind: TopicKindActiveGroup, wantID: 42},
+		{topic: "edu:5", wantKind: TopicKindEducationGroup, wantID: 5},
+		{topic: "room:12", wantKind: TopicKindRoom, wantID: 12},
+		{topic: "staff:9", wantKind: TopicKindStaff, wantID: 9},
+		{topic: "school", wantKind: TopicKindSchool, wantID: 0},
+		{topic: "room:", wantErr: true},
+		{topic: "room:abc", wantErr: true},
+		{topic: "edu:-1", wantErr: true},
+		{topic: "0", wantErr: true},
+		{topic: "unknown:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		kind, id, err := ParseTopic(tt.topic)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTopic(%q) expected error", tt.topic)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTopic(%q) error = %v", tt.topic, err)
+			continue
+		}
+		if kind != tt.wantKind || id != tt.wantID {
+			t.Errorf("ParseTopic(%q) = (%v, %v), want (%v, %v)", tt.topic, kind, id, tt.wantKind, tt.wantID)
+		}
+	}
+}
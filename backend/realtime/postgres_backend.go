@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	// DefaultNotifyChannel is the Postgres channel used for SSE fan-out
+	DefaultNotifyChannel = "phoenix_sse_events"
+
+	// maxNotifyPayload is the Postgres limit for NOTIFY payloads (bytes)
+	maxNotifyPayload = 8000
+)
+
+// notifyEnvelope is the NOTIFY payload; Origin lets each instance skip its own events
+type notifyEnvelope struct {
+	Origin string   `json:"origin"`
+	Topics []string `json:"topics"`
+	Event  Event    `json:"event"`
+}
+
+// PostgresBackend distributes events between replicas via Postgres NOTIFY/LISTEN
+type PostgresBackend struct {
+	db         *bun.DB
+	channel    string
+	instanceID string
+	logger     *slog.Logger
+}
+
+// NewPostgresBackend creates a backend that publishes on the given channel (DefaultNotifyChannel if empty).
+// The database must use the pgdriver connector.
+func NewPostgresBackend(db *bun.DB, channel string, logger *slog.Logger) *PostgresBackend {
+	if channel == "" {
+		channel = DefaultNotifyChannel
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PostgresBackend{
+		db:         db,
+		channel:    channel,
+		instanceID: newInstanceID(),
+		logger:     logger,
+	}
+}
+
+// newInstanceID returns a random identifier for this process
+func newInstanceID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Publish sends the event to all listening replicas
+func (b *PostgresBackend) Publish(ctx context.Context, topics []string, event Event) error {
+	payload, err := json.Marshal(notifyEnvelope{
+		Origin: b.instanceID,
+		Topics: topics,
+		Event:  event,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal SSE event: %w", err)
+	}
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("SSE event payload too large for NOTIFY: %d bytes", len(payload))
+	}
+
+	if err := pgdriver.Notify(ctx, b.db, b.channel, string(payload)); err != nil {
+		return fmt.Errorf("notify %s: %w", b.channel, err)
+	}
+	return nil
+}
+
+// Listen receives events from other replicas until ctx is canceled.
+// The pgdriver listener reconnects on its own after connection loss.
+func (b *PostgresBackend) Listen(ctx context.Context, deliver func(topics []string, event Event)) error {
+	ln := pgdriver.NewListener(b.db)
+	defer func() {
+		if err := ln.Close(); err != nil {
+			b.logger.Warn("failed to close SSE listener", slog.String("error", err.Error()))
+		}
+	}()
+
+	if err := ln.Listen(ctx, b.channel); err != nil {
+		return fmt.Errorf("listen %s: %w", b.channel, err)
+	}
+
+	notifications := ln.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+			b.handleNotification(n.Payload, deliver)
+		}
+	}
+}
+
+// handleNotification decodes a payload and delivers events from other instances
+func (b *PostgresBackend) handleNotification(payload string, deliver func(topics []string, event Event)) {
+	var envelope notifyEnvelope
+	if err := json.Unmarshal([]byte(payload), &envelope); err != nil {
+		b.logger.Warn("invalid SSE notification payload",
+			slog.String("channel", b.channel),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	if envelope.Origin == b.instanceID {
+		return // Already dispatched locally
+	}
+
+	deliver(envelope.Topics, envelope.Event)
+}
@@ -0,0 +1,99 @@
+package realtime_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moto-nrw/project-phoenix/realtime"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+)
+
+// startReplica creates a hub with a Postgres backend, as one server replica would
+func startReplica(t *testing.T, ctx context.Context, channel string) *realtime.Hub {
+	t.Helper()
+
+	db := testpkg.SetupTestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	hub := realtime.NewHub(slog.Default())
+	hub.SetBackend(realtime.NewPostgresBackend(db, channel, slog.Default()))
+	go func() { _ = hub.Run(ctx) }()
+	return hub
+}
+
+func subscribe(hub *realtime.Hub, groupID string) *realtime.Client {
+	client := &realtime.Client{
+		Channel:          make(chan realtime.Event, 10),
+		SubscribedGroups: make(map[string]bool),
+	}
+	hub.Register(client, []string{groupID})
+	return client
+}
+
+func drain(client *realtime.Client) {
+	for len(client.Channel) > 0 {
+		<-client.Channel
+	}
+}
+
+func TestPostgresBackend_FanOutBetweenReplicas(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Unique channel so parallel test runs don't see each other's events
+	channel := fmt.Sprintf("phoenix_sse_test_%d", time.Now().UnixNano())
+	replicaA := startReplica(t, ctx, channel)
+	replicaB := startReplica(t, ctx, channel)
+
+	clientA := subscribe(replicaA, "42")
+	clientB := subscribe(replicaB, "42")
+	otherGroup := subscribe(replicaB, "43")
+
+	studentID := "7"
+	event := realtime.NewEvent(realtime.EventStudentCheckIn, "42", realtime.EventData{StudentID: &studentID})
+
+	// LISTEN is set up asynchronously; retry until replica B receives the event
+	var received realtime.Event
+	require.Eventually(t, func() bool {
+		_ = replicaA.BroadcastToGroup("42", event)
+		select {
+		case received = <-clientB.Channel:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 100*time.Millisecond)
+
+	assert.Equal(t, realtime.EventStudentCheckIn, received.Type)
+	require.NotNil(t, received.Data.StudentID)
+	assert.Equal(t, studentID, *received.Data.StudentID)
+
+	// Each replica delivers exactly once per broadcast (A skips its own NOTIFY)
+	time.Sleep(500 * time.Millisecond)
+	drain(clientA)
+	drain(clientB)
+	require.NoError(t, replicaA.BroadcastToGroup("42", event))
+	time.Sleep(500 * time.Millisecond)
+	assert.Len(t, clientA.Channel, 1)
+	assert.Len(t, clientB.Channel, 1)
+
+	assert.Empty(t, otherGroup.Channel, "events must stay within their group")
+}
+
+func TestPostgresBackend_RejectsOversizedPayload(t *testing.T) {
+	// Size is checked before the database is touched
+	backend := realtime.NewPostgresBackend(nil, "", slog.Default())
+	hugeName := strings.Repeat("x", 9000)
+	event := realtime.NewEvent(realtime.EventActivityUpdate, "1", realtime.EventData{ActivityName: &hugeName})
+
+	err := backend.Publish(context.Background(), []string{"1"}, event)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too large")
+}
@@ -0,0 +1,107 @@
+package realtime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultReplayBufferSize is the number of recent events kept per group for replay
+	DefaultReplayBufferSize = 100
+
+	// DefaultReplayMaxAge is how long events stay replayable
+	DefaultReplayMaxAge = 10 * time.Minute
+
+	// pruneEvery controls how often (in recorded events) stale logs are trimmed
+	pruneEvery = 256
+)
+
+// replayableEvents are the event types a reconnecting client gets replayed
+var replayableEvents = map[EventType]bool{
+	EventStudentCheckIn:  true,
+	EventStudentCheckOut: true,
+	EventActivityStart:   true,
+	EventActivityEnd:     true,
+	EventActivityUpdate:  true,
+
+	EventStudentSick:      true,
+	EventPickupException:  true,
+	EventSupervisorChange: true,
+	EventDeviceOffline:    true,
+	EventDeviceOnline:     true,
+}
+
+// IsReplayable reports whether events of this type are kept for Last-Event-ID replay
+func IsReplayable(eventType EventType) bool {
+	return replayableEvents[eventType]
+}
+
+// loggedEvent is an event with its sequence number
+type loggedEvent struct {
+	seq   uint64
+	event Event
+}
+
+// groupLog is a bounded log of recent events for one group.
+// evictedUpTo is the highest sequence number that was dropped from the log;
+// a client that last saw an older event has missed something and must resync.
+type groupLog struct {
+	events      []loggedEvent
+	evictedUpTo uint64
+}
+
+// append adds an event, evicting the oldest one when the log is full
+func (l *groupLog) append(entry loggedEvent, capacity int) {
+	if len(l.events) >= capacity {
+		l.evictedUpTo = l.events[0].seq
+		l.events = append(l.events[:0], l.events[1:]...)
+	}
+	l.events = append(l.events, entry)
+}
+
+// pruneBefore evicts events older than the cutoff
+func (l *groupLog) pruneBefore(cutoff time.Time) {
+	n := 0
+	for n < len(l.events) && l.events[n].event.Timestamp.Before(cutoff) {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	l.evictedUpTo = l.events[n-1].seq
+	l.events = append(l.events[:0], l.events[n:]...)
+}
+
+// since returns the events after seq, or ok=false if some of them were already evicted
+func (l *groupLog) since(seq uint64) ([]loggedEvent, bool) {
+	if seq < l.evictedUpTo {
+		return nil, false
+	}
+
+	var missed []loggedEvent
+	for _, entry := range l.events {
+		if entry.seq > seq {
+			missed = append(missed, entry)
+		}
+	}
+	return missed, true
+}
+
+// formatEventID builds an event ID from the hub epoch and sequence number
+func formatEventID(epoch string, seq uint64) string {
+	return epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseEventID splits an event ID into epoch and sequence number
+func parseEventID(id string) (string, uint64, bool) {
+	i := strings.LastIndex(id, "-")
+	if i <= 0 {
+		return "", 0, false
+	}
+	seq, err := strconv.ParseUint(id[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return id[:i], seq, true
+}
@@ -0,0 +1,76 @@
+package realtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TopicKind identifies what a subscription topic refers to
+type TopicKind string
+
+// Topic kinds
+const (
+	TopicKindActiveGroup    TopicKind = "active_group"    // "<active_group_id>" - events of one session
+	TopicKindEducationGroup TopicKind = "education_group" // "edu:<group_id>" - all students of an OGS group, wherever they are
+	TopicKindRoom           TopicKind = "room"            // "room:<room_id>" - all sessions in a room
+	TopicKindStaff          TopicKind = "staff"           // "staff:<staff_id>" - all sessions a staff member supervises
+	TopicKindSchool         TopicKind = "school"          // "school" - school-wide, admins only
+)
+
+// Topic prefixes (active group topics are plain IDs for backwards compatibility)
+const (
+	topicPrefixEducationGroup = "edu:"
+	topicPrefixRoom           = "room:"
+	topicPrefixStaff          = "staff:"
+
+	// TopicSchool is the school-wide topic
+	TopicSchool = "school"
+)
+
+// ActiveGroupTopic returns the topic for an active group (session)
+func ActiveGroupTopic(activeGroupID int64) string {
+	return strconv.FormatInt(activeGroupID, 10)
+}
+
+// EducationGroupTopic returns the topic for an OGS education group
+func EducationGroupTopic(groupID int64) string {
+	return topicPrefixEducationGroup + strconv.FormatInt(groupID, 10)
+}
+
+// RoomTopic returns the topic for a room
+func RoomTopic(roomID int64) string {
+	return topicPrefixRoom + strconv.FormatInt(roomID, 10)
+}
+
+// StaffTopic returns the topic for a staff member's supervisions
+func StaffTopic(staffID int64) string {
+	return topicPrefixStaff + strconv.FormatInt(staffID, 10)
+}
+
+// ParseTopic returns the kind and ID of a topic (ID is 0 for the school topic)
+func ParseTopic(topic string) (TopicKind, int64, error) {
+	if topic == TopicSchool {
+		return TopicKindSchool, 0, nil
+	}
+
+	kind := TopicKindActiveGroup
+	rawID := topic
+	for prefix, k := range map[string]TopicKind{
+		topicPrefixEducationGroup: TopicKindEducationGroup,
+		topicPrefixRoom:           TopicKindRoom,
+		topicPrefixStaff:          TopicKindStaff,
+	} {
+		if strings.HasPrefix(topic, prefix) {
+			kind = k
+			rawID = strings.TrimPrefix(topic, prefix)
+			break
+		}
+	}
+
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("invalid topic %q", topic)
+	}
+	return kind, id, nil
+}
@@ -52,6 +52,9 @@ type ServiceDependencies struct {
 	GroupMappingRepo  active.GroupMappingRepository
 	AttendanceRepo    active.AttendanceRepository
 
+	// Optional: Student absences for the dashboard missing count
+	StudentAbsenceRepo active.StudentAbsenceRepository
+
 	// User domain repositories
 	StudentRepo userModels.StudentRepository
 	PersonRepo  userModels.PersonRepository
@@ -99,6 +102,7 @@ type service struct {
 
 	// New dependencies for attendance tracking
 	attendanceRepo   active.AttendanceRepository
+	absenceRepo      active.StudentAbsenceRepository
 	educationService education.Service
 	usersService     users.PersonService
 	teacherRepo      userModels.TeacherRepository
@@ -141,6 +145,7 @@ func NewService(deps ServiceDependencies) Service {
 		personRepo:         deps.PersonRepo,
 		deviceRepo:         deps.DeviceRepo,
 		attendanceRepo:     deps.AttendanceRepo,
+		absenceRepo:        deps.StudentAbsenceRepo,
 		educationService:   deps.EducationService,
 		usersService:       deps.UsersService,
 		teacherRepo:        deps.TeacherRepo,
@@ -169,6 +174,7 @@ func (s *service) WithTx(tx bun.Tx) interface{} {
 	var personRepo = s.personRepo
 	var deviceRepo = s.deviceRepo
 	var attendanceRepo = s.attendanceRepo
+	var absenceRepo = s.absenceRepo
 	var teacherRepo = s.teacherRepo
 	var staffRepo = s.staffRepo
 
@@ -212,6 +218,9 @@ func (s *service) WithTx(tx bun.Tx) interface{} {
 	if txRepo, ok := s.attendanceRepo.(base.TransactionalRepository); ok {
 		attendanceRepo = txRepo.WithTx(tx).(active.AttendanceRepository)
 	}
+	if txRepo, ok := s.absenceRepo.(base.TransactionalRepository); ok {
+		absenceRepo = txRepo.WithTx(tx).(active.StudentAbsenceRepository)
+	}
 	if txRepo, ok := s.teacherRepo.(base.TransactionalRepository); ok {
 		teacherRepo = txRepo.WithTx(tx).(userModels.TeacherRepository)
 	}
@@ -234,6 +243,7 @@ func (s *service) WithTx(tx bun.Tx) interface{} {
 		personRepo:         personRepo,
 		deviceRepo:         deviceRepo,
 		attendanceRepo:     attendanceRepo,
+		absenceRepo:        absenceRepo,
 		educationService:   s.educationService,
 		usersService:       s.usersService,
 		teacherRepo:        teacherRepo,
@@ -457,13 +467,15 @@ func (s *service) CreateVisit(ctx context.Context, visit *active.Visit) error {
 	}
 
 	// Validate active group exists before INSERT (prevents FK constraint errors in logs)
-	if err := s.validateActiveGroupExists(ctx, visit.ActiveGroupID); err != nil {
+	group, err := s.findActiveGroup(ctx, visit.ActiveGroupID)
+	if err != nil {
 		return &ActiveError{Op: "CreateVisit", Err: err}
 	}
 
 	deviceID, staffID := s.extractContextIDs(ctx)
 
-	err := s.txHandler.RunInTx(ctx, func(txCtx context.Context, tx bun.Tx) error {
+	var sicknessCleared *userModels.Student
+	err = s.txHandler.RunInTx(ctx, func(txCtx context.Context, tx bun.Tx) error {
 		txService := s.WithTx(tx).(*service)
 
 		// Ensure no existing active visit for this student
@@ -477,7 +489,7 @@ func (s *service) CreateVisit(ctx context.Context, visit *active.Visit) error {
 		}
 
 		// Auto-clear sickness when student checks in
-		txService.autoClearStudentSickness(txCtx, visit.StudentID)
+		sicknessCleared = txService.autoClearStudentSickness(txCtx, visit.StudentID)
 
 		// Create the visit record
 		if txService.visitRepo.Create(txCtx, visit) != nil {
@@ -494,8 +506,9 @@ func (s *service) CreateVisit(ctx context.Context, visit *active.Visit) error {
 		return &ActiveError{Op: "CreateVisit", Err: ErrDatabaseOperation}
 	}
 
-	// Broadcast SSE event (fire-and-forget, outside transaction)
-	s.broadcastVisitCreated(ctx, visit)
+	// Broadcast SSE events (fire-and-forget, outside transaction)
+	s.broadcastVisitCreated(ctx, visit, group.RoomID)
+	s.broadcastSicknessCleared(ctx, sicknessCleared)
 
 	return nil
 }
@@ -522,13 +535,20 @@ func (s *service) validateStudentExists(ctx context.Context, studentID int64) er
 
 // validateActiveGroupExists checks if an active group exists, returning appropriate errors
 func (s *service) validateActiveGroupExists(ctx context.Context, groupID int64) error {
-	if _, err := s.groupRepo.FindByID(ctx, groupID); err != nil {
+	_, err := s.findActiveGroup(ctx, groupID)
+	return err
+}
+
+// findActiveGroup loads an active group, returning appropriate errors
+func (s *service) findActiveGroup(ctx context.Context, groupID int64) (*active.Group, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
 		if isNotFoundError(err) {
-			return ErrActiveGroupNotFound
+			return nil, ErrActiveGroupNotFound
 		}
-		return err
+		return nil, err
 	}
-	return nil
+	return group, nil
 }
 
 // validateStaffExists checks if a staff member exists, returning appropriate errors
@@ -649,7 +669,8 @@ func (s *service) endVisitRecord(ctx context.Context, id int64) (*active.Visit,
 		return nil, &ActiveError{Op: "EndVisit", Err: ErrDatabaseOperation}
 	}
 
-	visit, err = s.visitRepo.FindByID(ctx, id)
+	// Reload with the active group, whose room the checkout event is sent to
+	visit, err = s.visitRepo.FindWithActiveGroup(ctx, id)
 	if err != nil || visit == nil {
 		return nil, &ActiveError{Op: "EndVisit", Err: ErrVisitNotFound}
 	}
@@ -676,33 +697,74 @@ func (s *service) broadcastVisitCheckout(ctx context.Context, endedVisit *active
 		},
 	)
 
-	s.broadcastWithLogging(activeGroupID, studentID, event, "student_checkout")
-	s.broadcastToEducationalGroup(studentRec, event)
+	var roomID int64
+	if endedVisit.ActiveGroup != nil {
+		roomID = endedVisit.ActiveGroup.RoomID
+	}
+	topics := sessionTopics(endedVisit.ActiveGroupID, roomID, s.sessionSupervisorIDs(ctx, endedVisit.ActiveGroupID, false))
+	s.broadcastWithLogging(withStudentTopic(topics, studentRec), studentID, event, "student_checkout")
 }
 
-// broadcastToEducationalGroup mirrors active-group broadcasts to the student's OGS group topic
-func (s *service) broadcastToEducationalGroup(student *userModels.Student, event realtime.Event) {
-	if s.broadcaster == nil || student == nil || student.GroupID == nil {
-		return
+// sessionTopics returns the SSE topics for events of an active group: the group itself,
+// its room, its supervisors and the school-wide topic
+func sessionTopics(activeGroupID, roomID int64, supervisorIDs []int64) []string {
+	topics := []string{realtime.ActiveGroupTopic(activeGroupID), realtime.TopicSchool}
+	if roomID > 0 {
+		topics = append(topics, realtime.RoomTopic(roomID))
 	}
-	groupID := fmt.Sprintf("edu:%d", *student.GroupID)
-	if err := s.broadcaster.BroadcastToGroup(groupID, event); err != nil {
-		studentID := ""
-		if event.Data.StudentID != nil {
-			studentID = *event.Data.StudentID
+
+	seen := make(map[int64]bool, len(supervisorIDs))
+	for _, staffID := range supervisorIDs {
+		if !seen[staffID] {
+			seen[staffID] = true
+			topics = append(topics, realtime.StaffTopic(staffID))
 		}
-		s.getLogger().Error(sseErrorMessage+" for educational topic",
+	}
+
+	return topics
+}
+
+// sessionSupervisorIDs loads the staff IDs supervising an active group for callers that do not
+// have them at hand. includeEnded also returns supervisors whose supervision already ended.
+func (s *service) sessionSupervisorIDs(ctx context.Context, activeGroupID int64, includeEnded bool) []int64 {
+	supervisors, err := s.supervisorRepo.FindByActiveGroupID(ctx, activeGroupID, !includeEnded)
+	if err != nil {
+		s.getLogger().Warn("failed to load supervisors for SSE topics",
+			slog.Int64("active_group_id", activeGroupID),
 			slog.String("error", err.Error()),
-			slog.String("event_type", string(event.Type)),
-			slog.String("education_group_topic", groupID),
-			slog.String("student_id", studentID),
 		)
+		return nil
+	}
+
+	ids := make([]int64, 0, len(supervisors))
+	for _, supervisor := range supervisors {
+		ids = append(ids, supervisor.StaffID)
 	}
+	return ids
+}
+
+// withStudentTopic adds the topic of the student's OGS group, so group teachers
+// see their students wherever they are
+func withStudentTopic(topics []string, student *userModels.Student) []string {
+	if student == nil || student.GroupID == nil {
+		return topics
+	}
+	return append(topics[:len(topics):len(topics)], realtime.EducationGroupTopic(*student.GroupID))
+}
+
+// broadcastSessionEndEvents sends checkout SSE events for each visit and the activity_end event
+// for a completed session. Supervisors whose supervision just ended are reached via their staff topic.
+func (s *service) broadcastSessionEndEvents(ctx context.Context, group *active.Group, supervisorIDs []int64, visitsToNotify []visitSSEData) {
+	topics := sessionTopics(group.ID, group.RoomID, supervisorIDs)
+	s.broadcastStudentCheckoutEvents(group.ID, topics, visitsToNotify)
+	s.broadcastActivityEndEvent(ctx, group, topics)
 }
 
 // broadcastStudentCheckoutEvents sends checkout SSE events for each visit.
 // This helper reduces cognitive complexity in session timeout processing.
-func (s *service) broadcastStudentCheckoutEvents(sessionIDStr string, visitsToNotify []visitSSEData) {
+func (s *service) broadcastStudentCheckoutEvents(sessionID int64, topics []string, visitsToNotify []visitSSEData) {
+	sessionIDStr := realtime.ActiveGroupTopic(sessionID)
+
 	for _, visitData := range visitsToNotify {
 		studentIDStr := fmt.Sprintf("%d", visitData.StudentID)
 		studentName := visitData.Name
@@ -716,26 +778,20 @@ func (s *service) broadcastStudentCheckoutEvents(sessionIDStr string, visitsToNo
 			},
 		)
 
-		s.broadcastWithLogging(sessionIDStr, studentIDStr, checkoutEvent, "student_checkout")
-		s.broadcastToEducationalGroup(visitData.Student, checkoutEvent)
+		s.broadcastWithLogging(withStudentTopic(topics, visitData.Student), studentIDStr, checkoutEvent, "student_checkout")
 	}
 }
 
 // broadcastActivityEndEvent sends the activity_end SSE event for a completed session.
 // This helper reduces cognitive complexity in session timeout processing.
-func (s *service) broadcastActivityEndEvent(ctx context.Context, sessionID int64, sessionIDStr string) {
-	finalGroup, err := s.groupRepo.FindByID(ctx, sessionID)
-	if err != nil || finalGroup == nil {
-		return
-	}
-
-	roomIDStr := fmt.Sprintf("%d", finalGroup.RoomID)
-	activityName := s.getActivityName(ctx, finalGroup.GroupID)
-	roomName := s.getRoomName(ctx, finalGroup.RoomID)
+func (s *service) broadcastActivityEndEvent(ctx context.Context, group *active.Group, topics []string) {
+	roomIDStr := fmt.Sprintf("%d", group.RoomID)
+	activityName := s.getActivityName(ctx, group.GroupID)
+	roomName := s.getRoomName(ctx, group.RoomID)
 
 	event := realtime.NewEvent(
 		realtime.EventActivityEnd,
-		sessionIDStr,
+		realtime.ActiveGroupTopic(group.ID),
 		realtime.EventData{
 			ActivityName: &activityName,
 			RoomID:       &roomIDStr,
@@ -743,16 +799,16 @@ func (s *service) broadcastActivityEndEvent(ctx context.Context, sessionID int64
 		},
 	)
 
-	s.broadcastWithLogging(sessionIDStr, "", event, "activity_end")
+	s.broadcastWithLogging(topics, "", event, "activity_end")
 }
 
-// broadcastWithLogging broadcasts an event and logs any errors.
-func (s *service) broadcastWithLogging(activeGroupID, studentID string, event realtime.Event, eventType string) {
-	if err := s.broadcaster.BroadcastToGroup(activeGroupID, event); err != nil {
+// broadcastWithLogging broadcasts an event to the topics and logs any errors.
+func (s *service) broadcastWithLogging(topics []string, studentID string, event realtime.Event, eventType string) {
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
 		attrs := []slog.Attr{
 			slog.String("error", err.Error()),
 			slog.String("event_type", eventType),
-			slog.String("active_group_id", activeGroupID),
+			slog.Any("topics", topics),
 		}
 		if studentID != "" {
 			attrs = append(attrs, slog.String("student_id", studentID))
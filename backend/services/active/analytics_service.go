@@ -165,6 +165,9 @@ func (s *service) GetDashboardAnalytics(ctx context.Context) (*DashboardAnalytic
 	// Phase 2: Calculate presence metrics
 	analytics.StudentsPresent = len(baseData.studentsPresent)
 	analytics.StudentsInTransit = calculateStudentsInTransit(baseData.studentsWithAttendance, baseData.studentsWithActiveVisits)
+	analytics.StudentsAbsent, analytics.StudentsMissing = calculateAbsentAndMissing(
+		baseData.enrolledStudents, baseData.studentsPresent, baseData.studentsAbsent,
+	)
 	analytics.TotalRooms = len(baseData.allRooms)
 	analytics.ActivityCategories = baseData.activityCategories
 	analytics.SupervisorsToday = baseData.supervisorsToday
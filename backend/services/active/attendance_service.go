@@ -9,6 +9,8 @@ import (
 	"github.com/moto-nrw/project-phoenix/auth/device"
 	"github.com/moto-nrw/project-phoenix/internal/timezone"
 	"github.com/moto-nrw/project-phoenix/models/active"
+	userSvc "github.com/moto-nrw/project-phoenix/services/users"
+	"github.com/uptrace/bun"
 )
 
 // Attendance tracking operations
@@ -79,6 +81,9 @@ func (s *service) GetStudentAttendanceStatus(ctx context.Context, studentID int6
 		CheckInTime:  &attendance.CheckInTime,
 		CheckOutTime: attendance.CheckOutTime,
 	}
+	if attendance.PickedUpByName != nil {
+		result.PickedUpBy = *attendance.PickedUpByName
+	}
 
 	s.populateAttendanceStaffNames(ctx, result, attendance)
 	return result, nil
@@ -131,7 +136,7 @@ func (s *service) ToggleStudentAttendance(ctx context.Context, studentID, staffI
 		return s.performCheckIn(ctx, studentID, authorizedStaffID, deviceID, now, today)
 	}
 
-	return s.performCheckOut(ctx, studentID, authorizedStaffID, now)
+	return s.performCheckOut(ctx, studentID, authorizedStaffID, now, nil)
 }
 
 // authorizeAttendanceToggle handles authorization and returns the staff ID to use
@@ -238,17 +243,82 @@ func (s *service) performCheckIn(ctx context.Context, studentID, staffID, device
 	}, nil
 }
 
+// CheckoutDetails are stored on the attendance record together with the checkout
+type CheckoutDetails struct {
+	PickedUpByGuardianID *int64
+	PickedUpByPersonID   *int64
+	PickedUpByName       *string
+	DepartedByBusRouteID *int64
+}
+
+// PickupCheckoutDetails records the verified pickup person with the checkout.
+// Returns nil when no pickup person was selected.
+func PickupCheckoutDetails(pickup *userSvc.AuthorizedPickup) *CheckoutDetails {
+	if pickup == nil {
+		return nil
+	}
+
+	id, name := pickup.ID, pickup.Name
+	details := &CheckoutDetails{PickedUpByName: &name}
+	switch pickup.Type {
+	case userSvc.PickupTypeGuardian:
+		details.PickedUpByGuardianID = &id
+	case userSvc.PickupTypePerson:
+		details.PickedUpByPersonID = &id
+	}
+	return details
+}
+
+// apply copies the details onto the attendance record
+func (d *CheckoutDetails) apply(attendance *active.Attendance) {
+	if d == nil {
+		return
+	}
+	if d.PickedUpByName != nil {
+		attendance.PickedUpByGuardianID = d.PickedUpByGuardianID
+		attendance.PickedUpByPersonID = d.PickedUpByPersonID
+		attendance.PickedUpByName = d.PickedUpByName
+	}
+	if d.DepartedByBusRouteID != nil {
+		attendance.DepartedByBusRouteID = d.DepartedByBusRouteID
+	}
+}
+
+// CheckOutStudent checks out a checked-in student. The details are written in the same
+// transaction as the checkout, so either both are stored or neither.
+func (s *service) CheckOutStudent(ctx context.Context, studentID, staffID, deviceID int64, skipAuthCheck bool, details *CheckoutDetails) (*AttendanceResult, error) {
+	authorizedStaffID, err := s.authorizeAttendanceToggle(ctx, studentID, staffID, deviceID, skipAuthCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *AttendanceResult
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*service)
+		result, err = txService.performCheckOut(ctx, studentID, authorizedStaffID, time.Now(), details)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // performCheckOut updates attendance record for check-out
-func (s *service) performCheckOut(ctx context.Context, studentID, staffID int64, now time.Time) (*AttendanceResult, error) {
+func (s *service) performCheckOut(ctx context.Context, studentID, staffID int64, now time.Time, details *CheckoutDetails) (*AttendanceResult, error) {
 	attendance, err := s.attendanceRepo.GetStudentCurrentStatus(ctx, studentID)
 	if err != nil {
 		return nil, &ActiveError{Op: "ToggleStudentAttendance", Err: err}
 	}
+	if attendance.CheckOutTime != nil {
+		return nil, &ActiveError{Op: "ToggleStudentAttendance", Err: ErrStudentNotCheckedIn}
+	}
 
 	attendance.CheckOutTime = &now
 	if staffID > 0 {
 		attendance.CheckedOutBy = &staffID
 	}
+	details.apply(attendance)
 
 	if err := s.attendanceRepo.Update(ctx, attendance); err != nil {
 		return nil, &ActiveError{Op: "ToggleStudentAttendance", Err: fmt.Errorf("database error during update: %w", err)}
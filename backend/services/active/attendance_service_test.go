@@ -27,6 +27,8 @@ import (
 	"github.com/moto-nrw/project-phoenix/auth/device"
 	"github.com/moto-nrw/project-phoenix/internal/timezone"
 	"github.com/moto-nrw/project-phoenix/models/active"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	userSvc "github.com/moto-nrw/project-phoenix/services/users"
 	testpkg "github.com/moto-nrw/project-phoenix/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -305,6 +307,59 @@ func TestToggleStudentAttendance_CheckOut(t *testing.T) {
 	assert.NotZero(t, result.AttendanceID)
 }
 
+// TestCheckOutStudent_RecordsPickupPerson tests that the pickup person is stored with the checkout
+func TestCheckOutStudent_RecordsPickupPerson(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	service := setupActiveService(t, db)
+	ctx := context.Background()
+
+	// ARRANGE: Create a checked-in student
+	student := testpkg.CreateTestStudent(t, db, "Pickup", "CheckOut", "4p")
+	staff := testpkg.CreateTestStaff(t, db, "Pickup", "Staff")
+	device := testpkg.CreateTestDevice(t, db, "toggle-device-pickup")
+	defer testpkg.CleanupActivityFixtures(t, db, student.ID, staff.ID, device.ID)
+
+	testpkg.CreateTestAttendance(t, db, student.ID, staff.ID, device.ID, time.Now().Add(-1*time.Hour), nil)
+
+	// ACT: Only the name, so no guardian or pickup person fixture is needed
+	name := "Erika Zimmer"
+	result, err := service.CheckOutStudent(ctx, student.ID, staff.ID, device.ID, true, &activeSvc.CheckoutDetails{
+		PickedUpByName: &name,
+	})
+
+	// ASSERT
+	require.NoError(t, err)
+	assert.Equal(t, "checked_out", result.Action)
+
+	status, err := service.GetStudentAttendanceStatus(ctx, student.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "checked_out", status.Status)
+	assert.Equal(t, "Erika Zimmer", status.PickedUpBy)
+
+	// A second checkout finds no checked-in record
+	_, err = service.CheckOutStudent(ctx, student.ID, staff.ID, device.ID, true, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, activeSvc.ErrStudentNotCheckedIn)
+}
+
+// TestPickupCheckoutDetails tests the mapping of a verified pickup person to checkout details
+func TestPickupCheckoutDetails(t *testing.T) {
+	assert.Nil(t, activeSvc.PickupCheckoutDetails(nil))
+
+	guardian := activeSvc.PickupCheckoutDetails(&userSvc.AuthorizedPickup{Type: userSvc.PickupTypeGuardian, ID: 31, Name: "Erika Zimmer"})
+	require.NotNil(t, guardian.PickedUpByGuardianID)
+	assert.Equal(t, int64(31), *guardian.PickedUpByGuardianID)
+	assert.Nil(t, guardian.PickedUpByPersonID)
+	assert.Equal(t, "Erika Zimmer", *guardian.PickedUpByName)
+
+	person := activeSvc.PickupCheckoutDetails(&userSvc.AuthorizedPickup{Type: userSvc.PickupTypePerson, ID: 42, Name: "Inge Zimmer"})
+	require.NotNil(t, person.PickedUpByPersonID)
+	assert.Equal(t, int64(42), *person.PickedUpByPersonID)
+	assert.Nil(t, person.PickedUpByGuardianID)
+}
+
 // TestToggleStudentAttendance_CheckOutWithZeroStaffID tests checking out when staffID is 0.
 // This exercises the `if staffID > 0` guard in performCheckOut.
 func TestToggleStudentAttendance_CheckOutWithZeroStaffID(t *testing.T) {
@@ -0,0 +1,570 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/auth/device"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	activityModels "github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	"github.com/uptrace/bun"
+)
+
+// DefaultBusReminderLead is how long before the departure bus children are reminded to leave
+const DefaultBusReminderLead = 10 * time.Minute
+
+// BusReminderResult summarizes one run of the bus departure reminder
+type BusReminderResult struct {
+	RoutesDue        int `json:"routes_due"`
+	StudentsNotified int `json:"students_notified"`
+}
+
+// BusCheckoutResult reports the outcome of the bulk checkout of a bus route
+type BusCheckoutResult struct {
+	RouteID       int64      `json:"route_id"`
+	RouteName     string     `json:"route_name"`
+	DepartureTime *time.Time `json:"departure_time,omitempty"` // nil when the bus does not run today
+	CheckedOut    []int64    `json:"checked_out"`              // Students recorded as departed by bus
+	NotPresent    []int64    `json:"not_present"`              // Assigned students not checked in today
+	Failed        []int64    `json:"failed"`                   // Students whose checkout failed or who the staff member may not check out
+}
+
+// BusRouteService manages bus routes and the departure of bus children
+type BusRouteService interface {
+	// ListRoutes returns all bus routes with their departures and assigned students
+	ListRoutes(ctx context.Context) ([]*scheduleModels.BusRoute, error)
+
+	// GetRoute returns a bus route with its departures and assigned students
+	GetRoute(ctx context.Context, id int64) (*scheduleModels.BusRoute, error)
+
+	// CreateRoute creates a bus route together with its departures
+	CreateRoute(ctx context.Context, route *scheduleModels.BusRoute) error
+
+	// UpdateRoute updates a bus route and replaces its departures
+	UpdateRoute(ctx context.Context, route *scheduleModels.BusRoute) error
+
+	// DeleteRoute removes a bus route with its departures and assignments
+	DeleteRoute(ctx context.Context, id int64) error
+
+	// AssignStudents replaces the students of a route. Only bus children (Student.Bus) can be assigned.
+	AssignStudents(ctx context.Context, routeID int64, studentIDs []int64) (*scheduleModels.BusRoute, error)
+
+	// DispatchBusReminders notifies the supervising staff once per day when a checked-in
+	// bus child should leave for the bus
+	DispatchBusReminders(ctx context.Context, now time.Time) (*BusReminderResult, error)
+
+	// CheckoutRoute checks out the checked-in students of a route and records them as departed by bus.
+	// Each student is only checked out when the staff member is their group teacher or supervises
+	// their current session.
+	CheckoutRoute(ctx context.Context, routeID int64, staff *userModels.Staff, now time.Time) (*BusCheckoutResult, error)
+}
+
+// BusCheckoutPerformer ends visits and checks students out (implemented by the active service)
+type BusCheckoutPerformer interface {
+	EndVisit(ctx context.Context, id int64) error
+	CheckOutStudent(ctx context.Context, studentID, staffID, deviceID int64, skipAuthCheck bool, details *CheckoutDetails) (*AttendanceResult, error)
+}
+
+// BusRouteServiceDependencies contains all dependencies required by the bus route service
+type BusRouteServiceDependencies struct {
+	BusRouteRepo   scheduleModels.BusRouteRepository
+	AttendanceRepo activeModels.AttendanceRepository
+	VisitRepo      activeModels.VisitRepository
+	StudentRepo    userModels.StudentRepository
+	NotifiedRepo   activeModels.DispatchedNotificationRepository // Sent reminders, one per student and day
+	Checkout       BusCheckoutPerformer
+	Broadcaster    realtime.Broadcaster // Reminders are skipped when nil
+	ReminderLead   time.Duration        // 0 uses DefaultBusReminderLead
+	Logger         *slog.Logger
+	DB             *bun.DB
+}
+
+// busRouteService implements BusRouteService
+type busRouteService struct {
+	busRouteRepo   scheduleModels.BusRouteRepository
+	attendanceRepo activeModels.AttendanceRepository
+	visitRepo      activeModels.VisitRepository
+	studentRepo    userModels.StudentRepository
+	notifiedRepo   activeModels.DispatchedNotificationRepository
+	checkout       BusCheckoutPerformer
+	broadcaster    realtime.Broadcaster
+	reminderLead   time.Duration
+	logger         *slog.Logger
+	txHandler      *base.TxHandler
+}
+
+// NewBusRouteService creates a new bus route service
+func NewBusRouteService(deps BusRouteServiceDependencies) BusRouteService {
+	reminderLead := deps.ReminderLead
+	if reminderLead <= 0 {
+		reminderLead = DefaultBusReminderLead
+	}
+	return &busRouteService{
+		busRouteRepo:   deps.BusRouteRepo,
+		attendanceRepo: deps.AttendanceRepo,
+		visitRepo:      deps.VisitRepo,
+		studentRepo:    deps.StudentRepo,
+		notifiedRepo:   deps.NotifiedRepo,
+		checkout:       deps.Checkout,
+		broadcaster:    deps.Broadcaster,
+		reminderLead:   reminderLead,
+		logger:         deps.Logger,
+		txHandler:      base.NewTxHandler(deps.DB),
+	}
+}
+
+// WithTx returns a new service that uses the provided transaction
+func (s *busRouteService) WithTx(tx bun.Tx) interface{} {
+	var busRouteRepo = s.busRouteRepo
+	if txRepo, ok := s.busRouteRepo.(base.TransactionalRepository); ok {
+		busRouteRepo = txRepo.WithTx(tx).(scheduleModels.BusRouteRepository)
+	}
+
+	return &busRouteService{
+		busRouteRepo:   busRouteRepo,
+		attendanceRepo: s.attendanceRepo,
+		visitRepo:      s.visitRepo,
+		studentRepo:    s.studentRepo,
+		notifiedRepo:   s.notifiedRepo,
+		checkout:       s.checkout,
+		broadcaster:    s.broadcaster,
+		reminderLead:   s.reminderLead,
+		logger:         s.logger,
+		txHandler:      s.txHandler.WithTx(tx),
+	}
+}
+
+// getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
+func (s *busRouteService) getLogger() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return slog.Default()
+}
+
+// ListRoutes returns all bus routes with their departures and assigned students
+func (s *busRouteService) ListRoutes(ctx context.Context) ([]*scheduleModels.BusRoute, error) {
+	routes, err := s.busRouteRepo.List(ctx, nil)
+	if err != nil {
+		return nil, &ActiveError{Op: "list bus routes", Err: err}
+	}
+	if err := s.attachRouteDetails(ctx, routes); err != nil {
+		return nil, &ActiveError{Op: "list bus routes", Err: err}
+	}
+	return routes, nil
+}
+
+// GetRoute returns a bus route with its departures and assigned students
+func (s *busRouteService) GetRoute(ctx context.Context, id int64) (*scheduleModels.BusRoute, error) {
+	route, err := s.busRouteRepo.FindByID(ctx, id)
+	if err != nil || route == nil {
+		return nil, &ActiveError{Op: "get bus route", Err: ErrBusRouteNotFound}
+	}
+	if err := s.attachRouteDetails(ctx, []*scheduleModels.BusRoute{route}); err != nil {
+		return nil, &ActiveError{Op: "get bus route", Err: err}
+	}
+	return route, nil
+}
+
+// attachRouteDetails loads the departures and assigned students of the routes
+func (s *busRouteService) attachRouteDetails(ctx context.Context, routes []*scheduleModels.BusRoute) error {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*scheduleModels.BusRoute, len(routes))
+	routeIDs := make([]int64, 0, len(routes))
+	for _, r := range routes {
+		r.Departures = []*scheduleModels.BusRouteDeparture{}
+		r.StudentIDs = []int64{}
+		byID[r.ID] = r
+		routeIDs = append(routeIDs, r.ID)
+	}
+
+	departures, err := s.busRouteRepo.FindDeparturesByRouteIDs(ctx, routeIDs)
+	if err != nil {
+		return err
+	}
+	for _, d := range departures {
+		if r, ok := byID[d.BusRouteID]; ok {
+			r.Departures = append(r.Departures, d)
+		}
+	}
+
+	assignments, err := s.busRouteRepo.FindStudentsByRouteIDs(ctx, routeIDs)
+	if err != nil {
+		return err
+	}
+	for _, a := range assignments {
+		if r, ok := byID[a.BusRouteID]; ok {
+			r.StudentIDs = append(r.StudentIDs, a.StudentID)
+		}
+	}
+	return nil
+}
+
+// CreateRoute creates a bus route together with its departures
+func (s *busRouteService) CreateRoute(ctx context.Context, route *scheduleModels.BusRoute) error {
+	route.Name = strings.TrimSpace(route.Name)
+	if err := validateDepartures(route.Departures); err != nil {
+		return &ActiveError{Op: "create bus route", Err: err}
+	}
+
+	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*busRouteService)
+		if err := txService.busRouteRepo.Create(ctx, route); err != nil {
+			return err
+		}
+		return txService.busRouteRepo.ReplaceDepartures(ctx, route.ID, route.Departures)
+	})
+	if err != nil {
+		return &ActiveError{Op: "create bus route", Err: err}
+	}
+	return nil
+}
+
+// UpdateRoute updates a bus route and replaces its departures
+func (s *busRouteService) UpdateRoute(ctx context.Context, route *scheduleModels.BusRoute) error {
+	route.Name = strings.TrimSpace(route.Name)
+	if err := validateDepartures(route.Departures); err != nil {
+		return &ActiveError{Op: "update bus route", Err: err}
+	}
+
+	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*busRouteService)
+		if err := txService.busRouteRepo.Update(ctx, route); err != nil {
+			return err
+		}
+		return txService.busRouteRepo.ReplaceDepartures(ctx, route.ID, route.Departures)
+	})
+	if err != nil {
+		return &ActiveError{Op: "update bus route", Err: err}
+	}
+	return nil
+}
+
+// validateDepartures checks each departure and rejects two departures on the same weekday
+func validateDepartures(departures []*scheduleModels.BusRouteDeparture) error {
+	seen := make(map[int]bool, len(departures))
+	for _, d := range departures {
+		if err := d.Validate(); err != nil {
+			return err
+		}
+		if seen[d.Weekday] {
+			return fmt.Errorf("%w: more than one departure on weekday %d", ErrInvalidData, d.Weekday)
+		}
+		seen[d.Weekday] = true
+	}
+	return nil
+}
+
+// DeleteRoute removes a bus route with its departures and assignments
+func (s *busRouteService) DeleteRoute(ctx context.Context, id int64) error {
+	if err := s.busRouteRepo.Delete(ctx, id); err != nil {
+		return &ActiveError{Op: "delete bus route", Err: err}
+	}
+	return nil
+}
+
+// AssignStudents replaces the students of a route. Only bus children (Student.Bus) can be assigned.
+func (s *busRouteService) AssignStudents(ctx context.Context, routeID int64, studentIDs []int64) (*scheduleModels.BusRoute, error) {
+	if _, err := s.GetRoute(ctx, routeID); err != nil {
+		return nil, err
+	}
+
+	unique := make([]int64, 0, len(studentIDs))
+	seen := make(map[int64]bool, len(studentIDs))
+	for _, studentID := range studentIDs {
+		if !seen[studentID] {
+			seen[studentID] = true
+			unique = append(unique, studentID)
+		}
+	}
+
+	infos, err := s.studentRepo.FindByIDsWithGroups(ctx, unique)
+	if err != nil {
+		return nil, &ActiveError{Op: "assign bus students", Err: err}
+	}
+	students := make(map[int64]*userModels.Student, len(infos))
+	for _, info := range infos {
+		students[info.Student.ID] = info.Student
+	}
+
+	for _, studentID := range unique {
+		student := students[studentID]
+		if student == nil {
+			return nil, &ActiveError{Op: "assign bus students", Err: ErrStudentNotFound}
+		}
+		if student.Bus == nil || !*student.Bus {
+			return nil, &ActiveError{Op: "assign bus students", Err: fmt.Errorf("%w: student %d", ErrStudentNotBusChild, studentID)}
+		}
+	}
+
+	if err := s.busRouteRepo.ReplaceStudents(ctx, routeID, unique); err != nil {
+		return nil, &ActiveError{Op: "assign bus students", Err: err}
+	}
+	return s.GetRoute(ctx, routeID)
+}
+
+// busRouteDue is a route whose bus departs today within the reminder lead time
+type busRouteDue struct {
+	route      *scheduleModels.BusRoute
+	departAt   time.Time
+	studentIDs []int64 // Assigned students checked in today
+}
+
+// DispatchBusReminders notifies the supervising staff once per day when a checked-in
+// bus child should leave for the bus
+func (s *busRouteService) DispatchBusReminders(ctx context.Context, now time.Time) (*BusReminderResult, error) {
+	result := &BusReminderResult{}
+	if s.broadcaster == nil || s.notifiedRepo == nil {
+		return result, nil
+	}
+
+	due, err := s.findDueRoutes(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(due) == 0 {
+		return result, nil
+	}
+	result.RoutesDue = len(due)
+
+	var studentIDs []int64
+	for _, d := range due {
+		studentIDs = append(studentIDs, d.studentIDs...)
+	}
+	if len(studentIDs) == 0 {
+		return result, nil
+	}
+
+	students, err := s.studentRepo.FindByIDsWithGroups(ctx, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get students: %w", err)
+	}
+	studentInfo := make(map[int64]*userModels.StudentWithGroupInfo, len(students))
+	for _, st := range students {
+		studentInfo[st.ID] = st
+	}
+
+	visits, err := s.visitRepo.GetCurrentByStudentIDs(ctx, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current visits: %w", err)
+	}
+
+	for _, d := range due {
+		for _, studentID := range d.studentIDs {
+			if !s.markNotified(ctx, now, studentID) {
+				continue
+			}
+			s.broadcastBusDeparture(d, studentID, studentInfo[studentID], visits[studentID])
+			result.StudentsNotified++
+		}
+	}
+	return result, nil
+}
+
+// findDueRoutes returns the active routes departing today within the reminder lead time
+// together with their checked-in students
+func (s *busRouteService) findDueRoutes(ctx context.Context, now time.Time) ([]*busRouteDue, error) {
+	routes, err := s.ListRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	date := timezone.DateOf(now)
+	weekday := activityModels.ISOWeekday(date)
+	var due []*busRouteDue
+	for _, r := range routes {
+		if !r.IsActive || len(r.StudentIDs) == 0 {
+			continue
+		}
+		departure := r.DepartureOn(weekday)
+		if departure == nil {
+			continue
+		}
+		departAt := pickupTimeOnDate(date, departure.DepartureTime)
+		if now.Before(departAt.Add(-s.reminderLead)) || !now.Before(departAt) {
+			continue
+		}
+		due = append(due, &busRouteDue{route: r, departAt: departAt})
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	present, err := s.checkedInToday(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range due {
+		for _, studentID := range d.route.StudentIDs {
+			if present[studentID] {
+				d.studentIDs = append(d.studentIDs, studentID)
+			}
+		}
+	}
+	return due, nil
+}
+
+// checkedInToday returns the students checked in today who have not been checked out
+func (s *busRouteService) checkedInToday(ctx context.Context, now time.Time) (map[int64]bool, error) {
+	attendance, err := s.attendanceRepo.FindForDate(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	present := make(map[int64]bool)
+	for _, studentID := range checkedInStudentIDs(attendance) {
+		present[studentID] = true
+	}
+	return present, nil
+}
+
+// markNotified records a sent reminder and reports whether it is new today. The record is
+// persisted, so restarts and other replicas do not remind the student again; when it cannot
+// be stored the reminder is skipped and retried on the next run.
+func (s *busRouteService) markNotified(ctx context.Context, now time.Time, studentID int64) bool {
+	isNew, err := s.notifiedRepo.MarkDispatched(ctx, studentID, string(realtime.EventBusDepartureDue), timezone.DateOfUTC(now))
+	if err != nil {
+		s.getLogger().WarnContext(ctx, "failed to record bus reminder",
+			slog.Int64("student_id", studentID),
+			slog.Any("error", err))
+		return false
+	}
+	return isNew
+}
+
+// broadcastBusDeparture sends bus_departure_due to the session the student is in and the OGS group
+func (s *busRouteService) broadcastBusDeparture(d *busRouteDue, studentID int64, info *userModels.StudentWithGroupInfo, visit *activeModels.Visit) {
+	id := strconv.FormatInt(studentID, 10)
+	routeID := strconv.FormatInt(d.route.ID, 10)
+	routeName := d.route.Name
+	departureTime := d.departAt.Format("15:04")
+	data := realtime.EventData{
+		StudentID:     &id,
+		BusRouteID:    &routeID,
+		BusRouteName:  &routeName,
+		DepartureTime: &departureTime,
+	}
+
+	var topics []string
+	activeGroupID := ""
+	if visit != nil {
+		activeGroupID = realtime.ActiveGroupTopic(visit.ActiveGroupID)
+		topics = append(topics, activeGroupID)
+	}
+	if info != nil {
+		if info.Person != nil {
+			name := fmt.Sprintf("%s %s", info.Person.FirstName, info.Person.LastName)
+			data.StudentName = &name
+		}
+		if info.SchoolClass != "" {
+			schoolClass := info.SchoolClass
+			data.SchoolClass = &schoolClass
+		}
+		if info.GroupName != "" {
+			groupName := info.GroupName
+			data.GroupName = &groupName
+		}
+		if info.GroupID != nil {
+			topics = append(topics, realtime.EducationGroupTopic(*info.GroupID))
+		}
+	}
+	if len(topics) == 0 {
+		return
+	}
+
+	event := realtime.NewEvent(realtime.EventBusDepartureDue, activeGroupID, data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		s.getLogger().Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(realtime.EventBusDepartureDue)),
+			slog.String("student_id", id),
+		)
+	}
+}
+
+// CheckoutRoute checks out the checked-in students of a route and records them as departed by bus.
+// Each student is only checked out when the staff member is their group teacher or supervises
+// their current session.
+func (s *busRouteService) CheckoutRoute(ctx context.Context, routeID int64, staff *userModels.Staff, now time.Time) (*BusCheckoutResult, error) {
+	// Embed staff in context so ended visits record who checked the students out
+	ctx = context.WithValue(ctx, device.CtxStaff, staff)
+
+	route, err := s.GetRoute(ctx, routeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BusCheckoutResult{
+		RouteID:    route.ID,
+		RouteName:  route.Name,
+		CheckedOut: []int64{},
+		NotPresent: []int64{},
+		Failed:     []int64{},
+	}
+	if departure := route.DepartureOn(activityModels.ISOWeekday(timezone.DateOf(now))); departure != nil {
+		departAt := pickupTimeOnDate(timezone.DateOf(now), departure.DepartureTime)
+		result.DepartureTime = &departAt
+	}
+	if len(route.StudentIDs) == 0 {
+		return result, nil
+	}
+
+	present, err := s.checkedInToday(ctx, now)
+	if err != nil {
+		return nil, &ActiveError{Op: "bus checkout", Err: err}
+	}
+	visits, err := s.visitRepo.GetCurrentByStudentIDs(ctx, route.StudentIDs)
+	if err != nil {
+		return nil, &ActiveError{Op: "bus checkout", Err: err}
+	}
+
+	for _, studentID := range route.StudentIDs {
+		if !present[studentID] {
+			result.NotPresent = append(result.NotPresent, studentID)
+			continue
+		}
+		if s.checkoutBusStudent(ctx, route.ID, studentID, staff.ID, visits[studentID]) {
+			result.CheckedOut = append(result.CheckedOut, studentID)
+		} else {
+			result.Failed = append(result.Failed, studentID)
+		}
+	}
+	return result, nil
+}
+
+// checkoutBusStudent checks the student out with the bus route recorded in the same transaction,
+// then ends the student's visit. Returns false when the checkout failed or the staff member may
+// not check the student out.
+func (s *busRouteService) checkoutBusStudent(ctx context.Context, routeID, studentID, staffID int64, visit *activeModels.Visit) bool {
+	// Checked out before the visit ends: supervisors are authorized through the student's
+	// current session, which must still be open
+	details := &CheckoutDetails{DepartedByBusRouteID: &routeID}
+	if _, err := s.checkout.CheckOutStudent(ctx, studentID, staffID, 0, false, details); err != nil {
+		s.getLogger().WarnContext(ctx, "bus checkout failed",
+			slog.Int64("student_id", studentID),
+			slog.String("error", err.Error()),
+		)
+		return false
+	}
+
+	if visit != nil {
+		if err := s.checkout.EndVisit(ctx, visit.ID); err != nil {
+			s.getLogger().WarnContext(ctx, "failed to end visit during bus checkout",
+				slog.Int64("visit_id", visit.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return true
+}
@@ -0,0 +1,307 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/auth/device"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// Mocks (prefixed with bus). Repository mocks embed the interface; methods
+// the bus route service does not use panic when called.
+// ============================================================================
+
+type busRouteRepoMock struct {
+	route            *scheduleModels.BusRoute
+	departures       []*scheduleModels.BusRouteDeparture
+	studentIDs       []int64
+	replacedStudents []int64
+}
+
+func (m *busRouteRepoMock) Create(_ context.Context, _ *scheduleModels.BusRoute) error {
+	return nil
+}
+func (m *busRouteRepoMock) FindByID(_ context.Context, id any) (*scheduleModels.BusRoute, error) {
+	if m.route == nil || id != m.route.ID {
+		return nil, errors.New("not found")
+	}
+	return m.route, nil
+}
+func (m *busRouteRepoMock) Update(_ context.Context, _ *scheduleModels.BusRoute) error {
+	return nil
+}
+func (m *busRouteRepoMock) Delete(_ context.Context, _ any) error {
+	return nil
+}
+func (m *busRouteRepoMock) List(_ context.Context, _ *base.QueryOptions) ([]*scheduleModels.BusRoute, error) {
+	return []*scheduleModels.BusRoute{m.route}, nil
+}
+func (m *busRouteRepoMock) FindDeparturesByRouteIDs(_ context.Context, _ []int64) ([]*scheduleModels.BusRouteDeparture, error) {
+	return m.departures, nil
+}
+func (m *busRouteRepoMock) ReplaceDepartures(_ context.Context, _ int64, _ []*scheduleModels.BusRouteDeparture) error {
+	return nil
+}
+func (m *busRouteRepoMock) FindStudentsByRouteIDs(_ context.Context, _ []int64) ([]*scheduleModels.BusRouteStudent, error) {
+	assignments := make([]*scheduleModels.BusRouteStudent, 0, len(m.studentIDs))
+	for _, id := range m.studentIDs {
+		assignments = append(assignments, &scheduleModels.BusRouteStudent{BusRouteID: m.route.ID, StudentID: id})
+	}
+	return assignments, nil
+}
+func (m *busRouteRepoMock) ReplaceStudents(_ context.Context, _ int64, studentIDs []int64) error {
+	m.replacedStudents = studentIDs
+	return nil
+}
+
+type busAttendanceRepoMock struct {
+	activeModels.AttendanceRepository
+	records []*activeModels.Attendance
+}
+
+func (m *busAttendanceRepoMock) FindForDate(_ context.Context, _ time.Time) ([]*activeModels.Attendance, error) {
+	return m.records, nil
+}
+
+type busVisitRepoMock struct {
+	activeModels.VisitRepository
+	visits map[int64]*activeModels.Visit
+}
+
+func (m *busVisitRepoMock) GetCurrentByStudentIDs(_ context.Context, _ []int64) (map[int64]*activeModels.Visit, error) {
+	return m.visits, nil
+}
+
+type busStudentRepoMock struct {
+	userModels.StudentRepository
+	students map[int64]*userModels.Student
+}
+
+func (m *busStudentRepoMock) FindByID(_ context.Context, id any) (*userModels.Student, error) {
+	if st, ok := m.students[id.(int64)]; ok {
+		return st, nil
+	}
+	return nil, errors.New("not found")
+}
+func (m *busStudentRepoMock) FindByIDsWithGroups(_ context.Context, ids []int64) ([]*userModels.StudentWithGroupInfo, error) {
+	result := make([]*userModels.StudentWithGroupInfo, 0, len(ids))
+	for _, id := range ids {
+		if st, ok := m.students[id]; ok {
+			result = append(result, &userModels.StudentWithGroupInfo{Student: st, GroupName: "Sonnengruppe"})
+		}
+	}
+	return result, nil
+}
+
+// busCheckoutMock denies students in forbidden unless the authorization check is skipped
+type busCheckoutMock struct {
+	forbidden   map[int64]bool
+	endedVisits []int64
+	endedBy     []*userModels.Staff
+	checkedOut  []int64
+	details     []*CheckoutDetails
+}
+
+func (m *busCheckoutMock) EndVisit(ctx context.Context, id int64) error {
+	m.endedVisits = append(m.endedVisits, id)
+	m.endedBy = append(m.endedBy, device.StaffFromCtx(ctx))
+	return nil
+}
+func (m *busCheckoutMock) CheckOutStudent(_ context.Context, studentID, _, _ int64, skipAuthCheck bool, details *CheckoutDetails) (*AttendanceResult, error) {
+	if m.forbidden[studentID] && !skipAuthCheck {
+		return nil, errors.New("teacher does not have access to this student")
+	}
+	m.checkedOut = append(m.checkedOut, studentID)
+	m.details = append(m.details, details)
+	return &AttendanceResult{Action: "checked_out", AttendanceID: studentID + 50, StudentID: studentID}, nil
+}
+
+type busBroadcaster struct {
+	topics [][]string
+	events []realtime.Event
+}
+
+func (b *busBroadcaster) BroadcastToGroup(activeGroupID string, event realtime.Event) error {
+	return b.BroadcastToTopics([]string{activeGroupID}, event)
+}
+func (b *busBroadcaster) BroadcastToTopics(topics []string, event realtime.Event) error {
+	b.topics = append(b.topics, topics)
+	b.events = append(b.events, event)
+	return nil
+}
+
+// busFixture is a route departing Wednesdays at 15:40 with student 20 checked in and student 21 absent
+type busFixture struct {
+	routes      *busRouteRepoMock
+	notified    *dispatchedNotificationRepo
+	attendance  *busAttendanceRepoMock
+	checkout    *busCheckoutMock
+	broadcaster *busBroadcaster
+	svc         BusRouteService
+}
+
+func newBusFixture() *busFixture {
+	groupID := int64(40)
+	isBus := true
+	notBus := false
+	checkIn := time.Date(2026, 3, 4, 8, 0, 0, 0, timezone.Berlin)
+
+	f := &busFixture{
+		routes: &busRouteRepoMock{
+			route: &scheduleModels.BusRoute{Model: base.Model{ID: 10}, Name: "Linie 12 Nord", IsActive: true},
+			departures: []*scheduleModels.BusRouteDeparture{
+				{BusRouteID: 10, Weekday: scheduleModels.WeekdayWednesday, DepartureTime: time.Date(0, 1, 1, 15, 40, 0, 0, time.UTC)},
+			},
+			studentIDs: []int64{20, 21},
+		},
+		attendance: &busAttendanceRepoMock{records: []*activeModels.Attendance{
+			{StudentID: 20, CheckInTime: checkIn},
+		}},
+		notified:    &dispatchedNotificationRepo{dispatched: make(map[string]bool)},
+		checkout:    &busCheckoutMock{},
+		broadcaster: &busBroadcaster{},
+	}
+	f.svc = NewBusRouteService(BusRouteServiceDependencies{
+		BusRouteRepo:   f.routes,
+		AttendanceRepo: f.attendance,
+		VisitRepo: &busVisitRepoMock{visits: map[int64]*activeModels.Visit{
+			20: {Model: base.Model{ID: 60}, StudentID: 20, ActiveGroupID: 30},
+		}},
+		StudentRepo: &busStudentRepoMock{students: map[int64]*userModels.Student{
+			20: {Model: base.Model{ID: 20}, GroupID: &groupID, Bus: &isBus, SchoolClass: "2b"},
+			21: {Model: base.Model{ID: 21}, GroupID: &groupID, Bus: &isBus},
+			22: {Model: base.Model{ID: 22}, GroupID: &groupID, Bus: &notBus},
+		}},
+		NotifiedRepo: f.notified,
+		Checkout:     f.checkout,
+		Broadcaster:  f.broadcaster,
+	})
+	return f
+}
+
+func TestDispatchBusReminders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("before the reminder lead time", func(t *testing.T) {
+		f := newBusFixture()
+		result, err := f.svc.DispatchBusReminders(ctx, time.Date(2026, 3, 4, 15, 25, 0, 0, timezone.Berlin))
+		require.NoError(t, err)
+		assert.Zero(t, result.RoutesDue)
+		assert.Empty(t, f.broadcaster.events)
+	})
+
+	t.Run("reminds checked-in students once", func(t *testing.T) {
+		f := newBusFixture()
+		now := time.Date(2026, 3, 4, 15, 32, 0, 0, timezone.Berlin)
+
+		result, err := f.svc.DispatchBusReminders(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.RoutesDue)
+		assert.Equal(t, 1, result.StudentsNotified)
+
+		require.Len(t, f.broadcaster.events, 1)
+		event := f.broadcaster.events[0]
+		assert.Equal(t, realtime.EventBusDepartureDue, event.Type)
+		assert.Equal(t, "20", *event.Data.StudentID)
+		assert.Equal(t, "Linie 12 Nord", *event.Data.BusRouteName)
+		assert.Equal(t, "15:40", *event.Data.DepartureTime)
+		assert.ElementsMatch(t, []string{realtime.ActiveGroupTopic(30), realtime.EducationGroupTopic(40)}, f.broadcaster.topics[0])
+
+		result, err = f.svc.DispatchBusReminders(ctx, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, result.StudentsNotified)
+	})
+
+	t.Run("no bus on that weekday", func(t *testing.T) {
+		f := newBusFixture()
+		result, err := f.svc.DispatchBusReminders(ctx, time.Date(2026, 3, 5, 15, 32, 0, 0, timezone.Berlin))
+		require.NoError(t, err)
+		assert.Zero(t, result.RoutesDue)
+	})
+}
+
+func TestCheckoutRoute(t *testing.T) {
+	f := newBusFixture()
+	now := time.Date(2026, 3, 4, 15, 35, 0, 0, timezone.Berlin)
+	staff := &userModels.Staff{Model: base.Model{ID: 90}}
+
+	result, err := f.svc.CheckoutRoute(context.Background(), 10, staff, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{20}, result.CheckedOut)
+	assert.Equal(t, []int64{21}, result.NotPresent)
+	assert.Empty(t, result.Failed)
+	require.NotNil(t, result.DepartureTime)
+	assert.Equal(t, "15:40", result.DepartureTime.Format("15:04"))
+
+	assert.Equal(t, []int64{60}, f.checkout.endedVisits)
+	assert.Equal(t, []*userModels.Staff{staff}, f.checkout.endedBy, "ended visits record the staff member")
+	assert.Equal(t, []int64{20}, f.checkout.checkedOut)
+
+	require.Len(t, f.checkout.details, 1)
+	require.NotNil(t, f.checkout.details[0].DepartedByBusRouteID, "the bus route is written with the checkout")
+	assert.Equal(t, int64(10), *f.checkout.details[0].DepartedByBusRouteID)
+}
+
+func TestCheckoutRoute_StaffWithoutAccess(t *testing.T) {
+	f := newBusFixture()
+	f.checkout.forbidden = map[int64]bool{20: true}
+	now := time.Date(2026, 3, 4, 15, 35, 0, 0, timezone.Berlin)
+
+	result, err := f.svc.CheckoutRoute(context.Background(), 10, &userModels.Staff{Model: base.Model{ID: 91}}, now)
+	require.NoError(t, err)
+
+	assert.Empty(t, result.CheckedOut)
+	assert.Equal(t, []int64{20}, result.Failed)
+	assert.Empty(t, f.checkout.endedVisits, "the visit stays open when the checkout is denied")
+}
+
+func TestCheckoutRoute_UnknownRoute(t *testing.T) {
+	f := newBusFixture()
+	_, err := f.svc.CheckoutRoute(context.Background(), 99, &userModels.Staff{Model: base.Model{ID: 90}}, time.Now())
+	assert.ErrorIs(t, err, ErrBusRouteNotFound)
+}
+
+func TestAssignStudents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("assigns bus children", func(t *testing.T) {
+		f := newBusFixture()
+		_, err := f.svc.AssignStudents(ctx, 10, []int64{21, 20, 21})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{21, 20}, f.routes.replacedStudents)
+	})
+
+	t.Run("rejects students without bus flag", func(t *testing.T) {
+		f := newBusFixture()
+		_, err := f.svc.AssignStudents(ctx, 10, []int64{20, 22})
+		assert.ErrorIs(t, err, ErrStudentNotBusChild)
+		assert.Nil(t, f.routes.replacedStudents)
+	})
+
+	t.Run("rejects unknown students", func(t *testing.T) {
+		f := newBusFixture()
+		_, err := f.svc.AssignStudents(ctx, 10, []int64{77})
+		assert.ErrorIs(t, err, ErrStudentNotFound)
+	})
+}
+
+func TestValidateDepartures_RejectsDuplicateWeekday(t *testing.T) {
+	departure := time.Date(0, 1, 1, 15, 40, 0, 0, time.UTC)
+	err := validateDepartures([]*scheduleModels.BusRouteDeparture{
+		{Weekday: scheduleModels.WeekdayMonday, DepartureTime: departure},
+		{Weekday: scheduleModels.WeekdayMonday, DepartureTime: departure.Add(time.Hour)},
+	})
+	assert.ErrorIs(t, err, ErrInvalidData)
+}
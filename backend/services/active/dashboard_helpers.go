@@ -25,6 +25,8 @@ type dashboardBaseData struct {
 	studentsWithActiveVisits map[int64]bool
 	studentsWithAttendance   map[int64]bool
 	studentsPresent          map[int64]bool
+	enrolledStudents         []*userModels.Student // Students assigned to an education group
+	studentsAbsent           map[int64]bool        // Students with a reported absence today
 }
 
 // dashboardRoomData holds room-related lookup maps
@@ -113,6 +115,11 @@ func (s *service) fetchDashboardBaseData(ctx context.Context, today time.Time) (
 	}
 	data.allEducationGroups = allEducationGroups
 
+	// Get enrolled students and today's reported absences for the missing count
+	if err := s.loadExpectedStudents(ctx, today, data); err != nil {
+		return nil, err
+	}
+
 	// Get activity categories count
 	activityCategories, err := s.activityCatRepo.List(ctx, nil)
 	if err != nil {
@@ -130,6 +137,48 @@ func (s *service) fetchDashboardBaseData(ctx context.Context, today time.Time) (
 	return data, nil
 }
 
+// loadExpectedStudents loads the students of all education groups and the absences reported for today
+func (s *service) loadExpectedStudents(ctx context.Context, today time.Time, data *dashboardBaseData) error {
+	data.studentsAbsent = make(map[int64]bool)
+	if s.absenceRepo == nil || len(data.allEducationGroups) == 0 {
+		return nil
+	}
+
+	groupIDs := make([]int64, 0, len(data.allEducationGroups))
+	for _, group := range data.allEducationGroups {
+		groupIDs = append(groupIDs, group.ID)
+	}
+	students, err := s.studentRepo.FindByGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return err
+	}
+	data.enrolledStudents = students
+
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	absences, err := s.absenceRepo.GetByDate(ctx, day)
+	if err != nil {
+		return err
+	}
+	data.studentsAbsent = absentStudentIDs(absences, day)
+	return nil
+}
+
+// calculateAbsentAndMissing counts enrolled students who are not present: reported absent
+// (absence record or sick flag) or missing without any report
+func calculateAbsentAndMissing(enrolled []*userModels.Student, present, absent map[int64]bool) (absentCount, missingCount int) {
+	for _, student := range enrolled {
+		switch {
+		case present[student.ID]:
+			continue
+		case absent[student.ID] || (student.Sick != nil && *student.Sick):
+			absentCount++
+		default:
+			missingCount++
+		}
+	}
+	return absentCount, missingCount
+}
+
 // countSupervisorsToday counts unique supervisors active today
 func (s *service) countSupervisorsToday(ctx context.Context, today time.Time) (int, error) {
 	supervisors, err := s.supervisorRepo.List(ctx, nil)
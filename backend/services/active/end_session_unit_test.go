@@ -58,6 +58,10 @@ func (m *mockGroupRepository) FindByTimeRange(ctx context.Context, start, end ti
 	return nil, nil
 }
 
+func (m *mockGroupRepository) FindEndedBetween(ctx context.Context, from, to time.Time) ([]*active.Group, error) {
+	return nil, nil
+}
+
 func (m *mockGroupRepository) EndSession(ctx context.Context, id int64) error {
 	if m.endSessionFunc != nil {
 		return m.endSessionFunc(ctx, id)
@@ -170,6 +174,10 @@ func (m *mockVisitRepository) FindByTimeRange(ctx context.Context, start, end ti
 	return nil, nil
 }
 
+func (m *mockVisitRepository) FindByStudentAndTimeRange(ctx context.Context, studentID int64, start, end time.Time) ([]*active.Visit, error) {
+	return nil, nil
+}
+
 func (m *mockVisitRepository) EndVisit(ctx context.Context, id int64) error {
 	if m.endVisitFunc != nil {
 		return m.endVisitFunc(ctx, id)
@@ -177,6 +185,10 @@ func (m *mockVisitRepository) EndVisit(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (m *mockVisitRepository) FindWithActiveGroup(ctx context.Context, id int64) (*active.Visit, error) {
+	return nil, nil
+}
+
 func (m *mockVisitRepository) TransferVisitsFromRecentSessions(ctx context.Context, newActiveGroupID, deviceID int64) (int, error) {
 	return 0, nil
 }
@@ -34,6 +34,15 @@ var (
 	ErrInvalidActivitySession = errors.New("invalid activity session parameters")
 	// Room conflict management errors
 	ErrRoomConflict = errors.New("room is already occupied by another active group")
+	// Bus route errors
+	ErrBusRouteNotFound   = errors.New("bus route not found")
+	ErrStudentNotBusChild = errors.New("student is not marked as bus child")
+
+	ErrStudentNotCheckedIn = errors.New("student is not checked in")
+	// Student absence errors
+	ErrStudentAbsenceNotFound = errors.New("absence not found")
+	ErrStudentAbsenceOverlap  = errors.New("absence overlaps")
+	ErrInvalidStudentAbsence  = errors.New("invalid student absence")
 )
 
 // ActiveError represents an error that occurred in the active service
@@ -102,8 +102,12 @@ type Service interface {
 	GetStudentAttendanceStatus(ctx context.Context, studentID int64) (*AttendanceStatus, error)
 	GetStudentsAttendanceStatuses(ctx context.Context, studentIDs []int64) (map[int64]*AttendanceStatus, error)
 	ToggleStudentAttendance(ctx context.Context, studentID, staffID, deviceID int64, skipAuthCheck bool) (*AttendanceResult, error)
+	CheckOutStudent(ctx context.Context, studentID, staffID, deviceID int64, skipAuthCheck bool, details *CheckoutDetails) (*AttendanceResult, error)
 	CheckTeacherStudentAccess(ctx context.Context, teacherID, studentID int64) (bool, error)
 
+	// Student absences (sick notes, trips) reported for a day
+	GetStudentAbsenceOnDate(ctx context.Context, studentID int64, date time.Time) (*active.StudentAbsence, error)
+
 	// Unclaimed groups management (deviceless claiming)
 	GetUnclaimedActiveGroups(ctx context.Context) ([]*active.Group, error)
 	ClaimActiveGroup(ctx context.Context, groupID, staffID int64, role string) (*active.GroupSupervisor, error)
@@ -116,6 +120,8 @@ type DashboardAnalytics struct {
 	StudentsInTransit    int // Students present but not in any active visit
 	StudentsOnPlayground int
 	StudentsInRooms      int // Students in indoor rooms (excluding playground)
+	StudentsAbsent       int // Enrolled students not present with a reported absence or sick flag
+	StudentsMissing      int // Enrolled students neither present nor reported absent
 
 	// Activities & Rooms
 	ActiveActivities    int
@@ -266,8 +272,9 @@ type AttendanceStatus struct {
 	Date         time.Time  `json:"date"`
 	CheckInTime  *time.Time `json:"check_in_time"`
 	CheckOutTime *time.Time `json:"check_out_time"`
-	CheckedInBy  string     `json:"checked_in_by"`  // Formatted as "FirstName LastName"
-	CheckedOutBy string     `json:"checked_out_by"` // Formatted as "FirstName LastName"
+	CheckedInBy  string     `json:"checked_in_by"`          // Formatted as "FirstName LastName"
+	CheckedOutBy string     `json:"checked_out_by"`         // Formatted as "FirstName LastName"
+	PickedUpBy   string     `json:"picked_up_by,omitempty"` // Guardian or pickup person who collected the student
 }
 
 // AttendanceResult represents the result of a student attendance toggle operation
@@ -0,0 +1,381 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	configModels "github.com/moto-nrw/project-phoenix/models/config"
+	educationModels "github.com/moto-nrw/project-phoenix/models/education"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
+)
+
+// MissingStudentCheckResult summarizes one run of the missing student check
+type MissingStudentCheckResult struct {
+	Date         time.Time `json:"date"`
+	Deadline     time.Time `json:"deadline,omitempty"`
+	Checked      bool      `json:"checked"`           // false on weekends, closure days and before the deadline
+	Closure      string    `json:"closure,omitempty"` // Public holiday or closure the check was skipped for
+	Expected     int       `json:"expected"`
+	AlertsRaised int       `json:"alerts_raised"`
+}
+
+// MissingStudentService detects students expected in OGS who have not checked in
+type MissingStudentService interface {
+	// CheckMissingStudents raises an alert for every expected student without attendance
+	// once today's grace period has passed. Students already alerted today are skipped.
+	CheckMissingStudents(ctx context.Context, now time.Time) (*MissingStudentCheckResult, error)
+
+	// ListAlerts returns the alerts raised on the given date, oldest first
+	ListAlerts(ctx context.Context, date time.Time) ([]*activeModels.MissingStudentAlert, error)
+
+	// ListStudentAlerts returns the alert history of a student, most recent first
+	ListStudentAlerts(ctx context.Context, studentID int64) ([]*activeModels.MissingStudentAlert, error)
+
+	// ResolveAlert records how an open alert was cleared (found, sick, went_home)
+	ResolveAlert(ctx context.Context, alertID, staffID int64, resolution, note string) (*activeModels.MissingStudentAlert, error)
+}
+
+// MissingAlertRulesProvider supplies the configured arrival times and grace periods (implemented by the config service)
+type MissingAlertRulesProvider interface {
+	GetMissingAlertRules(ctx context.Context) (*configModels.MissingAlertRules, error)
+}
+
+// MissingStudentServiceDependencies contains all dependencies required by the missing student service
+type MissingStudentServiceDependencies struct {
+	AlertRepo           activeModels.MissingStudentAlertRepository
+	AttendanceRepo      activeModels.AttendanceRepository
+	AbsenceRepo         activeModels.StudentAbsenceRepository
+	StudentRepo         userModels.StudentRepository
+	PersonRepo          userModels.PersonRepository // Student names in SSE alerts
+	EducationGroupRepo  educationModels.GroupRepository
+	PickupScheduleRepo  scheduleModels.StudentPickupScheduleRepository
+	PickupExceptionRepo scheduleModels.StudentPickupExceptionRepository
+	DateframeRepo       scheduleModels.DateframeRepository // Holidays and closure days; nil only skips public holidays
+	RulesProvider       MissingAlertRulesProvider          // nil uses the default arrival times
+	Broadcaster         realtime.Broadcaster               // SSE alerts are skipped when nil
+	Logger              *slog.Logger
+}
+
+// missingStudentService implements MissingStudentService
+type missingStudentService struct {
+	alertRepo           activeModels.MissingStudentAlertRepository
+	attendanceRepo      activeModels.AttendanceRepository
+	absenceRepo         activeModels.StudentAbsenceRepository
+	studentRepo         userModels.StudentRepository
+	personRepo          userModels.PersonRepository
+	educationGroupRepo  educationModels.GroupRepository
+	pickupScheduleRepo  scheduleModels.StudentPickupScheduleRepository
+	pickupExceptionRepo scheduleModels.StudentPickupExceptionRepository
+	dateframeRepo       scheduleModels.DateframeRepository
+	rulesProvider       MissingAlertRulesProvider
+	broadcaster         realtime.Broadcaster
+	logger              *slog.Logger
+}
+
+// NewMissingStudentService creates a new missing student service
+func NewMissingStudentService(deps MissingStudentServiceDependencies) MissingStudentService {
+	return &missingStudentService{
+		alertRepo:           deps.AlertRepo,
+		attendanceRepo:      deps.AttendanceRepo,
+		absenceRepo:         deps.AbsenceRepo,
+		studentRepo:         deps.StudentRepo,
+		personRepo:          deps.PersonRepo,
+		educationGroupRepo:  deps.EducationGroupRepo,
+		pickupScheduleRepo:  deps.PickupScheduleRepo,
+		pickupExceptionRepo: deps.PickupExceptionRepo,
+		dateframeRepo:       deps.DateframeRepo,
+		rulesProvider:       deps.RulesProvider,
+		broadcaster:         deps.Broadcaster,
+		logger:              deps.Logger,
+	}
+}
+
+// getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
+func (s *missingStudentService) getLogger() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return slog.Default()
+}
+
+// missingAlertRules returns the configured rules, falling back to the defaults
+func (s *missingStudentService) missingAlertRules(ctx context.Context) *configModels.MissingAlertRules {
+	if s.rulesProvider == nil {
+		return configModels.NewDefaultMissingAlertRules()
+	}
+	rules, err := s.rulesProvider.GetMissingAlertRules(ctx)
+	if err != nil || rules == nil {
+		s.getLogger().WarnContext(ctx, "failed to load missing alert rules, using defaults",
+			slog.Any("error", err))
+		return configModels.NewDefaultMissingAlertRules()
+	}
+	return rules
+}
+
+// CheckMissingStudents raises an alert for every expected student without attendance
+// once today's grace period has passed. Students already alerted today are skipped, and
+// nobody is expected on public holidays, school holidays and closure days.
+func (s *missingStudentService) CheckMissingStudents(ctx context.Context, now time.Time) (*MissingStudentCheckResult, error) {
+	day := timezone.DateOfUTC(now)
+	result := &MissingStudentCheckResult{Date: day}
+
+	deadline, ok := s.missingAlertRules(ctx).Deadline(timezone.DateOf(now))
+	if !ok || now.Before(deadline) {
+		return result, nil
+	}
+
+	closed, err := scheduleModels.SchoolClosureDays(ctx, s.dateframeRepo, day, day)
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := closed[day]; ok {
+		result.Closure = name
+		return result, nil
+	}
+	result.Deadline = deadline
+	result.Checked = true
+
+	expected, err := s.expectedStudents(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	result.Expected = len(expected)
+	if len(expected) == 0 {
+		return result, nil
+	}
+
+	attendance, err := s.attendanceRepo.FindForDate(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	present := make(map[int64]bool, len(attendance))
+	for _, a := range attendance {
+		present[a.StudentID] = true
+	}
+
+	existing, err := s.alertRepo.GetByDate(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing student alerts: %w", err)
+	}
+	alerted := make(map[int64]bool, len(existing))
+	for _, a := range existing {
+		alerted[a.StudentID] = true
+	}
+
+	for _, student := range missingStudents(expected, present, alerted) {
+		alert := &activeModels.MissingStudentAlert{
+			StudentID:  student.ID,
+			GroupID:    student.GroupID,
+			AlertDate:  day,
+			ExpectedBy: deadline,
+			Status:     activeModels.MissingAlertStatusOpen,
+		}
+		if err := s.alertRepo.Create(ctx, alert); err != nil {
+			// Another replica may have raised the alert concurrently (unique per student and day)
+			s.getLogger().WarnContext(ctx, "failed to create missing student alert",
+				slog.Int64("student_id", student.ID),
+				slog.Any("error", err))
+			continue
+		}
+		result.AlertsRaised++
+		s.broadcastMissingAlert(ctx, alert, student)
+	}
+
+	return result, nil
+}
+
+// expectedStudents returns the enrolled students who are expected in OGS today:
+// those with a pickup time today who are neither reported absent nor sick
+func (s *missingStudentService) expectedStudents(ctx context.Context, now time.Time) ([]*userModels.Student, error) {
+	groups, err := s.educationGroupRepo.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get education groups: %w", err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	groupIDs := make([]int64, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+
+	enrolled, err := s.studentRepo.FindByGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrolled students: %w", err)
+	}
+	if len(enrolled) == 0 {
+		return nil, nil
+	}
+	studentIDs := make([]int64, 0, len(enrolled))
+	for _, st := range enrolled {
+		studentIDs = append(studentIDs, st.ID)
+	}
+
+	date := timezone.DateOf(now)
+	weekday := int(date.Weekday())
+	if weekday == 0 {
+		weekday = scheduleModels.WeekdaySunday
+	}
+	schedules, err := s.pickupScheduleRepo.FindByStudentIDsAndWeekday(ctx, studentIDs, weekday)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pickup schedules: %w", err)
+	}
+	exceptions, err := s.pickupExceptionRepo.FindByStudentIDsAndDate(ctx, studentIDs, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pickup exceptions: %w", err)
+	}
+
+	absent := make(map[int64]bool)
+	if s.absenceRepo != nil {
+		day := timezone.DateOfUTC(now)
+		absences, err := s.absenceRepo.GetByDate(ctx, day)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get student absences: %w", err)
+		}
+		absent = absentStudentIDs(absences, day)
+	}
+
+	return filterExpectedStudents(enrolled, schedules, exceptions, absent), nil
+}
+
+// filterExpectedStudents keeps the students with a pickup time on the day. A pickup exception
+// overrides the weekly schedule; an exception without a pickup time means the student is not coming.
+// Students with an absence or the sick flag are not expected.
+func filterExpectedStudents(
+	enrolled []*userModels.Student,
+	schedules []*scheduleModels.StudentPickupSchedule,
+	exceptions []*scheduleModels.StudentPickupException,
+	absent map[int64]bool,
+) []*userModels.Student {
+	scheduled := make(map[int64]bool, len(schedules))
+	for _, sched := range schedules {
+		scheduled[sched.StudentID] = true
+	}
+	exceptionMap := make(map[int64]*scheduleModels.StudentPickupException, len(exceptions))
+	for _, exc := range exceptions {
+		exceptionMap[exc.StudentID] = exc
+	}
+
+	var expected []*userModels.Student
+	for _, student := range enrolled {
+		if absent[student.ID] || (student.Sick != nil && *student.Sick) {
+			continue
+		}
+		attends := scheduled[student.ID]
+		if exc, ok := exceptionMap[student.ID]; ok {
+			attends = exc.PickupTime != nil
+		}
+		if attends {
+			expected = append(expected, student)
+		}
+	}
+	return expected
+}
+
+// missingStudents returns the expected students who are neither present nor already alerted
+func missingStudents(expected []*userModels.Student, present, alerted map[int64]bool) []*userModels.Student {
+	var missing []*userModels.Student
+	for _, student := range expected {
+		if present[student.ID] || alerted[student.ID] {
+			continue
+		}
+		missing = append(missing, student)
+	}
+	return missing
+}
+
+// ListAlerts returns the alerts raised on the given date, oldest first
+func (s *missingStudentService) ListAlerts(ctx context.Context, date time.Time) ([]*activeModels.MissingStudentAlert, error) {
+	alerts, err := s.alertRepo.GetByDate(ctx, timezone.DateOfUTC(date))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing student alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// ListStudentAlerts returns the alert history of a student, most recent first
+func (s *missingStudentService) ListStudentAlerts(ctx context.Context, studentID int64) ([]*activeModels.MissingStudentAlert, error) {
+	alerts, err := s.alertRepo.GetByStudentID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing student alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// ResolveAlert records how an open alert was cleared (found, sick, went_home)
+func (s *missingStudentService) ResolveAlert(ctx context.Context, alertID, staffID int64, resolution, note string) (*activeModels.MissingStudentAlert, error) {
+	alert, err := s.alertRepo.FindByID(ctx, alertID)
+	if err != nil || alert == nil {
+		return nil, errors.New("missing student alert not found")
+	}
+
+	if err := alert.Resolve(resolution, staffID, strings.TrimSpace(note), time.Now()); err != nil {
+		return nil, err
+	}
+	alert.UpdatedAt = time.Now()
+	if err := s.alertRepo.Update(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to resolve missing student alert: %w", err)
+	}
+
+	if s.broadcaster != nil {
+		if student, err := s.studentRepo.FindByID(ctx, alert.StudentID); err == nil && student != nil {
+			s.broadcastMissingAlert(ctx, alert, student)
+		}
+	}
+	return alert, nil
+}
+
+// broadcastMissingAlert sends a student_missing event to the teachers of the student's OGS group
+func (s *missingStudentService) broadcastMissingAlert(ctx context.Context, alert *activeModels.MissingStudentAlert, student *userModels.Student) {
+	if s.broadcaster == nil {
+		return
+	}
+
+	studentID := strconv.FormatInt(student.ID, 10)
+	alertID := strconv.FormatInt(alert.ID, 10)
+	status := alert.Status
+	expectedBy := alert.ExpectedBy
+	data := realtime.EventData{
+		StudentID:   &studentID,
+		AlertID:     &alertID,
+		AlertStatus: &status,
+		ExpectedBy:  &expectedBy,
+	}
+	if alert.Resolution != "" {
+		resolution := alert.Resolution
+		data.Resolution = &resolution
+	}
+	if student.SchoolClass != "" {
+		schoolClass := student.SchoolClass
+		data.SchoolClass = &schoolClass
+	}
+	if s.personRepo != nil {
+		if person, err := s.personRepo.FindByID(ctx, student.PersonID); err == nil && person != nil {
+			name := fmt.Sprintf("%s %s", person.FirstName, person.LastName)
+			data.StudentName = &name
+		}
+	}
+
+	topics := []string{realtime.TopicSchool}
+	if alert.GroupID != nil {
+		topics = []string{realtime.EducationGroupTopic(*alert.GroupID)}
+	}
+
+	event := realtime.NewEvent(realtime.EventStudentMissing, "", data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		s.getLogger().Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(realtime.EventStudentMissing)),
+			slog.String("student_id", studentID),
+		)
+	}
+}
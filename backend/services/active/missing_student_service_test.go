@@ -0,0 +1,221 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	configModels "github.com/moto-nrw/project-phoenix/models/config"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// Mock for MissingStudentAlertRepository (prefixed with msa)
+// ============================================================================
+
+type msaAlertRepoMock struct {
+	findByIDFunc func(ctx context.Context, id any) (*activeModels.MissingStudentAlert, error)
+	updateFunc   func(ctx context.Context, entity *activeModels.MissingStudentAlert) error
+}
+
+func (m *msaAlertRepoMock) Create(_ context.Context, _ *activeModels.MissingStudentAlert) error {
+	return nil
+}
+func (m *msaAlertRepoMock) FindByID(ctx context.Context, id any) (*activeModels.MissingStudentAlert, error) {
+	if m.findByIDFunc != nil {
+		return m.findByIDFunc(ctx, id)
+	}
+	return nil, errors.New("not found")
+}
+func (m *msaAlertRepoMock) Update(ctx context.Context, entity *activeModels.MissingStudentAlert) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(ctx, entity)
+	}
+	return nil
+}
+func (m *msaAlertRepoMock) Delete(_ context.Context, _ any) error {
+	return nil
+}
+func (m *msaAlertRepoMock) List(_ context.Context, _ *base.QueryOptions) ([]*activeModels.MissingStudentAlert, error) {
+	return nil, nil
+}
+func (m *msaAlertRepoMock) GetByDate(_ context.Context, _ time.Time) ([]*activeModels.MissingStudentAlert, error) {
+	return nil, nil
+}
+func (m *msaAlertRepoMock) GetByStudentID(_ context.Context, _ int64) ([]*activeModels.MissingStudentAlert, error) {
+	return nil, nil
+}
+
+type msaRulesProvider struct {
+	rules *configModels.MissingAlertRules
+}
+
+func (p *msaRulesProvider) GetMissingAlertRules(_ context.Context) (*configModels.MissingAlertRules, error) {
+	return p.rules, nil
+}
+
+func msaStudent(id int64, sick bool) *userModels.Student {
+	groupID := int64(40)
+	return &userModels.Student{Model: base.Model{ID: id}, GroupID: &groupID, Sick: &sick}
+}
+
+func msaIDs(students []*userModels.Student) []int64 {
+	ids := make([]int64, 0, len(students))
+	for _, st := range students {
+		ids = append(ids, st.ID)
+	}
+	return ids
+}
+
+func TestFilterExpectedStudents(t *testing.T) {
+	pickup := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	enrolled := []*userModels.Student{
+		msaStudent(10, false), // scheduled
+		msaStudent(11, false), // no schedule today
+		msaStudent(12, false), // scheduled, exception without pickup time
+		msaStudent(13, false), // not scheduled, exception with pickup time
+		msaStudent(14, true),  // scheduled but sick
+		msaStudent(15, false), // scheduled but absent
+	}
+	schedules := []*scheduleModels.StudentPickupSchedule{
+		{StudentID: 10}, {StudentID: 12}, {StudentID: 14}, {StudentID: 15},
+	}
+	exceptions := []*scheduleModels.StudentPickupException{
+		{StudentID: 12},
+		{StudentID: 13, PickupTime: &pickup},
+	}
+	absent := map[int64]bool{15: true}
+
+	expected := filterExpectedStudents(enrolled, schedules, exceptions, absent)
+
+	assert.Equal(t, []int64{10, 13}, msaIDs(expected))
+}
+
+func TestMissingStudents(t *testing.T) {
+	expected := []*userModels.Student{msaStudent(10, false), msaStudent(11, false), msaStudent(12, false)}
+
+	missing := missingStudents(expected, map[int64]bool{10: true}, map[int64]bool{12: true})
+
+	assert.Equal(t, []int64{11}, msaIDs(missing))
+}
+
+func TestCheckMissingStudents_SkipsBeforeDeadlineAndOnWeekends(t *testing.T) {
+	rules := configModels.NewDefaultMissingAlertRules()
+	rules.Days[3] = &configModels.MissingAlertDayRule{ExpectedArrival: "13:00", GraceMinutes: 15}
+	svc := NewMissingStudentService(MissingStudentServiceDependencies{
+		RulesProvider: &msaRulesProvider{rules: rules},
+	})
+
+	t.Run("before the grace period has passed", func(t *testing.T) {
+		now := time.Date(2026, 3, 4, 13, 10, 0, 0, timezone.Berlin)
+		result, err := svc.CheckMissingStudents(context.Background(), now)
+		require.NoError(t, err)
+		assert.False(t, result.Checked)
+		assert.Zero(t, result.AlertsRaised)
+	})
+
+	t.Run("weekend", func(t *testing.T) {
+		now := time.Date(2026, 3, 7, 16, 0, 0, 0, timezone.Berlin)
+		result, err := svc.CheckMissingStudents(context.Background(), now)
+		require.NoError(t, err)
+		assert.False(t, result.Checked)
+	})
+}
+
+// msaDateframeRepo returns fixed holiday and closure dateframes
+type msaDateframeRepo struct {
+	scheduleModels.DateframeRepository
+	closures []*scheduleModels.Dateframe
+}
+
+func (m *msaDateframeRepo) FindClosures(_ context.Context, _, _ time.Time) ([]*scheduleModels.Dateframe, error) {
+	return m.closures, nil
+}
+
+func TestCheckMissingStudents_SkipsClosureDays(t *testing.T) {
+	svc := NewMissingStudentService(MissingStudentServiceDependencies{
+		DateframeRepo: &msaDateframeRepo{closures: []*scheduleModels.Dateframe{{
+			StartDate: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+			Name:      "Herbstferien",
+			Kind:      scheduleModels.DateframeKindHoliday,
+		}}},
+	})
+
+	t.Run("school holidays", func(t *testing.T) {
+		now := time.Date(2026, 10, 21, 17, 0, 0, 0, timezone.Berlin)
+		result, err := svc.CheckMissingStudents(context.Background(), now)
+		require.NoError(t, err)
+		assert.False(t, result.Checked)
+		assert.Equal(t, "Herbstferien", result.Closure)
+	})
+
+	t.Run("public holiday", func(t *testing.T) {
+		now := time.Date(2026, 6, 4, 17, 0, 0, 0, timezone.Berlin)
+		result, err := svc.CheckMissingStudents(context.Background(), now)
+		require.NoError(t, err)
+		assert.False(t, result.Checked)
+		assert.Equal(t, "Fronleichnam", result.Closure)
+	})
+}
+
+func TestResolveAlert(t *testing.T) {
+	openAlert := func() *activeModels.MissingStudentAlert {
+		return &activeModels.MissingStudentAlert{
+			Model:      base.Model{ID: 30},
+			StudentID:  10,
+			AlertDate:  time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+			ExpectedBy: time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC),
+			Status:     activeModels.MissingAlertStatusOpen,
+		}
+	}
+
+	t.Run("records the resolution", func(t *testing.T) {
+		var updated *activeModels.MissingStudentAlert
+		repo := &msaAlertRepoMock{
+			findByIDFunc: func(_ context.Context, _ any) (*activeModels.MissingStudentAlert, error) { return openAlert(), nil },
+			updateFunc: func(_ context.Context, a *activeModels.MissingStudentAlert) error {
+				updated = a
+				return nil
+			},
+		}
+		svc := NewMissingStudentService(MissingStudentServiceDependencies{AlertRepo: repo})
+
+		alert, err := svc.ResolveAlert(context.Background(), 30, 50, activeModels.MissingAlertResolutionWentHome, "  Mutter hat angerufen ")
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.Equal(t, activeModels.MissingAlertStatusResolved, alert.Status)
+		assert.Equal(t, activeModels.MissingAlertResolutionWentHome, alert.Resolution)
+		assert.Equal(t, "Mutter hat angerufen", alert.ResolutionNote)
+		require.NotNil(t, alert.ResolvedBy)
+		assert.Equal(t, int64(50), *alert.ResolvedBy)
+	})
+
+	t.Run("alert not found", func(t *testing.T) {
+		svc := NewMissingStudentService(MissingStudentServiceDependencies{AlertRepo: &msaAlertRepoMock{}})
+		_, err := svc.ResolveAlert(context.Background(), 30, 50, activeModels.MissingAlertResolutionFound, "")
+		require.Error(t, err)
+		assert.Equal(t, "missing student alert not found", err.Error())
+	})
+
+	t.Run("already resolved", func(t *testing.T) {
+		repo := &msaAlertRepoMock{
+			findByIDFunc: func(_ context.Context, _ any) (*activeModels.MissingStudentAlert, error) {
+				a := openAlert()
+				require.NoError(t, a.Resolve(activeModels.MissingAlertResolutionFound, 50, "", time.Now()))
+				return a, nil
+			},
+		}
+		svc := NewMissingStudentService(MissingStudentServiceDependencies{AlertRepo: repo})
+		_, err := svc.ResolveAlert(context.Background(), 30, 50, activeModels.MissingAlertResolutionSick, "")
+		require.Error(t, err)
+		assert.Equal(t, "alert is already resolved", err.Error())
+	})
+}
@@ -0,0 +1,437 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	scheduleSvc "github.com/moto-nrw/project-phoenix/services/schedule"
+)
+
+// Pickup board entry statuses
+const (
+	PickupStatusUpcoming = "upcoming"  // Pickup time lies within the board window
+	PickupStatusLeaveNow = "leave_now" // Pickup time reached; the student should leave now
+	PickupStatusOverdue  = "overdue"   // Pickup time passed by more than the escalation threshold without checkout
+)
+
+// DefaultPickupOverdueAfter is the time after the pickup time at which a student still
+// checked in is escalated as overdue
+const DefaultPickupOverdueAfter = 15 * time.Minute
+
+// PickupBoardEntry is one student on the pickup board
+type PickupBoardEntry struct {
+	StudentID    int64     `json:"student_id"`
+	StudentName  string    `json:"student_name"`
+	SchoolClass  string    `json:"school_class,omitempty"`
+	GroupID      *int64    `json:"group_id,omitempty"` // OGS group
+	GroupName    string    `json:"group_name,omitempty"`
+	PickupTime   time.Time `json:"pickup_time"`
+	IsException  bool      `json:"is_exception"`
+	Notes        string    `json:"notes,omitempty"`
+	MinutesUntil int       `json:"minutes_until"` // Negative once the pickup time has passed
+	Status       string    `json:"status"`
+
+	activeGroupID int64 // Session the student is currently visiting, 0 when not in a room
+}
+
+// PickupBoardRoom groups the pickups by the room the students are currently in
+type PickupBoardRoom struct {
+	RoomID   *int64              `json:"room_id,omitempty"` // nil for students not currently in a room
+	RoomName string              `json:"room_name,omitempty"`
+	Entries  []*PickupBoardEntry `json:"entries"`
+}
+
+// PickupBoard lists the upcoming and overdue pickups of checked-in students
+type PickupBoard struct {
+	GeneratedAt   time.Time          `json:"generated_at"`
+	WindowMinutes int                `json:"window_minutes"`
+	LeaveNowCount int                `json:"leave_now_count"`
+	OverdueCount  int                `json:"overdue_count"`
+	Rooms         []*PickupBoardRoom `json:"rooms"`
+}
+
+// PickupDispatchResult summarizes one run of the pickup dispatcher
+type PickupDispatchResult struct {
+	LeaveNowNotified int `json:"leave_now_notified"`
+	OverdueEscalated int `json:"overdue_escalated"`
+}
+
+// PickupBoardService drives the live pickup dispatch board
+type PickupBoardService interface {
+	// GetPickupBoard returns the checked-in students whose pickup is due within the window
+	// or already passed, grouped by their current room
+	GetPickupBoard(ctx context.Context, now time.Time, window time.Duration) (*PickupBoard, error)
+
+	// DispatchPickups notifies the supervising staff once when a student should leave and
+	// escalates once when the pickup is overdue
+	DispatchPickups(ctx context.Context, now time.Time) (*PickupDispatchResult, error)
+}
+
+// PickupTimeProvider supplies the effective pickup times including exceptions (implemented by the pickup schedule service)
+type PickupTimeProvider interface {
+	GetBulkEffectivePickupTimesForDate(ctx context.Context, studentIDs []int64, date time.Time) (map[int64]*scheduleSvc.EffectivePickupTime, error)
+}
+
+// PickupBoardServiceDependencies contains all dependencies required by the pickup board service
+type PickupBoardServiceDependencies struct {
+	AttendanceRepo activeModels.AttendanceRepository
+	VisitRepo      activeModels.VisitRepository
+	GroupRepo      activeModels.GroupRepository // Active groups with their rooms
+	StudentRepo    userModels.StudentRepository
+	PickupTimes    PickupTimeProvider
+	NotifiedRepo   activeModels.DispatchedNotificationRepository // Sent notifications, one per student, event and day
+	Broadcaster    realtime.Broadcaster                          // Dispatch events are skipped when nil
+	OverdueAfter   time.Duration                                 // 0 uses DefaultPickupOverdueAfter
+	Logger         *slog.Logger
+}
+
+// pickupBoardService implements PickupBoardService
+type pickupBoardService struct {
+	attendanceRepo activeModels.AttendanceRepository
+	visitRepo      activeModels.VisitRepository
+	groupRepo      activeModels.GroupRepository
+	studentRepo    userModels.StudentRepository
+	pickupTimes    PickupTimeProvider
+	notifiedRepo   activeModels.DispatchedNotificationRepository
+	broadcaster    realtime.Broadcaster
+	overdueAfter   time.Duration
+	logger         *slog.Logger
+}
+
+// NewPickupBoardService creates a new pickup board service
+func NewPickupBoardService(deps PickupBoardServiceDependencies) PickupBoardService {
+	overdueAfter := deps.OverdueAfter
+	if overdueAfter <= 0 {
+		overdueAfter = DefaultPickupOverdueAfter
+	}
+	return &pickupBoardService{
+		attendanceRepo: deps.AttendanceRepo,
+		visitRepo:      deps.VisitRepo,
+		groupRepo:      deps.GroupRepo,
+		studentRepo:    deps.StudentRepo,
+		pickupTimes:    deps.PickupTimes,
+		notifiedRepo:   deps.NotifiedRepo,
+		broadcaster:    deps.Broadcaster,
+		overdueAfter:   overdueAfter,
+		logger:         deps.Logger,
+	}
+}
+
+// getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
+func (s *pickupBoardService) getLogger() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return slog.Default()
+}
+
+// GetPickupBoard returns the checked-in students whose pickup is due within the window
+// or already passed, grouped by their current room
+func (s *pickupBoardService) GetPickupBoard(ctx context.Context, now time.Time, window time.Duration) (*PickupBoard, error) {
+	board := &PickupBoard{
+		GeneratedAt:   now,
+		WindowMinutes: int(window / time.Minute),
+		Rooms:         []*PickupBoardRoom{},
+	}
+
+	attendance, err := s.attendanceRepo.FindForDate(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	studentIDs := checkedInStudentIDs(attendance)
+	if len(studentIDs) == 0 {
+		return board, nil
+	}
+
+	pickupTimes, err := s.pickupTimes.GetBulkEffectivePickupTimesForDate(ctx, studentIDs, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pickup times: %w", err)
+	}
+
+	date := timezone.DateOf(now)
+	entries := make(map[int64]*PickupBoardEntry)
+	for _, studentID := range studentIDs {
+		ept := pickupTimes[studentID]
+		if ept == nil || ept.PickupTime == nil {
+			continue
+		}
+		pickupAt := pickupTimeOnDate(date, *ept.PickupTime)
+		status, ok := pickupStatus(now, pickupAt, window, s.overdueAfter)
+		if !ok {
+			continue
+		}
+		entries[studentID] = &PickupBoardEntry{
+			StudentID:    studentID,
+			PickupTime:   pickupAt,
+			IsException:  ept.IsException,
+			Notes:        ept.Notes,
+			MinutesUntil: int(pickupAt.Sub(now).Round(time.Minute) / time.Minute),
+			Status:       status,
+		}
+	}
+	if len(entries) == 0 {
+		return board, nil
+	}
+
+	if err := s.attachStudentInfo(ctx, entries); err != nil {
+		return nil, err
+	}
+	rooms, err := s.groupByRoom(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	board.Rooms = rooms
+
+	for _, e := range entries {
+		switch e.Status {
+		case PickupStatusLeaveNow:
+			board.LeaveNowCount++
+		case PickupStatusOverdue:
+			board.OverdueCount++
+		}
+	}
+	return board, nil
+}
+
+// checkedInStudentIDs returns the students checked in today who have not been checked out
+func checkedInStudentIDs(attendance []*activeModels.Attendance) []int64 {
+	seen := make(map[int64]bool, len(attendance))
+	checkedOut := make(map[int64]bool, len(attendance))
+	for _, a := range attendance {
+		seen[a.StudentID] = true
+		// A later record without checkout means the student came back
+		checkedOut[a.StudentID] = a.CheckOutTime != nil
+	}
+
+	ids := make([]int64, 0, len(seen))
+	for studentID := range seen {
+		if !checkedOut[studentID] {
+			ids = append(ids, studentID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// pickupTimeOnDate combines the date with the time of day of a pickup time (stored as TIME)
+func pickupTimeOnDate(date, pickupTime time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), pickupTime.Hour(), pickupTime.Minute(), 0, 0, date.Location())
+}
+
+// pickupStatus classifies a pickup relative to now; false when it lies beyond the window
+func pickupStatus(now, pickupAt time.Time, window, overdueAfter time.Duration) (string, bool) {
+	switch {
+	case pickupAt.After(now.Add(window)):
+		return "", false
+	case pickupAt.After(now):
+		return PickupStatusUpcoming, true
+	case now.Sub(pickupAt) < overdueAfter:
+		return PickupStatusLeaveNow, true
+	default:
+		return PickupStatusOverdue, true
+	}
+}
+
+// attachStudentInfo fills in names, classes and OGS groups of the board entries
+func (s *pickupBoardService) attachStudentInfo(ctx context.Context, entries map[int64]*PickupBoardEntry) error {
+	studentIDs := make([]int64, 0, len(entries))
+	for id := range entries {
+		studentIDs = append(studentIDs, id)
+	}
+	students, err := s.studentRepo.FindByIDsWithGroups(ctx, studentIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get students: %w", err)
+	}
+	for _, st := range students {
+		e, ok := entries[st.ID]
+		if !ok {
+			continue
+		}
+		e.SchoolClass = st.SchoolClass
+		e.GroupID = st.GroupID
+		e.GroupName = st.GroupName
+		if st.Person != nil {
+			e.StudentName = fmt.Sprintf("%s %s", st.Person.FirstName, st.Person.LastName)
+		}
+	}
+	return nil
+}
+
+// groupByRoom groups the entries by the room of the students' current visits,
+// rooms ordered by name and entries by pickup time
+func (s *pickupBoardService) groupByRoom(ctx context.Context, entries map[int64]*PickupBoardEntry) ([]*PickupBoardRoom, error) {
+	studentIDs := make([]int64, 0, len(entries))
+	for id := range entries {
+		studentIDs = append(studentIDs, id)
+	}
+	visits, err := s.visitRepo.GetCurrentByStudentIDs(ctx, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current visits: %w", err)
+	}
+
+	activeGroupIDs := make([]int64, 0, len(visits))
+	for _, v := range visits {
+		activeGroupIDs = append(activeGroupIDs, v.ActiveGroupID)
+	}
+	groups, err := s.groupRepo.FindByIDs(ctx, activeGroupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active groups: %w", err)
+	}
+
+	rooms := make(map[int64]*PickupBoardRoom)
+	unassigned := &PickupBoardRoom{}
+	for studentID, e := range entries {
+		room := unassigned
+		if v, ok := visits[studentID]; ok && v != nil {
+			e.activeGroupID = v.ActiveGroupID
+			if g, ok := groups[v.ActiveGroupID]; ok && g != nil {
+				room = rooms[g.RoomID]
+				if room == nil {
+					roomID := g.RoomID
+					room = &PickupBoardRoom{RoomID: &roomID}
+					if g.Room != nil {
+						room.RoomName = g.Room.Name
+					}
+					rooms[g.RoomID] = room
+				}
+			}
+		}
+		room.Entries = append(room.Entries, e)
+	}
+
+	result := make([]*PickupBoardRoom, 0, len(rooms)+1)
+	for _, room := range rooms {
+		result = append(result, room)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].RoomName < result[j].RoomName })
+	if len(unassigned.Entries) > 0 {
+		result = append(result, unassigned)
+	}
+	for _, room := range result {
+		sort.Slice(room.Entries, func(i, j int) bool {
+			if !room.Entries[i].PickupTime.Equal(room.Entries[j].PickupTime) {
+				return room.Entries[i].PickupTime.Before(room.Entries[j].PickupTime)
+			}
+			return room.Entries[i].StudentName < room.Entries[j].StudentName
+		})
+	}
+	return result, nil
+}
+
+// DispatchPickups notifies the supervising staff once when a student should leave and
+// escalates once when the pickup is overdue
+func (s *pickupBoardService) DispatchPickups(ctx context.Context, now time.Time) (*PickupDispatchResult, error) {
+	result := &PickupDispatchResult{}
+	if s.broadcaster == nil || s.notifiedRepo == nil {
+		return result, nil
+	}
+
+	board, err := s.GetPickupBoard(ctx, now, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, room := range board.Rooms {
+		for _, e := range room.Entries {
+			if e.Status == PickupStatusUpcoming || !s.markNotified(ctx, now, e.StudentID, pickupEventType(e.Status)) {
+				continue
+			}
+			s.broadcastPickupDispatch(e, room)
+			if e.Status == PickupStatusOverdue {
+				result.OverdueEscalated++
+			} else {
+				result.LeaveNowNotified++
+			}
+		}
+	}
+	return result, nil
+}
+
+// markNotified records a dispatched event and reports whether it is new today. The record is
+// persisted, so restarts and other replicas do not send the same event again; when it cannot
+// be stored the event is skipped and retried on the next run.
+func (s *pickupBoardService) markNotified(ctx context.Context, now time.Time, studentID int64, eventType realtime.EventType) bool {
+	isNew, err := s.notifiedRepo.MarkDispatched(ctx, studentID, string(eventType), timezone.DateOfUTC(now))
+	if err != nil {
+		s.getLogger().WarnContext(ctx, "failed to record pickup dispatch",
+			slog.Int64("student_id", studentID),
+			slog.String("event_type", string(eventType)),
+			slog.Any("error", err))
+		return false
+	}
+	return isNew
+}
+
+// pickupEventType returns the event dispatched for a board status
+func pickupEventType(status string) realtime.EventType {
+	if status == PickupStatusOverdue {
+		return realtime.EventPickupOverdue
+	}
+	return realtime.EventPickupDue
+}
+
+// broadcastPickupDispatch sends pickup_due to the session the student is in and the OGS group;
+// overdue pickups are escalated as pickup_overdue to the school-wide topic as well
+func (s *pickupBoardService) broadcastPickupDispatch(e *PickupBoardEntry, room *PickupBoardRoom) {
+	studentID := strconv.FormatInt(e.StudentID, 10)
+	pickupDate := e.PickupTime.Format(dateFormatISO)
+	pickupTime := e.PickupTime.Format("15:04")
+	data := realtime.EventData{
+		StudentID:  &studentID,
+		PickupDate: &pickupDate,
+		PickupTime: &pickupTime,
+	}
+	if e.StudentName != "" {
+		name := e.StudentName
+		data.StudentName = &name
+	}
+	if e.SchoolClass != "" {
+		schoolClass := e.SchoolClass
+		data.SchoolClass = &schoolClass
+	}
+	if e.GroupName != "" {
+		groupName := e.GroupName
+		data.GroupName = &groupName
+	}
+	if room.RoomID != nil {
+		roomID := strconv.FormatInt(*room.RoomID, 10)
+		roomName := room.RoomName
+		data.RoomID = &roomID
+		data.RoomName = &roomName
+	}
+
+	var topics []string
+	activeGroupID := ""
+	if e.activeGroupID > 0 {
+		activeGroupID = realtime.ActiveGroupTopic(e.activeGroupID)
+		topics = append(topics, activeGroupID)
+	}
+	if e.GroupID != nil {
+		topics = append(topics, realtime.EducationGroupTopic(*e.GroupID))
+	}
+
+	eventType := pickupEventType(e.Status)
+	if e.Status == PickupStatusOverdue {
+		topics = append(topics, realtime.TopicSchool)
+	}
+	if len(topics) == 0 {
+		return
+	}
+
+	event := realtime.NewEvent(eventType, activeGroupID, data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		s.getLogger().Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(eventType)),
+			slog.String("student_id", studentID),
+		)
+	}
+}
@@ -0,0 +1,99 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckedInStudentIDs(t *testing.T) {
+	checkIn := time.Date(2026, 3, 4, 8, 0, 0, 0, timezone.Berlin)
+	checkOut := checkIn.Add(6 * time.Hour)
+	attendance := []*activeModels.Attendance{
+		{StudentID: 12, CheckInTime: checkIn},                          // still checked in
+		{StudentID: 10, CheckInTime: checkIn, CheckOutTime: &checkOut}, // checked out
+		{StudentID: 11, CheckInTime: checkIn, CheckOutTime: &checkOut}, // checked out, then came back
+		{StudentID: 11, CheckInTime: checkOut.Add(time.Hour)},
+	}
+
+	assert.Equal(t, []int64{11, 12}, checkedInStudentIDs(attendance))
+}
+
+func TestPickupTimeOnDate(t *testing.T) {
+	date := time.Date(2026, 3, 4, 0, 0, 0, 0, timezone.Berlin)
+	pickupTime := time.Date(0, 1, 1, 15, 30, 0, 0, time.UTC)
+
+	got := pickupTimeOnDate(date, pickupTime)
+
+	assert.Equal(t, time.Date(2026, 3, 4, 15, 30, 0, 0, timezone.Berlin), got)
+}
+
+func TestPickupStatus(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, timezone.Berlin)
+	window := 30 * time.Minute
+	overdueAfter := 15 * time.Minute
+
+	tests := []struct {
+		name     string
+		pickupAt time.Time
+		status   string
+		onBoard  bool
+	}{
+		{"beyond window", now.Add(45 * time.Minute), "", false},
+		{"within window", now.Add(20 * time.Minute), PickupStatusUpcoming, true},
+		{"due now", now, PickupStatusLeaveNow, true},
+		{"recently passed", now.Add(-10 * time.Minute), PickupStatusLeaveNow, true},
+		{"overdue", now.Add(-15 * time.Minute), PickupStatusOverdue, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := pickupStatus(now, tt.pickupAt, window, overdueAfter)
+			assert.Equal(t, tt.onBoard, ok)
+			assert.Equal(t, tt.status, status)
+		})
+	}
+}
+
+// dispatchedNotificationRepo mimics the unique (student, event, day) constraint in memory
+type dispatchedNotificationRepo struct {
+	dispatched map[string]bool
+}
+
+func (m *dispatchedNotificationRepo) MarkDispatched(_ context.Context, studentID int64, eventType string, date time.Time) (bool, error) {
+	key := fmt.Sprintf("%d:%s:%s", studentID, eventType, date.Format(dateFormatISO))
+	if m.dispatched[key] {
+		return false, nil
+	}
+	m.dispatched[key] = true
+	return true, nil
+}
+
+func TestMarkNotified_OncePerStudentEventAndDay(t *testing.T) {
+	repo := &dispatchedNotificationRepo{dispatched: make(map[string]bool)}
+	svc := NewPickupBoardService(PickupBoardServiceDependencies{NotifiedRepo: repo}).(*pickupBoardService)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, timezone.Berlin)
+
+	assert.True(t, svc.markNotified(ctx, now, 10, realtime.EventPickupDue))
+	assert.False(t, svc.markNotified(ctx, now.Add(time.Minute), 10, realtime.EventPickupDue))
+	assert.True(t, svc.markNotified(ctx, now.Add(15*time.Minute), 10, realtime.EventPickupOverdue))
+	assert.True(t, svc.markNotified(ctx, now, 11, realtime.EventPickupDue))
+
+	// A restarted service or another replica sees the persisted record
+	restarted := NewPickupBoardService(PickupBoardServiceDependencies{NotifiedRepo: repo}).(*pickupBoardService)
+	assert.False(t, restarted.markNotified(ctx, now.Add(2*time.Minute), 10, realtime.EventPickupDue))
+
+	// A new day starts with a fresh record
+	assert.True(t, svc.markNotified(ctx, now.AddDate(0, 0, 1), 10, realtime.EventPickupDue))
+}
+
+func TestNewPickupBoardService_DefaultOverdueAfter(t *testing.T) {
+	svc := NewPickupBoardService(PickupBoardServiceDependencies{}).(*pickupBoardService)
+	assert.Equal(t, DefaultPickupOverdueAfter, svc.overdueAfter)
+}
@@ -111,7 +111,7 @@ func (s *service) broadcastActivityStartEvent(ctx context.Context, group *active
 		},
 	)
 
-	s.broadcastWithLogging(activeGroupID, "", event, "activity_start")
+	s.broadcastWithLogging(sessionTopics(group.ID, group.RoomID, supervisorIDs), "", event, "activity_start")
 }
 
 // validateSupervisorIDs validates that all supervisor IDs exist as staff members
@@ -449,9 +449,43 @@ func (s *service) UpdateActiveGroupSupervisors(ctx context.Context, activeGroupI
 		return nil, &ActiveError{Op: "UpdateActiveGroupSupervisors", Err: err}
 	}
 
+	s.broadcastSupervisorChangeEvent(ctx, updatedGroup)
+
 	return updatedGroup, nil
 }
 
+// broadcastSupervisorChangeEvent broadcasts SSE event for a changed supervisor team.
+// Removed supervisors are reached via their staff topic, since their supervision just ended.
+func (s *service) broadcastSupervisorChangeEvent(ctx context.Context, group *active.Group) {
+	if s.broadcaster == nil || group == nil {
+		return
+	}
+
+	supervisorIDStrs := make([]string, 0, len(group.Supervisors))
+	staffIDs := make([]int64, 0, len(group.Supervisors))
+	for _, supervisor := range group.Supervisors {
+		staffIDs = append(staffIDs, supervisor.StaffID)
+		if supervisor.EndDate == nil {
+			supervisorIDStrs = append(supervisorIDStrs, fmt.Sprintf("%d", supervisor.StaffID))
+		}
+	}
+
+	activityName := s.getActivityName(ctx, group.GroupID)
+	roomName := s.getRoomName(ctx, group.RoomID)
+
+	event := realtime.NewEvent(
+		realtime.EventSupervisorChange,
+		fmt.Sprintf("%d", group.ID),
+		realtime.EventData{
+			ActivityName:  &activityName,
+			RoomName:      &roomName,
+			SupervisorIDs: &supervisorIDStrs,
+		},
+	)
+
+	s.broadcastWithLogging(sessionTopics(group.ID, group.RoomID, staffIDs), "", event, "supervisor_change")
+}
+
 // validateActiveGroupForSupervisorUpdate validates that the group exists and is active
 func (s *service) validateActiveGroupForSupervisorUpdate(ctx context.Context, activeGroupID int64) error {
 	activeGroup, err := s.groupRepo.FindByID(ctx, activeGroupID)
@@ -630,6 +664,7 @@ func (s *service) EndActivitySession(ctx context.Context, activeGroupID int64) e
 	}
 
 	// Use transaction to ensure atomic cleanup
+	var endedSupervisorIDs []int64
 	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
 		txService := s.WithTx(tx).(*service)
 
@@ -649,6 +684,7 @@ func (s *service) EndActivitySession(ctx context.Context, activeGroupID int64) e
 			if err := txService.supervisorRepo.EndSupervision(ctx, sup.ID); err != nil {
 				return err
 			}
+			endedSupervisorIDs = append(endedSupervisorIDs, sup.StaffID)
 		}
 
 		// End the session
@@ -665,9 +701,7 @@ func (s *service) EndActivitySession(ctx context.Context, activeGroupID int64) e
 
 	// Broadcast SSE events (fire-and-forget, outside transaction)
 	if s.broadcaster != nil {
-		activeGroupIDStr := fmt.Sprintf("%d", activeGroupID)
-		s.broadcastStudentCheckoutEvents(activeGroupIDStr, visitsToNotify)
-		s.broadcastActivityEndEvent(ctx, activeGroupID, activeGroupIDStr)
+		s.broadcastSessionEndEvents(ctx, group, endedSupervisorIDs, visitsToNotify)
 	}
 
 	return nil
@@ -776,6 +810,7 @@ func (s *service) ProcessSessionTimeoutByID(ctx context.Context, sessionID int64
 	}
 
 	var result *TimeoutResult
+	var endedSession *active.Group
 	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
 		txService := s.WithTx(tx).(*service)
 
@@ -793,6 +828,7 @@ func (s *service) ProcessSessionTimeoutByID(ctx context.Context, sessionID int64
 			return err
 		}
 
+		endedSession = session
 		result = &TimeoutResult{
 			SessionID:          sessionID,
 			ActivityID:         session.GroupID,
@@ -810,10 +846,9 @@ func (s *service) ProcessSessionTimeoutByID(ctx context.Context, sessionID int64
 	}
 
 	// Broadcast SSE events (fire-and-forget, outside transaction)
-	if s.broadcaster != nil && result != nil {
-		sessionIDStr := fmt.Sprintf("%d", sessionID)
-		s.broadcastStudentCheckoutEvents(sessionIDStr, visitsToNotify)
-		s.broadcastActivityEndEvent(ctx, sessionID, sessionIDStr)
+	if s.broadcaster != nil && endedSession != nil {
+		supervisorIDs := s.sessionSupervisorIDs(ctx, sessionID, true)
+		s.broadcastSessionEndEvents(ctx, endedSession, supervisorIDs, visitsToNotify)
 	}
 
 	return result, nil
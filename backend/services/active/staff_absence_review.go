@@ -0,0 +1,198 @@
+package active
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/email"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+)
+
+// absenceReviewEmailType identifies review notifications in the email outbox
+const absenceReviewEmailType = "absence_review"
+
+// dateFormatGerman is the date format used in review notifications
+const dateFormatGerman = "02.01.2006"
+
+// maxDeclineReasonLength caps the reason shown to the staff member
+const maxDeclineReasonLength = 500
+
+// AbsenceReviewItem is a reported absence in the review queue
+type AbsenceReviewItem struct {
+	*StaffAbsenceResponse
+	StaffName string `json:"staff_name"`
+}
+
+// ListPendingAbsences returns all reported absences awaiting review, earliest first
+func (s *staffAbsenceService) ListPendingAbsences(ctx context.Context) ([]*AbsenceReviewItem, error) {
+	absences, err := s.absenceRepo.GetByStatus(ctx, activeModels.AbsenceStatusReported)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending absences: %w", err)
+	}
+
+	staffNames := s.loadStaffNames(ctx)
+
+	items := make([]*AbsenceReviewItem, len(absences))
+	for i, a := range absences {
+		items[i] = &AbsenceReviewItem{
+			StaffAbsenceResponse: toAbsenceResponse(a),
+			StaffName:            staffNames[a.StaffID],
+		}
+	}
+
+	return items, nil
+}
+
+// loadStaffNames maps staff IDs to display names; names are omitted if they cannot be loaded
+func (s *staffAbsenceService) loadStaffNames(ctx context.Context) map[int64]string {
+	names := make(map[int64]string)
+	if s.staffRepo == nil {
+		return names
+	}
+
+	staffMembers, err := s.staffRepo.ListAllWithPerson(ctx)
+	if err != nil {
+		slog.Default().WarnContext(ctx, "failed to load staff names for absence review",
+			slog.String("error", err.Error()))
+		return names
+	}
+
+	for _, staff := range staffMembers {
+		if staff != nil && staff.Person != nil {
+			names[staff.ID] = staff.Person.GetFullName()
+		}
+	}
+	return names
+}
+
+// ApproveAbsence approves a reported absence and notifies the staff member
+func (s *staffAbsenceService) ApproveAbsence(ctx context.Context, reviewerStaffID int64, absenceID int64) (*StaffAbsenceResponse, error) {
+	return s.reviewAbsence(ctx, reviewerStaffID, absenceID, activeModels.AbsenceStatusApproved, "")
+}
+
+// DeclineAbsence declines a reported absence with a reason and notifies the staff member
+func (s *staffAbsenceService) DeclineAbsence(ctx context.Context, reviewerStaffID int64, absenceID int64, reason string) (*StaffAbsenceResponse, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("decline reason is required")
+	}
+	if len([]rune(reason)) > maxDeclineReasonLength {
+		return nil, fmt.Errorf("decline reason must be at most %d characters", maxDeclineReasonLength)
+	}
+	return s.reviewAbsence(ctx, reviewerStaffID, absenceID, activeModels.AbsenceStatusDeclined, reason)
+}
+
+// reviewAbsence records the review decision on a reported absence
+func (s *staffAbsenceService) reviewAbsence(ctx context.Context, reviewerStaffID, absenceID int64, status, reason string) (*StaffAbsenceResponse, error) {
+	absence, err := s.absenceRepo.FindByID(ctx, absenceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("absence not found")
+		}
+		return nil, fmt.Errorf("failed to get absence: %w", err)
+	}
+
+	if absence.StaffID == reviewerStaffID {
+		return nil, fmt.Errorf("can only review absences of other staff")
+	}
+	if absence.IsReviewed() {
+		return nil, fmt.Errorf("absence has already been reviewed")
+	}
+
+	now := time.Now()
+	absence.Status = status
+	absence.ApprovedBy = &reviewerStaffID
+	absence.ApprovedAt = &now
+	absence.DeclineReason = reason
+	absence.UpdatedAt = now
+
+	// Conditional on the reported status, so of two concurrent reviews only one succeeds
+	updated, err := s.absenceRepo.UpdateReview(ctx, absence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review absence: %w", err)
+	}
+	if !updated {
+		return nil, fmt.Errorf("absence has already been reviewed")
+	}
+
+	if s.dispatcher != nil {
+		go s.sendReviewEmail(absence)
+	}
+
+	return toAbsenceResponse(absence), nil
+}
+
+// resetAbsenceReview sends an absence back to the review queue
+func resetAbsenceReview(absence *activeModels.StaffAbsence) {
+	absence.Status = activeModels.AbsenceStatusReported
+	absence.ApprovedBy = nil
+	absence.ApprovedAt = nil
+	absence.DeclineReason = ""
+}
+
+// sendReviewEmail notifies the staff member about the review decision (called asynchronously)
+func (s *staffAbsenceService) sendReviewEmail(absence *activeModels.StaffAbsence) {
+	ctx := context.Background()
+	if s.dispatcher == nil || s.staffRepo == nil || s.accountRepo == nil {
+		return
+	}
+
+	staff, err := s.staffRepo.FindWithPerson(ctx, absence.StaffID)
+	if err != nil || staff.Person == nil || staff.Person.AccountID == nil {
+		slog.Default().Warn("no account for absence review notification",
+			slog.Int64("absence_id", absence.ID),
+			slog.Int64("staff_id", absence.StaffID))
+		return
+	}
+
+	account, err := s.accountRepo.FindByID(ctx, *staff.Person.AccountID)
+	if err != nil || account.Email == "" {
+		slog.Default().Warn("no email address for absence review notification",
+			slog.Int64("absence_id", absence.ID),
+			slog.Int64("staff_id", absence.StaffID))
+		return
+	}
+
+	approved := absence.Status == activeModels.AbsenceStatusApproved
+	subject := "Deine Abwesenheit wurde genehmigt"
+	if !approved {
+		subject = "Deine Abwesenheit wurde abgelehnt"
+	}
+
+	typeLabel := germanAbsenceTypeLabels[absence.AbsenceType]
+	if typeLabel == "" {
+		typeLabel = absence.AbsenceType
+	}
+
+	message := email.Message{
+		From:     s.defaultFrom,
+		To:       email.NewEmail(staff.Person.GetFullName(), account.Email),
+		Subject:  subject,
+		Template: "absence-review.html",
+		Content: map[string]interface{}{
+			"FirstName":       staff.Person.FirstName,
+			"Approved":        approved,
+			"AbsenceType":     typeLabel,
+			"DateStart":       absence.DateStart.Format(dateFormatGerman),
+			"DateEnd":         absence.DateEnd.Format(dateFormatGerman),
+			"HalfDay":         absence.HalfDay,
+			"DeclineReason":   absence.DeclineReason,
+			"TimeTrackingURL": fmt.Sprintf("%s/time-tracking", s.frontendURL),
+			"LogoURL":         fmt.Sprintf("%s/logo.png", s.frontendURL),
+		},
+	}
+
+	s.dispatcher.Dispatch(ctx, email.DeliveryRequest{
+		Message: message,
+		Metadata: email.DeliveryMetadata{
+			Type:        absenceReviewEmailType,
+			ReferenceID: absence.ID,
+			Recipient:   account.Email,
+		},
+	})
+}
@@ -4,9 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/moto-nrw/project-phoenix/email"
 	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	activityModels "github.com/moto-nrw/project-phoenix/models/activities"
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	educationModels "github.com/moto-nrw/project-phoenix/models/education"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	educationSvc "github.com/moto-nrw/project-phoenix/services/education"
+	"github.com/uptrace/bun"
 )
 
 // dateFormatISO is the standard date format for parsing and formatting
@@ -43,19 +52,103 @@ type StaffAbsenceService interface {
 	DeleteAbsence(ctx context.Context, staffID int64, absenceID int64) error
 	GetAbsencesForRange(ctx context.Context, staffID int64, from, to time.Time) ([]*StaffAbsenceResponse, error)
 	HasAbsenceOnDate(ctx context.Context, staffID int64, date time.Time) (bool, *activeModels.StaffAbsence, error)
+
+	// Review workflow (time_tracking:manage)
+	ListPendingAbsences(ctx context.Context) ([]*AbsenceReviewItem, error)
+	ApproveAbsence(ctx context.Context, reviewerStaffID int64, absenceID int64) (*StaffAbsenceResponse, error)
+	DeclineAbsence(ctx context.Context, reviewerStaffID int64, absenceID int64, reason string) (*StaffAbsenceResponse, error)
+
+	// Substitution planning for the groups and activities an absence leaves uncovered
+	GetSubstitutionSuggestions(ctx context.Context, absenceID int64) (*SubstitutionSuggestions, error)
+	CreateSubstitutionsForAbsence(ctx context.Context, absenceID int64, assignments []SubstitutionAssignment) ([]*educationModels.GroupSubstitution, error)
+}
+
+// StaffAbsenceServiceDependencies contains all dependencies required by the staff absence service
+type StaffAbsenceServiceDependencies struct {
+	AbsenceRepo     activeModels.StaffAbsenceRepository
+	WorkSessionRepo activeModels.WorkSessionRepository
+	StaffRepo       userModels.StaffRepository   // Resolves staff names and review notification recipients
+	AccountRepo     authModels.AccountRepository // Resolves the staff member's email address
+	Dispatcher      *email.Dispatcher            // Review notifications are skipped when nil
+	DefaultFrom     email.Email
+	FrontendURL     string
+
+	// Substitution suggestions
+	EducationService  educationSvc.Service
+	TeacherRepo       userModels.TeacherRepository
+	ActivityGroupRepo activityModels.GroupRepository
+	ScheduleRepo      activityModels.ScheduleRepository           // Schedules of all supervised activities in one query
+	SupervisorRepo    activityModels.SupervisorPlannedRepository  // Supervisors of all supervised activities in one query
+	SubstitutionRepo  educationModels.GroupSubstitutionRepository // Conflicts of all candidates in one query
+	GroupTeacherRepo  educationModels.GroupTeacherRepository      // Candidates leading a group of their own
+	DB                *bun.DB
 }
 
 // staffAbsenceService implements StaffAbsenceService
 type staffAbsenceService struct {
 	absenceRepo     activeModels.StaffAbsenceRepository
 	workSessionRepo activeModels.WorkSessionRepository
+	staffRepo       userModels.StaffRepository
+	accountRepo     authModels.AccountRepository
+	dispatcher      *email.Dispatcher
+	defaultFrom     email.Email
+	frontendURL     string
+
+	educationService  educationSvc.Service
+	teacherRepo       userModels.TeacherRepository
+	activityGroupRepo activityModels.GroupRepository
+	scheduleRepo      activityModels.ScheduleRepository
+	supervisorRepo    activityModels.SupervisorPlannedRepository
+	substitutionRepo  educationModels.GroupSubstitutionRepository
+	groupTeacherRepo  educationModels.GroupTeacherRepository
+	txHandler         *base.TxHandler
 }
 
 // NewStaffAbsenceService creates a new staff absence service
-func NewStaffAbsenceService(absenceRepo activeModels.StaffAbsenceRepository, workSessionRepo activeModels.WorkSessionRepository) StaffAbsenceService {
+func NewStaffAbsenceService(deps StaffAbsenceServiceDependencies) StaffAbsenceService {
 	return &staffAbsenceService{
-		absenceRepo:     absenceRepo,
-		workSessionRepo: workSessionRepo,
+		absenceRepo:     deps.AbsenceRepo,
+		workSessionRepo: deps.WorkSessionRepo,
+		staffRepo:       deps.StaffRepo,
+		accountRepo:     deps.AccountRepo,
+		dispatcher:      deps.Dispatcher,
+		defaultFrom:     deps.DefaultFrom,
+		frontendURL:     strings.TrimRight(deps.FrontendURL, "/"),
+
+		educationService:  deps.EducationService,
+		teacherRepo:       deps.TeacherRepo,
+		activityGroupRepo: deps.ActivityGroupRepo,
+		scheduleRepo:      deps.ScheduleRepo,
+		supervisorRepo:    deps.SupervisorRepo,
+		substitutionRepo:  deps.SubstitutionRepo,
+		groupTeacherRepo:  deps.GroupTeacherRepo,
+		txHandler:         base.NewTxHandler(deps.DB),
+	}
+}
+
+// WithTx returns a new service that uses the provided transaction
+func (s *staffAbsenceService) WithTx(tx bun.Tx) interface{} {
+	var substitutionRepo = s.substitutionRepo
+	if txRepo, ok := s.substitutionRepo.(base.TransactionalRepository); ok {
+		substitutionRepo = txRepo.WithTx(tx).(educationModels.GroupSubstitutionRepository)
+	}
+
+	return &staffAbsenceService{
+		absenceRepo:       s.absenceRepo,
+		workSessionRepo:   s.workSessionRepo,
+		staffRepo:         s.staffRepo,
+		accountRepo:       s.accountRepo,
+		dispatcher:        s.dispatcher,
+		defaultFrom:       s.defaultFrom,
+		frontendURL:       s.frontendURL,
+		educationService:  s.educationService,
+		teacherRepo:       s.teacherRepo,
+		activityGroupRepo: s.activityGroupRepo,
+		scheduleRepo:      s.scheduleRepo,
+		supervisorRepo:    s.supervisorRepo,
+		substitutionRepo:  substitutionRepo,
+		groupTeacherRepo:  s.groupTeacherRepo,
+		txHandler:         s.txHandler.WithTx(tx),
 	}
 }
 
@@ -111,6 +204,9 @@ func (s *staffAbsenceService) mergeOverlappingAbsences(
 
 	// Update the primary absence with merged range
 	primary := existing[0]
+	if !primary.DateStart.Equal(mergedStart) || !primary.DateEnd.Equal(mergedEnd) {
+		resetAbsenceReview(primary)
+	}
 	primary.DateStart = mergedStart
 	primary.DateEnd = mergedEnd
 	if req.Note != "" && primary.Note == "" {
@@ -220,10 +316,17 @@ func (s *staffAbsenceService) UpdateAbsence(ctx context.Context, staffID int64,
 	}
 
 	// Apply updates from request
+	before := *absence
 	if err := applyAbsenceUpdates(absence, req); err != nil {
 		return nil, err
 	}
 
+	// Changing what was reviewed sends the absence back to the review queue
+	if absence.AbsenceType != before.AbsenceType || absence.HalfDay != before.HalfDay ||
+		!absence.DateStart.Equal(before.DateStart) || !absence.DateEnd.Equal(before.DateEnd) {
+		resetAbsenceReview(absence)
+	}
+
 	// Check for overlapping absences (excluding self)
 	if err := s.checkOverlapExcludingSelf(ctx, staffID, absenceID, absence.DateStart, absence.DateEnd); err != nil {
 		return nil, err
@@ -2,12 +2,14 @@ package active
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	activeModels "github.com/moto-nrw/project-phoenix/models/active"
 	"github.com/moto-nrw/project-phoenix/models/base"
+	educationModels "github.com/moto-nrw/project-phoenix/models/education"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +28,8 @@ type absStaffAbsenceRepoMock struct {
 	getByStaffAndDateFunc      func(ctx context.Context, staffID int64, date time.Time) (*activeModels.StaffAbsence, error)
 	getByDateRangeFunc         func(ctx context.Context, from, to time.Time) ([]*activeModels.StaffAbsence, error)
 	getTodayAbsenceMapFunc     func(ctx context.Context) (map[int64]string, error)
+	getByStatusFunc            func(ctx context.Context, status string) ([]*activeModels.StaffAbsence, error)
+	updateReviewFunc           func(ctx context.Context, absence *activeModels.StaffAbsence) (bool, error)
 }
 
 func (m *absStaffAbsenceRepoMock) Create(ctx context.Context, entity *activeModels.StaffAbsence) error {
@@ -91,6 +95,20 @@ func (m *absStaffAbsenceRepoMock) GetTodayAbsenceMap(ctx context.Context) (map[i
 	return nil, nil
 }
 
+func (m *absStaffAbsenceRepoMock) GetByStatus(ctx context.Context, status string) ([]*activeModels.StaffAbsence, error) {
+	if m.getByStatusFunc != nil {
+		return m.getByStatusFunc(ctx, status)
+	}
+	return nil, nil
+}
+
+func (m *absStaffAbsenceRepoMock) UpdateReview(ctx context.Context, absence *activeModels.StaffAbsence) (bool, error) {
+	if m.updateReviewFunc != nil {
+		return m.updateReviewFunc(ctx, absence)
+	}
+	return true, nil
+}
+
 // ============================================================================
 // Mock for WorkSessionRepository (prefixed with abs)
 // ============================================================================
@@ -104,6 +122,7 @@ type absWorkSessionRepoMock struct {
 	getByStaffAndDateFunc   func(ctx context.Context, staffID int64, date time.Time) (*activeModels.WorkSession, error)
 	getCurrentByStaffIDFunc func(ctx context.Context, staffID int64) (*activeModels.WorkSession, error)
 	getHistoryByStaffIDFunc func(ctx context.Context, staffID int64, from, to time.Time) ([]*activeModels.WorkSession, error)
+	getByDateRangeFunc      func(ctx context.Context, from, to time.Time) ([]*activeModels.WorkSession, error)
 	getOpenSessionsFunc     func(ctx context.Context, beforeDate time.Time) ([]*activeModels.WorkSession, error)
 	getTodayPresenceMapFunc func(ctx context.Context) (map[int64]string, error)
 	closeSessionFunc        func(ctx context.Context, id int64, checkOutTime time.Time, autoCheckedOut bool) error
@@ -166,6 +185,13 @@ func (m *absWorkSessionRepoMock) GetHistoryByStaffID(ctx context.Context, staffI
 	return nil, nil
 }
 
+func (m *absWorkSessionRepoMock) GetByDateRange(ctx context.Context, from, to time.Time) ([]*activeModels.WorkSession, error) {
+	if m.getByDateRangeFunc != nil {
+		return m.getByDateRangeFunc(ctx, from, to)
+	}
+	return nil, nil
+}
+
 func (m *absWorkSessionRepoMock) GetOpenSessions(ctx context.Context, beforeDate time.Time) ([]*activeModels.WorkSession, error) {
 	if m.getOpenSessionsFunc != nil {
 		return m.getOpenSessionsFunc(ctx, beforeDate)
@@ -779,3 +805,303 @@ func TestAbsHasAbsenceOnDate_RepoError(t *testing.T) {
 	assert.Nil(t, absence)
 	assert.Contains(t, err.Error(), "failed to check absence")
 }
+
+// ============================================================================
+// Review Tests
+// ============================================================================
+
+func absReportedAbsence(staffID int64) *activeModels.StaffAbsence {
+	return &activeModels.StaffAbsence{
+		Model:       base.Model{ID: 55},
+		StaffID:     staffID,
+		AbsenceType: activeModels.AbsenceTypeVacation,
+		DateStart:   time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC),
+		DateEnd:     time.Date(2026, 7, 17, 0, 0, 0, 0, time.UTC),
+		Status:      activeModels.AbsenceStatusReported,
+		CreatedBy:   staffID,
+	}
+}
+
+func TestAbsListPendingAbsences_Success(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+
+	absRepo.getByStatusFunc = func(_ context.Context, status string) ([]*activeModels.StaffAbsence, error) {
+		assert.Equal(t, activeModels.AbsenceStatusReported, status)
+		return []*activeModels.StaffAbsence{absReportedAbsence(100)}, nil
+	}
+
+	items, err := svc.ListPendingAbsences(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 12, items[0].DurationDays)
+}
+
+func TestAbsApproveAbsence_Success(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+	reviewerID := int64(200)
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return absReportedAbsence(100), nil
+	}
+	absRepo.updateReviewFunc = func(_ context.Context, entity *activeModels.StaffAbsence) (bool, error) {
+		assert.Equal(t, activeModels.AbsenceStatusApproved, entity.Status)
+		require.NotNil(t, entity.ApprovedBy)
+		assert.Equal(t, reviewerID, *entity.ApprovedBy)
+		assert.NotNil(t, entity.ApprovedAt)
+		assert.Empty(t, entity.DeclineReason)
+		return true, nil
+	}
+
+	result, err := svc.ApproveAbsence(context.Background(), reviewerID, 55)
+	require.NoError(t, err)
+	assert.Equal(t, activeModels.AbsenceStatusApproved, result.Status)
+}
+
+func TestAbsApproveAbsence_OwnAbsence(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return absReportedAbsence(100), nil
+	}
+
+	_, err := svc.ApproveAbsence(context.Background(), 100, 55)
+	require.Error(t, err)
+	assert.Equal(t, "can only review absences of other staff", err.Error())
+}
+
+func TestAbsApproveAbsence_AlreadyReviewed(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		absence := absReportedAbsence(100)
+		absence.Status = activeModels.AbsenceStatusDeclined
+		return absence, nil
+	}
+
+	_, err := svc.ApproveAbsence(context.Background(), 200, 55)
+	require.Error(t, err)
+	assert.Equal(t, "absence has already been reviewed", err.Error())
+}
+
+func TestAbsApproveAbsence_ConcurrentReview(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return absReportedAbsence(100), nil
+	}
+	// Another reviewer decided between loading and updating
+	absRepo.updateReviewFunc = func(_ context.Context, _ *activeModels.StaffAbsence) (bool, error) {
+		return false, nil
+	}
+
+	_, err := svc.ApproveAbsence(context.Background(), 200, 55)
+	require.Error(t, err)
+	assert.Equal(t, "absence has already been reviewed", err.Error())
+}
+
+func TestAbsApproveAbsence_NotFound(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return nil, &base.DatabaseError{Op: "find by id", Err: sql.ErrNoRows}
+	}
+
+	_, err := svc.ApproveAbsence(context.Background(), 200, 55)
+	require.Error(t, err)
+	assert.Equal(t, "absence not found", err.Error())
+}
+
+func TestAbsApproveAbsence_LookupError(t *testing.T) {
+	svc, _, _ := absSetupService()
+
+	// Errors other than a missing row are not reported as not found
+	_, err := svc.ApproveAbsence(context.Background(), 200, 55)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get absence")
+}
+
+func TestAbsDeclineAbsence_Success(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return absReportedAbsence(100), nil
+	}
+	absRepo.updateReviewFunc = func(_ context.Context, entity *activeModels.StaffAbsence) (bool, error) {
+		assert.Equal(t, activeModels.AbsenceStatusDeclined, entity.Status)
+		assert.Equal(t, "Ferienbetreuung unterbesetzt", entity.DeclineReason)
+		return true, nil
+	}
+
+	result, err := svc.DeclineAbsence(context.Background(), 200, 55, "  Ferienbetreuung unterbesetzt ")
+	require.NoError(t, err)
+	assert.Equal(t, activeModels.AbsenceStatusDeclined, result.Status)
+}
+
+func TestAbsDeclineAbsence_RequiresReason(t *testing.T) {
+	svc, _, _ := absSetupService()
+
+	_, err := svc.DeclineAbsence(context.Background(), 200, 55, "   ")
+	require.Error(t, err)
+	assert.Equal(t, "decline reason is required", err.Error())
+}
+
+func TestAbsUpdateAbsence_DateChangeResetsReview(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+	staffID := int64(100)
+
+	existing := absReportedAbsence(staffID)
+	reviewerID := int64(200)
+	approvedAt := time.Now()
+	existing.Status = activeModels.AbsenceStatusApproved
+	existing.ApprovedBy = &reviewerID
+	existing.ApprovedAt = &approvedAt
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return existing, nil
+	}
+	absRepo.getByStaffAndDateRangeFunc = func(_ context.Context, _ int64, _, _ time.Time) ([]*activeModels.StaffAbsence, error) {
+		return []*activeModels.StaffAbsence{existing}, nil
+	}
+	absRepo.updateFunc = func(_ context.Context, entity *activeModels.StaffAbsence) error {
+		assert.Equal(t, activeModels.AbsenceStatusReported, entity.Status)
+		assert.Nil(t, entity.ApprovedBy)
+		assert.Nil(t, entity.ApprovedAt)
+		return nil
+	}
+
+	newEnd := "2026-07-24"
+	_, err := svc.UpdateAbsence(context.Background(), staffID, existing.ID, UpdateAbsenceRequest{DateEnd: &newEnd})
+	require.NoError(t, err)
+}
+
+func TestAbsUpdateAbsence_NoteChangeKeepsReview(t *testing.T) {
+	svc, absRepo, _ := absSetupService()
+	staffID := int64(100)
+
+	existing := absReportedAbsence(staffID)
+	existing.Status = activeModels.AbsenceStatusApproved
+
+	absRepo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StaffAbsence, error) {
+		return existing, nil
+	}
+	absRepo.updateFunc = func(_ context.Context, entity *activeModels.StaffAbsence) error {
+		assert.Equal(t, activeModels.AbsenceStatusApproved, entity.Status)
+		return nil
+	}
+
+	note := "Sommerurlaub"
+	_, err := svc.UpdateAbsence(context.Background(), staffID, existing.ID, UpdateAbsenceRequest{Note: &note})
+	require.NoError(t, err)
+}
+
+// ============================================================================
+// Substitution Suggestion Tests
+// ============================================================================
+
+func TestAbsGetSubstitutionSuggestions_NotConfigured(t *testing.T) {
+	svc, _, _ := absSetupService()
+
+	_, err := svc.GetSubstitutionSuggestions(context.Background(), 55)
+	require.Error(t, err)
+	assert.Equal(t, "substitution suggestions are not available", err.Error())
+}
+
+func TestAbsBuildAbsenceSubstitutions_Success(t *testing.T) {
+	absence := absReportedAbsence(100)
+	groups := []*AffectedGroup{{GroupID: 12, GroupName: "Sonnengruppe"}, {GroupID: 13, GroupName: "Mondgruppe"}}
+	start := time.Date(2026, 7, 8, 0, 0, 0, 0, time.UTC)
+
+	subs, err := buildAbsenceSubstitutions(absence, groups, []SubstitutionAssignment{
+		{GroupID: 12, SubstituteStaffID: 200},
+		{GroupID: 13, SubstituteStaffID: 201},
+	}, start)
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+
+	assert.Equal(t, int64(12), subs[0].GroupID)
+	assert.Equal(t, int64(200), subs[0].SubstituteStaffID)
+	require.NotNil(t, subs[0].RegularStaffID)
+	assert.Equal(t, int64(100), *subs[0].RegularStaffID)
+	assert.Equal(t, start, subs[0].StartDate)
+	assert.Equal(t, absence.DateEnd, subs[0].EndDate)
+	assert.Equal(t, "Vertretung: Urlaub", subs[0].Reason)
+}
+
+func TestAbsBuildAbsenceSubstitutions_Rejects(t *testing.T) {
+	absence := absReportedAbsence(100)
+	groups := []*AffectedGroup{{GroupID: 12}, {GroupID: 13, Covered: true}}
+
+	tests := []struct {
+		name        string
+		assignments []SubstitutionAssignment
+		wantErr     string
+	}{
+		{"group not led", []SubstitutionAssignment{{GroupID: 99, SubstituteStaffID: 200}}, "is not led by the absent staff member"},
+		{"already covered", []SubstitutionAssignment{{GroupID: 13, SubstituteStaffID: 200}}, "already has a substitution"},
+		{"assigned twice", []SubstitutionAssignment{{GroupID: 12, SubstituteStaffID: 200}, {GroupID: 12, SubstituteStaffID: 201}}, "assigned twice"},
+		{"self substitute", []SubstitutionAssignment{{GroupID: 12, SubstituteStaffID: 100}}, "substitute must be another staff member"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildAbsenceSubstitutions(absence, groups, tt.assignments, absence.DateStart)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestAbsCheckSubstitutesAvailable(t *testing.T) {
+	candidates := []*SubstitutionCandidate{{StaffID: 200}, {StaffID: 201}}
+	sub := func(groupID, staffID int64) *educationModels.GroupSubstitution {
+		return &educationModels.GroupSubstitution{GroupID: groupID, SubstituteStaffID: staffID}
+	}
+
+	require.NoError(t, checkSubstitutesAvailable(
+		[]*educationModels.GroupSubstitution{sub(12, 200), sub(13, 201)}, candidates))
+
+	err := checkSubstitutesAvailable([]*educationModels.GroupSubstitution{sub(12, 300)}, candidates)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not available for substitution")
+
+	err = checkSubstitutesAvailable([]*educationModels.GroupSubstitution{sub(12, 200), sub(13, 200)}, candidates)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has a substitution")
+}
+
+func TestAbsSortSubstitutionCandidates(t *testing.T) {
+	candidates := []*SubstitutionCandidate{
+		{StaffID: 201, Name: "Berta", ConflictCount: 1, PresentToday: true},
+		{StaffID: 202, Name: "Carla", HasOwnGroup: true, PresentToday: true},
+		{StaffID: 203, Name: "Dora"},
+		{StaffID: 204, Name: "Anna", PresentToday: true},
+	}
+
+	sortSubstitutionCandidates(candidates)
+
+	ids := make([]int64, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.StaffID
+	}
+	assert.Equal(t, []int64{204, 202, 203, 201}, ids)
+}
+
+func TestAbsCountSubstitutionsByStaff(t *testing.T) {
+	regular := int64(100)
+	counts := countSubstitutionsByStaff([]*educationModels.GroupSubstitution{
+		{GroupID: 12, RegularStaffID: &regular, SubstituteStaffID: 200},
+		{GroupID: 13, RegularStaffID: &regular, SubstituteStaffID: 201},
+		{GroupID: 14, SubstituteStaffID: 200},
+	})
+
+	assert.Equal(t, map[int64]int{100: 2, 200: 2, 201: 1}, counts)
+}
+
+func TestAbsAbsenceCoversDate(t *testing.T) {
+	absence := absReportedAbsence(100)
+
+	assert.True(t, absenceCoversDate(absence, absence.DateStart))
+	assert.True(t, absenceCoversDate(absence, absence.DateEnd))
+	assert.False(t, absenceCoversDate(absence, absence.DateStart.AddDate(0, 0, -1)), "presence today says nothing about a future absence")
+	assert.False(t, absenceCoversDate(absence, absence.DateEnd.AddDate(0, 0, 1)))
+}
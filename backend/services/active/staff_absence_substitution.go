@@ -0,0 +1,503 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	activityModels "github.com/moto-nrw/project-phoenix/models/activities"
+	educationModels "github.com/moto-nrw/project-phoenix/models/education"
+	"github.com/uptrace/bun"
+)
+
+// AffectedGroup is an education group led by the absent staff member
+type AffectedGroup struct {
+	GroupID   int64  `json:"group_id"`
+	GroupName string `json:"group_name"`
+	Covered   bool   `json:"covered"` // An existing substitution already overlaps the absence
+}
+
+// AffectedSession is a planned activity session the absent staff member supervises
+type AffectedSession struct {
+	ActivityGroupID  int64     `json:"activity_group_id"`
+	ActivityName     string    `json:"activity_name"`
+	Date             time.Time `json:"date"`
+	TimeframeID      *int64    `json:"timeframe_id,omitempty"`
+	OtherSupervisors int       `json:"other_supervisors"` // Planned supervisors who are not absent
+}
+
+// SubstitutionCandidate is a staff member who could cover the affected groups
+type SubstitutionCandidate struct {
+	StaffID       int64  `json:"staff_id"`
+	TeacherID     int64  `json:"teacher_id"`
+	Name          string `json:"name"`
+	Role          string `json:"role,omitempty"`
+	ConflictCount int    `json:"conflict_count"` // Substitutions overlapping the absence
+	PresentToday  bool   `json:"present_today"`  // Checked in on site today
+	HasOwnGroup   bool   `json:"has_own_group"`
+}
+
+// SubstitutionSuggestions lists what an absence leaves uncovered and who could step in
+type SubstitutionSuggestions struct {
+	AbsenceID  int64                    `json:"absence_id"`
+	StaffID    int64                    `json:"staff_id"`
+	DateStart  time.Time                `json:"date_start"`
+	DateEnd    time.Time                `json:"date_end"`
+	Groups     []*AffectedGroup         `json:"groups"`
+	Sessions   []*AffectedSession       `json:"sessions"`
+	Candidates []*SubstitutionCandidate `json:"candidates"` // Best candidate first
+}
+
+// SubstitutionAssignment assigns a substitute to one affected group
+type SubstitutionAssignment struct {
+	GroupID           int64 `json:"group_id"`
+	SubstituteStaffID int64 `json:"substitute_staff_id"`
+}
+
+// GetSubstitutionSuggestions computes the groups and activity sessions affected by an absence
+// and ranks possible substitutes: staff without overlapping substitutions first, then staff
+// present today, then staff without a group of their own.
+func (s *staffAbsenceService) GetSubstitutionSuggestions(ctx context.Context, absenceID int64) (*SubstitutionSuggestions, error) {
+	if s.educationService == nil || s.teacherRepo == nil {
+		return nil, fmt.Errorf("substitution suggestions are not available")
+	}
+
+	absence, err := s.absenceRepo.FindByID(ctx, absenceID)
+	if err != nil {
+		return nil, fmt.Errorf("absence not found")
+	}
+	if absence.Status == activeModels.AbsenceStatusDeclined {
+		return nil, fmt.Errorf("absence has been declined")
+	}
+
+	groups, err := s.affectedGroups(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.affectedSessions(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.rankSubstitutionCandidates(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubstitutionSuggestions{
+		AbsenceID:  absence.ID,
+		StaffID:    absence.StaffID,
+		DateStart:  absence.DateStart,
+		DateEnd:    absence.DateEnd,
+		Groups:     groups,
+		Sessions:   sessions,
+		Candidates: candidates,
+	}, nil
+}
+
+// affectedGroups returns the education groups the absent staff member leads
+func (s *staffAbsenceService) affectedGroups(ctx context.Context, absence *activeModels.StaffAbsence) ([]*AffectedGroup, error) {
+	result := []*AffectedGroup{}
+
+	teacher, err := s.teacherRepo.FindByStaffID(ctx, absence.StaffID)
+	if err != nil || teacher == nil {
+		// Non-teaching staff lead no groups
+		return result, nil
+	}
+
+	groups, err := s.educationService.GetTeacherGroups(ctx, teacher.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups of absent staff: %w", err)
+	}
+
+	covered := s.coveredGroupIDs(ctx, absence)
+	for _, group := range groups {
+		result = append(result, &AffectedGroup{
+			GroupID:   group.ID,
+			GroupName: group.Name,
+			Covered:   covered[group.ID],
+		})
+	}
+
+	return result, nil
+}
+
+// coveredGroupIDs returns groups with a substitution for the absent staff member overlapping the absence
+func (s *staffAbsenceService) coveredGroupIDs(ctx context.Context, absence *activeModels.StaffAbsence) map[int64]bool {
+	covered := make(map[int64]bool)
+
+	substitutions, err := s.educationService.GetStaffSubstitutions(ctx, absence.StaffID, true)
+	if err != nil {
+		slog.Default().WarnContext(ctx, "failed to load existing substitutions for absence",
+			slog.Int64("absence_id", absence.ID),
+			slog.String("error", err.Error()))
+		return covered
+	}
+
+	for _, sub := range substitutions {
+		if !sub.StartDate.After(absence.DateEnd) && !sub.EndDate.Before(absence.DateStart) {
+			covered[sub.GroupID] = true
+		}
+	}
+	return covered
+}
+
+// affectedSessions expands the weekly schedules of supervised activities into dated sessions.
+// Schedules and supervisors of all activities are loaded in one query each.
+func (s *staffAbsenceService) affectedSessions(ctx context.Context, absence *activeModels.StaffAbsence) ([]*AffectedSession, error) {
+	result := []*AffectedSession{}
+	if s.activityGroupRepo == nil || s.scheduleRepo == nil {
+		return result, nil
+	}
+
+	activityGroups, err := s.activityGroupRepo.FindByStaffSupervisor(ctx, absence.StaffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supervised activities: %w", err)
+	}
+	if len(activityGroups) == 0 {
+		return result, nil
+	}
+
+	activityIDs := make([]int64, 0, len(activityGroups))
+	for _, activity := range activityGroups {
+		activityIDs = append(activityIDs, activity.ID)
+	}
+
+	schedules, err := s.scheduleRepo.FindByGroupIDs(ctx, activityIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity schedules: %w", err)
+	}
+	schedulesByActivity := make(map[int64][]*activityModels.Schedule)
+	for _, schedule := range schedules {
+		schedulesByActivity[schedule.ActivityGroupID] = append(schedulesByActivity[schedule.ActivityGroupID], schedule)
+	}
+
+	otherSupervisors := make(map[int64]int)
+	if s.supervisorRepo != nil {
+		if supervisors, err := s.supervisorRepo.FindByGroupIDs(ctx, activityIDs); err == nil {
+			for _, sup := range supervisors {
+				if sup.StaffID != absence.StaffID {
+					otherSupervisors[sup.GroupID]++
+				}
+			}
+		}
+	}
+
+	for _, activity := range activityGroups {
+		activitySchedules := schedulesByActivity[activity.ID]
+		if len(activitySchedules) == 0 {
+			continue
+		}
+
+		for d := absence.DateStart; !d.After(absence.DateEnd); d = d.AddDate(0, 0, 1) {
+			for _, schedule := range activitySchedules {
+				if schedule.Weekday != activityModels.ISOWeekday(d) {
+					continue
+				}
+				result = append(result, &AffectedSession{
+					ActivityGroupID:  activity.ID,
+					ActivityName:     activity.Name,
+					Date:             d,
+					TimeframeID:      schedule.TimeframeID,
+					OtherSupervisors: otherSupervisors[activity.ID],
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+
+	return result, nil
+}
+
+// rankSubstitutionCandidates returns teachers who are not absent themselves, best candidate first
+func (s *staffAbsenceService) rankSubstitutionCandidates(ctx context.Context, absence *activeModels.StaffAbsence) ([]*SubstitutionCandidate, error) {
+	teachers, err := s.teacherRepo.ListAllWithStaffAndPerson(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teachers: %w", err)
+	}
+
+	absentStaff, err := s.absentStaffIDs(ctx, absence.DateStart, absence.DateEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.substitutionConflictCounts(ctx, absence.DateStart, absence.DateEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	ownGroups, err := s.teachersWithGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Presence only helps when the absence starts today; it says nothing about later days
+	presence := make(map[int64]string)
+	if s.workSessionRepo != nil && absenceCoversDate(absence, timezone.TodayUTC()) {
+		if m, err := s.workSessionRepo.GetTodayPresenceMap(ctx); err == nil {
+			presence = m
+		}
+	}
+
+	candidates := make([]*SubstitutionCandidate, 0, len(teachers))
+	for _, teacher := range teachers {
+		if teacher.Staff == nil || teacher.Staff.Person == nil {
+			continue
+		}
+		staffID := teacher.Staff.ID
+		if staffID == absence.StaffID || absentStaff[staffID] {
+			continue
+		}
+
+		candidates = append(candidates, &SubstitutionCandidate{
+			StaffID:       staffID,
+			TeacherID:     teacher.ID,
+			Name:          teacher.Staff.Person.GetFullName(),
+			Role:          teacher.Role,
+			ConflictCount: conflicts[staffID],
+			PresentToday:  presence[staffID] == activeModels.WorkSessionStatusPresent,
+			HasOwnGroup:   ownGroups[teacher.ID],
+		})
+	}
+
+	sortSubstitutionCandidates(candidates)
+	return candidates, nil
+}
+
+// absenceCoversDate reports whether the date lies within the absence
+func absenceCoversDate(absence *activeModels.StaffAbsence, date time.Time) bool {
+	return !date.Before(absence.DateStart) && !date.After(absence.DateEnd)
+}
+
+// substitutionConflictCounts counts the substitutions overlapping the range per staff member,
+// as regular or substitute staff
+func (s *staffAbsenceService) substitutionConflictCounts(ctx context.Context, from, to time.Time) (map[int64]int, error) {
+	if s.substitutionRepo == nil {
+		return map[int64]int{}, nil
+	}
+
+	substitutions, err := s.substitutionRepo.FindOverlappingRange(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overlapping substitutions: %w", err)
+	}
+	return countSubstitutionsByStaff(substitutions), nil
+}
+
+// countSubstitutionsByStaff counts each substitution once for its substitute and once for its regular staff
+func countSubstitutionsByStaff(substitutions []*educationModels.GroupSubstitution) map[int64]int {
+	counts := make(map[int64]int)
+	for _, sub := range substitutions {
+		counts[sub.SubstituteStaffID]++
+		if sub.RegularStaffID != nil && *sub.RegularStaffID != sub.SubstituteStaffID {
+			counts[*sub.RegularStaffID]++
+		}
+	}
+	return counts
+}
+
+// teachersWithGroups returns the teachers leading at least one education group
+func (s *staffAbsenceService) teachersWithGroups(ctx context.Context) (map[int64]bool, error) {
+	result := make(map[int64]bool)
+	if s.groupTeacherRepo == nil {
+		return result, nil
+	}
+
+	groupTeachers, err := s.groupTeacherRepo.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group teachers: %w", err)
+	}
+	for _, gt := range groupTeachers {
+		result[gt.TeacherID] = true
+	}
+	return result, nil
+}
+
+// sortSubstitutionCandidates orders candidates by fewest conflicts, presence today, no own group, then name
+func sortSubstitutionCandidates(candidates []*SubstitutionCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.ConflictCount != b.ConflictCount {
+			return a.ConflictCount < b.ConflictCount
+		}
+		if a.PresentToday != b.PresentToday {
+			return a.PresentToday
+		}
+		if a.HasOwnGroup != b.HasOwnGroup {
+			return !a.HasOwnGroup
+		}
+		return a.Name < b.Name
+	})
+}
+
+// absentStaffIDs returns staff with a reported or approved absence overlapping the range
+func (s *staffAbsenceService) absentStaffIDs(ctx context.Context, from, to time.Time) (map[int64]bool, error) {
+	absences, err := s.absenceRepo.GetByDateRange(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absences: %w", err)
+	}
+
+	absent := make(map[int64]bool, len(absences))
+	for _, a := range absences {
+		if a.Status != activeModels.AbsenceStatusDeclined {
+			absent[a.StaffID] = true
+		}
+	}
+	return absent, nil
+}
+
+// CreateSubstitutionsForAbsence creates the group substitutions chosen from the suggestions.
+// Substitutions start today at the earliest, since they cannot be backdated.
+func (s *staffAbsenceService) CreateSubstitutionsForAbsence(ctx context.Context, absenceID int64, assignments []SubstitutionAssignment) ([]*educationModels.GroupSubstitution, error) {
+	if s.educationService == nil || s.teacherRepo == nil || s.substitutionRepo == nil {
+		return nil, fmt.Errorf("substitution suggestions are not available")
+	}
+	if len(assignments) == 0 {
+		return nil, fmt.Errorf("invalid request: at least one assignment is required")
+	}
+
+	absence, err := s.absenceRepo.FindByID(ctx, absenceID)
+	if err != nil {
+		return nil, fmt.Errorf("absence not found")
+	}
+	if absence.Status == activeModels.AbsenceStatusDeclined {
+		return nil, fmt.Errorf("absence has been declined")
+	}
+
+	startDate := absence.DateStart
+	if today := timezone.TodayUTC(); startDate.Before(today) {
+		startDate = today
+	}
+	if startDate.After(absence.DateEnd) {
+		return nil, fmt.Errorf("absence has already ended")
+	}
+
+	groups, err := s.affectedGroups(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	substitutions, err := buildAbsenceSubstitutions(absence, groups, assignments, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the ranked candidates are available: teachers who are not absent themselves
+	candidates, err := s.rankSubstitutionCandidates(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSubstitutesAvailable(substitutions, candidates); err != nil {
+		return nil, err
+	}
+	if err := s.checkSubstituteConflicts(ctx, substitutions); err != nil {
+		return nil, err
+	}
+
+	// Either all groups get their substitute or none
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*staffAbsenceService)
+		for _, substitution := range substitutions {
+			if err := txService.substitutionRepo.Create(ctx, substitution); err != nil {
+				return fmt.Errorf("failed to create substitution for group %d: %w", substitution.GroupID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return substitutions, nil
+}
+
+// checkSubstitutesAvailable rejects substitutes who are not among the candidates
+// and substitutes assigned to more than one group, who would be double-booked
+func checkSubstitutesAvailable(substitutions []*educationModels.GroupSubstitution, candidates []*SubstitutionCandidate) error {
+	available := make(map[int64]bool, len(candidates))
+	for _, c := range candidates {
+		available[c.StaffID] = true
+	}
+
+	assigned := make(map[int64]bool, len(substitutions))
+	for _, sub := range substitutions {
+		if !available[sub.SubstituteStaffID] {
+			return fmt.Errorf("substitute staff %d is not available for substitution", sub.SubstituteStaffID)
+		}
+		if assigned[sub.SubstituteStaffID] {
+			return fmt.Errorf("substitute staff %d already has a substitution for group %d", sub.SubstituteStaffID, sub.GroupID)
+		}
+		assigned[sub.SubstituteStaffID] = true
+	}
+	return nil
+}
+
+// checkSubstituteConflicts returns one error per group whose substitute already has an
+// overlapping substitution
+func (s *staffAbsenceService) checkSubstituteConflicts(ctx context.Context, substitutions []*educationModels.GroupSubstitution) error {
+	var conflicts []error
+	for _, sub := range substitutions {
+		existing, err := s.educationService.CheckSubstitutionConflicts(ctx, sub.SubstituteStaffID, sub.StartDate, sub.EndDate)
+		if err != nil {
+			return fmt.Errorf("failed to check substitution conflicts: %w", err)
+		}
+		if len(existing) > 0 {
+			conflicts = append(conflicts, fmt.Errorf("substitute staff %d already has a substitution overlapping group %d", sub.SubstituteStaffID, sub.GroupID))
+		}
+	}
+	return errors.Join(conflicts...)
+}
+
+// buildAbsenceSubstitutions validates the assignments against the affected groups
+func buildAbsenceSubstitutions(absence *activeModels.StaffAbsence, groups []*AffectedGroup, assignments []SubstitutionAssignment, startDate time.Time) ([]*educationModels.GroupSubstitution, error) {
+	affected := make(map[int64]*AffectedGroup, len(groups))
+	for _, g := range groups {
+		affected[g.GroupID] = g
+	}
+
+	reason := germanAbsenceTypeLabels[absence.AbsenceType]
+	if reason == "" {
+		reason = absence.AbsenceType
+	}
+	reason = "Vertretung: " + strings.TrimSpace(reason)
+
+	seen := make(map[int64]bool, len(assignments))
+	substitutions := make([]*educationModels.GroupSubstitution, 0, len(assignments))
+	for _, assignment := range assignments {
+		group, ok := affected[assignment.GroupID]
+		if !ok {
+			return nil, fmt.Errorf("invalid assignment: group %d is not led by the absent staff member", assignment.GroupID)
+		}
+		if group.Covered {
+			return nil, fmt.Errorf("group %d already has a substitution for this absence", assignment.GroupID)
+		}
+		if seen[assignment.GroupID] {
+			return nil, fmt.Errorf("invalid assignment: group %d is assigned twice", assignment.GroupID)
+		}
+		if assignment.SubstituteStaffID <= 0 || assignment.SubstituteStaffID == absence.StaffID {
+			return nil, fmt.Errorf("invalid assignment: substitute must be another staff member")
+		}
+		seen[assignment.GroupID] = true
+
+		regularStaffID := absence.StaffID
+		substitutions = append(substitutions, &educationModels.GroupSubstitution{
+			GroupID:           assignment.GroupID,
+			RegularStaffID:    &regularStaffID,
+			SubstituteStaffID: assignment.SubstituteStaffID,
+			StartDate:         startDate,
+			EndDate:           absence.DateEnd,
+			Reason:            reason,
+		})
+	}
+
+	return substitutions, nil
+}
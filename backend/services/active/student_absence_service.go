@@ -0,0 +1,215 @@
+package active
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+)
+
+// StudentAbsenceRequest defines the request for reporting or updating a student absence
+type StudentAbsenceRequest struct {
+	AbsenceType  string `json:"absence_type"`
+	DateStart    string `json:"date_start"`
+	DateEnd      string `json:"date_end"`
+	Note         string `json:"note"`
+	GuardianNote string `json:"guardian_note"`
+}
+
+// StudentAbsenceReporter identifies who reported a student absence: a staff member or a guardian
+type StudentAbsenceReporter struct {
+	StaffID           int64
+	GuardianProfileID int64
+}
+
+// StudentAbsenceService defines operations for student absence management
+type StudentAbsenceService interface {
+	// ListAbsences returns the absence history of a student, most recent first
+	ListAbsences(ctx context.Context, studentID int64) ([]*activeModels.StudentAbsence, error)
+
+	// CreateAbsence records a new absence; overlapping absences are rejected
+	CreateAbsence(ctx context.Context, studentID int64, reporter StudentAbsenceReporter, req StudentAbsenceRequest) (*activeModels.StudentAbsence, error)
+
+	// UpdateAbsence replaces the type, dates and notes of an absence, keeping its reporter
+	UpdateAbsence(ctx context.Context, studentID, absenceID int64, req StudentAbsenceRequest) (*activeModels.StudentAbsence, error)
+
+	// DeleteAbsence removes an absence of the student
+	DeleteAbsence(ctx context.Context, studentID, absenceID int64) error
+
+	// GetAbsenceOnDate returns the student's absence covering the date, or nil
+	GetAbsenceOnDate(ctx context.Context, studentID int64, date time.Time) (*activeModels.StudentAbsence, error)
+}
+
+// studentAbsenceService implements StudentAbsenceService
+type studentAbsenceService struct {
+	absenceRepo activeModels.StudentAbsenceRepository
+}
+
+// NewStudentAbsenceService creates a new student absence service
+func NewStudentAbsenceService(absenceRepo activeModels.StudentAbsenceRepository) StudentAbsenceService {
+	return &studentAbsenceService{absenceRepo: absenceRepo}
+}
+
+// ListAbsences returns the absence history of a student, most recent first
+func (s *studentAbsenceService) ListAbsences(ctx context.Context, studentID int64) ([]*activeModels.StudentAbsence, error) {
+	absences, err := s.absenceRepo.GetByStudentID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student absences: %w", err)
+	}
+	return absences, nil
+}
+
+// CreateAbsence records a new absence; overlapping absences are rejected
+func (s *studentAbsenceService) CreateAbsence(ctx context.Context, studentID int64, reporter StudentAbsenceReporter, req StudentAbsenceRequest) (*activeModels.StudentAbsence, error) {
+	absence := &activeModels.StudentAbsence{StudentID: studentID}
+	switch {
+	case reporter.StaffID > 0:
+		absence.ReportedBy = reporter.StaffID
+	case reporter.GuardianProfileID > 0:
+		guardianID := reporter.GuardianProfileID
+		absence.ReportedByGuardianID = &guardianID
+	default:
+		return nil, fmt.Errorf("%w: reported_by is required", ErrInvalidStudentAbsence)
+	}
+
+	if err := applyStudentAbsenceRequest(absence, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNoOverlap(ctx, absence); err != nil {
+		return nil, err
+	}
+
+	if err := s.absenceRepo.Create(ctx, absence); err != nil {
+		return nil, fmt.Errorf("failed to create student absence: %w", err)
+	}
+	return absence, nil
+}
+
+// UpdateAbsence replaces the type, dates and notes of an absence, keeping its reporter
+func (s *studentAbsenceService) UpdateAbsence(ctx context.Context, studentID, absenceID int64, req StudentAbsenceRequest) (*activeModels.StudentAbsence, error) {
+	absence, err := s.findStudentAbsence(ctx, studentID, absenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyStudentAbsenceRequest(absence, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNoOverlap(ctx, absence); err != nil {
+		return nil, err
+	}
+
+	absence.UpdatedAt = time.Now()
+	if err := s.absenceRepo.Update(ctx, absence); err != nil {
+		return nil, fmt.Errorf("failed to update student absence: %w", err)
+	}
+	return absence, nil
+}
+
+// DeleteAbsence removes an absence of the student
+func (s *studentAbsenceService) DeleteAbsence(ctx context.Context, studentID, absenceID int64) error {
+	if _, err := s.findStudentAbsence(ctx, studentID, absenceID); err != nil {
+		return err
+	}
+
+	if err := s.absenceRepo.Delete(ctx, absenceID); err != nil {
+		return fmt.Errorf("failed to delete student absence: %w", err)
+	}
+	return nil
+}
+
+// GetAbsenceOnDate returns the student's absence covering the date, or nil
+func (s *studentAbsenceService) GetAbsenceOnDate(ctx context.Context, studentID int64, date time.Time) (*activeModels.StudentAbsence, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	absences, err := s.absenceRepo.GetByStudentAndDateRange(ctx, studentID, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student absence: %w", err)
+	}
+	if len(absences) == 0 {
+		return nil, nil
+	}
+	return absences[0], nil
+}
+
+// findStudentAbsence loads an absence and verifies it belongs to the student
+func (s *studentAbsenceService) findStudentAbsence(ctx context.Context, studentID, absenceID int64) (*activeModels.StudentAbsence, error) {
+	absence, err := s.absenceRepo.FindByID(ctx, absenceID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get student absence: %w", err)
+	}
+	if err != nil || absence == nil || absence.StudentID != studentID {
+		return nil, ErrStudentAbsenceNotFound
+	}
+	return absence, nil
+}
+
+// ensureNoOverlap rejects an absence that overlaps another absence of the same student
+func (s *studentAbsenceService) ensureNoOverlap(ctx context.Context, absence *activeModels.StudentAbsence) error {
+	existing, err := s.absenceRepo.GetByStudentAndDateRange(ctx, absence.StudentID, absence.DateStart, absence.DateEnd)
+	if err != nil {
+		return fmt.Errorf("failed to check existing absences: %w", err)
+	}
+
+	for _, e := range existing {
+		if e.ID == absence.ID {
+			continue
+		}
+		return fmt.Errorf("%w with existing %s absence from %s to %s",
+			ErrStudentAbsenceOverlap,
+			e.AbsenceType,
+			e.DateStart.Format(dateFormatISO),
+			e.DateEnd.Format(dateFormatISO))
+	}
+	return nil
+}
+
+// applyStudentAbsenceRequest copies the request fields onto the absence and validates it
+func applyStudentAbsenceRequest(absence *activeModels.StudentAbsence, req StudentAbsenceRequest) error {
+	dateStart, dateEnd, err := parseDateRange(req.DateStart, req.DateEnd)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidStudentAbsence, err)
+	}
+
+	absence.AbsenceType = req.AbsenceType
+	absence.DateStart = dateStart
+	absence.DateEnd = dateEnd
+	absence.Note = strings.TrimSpace(req.Note)
+	absence.GuardianNote = strings.TrimSpace(req.GuardianNote)
+
+	if err := absence.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidStudentAbsence, err)
+	}
+	return nil
+}
+
+// GetStudentAbsenceOnDate returns the student's absence covering the date, or nil when none
+// is reported or the absence repository is not configured
+func (s *service) GetStudentAbsenceOnDate(ctx context.Context, studentID int64, date time.Time) (*activeModels.StudentAbsence, error) {
+	if s.absenceRepo == nil {
+		return nil, nil
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	absences, err := s.absenceRepo.GetByStudentAndDateRange(ctx, studentID, day, day)
+	if err != nil {
+		return nil, &ActiveError{Op: "GetStudentAbsenceOnDate", Err: ErrDatabaseOperation}
+	}
+	if len(absences) == 0 {
+		return nil, nil
+	}
+	return absences[0], nil
+}
+
+// absentStudentIDs collects the students with an absence covering the day
+func absentStudentIDs(absences []*activeModels.StudentAbsence, day time.Time) map[int64]bool {
+	result := make(map[int64]bool, len(absences))
+	for _, a := range absences {
+		if a.CoversDate(day) {
+			result[a.StudentID] = true
+		}
+	}
+	return result
+}
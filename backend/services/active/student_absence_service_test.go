@@ -0,0 +1,288 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// Mock for StudentAbsenceRepository (prefixed with sabs)
+// ============================================================================
+
+type sabsStudentAbsenceRepoMock struct {
+	createFunc                   func(ctx context.Context, entity *activeModels.StudentAbsence) error
+	findByIDFunc                 func(ctx context.Context, id any) (*activeModels.StudentAbsence, error)
+	updateFunc                   func(ctx context.Context, entity *activeModels.StudentAbsence) error
+	deleteFunc                   func(ctx context.Context, id any) error
+	getByStudentIDFunc           func(ctx context.Context, studentID int64) ([]*activeModels.StudentAbsence, error)
+	getByStudentAndDateRangeFunc func(ctx context.Context, studentID int64, from, to time.Time) ([]*activeModels.StudentAbsence, error)
+}
+
+func (m *sabsStudentAbsenceRepoMock) Create(ctx context.Context, entity *activeModels.StudentAbsence) error {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, entity)
+	}
+	return nil
+}
+func (m *sabsStudentAbsenceRepoMock) FindByID(ctx context.Context, id any) (*activeModels.StudentAbsence, error) {
+	if m.findByIDFunc != nil {
+		return m.findByIDFunc(ctx, id)
+	}
+	return nil, errors.New("not found")
+}
+func (m *sabsStudentAbsenceRepoMock) Update(ctx context.Context, entity *activeModels.StudentAbsence) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(ctx, entity)
+	}
+	return nil
+}
+func (m *sabsStudentAbsenceRepoMock) Delete(ctx context.Context, id any) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, id)
+	}
+	return nil
+}
+func (m *sabsStudentAbsenceRepoMock) List(_ context.Context, _ *base.QueryOptions) ([]*activeModels.StudentAbsence, error) {
+	return nil, nil
+}
+func (m *sabsStudentAbsenceRepoMock) GetByStudentID(ctx context.Context, studentID int64) ([]*activeModels.StudentAbsence, error) {
+	if m.getByStudentIDFunc != nil {
+		return m.getByStudentIDFunc(ctx, studentID)
+	}
+	return nil, nil
+}
+func (m *sabsStudentAbsenceRepoMock) GetByStudentAndDateRange(ctx context.Context, studentID int64, from, to time.Time) ([]*activeModels.StudentAbsence, error) {
+	if m.getByStudentAndDateRangeFunc != nil {
+		return m.getByStudentAndDateRangeFunc(ctx, studentID, from, to)
+	}
+	return nil, nil
+}
+func (m *sabsStudentAbsenceRepoMock) GetByDate(_ context.Context, _ time.Time) ([]*activeModels.StudentAbsence, error) {
+	return nil, nil
+}
+
+func sabsAbsence(id, studentID int64, absenceType string, startDay, endDay int) *activeModels.StudentAbsence {
+	return &activeModels.StudentAbsence{
+		Model:       base.Model{ID: id},
+		StudentID:   studentID,
+		AbsenceType: absenceType,
+		DateStart:   time.Date(2026, 3, startDay, 0, 0, 0, 0, time.UTC),
+		DateEnd:     time.Date(2026, 3, endDay, 0, 0, 0, 0, time.UTC),
+		ReportedBy:  50,
+	}
+}
+
+// ============================================================================
+// CreateAbsence Tests
+// ============================================================================
+
+func TestStudentAbsenceCreate_StaffReported(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+
+	repo.createFunc = func(_ context.Context, entity *activeModels.StudentAbsence) error {
+		assert.Equal(t, int64(10), entity.StudentID)
+		assert.Equal(t, int64(50), entity.ReportedBy)
+		assert.Nil(t, entity.ReportedByGuardianID)
+		entity.ID = 99
+		return nil
+	}
+
+	result, err := svc.CreateAbsence(context.Background(), 10, StudentAbsenceReporter{StaffID: 50}, StudentAbsenceRequest{
+		AbsenceType: activeModels.StudentAbsenceTypeTrip,
+		DateStart:   "2026-03-02",
+		DateEnd:     "2026-03-04",
+		Note:        "  Klassenfahrt  ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), result.ID)
+	assert.Equal(t, "Klassenfahrt", result.Note)
+	assert.Equal(t, 3, result.DurationDays())
+}
+
+func TestStudentAbsenceCreate_GuardianReported(t *testing.T) {
+	svc := NewStudentAbsenceService(&sabsStudentAbsenceRepoMock{})
+
+	result, err := svc.CreateAbsence(context.Background(), 10, StudentAbsenceReporter{GuardianProfileID: 70}, StudentAbsenceRequest{
+		AbsenceType:  activeModels.StudentAbsenceTypeSick,
+		DateStart:    "2026-03-02",
+		DateEnd:      "2026-03-02",
+		GuardianNote: "Fieber",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.ReportedByGuardianID)
+	assert.Equal(t, int64(70), *result.ReportedByGuardianID)
+	assert.Equal(t, "Fieber", result.GuardianNote)
+}
+
+func TestStudentAbsenceCreate_ValidationErrors(t *testing.T) {
+	svc := NewStudentAbsenceService(&sabsStudentAbsenceRepoMock{})
+	ctx := context.Background()
+	staff := StudentAbsenceReporter{StaffID: 50}
+
+	_, err := svc.CreateAbsence(ctx, 10, StudentAbsenceReporter{}, StudentAbsenceRequest{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidStudentAbsence)
+	assert.Contains(t, err.Error(), "reported_by is required")
+
+	_, err = svc.CreateAbsence(ctx, 10, staff, StudentAbsenceRequest{AbsenceType: "sick", DateStart: "bad", DateEnd: "2026-03-02"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidStudentAbsence)
+	assert.Contains(t, err.Error(), "invalid date_start format")
+
+	_, err = svc.CreateAbsence(ctx, 10, staff, StudentAbsenceRequest{AbsenceType: "holiday", DateStart: "2026-03-02", DateEnd: "2026-03-02"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidStudentAbsence)
+	assert.Contains(t, err.Error(), "invalid absence type")
+}
+
+func TestStudentAbsenceCreate_Overlap(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+
+	repo.getByStudentAndDateRangeFunc = func(_ context.Context, _ int64, _, _ time.Time) ([]*activeModels.StudentAbsence, error) {
+		return []*activeModels.StudentAbsence{sabsAbsence(20, 10, activeModels.StudentAbsenceTypeSick, 3, 5)}, nil
+	}
+	repo.createFunc = func(_ context.Context, _ *activeModels.StudentAbsence) error {
+		t.Fatal("create must not be called")
+		return nil
+	}
+
+	_, err := svc.CreateAbsence(context.Background(), 10, StudentAbsenceReporter{StaffID: 50}, StudentAbsenceRequest{
+		AbsenceType: activeModels.StudentAbsenceTypeTrip,
+		DateStart:   "2026-03-02",
+		DateEnd:     "2026-03-04",
+	})
+	require.ErrorIs(t, err, ErrStudentAbsenceOverlap)
+	assert.Contains(t, err.Error(), "absence overlaps with existing sick absence from 2026-03-03 to 2026-03-05")
+}
+
+// ============================================================================
+// Update / Delete Tests
+// ============================================================================
+
+func TestStudentAbsenceUpdate_IgnoresItselfInOverlapCheck(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+	existing := sabsAbsence(20, 10, activeModels.StudentAbsenceTypeSick, 3, 5)
+
+	repo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StudentAbsence, error) {
+		return existing, nil
+	}
+	repo.getByStudentAndDateRangeFunc = func(_ context.Context, _ int64, _, _ time.Time) ([]*activeModels.StudentAbsence, error) {
+		return []*activeModels.StudentAbsence{existing}, nil
+	}
+
+	result, err := svc.UpdateAbsence(context.Background(), 10, 20, StudentAbsenceRequest{
+		AbsenceType: activeModels.StudentAbsenceTypeExcused,
+		DateStart:   "2026-03-03",
+		DateEnd:     "2026-03-06",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, activeModels.StudentAbsenceTypeExcused, result.AbsenceType)
+	assert.Equal(t, int64(50), result.ReportedBy, "reporter is kept")
+}
+
+func TestStudentAbsenceUpdateDelete_WrongStudent(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+
+	repo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StudentAbsence, error) {
+		return sabsAbsence(20, 11, activeModels.StudentAbsenceTypeSick, 3, 5), nil
+	}
+	repo.deleteFunc = func(_ context.Context, _ any) error {
+		t.Fatal("delete must not be called")
+		return nil
+	}
+
+	_, err := svc.UpdateAbsence(context.Background(), 10, 20, StudentAbsenceRequest{})
+	assert.ErrorIs(t, err, ErrStudentAbsenceNotFound)
+
+	err = svc.DeleteAbsence(context.Background(), 10, 20)
+	assert.ErrorIs(t, err, ErrStudentAbsenceNotFound)
+}
+
+func TestStudentAbsenceDelete_LookupError(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+
+	repo.findByIDFunc = func(_ context.Context, _ any) (*activeModels.StudentAbsence, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	err := svc.DeleteAbsence(context.Background(), 10, 20)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrStudentAbsenceNotFound)
+	assert.Contains(t, err.Error(), "failed to get student absence")
+}
+
+// ============================================================================
+// Query Tests
+// ============================================================================
+
+func TestStudentAbsenceGetAbsenceOnDate(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+
+	repo.getByStudentAndDateRangeFunc = func(_ context.Context, _ int64, from, to time.Time) ([]*activeModels.StudentAbsence, error) {
+		assert.Equal(t, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, from, to)
+		return []*activeModels.StudentAbsence{sabsAbsence(20, 10, activeModels.StudentAbsenceTypeSick, 3, 5)}, nil
+	}
+
+	absence, err := svc.GetAbsenceOnDate(context.Background(), 10, time.Date(2026, 3, 4, 14, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NotNil(t, absence)
+	assert.Equal(t, int64(20), absence.ID)
+
+	repo.getByStudentAndDateRangeFunc = nil
+	absence, err = svc.GetAbsenceOnDate(context.Background(), 10, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, absence)
+}
+
+func TestStudentAbsenceListAbsences_RepoError(t *testing.T) {
+	repo := &sabsStudentAbsenceRepoMock{}
+	svc := NewStudentAbsenceService(repo)
+	repo.getByStudentIDFunc = func(_ context.Context, _ int64) ([]*activeModels.StudentAbsence, error) {
+		return nil, errors.New("db error")
+	}
+
+	result, err := svc.ListAbsences(context.Background(), 10)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+// ============================================================================
+// Dashboard missing count
+// ============================================================================
+
+func TestCalculateAbsentAndMissing(t *testing.T) {
+	sick := true
+	enrolled := []*userModels.Student{
+		{Model: base.Model{ID: 10}},              // present
+		{Model: base.Model{ID: 11}},              // absence record
+		{Model: base.Model{ID: 12}, Sick: &sick}, // legacy sick flag
+		{Model: base.Model{ID: 13}},              // missing
+		{Model: base.Model{ID: 14}},              // absence record but checked in anyway
+	}
+	present := map[int64]bool{10: true, 14: true}
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	absent := absentStudentIDs([]*activeModels.StudentAbsence{
+		sabsAbsence(20, 11, activeModels.StudentAbsenceTypeTrip, 3, 5),
+		sabsAbsence(21, 14, activeModels.StudentAbsenceTypeSick, 4, 4),
+		sabsAbsence(22, 13, activeModels.StudentAbsenceTypeSick, 1, 2), // ended before today
+	}, day)
+
+	absentCount, missingCount := calculateAbsentAndMissing(enrolled, present, absent)
+	assert.Equal(t, 2, absentCount)
+	assert.Equal(t, 1, missingCount)
+}
@@ -0,0 +1,291 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	feedbackModels "github.com/moto-nrw/project-phoenix/models/feedback"
+	iotModels "github.com/moto-nrw/project-phoenix/models/iot"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+)
+
+// Timeline event types
+const (
+	TimelineEventArrival    = "arrival"     // Checked in for the day
+	TimelineEventRoomChange = "room_change" // Entered a room
+	TimelineEventSchulhof   = "schulhof"    // Went out to the school yard
+	TimelineEventFeedback   = "feedback"    // Gave feedback at a device
+	TimelineEventPickup     = "pickup"      // Checked out (collected, went home alone or left by bus)
+)
+
+// defaultVisitRetentionDays applies when a student has no accepted privacy consent
+const defaultVisitRetentionDays = 30
+
+// TimelineEvent is one entry of a student's daily timeline
+type TimelineEvent struct {
+	Type            string     `json:"type"`
+	Time            time.Time  `json:"time"`
+	EndTime         *time.Time `json:"end_time,omitempty"`         // Room visits only; nil while still in the room
+	DurationMinutes *int       `json:"duration_minutes,omitempty"` // Room visits only
+	RoomID          *int64     `json:"room_id,omitempty"`
+	RoomName        string     `json:"room_name,omitempty"`
+	PerformedBy     string     `json:"performed_by,omitempty"` // Staff member who checked the student in or out
+	DeviceName      string     `json:"device_name,omitempty"`  // Device the action was recorded on
+	PickedUpBy      string     `json:"picked_up_by,omitempty"` // Guardian or pickup person who collected the student
+	BusRouteName    string     `json:"bus_route_name,omitempty"`
+	FeedbackValue   string     `json:"feedback_value,omitempty"`
+	IsMensaFeedback bool       `json:"is_mensa_feedback,omitempty"`
+}
+
+// StudentTimeline is the ordered list of what happened to a student on one day
+type StudentTimeline struct {
+	StudentID     int64            `json:"student_id"`
+	Date          time.Time        `json:"date"`
+	RetentionDays int              `json:"retention_days"`
+	VisitsExpired bool             `json:"visits_expired"` // Room visits of the date lie outside the retention window and are omitted
+	Events        []*TimelineEvent `json:"events"`
+}
+
+// StudentTimelineService answers "where was my child today?"
+type StudentTimelineService interface {
+	// GetStudentTimeline returns the arrival, room changes, Schulhof hops, feedback and pickup
+	// of a student on the given date, ordered by time
+	GetStudentTimeline(ctx context.Context, studentID int64, date time.Time) (*StudentTimeline, error)
+}
+
+// StudentTimelineServiceDependencies contains all dependencies required by the student timeline service
+type StudentTimelineServiceDependencies struct {
+	AttendanceRepo     activeModels.AttendanceRepository
+	VisitRepo          activeModels.VisitRepository
+	GroupRepo          activeModels.GroupRepository // Active groups with their rooms
+	FeedbackRepo       feedbackModels.EntryRepository
+	PrivacyConsentRepo userModels.PrivacyConsentRepository
+	StaffRepo          userModels.StaffRepository
+	DeviceRepo         iotModels.DeviceRepository
+	BusRouteRepo       scheduleModels.BusRouteRepository
+}
+
+// studentTimelineService implements StudentTimelineService
+type studentTimelineService struct {
+	attendanceRepo     activeModels.AttendanceRepository
+	visitRepo          activeModels.VisitRepository
+	groupRepo          activeModels.GroupRepository
+	feedbackRepo       feedbackModels.EntryRepository
+	privacyConsentRepo userModels.PrivacyConsentRepository
+	staffRepo          userModels.StaffRepository
+	deviceRepo         iotModels.DeviceRepository
+	busRouteRepo       scheduleModels.BusRouteRepository
+}
+
+// NewStudentTimelineService creates a new student timeline service
+func NewStudentTimelineService(deps StudentTimelineServiceDependencies) StudentTimelineService {
+	return &studentTimelineService{
+		attendanceRepo:     deps.AttendanceRepo,
+		visitRepo:          deps.VisitRepo,
+		groupRepo:          deps.GroupRepo,
+		feedbackRepo:       deps.FeedbackRepo,
+		privacyConsentRepo: deps.PrivacyConsentRepo,
+		staffRepo:          deps.StaffRepo,
+		deviceRepo:         deps.DeviceRepo,
+		busRouteRepo:       deps.BusRouteRepo,
+	}
+}
+
+// timelineNames caches staff and device names looked up while building one timeline
+type timelineNames struct {
+	staff   map[int64]string
+	devices map[int64]string
+}
+
+// GetStudentTimeline returns the arrival, room changes, Schulhof hops, feedback and pickup
+// of a student on the given date, ordered by time
+func (s *studentTimelineService) GetStudentTimeline(ctx context.Context, studentID int64, date time.Time) (*StudentTimeline, error) {
+	day := timezone.DateOf(date)
+	retentionDays, err := s.visitRetentionDays(ctx, studentID)
+	if err != nil {
+		return nil, &ActiveError{Op: "get student timeline", Err: err}
+	}
+
+	timeline := &StudentTimeline{
+		StudentID:     studentID,
+		Date:          day,
+		RetentionDays: retentionDays,
+		// Visits older than the retention window are deleted by the cleanup job; do not show partial leftovers
+		VisitsExpired: day.Before(timezone.Today().AddDate(0, 0, -retentionDays)),
+		Events:        []*TimelineEvent{},
+	}
+	names := &timelineNames{staff: make(map[int64]string), devices: make(map[int64]string)}
+
+	attendance, err := s.attendanceRepo.FindByStudentAndDate(ctx, studentID, day)
+	if err != nil {
+		return nil, &ActiveError{Op: "get student timeline", Err: fmt.Errorf("failed to get attendance: %w", err)}
+	}
+	for _, a := range attendance {
+		timeline.Events = append(timeline.Events, s.attendanceEvents(ctx, a, names)...)
+	}
+
+	if !timeline.VisitsExpired {
+		visitEvents, err := s.visitEvents(ctx, studentID, day, names)
+		if err != nil {
+			return nil, &ActiveError{Op: "get student timeline", Err: err}
+		}
+		timeline.Events = append(timeline.Events, visitEvents...)
+	}
+
+	entries, err := s.feedbackRepo.FindByStudentAndDateRange(ctx, studentID, timezone.DateOfUTC(day), timezone.DateOfUTC(day))
+	if err != nil {
+		return nil, &ActiveError{Op: "get student timeline", Err: fmt.Errorf("failed to get feedback: %w", err)}
+	}
+	for _, e := range entries {
+		timeline.Events = append(timeline.Events, &TimelineEvent{
+			Type:            TimelineEventFeedback,
+			Time:            pickupTimeOnDate(day, e.Time),
+			FeedbackValue:   e.Value,
+			IsMensaFeedback: e.IsMensaFeedback,
+		})
+	}
+
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		return timeline.Events[i].Time.Before(timeline.Events[j].Time)
+	})
+	return timeline, nil
+}
+
+// visitRetentionDays returns the retention window of the student's most recent accepted privacy consent
+func (s *studentTimelineService) visitRetentionDays(ctx context.Context, studentID int64) (int, error) {
+	consents, err := s.privacyConsentRepo.FindByStudentID(ctx, studentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get privacy consent: %w", err)
+	}
+
+	var consent *userModels.PrivacyConsent
+	for _, c := range consents {
+		if c.Accepted && (consent == nil || c.CreatedAt.After(consent.CreatedAt)) {
+			consent = c
+		}
+	}
+	if consent == nil {
+		return defaultVisitRetentionDays, nil
+	}
+	return consent.GetDataRetentionDays(), nil
+}
+
+// attendanceEvents builds the arrival and pickup events of one attendance record
+func (s *studentTimelineService) attendanceEvents(ctx context.Context, a *activeModels.Attendance, names *timelineNames) []*TimelineEvent {
+	events := []*TimelineEvent{{
+		Type:        TimelineEventArrival,
+		Time:        a.CheckInTime,
+		PerformedBy: s.staffName(ctx, a.CheckedInBy, names),
+		DeviceName:  s.deviceName(ctx, a.DeviceID, names),
+	}}
+	if a.CheckOutTime == nil {
+		return events
+	}
+
+	pickup := &TimelineEvent{
+		Type: TimelineEventPickup,
+		Time: *a.CheckOutTime,
+	}
+	if a.CheckedOutBy != nil {
+		pickup.PerformedBy = s.staffName(ctx, *a.CheckedOutBy, names)
+	}
+	if a.PickedUpByName != nil {
+		pickup.PickedUpBy = *a.PickedUpByName
+	}
+	if a.DepartedByBusRouteID != nil {
+		if route, err := s.busRouteRepo.FindByID(ctx, *a.DepartedByBusRouteID); err == nil && route != nil {
+			pickup.BusRouteName = route.Name
+		}
+	}
+	return append(events, pickup)
+}
+
+// visitEvents builds one room change or Schulhof event per visit of the day
+func (s *studentTimelineService) visitEvents(ctx context.Context, studentID int64, day time.Time, names *timelineNames) ([]*TimelineEvent, error) {
+	visits, err := s.visitRepo.FindByStudentAndTimeRange(ctx, studentID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visits: %w", err)
+	}
+	if len(visits) == 0 {
+		return nil, nil
+	}
+
+	groupIDs := make([]int64, 0, len(visits))
+	for _, v := range visits {
+		groupIDs = append(groupIDs, v.ActiveGroupID)
+	}
+	groups, err := s.groupRepo.FindByIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active groups: %w", err)
+	}
+
+	events := make([]*TimelineEvent, 0, len(visits))
+	for _, v := range visits {
+		event := &TimelineEvent{
+			Type:    TimelineEventRoomChange,
+			Time:    v.EntryTime,
+			EndTime: v.ExitTime,
+		}
+		if v.ExitTime != nil {
+			minutes := int(v.ExitTime.Sub(v.EntryTime).Round(time.Minute) / time.Minute)
+			event.DurationMinutes = &minutes
+		}
+		if group := groups[v.ActiveGroupID]; group != nil {
+			roomID := group.RoomID
+			event.RoomID = &roomID
+			if group.Room != nil {
+				event.RoomName = group.Room.Name
+			}
+			if isPlaygroundRoom(group.Room) {
+				event.Type = TimelineEventSchulhof
+			}
+			if group.DeviceID != nil {
+				event.DeviceName = s.deviceName(ctx, *group.DeviceID, names)
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// staffName resolves a staff member's full name; empty when unknown
+func (s *studentTimelineService) staffName(ctx context.Context, staffID int64, names *timelineNames) string {
+	if staffID <= 0 {
+		return ""
+	}
+	if name, ok := names.staff[staffID]; ok {
+		return name
+	}
+
+	name := ""
+	if staff, err := s.staffRepo.FindWithPerson(ctx, staffID); err == nil && staff != nil && staff.Person != nil {
+		name = fmt.Sprintf("%s %s", staff.Person.FirstName, staff.Person.LastName)
+	}
+	names.staff[staffID] = name
+	return name
+}
+
+// deviceName resolves a device's display name, falling back to its hardware ID; empty when unknown
+func (s *studentTimelineService) deviceName(ctx context.Context, deviceID int64, names *timelineNames) string {
+	if deviceID <= 0 {
+		return ""
+	}
+	if name, ok := names.devices[deviceID]; ok {
+		return name
+	}
+
+	name := ""
+	if device, err := s.deviceRepo.FindByID(ctx, deviceID); err == nil && device != nil {
+		name = device.DeviceID
+		if device.Name != nil && *device.Name != "" {
+			name = *device.Name
+		}
+	}
+	names.devices[deviceID] = name
+	return name
+}
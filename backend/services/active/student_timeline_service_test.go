@@ -0,0 +1,215 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	facilityModels "github.com/moto-nrw/project-phoenix/models/facilities"
+	feedbackModels "github.com/moto-nrw/project-phoenix/models/feedback"
+	iotModels "github.com/moto-nrw/project-phoenix/models/iot"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// Mocks (prefixed with timeline). Repository mocks embed the interface; methods
+// the timeline service does not use panic when called.
+// ============================================================================
+
+type timelineAttendanceRepoMock struct {
+	activeModels.AttendanceRepository
+	records []*activeModels.Attendance
+}
+
+func (m *timelineAttendanceRepoMock) FindByStudentAndDate(_ context.Context, _ int64, _ time.Time) ([]*activeModels.Attendance, error) {
+	return m.records, nil
+}
+
+type timelineVisitRepoMock struct {
+	activeModels.VisitRepository
+	visits []*activeModels.Visit
+	called bool
+}
+
+func (m *timelineVisitRepoMock) FindByStudentAndTimeRange(_ context.Context, _ int64, _, _ time.Time) ([]*activeModels.Visit, error) {
+	m.called = true
+	return m.visits, nil
+}
+
+type timelineGroupRepoMock struct {
+	activeModels.GroupRepository
+	groups map[int64]*activeModels.Group
+}
+
+func (m *timelineGroupRepoMock) FindByIDs(_ context.Context, _ []int64) (map[int64]*activeModels.Group, error) {
+	return m.groups, nil
+}
+
+type timelineFeedbackRepoMock struct {
+	feedbackModels.EntryRepository
+	entries []*feedbackModels.Entry
+}
+
+func (m *timelineFeedbackRepoMock) FindByStudentAndDateRange(_ context.Context, _ int64, _, _ time.Time) ([]*feedbackModels.Entry, error) {
+	return m.entries, nil
+}
+
+type timelineConsentRepoMock struct {
+	userModels.PrivacyConsentRepository
+	consents []*userModels.PrivacyConsent
+}
+
+func (m *timelineConsentRepoMock) FindByStudentID(_ context.Context, _ int64) ([]*userModels.PrivacyConsent, error) {
+	return m.consents, nil
+}
+
+type timelineStaffRepoMock struct {
+	userModels.StaffRepository
+	lookups int
+}
+
+func (m *timelineStaffRepoMock) FindWithPerson(_ context.Context, id int64) (*userModels.Staff, error) {
+	m.lookups++
+	if id != 90 {
+		return nil, errors.New("not found")
+	}
+	return &userModels.Staff{Person: &userModels.Person{FirstName: "Jana", LastName: "Becker"}}, nil
+}
+
+type timelineDeviceRepoMock struct {
+	iotModels.DeviceRepository
+}
+
+func (m *timelineDeviceRepoMock) FindByID(_ context.Context, _ interface{}) (*iotModels.Device, error) {
+	name := "Eingang"
+	return &iotModels.Device{DeviceID: "rfid-eingang-01", Name: &name}, nil
+}
+
+// timelineFixture is a Wednesday with arrival at 11:45, the Kunstraum, a Schulhof hop,
+// mensa feedback and a bus departure at 15:40
+func newTimelineFixture(day time.Time, consents []*userModels.PrivacyConsent) (StudentTimelineService, *timelineVisitRepoMock, *timelineStaffRepoMock) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, timezone.Berlin)
+	}
+	checkOut := at(15, 40)
+	kunstExit := at(13, 30)
+	hofExit := at(14, 5)
+	staffID := int64(90)
+	busRouteID := int64(10)
+	deviceID := int64(80)
+	kunstCategory := "Kreativ"
+	hofCategory := "Schulhof"
+
+	visits := &timelineVisitRepoMock{visits: []*activeModels.Visit{
+		{StudentID: 20, ActiveGroupID: 30, EntryTime: at(12, 0), ExitTime: &kunstExit},
+		{StudentID: 20, ActiveGroupID: 31, EntryTime: at(13, 30), ExitTime: &hofExit},
+	}}
+	staff := &timelineStaffRepoMock{}
+
+	svc := NewStudentTimelineService(StudentTimelineServiceDependencies{
+		AttendanceRepo: &timelineAttendanceRepoMock{records: []*activeModels.Attendance{{
+			StudentID:            20,
+			CheckInTime:          at(11, 45),
+			CheckedInBy:          staffID,
+			DeviceID:             deviceID,
+			CheckOutTime:         &checkOut,
+			CheckedOutBy:         &staffID,
+			DepartedByBusRouteID: &busRouteID,
+		}}},
+		VisitRepo: visits,
+		GroupRepo: &timelineGroupRepoMock{groups: map[int64]*activeModels.Group{
+			30: {RoomID: 50, DeviceID: &deviceID, Room: &facilityModels.Room{Name: "Kunstraum", Category: &kunstCategory}},
+			31: {RoomID: 51, Room: &facilityModels.Room{Name: "Schulhof", Category: &hofCategory}},
+		}},
+		FeedbackRepo: &timelineFeedbackRepoMock{entries: []*feedbackModels.Entry{
+			{StudentID: 20, Value: feedbackModels.ValuePositive, Day: day, Time: time.Date(0, 1, 1, 12, 50, 0, 0, time.UTC), IsMensaFeedback: true},
+		}},
+		PrivacyConsentRepo: &timelineConsentRepoMock{consents: consents},
+		StaffRepo:          staff,
+		DeviceRepo:         &timelineDeviceRepoMock{},
+		BusRouteRepo: &busRouteRepoMock{
+			route: &scheduleModels.BusRoute{Model: base.Model{ID: busRouteID}, Name: "Linie 12 Nord"},
+		},
+	})
+	return svc, visits, staff
+}
+
+func TestGetStudentTimeline(t *testing.T) {
+	day := timezone.Today().AddDate(0, 0, -3)
+	svc, _, staff := newTimelineFixture(day, nil)
+
+	timeline, err := svc.GetStudentTimeline(context.Background(), 20, day)
+	require.NoError(t, err)
+
+	assert.Equal(t, 30, timeline.RetentionDays)
+	assert.False(t, timeline.VisitsExpired)
+
+	types := make([]string, 0, len(timeline.Events))
+	for _, e := range timeline.Events {
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []string{
+		TimelineEventArrival,
+		TimelineEventRoomChange,
+		TimelineEventFeedback,
+		TimelineEventSchulhof,
+		TimelineEventPickup,
+	}, types)
+
+	arrival := timeline.Events[0]
+	assert.Equal(t, "Jana Becker", arrival.PerformedBy)
+	assert.Equal(t, "Eingang", arrival.DeviceName)
+
+	kunst := timeline.Events[1]
+	assert.Equal(t, "Kunstraum", kunst.RoomName)
+	require.NotNil(t, kunst.DurationMinutes)
+	assert.Equal(t, 90, *kunst.DurationMinutes)
+	assert.Equal(t, "Eingang", kunst.DeviceName)
+
+	feedback := timeline.Events[2]
+	assert.Equal(t, "12:50", feedback.Time.Format("15:04"))
+	assert.True(t, feedback.IsMensaFeedback)
+
+	hof := timeline.Events[3]
+	require.NotNil(t, hof.DurationMinutes)
+	assert.Equal(t, 35, *hof.DurationMinutes)
+	assert.Empty(t, hof.DeviceName)
+
+	pickup := timeline.Events[4]
+	assert.Equal(t, "Jana Becker", pickup.PerformedBy)
+	assert.Equal(t, "Linie 12 Nord", pickup.BusRouteName)
+
+	// Check-in and check-out by the same staff member resolve the name once
+	assert.Equal(t, 1, staff.lookups)
+}
+
+func TestGetStudentTimeline_OutsideRetentionWindow(t *testing.T) {
+	day := timezone.Today().AddDate(0, 0, -10)
+	consents := []*userModels.PrivacyConsent{
+		{Model: base.Model{CreatedAt: day.AddDate(0, -2, 0)}, Accepted: true, DataRetentionDays: 14},
+		{Model: base.Model{CreatedAt: day.AddDate(0, -1, 0)}, Accepted: true, DataRetentionDays: 7},
+		{Model: base.Model{CreatedAt: day}, Accepted: false, DataRetentionDays: 30},
+	}
+	svc, visits, _ := newTimelineFixture(day, consents)
+
+	timeline, err := svc.GetStudentTimeline(context.Background(), 20, day)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, timeline.RetentionDays)
+	assert.True(t, timeline.VisitsExpired)
+	assert.False(t, visits.called)
+
+	// Attendance and feedback are kept beyond the visit retention window
+	require.Len(t, timeline.Events, 3)
+	for _, e := range timeline.Events {
+		assert.NotEqual(t, TimelineEventRoomChange, e.Type)
+		assert.NotEqual(t, TimelineEventSchulhof, e.Type)
+	}
+}
@@ -0,0 +1,390 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+)
+
+// balanceExportHeaders are the column headers of the monthly balance report export
+var balanceExportHeaders = []string{"Name", "Wochenstunden", "Soll (Std)", "Ist (Std)", "Abwesenheit (Std)", "Saldo (Std)"}
+
+// ContractRequest defines the request for setting a staff member's contracted hours
+type ContractRequest struct {
+	StaffID     int64   `json:"staff_id"`
+	WeeklyHours float64 `json:"weekly_hours"`
+	ValidFrom   string  `json:"valid_from"`
+	Note        string  `json:"note"`
+}
+
+// BalanceDay is the target and actual working time of a single day
+type BalanceDay struct {
+	Date           time.Time `json:"date"`
+	TargetMinutes  int       `json:"target_minutes"`
+	WorkedMinutes  int       `json:"worked_minutes"`
+	AbsenceMinutes int       `json:"absence_minutes"`
+	AbsenceType    string    `json:"absence_type,omitempty"`
+	Holiday        string    `json:"holiday,omitempty"` // Public holiday, school holiday or closure day
+}
+
+// MonthlyBalance is a staff member's target vs. actual working time for a month.
+// Days after today are not counted, so the balance of the current month is the balance so far.
+type MonthlyBalance struct {
+	StaffID        int64         `json:"staff_id"`
+	StaffName      string        `json:"staff_name,omitempty"`
+	Month          string        `json:"month"`          // YYYY-MM
+	WeeklyMinutes  int           `json:"weekly_minutes"` // Contract in effect on the last counted day
+	TargetMinutes  int           `json:"target_minutes"`
+	WorkedMinutes  int           `json:"worked_minutes"`  // Net of breaks
+	AbsenceMinutes int           `json:"absence_minutes"` // Credited for approved absences
+	BalanceMinutes int           `json:"balance_minutes"`
+	Days           []*BalanceDay `json:"days,omitempty"`
+}
+
+// TimeBalanceService defines operations for contracted hours and overtime balances
+type TimeBalanceService interface {
+	ListContracts(ctx context.Context, staffID int64) ([]*activeModels.StaffContract, error)
+	SetContract(ctx context.Context, actorStaffID int64, req ContractRequest) (*activeModels.StaffContract, error)
+	DeleteContract(ctx context.Context, contractID int64) error
+	GetMonthlyBalance(ctx context.Context, staffID int64, month time.Time) (*MonthlyBalance, error)
+	GetBalanceReport(ctx context.Context, month time.Time) ([]*MonthlyBalance, error)
+	ExportBalanceReport(ctx context.Context, month time.Time, format string) ([]byte, string, error)
+}
+
+// timeBalanceService implements TimeBalanceService
+type timeBalanceService struct {
+	contractRepo    activeModels.StaffContractRepository
+	workSessionRepo activeModels.WorkSessionRepository
+	absenceRepo     activeModels.StaffAbsenceRepository
+	staffRepo       userModels.StaffRepository
+	dateframeRepo   scheduleModels.DateframeRepository
+}
+
+// NewTimeBalanceService creates a new time balance service; without a dateframe repository
+// only public holidays are excluded from the targets
+func NewTimeBalanceService(contractRepo activeModels.StaffContractRepository, workSessionRepo activeModels.WorkSessionRepository, absenceRepo activeModels.StaffAbsenceRepository, staffRepo userModels.StaffRepository, dateframeRepo scheduleModels.DateframeRepository) TimeBalanceService {
+	return &timeBalanceService{contractRepo: contractRepo, workSessionRepo: workSessionRepo, absenceRepo: absenceRepo, staffRepo: staffRepo, dateframeRepo: dateframeRepo}
+}
+
+// ListContracts returns a staff member's contracted hours history, oldest first
+func (s *timeBalanceService) ListContracts(ctx context.Context, staffID int64) ([]*activeModels.StaffContract, error) {
+	contracts, err := s.contractRepo.GetByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contracts: %w", err)
+	}
+	return contracts, nil
+}
+
+// SetContract records contracted hours from a date on; an entry starting on the same date is replaced
+func (s *timeBalanceService) SetContract(ctx context.Context, actorStaffID int64, req ContractRequest) (*activeModels.StaffContract, error) {
+	validFrom, err := time.Parse(dateFormatISO, req.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid valid_from date: %w", err)
+	}
+	if _, err := s.staffRepo.FindByID(ctx, req.StaffID); err != nil {
+		return nil, fmt.Errorf("staff not found")
+	}
+
+	weeklyMinutes := int(math.Round(req.WeeklyHours * 60))
+
+	existing, err := s.contractRepo.GetByStaffAndValidFrom(ctx, req.StaffID, validFrom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing contract: %w", err)
+	}
+
+	if existing != nil {
+		existing.WeeklyMinutes = weeklyMinutes
+		existing.Note = strings.TrimSpace(req.Note)
+		if err := existing.Validate(); err != nil {
+			return nil, err
+		}
+		if err := s.contractRepo.Update(ctx, existing); err != nil {
+			return nil, fmt.Errorf("failed to update contract: %w", err)
+		}
+		return existing, nil
+	}
+
+	contract := &activeModels.StaffContract{
+		StaffID:       req.StaffID,
+		WeeklyMinutes: weeklyMinutes,
+		ValidFrom:     validFrom,
+		Note:          strings.TrimSpace(req.Note),
+		CreatedBy:     actorStaffID,
+	}
+	if err := contract.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.contractRepo.Create(ctx, contract); err != nil {
+		return nil, fmt.Errorf("failed to create contract: %w", err)
+	}
+	return contract, nil
+}
+
+// DeleteContract removes a contracted hours entry, e.g. one recorded by mistake
+func (s *timeBalanceService) DeleteContract(ctx context.Context, contractID int64) error {
+	if _, err := s.contractRepo.FindByID(ctx, contractID); err != nil {
+		return fmt.Errorf("contract not found")
+	}
+	if err := s.contractRepo.Delete(ctx, contractID); err != nil {
+		return fmt.Errorf("failed to delete contract: %w", err)
+	}
+	return nil
+}
+
+// GetMonthlyBalance computes a staff member's balance for the month containing the given date, with daily details
+func (s *timeBalanceService) GetMonthlyBalance(ctx context.Context, staffID int64, month time.Time) (*MonthlyBalance, error) {
+	from, to := balancePeriod(month)
+
+	contracts, err := s.contractRepo.GetByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contracts: %w", err)
+	}
+
+	var sessions []*activeModels.WorkSession
+	var absences []*activeModels.StaffAbsence
+	if !to.Before(from) {
+		sessions, err = s.workSessionRepo.GetHistoryByStaffID(ctx, staffID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sessions: %w", err)
+		}
+		absences, err = s.absenceRepo.GetByStaffAndDateRange(ctx, staffID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absences: %w", err)
+		}
+	}
+
+	closed, err := scheduleModels.SchoolClosureDays(ctx, s.dateframeRepo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := computeMonthlyBalance(staffID, month, from, to, closed, contracts, sessions, absences)
+	if staff, err := s.staffRepo.FindWithPerson(ctx, staffID); err == nil && staff.Person != nil {
+		balance.StaffName = staff.Person.GetFullName()
+	}
+	return balance, nil
+}
+
+// GetBalanceReport computes the monthly balance of every staff member with a contract or
+// recorded working time in the month, sorted by name. Daily details are omitted.
+func (s *timeBalanceService) GetBalanceReport(ctx context.Context, month time.Time) ([]*MonthlyBalance, error) {
+	from, to := balancePeriod(month)
+	monthEnd := from.AddDate(0, 1, -1)
+
+	contracts, err := s.contractRepo.ListValidUntil(ctx, monthEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contracts: %w", err)
+	}
+
+	var sessions []*activeModels.WorkSession
+	var absences []*activeModels.StaffAbsence
+	if !to.Before(from) {
+		sessions, err = s.workSessionRepo.GetByDateRange(ctx, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sessions: %w", err)
+		}
+		absences, err = s.absenceRepo.GetByDateRange(ctx, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absences: %w", err)
+		}
+	}
+
+	closed, err := scheduleModels.SchoolClosureDays(ctx, s.dateframeRepo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	contractsByStaff := make(map[int64][]*activeModels.StaffContract)
+	for _, c := range contracts {
+		contractsByStaff[c.StaffID] = append(contractsByStaff[c.StaffID], c)
+	}
+	sessionsByStaff := make(map[int64][]*activeModels.WorkSession)
+	for _, ws := range sessions {
+		sessionsByStaff[ws.StaffID] = append(sessionsByStaff[ws.StaffID], ws)
+	}
+	absencesByStaff := make(map[int64][]*activeModels.StaffAbsence)
+	for _, a := range absences {
+		absencesByStaff[a.StaffID] = append(absencesByStaff[a.StaffID], a)
+	}
+
+	staffIDs := make(map[int64]bool)
+	for id := range contractsByStaff {
+		staffIDs[id] = true
+	}
+	for id := range sessionsByStaff {
+		staffIDs[id] = true
+	}
+
+	names := staffNamesByID(ctx, s.staffRepo)
+
+	report := make([]*MonthlyBalance, 0, len(staffIDs))
+	for staffID := range staffIDs {
+		balance := computeMonthlyBalance(staffID, month, from, to, closed, contractsByStaff[staffID], sessionsByStaff[staffID], absencesByStaff[staffID])
+		balance.StaffName = names[staffID]
+		balance.Days = nil
+		report = append(report, balance)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].StaffName != report[j].StaffName {
+			return report[i].StaffName < report[j].StaffName
+		}
+		return report[i].StaffID < report[j].StaffID
+	})
+
+	return report, nil
+}
+
+// ExportBalanceReport generates a CSV or XLSX export of the monthly balance report
+func (s *timeBalanceService) ExportBalanceReport(ctx context.Context, month time.Time, format string) ([]byte, string, error) {
+	report, err := s.GetBalanceReport(ctx, month)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows := make([][]string, len(report))
+	for i, b := range report {
+		rows[i] = []string{
+			b.StaffName,
+			formatHoursGerman(b.WeeklyMinutes),
+			formatMinutesGerman(b.TargetMinutes),
+			formatMinutesGerman(b.WorkedMinutes),
+			formatMinutesGerman(b.AbsenceMinutes),
+			formatMinutesGerman(b.BalanceMinutes),
+		}
+	}
+
+	monthStr := month.Format("2006-01")
+
+	switch format {
+	case "xlsx":
+		data, err := writeExportXLSX("Stundensaldo", balanceExportHeaders, rows)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fmt.Sprintf("stundensaldo_%s.xlsx", monthStr), nil
+	default:
+		data, err := writeExportCSV(balanceExportHeaders, rows)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fmt.Sprintf("stundensaldo_%s.csv", monthStr), nil
+	}
+}
+
+// staffNamesByID maps staff IDs to display names; names are omitted if they cannot be loaded
+func staffNamesByID(ctx context.Context, staffRepo userModels.StaffRepository) map[int64]string {
+	names := make(map[int64]string)
+	if staffRepo == nil {
+		return names
+	}
+	staffMembers, err := staffRepo.ListAllWithPerson(ctx)
+	if err != nil {
+		return names
+	}
+	for _, staff := range staffMembers {
+		if staff != nil && staff.Person != nil {
+			names[staff.ID] = staff.Person.GetFullName()
+		}
+	}
+	return names
+}
+
+// balancePeriod returns the counted days of the month containing the given date:
+// the first of the month up to the month end or today, whichever is earlier.
+// For future months the returned end lies before the start.
+func balancePeriod(month time.Time) (from, to time.Time) {
+	from = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to = from.AddDate(0, 1, -1)
+	if today := timezone.TodayUTC(); today.Before(to) {
+		to = today
+	}
+	return from, to
+}
+
+// computeMonthlyBalance sums the daily targets and actual times of one staff member.
+// Weekdays carry the daily share of the contract in effect; weekends and the closed days
+// (NRW public holidays, school holidays and closure days, keyed by midnight UTC) have no
+// target. Approved absences credit the day's target (half for half days).
+func computeMonthlyBalance(staffID int64, month, from, to time.Time, closed map[time.Time]string, contracts []*activeModels.StaffContract, sessions []*activeModels.WorkSession, absences []*activeModels.StaffAbsence) *MonthlyBalance {
+	balance := &MonthlyBalance{
+		StaffID: staffID,
+		Month:   month.Format("2006-01"),
+		Days:    []*BalanceDay{},
+	}
+
+	worked := make(map[time.Time]int)
+	for _, ws := range sessions {
+		day := time.Date(ws.Date.Year(), ws.Date.Month(), ws.Date.Day(), 0, 0, 0, 0, time.UTC)
+		worked[day] += ws.NetMinutes()
+	}
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		day := &BalanceDay{Date: d, WorkedMinutes: worked[d]}
+
+		contract := activeModels.EffectiveContract(contracts, d)
+		if contract != nil {
+			balance.WeeklyMinutes = contract.WeeklyMinutes
+		}
+
+		if name, ok := closed[d]; ok {
+			day.Holiday = name
+		} else if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday && contract != nil {
+			day.TargetMinutes = contract.DailyTargetMinutes()
+		}
+
+		if absence := approvedAbsenceOn(absences, d); absence != nil {
+			day.AbsenceType = absence.AbsenceType
+			day.AbsenceMinutes = day.TargetMinutes
+			if absence.HalfDay {
+				day.AbsenceMinutes = day.TargetMinutes / 2
+			}
+		}
+
+		balance.TargetMinutes += day.TargetMinutes
+		balance.WorkedMinutes += day.WorkedMinutes
+		balance.AbsenceMinutes += day.AbsenceMinutes
+		balance.Days = append(balance.Days, day)
+	}
+
+	balance.BalanceMinutes = balance.WorkedMinutes + balance.AbsenceMinutes - balance.TargetMinutes
+	return balance
+}
+
+// approvedAbsenceOn returns the approved absence covering the date, or nil
+func approvedAbsenceOn(absences []*activeModels.StaffAbsence, date time.Time) *activeModels.StaffAbsence {
+	for _, a := range absences {
+		if a.Status != activeModels.AbsenceStatusApproved {
+			continue
+		}
+		if !date.Before(a.DateStart) && !date.After(a.DateEnd) {
+			return a
+		}
+	}
+	return nil
+}
+
+// formatMinutesGerman formats minutes as "Xh YYmin", with a leading minus for negative values
+func formatMinutesGerman(minutes int) string {
+	sign := ""
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	return fmt.Sprintf("%s%dh %02dmin", sign, minutes/60, minutes%60)
+}
+
+// formatHoursGerman formats minutes as decimal hours with a comma, e.g. "19,5"
+func formatHoursGerman(minutes int) string {
+	hours := strconv.FormatFloat(float64(minutes)/60, 'f', -1, 64)
+	return strings.Replace(hours, ".", ",", 1)
+}
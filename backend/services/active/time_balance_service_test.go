@@ -0,0 +1,143 @@
+package active
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/holidays"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tbDate(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func tbContract(weeklyHours int, validFrom time.Time) *activeModels.StaffContract {
+	return &activeModels.StaffContract{StaffID: 100, WeeklyMinutes: weeklyHours * 60, ValidFrom: validFrom, CreatedBy: 100}
+}
+
+func tbAbsence(absenceType, status string, start, end time.Time, halfDay bool) *activeModels.StaffAbsence {
+	return &activeModels.StaffAbsence{
+		StaffID:     100,
+		AbsenceType: absenceType,
+		DateStart:   start,
+		DateEnd:     end,
+		HalfDay:     halfDay,
+		Status:      status,
+	}
+}
+
+func TestComputeMonthlyBalance(t *testing.T) {
+	// April 2026: 22 weekdays, Karfreitag (3rd) and Ostermontag (6th) are public holidays
+	from, to := tbDate(time.April, 1), tbDate(time.April, 30)
+	contracts := []*activeModels.StaffContract{tbContract(40, tbDate(time.January, 1))}
+
+	checkOut := time.Date(2026, 4, 1, 17, 0, 0, 0, time.UTC)
+	sessions := []*activeModels.WorkSession{{
+		Model:        base.Model{ID: 10},
+		StaffID:      100,
+		Date:         tbDate(time.April, 1),
+		CheckInTime:  time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC),
+		CheckOutTime: &checkOut,
+		BreakMinutes: 30,
+	}}
+
+	absences := []*activeModels.StaffAbsence{
+		tbAbsence(activeModels.AbsenceTypeVacation, activeModels.AbsenceStatusApproved, tbDate(time.April, 7), tbDate(time.April, 8), false),
+		tbAbsence(activeModels.AbsenceTypeSick, activeModels.AbsenceStatusReported, tbDate(time.April, 9), tbDate(time.April, 9), false),
+		tbAbsence(activeModels.AbsenceTypeTraining, activeModels.AbsenceStatusApproved, tbDate(time.April, 10), tbDate(time.April, 10), true),
+	}
+
+	balance := computeMonthlyBalance(100, from, from, to, holidays.InRange(from, to), contracts, sessions, absences)
+
+	assert.Equal(t, "2026-04", balance.Month)
+	assert.Equal(t, 2400, balance.WeeklyMinutes)
+	assert.Equal(t, 20*480, balance.TargetMinutes)
+	assert.Equal(t, 510, balance.WorkedMinutes)
+	assert.Equal(t, 2*480+240, balance.AbsenceMinutes)
+	assert.Equal(t, 510+1200-9600, balance.BalanceMinutes)
+
+	require.Len(t, balance.Days, 30)
+	goodFriday := balance.Days[2]
+	assert.Equal(t, "Karfreitag", goodFriday.Holiday)
+	assert.Equal(t, 0, goodFriday.TargetMinutes)
+
+	sickDay := balance.Days[8]
+	assert.Empty(t, sickDay.AbsenceType, "unapproved absences are not credited")
+	assert.Equal(t, 480, sickDay.TargetMinutes)
+
+	saturday := balance.Days[3]
+	assert.Equal(t, 0, saturday.TargetMinutes)
+}
+
+func TestComputeMonthlyBalance_ContractChange(t *testing.T) {
+	from, to := tbDate(time.April, 1), tbDate(time.April, 30)
+	contracts := []*activeModels.StaffContract{
+		tbContract(20, tbDate(time.April, 16)),
+		tbContract(40, tbDate(time.January, 1)),
+	}
+
+	balance := computeMonthlyBalance(100, from, from, to, holidays.InRange(from, to), contracts, nil, nil)
+
+	// 9 working days at 8h before the change, 11 at 4h from the 16th on
+	assert.Equal(t, 9*480+11*240, balance.TargetMinutes)
+	assert.Equal(t, 1200, balance.WeeklyMinutes)
+	assert.Equal(t, -balance.TargetMinutes, balance.BalanceMinutes)
+}
+
+func TestComputeMonthlyBalance_ClosureDays(t *testing.T) {
+	from, to := tbDate(time.April, 1), tbDate(time.April, 30)
+	closed := holidays.InRange(from, to)
+	for d := tbDate(time.April, 1); !d.After(tbDate(time.April, 11)); d = d.AddDate(0, 0, 1) {
+		if _, ok := closed[d]; !ok {
+			closed[d] = "Osterferien"
+		}
+	}
+
+	balance := computeMonthlyBalance(100, from, from, to, closed, []*activeModels.StaffContract{tbContract(40, tbDate(time.January, 1))}, nil, nil)
+
+	// 20 working days in April minus the six weekdays of the Easter holidays that are no public holidays
+	assert.Equal(t, 14*480, balance.TargetMinutes)
+	assert.Equal(t, "Osterferien", balance.Days[0].Holiday)
+	assert.Equal(t, "Karfreitag", balance.Days[2].Holiday, "public holidays keep their name")
+}
+
+func TestComputeMonthlyBalance_NoContract(t *testing.T) {
+	from, to := tbDate(time.April, 1), tbDate(time.April, 30)
+
+	balance := computeMonthlyBalance(100, from, from, to, holidays.InRange(from, to), nil, nil, []*activeModels.StaffAbsence{
+		tbAbsence(activeModels.AbsenceTypeVacation, activeModels.AbsenceStatusApproved, tbDate(time.April, 7), tbDate(time.April, 8), false),
+	})
+
+	assert.Equal(t, 0, balance.TargetMinutes)
+	assert.Equal(t, 0, balance.AbsenceMinutes)
+	assert.Equal(t, 0, balance.BalanceMinutes)
+}
+
+func TestBalancePeriod(t *testing.T) {
+	from, to := balancePeriod(tbDate(time.February, 14).AddDate(-1, 0, 0))
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), to)
+
+	today := timezone.TodayUTC()
+	_, to = balancePeriod(today)
+	assert.Equal(t, today, to, "current month is counted up to today")
+
+	from, to = balancePeriod(today.AddDate(0, 2, 0))
+	assert.True(t, to.Before(from), "future months have no counted days")
+}
+
+func TestFormatMinutesGerman(t *testing.T) {
+	assert.Equal(t, "8h 00min", formatMinutesGerman(480))
+	assert.Equal(t, "-2h 05min", formatMinutesGerman(-125))
+	assert.Equal(t, "0h 00min", formatMinutesGerman(0))
+}
+
+func TestFormatHoursGerman(t *testing.T) {
+	assert.Equal(t, "39", formatHoursGerman(2340))
+	assert.Equal(t, "19,5", formatHoursGerman(1170))
+}
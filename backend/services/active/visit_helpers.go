@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/moto-nrw/project-phoenix/internal/timezone"
@@ -121,15 +122,16 @@ func (s *service) clearCheckoutOnReentry(ctx context.Context, studentID int64, a
 	}
 }
 
-// autoClearStudentSickness clears sickness flag when student checks in
-func (s *service) autoClearStudentSickness(ctx context.Context, studentID int64) {
+// autoClearStudentSickness clears sickness flag when student checks in.
+// Returns the student when the flag was cleared, so the caller can broadcast it after commit.
+func (s *service) autoClearStudentSickness(ctx context.Context, studentID int64) *userModels.Student {
 	student, err := s.studentRepo.FindByID(ctx, studentID)
 	if err != nil || student == nil {
-		return
+		return nil
 	}
 
 	if student.Sick == nil || !*student.Sick {
-		return
+		return nil
 	}
 
 	// Student is marked as sick, clear it since they're checking in
@@ -142,16 +144,54 @@ func (s *service) autoClearStudentSickness(ctx context.Context, studentID int64)
 			slog.Int64("student_id", studentID),
 			slog.String("error", err.Error()),
 		)
-		return
+		return nil
 	}
 
 	s.getLogger().Info("auto-cleared sickness on student check-in",
 		slog.Int64("student_id", studentID),
 	)
+	return student
 }
 
-// broadcastVisitCreated sends SSE event for visit creation
-func (s *service) broadcastVisitCreated(ctx context.Context, visit *active.Visit) {
+// broadcastSicknessCleared sends the student_sick event the manual sick toggle emits to the
+// student's OGS group and the school-wide topic
+func (s *service) broadcastSicknessCleared(ctx context.Context, student *userModels.Student) {
+	if s.broadcaster == nil || student == nil {
+		return
+	}
+
+	studentID := strconv.FormatInt(student.ID, 10)
+	sick := false
+	data := realtime.EventData{
+		StudentID: &studentID,
+		Sick:      &sick,
+	}
+	if student.SchoolClass != "" {
+		schoolClass := student.SchoolClass
+		data.SchoolClass = &schoolClass
+	}
+	if person, err := s.personRepo.FindByID(ctx, student.PersonID); err == nil && person != nil {
+		name := fmt.Sprintf("%s %s", person.FirstName, person.LastName)
+		data.StudentName = &name
+	}
+
+	topics := []string{realtime.TopicSchool}
+	if student.GroupID != nil {
+		topics = append(topics, realtime.EducationGroupTopic(*student.GroupID))
+	}
+
+	event := realtime.NewEvent(realtime.EventStudentSick, "", data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		s.getLogger().Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(realtime.EventStudentSick)),
+			slog.String("student_id", studentID),
+		)
+	}
+}
+
+// broadcastVisitCreated sends SSE event for visit creation to the session and its room
+func (s *service) broadcastVisitCreated(ctx context.Context, visit *active.Visit, roomID int64) {
 	if s.broadcaster == nil {
 		return
 	}
@@ -170,16 +210,8 @@ func (s *service) broadcastVisitCreated(ctx context.Context, visit *active.Visit
 		},
 	)
 
-	if err := s.broadcaster.BroadcastToGroup(activeGroupID, event); err != nil {
-		s.getLogger().Error("SSE broadcast failed",
-			slog.String("error", err.Error()),
-			slog.String("event_type", "student_checkin"),
-			slog.String("active_group_id", activeGroupID),
-			slog.String("student_id", studentID),
-		)
-	}
-
-	s.broadcastToEducationalGroup(studentRec, event)
+	topics := sessionTopics(visit.ActiveGroupID, roomID, s.sessionSupervisorIDs(ctx, visit.ActiveGroupID, false))
+	s.broadcastWithLogging(withStudentTopic(topics, studentRec), studentID, event, "student_checkin")
 }
 
 // getStudentDisplayData fetches student name for display
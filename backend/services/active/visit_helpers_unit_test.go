@@ -0,0 +1,83 @@
+package active
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sickStudentRepoMock struct {
+	userModels.StudentRepository
+	student *userModels.Student
+	updated int
+}
+
+func (m *sickStudentRepoMock) FindByID(_ context.Context, _ any) (*userModels.Student, error) {
+	return m.student, nil
+}
+func (m *sickStudentRepoMock) Update(_ context.Context, _ *userModels.Student) error {
+	m.updated++
+	return nil
+}
+
+type sickPersonRepoMock struct {
+	userModels.PersonRepository
+	person *userModels.Person
+}
+
+func (m *sickPersonRepoMock) FindByID(_ context.Context, _ any) (*userModels.Person, error) {
+	return m.person, nil
+}
+
+func TestBroadcastSicknessCleared(t *testing.T) {
+	groupID := int64(40)
+	sick := true
+	broadcaster := &busBroadcaster{}
+	students := &sickStudentRepoMock{
+		student: &userModels.Student{Model: base.Model{ID: 20}, PersonID: 30, GroupID: &groupID, SchoolClass: "3b", Sick: &sick},
+	}
+	svc := &service{
+		studentRepo: students,
+		personRepo:  &sickPersonRepoMock{person: &userModels.Person{FirstName: "Mia", LastName: "Krause"}},
+		broadcaster: broadcaster,
+	}
+
+	cleared := svc.autoClearStudentSickness(context.Background(), 20)
+	require.NotNil(t, cleared)
+	svc.broadcastSicknessCleared(context.Background(), cleared)
+
+	require.Len(t, broadcaster.events, 1)
+	event := broadcaster.events[0]
+	assert.Equal(t, realtime.EventStudentSick, event.Type)
+	assert.Equal(t, []string{realtime.TopicSchool, realtime.EducationGroupTopic(40)}, broadcaster.topics[0])
+	require.NotNil(t, event.Data.Sick)
+	assert.False(t, *event.Data.Sick)
+	assert.Equal(t, "20", *event.Data.StudentID)
+	assert.Equal(t, "Mia Krause", *event.Data.StudentName)
+	assert.Equal(t, "3b", *event.Data.SchoolClass)
+
+	// A student who is not sick is left alone and nothing is broadcast
+	assert.Nil(t, svc.autoClearStudentSickness(context.Background(), 20))
+	svc.broadcastSicknessCleared(context.Background(), nil)
+	assert.Len(t, broadcaster.events, 1)
+	assert.Equal(t, 1, students.updated)
+}
+
+func TestSessionTopics(t *testing.T) {
+	topics := sessionTopics(12, 30, []int64{50, 51, 50})
+	assert.Equal(t, []string{
+		realtime.ActiveGroupTopic(12),
+		realtime.TopicSchool,
+		realtime.RoomTopic(30),
+		realtime.StaffTopic(50),
+		realtime.StaffTopic(51),
+	}, topics)
+
+	// Without a known room the room topic is left out
+	assert.Equal(t, []string{realtime.ActiveGroupTopic(12), realtime.TopicSchool}, sessionTopics(12, 0, nil))
+}
@@ -242,6 +242,35 @@ func TestWSBuildExportRows_AbsencesOnly(t *testing.T) {
 	assert.Contains(t, rows[0].Row[7], "Flu")
 }
 
+func TestWSBuildExportRows_SkipsUnapprovedAbsences(t *testing.T) {
+	svc, _, _, _, _, _ := wsCreateTestServiceWithAbsenceRepo()
+
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	absences := []*activeModels.StaffAbsence{
+		{
+			Model:       base.Model{ID: 1},
+			StaffID:     100,
+			AbsenceType: activeModels.AbsenceTypeVacation,
+			DateStart:   date,
+			DateEnd:     date,
+			Status:      activeModels.AbsenceStatusReported,
+		},
+		{
+			Model:       base.Model{ID: 2},
+			StaffID:     100,
+			AbsenceType: activeModels.AbsenceTypeVacation,
+			DateStart:   date.AddDate(0, 0, 1),
+			DateEnd:     date.AddDate(0, 0, 1),
+			Status:      activeModels.AbsenceStatusDeclined,
+		},
+	}
+
+	rows := svc.buildExportRows(nil, absences)
+
+	assert.Empty(t, rows)
+}
+
 func TestWSBuildExportRows_Mixed(t *testing.T) {
 	svc, _, _, _, _, _ := wsCreateTestServiceWithAbsenceRepo()
 
@@ -346,7 +375,7 @@ func TestWSSessionToRow_Complete(t *testing.T) {
 
 	row := svc.sessionToRow(sr)
 
-	require.Len(t, row, 8)
+	require.Len(t, row, 9)
 	assert.Equal(t, "15.01.2024", row[0])  // Datum
 	assert.Equal(t, "Montag", row[1])      // Wochentag
 	assert.Equal(t, "08:30", row[2])       // Start
@@ -355,6 +384,7 @@ func TestWSSessionToRow_Complete(t *testing.T) {
 	assert.Equal(t, "8h 00min", row[5])    // Netto
 	assert.Equal(t, "In der OGS", row[6])  // Ort
 	assert.Equal(t, "Regular day", row[7]) // Bemerkungen
+	assert.Empty(t, row[8])                // Hinweise
 }
 
 func TestWSSessionToRow_NoCheckOut(t *testing.T) {
@@ -503,6 +533,7 @@ func TestWSExportCSV_Headers(t *testing.T) {
 	assert.Equal(t, "Netto (Std)", header[5])
 	assert.Equal(t, "Ort", header[6])
 	assert.Equal(t, "Bemerkungen", header[7])
+	assert.Equal(t, "Hinweise", header[8])
 }
 
 func TestWSExportCSV_UTF8BOM(t *testing.T) {
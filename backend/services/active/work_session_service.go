@@ -16,6 +16,7 @@ import (
 	"github.com/moto-nrw/project-phoenix/internal/timezone"
 	activeModels "github.com/moto-nrw/project-phoenix/models/active"
 	auditModels "github.com/moto-nrw/project-phoenix/models/audit"
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
 	"github.com/xuri/excelize/v2"
 )
 
@@ -50,6 +51,7 @@ type SessionResponse struct {
 	IsBreakCompliant bool                             `json:"is_break_compliant"`
 	Breaks           []*activeModels.WorkSessionBreak `json:"breaks"`
 	EditCount        int                              `json:"edit_count"`
+	Violations       []*ComplianceViolation           `json:"violations"`
 }
 
 // WorkSessionService defines operations for staff time tracking
@@ -68,6 +70,8 @@ type WorkSessionService interface {
 	EnsureCheckedIn(ctx context.Context, staffID int64) (*activeModels.WorkSession, error)
 	ExportSessions(ctx context.Context, staffID int64, from, to time.Time, format string) ([]byte, string, error)
 	AutoEndExpiredBreaks(ctx context.Context) (int, error)
+	GetSessionViolations(ctx context.Context, session *activeModels.WorkSession) ([]*ComplianceViolation, error)
+	GetComplianceReport(ctx context.Context, month time.Time) ([]*StaffComplianceReport, error)
 }
 
 // workSessionService implements WorkSessionService
@@ -77,9 +81,23 @@ type workSessionService struct {
 	auditRepo      auditModels.WorkSessionEditRepository
 	absenceRepo    activeModels.StaffAbsenceRepository
 	supervisorRepo activeModels.GroupSupervisorRepository
+	staffRepo      userModels.StaffRepository
+	rulesProvider  WorkTimeRulesProvider
 	logger         *slog.Logger
 }
 
+// WorkSessionServiceDependencies contains all dependencies required by the work session service
+type WorkSessionServiceDependencies struct {
+	Repo           activeModels.WorkSessionRepository
+	BreakRepo      activeModels.WorkSessionBreakRepository
+	AuditRepo      auditModels.WorkSessionEditRepository
+	AbsenceRepo    activeModels.StaffAbsenceRepository
+	SupervisorRepo activeModels.GroupSupervisorRepository
+	StaffRepo      userModels.StaffRepository // Staff names in the compliance report
+	RulesProvider  WorkTimeRulesProvider      // Working time rules; nil uses the ArbZG defaults
+	Logger         *slog.Logger
+}
+
 // getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
 func (s *workSessionService) getLogger() *slog.Logger {
 	if s.logger != nil {
@@ -89,8 +107,17 @@ func (s *workSessionService) getLogger() *slog.Logger {
 }
 
 // NewWorkSessionService creates a new work session service
-func NewWorkSessionService(repo activeModels.WorkSessionRepository, breakRepo activeModels.WorkSessionBreakRepository, auditRepo auditModels.WorkSessionEditRepository, absenceRepo activeModels.StaffAbsenceRepository, supervisorRepo activeModels.GroupSupervisorRepository, logger *slog.Logger) WorkSessionService {
-	return &workSessionService{repo: repo, breakRepo: breakRepo, auditRepo: auditRepo, absenceRepo: absenceRepo, supervisorRepo: supervisorRepo, logger: logger}
+func NewWorkSessionService(deps WorkSessionServiceDependencies) WorkSessionService {
+	return &workSessionService{
+		repo:           deps.Repo,
+		breakRepo:      deps.BreakRepo,
+		auditRepo:      deps.AuditRepo,
+		absenceRepo:    deps.AbsenceRepo,
+		supervisorRepo: deps.SupervisorRepo,
+		staffRepo:      deps.StaffRepo,
+		rulesProvider:  deps.RulesProvider,
+		logger:         deps.Logger,
+	}
 }
 
 // CheckIn creates a new work session for the staff member
@@ -608,6 +635,9 @@ func (s *workSessionService) GetHistory(ctx context.Context, staffID int64, from
 		return nil, fmt.Errorf("failed to get edit counts: %w", err)
 	}
 
+	now := time.Now()
+	violations := s.historyViolations(ctx, staffID, from, now, sessions)
+
 	// Wrap each session in SessionResponse with calculated fields and breaks
 	responses := make([]*SessionResponse, len(sessions))
 	for i, session := range sessions {
@@ -616,13 +646,15 @@ func (s *workSessionService) GetHistory(ctx context.Context, staffID int64, from
 			return nil, fmt.Errorf("failed to get breaks for session %d: %w", session.ID, err)
 		}
 
+		sessionViolations := violations[session.ID]
 		responses[i] = &SessionResponse{
 			WorkSession:      session,
-			NetMinutes:       session.NetMinutes(),
-			IsOvertime:       session.IsOvertime(),
-			IsBreakCompliant: session.IsBreakCompliant(),
+			NetMinutes:       session.NetMinutesAt(now),
+			IsOvertime:       hasViolation(sessionViolations, ViolationMaxDailyExceeded),
+			IsBreakCompliant: !hasViolation(sessionViolations, ViolationBreakTooShort),
 			Breaks:           breaks,
 			EditCount:        editCounts[session.ID],
+			Violations:       sessionViolations,
 		}
 	}
 
@@ -785,8 +817,11 @@ func (s *workSessionService) buildExportRows(sessions []*SessionResponse, absenc
 		})
 	}
 
-	// Add absence rows (one row per day in the absence range)
+	// Add absence rows (one row per day in the absence range); only approved absences count
 	for _, absence := range absences {
+		if absence.Status != activeModels.AbsenceStatusApproved {
+			continue
+		}
 		label := germanAbsenceTypeLabels[absence.AbsenceType]
 		if label == "" {
 			label = absence.AbsenceType
@@ -798,7 +833,7 @@ func (s *workSessionService) buildExportRows(sessions []*SessionResponse, absenc
 			wochentag := germanWeekdays[d.Weekday()]
 			rows = append(rows, exportRow{
 				Date: d,
-				Row:  []string{datum, wochentag, "--", "--", "--", "--", label, absence.Note},
+				Row:  []string{datum, wochentag, "--", "--", "--", "--", label, absence.Note, ""},
 			})
 			d = d.AddDate(0, 0, 1)
 		}
@@ -812,7 +847,28 @@ func (s *workSessionService) buildExportRows(sessions []*SessionResponse, absenc
 	return rows
 }
 
+// sessionExportHeaders are the column headers of the work session export
+var sessionExportHeaders = []string{"Datum", "Wochentag", "Start", "Ende", "Pause (Min)", "Netto (Std)", "Ort", "Bemerkungen", "Hinweise"}
+
 func (s *workSessionService) exportCSV(rows []exportRow) ([]byte, error) {
+	return writeExportCSV(sessionExportHeaders, exportRowValues(rows))
+}
+
+func (s *workSessionService) exportXLSX(rows []exportRow) ([]byte, error) {
+	return writeExportXLSX("Zeiterfassung", sessionExportHeaders, exportRowValues(rows))
+}
+
+// exportRowValues strips the sort dates from export rows
+func exportRowValues(rows []exportRow) [][]string {
+	values := make([][]string, len(rows))
+	for i, er := range rows {
+		values[i] = er.Row
+	}
+	return values
+}
+
+// writeExportCSV writes a semicolon-separated CSV with UTF-8 BOM, as expected by German Excel
+func writeExportCSV(headers []string, rows [][]string) ([]byte, error) {
 	var buf bytes.Buffer
 
 	// UTF-8 BOM for Excel compatibility
@@ -822,12 +878,12 @@ func (s *workSessionService) exportCSV(rows []exportRow) ([]byte, error) {
 	w.Comma = ';'
 
 	// Header
-	if err := w.Write([]string{"Datum", "Wochentag", "Start", "Ende", "Pause (Min)", "Netto (Std)", "Ort", "Bemerkungen"}); err != nil {
+	if err := w.Write(headers); err != nil {
 		return nil, fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
-	for _, er := range rows {
-		if err := w.Write(er.Row); err != nil {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
 			return nil, fmt.Errorf("failed to write CSV row: %w", err)
 		}
 	}
@@ -840,11 +896,11 @@ func (s *workSessionService) exportCSV(rows []exportRow) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func (s *workSessionService) exportXLSX(rows []exportRow) ([]byte, error) {
+// writeExportXLSX writes a single-sheet workbook with a highlighted header row
+func writeExportXLSX(sheet string, headers []string, rows [][]string) ([]byte, error) {
 	f := excelize.NewFile()
 	defer func() { _ = f.Close() }()
 
-	sheet := "Zeiterfassung"
 	idx, err := f.NewSheet(sheet)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sheet: %w", err)
@@ -855,8 +911,6 @@ func (s *workSessionService) exportXLSX(rows []exportRow) ([]byte, error) {
 		_ = f.DeleteSheet("Sheet1")
 	}
 
-	headers := []string{"Datum", "Wochentag", "Start", "Ende", "Pause (Min)", "Netto (Std)", "Ort", "Bemerkungen"}
-
 	// Header style
 	headerStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{Bold: true},
@@ -870,8 +924,8 @@ func (s *workSessionService) exportXLSX(rows []exportRow) ([]byte, error) {
 	}
 
 	// Data rows
-	for rowIdx, er := range rows {
-		for colIdx, val := range er.Row {
+	for rowIdx, row := range rows {
+		for colIdx, val := range row {
 			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+2)
 			_ = f.SetCellValue(sheet, cell, val)
 		}
@@ -917,7 +971,7 @@ func (s *workSessionService) sessionToRow(sr *SessionResponse) []string {
 		ort = "Homeoffice"
 	}
 
-	return []string{datum, wochentag, start, ende, pauseMin, netto, ort, sess.Notes}
+	return []string{datum, wochentag, start, ende, pauseMin, netto, ort, sess.Notes, violationMessages(sr.Violations)}
 }
 
 // AutoEndExpiredBreaks ends all breaks whose planned_end_time has passed
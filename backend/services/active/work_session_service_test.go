@@ -27,6 +27,7 @@ type wsMockWorkSessionRepository struct {
 	getByStaffAndDateFunc   func(ctx context.Context, staffID int64, date time.Time) (*activeModels.WorkSession, error)
 	getCurrentByStaffIDFunc func(ctx context.Context, staffID int64) (*activeModels.WorkSession, error)
 	getHistoryByStaffIDFunc func(ctx context.Context, staffID int64, from, to time.Time) ([]*activeModels.WorkSession, error)
+	getByDateRangeFunc      func(ctx context.Context, from, to time.Time) ([]*activeModels.WorkSession, error)
 	getOpenSessionsFunc     func(ctx context.Context, beforeDate time.Time) ([]*activeModels.WorkSession, error)
 	getTodayPresenceMapFunc func(ctx context.Context) (map[int64]string, error)
 	closeSessionFunc        func(ctx context.Context, id int64, checkOutTime time.Time, autoCheckedOut bool) error
@@ -89,6 +90,13 @@ func (m *wsMockWorkSessionRepository) GetHistoryByStaffID(ctx context.Context, s
 	return nil, nil
 }
 
+func (m *wsMockWorkSessionRepository) GetByDateRange(ctx context.Context, from, to time.Time) ([]*activeModels.WorkSession, error) {
+	if m.getByDateRangeFunc != nil {
+		return m.getByDateRangeFunc(ctx, from, to)
+	}
+	return nil, nil
+}
+
 func (m *wsMockWorkSessionRepository) GetOpenSessions(ctx context.Context, beforeDate time.Time) ([]*activeModels.WorkSession, error) {
 	if m.getOpenSessionsFunc != nil {
 		return m.getOpenSessionsFunc(ctx, beforeDate)
@@ -257,6 +265,7 @@ type wsMockStaffAbsenceRepository struct {
 	getByStaffAndDateFunc      func(ctx context.Context, staffID int64, date time.Time) (*activeModels.StaffAbsence, error)
 	getByDateRangeFunc         func(ctx context.Context, from, to time.Time) ([]*activeModels.StaffAbsence, error)
 	getTodayAbsenceMapFunc     func(ctx context.Context) (map[int64]string, error)
+	getByStatusFunc            func(ctx context.Context, status string) ([]*activeModels.StaffAbsence, error)
 }
 
 func (m *wsMockStaffAbsenceRepository) Create(ctx context.Context, entity *activeModels.StaffAbsence) error {
@@ -322,6 +331,17 @@ func (m *wsMockStaffAbsenceRepository) GetTodayAbsenceMap(ctx context.Context) (
 	return nil, nil
 }
 
+func (m *wsMockStaffAbsenceRepository) GetByStatus(ctx context.Context, status string) ([]*activeModels.StaffAbsence, error) {
+	if m.getByStatusFunc != nil {
+		return m.getByStatusFunc(ctx, status)
+	}
+	return nil, nil
+}
+
+func (m *wsMockStaffAbsenceRepository) UpdateReview(_ context.Context, _ *activeModels.StaffAbsence) (bool, error) {
+	return true, nil
+}
+
 // ============================================================================
 // Mock for GroupSupervisorRepository (prefixed with ws)
 // ============================================================================
@@ -0,0 +1,219 @@
+package active
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	configModels "github.com/moto-nrw/project-phoenix/models/config"
+)
+
+// Compliance rule identifiers
+const (
+	ViolationBreakTooShort    = "break_too_short"    // §4 ArbZG
+	ViolationMaxDailyExceeded = "max_daily_exceeded" // §3 ArbZG
+	ViolationRestTooShort     = "rest_too_short"     // §5 ArbZG
+)
+
+// ComplianceViolation is a breach of a working time rule by a single work session
+type ComplianceViolation struct {
+	Rule            string    `json:"rule"`
+	SessionID       int64     `json:"session_id"`
+	Date            time.Time `json:"date"`
+	ActualMinutes   int       `json:"actual_minutes"`
+	RequiredMinutes int       `json:"required_minutes"` // Minimum for breaks and rest, maximum for daily working time
+	Message         string    `json:"message"`          // German description for UI and export
+}
+
+// StaffComplianceReport lists one staff member's violations in a month
+type StaffComplianceReport struct {
+	StaffID    int64                  `json:"staff_id"`
+	StaffName  string                 `json:"staff_name,omitempty"`
+	Violations []*ComplianceViolation `json:"violations"`
+}
+
+// WorkTimeRulesProvider supplies the configured working time rules (implemented by the config service)
+type WorkTimeRulesProvider interface {
+	GetWorkTimeRules(ctx context.Context) (*configModels.WorkTimeRules, error)
+}
+
+// workTimeRules returns the configured rules, falling back to the statutory defaults
+func (s *workSessionService) workTimeRules(ctx context.Context) *configModels.WorkTimeRules {
+	if s.rulesProvider == nil {
+		return configModels.NewDefaultWorkTimeRules()
+	}
+	rules, err := s.rulesProvider.GetWorkTimeRules(ctx)
+	if err != nil || rules == nil {
+		s.getLogger().WarnContext(ctx, "failed to load work time rules, using defaults",
+			slog.Any("error", err))
+		return configModels.NewDefaultWorkTimeRules()
+	}
+	return rules
+}
+
+// previousSession returns the staff member's session on the day before the given date, or nil
+func (s *workSessionService) previousSession(ctx context.Context, staffID int64, date time.Time) *activeModels.WorkSession {
+	previous, err := s.repo.GetByStaffAndDate(ctx, staffID, date.AddDate(0, 0, -1))
+	if err != nil {
+		return nil
+	}
+	return previous
+}
+
+// historyViolations checks a staff member's sessions as of now, using the session of the day
+// before from as the previous session of the first day
+func (s *workSessionService) historyViolations(ctx context.Context, staffID int64, from, now time.Time, sessions []*activeModels.WorkSession) map[int64][]*ComplianceViolation {
+	if len(sessions) == 0 {
+		return nil
+	}
+	checked := sessions
+	if previous := s.previousSession(ctx, staffID, from); previous != nil {
+		checked = append([]*activeModels.WorkSession{previous}, sessions...)
+	}
+	return checkSessionsCompliance(s.workTimeRules(ctx), checked, now)
+}
+
+// GetSessionViolations checks a single session against the working time rules,
+// including the rest period since the previous day's session
+func (s *workSessionService) GetSessionViolations(ctx context.Context, session *activeModels.WorkSession) ([]*ComplianceViolation, error) {
+	if session == nil {
+		return nil, fmt.Errorf("session not found")
+	}
+	rules := s.workTimeRules(ctx)
+	return checkSessionCompliance(rules, session, s.previousSession(ctx, session.StaffID, session.Date), time.Now()), nil
+}
+
+// GetComplianceReport lists the working time rule violations of all staff in the month
+// containing the given date, sorted by staff name. Staff without violations are omitted.
+func (s *workSessionService) GetComplianceReport(ctx context.Context, month time.Time) ([]*StaffComplianceReport, error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	// Include the previous day so the rest period of the first day can be checked
+	sessions, err := s.repo.GetByDateRange(ctx, from.AddDate(0, 0, -1), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	violations := checkSessionsCompliance(s.workTimeRules(ctx), sessions, time.Now())
+
+	byStaff := make(map[int64]*StaffComplianceReport)
+	for _, ws := range sessions {
+		if ws.Date.Before(from) || len(violations[ws.ID]) == 0 {
+			continue
+		}
+		entry, ok := byStaff[ws.StaffID]
+		if !ok {
+			entry = &StaffComplianceReport{StaffID: ws.StaffID}
+			byStaff[ws.StaffID] = entry
+		}
+		entry.Violations = append(entry.Violations, violations[ws.ID]...)
+	}
+
+	names := staffNamesByID(ctx, s.staffRepo)
+
+	report := make([]*StaffComplianceReport, 0, len(byStaff))
+	for _, entry := range byStaff {
+		entry.StaffName = names[entry.StaffID]
+		report = append(report, entry)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].StaffName != report[j].StaffName {
+			return report[i].StaffName < report[j].StaffName
+		}
+		return report[i].StaffID < report[j].StaffID
+	})
+
+	return report, nil
+}
+
+// checkSessionsCompliance checks sessions of one or more staff members and returns the
+// violations keyed by session ID. Each session's rest period is checked against the
+// same staff member's session on the previous day, if it is part of the input.
+func checkSessionsCompliance(rules *configModels.WorkTimeRules, sessions []*activeModels.WorkSession, now time.Time) map[int64][]*ComplianceViolation {
+	sorted := make([]*activeModels.WorkSession, len(sessions))
+	copy(sorted, sessions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].StaffID != sorted[j].StaffID {
+			return sorted[i].StaffID < sorted[j].StaffID
+		}
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	result := make(map[int64][]*ComplianceViolation)
+	for i, ws := range sorted {
+		var previous *activeModels.WorkSession
+		if i > 0 && sorted[i-1].StaffID == ws.StaffID && sorted[i-1].Date.Equal(ws.Date.AddDate(0, 0, -1)) {
+			previous = sorted[i-1]
+		}
+		if v := checkSessionCompliance(rules, ws, previous, now); len(v) > 0 {
+			result[ws.ID] = v
+		}
+	}
+	return result
+}
+
+// checkSessionCompliance evaluates the ArbZG rules for one session.
+// Open sessions are checked against the time worked until now, so a running session
+// is flagged as soon as it needs a longer break or exceeds the daily maximum.
+// The previous session may be nil.
+func checkSessionCompliance(rules *configModels.WorkTimeRules, session, previous *activeModels.WorkSession, now time.Time) []*ComplianceViolation {
+	var violations []*ComplianceViolation
+
+	newViolation := func(rule string, actual, required int, message string) *ComplianceViolation {
+		return &ComplianceViolation{
+			Rule:            rule,
+			SessionID:       session.ID,
+			Date:            session.Date,
+			ActualMinutes:   actual,
+			RequiredMinutes: required,
+			Message:         message,
+		}
+	}
+
+	if previous != nil && previous.CheckOutTime != nil && previous.ID != session.ID {
+		rest := int(session.CheckInTime.Sub(*previous.CheckOutTime).Minutes())
+		if rest >= 0 && rest < rules.MinRestMinutes {
+			violations = append(violations, newViolation(ViolationRestTooShort, rest, rules.MinRestMinutes,
+				fmt.Sprintf("Ruhezeit zu kurz: %s statt mindestens %s (§5 ArbZG)",
+					formatMinutesGerman(rest), formatMinutesGerman(rules.MinRestMinutes))))
+		}
+	}
+
+	net := session.NetMinutesAt(now)
+	if required := rules.RequiredBreakMinutes(net); session.BreakMinutes < required {
+		violations = append(violations, newViolation(ViolationBreakTooShort, session.BreakMinutes, required,
+			fmt.Sprintf("Pause zu kurz: %d Min. statt mindestens %d Min. (§4 ArbZG)", session.BreakMinutes, required)))
+	}
+
+	if net > rules.MaxDailyMinutes {
+		violations = append(violations, newViolation(ViolationMaxDailyExceeded, net, rules.MaxDailyMinutes,
+			fmt.Sprintf("Höchstarbeitszeit überschritten: %s statt höchstens %s (§3 ArbZG)",
+				formatMinutesGerman(net), formatMinutesGerman(rules.MaxDailyMinutes))))
+	}
+
+	return violations
+}
+
+// violationMessages joins the German violation messages for the export
+func violationMessages(violations []*ComplianceViolation) string {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// hasViolation reports whether any of the violations breaks the given rule
+func hasViolation(violations []*ComplianceViolation, rule string) bool {
+	for _, v := range violations {
+		if v.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package active
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	activeModels "github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	configModels "github.com/moto-nrw/project-phoenix/models/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wtcSession builds a session on the given day of January 2026 from check-in/out hours and minutes
+func wtcSession(id, staffID int64, day, inHour, inMin, outHour, outMin, breakMinutes int) *activeModels.WorkSession {
+	checkIn := time.Date(2026, 1, day, inHour, inMin, 0, 0, time.UTC)
+	checkOut := time.Date(2026, 1, day, outHour, outMin, 0, 0, time.UTC)
+	return &activeModels.WorkSession{
+		Model:        base.Model{ID: id},
+		StaffID:      staffID,
+		Date:         time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC),
+		CheckInTime:  checkIn,
+		CheckOutTime: &checkOut,
+		BreakMinutes: breakMinutes,
+		Status:       activeModels.WorkSessionStatusPresent,
+	}
+}
+
+// wtcNow is the evaluation time for open sessions; it lies after all closed test sessions
+var wtcNow = time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+
+type wtcRulesProvider struct {
+	rules *configModels.WorkTimeRules
+	err   error
+}
+
+func (p *wtcRulesProvider) GetWorkTimeRules(_ context.Context) (*configModels.WorkTimeRules, error) {
+	return p.rules, p.err
+}
+
+func TestCheckSessionCompliance_Compliant(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+
+	// 8h net with 30 min break
+	assert.Empty(t, checkSessionCompliance(rules, wtcSession(10, 100, 13, 8, 0, 16, 30, 30), nil, wtcNow))
+	// Exactly 6h net needs no break
+	assert.Empty(t, checkSessionCompliance(rules, wtcSession(10, 100, 13, 8, 0, 14, 0, 0), nil, wtcNow))
+}
+
+func TestCheckSessionCompliance_BreakTooShort(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+
+	// 6h45 net with 15 min break: 30 min required
+	violations := checkSessionCompliance(rules, wtcSession(10, 100, 13, 8, 0, 15, 0, 15), nil, wtcNow)
+	require.Len(t, violations, 1)
+	assert.Equal(t, ViolationBreakTooShort, violations[0].Rule)
+	assert.Equal(t, 15, violations[0].ActualMinutes)
+	assert.Equal(t, 30, violations[0].RequiredMinutes)
+	assert.Equal(t, int64(10), violations[0].SessionID)
+	assert.Contains(t, violations[0].Message, "§4 ArbZG")
+
+	// 9h15 net with 30 min break: 45 min required
+	violations = checkSessionCompliance(rules, wtcSession(10, 100, 13, 7, 0, 16, 45, 30), nil, wtcNow)
+	require.Len(t, violations, 1)
+	assert.Equal(t, 45, violations[0].RequiredMinutes)
+}
+
+func TestCheckSessionCompliance_MaxDailyExceeded(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+
+	// 10h30 net with 45 min break
+	violations := checkSessionCompliance(rules, wtcSession(10, 100, 13, 6, 0, 17, 15, 45), nil, wtcNow)
+	require.Len(t, violations, 1)
+	assert.Equal(t, ViolationMaxDailyExceeded, violations[0].Rule)
+	assert.Equal(t, 630, violations[0].ActualMinutes)
+	assert.Equal(t, 600, violations[0].RequiredMinutes)
+	assert.Contains(t, violations[0].Message, "§3 ArbZG")
+}
+
+func TestCheckSessionCompliance_RestTooShort(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+	previous := wtcSession(10, 100, 12, 12, 0, 22, 0, 45)
+	session := wtcSession(11, 100, 13, 7, 0, 12, 0, 0)
+
+	violations := checkSessionCompliance(rules, session, previous, wtcNow)
+	require.Len(t, violations, 1)
+	assert.Equal(t, ViolationRestTooShort, violations[0].Rule)
+	assert.Equal(t, 540, violations[0].ActualMinutes)
+	assert.Equal(t, 660, violations[0].RequiredMinutes)
+	assert.Contains(t, violations[0].Message, "§5 ArbZG")
+
+	// Open previous session is ignored
+	previous.CheckOutTime = nil
+	assert.Empty(t, checkSessionCompliance(rules, session, previous, wtcNow))
+}
+
+func TestCheckSessionCompliance_ActiveSessionCheckedAgainstNow(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+	previous := wtcSession(10, 100, 12, 12, 0, 22, 0, 45)
+	session := wtcSession(11, 100, 13, 5, 0, 0, 0, 0)
+	session.CheckOutTime = nil
+
+	// 5h worked so far: only the rest period is violated
+	violations := checkSessionCompliance(rules, session, previous, time.Date(2026, 1, 13, 10, 0, 0, 0, time.UTC))
+	require.Len(t, violations, 1)
+	assert.Equal(t, ViolationRestTooShort, violations[0].Rule)
+
+	// 6h30 worked without a break: the running session already lacks the required break
+	violations = checkSessionCompliance(rules, session, previous, time.Date(2026, 1, 13, 11, 30, 0, 0, time.UTC))
+	require.Len(t, violations, 2)
+	assert.Equal(t, ViolationBreakTooShort, violations[1].Rule)
+	assert.Equal(t, 30, violations[1].RequiredMinutes)
+}
+
+func TestCheckSessionCompliance_CustomRules(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+	rules.MaxDailyMinutes = 480
+
+	violations := checkSessionCompliance(rules, wtcSession(10, 100, 13, 8, 0, 17, 0, 30), nil, wtcNow)
+	require.Len(t, violations, 1)
+	assert.Equal(t, ViolationMaxDailyExceeded, violations[0].Rule)
+}
+
+func TestCheckSessionsCompliance_RestAcrossStaff(t *testing.T) {
+	rules := configModels.NewDefaultWorkTimeRules()
+	sessions := []*activeModels.WorkSession{
+		wtcSession(12, 100, 13, 6, 0, 12, 0, 0),
+		wtcSession(10, 100, 12, 12, 0, 22, 0, 45),
+		// Different staff member: no rest violation against staff 100
+		wtcSession(11, 200, 13, 6, 0, 12, 0, 0),
+		// Gap of one day: previous session is not the day before
+		wtcSession(13, 100, 15, 6, 0, 12, 0, 0),
+	}
+
+	result := checkSessionsCompliance(rules, sessions, wtcNow)
+	require.Len(t, result, 1)
+	require.Len(t, result[12], 1)
+	assert.Equal(t, ViolationRestTooShort, result[12][0].Rule)
+}
+
+func TestWorkTimeRules_ProviderFallback(t *testing.T) {
+	svc := &workSessionService{}
+	assert.Equal(t, configModels.NewDefaultWorkTimeRules(), svc.workTimeRules(context.Background()))
+
+	svc.rulesProvider = &wtcRulesProvider{err: errors.New("db down")}
+	assert.Equal(t, configModels.NewDefaultWorkTimeRules(), svc.workTimeRules(context.Background()))
+
+	custom := configModels.NewDefaultWorkTimeRules()
+	custom.MinRestMinutes = 600
+	svc.rulesProvider = &wtcRulesProvider{rules: custom}
+	assert.Equal(t, 600, svc.workTimeRules(context.Background()).MinRestMinutes)
+}
+
+func TestGetComplianceReport(t *testing.T) {
+	svc, sessionRepo, _, _, _, _ := wsCreateTestServiceWithAbsenceRepo()
+	sessionRepo.getByDateRangeFunc = func(_ context.Context, from, to time.Time) ([]*activeModels.WorkSession, error) {
+		assert.Equal(t, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), to)
+		// 31 Dec: 9h45 net with 15 min break
+		previousMonth := wtcSession(10, 100, 1, 12, 0, 22, 0, 15)
+		previousMonth.Date = previousMonth.Date.AddDate(0, 0, -1)
+		previousMonth.CheckInTime = previousMonth.CheckInTime.AddDate(0, 0, -1)
+		checkOut := previousMonth.CheckOutTime.AddDate(0, 0, -1)
+		previousMonth.CheckOutTime = &checkOut
+		return []*activeModels.WorkSession{
+			previousMonth,
+			wtcSession(11, 100, 1, 6, 0, 12, 0, 0),
+			wtcSession(12, 200, 14, 8, 0, 16, 0, 30),
+		}, nil
+	}
+
+	report, err := svc.GetComplianceReport(context.Background(), time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, int64(100), report[0].StaffID)
+	require.Len(t, report[0].Violations, 1) // previous month's break violation is not reported
+	assert.Equal(t, ViolationRestTooShort, report[0].Violations[0].Rule)
+}
+
+func TestGetComplianceReport_RepoError(t *testing.T) {
+	svc, sessionRepo, _, _, _, _ := wsCreateTestServiceWithAbsenceRepo()
+	sessionRepo.getByDateRangeFunc = func(_ context.Context, _, _ time.Time) ([]*activeModels.WorkSession, error) {
+		return nil, errors.New("db error")
+	}
+
+	report, err := svc.GetComplianceReport(context.Background(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestGetSessionViolations_UsesPreviousDay(t *testing.T) {
+	svc, sessionRepo, _, _, _, _ := wsCreateTestServiceWithAbsenceRepo()
+	sessionRepo.getByStaffAndDateFunc = func(_ context.Context, staffID int64, date time.Time) (*activeModels.WorkSession, error) {
+		assert.Equal(t, int64(100), staffID)
+		assert.Equal(t, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), date)
+		return wtcSession(10, 100, 12, 12, 0, 23, 0, 45), nil
+	}
+
+	violations, err := svc.GetSessionViolations(context.Background(), wtcSession(11, 100, 13, 8, 0, 12, 0, 0))
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, ViolationRestTooShort, violations[0].Rule)
+
+	_, err = svc.GetSessionViolations(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestViolationMessages(t *testing.T) {
+	assert.Empty(t, violationMessages(nil))
+	assert.Equal(t, "a; b", violationMessages([]*ComplianceViolation{{Message: "a"}, {Message: "b"}}))
+}
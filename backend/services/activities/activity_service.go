@@ -9,6 +9,7 @@ import (
 	activeModels "github.com/moto-nrw/project-phoenix/models/active"
 	"github.com/moto-nrw/project-phoenix/models/activities"
 	"github.com/moto-nrw/project-phoenix/models/base"
+	scheduleModels "github.com/moto-nrw/project-phoenix/models/schedule"
 	"github.com/uptrace/bun"
 )
 
@@ -20,6 +21,7 @@ type Service struct {
 	supervisorRepo  activities.SupervisorPlannedRepository
 	enrollmentRepo  activities.StudentEnrollmentRepository
 	activeGroupRepo activeModels.GroupRepository
+	dateframeRepo   scheduleModels.DateframeRepository
 	db              *bun.DB
 	txHandler       *base.TxHandler
 }
@@ -32,6 +34,7 @@ func NewService(
 	supervisorRepo activities.SupervisorPlannedRepository,
 	enrollmentRepo activities.StudentEnrollmentRepository,
 	activeGroupRepo activeModels.GroupRepository,
+	dateframeRepo scheduleModels.DateframeRepository,
 	db *bun.DB,
 ) (*Service, error) {
 	return &Service{
@@ -41,6 +44,7 @@ func NewService(
 		supervisorRepo:  supervisorRepo,
 		enrollmentRepo:  enrollmentRepo,
 		activeGroupRepo: activeGroupRepo,
+		dateframeRepo:   dateframeRepo,
 		db:              db,
 		txHandler:       base.NewTxHandler(db),
 	}, nil
@@ -99,6 +103,7 @@ func (s *Service) WithTx(tx bun.Tx) interface{} {
 		supervisorRepo:  supervisorRepo,
 		enrollmentRepo:  enrollmentRepo,
 		activeGroupRepo: s.activeGroupRepo,
+		dateframeRepo:   s.dateframeRepo,
 		db:              s.db,
 		txHandler:       s.txHandler.WithTx(tx),
 	}
@@ -312,7 +317,20 @@ func (s *Service) UpdateGroup(ctx context.Context, group *activities.Group, requ
 		return nil, &ActivityError{Op: "update group", Err: ErrNotOwner}
 	}
 
-	if err := s.groupRepo.Update(ctx, group); err != nil {
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*Service)
+
+		if _, err := txService.lockGroup(ctx, group.ID); err != nil {
+			return err
+		}
+		if err := txService.groupRepo.Update(ctx, group); err != nil {
+			return err
+		}
+
+		// A raised MaxParticipants hands the new places to the waitlist
+		return txService.rebalanceWaitlist(ctx, group.ID)
+	})
+	if err != nil {
 		return nil, &ActivityError{Op: "update group", Err: err}
 	}
 
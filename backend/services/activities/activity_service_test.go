@@ -462,7 +462,7 @@ func TestActivityService_EnrollStudent(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, group.ID, student.ID)
 
 		// ACT
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 
 		// ASSERT
 		require.NoError(t, err)
@@ -479,7 +479,7 @@ func TestActivityService_EnrollStudent(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, student.ID)
 
 		// ACT
-		err := service.EnrollStudent(ctx, 99999999, student.ID)
+		_, err := service.EnrollStudent(ctx, 99999999, student.ID)
 
 		// ASSERT
 		require.Error(t, err)
@@ -500,7 +500,7 @@ func TestActivityService_UnenrollStudent(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, group.ID, student.ID)
 
 		// First enroll the student
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// ACT
@@ -524,7 +524,7 @@ func TestActivityService_GetEnrolledStudents(t *testing.T) {
 		student := testpkg.CreateTestStudent(t, db, "Enrolled", "Student", "1a")
 		defer testpkg.CleanupActivityFixtures(t, db, group.ID, student.ID)
 
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// ACT
@@ -563,7 +563,7 @@ func TestActivityService_GetStudentEnrollments(t *testing.T) {
 		student := testpkg.CreateTestStudent(t, db, "GetEnroll", "Student", "1a")
 		defer testpkg.CleanupActivityFixtures(t, db, group.ID, student.ID)
 
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// ACT
@@ -1242,7 +1242,7 @@ func TestActivityService_UpdateGroupEnrollments(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, group.ID, student.ID)
 
 		// First enroll
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// ACT - clear all enrollments
@@ -1453,17 +1453,75 @@ func TestActivityService_EnrollStudent_Duplicate(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, group.ID, student.ID)
 
 		// Enroll first time
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// ACT - try to enroll same student again
-		err = service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err = service.EnrollStudent(ctx, group.ID, student.ID)
 
 		// ASSERT - should fail with duplicate error
 		require.Error(t, err)
 	})
 }
 
+func TestActivityService_EnrollStudent_WaitlistAndPromotion(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	service := setupActivityService(t, db)
+	ctx := context.Background()
+
+	t.Run("waitlists beyond capacity and promotes on unenroll", func(t *testing.T) {
+		// ARRANGE - group with a single place
+		group := testpkg.CreateTestActivityGroup(t, db, "waitlist")
+		first := testpkg.CreateTestStudent(t, db, "Wait", "First", "1a")
+		second := testpkg.CreateTestStudent(t, db, "Wait", "Second", "1a")
+		third := testpkg.CreateTestStudent(t, db, "Wait", "Third", "1a")
+		defer testpkg.CleanupActivityFixtures(t, db, first.ID, second.ID, third.ID)
+		defer func() { cleanupGroup(service, ctx, group.ID) }()
+
+		_, err := db.NewUpdate().
+			Table("activities.groups").
+			Set("max_participants = 1").
+			Where("id = ?", group.ID).
+			Exec(ctx)
+		require.NoError(t, err)
+
+		// ACT
+		enrollment, err := service.EnrollStudent(ctx, group.ID, first.ID)
+		require.NoError(t, err)
+		assert.False(t, enrollment.IsWaitlisted())
+
+		enrollment, err = service.EnrollStudent(ctx, group.ID, second.ID)
+		require.NoError(t, err)
+		require.True(t, enrollment.IsWaitlisted())
+		assert.Equal(t, 1, *enrollment.WaitlistPosition)
+
+		enrollment, err = service.EnrollStudent(ctx, group.ID, third.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, *enrollment.WaitlistPosition)
+
+		enrolled, err := service.GetEnrolledStudents(ctx, group.ID)
+		require.NoError(t, err)
+		assert.Len(t, enrolled, 1)
+
+		require.NoError(t, service.UnenrollStudent(ctx, group.ID, first.ID))
+
+		// ASSERT - second holds the place, third moved up
+		secondEnrollments, err := service.GetStudentEnrollments(ctx, second.ID)
+		require.NoError(t, err)
+		require.Len(t, secondEnrollments, 1)
+		assert.Equal(t, activitiesModels.EnrollmentStatusEnrolled, secondEnrollments[0].Status)
+		assert.Equal(t, group.ID, secondEnrollments[0].ActivityGroup.ID)
+
+		thirdEnrollments, err := service.GetStudentEnrollments(ctx, third.ID)
+		require.NoError(t, err)
+		require.Len(t, thirdEnrollments, 1)
+		require.True(t, thirdEnrollments[0].IsWaitlisted())
+		assert.Equal(t, 1, *thirdEnrollments[0].WaitlistPosition)
+	})
+}
+
 func TestActivityService_UnenrollStudent_NotEnrolled(t *testing.T) {
 	db := testpkg.SetupTestDB(t)
 	defer func() { _ = db.Close() }()
@@ -1520,7 +1578,7 @@ func TestActivityService_DeleteGroup_WithEnrollments(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, student.ID) // group will be deleted
 
 		// Enroll student
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// ACT - delete group (using creator's staff ID with manage permission for test)
@@ -1553,7 +1611,7 @@ func TestActivityService_UpdateAttendanceStatus(t *testing.T) {
 		defer func() { cleanupGroup(service, ctx, group.ID) }()
 
 		// Enroll student
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		// Get the enrollment to get its ID using GetEnrollmentsByDate
@@ -1936,7 +1994,7 @@ func TestActivityService_GetEnrollmentHistory_NoData(t *testing.T) {
 		defer func() { cleanupGroup(service, ctx, group.ID) }()
 
 		// Enroll student
-		err := service.EnrollStudent(ctx, group.ID, student.ID)
+		_, err := service.EnrollStudent(ctx, group.ID, student.ID)
 		require.NoError(t, err)
 
 		startDate := time.Now().AddDate(0, 0, -1)
@@ -2260,7 +2318,7 @@ func TestActivityService_EnrollStudent_GroupNotFound(t *testing.T) {
 		defer testpkg.CleanupActivityFixtures(t, db, student.ID)
 
 		// ACT
-		err := service.EnrollStudent(ctx, 99999999, student.ID)
+		_, err := service.EnrollStudent(ctx, 99999999, student.ID)
 
 		// ASSERT
 		require.Error(t, err)
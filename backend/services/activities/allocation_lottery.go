@@ -0,0 +1,150 @@
+package activities
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/moto-nrw/project-phoenix/models/activities"
+)
+
+// missedRoundPenalty is added to a student's penalty for a round without a place; it is
+// worse than the lowest-ranked wish so unlucky students go first in the next round
+const missedRoundPenalty = activities.MaxAllocationPreferences + 1
+
+// lotteryInput holds everything the allocation algorithm needs; it performs no I/O
+type lotteryInput struct {
+	Seed         int64
+	Rounds       int                                          // Places a student can receive at most
+	Preferences  map[int64][]*activities.AllocationPreference // Student ID -> wishes ordered by rank
+	FreePlaces   map[int64]int                                // Activity group ID -> places left
+	Weekdays     map[int64][]int                              // Activity group ID -> scheduled weekdays
+	BusyWeekdays map[int64]map[int]bool                       // Student ID -> weekdays taken by existing enrollments
+	Enrolled     map[int64]map[int64]bool                     // Student ID -> activity groups the student is already in
+}
+
+// lotteryResult is the outcome of one allocation run
+type lotteryResult struct {
+	Assignments []*activities.AllocationAssignment
+	Unplaced    []int64 // Students without any place, ascending
+}
+
+// runLottery assigns places round by round. Each round every student receives at most one
+// place: their best-ranked wish that still has room, is not already theirs and does not share
+// a weekday with an AG they already attend. Round one follows a lottery order drawn from the
+// seed; later rounds let students with fewer and worse-ranked places pick first, ties broken
+// by the lottery order, reversed every other round. The same input and seed always yield the
+// same result.
+func runLottery(in lotteryInput) *lotteryResult {
+	students := make([]int64, 0, len(in.Preferences))
+	for studentID := range in.Preferences {
+		students = append(students, studentID)
+	}
+	sort.Slice(students, func(i, j int) bool { return students[i] < students[j] })
+
+	rng := rand.New(rand.NewSource(in.Seed))
+	rng.Shuffle(len(students), func(i, j int) {
+		students[i], students[j] = students[j], students[i]
+	})
+	lotteryPosition := make(map[int64]int, len(students))
+	for i, studentID := range students {
+		lotteryPosition[studentID] = i
+	}
+
+	freePlaces := make(map[int64]int, len(in.FreePlaces))
+	for groupID, places := range in.FreePlaces {
+		freePlaces[groupID] = places
+	}
+	busy := make(map[int64]map[int]bool, len(students))
+	taken := make(map[int64]map[int64]bool, len(students))
+	for _, studentID := range students {
+		busy[studentID] = make(map[int]bool)
+		for weekday := range in.BusyWeekdays[studentID] {
+			busy[studentID][weekday] = true
+		}
+		taken[studentID] = make(map[int64]bool)
+		for groupID := range in.Enrolled[studentID] {
+			taken[studentID][groupID] = true
+		}
+	}
+
+	result := &lotteryResult{Assignments: []*activities.AllocationAssignment{}, Unplaced: []int64{}}
+	placed := make(map[int64]int, len(students))
+	penalty := make(map[int64]int, len(students))
+
+	for round := 1; round <= in.Rounds; round++ {
+		order := append([]int64(nil), students...)
+		reversed := round%2 == 0
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := order[i], order[j]
+			if penalty[a] != penalty[b] {
+				return penalty[a] > penalty[b]
+			}
+			if reversed {
+				return lotteryPosition[a] > lotteryPosition[b]
+			}
+			return lotteryPosition[a] < lotteryPosition[b]
+		})
+
+		for _, studentID := range order {
+			wish := pickWish(in.Preferences[studentID], freePlaces, in.Weekdays, busy[studentID], taken[studentID])
+			if wish == nil {
+				penalty[studentID] += missedRoundPenalty
+				continue
+			}
+
+			freePlaces[wish.ActivityGroupID]--
+			taken[studentID][wish.ActivityGroupID] = true
+			for _, weekday := range in.Weekdays[wish.ActivityGroupID] {
+				busy[studentID][weekday] = true
+			}
+			penalty[studentID] += wish.Rank
+			placed[studentID]++
+
+			result.Assignments = append(result.Assignments, &activities.AllocationAssignment{
+				StudentID:       studentID,
+				ActivityGroupID: wish.ActivityGroupID,
+				Rank:            wish.Rank,
+				Round:           round,
+			})
+		}
+	}
+
+	for studentID := range in.Preferences {
+		if placed[studentID] == 0 {
+			result.Unplaced = append(result.Unplaced, studentID)
+		}
+	}
+	sort.Slice(result.Unplaced, func(i, j int) bool { return result.Unplaced[i] < result.Unplaced[j] })
+
+	return result
+}
+
+// pickWish returns the best-ranked wish that can still be granted, or nil
+func pickWish(
+	wishes []*activities.AllocationPreference,
+	freePlaces map[int64]int,
+	weekdays map[int64][]int,
+	busy map[int]bool,
+	taken map[int64]bool,
+) *activities.AllocationPreference {
+	for _, wish := range wishes {
+		if taken[wish.ActivityGroupID] || freePlaces[wish.ActivityGroupID] <= 0 {
+			continue
+		}
+		if clashes(weekdays[wish.ActivityGroupID], busy) {
+			continue
+		}
+		return wish
+	}
+	return nil
+}
+
+// clashes reports whether any of the weekdays is already taken
+func clashes(weekdays []int, busy map[int]bool) bool {
+	for _, weekday := range weekdays {
+		if busy[weekday] {
+			return true
+		}
+	}
+	return false
+}
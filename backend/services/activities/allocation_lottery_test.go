@@ -0,0 +1,134 @@
+package activities
+
+import (
+	"testing"
+
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wishes builds ranked preferences from activity group IDs
+func wishes(groupIDs ...int64) []*activities.AllocationPreference {
+	prefs := make([]*activities.AllocationPreference, 0, len(groupIDs))
+	for i, groupID := range groupIDs {
+		prefs = append(prefs, &activities.AllocationPreference{ActivityGroupID: groupID, Rank: i + 1})
+	}
+	return prefs
+}
+
+// assignedGroups maps each student to the groups they were assigned, in round order
+func assignedGroups(result *lotteryResult) map[int64][]int64 {
+	groups := make(map[int64][]int64)
+	for _, a := range result.Assignments {
+		groups[a.StudentID] = append(groups[a.StudentID], a.ActivityGroupID)
+	}
+	return groups
+}
+
+func TestRunLottery_ReproducibleFromSeed(t *testing.T) {
+	input := lotteryInput{
+		Seed:   20260901,
+		Rounds: 1,
+		Preferences: map[int64][]*activities.AllocationPreference{
+			20: wishes(30, 31), 21: wishes(30, 31), 22: wishes(30, 31), 23: wishes(30, 31),
+		},
+		FreePlaces: map[int64]int{30: 2, 31: 2},
+	}
+
+	first := runLottery(input)
+	for range 5 {
+		assert.Equal(t, first, runLottery(input))
+	}
+
+	perGroup := make(map[int64]int)
+	for _, a := range first.Assignments {
+		perGroup[a.ActivityGroupID]++
+	}
+	assert.Equal(t, map[int64]int{30: 2, 31: 2}, perGroup, "MaxParticipants must be respected")
+	assert.Empty(t, first.Unplaced)
+}
+
+func TestRunLottery_SkipsWeekdayClashesAndExistingEnrollments(t *testing.T) {
+	result := runLottery(lotteryInput{
+		Seed:   42,
+		Rounds: 2,
+		Preferences: map[int64][]*activities.AllocationPreference{
+			20: wishes(30, 31, 32),
+		},
+		FreePlaces: map[int64]int{30: 10, 31: 10, 32: 10},
+		Weekdays: map[int64][]int{
+			30: {activities.WeekdayMonday},
+			31: {activities.WeekdayMonday, activities.WeekdayThursday}, // Clashes with 30
+			32: {activities.WeekdayWednesday},                          // Clashes with the existing enrollment
+		},
+		BusyWeekdays: map[int64]map[int]bool{20: {activities.WeekdayWednesday: true}},
+	})
+
+	require.Len(t, result.Assignments, 1)
+	assert.Equal(t, int64(30), result.Assignments[0].ActivityGroupID)
+	assert.Equal(t, 1, result.Assignments[0].Rank)
+
+	result = runLottery(lotteryInput{
+		Seed:        42,
+		Rounds:      1,
+		Preferences: map[int64][]*activities.AllocationPreference{20: wishes(30, 31)},
+		FreePlaces:  map[int64]int{30: 10, 31: 10},
+		Enrolled:    map[int64]map[int64]bool{20: {30: true}},
+	})
+	require.Len(t, result.Assignments, 1)
+	assert.Equal(t, int64(31), result.Assignments[0].ActivityGroupID)
+}
+
+func TestRunLottery_FairnessAcrossRounds(t *testing.T) {
+	// AG 30 has two places, AG 31 and AG 32 one each. Whoever loses the first-round draw
+	// settles for AG 31 and therefore picks first in round two, taking the last place in AG 32.
+	for seed := int64(10); seed < 40; seed++ {
+		result := runLottery(lotteryInput{
+			Seed:   seed,
+			Rounds: 2,
+			Preferences: map[int64][]*activities.AllocationPreference{
+				20: wishes(30, 31, 32), 21: wishes(30, 31, 32), 22: wishes(30, 31, 32),
+			},
+			FreePlaces: map[int64]int{30: 2, 31: 1, 32: 1},
+		})
+
+		require.Len(t, result.Assignments, 4, "seed %d", seed)
+		var secondChoice int64
+		for _, a := range result.Assignments {
+			if a.Round == 1 && a.Rank == 2 {
+				secondChoice = a.StudentID
+			}
+		}
+		require.NotZero(t, secondChoice, "seed %d", seed)
+		assert.Equal(t, []int64{31, 32}, assignedGroups(result)[secondChoice], "seed %d: the unlucky student picks first in round two", seed)
+		assert.Empty(t, result.Unplaced)
+	}
+}
+
+func TestRunLottery_Unplaced(t *testing.T) {
+	result := runLottery(lotteryInput{
+		Seed:        77,
+		Rounds:      1,
+		Preferences: map[int64][]*activities.AllocationPreference{20: wishes(30), 21: wishes(30), 22: {}},
+		FreePlaces:  map[int64]int{30: 1},
+	})
+
+	require.Len(t, result.Assignments, 1)
+	assert.Len(t, result.Unplaced, 2)
+	assert.Contains(t, result.Unplaced, int64(22))
+}
+
+func TestAssignmentsFingerprint(t *testing.T) {
+	a := &activities.AllocationAssignment{StudentID: 11, ActivityGroupID: 21, Rank: 1}
+	b := &activities.AllocationAssignment{StudentID: 12, ActivityGroupID: 22, Rank: 2}
+
+	// Order does not matter, the outcome does
+	assert.Equal(t, assignmentsFingerprint([]*activities.AllocationAssignment{a, b}),
+		assignmentsFingerprint([]*activities.AllocationAssignment{b, a}))
+
+	moved := &activities.AllocationAssignment{StudentID: 12, ActivityGroupID: 21, Rank: 2}
+	assert.NotEqual(t, assignmentsFingerprint([]*activities.AllocationAssignment{a, b}),
+		assignmentsFingerprint([]*activities.AllocationAssignment{a, moved}))
+	assert.NotEmpty(t, assignmentsFingerprint(nil))
+}
@@ -0,0 +1,541 @@
+package activities
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/uptrace/bun"
+)
+
+// AllocationService runs the per-term AG lottery: students submit up to three ranked wishes,
+// the allocation is previewed from its seed and applied as enrollments
+type AllocationService interface {
+	// Allocation management
+	CreateAllocation(ctx context.Context, allocation *activities.Allocation) (*activities.Allocation, error)
+	UpdateAllocation(ctx context.Context, allocation *activities.Allocation) (*activities.Allocation, error)
+	DeleteAllocation(ctx context.Context, id int64) error
+	GetAllocation(ctx context.Context, id int64) (*activities.Allocation, error)
+	ListAllocations(ctx context.Context, options *base.QueryOptions) ([]*activities.Allocation, error)
+
+	// SetStudentPreferences replaces a student's wishes; groupIDs are ordered by rank, empty clears them
+	SetStudentPreferences(ctx context.Context, allocationID, studentID int64, groupIDs []int64) ([]*activities.AllocationPreference, error)
+
+	// Preview & Apply
+	Preview(ctx context.Context, id int64) (*AllocationPreview, error)
+	Apply(ctx context.Context, id int64, accountID int64, fingerprint string) (*AllocationResult, error)
+}
+
+// AllocationPreview shows what applying the allocation would do
+type AllocationPreview struct {
+	AllocationID  int64                              `json:"allocation_id"`
+	Seed          int64                              `json:"seed"`
+	TotalStudents int                                `json:"total_students"`
+	FirstChoice   int                                `json:"first_choice"`  // Places granted as first wish
+	SecondChoice  int                                `json:"second_choice"` // Places granted as second wish
+	ThirdChoice   int                                `json:"third_choice"`  // Places granted as third wish
+	Unplaced      []int64                            `json:"unplaced"`      // Students without any place
+	Groups        []AllocationGroupPreview           `json:"groups"`
+	Assignments   []*activities.AllocationAssignment `json:"assignments"`
+	Warnings      []string                           `json:"warnings"`
+	Fingerprint   string                             `json:"fingerprint"` // Passed to Apply, which rejects a different outcome
+}
+
+// AllocationGroupPreview shows demand and outcome of one activity group
+type AllocationGroupPreview struct {
+	ActivityGroupID int64  `json:"activity_group_id"`
+	Name            string `json:"name"`
+	MaxParticipants int    `json:"max_participants"`
+	AlreadyEnrolled int    `json:"already_enrolled"`
+	FirstWishes     int    `json:"first_wishes"`
+	Assigned        int    `json:"assigned"`
+}
+
+// AllocationResult contains the result of applying an allocation
+type AllocationResult struct {
+	AllocationID int64    `json:"allocation_id"`
+	Status       string   `json:"status"`
+	Enrolled     int      `json:"enrolled"`
+	Unplaced     []int64  `json:"unplaced"`
+	Warnings     []string `json:"warnings"`
+}
+
+// AllocationServiceDependencies contains all dependencies required by the allocation service
+type AllocationServiceDependencies struct {
+	AllocationRepo activities.AllocationRepository
+	GroupRepo      activities.GroupRepository
+	ScheduleRepo   activities.ScheduleRepository
+	EnrollmentRepo activities.StudentEnrollmentRepository
+	DB             *bun.DB
+}
+
+// allocationService implements AllocationService
+type allocationService struct {
+	allocationRepo activities.AllocationRepository
+	groupRepo      activities.GroupRepository
+	scheduleRepo   activities.ScheduleRepository
+	enrollmentRepo activities.StudentEnrollmentRepository
+	txHandler      *base.TxHandler
+}
+
+// NewAllocationService creates a new allocation service
+func NewAllocationService(deps AllocationServiceDependencies) AllocationService {
+	return &allocationService{
+		allocationRepo: deps.AllocationRepo,
+		groupRepo:      deps.GroupRepo,
+		scheduleRepo:   deps.ScheduleRepo,
+		enrollmentRepo: deps.EnrollmentRepo,
+		txHandler:      base.NewTxHandler(deps.DB),
+	}
+}
+
+// WithTx returns a new service that uses the provided transaction
+func (s *allocationService) WithTx(tx bun.Tx) interface{} {
+	var allocationRepo = s.allocationRepo
+	var groupRepo = s.groupRepo
+	var scheduleRepo = s.scheduleRepo
+	var enrollmentRepo = s.enrollmentRepo
+
+	if txRepo, ok := s.allocationRepo.(base.TransactionalRepository); ok {
+		allocationRepo = txRepo.WithTx(tx).(activities.AllocationRepository)
+	}
+	if txRepo, ok := s.groupRepo.(base.TransactionalRepository); ok {
+		groupRepo = txRepo.WithTx(tx).(activities.GroupRepository)
+	}
+	if txRepo, ok := s.scheduleRepo.(base.TransactionalRepository); ok {
+		scheduleRepo = txRepo.WithTx(tx).(activities.ScheduleRepository)
+	}
+	if txRepo, ok := s.enrollmentRepo.(base.TransactionalRepository); ok {
+		enrollmentRepo = txRepo.WithTx(tx).(activities.StudentEnrollmentRepository)
+	}
+
+	return &allocationService{
+		allocationRepo: allocationRepo,
+		groupRepo:      groupRepo,
+		scheduleRepo:   scheduleRepo,
+		enrollmentRepo: enrollmentRepo,
+		txHandler:      s.txHandler.WithTx(tx),
+	}
+}
+
+// CreateAllocation creates a draft allocation; a zero seed draws a random one
+func (s *allocationService) CreateAllocation(ctx context.Context, allocation *activities.Allocation) (*activities.Allocation, error) {
+	allocation.Status = activities.AllocationStatusDraft
+	if allocation.Seed == 0 {
+		allocation.Seed = rand.Int63()
+	}
+
+	if err := s.allocationRepo.Create(ctx, allocation); err != nil {
+		return nil, &ActivityError{Op: "create allocation", Err: err}
+	}
+	return allocation, nil
+}
+
+// UpdateAllocation updates name, seed, rounds and notes of a draft allocation
+func (s *allocationService) UpdateAllocation(ctx context.Context, allocation *activities.Allocation) (*activities.Allocation, error) {
+	existing, err := s.findDraft(ctx, "update allocation", allocation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Term, status and creator cannot change once preferences are being collected
+	allocation.DateframeID = existing.DateframeID
+	allocation.Status = existing.Status
+	allocation.CreatedBy = existing.CreatedBy
+	allocation.CreatedAt = existing.CreatedAt
+	if allocation.Seed == 0 {
+		allocation.Seed = existing.Seed
+	}
+
+	if err := s.allocationRepo.Update(ctx, allocation); err != nil {
+		return nil, &ActivityError{Op: "update allocation", Err: err}
+	}
+	return allocation, nil
+}
+
+// DeleteAllocation deletes a draft allocation with its preferences
+func (s *allocationService) DeleteAllocation(ctx context.Context, id int64) error {
+	if _, err := s.findDraft(ctx, "delete allocation", id); err != nil {
+		return err
+	}
+
+	if err := s.allocationRepo.Delete(ctx, id); err != nil {
+		return &ActivityError{Op: "delete allocation", Err: err}
+	}
+	return nil
+}
+
+// GetAllocation returns an allocation with its preferences and, once applied, its assignments
+func (s *allocationService) GetAllocation(ctx context.Context, id int64) (*activities.Allocation, error) {
+	allocation, err := s.allocationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, &ActivityError{Op: "get allocation", Err: ErrAllocationNotFound}
+	}
+
+	if allocation.Preferences, err = s.allocationRepo.FindPreferences(ctx, id); err != nil {
+		return nil, &ActivityError{Op: "get allocation", Err: err}
+	}
+	if allocation.IsApplied() {
+		if allocation.Assignments, err = s.allocationRepo.FindAssignments(ctx, id); err != nil {
+			return nil, &ActivityError{Op: "get allocation", Err: err}
+		}
+	}
+	return allocation, nil
+}
+
+// ListAllocations lists allocations
+func (s *allocationService) ListAllocations(ctx context.Context, options *base.QueryOptions) ([]*activities.Allocation, error) {
+	allocations, err := s.allocationRepo.List(ctx, options)
+	if err != nil {
+		return nil, &ActivityError{Op: "list allocations", Err: err}
+	}
+	return allocations, nil
+}
+
+// SetStudentPreferences replaces a student's wishes; groupIDs are ordered by rank, empty clears them
+func (s *allocationService) SetStudentPreferences(ctx context.Context, allocationID, studentID int64, groupIDs []int64) ([]*activities.AllocationPreference, error) {
+	const op = "set student preferences"
+
+	allocation, err := s.findDraft(ctx, op, allocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupIDs) > activities.MaxAllocationPreferences {
+		return nil, &ActivityError{Op: op, Err: ErrInvalidPreferences}
+	}
+
+	preferences := make([]*activities.AllocationPreference, 0, len(groupIDs))
+	seen := make(map[int64]bool, len(groupIDs))
+	for i, groupID := range groupIDs {
+		if seen[groupID] {
+			return nil, &ActivityError{Op: op, Err: ErrInvalidPreferences}
+		}
+		seen[groupID] = true
+
+		group, err := s.groupRepo.FindByID(ctx, groupID)
+		if err != nil {
+			return nil, &ActivityError{Op: op, Err: ErrGroupNotFound}
+		}
+		if group.DateframeID == nil || *group.DateframeID != allocation.DateframeID {
+			return nil, &ActivityError{Op: op, Err: ErrInvalidPreferences}
+		}
+
+		preferences = append(preferences, &activities.AllocationPreference{
+			ActivityGroupID: groupID,
+			Rank:            i + 1,
+		})
+	}
+
+	if err := s.allocationRepo.ReplaceStudentPreferences(ctx, allocationID, studentID, preferences); err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+	return preferences, nil
+}
+
+// Preview computes a draft allocation from its seed without changing anything. Applied
+// allocations are not previewed; GetAllocation returns their recorded assignments.
+func (s *allocationService) Preview(ctx context.Context, id int64) (*AllocationPreview, error) {
+	allocation, err := s.findDraft(ctx, "preview allocation", id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.loadLotteryData(ctx, allocation, false)
+	if err != nil {
+		return nil, &ActivityError{Op: "preview allocation", Err: err}
+	}
+	result := runLottery(data.input)
+
+	preview := &AllocationPreview{
+		AllocationID:  allocation.ID,
+		Seed:          allocation.Seed,
+		TotalStudents: len(data.input.Preferences),
+		Unplaced:      result.Unplaced,
+		Groups:        make([]AllocationGroupPreview, 0, len(data.groupIDs)),
+		Assignments:   result.Assignments,
+		Warnings:      data.warnings,
+		Fingerprint:   assignmentsFingerprint(result.Assignments),
+	}
+
+	assigned := make(map[int64]int, len(data.groupIDs))
+	for _, a := range result.Assignments {
+		a.AllocationID = allocation.ID
+		assigned[a.ActivityGroupID]++
+		switch a.Rank {
+		case 1:
+			preview.FirstChoice++
+		case 2:
+			preview.SecondChoice++
+		default:
+			preview.ThirdChoice++
+		}
+	}
+
+	firstWishes := make(map[int64]int, len(data.groupIDs))
+	for _, wishes := range data.input.Preferences {
+		if len(wishes) > 0 && wishes[0].Rank == 1 {
+			firstWishes[wishes[0].ActivityGroupID]++
+		}
+	}
+
+	for _, groupID := range data.groupIDs {
+		group := data.groups[groupID]
+		preview.Groups = append(preview.Groups, AllocationGroupPreview{
+			ActivityGroupID: groupID,
+			Name:            group.Name,
+			MaxParticipants: group.MaxParticipants,
+			AlreadyEnrolled: data.enrolledCounts[groupID],
+			FirstWishes:     firstWishes[groupID],
+			Assigned:        assigned[groupID],
+		})
+	}
+
+	if len(result.Unplaced) > 0 {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("%d students will not receive any place", len(result.Unplaced)))
+	}
+	return preview, nil
+}
+
+// Apply recomputes the allocation from its seed, enrolls the students and records the assignments.
+// The allocation row and the wished-for groups stay locked until the transaction ends, so
+// concurrent applies cannot both see a draft and enrollments cannot take the places meanwhile.
+// Enrollments made since the preview change the outcome; the fingerprint of the preview must
+// match the recomputed one, otherwise ErrAllocationPreviewOutdated is returned.
+func (s *allocationService) Apply(ctx context.Context, id int64, accountID int64, fingerprint string) (*AllocationResult, error) {
+	const op = "apply allocation"
+
+	var allocation *activities.Allocation
+	var data *lotteryData
+	var result *lotteryResult
+	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*allocationService)
+
+		var err error
+		allocation, err = txService.allocationRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return ErrAllocationNotFound
+		}
+		if !allocation.CanModify() {
+			return ErrAllocationApplied
+		}
+
+		data, err = txService.loadLotteryData(ctx, allocation, true)
+		if err != nil {
+			return err
+		}
+		if len(data.input.Preferences) == 0 {
+			return ErrNoPreferences
+		}
+		result = runLottery(data.input)
+		if assignmentsFingerprint(result.Assignments) != fingerprint {
+			return ErrAllocationPreviewOutdated
+		}
+
+		now := time.Now()
+		for _, a := range result.Assignments {
+			a.AllocationID = allocation.ID
+			enrollment := &activities.StudentEnrollment{
+				StudentID:       a.StudentID,
+				ActivityGroupID: a.ActivityGroupID,
+				EnrollmentDate:  now,
+				Status:          activities.EnrollmentStatusEnrolled,
+			}
+			if err := txService.enrollmentRepo.Create(ctx, enrollment); err != nil {
+				return fmt.Errorf("failed to enroll student %d: %w", a.StudentID, err)
+			}
+		}
+
+		if err := txService.allocationRepo.CreateAssignments(ctx, result.Assignments); err != nil {
+			return err
+		}
+
+		allocation.Status = activities.AllocationStatusApplied
+		allocation.AppliedAt = &now
+		allocation.AppliedBy = &accountID
+		return txService.allocationRepo.Update(ctx, allocation)
+	})
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	return &AllocationResult{
+		AllocationID: allocation.ID,
+		Status:       allocation.Status,
+		Enrolled:     len(result.Assignments),
+		Unplaced:     result.Unplaced,
+		Warnings:     data.warnings,
+	}, nil
+}
+
+// findDraft loads an allocation and ensures it has not been applied yet
+func (s *allocationService) findDraft(ctx context.Context, op string, id int64) (*activities.Allocation, error) {
+	allocation, err := s.allocationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: ErrAllocationNotFound}
+	}
+	if !allocation.CanModify() {
+		return nil, &ActivityError{Op: op, Err: ErrAllocationApplied}
+	}
+	return allocation, nil
+}
+
+// lotteryData is the lottery input together with the groups it refers to
+type lotteryData struct {
+	input          lotteryInput
+	groupIDs       []int64 // Wished-for groups in order of first appearance
+	groups         map[int64]*activities.Group
+	enrolledCounts map[int64]int
+	warnings       []string
+}
+
+// loadLotteryData gathers preferences, free places, schedules and existing enrollments.
+// With lockGroups the wished-for groups are locked before their enrollments are counted,
+// like every other change to a group's places; this must run in a transaction.
+func (s *allocationService) loadLotteryData(ctx context.Context, allocation *activities.Allocation, lockGroups bool) (*lotteryData, error) {
+	preferences, err := s.allocationRepo.FindPreferences(ctx, allocation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := make(map[int64]*activities.Group)
+	if lockGroups {
+		if locked, err = s.lockGroups(ctx, preferences); err != nil {
+			return nil, err
+		}
+	}
+
+	data := &lotteryData{
+		input: lotteryInput{
+			Seed:         allocation.Seed,
+			Rounds:       allocation.MaxAssignmentsPerStudent,
+			Preferences:  make(map[int64][]*activities.AllocationPreference),
+			FreePlaces:   make(map[int64]int),
+			Weekdays:     make(map[int64][]int),
+			BusyWeekdays: make(map[int64]map[int]bool),
+			Enrolled:     make(map[int64]map[int64]bool),
+		},
+		groups:         make(map[int64]*activities.Group),
+		enrolledCounts: make(map[int64]int),
+		warnings:       []string{},
+	}
+
+	// Preferences arrive ordered by student and rank
+	for _, p := range preferences {
+		data.input.Preferences[p.StudentID] = append(data.input.Preferences[p.StudentID], p)
+		if _, ok := data.groups[p.ActivityGroupID]; ok {
+			continue
+		}
+
+		group, ok := locked[p.ActivityGroupID]
+		if !ok {
+			if group, err = s.groupRepo.FindByID(ctx, p.ActivityGroupID); err != nil {
+				return nil, ErrGroupNotFound
+			}
+		}
+		count, err := s.enrollmentRepo.CountByGroupID(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		weekdays, err := s.groupWeekdays(ctx, data.input.Weekdays, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(weekdays) == 0 {
+			data.warnings = append(data.warnings, fmt.Sprintf("activity group %q has no schedule; weekday clashes cannot be checked", group.Name))
+		}
+
+		data.groups[group.ID] = group
+		data.groupIDs = append(data.groupIDs, group.ID)
+		data.enrolledCounts[group.ID] = count
+		data.input.FreePlaces[group.ID] = max(group.MaxParticipants-count, 0)
+	}
+
+	// Existing enrollments block their weekdays and, like waitlist entries, cannot be granted again
+	for studentID := range data.input.Preferences {
+		enrollments, err := s.enrollmentRepo.FindByStudentID(ctx, studentID)
+		if err != nil {
+			return nil, err
+		}
+
+		busy := make(map[int]bool)
+		enrolled := make(map[int64]bool)
+		for _, e := range enrollments {
+			enrolled[e.ActivityGroupID] = true
+			if e.IsWaitlisted() {
+				continue
+			}
+			weekdays, err := s.groupWeekdays(ctx, data.input.Weekdays, e.ActivityGroupID)
+			if err != nil {
+				return nil, err
+			}
+			for _, weekday := range weekdays {
+				busy[weekday] = true
+			}
+		}
+		data.input.BusyWeekdays[studentID] = busy
+		data.input.Enrolled[studentID] = enrolled
+	}
+
+	return data, nil
+}
+
+// lockGroups locks the wished-for groups in ascending ID order, so concurrent lockers cannot deadlock
+func (s *allocationService) lockGroups(ctx context.Context, preferences []*activities.AllocationPreference) (map[int64]*activities.Group, error) {
+	groupIDs := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, p := range preferences {
+		if !seen[p.ActivityGroupID] {
+			seen[p.ActivityGroupID] = true
+			groupIDs = append(groupIDs, p.ActivityGroupID)
+		}
+	}
+	sort.Slice(groupIDs, func(i, j int) bool { return groupIDs[i] < groupIDs[j] })
+
+	groups := make(map[int64]*activities.Group, len(groupIDs))
+	for _, groupID := range groupIDs {
+		group, err := lockGroup(ctx, s.groupRepo, groupID)
+		if err != nil {
+			return nil, err
+		}
+		groups[groupID] = group
+	}
+	return groups, nil
+}
+
+// assignmentsFingerprint identifies a lottery outcome independent of assignment order
+func assignmentsFingerprint(assignments []*activities.AllocationAssignment) string {
+	keys := make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		keys = append(keys, fmt.Sprintf("%d:%d:%d", a.StudentID, a.ActivityGroupID, a.Rank))
+	}
+	sort.Strings(keys)
+
+	sum := sha256.Sum256([]byte(strings.Join(keys, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// groupWeekdays returns the scheduled weekdays of a group, caching them in weekdays
+func (s *allocationService) groupWeekdays(ctx context.Context, weekdays map[int64][]int, groupID int64) ([]int, error) {
+	if days, ok := weekdays[groupID]; ok {
+		return days, nil
+	}
+
+	schedules, err := s.scheduleRepo.FindByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	days := make([]int, 0, len(schedules))
+	for _, schedule := range schedules {
+		days = append(days, schedule.Weekday)
+	}
+	weekdays[groupID] = days
+	return days, nil
+}
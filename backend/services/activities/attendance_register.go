@@ -0,0 +1,86 @@
+package activities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/activities"
+)
+
+// registerVisit is one RFID visit of a session, with the exit time resolved to the session end
+// (or the derivation time) while the child is still checked in
+type registerVisit struct {
+	StudentID int64
+	EntryTime time.Time
+	ExitTime  time.Time
+}
+
+// deriveRegisterEntries builds the register entries for the enrolled students. A child with at
+// least one visit is PRESENT. Without visits a manual excuse already on the register is kept, a
+// reported absence makes the child EXCUSED and everyone else is ABSENT. It performs no I/O.
+func deriveRegisterEntries(
+	registerID int64,
+	enrolled []int64,
+	visits []registerVisit,
+	existing map[int64]*activities.SessionRegisterEntry,
+	reportedAbsent map[int64]bool,
+) []*activities.SessionRegisterEntry {
+	firstSeen := make(map[int64]time.Time)
+	minutes := make(map[int64]time.Duration)
+	for _, v := range visits {
+		if first, ok := firstSeen[v.StudentID]; !ok || v.EntryTime.Before(first) {
+			firstSeen[v.StudentID] = v.EntryTime
+		}
+		if v.ExitTime.After(v.EntryTime) {
+			minutes[v.StudentID] += v.ExitTime.Sub(v.EntryTime)
+		}
+	}
+
+	sorted := append([]int64(nil), enrolled...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	entries := make([]*activities.SessionRegisterEntry, 0, len(sorted))
+	for _, studentID := range sorted {
+		entry := &activities.SessionRegisterEntry{RegisterID: registerID, StudentID: studentID}
+		if prev := existing[studentID]; prev != nil {
+			entry.Model = prev.Model
+			entry.Note = prev.Note
+		}
+
+		first, seen := firstSeen[studentID]
+		switch {
+		case seen:
+			entry.Status = activities.AttendancePresent
+			entry.FirstSeenAt = &first
+			entry.MinutesPresent = int(minutes[studentID].Minutes())
+		case existing[studentID] != nil && existing[studentID].ExcusedBy != nil:
+			entry.Status = activities.AttendanceExcused
+			entry.ExcusedBy = existing[studentID].ExcusedBy
+			entry.ExcusedAt = existing[studentID].ExcusedAt
+		case reportedAbsent[studentID]:
+			entry.Status = activities.AttendanceExcused
+		default:
+			entry.Status = activities.AttendanceAbsent
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// scheduledDates returns the dates between from and to (inclusive) falling on one of the ISO
+// weekdays, skipping closed days (public holidays, school holidays and closure days keyed by
+// midnight UTC); from and to are expected at midnight
+func scheduledDates(from, to time.Time, weekdays map[int]bool, closed map[time.Time]string) []time.Time {
+	var dates []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if _, ok := closed[time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)]; ok {
+			continue
+		}
+		if weekdays[activities.ISOWeekday(d)] {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
@@ -0,0 +1,611 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/uptrace/bun"
+	"github.com/xuri/excelize/v2"
+)
+
+// AttendanceRegisterService keeps the per-session attendance register of activity groups. Registers
+// are derived from RFID visits when a session ends; staff can excuse absent children afterwards.
+type AttendanceRegisterService interface {
+	// DeriveRegister (re)builds the register of an activity group for one day from that day's sessions
+	DeriveRegister(ctx context.Context, activityGroupID int64, date time.Time) (*activities.SessionRegister, error)
+
+	// DeriveEndedSessions derives the registers of all sessions that ended in [from, to) and returns how many were written
+	DeriveEndedSessions(ctx context.Context, from, to time.Time) (int, error)
+
+	// GetRegisters returns the registers of an activity group between from and to (inclusive) with their entries
+	GetRegisters(ctx context.Context, activityGroupID int64, from, to time.Time) ([]*activities.SessionRegister, error)
+
+	// ExcuseStudent marks an enrolled child as excused for one day's session
+	ExcuseStudent(ctx context.Context, activityGroupID int64, date time.Time, studentID, staffID int64, note *string) (*activities.SessionRegisterEntry, error)
+
+	// Term report
+	GetTermReport(ctx context.Context, activityGroupID int64) (*TermAttendanceReport, error)
+	ExportTermReport(ctx context.Context, activityGroupID int64) ([]byte, string, error)
+}
+
+// TermAttendanceReport summarises the attendance of an activity group over its term
+type TermAttendanceReport struct {
+	ActivityGroupID int64                   `json:"activity_group_id"`
+	ActivityName    string                  `json:"activity_name"`
+	DateframeID     int64                   `json:"dateframe_id"`
+	TermName        string                  `json:"term_name"`
+	From            time.Time               `json:"from"`
+	To              time.Time               `json:"to"` // Term end or today, whichever comes first
+	Sessions        []TermReportSession     `json:"sessions"`
+	Students        []StudentTermAttendance `json:"students"`
+}
+
+// TermReportSession is one scheduled or held session of the term
+type TermReportSession struct {
+	Date      time.Time `json:"date"`
+	Scheduled bool      `json:"scheduled"` // Falls on one of the group's schedule weekdays
+	Recorded  bool      `json:"recorded"`  // A register exists; false means the session was not held
+}
+
+// StudentTermAttendance holds one child's attendance over the term
+type StudentTermAttendance struct {
+	StudentID      int64    `json:"student_id"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	SchoolClass    string   `json:"school_class"`
+	Present        int      `json:"present"`
+	Absent         int      `json:"absent"`
+	Excused        int      `json:"excused"`
+	AttendanceRate float64  `json:"attendance_rate"` // Present sessions in percent of recorded ones
+	Statuses       []string `json:"statuses"`        // One per report session; empty when not recorded
+}
+
+// AttendanceRegisterServiceDependencies contains all dependencies required by the register service
+type AttendanceRegisterServiceDependencies struct {
+	RegisterRepo       activities.SessionRegisterRepository
+	GroupRepo          activities.GroupRepository
+	ScheduleRepo       activities.ScheduleRepository
+	EnrollmentRepo     activities.StudentEnrollmentRepository
+	ActiveGroupRepo    active.GroupRepository
+	VisitRepo          active.VisitRepository
+	StudentAbsenceRepo active.StudentAbsenceRepository
+	DateframeRepo      schedule.DateframeRepository
+	DB                 *bun.DB
+}
+
+// attendanceRegisterService implements AttendanceRegisterService
+type attendanceRegisterService struct {
+	registerRepo       activities.SessionRegisterRepository
+	groupRepo          activities.GroupRepository
+	scheduleRepo       activities.ScheduleRepository
+	enrollmentRepo     activities.StudentEnrollmentRepository
+	activeGroupRepo    active.GroupRepository
+	visitRepo          active.VisitRepository
+	studentAbsenceRepo active.StudentAbsenceRepository
+	dateframeRepo      schedule.DateframeRepository
+	txHandler          *base.TxHandler
+}
+
+// NewAttendanceRegisterService creates a new attendance register service
+func NewAttendanceRegisterService(deps AttendanceRegisterServiceDependencies) AttendanceRegisterService {
+	return &attendanceRegisterService{
+		registerRepo:       deps.RegisterRepo,
+		groupRepo:          deps.GroupRepo,
+		scheduleRepo:       deps.ScheduleRepo,
+		enrollmentRepo:     deps.EnrollmentRepo,
+		activeGroupRepo:    deps.ActiveGroupRepo,
+		visitRepo:          deps.VisitRepo,
+		studentAbsenceRepo: deps.StudentAbsenceRepo,
+		dateframeRepo:      deps.DateframeRepo,
+		txHandler:          base.NewTxHandler(deps.DB),
+	}
+}
+
+// WithTx returns a new service that uses the provided transaction
+func (s *attendanceRegisterService) WithTx(tx bun.Tx) interface{} {
+	var registerRepo = s.registerRepo
+	var enrollmentRepo = s.enrollmentRepo
+
+	if txRepo, ok := s.registerRepo.(base.TransactionalRepository); ok {
+		registerRepo = txRepo.WithTx(tx).(activities.SessionRegisterRepository)
+	}
+	if txRepo, ok := s.enrollmentRepo.(base.TransactionalRepository); ok {
+		enrollmentRepo = txRepo.WithTx(tx).(activities.StudentEnrollmentRepository)
+	}
+
+	return &attendanceRegisterService{
+		registerRepo:       registerRepo,
+		groupRepo:          s.groupRepo,
+		scheduleRepo:       s.scheduleRepo,
+		enrollmentRepo:     enrollmentRepo,
+		activeGroupRepo:    s.activeGroupRepo,
+		visitRepo:          s.visitRepo,
+		studentAbsenceRepo: s.studentAbsenceRepo,
+		dateframeRepo:      s.dateframeRepo,
+		txHandler:          s.txHandler.WithTx(tx),
+	}
+}
+
+// DeriveRegister (re)builds the register of an activity group for one day. All sessions of the
+// group started that day count; a child is present when any of their visits belongs to one of them.
+// Deriving again keeps manual excuses. For today's register the enrollments' attendance status is
+// updated as well.
+func (s *attendanceRegisterService) DeriveRegister(ctx context.Context, activityGroupID int64, date time.Time) (*activities.SessionRegister, error) {
+	const op = "derive register"
+
+	if _, err := s.groupRepo.FindByID(ctx, activityGroupID); err != nil {
+		return nil, &ActivityError{Op: op, Err: ErrGroupNotFound}
+	}
+	day := timezone.DateOf(date)
+	now := time.Now()
+
+	visits, err := s.sessionVisits(ctx, activityGroupID, day, now)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByGroupID(ctx, activityGroupID)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+	enrolled := make([]int64, 0, len(enrollments))
+	for _, e := range enrollments {
+		// Waitlisted children and those who joined after the session are not expected
+		if e.Status != activities.EnrollmentStatusEnrolled || timezone.DateOf(e.EnrollmentDate).After(day) {
+			continue
+		}
+		enrolled = append(enrolled, e.StudentID)
+	}
+	if len(enrolled) == 0 {
+		return nil, &ActivityError{Op: op, Err: ErrNoEnrolledStudents}
+	}
+
+	absences, err := s.studentAbsenceRepo.GetByDate(ctx, timezone.DateOfUTC(day))
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+	reportedAbsent := make(map[int64]bool, len(absences))
+	for _, a := range absences {
+		reportedAbsent[a.StudentID] = true
+	}
+
+	scheduleID, err := s.scheduleFor(ctx, activityGroupID, day)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	var register *activities.SessionRegister
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*attendanceRegisterService)
+
+		register, err = txService.registerRepo.FindByGroupAndDate(ctx, activityGroupID, day)
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[int64]*activities.SessionRegisterEntry)
+		if register == nil {
+			register = &activities.SessionRegister{
+				ActivityGroupID: activityGroupID,
+				SessionDate:     timezone.DateOfUTC(day),
+				ScheduleID:      scheduleID,
+				DerivedAt:       now,
+			}
+			if err := txService.registerRepo.Create(ctx, register); err != nil {
+				return err
+			}
+		} else {
+			entries, err := txService.registerRepo.FindEntries(ctx, register.ID)
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				existing[entry.StudentID] = entry
+			}
+			register.ScheduleID = scheduleID
+			register.DerivedAt = now
+			if err := txService.registerRepo.Update(ctx, register); err != nil {
+				return err
+			}
+		}
+
+		register.Entries = deriveRegisterEntries(register.ID, enrolled, visits, existing, reportedAbsent)
+		if err := txService.registerRepo.SaveEntries(ctx, register.Entries); err != nil {
+			return err
+		}
+
+		if !day.Equal(timezone.Today()) {
+			return nil
+		}
+		statuses := make(map[int64]string, len(register.Entries))
+		for _, entry := range register.Entries {
+			statuses[entry.StudentID] = entry.Status
+		}
+		for _, e := range enrollments {
+			status, ok := statuses[e.StudentID]
+			if !ok {
+				continue
+			}
+			if err := txService.enrollmentRepo.UpdateAttendanceStatus(ctx, e.ID, &status); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	return register, nil
+}
+
+// sessionVisits collects the visits of all sessions of the group started on day; open visits
+// and sessions count until their end, or until now while still running. Returns
+// ErrNoSessionOnDate when no session of the group started that day.
+func (s *attendanceRegisterService) sessionVisits(ctx context.Context, activityGroupID int64, day, now time.Time) ([]registerVisit, error) {
+	sessions, err := s.activeGroupRepo.FindByTimeRange(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	var visits []registerVisit
+	held := false
+	for _, session := range sessions {
+		if session.GroupID != activityGroupID || !timezone.DateOf(session.StartTime).Equal(day) {
+			continue
+		}
+		held = true
+		sessionEnd := now
+		if session.EndTime != nil {
+			sessionEnd = *session.EndTime
+		}
+
+		sessionVisits, err := s.visitRepo.FindByActiveGroupID(ctx, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range sessionVisits {
+			exit := sessionEnd
+			if v.ExitTime != nil && v.ExitTime.Before(sessionEnd) {
+				exit = *v.ExitTime
+			}
+			visits = append(visits, registerVisit{StudentID: v.StudentID, EntryTime: v.EntryTime, ExitTime: exit})
+		}
+	}
+	if !held {
+		return nil, ErrNoSessionOnDate
+	}
+	return visits, nil
+}
+
+// scheduleFor returns the group's schedule on the day's weekday, nil for off-schedule sessions
+func (s *attendanceRegisterService) scheduleFor(ctx context.Context, activityGroupID int64, day time.Time) (*int64, error) {
+	schedules, err := s.scheduleRepo.FindByGroupID(ctx, activityGroupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sched := range schedules {
+		if sched.Weekday == activities.ISOWeekday(day) {
+			return &sched.ID, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeriveEndedSessions derives the registers of all sessions that ended in [from, to). Groups
+// without enrolled children (free play, Schulhof) are skipped.
+func (s *attendanceRegisterService) DeriveEndedSessions(ctx context.Context, from, to time.Time) (int, error) {
+	sessions, err := s.activeGroupRepo.FindEndedBetween(ctx, from, to)
+	if err != nil {
+		return 0, &ActivityError{Op: "derive ended sessions", Err: err}
+	}
+
+	type groupDay struct {
+		groupID int64
+		day     time.Time
+	}
+	seen := make(map[groupDay]bool)
+	derived := 0
+	for _, session := range sessions {
+		key := groupDay{groupID: session.GroupID, day: timezone.DateOf(session.StartTime)}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if _, err := s.DeriveRegister(ctx, key.groupID, key.day); err != nil {
+			if errors.Is(err, ErrNoEnrolledStudents) {
+				continue
+			}
+			return derived, err
+		}
+		derived++
+	}
+	return derived, nil
+}
+
+// GetRegisters returns the registers of an activity group between from and to (inclusive)
+func (s *attendanceRegisterService) GetRegisters(ctx context.Context, activityGroupID int64, from, to time.Time) ([]*activities.SessionRegister, error) {
+	registers, err := s.registerRepo.FindByGroupAndDateRange(ctx, activityGroupID, from, to)
+	if err != nil {
+		return nil, &ActivityError{Op: "get registers", Err: err}
+	}
+	return registers, nil
+}
+
+// ExcuseStudent marks an enrolled child as excused for one day's session. The session must have
+// been recorded: registers are derived when a session ends or on request via DeriveRegister.
+func (s *attendanceRegisterService) ExcuseStudent(ctx context.Context, activityGroupID int64, date time.Time, studentID, staffID int64, note *string) (*activities.SessionRegisterEntry, error) {
+	const op = "excuse student"
+
+	day := timezone.DateOf(date)
+	register, err := s.registerRepo.FindByGroupAndDate(ctx, activityGroupID, day)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+	if register == nil {
+		return nil, &ActivityError{Op: op, Err: ErrNoSessionOnDate}
+	}
+	if register.Entries, err = s.registerRepo.FindEntries(ctx, register.ID); err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	for _, entry := range register.Entries {
+		if entry.StudentID != studentID {
+			continue
+		}
+		if entry.Status == activities.AttendancePresent {
+			return nil, &ActivityError{Op: op, Err: ErrStudentWasPresent}
+		}
+		entry.Excuse(note, &staffID, time.Now())
+		if err := s.registerRepo.SaveEntries(ctx, []*activities.SessionRegisterEntry{entry}); err != nil {
+			return nil, &ActivityError{Op: op, Err: err}
+		}
+		return entry, nil
+	}
+
+	return nil, &ActivityError{Op: op, Err: ErrNotEnrolled}
+}
+
+// GetTermReport builds the attendance report of an activity group over its term (dateframe),
+// from the term start up to today
+func (s *attendanceRegisterService) GetTermReport(ctx context.Context, activityGroupID int64) (*TermAttendanceReport, error) {
+	const op = "get term report"
+
+	group, err := s.groupRepo.FindByID(ctx, activityGroupID)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: ErrGroupNotFound}
+	}
+	if group.DateframeID == nil {
+		return nil, &ActivityError{Op: op, Err: ErrGroupHasNoTerm}
+	}
+	term, err := s.dateframeRepo.FindByID(ctx, *group.DateframeID)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	from := timezone.DateOf(term.StartDate)
+	to := timezone.DateOf(term.EndDate)
+	if today := timezone.Today(); today.Before(to) {
+		to = today
+	}
+
+	schedules, err := s.scheduleRepo.FindByGroupID(ctx, activityGroupID)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+	weekdays := make(map[int]bool, len(schedules))
+	for _, sched := range schedules {
+		weekdays[sched.Weekday] = true
+	}
+
+	registers, err := s.registerRepo.FindByGroupAndDateRange(ctx, activityGroupID, from, to)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+	enrollments, err := s.enrollmentRepo.FindByGroupID(ctx, activityGroupID)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	closed, err := schedule.SchoolClosureDays(ctx, s.dateframeRepo, from, to)
+	if err != nil {
+		return nil, &ActivityError{Op: op, Err: err}
+	}
+
+	report := buildTermReport(scheduledDates(from, to, weekdays, closed), registers, enrollments)
+	report.ActivityGroupID = group.ID
+	report.ActivityName = group.Name
+	report.DateframeID = term.ID
+	report.TermName = term.Name
+	report.From = from
+	report.To = to
+	return report, nil
+}
+
+// buildTermReport lays the registers out as one column per scheduled or recorded session and
+// one row per enrolled or recorded child
+func buildTermReport(scheduled []time.Time, registers []*activities.SessionRegister, enrollments []*activities.StudentEnrollment) *TermAttendanceReport {
+	const dayKey = "2006-01-02"
+
+	byDay := make(map[string]*activities.SessionRegister, len(registers))
+	for _, register := range registers {
+		byDay[register.SessionDate.Format(dayKey)] = register
+	}
+
+	sessions := make([]TermReportSession, 0, len(scheduled)+len(registers))
+	seenDay := make(map[string]bool)
+	for _, d := range scheduled {
+		key := d.Format(dayKey)
+		seenDay[key] = true
+		sessions = append(sessions, TermReportSession{Date: d, Scheduled: true, Recorded: byDay[key] != nil})
+	}
+	for _, register := range registers {
+		key := register.SessionDate.Format(dayKey)
+		if seenDay[key] {
+			continue
+		}
+		d, _ := time.ParseInLocation(dayKey, key, timezone.Berlin)
+		sessions = append(sessions, TermReportSession{Date: d, Recorded: true})
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Date.Before(sessions[j].Date) })
+
+	rows := make(map[int64]*StudentTermAttendance)
+	var order []int64
+	row := func(studentID int64) *StudentTermAttendance {
+		if r, ok := rows[studentID]; ok {
+			return r
+		}
+		r := &StudentTermAttendance{StudentID: studentID, Statuses: make([]string, len(sessions))}
+		rows[studentID] = r
+		order = append(order, studentID)
+		return r
+	}
+
+	for _, e := range enrollments {
+		if e.Status != activities.EnrollmentStatusEnrolled {
+			continue
+		}
+		r := row(e.StudentID)
+		if e.Student != nil {
+			r.SchoolClass = e.Student.SchoolClass
+			if e.Student.Person != nil {
+				r.FirstName = e.Student.Person.FirstName
+				r.LastName = e.Student.Person.LastName
+			}
+		}
+	}
+
+	for i, session := range sessions {
+		register := byDay[session.Date.Format(dayKey)]
+		if register == nil {
+			continue
+		}
+		for _, entry := range register.Entries {
+			r := row(entry.StudentID)
+			r.Statuses[i] = entry.Status
+			switch entry.Status {
+			case activities.AttendancePresent:
+				r.Present++
+			case activities.AttendanceAbsent:
+				r.Absent++
+			case activities.AttendanceExcused:
+				r.Excused++
+			}
+		}
+	}
+
+	report := &TermAttendanceReport{Sessions: sessions, Students: make([]StudentTermAttendance, 0, len(order))}
+	for _, studentID := range order {
+		r := rows[studentID]
+		if recorded := r.Present + r.Absent + r.Excused; recorded > 0 {
+			r.AttendanceRate = float64(r.Present*1000/recorded) / 10
+		}
+		report.Students = append(report.Students, *r)
+	}
+	sort.SliceStable(report.Students, func(i, j int) bool {
+		a, b := report.Students[i], report.Students[j]
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		return a.FirstName < b.FirstName
+	})
+	return report
+}
+
+// ExportTermReport renders the term report as an XLSX file and returns it with a file name
+func (s *attendanceRegisterService) ExportTermReport(ctx context.Context, activityGroupID int64) ([]byte, string, error) {
+	report, err := s.GetTermReport(ctx, activityGroupID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := writeTermReportXLSX(report)
+	if err != nil {
+		return nil, "", &ActivityError{Op: "export term report", Err: err}
+	}
+
+	filename := fmt.Sprintf("anwesenheit-ag-%d-%s.xlsx", report.ActivityGroupID, report.To.Format("2006-01-02"))
+	return data, filename, nil
+}
+
+// termReportStatusLabels are the German cell labels of the XLSX export
+var termReportStatusLabels = map[string]string{
+	activities.AttendancePresent: "anwesend",
+	activities.AttendanceAbsent:  "fehlt",
+	activities.AttendanceExcused: "entschuldigt",
+	activities.AttendanceUnknown: "?",
+}
+
+// writeTermReportXLSX writes one row per child and one column per session, followed by the totals
+func writeTermReportXLSX(report *TermAttendanceReport) ([]byte, error) {
+	const sheet = "Anwesenheit"
+
+	f := excelize.NewFile()
+	defer func() { _ = f.Close() }()
+
+	idx, err := f.NewSheet(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sheet: %w", err)
+	}
+	f.SetActiveSheet(idx)
+	_ = f.DeleteSheet("Sheet1")
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E2E8F0"}, Pattern: 1},
+	})
+
+	headers := []string{"Nachname", "Vorname", "Klasse"}
+	for _, session := range report.Sessions {
+		label := session.Date.Format("02.01.")
+		if !session.Recorded {
+			label += " (ausgefallen)"
+		}
+		headers = append(headers, label)
+	}
+	headers = append(headers, "Anwesend", "Fehlt", "Entschuldigt", "Quote %")
+
+	for i, h := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		_ = f.SetCellValue(sheet, cell, h)
+		_ = f.SetCellStyle(sheet, cell, cell, headerStyle)
+	}
+
+	for rowIdx, student := range report.Students {
+		row := []any{student.LastName, student.FirstName, student.SchoolClass}
+		if student.LastName == "" && student.FirstName == "" {
+			row[0] = "#" + strconv.FormatInt(student.StudentID, 10)
+		}
+		for _, status := range student.Statuses {
+			row = append(row, termReportStatusLabels[status])
+		}
+		row = append(row, student.Present, student.Absent, student.Excused, student.AttendanceRate)
+
+		for colIdx, val := range row {
+			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+2)
+			_ = f.SetCellValue(sheet, cell, val)
+		}
+	}
+
+	lastCol, _ := excelize.ColumnNumberToName(len(headers))
+	_ = f.SetColWidth(sheet, "A", "C", 16)
+	_ = f.SetColWidth(sheet, "D", lastCol, 12)
+	_ = f.SetPanes(sheet, &excelize.Panes{Freeze: true, XSplit: 3, YSplit: 1, TopLeftCell: "D2", ActivePane: "bottomRight"})
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,195 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// at returns the given time on 2026-09-14 (a Monday) in Berlin
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 9, 14, hour, minute, 0, 0, timezone.Berlin)
+}
+
+func TestDeriveRegisterEntries(t *testing.T) {
+	staffID := int64(90)
+	note := "Arzttermin"
+	excusedAt := at(9, 0)
+	existing := map[int64]*activities.SessionRegisterEntry{
+		22: {Model: base.Model{ID: 70}, StudentID: 22, Status: activities.AttendanceExcused, Note: &note, ExcusedBy: &staffID, ExcusedAt: &excusedAt},
+		24: {Model: base.Model{ID: 71}, StudentID: 24, Status: activities.AttendanceExcused, Note: &note, ExcusedBy: &staffID, ExcusedAt: &excusedAt},
+	}
+	visits := []registerVisit{
+		{StudentID: 20, EntryTime: at(14, 30), ExitTime: at(15, 0)},
+		{StudentID: 20, EntryTime: at(14, 5), ExitTime: at(14, 20)},
+		{StudentID: 24, EntryTime: at(14, 10), ExitTime: at(15, 30)}, // Came after all
+	}
+
+	entries := deriveRegisterEntries(50, []int64{24, 23, 22, 21, 20}, visits, existing, map[int64]bool{23: true})
+	require.Len(t, entries, 5)
+
+	byStudent := make(map[int64]*activities.SessionRegisterEntry, len(entries))
+	for i, entry := range entries {
+		if i > 0 {
+			assert.Less(t, entries[i-1].StudentID, entry.StudentID, "entries are ordered by student")
+		}
+		assert.Equal(t, int64(50), entry.RegisterID)
+		require.NoError(t, entry.Validate())
+		byStudent[entry.StudentID] = entry
+	}
+
+	present := byStudent[20]
+	assert.Equal(t, activities.AttendancePresent, present.Status)
+	require.NotNil(t, present.FirstSeenAt)
+	assert.Equal(t, at(14, 5), *present.FirstSeenAt)
+	assert.Equal(t, 45, present.MinutesPresent)
+
+	assert.Equal(t, activities.AttendanceAbsent, byStudent[21].Status)
+
+	kept := byStudent[22]
+	assert.Equal(t, activities.AttendanceExcused, kept.Status, "manual excuses survive a re-derivation")
+	assert.Equal(t, int64(70), kept.ID)
+	assert.Equal(t, &staffID, kept.ExcusedBy)
+
+	reported := byStudent[23]
+	assert.Equal(t, activities.AttendanceExcused, reported.Status)
+	assert.Nil(t, reported.ExcusedBy, "reported absences have no excusing staff member")
+
+	cameAnyway := byStudent[24]
+	assert.Equal(t, activities.AttendancePresent, cameAnyway.Status, "a visit overrides an excuse")
+	assert.Equal(t, int64(71), cameAnyway.ID)
+	assert.Nil(t, cameAnyway.ExcusedBy)
+	assert.Equal(t, 80, cameAnyway.MinutesPresent)
+}
+
+func TestScheduledDates(t *testing.T) {
+	from := time.Date(2026, 9, 14, 0, 0, 0, 0, timezone.Berlin) // Monday
+	to := time.Date(2026, 9, 27, 0, 0, 0, 0, timezone.Berlin)   // Sunday
+
+	weekdays := map[int]bool{activities.WeekdayMonday: true, activities.WeekdaySunday: true}
+	dates := scheduledDates(from, to, weekdays, nil)
+
+	var days []string
+	for _, d := range dates {
+		days = append(days, d.Format("2006-01-02"))
+	}
+	assert.Equal(t, []string{"2026-09-14", "2026-09-20", "2026-09-21", "2026-09-27"}, days)
+	assert.Empty(t, scheduledDates(from, to, nil, nil))
+
+	closed := map[time.Time]string{time.Date(2026, 9, 21, 0, 0, 0, 0, time.UTC): "Pädagogischer Tag"}
+	assert.Len(t, scheduledDates(from, to, weekdays, closed), 3, "no session is expected on closure days")
+}
+
+func TestBuildTermReport(t *testing.T) {
+	monday := time.Date(2026, 9, 14, 0, 0, 0, 0, timezone.Berlin)
+	nextMonday := monday.AddDate(0, 0, 7)
+	friday := time.Date(2026, 9, 18, 0, 0, 0, 0, time.UTC) // Off-schedule, as scanned from a DATE column
+
+	registers := []*activities.SessionRegister{
+		{ActivityGroupID: 30, SessionDate: time.Date(2026, 9, 14, 0, 0, 0, 0, time.UTC), Entries: []*activities.SessionRegisterEntry{
+			{StudentID: 20, Status: activities.AttendancePresent},
+			{StudentID: 21, Status: activities.AttendanceAbsent},
+			{StudentID: 22, Status: activities.AttendancePresent}, // Left the AG since
+		}},
+		{ActivityGroupID: 30, SessionDate: friday, Entries: []*activities.SessionRegisterEntry{
+			{StudentID: 20, Status: activities.AttendancePresent},
+			{StudentID: 21, Status: activities.AttendanceExcused},
+		}},
+	}
+	enrollments := []*activities.StudentEnrollment{
+		{StudentID: 20, Status: activities.EnrollmentStatusEnrolled, Student: &users.Student{SchoolClass: "3a", Person: &users.Person{FirstName: "Mia", LastName: "Yilmaz"}}},
+		{StudentID: 21, Status: activities.EnrollmentStatusEnrolled, Student: &users.Student{SchoolClass: "3b", Person: &users.Person{FirstName: "Ben", LastName: "Albers"}}},
+		{StudentID: 25, Status: activities.EnrollmentStatusWaitlisted},
+	}
+
+	report := buildTermReport([]time.Time{monday, nextMonday}, registers, enrollments)
+
+	require.Len(t, report.Sessions, 3)
+	assert.Equal(t, TermReportSession{Date: monday, Scheduled: true, Recorded: true}, report.Sessions[0])
+	assert.False(t, report.Sessions[1].Scheduled)
+	assert.True(t, report.Sessions[1].Recorded)
+	assert.Equal(t, "2026-09-18", report.Sessions[1].Date.Format("2006-01-02"))
+	assert.Equal(t, TermReportSession{Date: nextMonday, Scheduled: true}, report.Sessions[2], "scheduled sessions without register count as not held")
+
+	require.Len(t, report.Students, 3, "waitlisted children are not listed")
+	assert.Equal(t, int64(22), report.Students[0].StudentID, "children without name sort first")
+	assert.Equal(t, "Albers", report.Students[1].LastName)
+	assert.Equal(t, "Yilmaz", report.Students[2].LastName)
+
+	ben := report.Students[1]
+	assert.Equal(t, []string{activities.AttendanceAbsent, activities.AttendanceExcused, ""}, ben.Statuses)
+	assert.Equal(t, 1, ben.Absent)
+	assert.Equal(t, 1, ben.Excused)
+	assert.Equal(t, 0.0, ben.AttendanceRate)
+
+	mia := report.Students[2]
+	assert.Equal(t, 2, mia.Present)
+	assert.Equal(t, 100.0, mia.AttendanceRate)
+
+	data, err := writeTermReportXLSX(report)
+	require.NoError(t, err)
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	rows, err := f.GetRows("Anwesenheit")
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"Nachname", "Vorname", "Klasse", "14.09.", "18.09.", "21.09. (ausgefallen)", "Anwesend", "Fehlt", "Entschuldigt", "Quote %"}, rows[0])
+	assert.Equal(t, "#22", rows[1][0])
+	assert.Equal(t, []string{"Albers", "Ben", "3b", "fehlt", "entschuldigt", "", "0", "1", "1", "0"}, rows[2])
+}
+
+// registerGroupRepo knows a single activity group
+type registerGroupRepo struct {
+	activities.GroupRepository
+}
+
+func (m *registerGroupRepo) FindByID(_ context.Context, id interface{}) (*activities.Group, error) {
+	return &activities.Group{Model: base.Model{ID: id.(int64)}, Name: "Fußball-AG"}, nil
+}
+
+// registerSessionRepo returns fixed active sessions
+type registerSessionRepo struct {
+	active.GroupRepository
+	sessions []*active.Group
+}
+
+func (m *registerSessionRepo) FindByTimeRange(_ context.Context, _, _ time.Time) ([]*active.Group, error) {
+	return m.sessions, nil
+}
+
+// registerRepo has no recorded registers
+type registerRepo struct {
+	activities.SessionRegisterRepository
+}
+
+func (m *registerRepo) FindByGroupAndDate(_ context.Context, _ int64, _ time.Time) (*activities.SessionRegister, error) {
+	return nil, nil
+}
+
+func TestAttendanceRegisterService_NoSessionOnDate(t *testing.T) {
+	// Another group held a session that day
+	svc := &attendanceRegisterService{
+		groupRepo:       &registerGroupRepo{},
+		activeGroupRepo: &registerSessionRepo{sessions: []*active.Group{{Model: base.Model{ID: 90}, GroupID: 31, StartTime: at(14, 0)}}},
+		registerRepo:    &registerRepo{},
+	}
+	ctx := context.Background()
+
+	_, err := svc.DeriveRegister(ctx, 30, at(0, 0))
+	assert.ErrorIs(t, err, ErrNoSessionOnDate)
+
+	_, err = svc.ExcuseStudent(ctx, 30, at(0, 0), 40, 10, nil)
+	assert.ErrorIs(t, err, ErrNoSessionOnDate, "unrecorded sessions are not derived on the fly")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/moto-nrw/project-phoenix/models/activities"
@@ -14,20 +15,25 @@ import (
 
 // ======== Enrollment Methods ========
 
-// EnrollStudent enrolls a student in an activity group
-func (s *Service) EnrollStudent(ctx context.Context, groupID, studentID int64) error {
+// EnrollStudent enrolls a student in an activity group. When the group is at MaxParticipants
+// the student is put at the end of its waitlist instead. Groups linked to a dateframe only
+// accept enrollments within the dateframe's enrollment window.
+func (s *Service) EnrollStudent(ctx context.Context, groupID, studentID int64) (*activities.StudentEnrollment, error) {
+	var enrollment *activities.StudentEnrollment
+
 	// Execute in transaction to ensure consistency
 	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
 		// Get transactional service
 		txService := s.WithTx(tx).(ActivityService)
 
-		// Check if group exists
-		_, err := txService.(*Service).groupRepo.FindByID(ctx, groupID)
+		// Lock the group so concurrent enrollments count its places one after another
+		group, err := txService.(*Service).lockGroup(ctx, groupID)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return ErrGroupNotFound
-			}
-			return &ActivityError{Op: opFindGroup, Err: err}
+			return err
+		}
+
+		if err := txService.(*Service).checkEnrollmentWindow(ctx, group, time.Now()); err != nil {
+			return err
 		}
 
 		// Check if student is already enrolled
@@ -36,18 +42,23 @@ func (s *Service) EnrollStudent(ctx context.Context, groupID, studentID int64) e
 			return &ActivityError{Op: "check existing enrollment", Err: err}
 		}
 
-		// Check if student is already enrolled
-		for _, enrollment := range enrollments {
-			if enrollment.StudentID == studentID {
+		// Check if student is already enrolled or waitlisted
+		for _, existing := range enrollments {
+			if existing.StudentID == studentID {
 				return ErrStudentAlreadyEnrolled
 			}
 		}
 
-		// Create enrollment
-		enrollment := &activities.StudentEnrollment{
+		// Create enrollment, on the waitlist once all places are taken
+		enrollment = &activities.StudentEnrollment{
 			StudentID:       studentID,
 			ActivityGroupID: groupID,
 			EnrollmentDate:  time.Now(),
+			Status:          activities.EnrollmentStatusEnrolled,
+		}
+		enrolled, waitlist := splitEnrollments(enrollments)
+		if len(waitlist) > 0 || !group.HasAvailableSpots(len(enrolled)) {
+			enrollment.Waitlist(len(waitlist) + 1)
 		}
 
 		if err := txService.(*Service).enrollmentRepo.Create(ctx, enrollment); err != nil {
@@ -58,18 +69,35 @@ func (s *Service) EnrollStudent(ctx context.Context, groupID, studentID int64) e
 	})
 
 	if err != nil {
-		return &ActivityError{Op: "enroll student", Err: err}
+		return nil, &ActivityError{Op: "enroll student", Err: err}
 	}
 
+	return enrollment, nil
+}
+
+// checkEnrollmentWindow rejects enrollments outside the enrollment window of the group's dateframe
+func (s *Service) checkEnrollmentWindow(ctx context.Context, group *activities.Group, now time.Time) error {
+	if group.DateframeID == nil || s.dateframeRepo == nil {
+		return nil
+	}
+
+	dateframe, err := s.dateframeRepo.FindByID(ctx, *group.DateframeID)
+	if err != nil {
+		return &ActivityError{Op: "find dateframe", Err: err}
+	}
+	if !dateframe.IsEnrollmentOpen(now) {
+		return ErrEnrollmentWindowClosed
+	}
 	return nil
 }
 
-// UnenrollStudent removes a student from an activity group
+// UnenrollStudent removes a student from an activity group or its waitlist.
+// A freed place is given to the first student on the waitlist.
 func (s *Service) UnenrollStudent(ctx context.Context, groupID, studentID int64) error {
 	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
 		txService := s.WithTx(tx).(ActivityService)
 
-		if err := s.validateGroupExists(ctx, txService, groupID); err != nil {
+		if _, err := txService.(*Service).lockGroup(ctx, groupID); err != nil {
 			return err
 		}
 
@@ -87,7 +115,7 @@ func (s *Service) UnenrollStudent(ctx context.Context, groupID, studentID int64)
 			return &ActivityError{Op: "delete enrollment", Err: err}
 		}
 
-		return nil
+		return txService.(*Service).rebalanceWaitlist(ctx, groupID)
 	})
 
 	if err != nil {
@@ -97,6 +125,86 @@ func (s *Service) UnenrollStudent(ctx context.Context, groupID, studentID int64)
 	return nil
 }
 
+// splitEnrollments separates the students holding a place from the waitlist, which is returned in position order
+func splitEnrollments(enrollments []*activities.StudentEnrollment) ([]*activities.StudentEnrollment, []*activities.StudentEnrollment) {
+	enrolled := make([]*activities.StudentEnrollment, 0, len(enrollments))
+	waitlist := make([]*activities.StudentEnrollment, 0)
+	for _, enrollment := range enrollments {
+		if enrollment.IsWaitlisted() {
+			waitlist = append(waitlist, enrollment)
+		} else {
+			enrolled = append(enrolled, enrollment)
+		}
+	}
+	sort.SliceStable(waitlist, func(i, j int) bool {
+		return waitlistPosition(waitlist[i]) < waitlistPosition(waitlist[j])
+	})
+	return enrolled, waitlist
+}
+
+// waitlistPosition returns the waitlist position of an enrollment, 0 when it holds a place
+func waitlistPosition(enrollment *activities.StudentEnrollment) int {
+	if enrollment.WaitlistPosition == nil {
+		return 0
+	}
+	return *enrollment.WaitlistPosition
+}
+
+// lockGroup loads a group and locks its row until the transaction ends. Every change to a group's
+// places or waitlist takes this lock before counting enrollments, so concurrent changes cannot
+// both see the same free place.
+func (s *Service) lockGroup(ctx context.Context, groupID int64) (*activities.Group, error) {
+	return lockGroup(ctx, s.groupRepo, groupID)
+}
+
+// lockGroup locks a group row through the given repository; shared with the allocation service
+func lockGroup(ctx context.Context, groupRepo activities.GroupRepository, groupID int64) (*activities.Group, error) {
+	group, err := groupRepo.FindByIDForUpdate(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, &ActivityError{Op: opFindGroup, Err: err}
+	}
+	return group, nil
+}
+
+// rebalanceWaitlist promotes waitlisted students in order while the group has free places
+// and renumbers the remaining waitlist without gaps. Must run in a transaction.
+func (s *Service) rebalanceWaitlist(ctx context.Context, groupID int64) error {
+	group, err := s.lockGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByGroupID(ctx, group.ID)
+	if err != nil {
+		return &ActivityError{Op: "find enrollments", Err: err}
+	}
+
+	enrolled, waitlist := splitEnrollments(enrollments)
+	freePlaces := group.MaxParticipants - len(enrolled)
+	position := 0
+	// Positions only move forward, so updating in order never collides with the unique position index
+	for _, enrollment := range waitlist {
+		if freePlaces > 0 {
+			enrollment.Promote()
+			freePlaces--
+		} else {
+			position++
+			if waitlistPosition(enrollment) == position {
+				continue
+			}
+			enrollment.Waitlist(position)
+		}
+
+		if err := s.enrollmentRepo.Update(ctx, enrollment); err != nil {
+			return &ActivityError{Op: "update waitlist", Err: err}
+		}
+	}
+	return nil
+}
+
 // findEnrollmentID finds the enrollment ID for a specific student in a group
 func (s *Service) findEnrollmentID(enrollments []*activities.StudentEnrollment, studentID int64) (int64, error) {
 	for _, enrollment := range enrollments {
@@ -108,16 +216,17 @@ func (s *Service) findEnrollmentID(enrollments []*activities.StudentEnrollment,
 }
 
 // UpdateGroupEnrollments updates the student enrollments for a group
-// This follows the education.UpdateGroupTeachers pattern but for student enrollments
+// This follows the education.UpdateGroupTeachers pattern but for student enrollments.
+// Places freed by removed students go to the waitlist first; new students beyond
+// MaxParticipants are waitlisted in the given order.
 func (s *Service) UpdateGroupEnrollments(ctx context.Context, groupID int64, studentIDs []int64) error {
-	_, err := s.groupRepo.FindByID(ctx, groupID)
-	if err != nil {
-		return &ActivityError{Op: "UpdateGroupEnrollments", Err: ErrGroupNotFound}
-	}
-
-	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
 		txService := s.WithTx(tx).(ActivityService)
 
+		if _, err := txService.(*Service).lockGroup(ctx, groupID); err != nil {
+			return err
+		}
+
 		enrollments, err := txService.(*Service).enrollmentRepo.FindByGroupID(ctx, groupID)
 		if err != nil {
 			return &ActivityError{Op: "get current enrollments", Err: err}
@@ -129,6 +238,10 @@ func (s *Service) UpdateGroupEnrollments(ctx context.Context, groupID int64, stu
 			return err
 		}
 
+		if err := txService.(*Service).rebalanceWaitlist(ctx, groupID); err != nil {
+			return err
+		}
+
 		if err := s.addNewEnrollmentsInTx(ctx, txService, groupID, currentStudentIDs, studentIDs); err != nil {
 			return err
 		}
@@ -170,14 +283,33 @@ func (s *Service) removeUnwantedEnrollmentsInTx(ctx context.Context, txService A
 	return nil
 }
 
-// addNewEnrollmentsInTx adds new student enrollments
+// addNewEnrollmentsInTx adds new student enrollments, waitlisting them once the group is full
 func (s *Service) addNewEnrollmentsInTx(ctx context.Context, txService ActivityService, groupID int64, currentStudentIDs map[int64]int64, studentIDs []int64) error {
+	group, err := txService.(*Service).lockGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	remaining, err := txService.(*Service).enrollmentRepo.FindByGroupID(ctx, group.ID)
+	if err != nil {
+		return &ActivityError{Op: "get current enrollments", Err: err}
+	}
+	enrolled, waitlist := splitEnrollments(remaining)
+	enrolledCount, waitlistLength := len(enrolled), len(waitlist)
+
 	for _, studentID := range studentIDs {
 		if _, exists := currentStudentIDs[studentID]; !exists {
 			enrollment := &activities.StudentEnrollment{
 				StudentID:       studentID,
-				ActivityGroupID: groupID,
+				ActivityGroupID: group.ID,
 				EnrollmentDate:  time.Now(),
+				Status:          activities.EnrollmentStatusEnrolled,
+			}
+			if waitlistLength > 0 || !group.HasAvailableSpots(enrolledCount) {
+				waitlistLength++
+				enrollment.Waitlist(waitlistLength)
+			} else {
+				enrolledCount++
 			}
 
 			if err := txService.(*Service).enrollmentRepo.Create(ctx, enrollment); err != nil {
@@ -188,7 +320,7 @@ func (s *Service) addNewEnrollmentsInTx(ctx context.Context, txService ActivityS
 	return nil
 }
 
-// GetEnrolledStudents retrieves all students enrolled in a group
+// GetEnrolledStudents retrieves all students holding a place in a group (waitlist excluded)
 func (s *Service) GetEnrolledStudents(ctx context.Context, groupID int64) ([]*users.Student, error) {
 	// Get the enrollments for this group
 	enrollments, err := s.enrollmentRepo.FindByGroupID(ctx, groupID)
@@ -199,6 +331,10 @@ func (s *Service) GetEnrolledStudents(ctx context.Context, groupID int64) ([]*us
 	// Extract the Student objects from the enrollments
 	students := make([]*users.Student, 0, len(enrollments))
 	for _, enrollment := range enrollments {
+		// Waitlisted students do not hold a place yet
+		if enrollment.IsWaitlisted() {
+			continue
+		}
 		// Check if the Student relation is loaded
 		if enrollment.Student != nil {
 			students = append(students, enrollment.Student)
@@ -208,9 +344,10 @@ func (s *Service) GetEnrolledStudents(ctx context.Context, groupID int64) ([]*us
 	return students, nil
 }
 
-// GetStudentEnrollments retrieves all groups a student is enrolled in
-func (s *Service) GetStudentEnrollments(ctx context.Context, studentID int64) ([]*activities.Group, error) {
-	var result []*activities.Group
+// GetStudentEnrollments retrieves all enrollments of a student, including waitlisted ones,
+// with the activity group attached to each enrollment
+func (s *Service) GetStudentEnrollments(ctx context.Context, studentID int64) ([]*activities.StudentEnrollment, error) {
+	var result []*activities.StudentEnrollment
 
 	// Use transaction to ensure consistent data
 	err := s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
@@ -231,7 +368,7 @@ func (s *Service) GetStudentEnrollments(ctx context.Context, studentID int64) ([
 
 		// If no enrollments found, return empty slice
 		if len(groupIDs) == 0 {
-			result = []*activities.Group{}
+			result = []*activities.StudentEnrollment{}
 			return nil
 		}
 
@@ -254,8 +391,19 @@ func (s *Service) GetStudentEnrollments(ctx context.Context, studentID int64) ([
 			return &ActivityError{Op: "get groups by ids", Err: err}
 		}
 
+		groupsByID := make(map[int64]*activities.Group, len(groups))
+		for _, group := range groups {
+			groupsByID[group.ID] = group
+		}
+
 		// Store result for returning after transaction completes
-		result = groups
+		result = make([]*activities.StudentEnrollment, 0, len(enrollments))
+		for _, enrollment := range enrollments {
+			enrollment.ActivityGroup = groupsByID[enrollment.ActivityGroupID]
+			if enrollment.ActivityGroup != nil {
+				result = append(result, enrollment)
+			}
+		}
 		return nil
 	})
 
@@ -0,0 +1,127 @@
+package activities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitlistEnrollmentRepo keeps enrollments in memory; methods the waitlist does not use panic when called
+type waitlistEnrollmentRepo struct {
+	activities.StudentEnrollmentRepository
+	enrollments []*activities.StudentEnrollment
+	updated     []int64
+}
+
+func (m *waitlistEnrollmentRepo) FindByGroupID(_ context.Context, _ int64) ([]*activities.StudentEnrollment, error) {
+	return m.enrollments, nil
+}
+
+func (m *waitlistEnrollmentRepo) Update(_ context.Context, enrollment *activities.StudentEnrollment) error {
+	if err := enrollment.Validate(); err != nil {
+		return err
+	}
+	m.updated = append(m.updated, enrollment.StudentID)
+	return nil
+}
+
+// waitlistGroupRepo returns a single group
+type waitlistGroupRepo struct {
+	activities.GroupRepository
+	group *activities.Group
+}
+
+func (m *waitlistGroupRepo) FindByIDForUpdate(_ context.Context, _ int64) (*activities.Group, error) {
+	return m.group, nil
+}
+
+// waitlistDateframeRepo returns a single dateframe
+type waitlistDateframeRepo struct {
+	schedule.DateframeRepository
+	dateframe *schedule.Dateframe
+}
+
+func (m *waitlistDateframeRepo) FindByID(_ context.Context, _ any) (*schedule.Dateframe, error) {
+	return m.dateframe, nil
+}
+
+func waitlisted(studentID int64, position int) *activities.StudentEnrollment {
+	e := &activities.StudentEnrollment{Model: base.Model{ID: studentID + 100}, StudentID: studentID, ActivityGroupID: 10}
+	e.Waitlist(position)
+	return e
+}
+
+func enrolled(studentID int64) *activities.StudentEnrollment {
+	return &activities.StudentEnrollment{Model: base.Model{ID: studentID + 100}, StudentID: studentID, ActivityGroupID: 10, Status: activities.EnrollmentStatusEnrolled}
+}
+
+func TestRebalanceWaitlist(t *testing.T) {
+	group := &activities.Group{Model: base.Model{ID: 10}, MaxParticipants: 2}
+
+	t.Run("promotes in waitlist order and closes gaps", func(t *testing.T) {
+		// Student 20 unenrolled: one free place, waitlist has a gap at position 2
+		repo := &waitlistEnrollmentRepo{enrollments: []*activities.StudentEnrollment{
+			enrolled(21),
+			waitlisted(24, 4),
+			waitlisted(22, 1),
+			waitlisted(23, 3),
+		}}
+		s := &Service{groupRepo: &waitlistGroupRepo{group: group}, enrollmentRepo: repo}
+
+		require.NoError(t, s.rebalanceWaitlist(context.Background(), group.ID))
+
+		byStudent := make(map[int64]*activities.StudentEnrollment)
+		for _, e := range repo.enrollments {
+			byStudent[e.StudentID] = e
+		}
+		assert.False(t, byStudent[22].IsWaitlisted())
+		require.True(t, byStudent[23].IsWaitlisted())
+		assert.Equal(t, 1, *byStudent[23].WaitlistPosition)
+		assert.Equal(t, 2, *byStudent[24].WaitlistPosition)
+		assert.Equal(t, []int64{22, 23, 24}, repo.updated)
+	})
+
+	t.Run("full group keeps an ordered waitlist untouched", func(t *testing.T) {
+		repo := &waitlistEnrollmentRepo{enrollments: []*activities.StudentEnrollment{
+			enrolled(21),
+			enrolled(22),
+			waitlisted(23, 1),
+			waitlisted(24, 2),
+		}}
+		s := &Service{groupRepo: &waitlistGroupRepo{group: group}, enrollmentRepo: repo}
+
+		require.NoError(t, s.rebalanceWaitlist(context.Background(), group.ID))
+		assert.Empty(t, repo.updated)
+	})
+}
+
+func TestCheckEnrollmentWindow(t *testing.T) {
+	opens := time.Date(2026, 8, 1, 8, 0, 0, 0, time.UTC)
+	closes := time.Date(2026, 8, 15, 18, 0, 0, 0, time.UTC)
+	dateframeID := int64(30)
+	s := &Service{dateframeRepo: &waitlistDateframeRepo{dateframe: &schedule.Dateframe{
+		EnrollmentOpensAt:  &opens,
+		EnrollmentClosesAt: &closes,
+	}}}
+	ctx := context.Background()
+
+	t.Run("group without dateframe", func(t *testing.T) {
+		assert.NoError(t, s.checkEnrollmentWindow(ctx, &activities.Group{}, opens.Add(-time.Hour)))
+	})
+
+	t.Run("inside the window", func(t *testing.T) {
+		assert.NoError(t, s.checkEnrollmentWindow(ctx, &activities.Group{DateframeID: &dateframeID}, opens))
+	})
+
+	t.Run("before and after the window", func(t *testing.T) {
+		group := &activities.Group{DateframeID: &dateframeID}
+		assert.ErrorIs(t, s.checkEnrollmentWindow(ctx, group, opens.Add(-time.Minute)), ErrEnrollmentWindowClosed)
+		assert.ErrorIs(t, s.checkEnrollmentWindow(ctx, group, closes), ErrEnrollmentWindowClosed)
+	})
+}
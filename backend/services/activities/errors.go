@@ -39,6 +39,9 @@ var (
 	// ErrGroupClosed returned when an activity group is not open for enrollment
 	ErrGroupClosed = errors.New("activity group is not open for enrollment")
 
+	// ErrEnrollmentWindowClosed returned when enrolling outside the enrollment window of the group's dateframe
+	ErrEnrollmentWindowClosed = errors.New("enrollment window for this activity group is closed")
+
 	// ErrCannotDeletePrimary returned when attempting to delete a primary supervisor
 	ErrCannotDeletePrimary = errors.New("cannot delete primary supervisor")
 
@@ -50,6 +53,33 @@ var (
 
 	// ErrUnauthorized returned when user doesn't have permission to perform the action
 	ErrUnauthorized = errors.New("you are not authorized to perform this action")
+
+	// ErrAllocationNotFound returned when an activity allocation doesn't exist
+	ErrAllocationNotFound = errors.New("activity allocation not found")
+
+	// ErrAllocationApplied returned when modifying or re-applying an applied allocation
+	ErrAllocationApplied = errors.New("activity allocation has already been applied")
+
+	// ErrAllocationPreviewOutdated returned when applying an allocation whose outcome changed since the preview
+	ErrAllocationPreviewOutdated = errors.New("activity allocation outcome differs from the preview; preview it again")
+
+	// ErrInvalidPreferences returned when wishes are duplicated, too many or outside the allocation's term
+	ErrInvalidPreferences = errors.New("preferences must name up to 3 different activity groups of the allocation's term")
+
+	// ErrNoPreferences returned when applying an allocation nobody has submitted wishes for
+	ErrNoPreferences = errors.New("activity allocation has no preferences")
+
+	// ErrNoEnrolledStudents returned when deriving a register for a group nobody is enrolled in
+	ErrNoEnrolledStudents = errors.New("activity group has no enrolled students")
+
+	// ErrNoSessionOnDate returned when the activity group held no session on the requested day
+	ErrNoSessionOnDate = errors.New("activity group had no session on this date")
+
+	// ErrStudentWasPresent returned when excusing a child the register records as present
+	ErrStudentWasPresent = errors.New("student was present at this session")
+
+	// ErrGroupHasNoTerm returned when a term report is requested for a group without dateframe
+	ErrGroupHasNoTerm = errors.New("activity group is not assigned to a term")
 )
 
 // ActivityError represents an activity-related error
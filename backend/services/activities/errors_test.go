@@ -25,8 +25,15 @@ func TestActivitiesErrorVariables(t *testing.T) {
 		{"ErrNotEnrolled", ErrNotEnrolled, "student is not enrolled in this activity group"},
 		{"ErrInvalidAttendanceStatus", ErrInvalidAttendanceStatus, "invalid attendance status"},
 		{"ErrGroupClosed", ErrGroupClosed, "activity group is not open for enrollment"},
+		{"ErrEnrollmentWindowClosed", ErrEnrollmentWindowClosed, "enrollment window for this activity group is closed"},
 		{"ErrCannotDeletePrimary", ErrCannotDeletePrimary, "cannot delete primary supervisor"},
 		{"ErrStaffNotFound", ErrStaffNotFound, "staff not found"},
+		{"ErrAllocationNotFound", ErrAllocationNotFound, "activity allocation not found"},
+		{"ErrAllocationApplied", ErrAllocationApplied, "activity allocation has already been applied"},
+		{"ErrNoPreferences", ErrNoPreferences, "activity allocation has no preferences"},
+		{"ErrNoEnrolledStudents", ErrNoEnrolledStudents, "activity group has no enrolled students"},
+		{"ErrStudentWasPresent", ErrStudentWasPresent, "student was present at this session"},
+		{"ErrGroupHasNoTerm", ErrGroupHasNoTerm, "activity group is not assigned to a term"},
 	}
 
 	for _, tt := range tests {
@@ -49,8 +56,16 @@ func TestActivitiesErrorsAreDistinct(t *testing.T) {
 		ErrNotEnrolled,
 		ErrInvalidAttendanceStatus,
 		ErrGroupClosed,
+		ErrEnrollmentWindowClosed,
 		ErrCannotDeletePrimary,
 		ErrStaffNotFound,
+		ErrAllocationNotFound,
+		ErrAllocationApplied,
+		ErrInvalidPreferences,
+		ErrNoPreferences,
+		ErrNoEnrolledStudents,
+		ErrStudentWasPresent,
+		ErrGroupHasNoTerm,
 	}
 
 	for i, err1 := range errorVars {
@@ -59,11 +59,11 @@ type ActivityService interface {
 	UpdateGroupSupervisors(ctx context.Context, groupID int64, staffIDs []int64) error
 
 	// Enrollment operations
-	EnrollStudent(ctx context.Context, groupID, studentID int64) error
+	EnrollStudent(ctx context.Context, groupID, studentID int64) (*activities.StudentEnrollment, error)
 	UnenrollStudent(ctx context.Context, groupID, studentID int64) error
 	UpdateGroupEnrollments(ctx context.Context, groupID int64, studentIDs []int64) error
 	GetEnrolledStudents(ctx context.Context, groupID int64) ([]*users.Student, error)
-	GetStudentEnrollments(ctx context.Context, studentID int64) ([]*activities.Group, error)
+	GetStudentEnrollments(ctx context.Context, studentID int64) ([]*activities.StudentEnrollment, error)
 	GetAvailableGroups(ctx context.Context, studentID int64) ([]*activities.Group, error)
 	UpdateAttendanceStatus(ctx context.Context, enrollmentID int64, status *string) error
 	GetEnrollmentsByDate(ctx context.Context, date time.Time) ([]*activities.StudentEnrollment, error)
@@ -22,6 +22,9 @@ const (
 	opCreateParentAccount           = "create parent account"
 )
 
+// passwordResetEmailType identifies password reset mails in the email outbox
+const passwordResetEmailType = "password_reset"
+
 var passwordResetEmailBackoff = []time.Duration{
 	time.Second,
 	5 * time.Second,
@@ -96,7 +99,7 @@ func NewService(
 		return nil, &AuthError{Op: "create token auth", Err: err}
 	}
 
-	return &Service{
+	service := &Service{
 		repos:               repos,
 		tokenAuth:           tokenAuth,
 		dispatcher:          config.Dispatcher,
@@ -108,7 +111,11 @@ func NewService(
 		txHandler:           base.NewTxHandler(db),
 		db:                  db,
 		logger:              logger,
-	}, nil
+	}
+
+	config.Dispatcher.RegisterHandler(passwordResetEmailType, service.persistPasswordResetDelivery)
+
+	return service, nil
 }
 
 // getLogger returns the service's logger, falling back to slog.Default() if nil.
@@ -99,7 +99,7 @@ func NewInvitationService(config InvitationServiceConfig) InvitationService {
 	if dispatcher == nil && config.Mailer != nil {
 		dispatcher = email.NewDispatcher(config.Mailer, logger.With("component", "email"))
 	}
-	return &invitationService{
+	service := &invitationService{
 		invitationRepo:   config.InvitationRepo,
 		accountRepo:      config.AccountRepo,
 		roleRepo:         config.RoleRepo,
@@ -115,6 +115,10 @@ func NewInvitationService(config InvitationServiceConfig) InvitationService {
 		txHandler:        modelBase.NewTxHandler(config.DB),
 		logger:           logger,
 	}
+
+	dispatcher.RegisterHandler(invitationEmailType, service.persistInvitationDelivery)
+
+	return service
 }
 
 // WithTx clones the service with repositories bound to the provided transaction when supported.
@@ -632,6 +636,9 @@ func (s *invitationService) lookupRoleName(ctx context.Context, roleID int64) (s
 	return role.Name, nil
 }
 
+// invitationEmailType identifies staff invitation mails in the email outbox
+const invitationEmailType = "invitation"
+
 var invitationEmailBackoff = []time.Duration{
 	time.Second,
 	5 * time.Second,
@@ -670,27 +677,27 @@ func (s *invitationService) sendInvitationEmail(invitation *authModels.Invitatio
 	}
 
 	meta := email.DeliveryMetadata{
-		Type:        "invitation",
+		Type:        invitationEmailType,
 		ReferenceID: invitation.ID,
 		Token:       invitation.Token,
 		Recipient:   invitation.Email,
+		BaseRetry:   invitation.EmailRetryCount,
 	}
 
-	baseRetry := invitation.EmailRetryCount
-
+	// Outbox deliveries report to the handler registered for this type in NewInvitationService instead of the callback
 	s.dispatcher.Dispatch(context.Background(), email.DeliveryRequest{
 		Message:       message,
 		Metadata:      meta,
 		BackoffPolicy: invitationEmailBackoff,
 		MaxAttempts:   3,
-		Callback: func(cbCtx context.Context, result email.DeliveryResult) {
-			s.persistInvitationDelivery(cbCtx, meta, baseRetry, result)
-		},
+		Callback:      s.persistInvitationDelivery,
 	})
 }
 
-func (s *invitationService) persistInvitationDelivery(ctx context.Context, meta email.DeliveryMetadata, baseRetry int, result email.DeliveryResult) {
-	retryCount := baseRetry + result.Attempt
+// persistInvitationDelivery records a delivery attempt on the invitation token
+func (s *invitationService) persistInvitationDelivery(ctx context.Context, result email.DeliveryResult) {
+	meta := result.Metadata
+	retryCount := meta.BaseRetry + result.Attempt
 	var sentAt *time.Time
 	var errText *string
 
@@ -698,7 +705,7 @@ func (s *invitationService) persistInvitationDelivery(ctx context.Context, meta
 		sentTime := result.SentAt
 		sentAt = &sentTime
 	} else if result.Err != nil {
-		msg := sanitizeEmailError(result.Err)
+		msg := email.ErrorText(result.Err)
 		errText = &msg
 	}
 
@@ -719,13 +726,6 @@ func (s *invitationService) persistInvitationDelivery(ctx context.Context, meta
 	}
 }
 
-func sanitizeEmailError(err error) string {
-	if err == nil {
-		return ""
-	}
-	return strings.TrimSpace(err.Error())
-}
-
 func isNotFoundError(err error) bool {
 	if err == nil {
 		return false
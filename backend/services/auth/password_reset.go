@@ -157,22 +157,20 @@ func (s *Service) dispatchPasswordResetEmail(ctx context.Context, resetToken *au
 	}
 
 	meta := email.DeliveryMetadata{
-		Type:        "password_reset",
+		Type:        passwordResetEmailType,
 		ReferenceID: resetToken.ID,
 		Token:       resetToken.Token,
 		Recipient:   accountEmail,
+		BaseRetry:   resetToken.EmailRetryCount,
 	}
 
-	baseRetry := resetToken.EmailRetryCount
-
+	// Outbox deliveries report to the handler registered for this type in NewService instead of the callback
 	s.dispatcher.Dispatch(ctx, email.DeliveryRequest{
 		Message:       message,
 		Metadata:      meta,
 		BackoffPolicy: passwordResetEmailBackoff,
 		MaxAttempts:   3,
-		Callback: func(cbCtx context.Context, result email.DeliveryResult) {
-			s.persistPasswordResetDelivery(cbCtx, meta, baseRetry, result)
-		},
+		Callback:      s.persistPasswordResetDelivery,
 	})
 }
 
@@ -229,8 +227,10 @@ func (s *Service) ResetPassword(ctx context.Context, token, newPassword string)
 	return nil
 }
 
-func (s *Service) persistPasswordResetDelivery(ctx context.Context, meta email.DeliveryMetadata, baseRetry int, result email.DeliveryResult) {
-	retryCount := baseRetry + result.Attempt
+// persistPasswordResetDelivery records a delivery attempt on the password reset token
+func (s *Service) persistPasswordResetDelivery(ctx context.Context, result email.DeliveryResult) {
+	meta := result.Metadata
+	retryCount := meta.BaseRetry + result.Attempt
 	var sentAt *time.Time
 	var errText *string
 
@@ -238,7 +238,7 @@ func (s *Service) persistPasswordResetDelivery(ctx context.Context, meta email.D
 		sentTime := result.SentAt
 		sentAt = &sentTime
 	} else if result.Err != nil {
-		msg := sanitizeEmailError(result.Err)
+		msg := email.ErrorText(result.Err)
 		errText = &msg
 	}
 
@@ -258,5 +258,3 @@ func (s *Service) persistPasswordResetDelivery(ctx context.Context, meta email.D
 		)
 	}
 }
-
-// Note: sanitizeEmailError is defined in invitation_service.go and shared across the package
@@ -512,6 +512,12 @@ func (s *service) InitializeDefaultSettings(ctx context.Context) error {
 		},
 	}
 
+	// Working time rules (ArbZG)
+	defaultSettings = append(defaultSettings, defaultWorkTimeRuleSettings()...)
+
+	// Missing student alert rules
+	defaultSettings = append(defaultSettings, defaultMissingAlertRuleSettings()...)
+
 	// Execute in transaction using txHandler
 	return s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
 		// Get transactional service
@@ -632,6 +638,137 @@ func (s *service) UpdateTimeoutSettings(ctx context.Context, settings *config.Ti
 	})
 }
 
+// defaultWorkTimeRuleSettings returns the settings holding the statutory ArbZG thresholds
+func defaultWorkTimeRuleSettings() []*config.Setting {
+	defaults := config.NewDefaultWorkTimeRules()
+	entries := []struct {
+		key         string
+		value       int
+		description string
+	}{
+		{config.SettingBreakThresholdMinutes, defaults.BreakThresholdMinutes, "Working minutes after which a break is required"},
+		{config.SettingBreakMinutes, defaults.BreakMinutes, "Required break minutes after the break threshold"},
+		{config.SettingLongBreakThresholdMinutes, defaults.LongBreakThresholdMinutes, "Working minutes after which the longer break is required"},
+		{config.SettingLongBreakMinutes, defaults.LongBreakMinutes, "Required break minutes after the long break threshold"},
+		{config.SettingMaxDailyMinutes, defaults.MaxDailyMinutes, "Maximum daily working minutes"},
+		{config.SettingMinRestMinutes, defaults.MinRestMinutes, "Minimum rest minutes between two shifts"},
+	}
+
+	settings := make([]*config.Setting, len(entries))
+	for i, e := range entries {
+		settings[i] = &config.Setting{
+			Key:         e.key,
+			Value:       strconv.Itoa(e.value),
+			Category:    "time_tracking",
+			Description: e.description,
+		}
+	}
+	return settings
+}
+
+// GetWorkTimeRules retrieves the working time rule thresholds, falling back to the statutory defaults
+func (s *service) GetWorkTimeRules(ctx context.Context) (*config.WorkTimeRules, error) {
+	rules := config.NewDefaultWorkTimeRules()
+	fields := []struct {
+		key   string
+		value *int
+	}{
+		{config.SettingBreakThresholdMinutes, &rules.BreakThresholdMinutes},
+		{config.SettingBreakMinutes, &rules.BreakMinutes},
+		{config.SettingLongBreakThresholdMinutes, &rules.LongBreakThresholdMinutes},
+		{config.SettingLongBreakMinutes, &rules.LongBreakMinutes},
+		{config.SettingMaxDailyMinutes, &rules.MaxDailyMinutes},
+		{config.SettingMinRestMinutes, &rules.MinRestMinutes},
+	}
+
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.key)
+	}
+
+	// Load all thresholds in one query; missing keys keep their default
+	settings, err := s.settingRepo.FindByKeys(ctx, keys)
+	if err != nil {
+		return nil, &ConfigError{Op: "GetWorkTimeRules", Err: err}
+	}
+	values := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		values[setting.Key] = setting.Value
+	}
+
+	for _, f := range fields {
+		raw, ok := values[f.key]
+		if !ok {
+			continue
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, &ConfigError{
+				Op:  "GetWorkTimeRules",
+				Err: &ValueParsingError{Key: f.key, Value: raw, Type: "integer"},
+			}
+		}
+		*f.value = value
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, &ConfigError{Op: "GetWorkTimeRules", Err: fmt.Errorf("invalid work time rules: %w", err)}
+	}
+
+	return rules, nil
+}
+
+// defaultMissingAlertRuleSettings returns the per-weekday arrival and grace settings for missing student alerts
+func defaultMissingAlertRuleSettings() []*config.Setting {
+	defaults := config.NewDefaultMissingAlertRules()
+	settings := make([]*config.Setting, 0, 2*len(defaults.Days))
+	for weekday := 1; weekday <= len(config.MissingAlertWeekdayKeys); weekday++ {
+		name := config.MissingAlertWeekdayKeys[weekday]
+		day := defaults.Days[weekday]
+		settings = append(settings,
+			&config.Setting{
+				Key:         config.SettingMissingAlertArrivalPrefix + name,
+				Value:       day.ExpectedArrival,
+				Category:    "attendance",
+				Description: fmt.Sprintf("Expected arrival time (HH:MM) of students on %s", name),
+			},
+			&config.Setting{
+				Key:         config.SettingMissingAlertGracePrefix + name,
+				Value:       strconv.Itoa(day.GraceMinutes),
+				Category:    "attendance",
+				Description: fmt.Sprintf("Grace minutes after the expected arrival before missing students are reported on %s", name),
+			},
+		)
+	}
+	return settings
+}
+
+// GetMissingAlertRules retrieves the per-weekday arrival times and grace periods for missing student alerts
+func (s *service) GetMissingAlertRules(ctx context.Context) (*config.MissingAlertRules, error) {
+	rules := config.NewDefaultMissingAlertRules()
+	for weekday, day := range rules.Days {
+		name := config.MissingAlertWeekdayKeys[weekday]
+
+		arrival, err := s.GetStringValue(ctx, config.SettingMissingAlertArrivalPrefix+name, day.ExpectedArrival)
+		if err != nil {
+			return nil, &ConfigError{Op: "GetMissingAlertRules", Err: err}
+		}
+		grace, err := s.GetIntValue(ctx, config.SettingMissingAlertGracePrefix+name, day.GraceMinutes)
+		if err != nil {
+			return nil, &ConfigError{Op: "GetMissingAlertRules", Err: err}
+		}
+
+		day.ExpectedArrival = strings.TrimSpace(arrival)
+		day.GraceMinutes = grace
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, &ConfigError{Op: "GetMissingAlertRules", Err: fmt.Errorf("invalid missing alert rules: %w", err)}
+	}
+
+	return rules, nil
+}
+
 // GetDeviceTimeoutSettings retrieves timeout settings for a specific device
 func (s *service) GetDeviceTimeoutSettings(ctx context.Context, deviceID int64) (*config.TimeoutSettings, error) {
 	// Start with global settings
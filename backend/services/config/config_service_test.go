@@ -832,6 +832,44 @@ func TestConfigService_GetTimeoutSettings(t *testing.T) {
 	})
 }
 
+func TestConfigService_GetWorkTimeRules(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	service := setupConfigService(t, db)
+	ctx := context.Background()
+
+	t.Run("returns valid work time rules", func(t *testing.T) {
+		// ACT
+		rules, err := service.GetWorkTimeRules(ctx)
+
+		// ASSERT
+		require.NoError(t, err)
+		require.NotNil(t, rules)
+		assert.NoError(t, rules.Validate())
+		assert.Greater(t, rules.LongBreakThresholdMinutes, rules.BreakThresholdMinutes)
+	})
+}
+
+func TestConfigService_GetMissingAlertRules(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	service := setupConfigService(t, db)
+	ctx := context.Background()
+
+	t.Run("returns a rule for every school day", func(t *testing.T) {
+		// ACT
+		rules, err := service.GetMissingAlertRules(ctx)
+
+		// ASSERT
+		require.NoError(t, err)
+		require.NotNil(t, rules)
+		assert.NoError(t, rules.Validate())
+		assert.Len(t, rules.Days, 5)
+	})
+}
+
 func TestConfigService_UpdateTimeoutSettings(t *testing.T) {
 	db := testpkg.SetupTestDB(t)
 	defer func() { _ = db.Close() }()
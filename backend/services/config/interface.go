@@ -42,6 +42,12 @@ type Service interface {
 	UpdateTimeoutSettings(ctx context.Context, settings *config.TimeoutSettings) error
 	GetDeviceTimeoutSettings(ctx context.Context, deviceID int64) (*config.TimeoutSettings, error)
 
+	// Working time rules (ArbZG)
+	GetWorkTimeRules(ctx context.Context) (*config.WorkTimeRules, error)
+
+	// Missing student alert rules
+	GetMissingAlertRules(ctx context.Context) (*config.MissingAlertRules, error)
+
 	// Transaction support
 	// WithTx is already defined in base.TransactionalService
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/moto-nrw/project-phoenix/auth/authorize/permissions"
 	"github.com/moto-nrw/project-phoenix/auth/jwt"
 	"github.com/moto-nrw/project-phoenix/database/repositories"
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
 )
 
 // databaseService implements the DatabaseService interface
@@ -51,6 +52,18 @@ func (s *databaseService) GetStats(ctx context.Context) (*StatsResponse, error)
 	return response, nil
 }
 
+// GetEmailDeliveryStats returns outbox counts grouped by delivery type and status
+func (s *databaseService) GetEmailDeliveryStats(ctx context.Context) ([]*authModels.EmailDeliveryStats, error) {
+	stats, err := s.repos.EmailOutbox.GetDeliveryStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		stats = []*authModels.EmailDeliveryStats{}
+	}
+	return stats, nil
+}
+
 // checkUserPermission checks if user has any of the given permissions
 func checkUserPermission(claims jwt.AppClaims, requiredPerms ...string) bool {
 	for _, userPerm := range claims.Permissions {
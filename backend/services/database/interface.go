@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
 )
 
 // StatsResponse represents the database statistics with counts and permissions
@@ -39,5 +41,14 @@ type StatsGetter interface {
 	GetStats(ctx context.Context) (*StatsResponse, error)
 }
 
-// DatabaseService is an alias for backward compatibility (deprecated - use StatsGetter)
-type DatabaseService = StatsGetter
+// EmailDeliveryStatsGetter exposes the durable email outbox status
+type EmailDeliveryStatsGetter interface {
+	// GetEmailDeliveryStats returns outbox counts grouped by delivery type and status
+	GetEmailDeliveryStats(ctx context.Context) ([]*authModels.EmailDeliveryStats, error)
+}
+
+// DatabaseService combines the statistics operations exposed to the admin API
+type DatabaseService interface {
+	StatsGetter
+	EmailDeliveryStatsGetter
+}
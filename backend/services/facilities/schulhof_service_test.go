@@ -40,6 +40,7 @@ func setupSchulhofService(t *testing.T, db *bun.DB) facilitiesSvc.SchulhofServic
 		repoFactory.ActivitySupervisor,
 		repoFactory.StudentEnrollment,
 		repoFactory.ActiveGroup,
+		repoFactory.Dateframe,
 		db,
 	)
 	require.NoError(t, err)
@@ -375,7 +376,7 @@ func TestSchulhofService_ToggleSupervision_StartSuccess(t *testing.T) {
 	filter.Equal("name", constants.SchulhofActivityName)
 	options.Filter = filter
 	repoFactory := repositories.NewFactory(db)
-	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, db)
+	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, repoFactory.Dateframe, db)
 	groups, _ := activityService.ListGroups(ctx, options)
 	if len(groups) > 0 {
 		testpkg.CleanupActivityFixtures(t, db, groups[0].ID, groups[0].CategoryID)
@@ -420,7 +421,7 @@ func TestSchulhofService_ToggleSupervision_StopSuccess(t *testing.T) {
 	filter.Equal("name", constants.SchulhofActivityName)
 	options.Filter = filter
 	repoFactory := repositories.NewFactory(db)
-	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, db)
+	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, repoFactory.Dateframe, db)
 	groups, _ := activityService.ListGroups(ctx, options)
 	if len(groups) > 0 {
 		testpkg.CleanupActivityFixtures(t, db, groups[0].ID, groups[0].CategoryID)
@@ -563,7 +564,7 @@ func TestSchulhofService_GetOrCreateActiveGroup_Creates(t *testing.T) {
 	filter.Equal("name", constants.SchulhofActivityName)
 	options.Filter = filter
 	repoFactory := repositories.NewFactory(db)
-	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, db)
+	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, repoFactory.Dateframe, db)
 	groups, _ := activityService.ListGroups(ctx, options)
 	if len(groups) > 0 {
 		testpkg.CleanupActivityFixtures(t, db, groups[0].CategoryID)
@@ -601,7 +602,7 @@ func TestSchulhofService_GetOrCreateActiveGroup_ReturnsExisting(t *testing.T) {
 	filter.Equal("name", constants.SchulhofActivityName)
 	options.Filter = filter
 	repoFactory := repositories.NewFactory(db)
-	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, db)
+	activityService, _ := activitiesSvc.NewService(repoFactory.ActivityCategory, repoFactory.ActivityGroup, repoFactory.ActivitySchedule, repoFactory.ActivitySupervisor, repoFactory.StudentEnrollment, repoFactory.ActiveGroup, repoFactory.Dateframe, db)
 	groups, _ := activityService.ListGroups(ctx, options)
 	if len(groups) > 0 {
 		testpkg.CleanupActivityFixtures(t, db, groups[0].CategoryID)
@@ -26,6 +26,7 @@ import (
 	"github.com/moto-nrw/project-phoenix/services/feedback"
 	importService "github.com/moto-nrw/project-phoenix/services/import"
 	"github.com/moto-nrw/project-phoenix/services/iot"
+	"github.com/moto-nrw/project-phoenix/services/parent"
 	"github.com/moto-nrw/project-phoenix/services/platform"
 	"github.com/moto-nrw/project-phoenix/services/schedule"
 	"github.com/moto-nrw/project-phoenix/services/suggestions"
@@ -40,7 +41,12 @@ type Factory struct {
 	ActiveCleanup            active.CleanupService
 	WorkSession              active.WorkSessionService
 	StaffAbsence             active.StaffAbsenceService
+	StudentAbsence           active.StudentAbsenceService
+	MissingStudents          active.MissingStudentService
+	TimeBalance              active.TimeBalanceService
 	Activities               activities.ActivityService
+	ActivityAllocation       activities.AllocationService
+	AttendanceRegister       activities.AttendanceRegisterService
 	Education                education.Service
 	GradeTransition          education.GradeTransitionService
 	Facilities               facilities.Service
@@ -52,13 +58,21 @@ type Factory struct {
 	Config                   config.Service
 	Schedule                 schedule.Service
 	PickupSchedule           schedule.PickupScheduleService
+	CalendarFeed             schedule.CalendarFeedService
+	PickupBoard              active.PickupBoardService
+	BusRoute                 active.BusRouteService
+	StudentTimeline          active.StudentTimelineService
 	Users                    users.PersonService
 	Guardian                 users.GuardianService
+	PickupAuthorization      users.PickupAuthorizationService
+	ParentPortal             parent.Service
 	UserContext              usercontext.UserContextService
 	Database                 database.DatabaseService
 	Import                   *importService.ImportService[importModels.StudentImportRow] // Student import service
 	RealtimeHub              *realtime.Hub                                               // SSE event hub (shared by services and API)
 	Mailer                   email.Mailer
+	EmailDispatcher          *email.Dispatcher // Email dispatcher; durable once the scheduler enables EmailOutbox
+	EmailOutbox              email.OutboxStore // Outbox drained by the scheduler's email outbox worker
 	DefaultFrom              email.Email
 	FrontendURL              string
 	InvitationTokenExpiry    time.Duration
@@ -89,8 +103,10 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 	facilitiesLogger := logger.With("service", "facilities")
 	databaseLogger := logger.With("service", "database")
 	platformLogger := logger.With("service", "platform")
+	scheduleLogger := logger.With("service", "schedule")
 	emailLogger := logger.With("component", "email")
 
+	// Mail is sent directly until the server's scheduler enables the outbox it drains
 	dispatcher := email.NewDispatcher(mailer, emailLogger)
 
 	defaultFrom := email.NewEmail(viper.GetString("email_from_name"), viper.GetString("email_from_address"))
@@ -127,6 +143,10 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 
 	// Create realtime hub for SSE broadcasting (single shared instance)
 	realtimeHub := realtime.NewHub(logger.With("component", "sse-hub"))
+	if strings.EqualFold(viper.GetString("sse_broadcast_backend"), "postgres") {
+		// Multi-instance deployments: replicas exchange events via NOTIFY/LISTEN
+		realtimeHub.SetBackend(realtime.NewPostgresBackend(db, realtime.DefaultNotifyChannel, logger.With("component", "sse-backend")))
+	}
 
 	// Initialize education service first (needed for active service)
 	educationService := education.NewService(
@@ -157,6 +177,7 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		StaffRepo:          repos.Staff,
 		TeacherRepo:        repos.Teacher,
 		DB:                 db,
+		Broadcaster:        realtimeHub,
 	})
 
 	// Initialize guardian service
@@ -176,11 +197,71 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		DB:                      db,
 	})
 
+	// Initialize pickup authorization service (guardians plus extra pickup persons, verified at checkout)
+	pickupAuthorizationService := users.NewPickupAuthorizationService(users.PickupAuthorizationServiceDependencies{
+		PickupPersonRepo: repos.StudentPickupPerson,
+		Guardians:        guardianService,
+	})
+
+	// Initialize config service (before work session service - provides working time rules)
+	configService := config.NewService(
+		repos.Setting,
+		db,
+	)
+
 	// Initialize work session service (before active service - needed for NFC auto-check-in)
-	workSessionService := active.NewWorkSessionService(repos.WorkSession, repos.WorkSessionBreak, repos.WorkSessionEdit, repos.StaffAbsence, repos.GroupSupervisor, activeLogger)
+	workSessionService := active.NewWorkSessionService(active.WorkSessionServiceDependencies{
+		Repo:           repos.WorkSession,
+		BreakRepo:      repos.WorkSessionBreak,
+		AuditRepo:      repos.WorkSessionEdit,
+		AbsenceRepo:    repos.StaffAbsence,
+		SupervisorRepo: repos.GroupSupervisor,
+		StaffRepo:      repos.Staff,
+		RulesProvider:  configService,
+		Logger:         activeLogger,
+	})
 
 	// Initialize staff absence service
-	staffAbsenceService := active.NewStaffAbsenceService(repos.StaffAbsence, repos.WorkSession)
+	staffAbsenceService := active.NewStaffAbsenceService(active.StaffAbsenceServiceDependencies{
+		AbsenceRepo:     repos.StaffAbsence,
+		WorkSessionRepo: repos.WorkSession,
+		StaffRepo:       repos.Staff,
+		AccountRepo:     repos.Account,
+		Dispatcher:      dispatcher,
+		DefaultFrom:     defaultFrom,
+		FrontendURL:     frontendURL,
+
+		EducationService:  educationService,
+		TeacherRepo:       repos.Teacher,
+		ActivityGroupRepo: repos.ActivityGroup,
+		ScheduleRepo:      repos.ActivitySchedule,
+		SupervisorRepo:    repos.ActivitySupervisor,
+		SubstitutionRepo:  repos.GroupSubstitution,
+		GroupTeacherRepo:  repos.GroupTeacher,
+		DB:                db,
+	})
+
+	// Initialize student absence service (sick notes, trips, vacations)
+	studentAbsenceService := active.NewStudentAbsenceService(repos.StudentAbsence)
+
+	// Initialize missing student service (expected students who have not checked in)
+	missingStudentService := active.NewMissingStudentService(active.MissingStudentServiceDependencies{
+		AlertRepo:           repos.MissingStudentAlert,
+		AttendanceRepo:      repos.Attendance,
+		AbsenceRepo:         repos.StudentAbsence,
+		StudentRepo:         repos.Student,
+		PersonRepo:          repos.Person,
+		EducationGroupRepo:  repos.Group,
+		PickupScheduleRepo:  repos.StudentPickupSchedule,
+		PickupExceptionRepo: repos.StudentPickupException,
+		DateframeRepo:       repos.Dateframe,
+		RulesProvider:       configService,
+		Broadcaster:         realtimeHub,
+		Logger:              activeLogger,
+	})
+
+	// Initialize time balance service (contracted hours and overtime)
+	timeBalanceService := active.NewTimeBalanceService(repos.StaffContract, repos.WorkSession, repos.StaffAbsence, repos.Staff, repos.Dateframe)
 
 	// Initialize active service with SSE broadcaster
 	activeService := active.NewService(active.ServiceDependencies{
@@ -190,6 +271,7 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		CombinedGroupRepo:  repos.CombinedGroup,
 		GroupMappingRepo:   repos.GroupMapping,
 		AttendanceRepo:     repos.Attendance,
+		StudentAbsenceRepo: repos.StudentAbsence,
 		StudentRepo:        repos.Student,
 		PersonRepo:         repos.Person,
 		TeacherRepo:        repos.Teacher,
@@ -223,16 +305,15 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 	)
 
 	// Initialize IoT service
-	iotService := iot.NewService(
-		repos.Device,
-		db,
-	)
-
-	// Initialize config service
-	configService := config.NewService(
-		repos.Setting,
-		db,
-	)
+	iotService := iot.NewService(iot.ServiceDependencies{
+		DeviceRepo:           repos.Device,
+		DeviceEventRepo:      repos.DeviceEvent,
+		DeviceEnrollmentRepo: repos.DeviceEnrollment,
+		DeviceKeyEventRepo:   repos.DeviceKeyEvent,
+		ActiveGroupRepo:      repos.ActiveGroup,
+		Broadcaster:          realtimeHub,
+		DB:                   db,
+	})
 
 	// Initialize activities service
 	activitiesService, err := activities.NewService(
@@ -242,12 +323,35 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		repos.ActivitySupervisor,
 		repos.StudentEnrollment,
 		repos.ActiveGroup,
+		repos.Dateframe,
 		db,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// Initialize AG lottery (activity allocation) service
+	activityAllocationService := activities.NewAllocationService(activities.AllocationServiceDependencies{
+		AllocationRepo: repos.ActivityAllocation,
+		GroupRepo:      repos.ActivityGroup,
+		ScheduleRepo:   repos.ActivitySchedule,
+		EnrollmentRepo: repos.StudentEnrollment,
+		DB:             db,
+	})
+
+	// Initialize per-session activity attendance register service
+	attendanceRegisterService := activities.NewAttendanceRegisterService(activities.AttendanceRegisterServiceDependencies{
+		RegisterRepo:       repos.SessionRegister,
+		GroupRepo:          repos.ActivityGroup,
+		ScheduleRepo:       repos.ActivitySchedule,
+		EnrollmentRepo:     repos.StudentEnrollment,
+		ActiveGroupRepo:    repos.ActiveGroup,
+		VisitRepo:          repos.ActiveVisit,
+		StudentAbsenceRepo: repos.StudentAbsence,
+		DateframeRepo:      repos.Dateframe,
+		DB:                 db,
+	})
+
 	// Initialize facilities service
 	facilitiesService := facilities.NewService(
 		repos.Room,
@@ -272,14 +376,83 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		db,
 	)
 
+	// Initialize calendar feed service (ICS subscriptions of supervision plans and activity groups)
+	calendarFeedService := schedule.NewCalendarFeedService(schedule.CalendarFeedServiceDependencies{
+		FeedRepo:             repos.CalendarFeed,
+		DateframeRepo:        repos.Dateframe,
+		TimeframeRepo:        repos.Timeframe,
+		ActivityGroupRepo:    repos.ActivityGroup,
+		ActivityScheduleRepo: repos.ActivitySchedule,
+		SupervisorRepo:       repos.ActivitySupervisor,
+		SubstitutionRepo:     repos.GroupSubstitution,
+		EducationGroupRepo:   repos.Group,
+		StaffRepo:            repos.Staff,
+		AccountRepo:          repos.Account,
+		Logger:               scheduleLogger,
+	})
+
 	// Initialize pickup schedule service
 	pickupScheduleService := schedule.NewPickupScheduleService(
 		repos.StudentPickupSchedule,
 		repos.StudentPickupException,
 		repos.StudentPickupNote,
+		repos.Student,
+		repos.Person,
+		realtimeHub,
 		db,
 	)
 
+	// Initialize pickup board service (live dispatch board and overdue escalation)
+	pickupOverdueMinutes := viper.GetInt("pickup_overdue_minutes")
+	if pickupOverdueMinutes <= 0 {
+		pickupOverdueMinutes = 15
+	} else if pickupOverdueMinutes > 120 {
+		pickupOverdueMinutes = 120
+	}
+	pickupBoardService := active.NewPickupBoardService(active.PickupBoardServiceDependencies{
+		AttendanceRepo: repos.Attendance,
+		VisitRepo:      repos.ActiveVisit,
+		GroupRepo:      repos.ActiveGroup,
+		StudentRepo:    repos.Student,
+		PickupTimes:    pickupScheduleService,
+		NotifiedRepo:   repos.DispatchedNotification,
+		Broadcaster:    realtimeHub,
+		OverdueAfter:   time.Duration(pickupOverdueMinutes) * time.Minute,
+		Logger:         activeLogger,
+	})
+
+	// Initialize bus route service (departure reminders and bulk bus checkout)
+	busReminderMinutes := viper.GetInt("bus_reminder_minutes")
+	if busReminderMinutes <= 0 {
+		busReminderMinutes = 10
+	} else if busReminderMinutes > 60 {
+		busReminderMinutes = 60
+	}
+	busRouteService := active.NewBusRouteService(active.BusRouteServiceDependencies{
+		BusRouteRepo:   repos.BusRoute,
+		AttendanceRepo: repos.Attendance,
+		VisitRepo:      repos.ActiveVisit,
+		StudentRepo:    repos.Student,
+		NotifiedRepo:   repos.DispatchedNotification,
+		Checkout:       activeService,
+		Broadcaster:    realtimeHub,
+		ReminderLead:   time.Duration(busReminderMinutes) * time.Minute,
+		Logger:         activeLogger,
+		DB:             db,
+	})
+
+	// Initialize student timeline service (daily timeline across attendance, visits and feedback)
+	studentTimelineService := active.NewStudentTimelineService(active.StudentTimelineServiceDependencies{
+		AttendanceRepo:     repos.Attendance,
+		VisitRepo:          repos.ActiveVisit,
+		GroupRepo:          repos.ActiveGroup,
+		FeedbackRepo:       repos.FeedbackEntry,
+		PrivacyConsentRepo: repos.PrivacyConsent,
+		StaffRepo:          repos.Staff,
+		DeviceRepo:         repos.Device,
+		BusRouteRepo:       repos.BusRoute,
+	})
+
 	// Initialize auth service with validated config
 	authConfig, err := auth.NewServiceConfig(
 		dispatcher,
@@ -375,6 +548,23 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 	)
 	studentImportService := importService.NewImportService(studentImportConfig, db)
 
+	// Initialize parent portal service (guardian-facing, parent-scoped tokens)
+	parentPortalService, err := parent.NewService(parent.ServiceConfig{
+		AccountRepo:      repos.AccountParent,
+		GuardianProfiles: repos.GuardianProfile,
+		GuardianLinks:    guardianService,
+		StudentRepo:      repos.Student,
+		PersonRepo:       repos.Person,
+		Attendance:       activeService,
+		PickupExceptions: pickupScheduleService,
+		Sickness:         usersService,
+		Absences:         studentAbsenceService,
+		Logger:           logger.With("service", "parent"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parent portal service: %w", err)
+	}
+
 	// Initialize platform services (operator dashboard)
 	operatorAuthService, err := platform.NewOperatorAuthService(platform.OperatorAuthServiceConfig{
 		OperatorRepo: repos.Operator,
@@ -410,7 +600,12 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		ActiveCleanup:            activeCleanupService,
 		WorkSession:              workSessionService,
 		StaffAbsence:             staffAbsenceService,
+		StudentAbsence:           studentAbsenceService,
+		MissingStudents:          missingStudentService,
+		TimeBalance:              timeBalanceService,
 		Activities:               activitiesService,
+		ActivityAllocation:       activityAllocationService,
+		AttendanceRegister:       attendanceRegisterService,
 		Education:                educationService,
 		GradeTransition:          gradeTransitionService,
 		Facilities:               facilitiesService,
@@ -421,14 +616,22 @@ func NewFactory(repos *repositories.Factory, db *bun.DB, logger *slog.Logger) (*
 		Config:                   configService,
 		Schedule:                 scheduleService,
 		PickupSchedule:           pickupScheduleService,
+		CalendarFeed:             calendarFeedService,
+		PickupBoard:              pickupBoardService,
+		BusRoute:                 busRouteService,
+		StudentTimeline:          studentTimelineService,
 		Users:                    usersService,
 		Guardian:                 guardianService,
+		PickupAuthorization:      pickupAuthorizationService,
+		ParentPortal:             parentPortalService,
 		UserContext:              userContextService,
 		Database:                 databaseService,
 		Import:                   studentImportService, // Student import service
 		RealtimeHub:              realtimeHub,          // Expose SSE hub for API layer
 		Invitation:               invitationService,
 		Mailer:                   mailer,
+		EmailDispatcher:          dispatcher,
+		EmailOutbox:              repos.EmailOutbox,
 		DefaultFrom:              defaultFrom,
 		FrontendURL:              frontendURL,
 		InvitationTokenExpiry:    invitationTokenExpiry,
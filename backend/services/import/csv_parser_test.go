@@ -53,7 +53,7 @@ Max,Mustermann,1A,Maria,Müller,maria@example.com,0123-456789,Mutter,Ja`
 	assert.Equal(t, "maria@example.com", guardian.Email)
 	assert.Equal(t, "0123-456789", guardian.Phone)
 	assert.Equal(t, "Mutter", guardian.RelationshipType)
-	assert.True(t, guardian.IsPrimary)
+	assert.True(t, boolValue(guardian.IsPrimary))
 }
 
 func TestCSVParser_ParseStudents_MultipleGuardians(t *testing.T) {
@@ -72,12 +72,12 @@ Max,Mustermann,1A,maria@example.com,111,Ja,hans@example.com,222,Nein,oma@example
 	// Guardian 1
 	assert.Equal(t, "maria@example.com", rows[0].Guardians[0].Email)
 	assert.Equal(t, "111", rows[0].Guardians[0].Phone)
-	assert.True(t, rows[0].Guardians[0].IsPrimary)
+	assert.True(t, boolValue(rows[0].Guardians[0].IsPrimary))
 
 	// Guardian 2
 	assert.Equal(t, "hans@example.com", rows[0].Guardians[1].Email)
 	assert.Equal(t, "222", rows[0].Guardians[1].Phone)
-	assert.False(t, rows[0].Guardians[1].IsPrimary)
+	assert.False(t, boolValue(rows[0].Guardians[1].IsPrimary))
 
 	// Guardian 3 (email only)
 	assert.Equal(t, "oma@example.com", rows[0].Guardians[2].Email)
@@ -139,9 +139,9 @@ Lisa,Muster,4D,JA,NEIN,JA,test4@example.com`
 
 	for _, tt := range tests {
 		assert.Equal(t, tt.privacyAccepted, rows[tt.rowIdx].PrivacyAccepted, "Row %d privacy", tt.rowIdx)
-		assert.Equal(t, tt.busPermission, rows[tt.rowIdx].BusPermission, "Row %d bus", tt.rowIdx)
+		assert.Equal(t, tt.busPermission, boolValue(rows[tt.rowIdx].BusPermission), "Row %d bus", tt.rowIdx)
 		if len(rows[tt.rowIdx].Guardians) > 0 {
-			assert.Equal(t, tt.guardianPrimary, rows[tt.rowIdx].Guardians[0].IsPrimary, "Row %d guardian primary", tt.rowIdx)
+			assert.Equal(t, tt.guardianPrimary, boolValue(rows[tt.rowIdx].Guardians[0].IsPrimary), "Row %d guardian primary", tt.rowIdx)
 		}
 	}
 }
@@ -300,9 +300,9 @@ Max,Mustermann,1A,Maria,Müller,maria@example.com,0123-456789,0176-12345678,Mutt
 	assert.Equal(t, "0123-456789", guardian.Phone)
 	assert.Equal(t, "0176-12345678", guardian.MobilePhone)
 	assert.Equal(t, "Mutter", guardian.RelationshipType)
-	assert.True(t, guardian.IsPrimary)
-	assert.True(t, guardian.IsEmergencyContact)
-	assert.True(t, guardian.CanPickup)
+	assert.True(t, boolValue(guardian.IsPrimary))
+	assert.True(t, boolValue(guardian.IsEmergencyContact))
+	assert.True(t, boolValue(guardian.CanPickup))
 }
 
 func TestCSVParser_ParseStudents_PartialGuardianData(t *testing.T) {
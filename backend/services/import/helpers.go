@@ -75,6 +75,16 @@ func ParseBool(val string) bool {
 	return normalized == "ja" || normalized == "yes" || normalized == "true" || normalized == "1"
 }
 
+// ParseOptionalBool parses a German boolean value, returning nil for a blank cell or missing
+// column so imports can tell "not provided" from "Nein"
+func ParseOptionalBool(val string) *bool {
+	if strings.TrimSpace(val) == "" {
+		return nil
+	}
+	parsed := ParseBool(val)
+	return &parsed
+}
+
 // MapStudentRow maps column values to StudentImportRow using the shared mapping logic
 func MapStudentRow(mapper *ColumnMapper) (importModels.StudentImportRow, error) {
 	row := importModels.StudentImportRow{
@@ -92,7 +102,7 @@ func MapStudentRow(mapper *ColumnMapper) (importModels.StudentImportRow, error)
 	row.SupervisorNotes = mapper.GetCol("betreuernotizen")
 	row.ExtraInfo = mapper.GetCol("zusatzinfo")
 	row.PickupStatus = mapper.GetCol("abholstatus")
-	row.BusPermission = ParseBool(mapper.GetCol("bus"))
+	row.BusPermission = ParseOptionalBool(mapper.GetCol("bus"))
 
 	// Privacy consent
 	row.PrivacyAccepted = ParseBool(mapper.GetCol("datenschutz"))
@@ -123,9 +133,9 @@ func MapStudentRow(mapper *ColumnMapper) (importModels.StudentImportRow, error)
 			Phone:              mapper.GetRawCol(phoneKey),
 			MobilePhone:        mapper.GetRawCol(mobileKey),
 			RelationshipType:   mapper.GetCol(fmt.Sprintf("erz%d.verhältnis", guardianNum)),
-			IsPrimary:          ParseBool(mapper.GetCol(fmt.Sprintf("erz%d.primär", guardianNum))),
-			IsEmergencyContact: ParseBool(mapper.GetCol(fmt.Sprintf("erz%d.notfall", guardianNum))),
-			CanPickup:          ParseBool(mapper.GetCol(fmt.Sprintf("erz%d.abholung", guardianNum))),
+			IsPrimary:          ParseOptionalBool(mapper.GetCol(fmt.Sprintf("erz%d.primär", guardianNum))),
+			IsEmergencyContact: ParseOptionalBool(mapper.GetCol(fmt.Sprintf("erz%d.notfall", guardianNum))),
+			CanPickup:          ParseOptionalBool(mapper.GetCol(fmt.Sprintf("erz%d.abholung", guardianNum))),
 		}
 
 		// Parse flexible phone numbers into PhoneNumbers array
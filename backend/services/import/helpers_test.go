@@ -255,7 +255,7 @@ func TestMapStudentRow(t *testing.T) {
 		assert.Equal(t, "Notes here", row.SupervisorNotes)
 		assert.Equal(t, "Extra info", row.ExtraInfo)
 		assert.Equal(t, "Authorized", row.PickupStatus)
-		assert.True(t, row.BusPermission)
+		assert.True(t, boolValue(row.BusPermission))
 		assert.Equal(t, 7, row.DataRetentionDays)
 	})
 
@@ -319,9 +319,9 @@ func TestMapStudentRow(t *testing.T) {
 		assert.Equal(t, "+4912345", guardian.Phone)
 		assert.Equal(t, "+4967890", guardian.MobilePhone)
 		assert.Equal(t, "Vater", guardian.RelationshipType)
-		assert.True(t, guardian.IsPrimary)
-		assert.True(t, guardian.IsEmergencyContact)
-		assert.True(t, guardian.CanPickup)
+		assert.True(t, boolValue(guardian.IsPrimary))
+		assert.True(t, boolValue(guardian.IsEmergencyContact))
+		assert.True(t, boolValue(guardian.CanPickup))
 	})
 
 	t.Run("maps multiple guardians", func(t *testing.T) {
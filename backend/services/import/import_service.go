@@ -76,7 +76,7 @@ func (s *ImportService[T]) processImportRow(ctx context.Context, request importM
 	}
 
 	if request.DryRun {
-		return s.processDryRunRow(ctx, result, row, rowNum)
+		return s.processDryRunRow(ctx, request, result, row, rowNum)
 	}
 
 	return s.processActualImportRow(ctx, request, result, row, rowNum)
@@ -120,18 +120,32 @@ func recordBlockingErrors[T any](result *importModels.ImportResult[T], rowNum in
 	result.ErrorCount++
 }
 
-// processDryRunRow processes a row in dry run mode
-func (s *ImportService[T]) processDryRunRow(ctx context.Context, result *importModels.ImportResult[T], row *T, rowNum int) bool {
+// processDryRunRow processes a row in dry run mode, reporting what the actual import would do
+func (s *ImportService[T]) processDryRunRow(ctx context.Context, request importModels.ImportRequest[T], result *importModels.ImportResult[T], row *T, rowNum int) bool {
 	existingID, err := s.config.FindExisting(ctx, *row)
 	if err != nil {
 		recordDuplicateCheckError(result, rowNum, row, err)
 		return false
 	}
 
-	if existingID != nil {
-		result.UpdatedCount++
-	} else {
+	action, shouldSkip := s.determineImportAction(request, result, row, rowNum, existingID)
+	if shouldSkip {
+		return false
+	}
+
+	if action == "create" {
 		result.CreatedCount++
+		return false
+	}
+
+	unchanged, err := s.diffExisting(ctx, result, row, rowNum, *existingID)
+	switch {
+	case err != nil:
+		recordUpdateError(result, rowNum, row, err)
+	case unchanged:
+		result.SkippedCount++
+	default:
+		result.UpdatedCount++
 	}
 
 	return false
@@ -197,8 +211,18 @@ func (s *ImportService[T]) performCreateAction(ctx context.Context, request impo
 	return false
 }
 
-// performUpdateAction performs the update operation
+// performUpdateAction performs the update operation; rows without changes are skipped
 func (s *ImportService[T]) performUpdateAction(ctx context.Context, request importModels.ImportRequest[T], result *importModels.ImportResult[T], row *T, rowNum int, existingID *int64) bool {
+	unchanged, err := s.diffExisting(ctx, result, row, rowNum, *existingID)
+	if err != nil {
+		recordUpdateError(result, rowNum, row, err)
+		return request.StopOnError
+	}
+	if unchanged {
+		result.SkippedCount++
+		return false
+	}
+
 	if err := s.config.Update(ctx, *existingID, *row); err != nil {
 		recordUpdateError(result, rowNum, row, err)
 		return request.StopOnError
@@ -207,6 +231,30 @@ func (s *ImportService[T]) performUpdateAction(ctx context.Context, request impo
 	return false
 }
 
+// diffExisting records the field changes for an existing entity when the config supports diffs.
+// It reports unchanged=true when the config found nothing to update.
+func (s *ImportService[T]) diffExisting(ctx context.Context, result *importModels.ImportResult[T], row *T, rowNum int, id int64) (bool, error) {
+	differ, ok := s.config.(importModels.ImportDiffer[T])
+	if !ok {
+		return false, nil
+	}
+
+	changes, err := differ.Diff(ctx, id, *row)
+	if err != nil {
+		return false, err
+	}
+	if len(changes) == 0 {
+		return true, nil
+	}
+
+	result.Diffs = append(result.Diffs, importModels.RowDiff{
+		RowNumber: rowNum,
+		EntityID:  id,
+		Changes:   changes,
+	})
+	return false, nil
+}
+
 // recordDuplicateCheckError records a duplicate check error
 func recordDuplicateCheckError[T any](result *importModels.ImportResult[T], rowNum int, row *T, err error) {
 	result.Errors = append(result.Errors, importModels.ImportError[T]{
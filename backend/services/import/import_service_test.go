@@ -581,3 +581,144 @@ func TestImportService_DryRunDuplicateCheckError(t *testing.T) {
 		assert.Equal(t, "duplicate_check_failed", result.Errors[0].Errors[0].Code)
 	})
 }
+
+// ============================================================================
+// Update Diff Tests
+// ============================================================================
+
+// mockDiffingImportConfig adds ImportDiffer support to mockImportConfig
+type mockDiffingImportConfig struct {
+	mockImportConfig
+	changes     []importModels.FieldChange
+	diffErr     error
+	updateCalls int
+}
+
+func (m *mockDiffingImportConfig) Diff(_ context.Context, _ int64, _ testRow) ([]importModels.FieldChange, error) {
+	return m.changes, m.diffErr
+}
+
+func (m *mockDiffingImportConfig) Update(_ context.Context, _ int64, _ testRow) error {
+	m.updateCalls++
+	return m.updateErr
+}
+
+func TestImportService_UpdateDiffs(t *testing.T) {
+	ctx := context.Background()
+	existingID := int64(42)
+	changes := []importModels.FieldChange{{Field: "school_class", OldValue: "3a", NewValue: "4a"}}
+
+	t.Run("dry run reports planned changes without updating", func(t *testing.T) {
+		// ARRANGE
+		config := &mockDiffingImportConfig{
+			mockImportConfig: mockImportConfig{findExistingID: &existingID},
+			changes:          changes,
+		}
+		service := NewImportService[testRow](config, nil)
+		request := importModels.ImportRequest[testRow]{
+			Rows:   []testRow{{Name: "test"}},
+			Mode:   importModels.ImportModeUpsert,
+			DryRun: true,
+		}
+
+		// ACT
+		result, err := service.Import(ctx, request)
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.UpdatedCount)
+		assert.Equal(t, 0, config.updateCalls)
+		require.Len(t, result.Diffs, 1)
+		assert.Equal(t, 2, result.Diffs[0].RowNumber) // Row 1 is the header
+		assert.Equal(t, existingID, result.Diffs[0].EntityID)
+		assert.Equal(t, changes, result.Diffs[0].Changes)
+	})
+
+	t.Run("dry run in create mode reports existing entity as error", func(t *testing.T) {
+		// ARRANGE
+		config := &mockDiffingImportConfig{
+			mockImportConfig: mockImportConfig{findExistingID: &existingID},
+			changes:          changes,
+		}
+		service := NewImportService[testRow](config, nil)
+		request := importModels.ImportRequest[testRow]{
+			Rows:   []testRow{{Name: "test"}},
+			Mode:   importModels.ImportModeCreate,
+			DryRun: true,
+		}
+
+		// ACT
+		result, err := service.Import(ctx, request)
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.UpdatedCount)
+		assert.Equal(t, 1, result.ErrorCount)
+		assert.Empty(t, result.Diffs)
+	})
+
+	t.Run("skips unchanged rows", func(t *testing.T) {
+		// ARRANGE
+		config := &mockDiffingImportConfig{
+			mockImportConfig: mockImportConfig{findExistingID: &existingID},
+		}
+		service := NewImportService[testRow](config, nil)
+		request := importModels.ImportRequest[testRow]{
+			Rows: []testRow{{Name: "test"}},
+			Mode: importModels.ImportModeUpdate,
+		}
+
+		// ACT
+		result, err := service.Import(ctx, request)
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.SkippedCount)
+		assert.Equal(t, 0, result.UpdatedCount)
+		assert.Equal(t, 0, config.updateCalls)
+		assert.Empty(t, result.Diffs)
+	})
+
+	t.Run("updates changed rows and records diff", func(t *testing.T) {
+		// ARRANGE
+		config := &mockDiffingImportConfig{
+			mockImportConfig: mockImportConfig{findExistingID: &existingID},
+			changes:          changes,
+		}
+		service := NewImportService[testRow](config, nil)
+		request := importModels.ImportRequest[testRow]{
+			Rows: []testRow{{Name: "test"}},
+			Mode: importModels.ImportModeUpdate,
+		}
+
+		// ACT
+		result, err := service.Import(ctx, request)
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.UpdatedCount)
+		assert.Equal(t, 1, config.updateCalls)
+		require.Len(t, result.Diffs, 1)
+	})
+
+	t.Run("records diff failure as update error", func(t *testing.T) {
+		// ARRANGE
+		config := &mockDiffingImportConfig{
+			mockImportConfig: mockImportConfig{findExistingID: &existingID},
+			diffErr:          errors.New("load failed"),
+		}
+		service := NewImportService[testRow](config, nil)
+		request := importModels.ImportRequest[testRow]{
+			Rows: []testRow{{Name: "test"}},
+			Mode: importModels.ImportModeUpsert,
+		}
+
+		// ACT
+		result, err := service.Import(ctx, request)
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.ErrorCount)
+		assert.Equal(t, 0, config.updateCalls)
+	})
+}
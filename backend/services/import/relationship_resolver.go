@@ -129,6 +129,19 @@ func (r *RelationshipResolver) ResolveGroup(_ context.Context, groupName string)
 	)
 }
 
+// GroupName returns the name of a preloaded group, or an empty string if the ID is unknown
+func (r *RelationshipResolver) GroupName(groupID *int64) string {
+	if groupID == nil {
+		return ""
+	}
+	for _, group := range r.groupCache {
+		if group.ID == *groupID {
+			return group.Name
+		}
+	}
+	return ""
+}
+
 // ResolveRoom resolves human-readable room name to ID with fuzzy matching
 func (r *RelationshipResolver) ResolveRoom(_ context.Context, roomName string) (*int64, []importModels.ValidationError) {
 	return r.resolveEntity(
@@ -308,17 +308,36 @@ func (c *StudentImportConfig) FindExisting(ctx context.Context, row importModels
 		return nil, err
 	}
 
-	if len(students) == 0 {
+	if len(students) == 1 {
+		return &students[0].ID, nil
+	}
+
+	if len(students) > 1 {
+		// Multiple matches - ambiguous
+		return nil, fmt.Errorf("mehrere Schüler gefunden mit Name '%s %s' in Klasse '%s'",
+			row.FirstName, row.LastName, row.SchoolClass)
+	}
+
+	// Fallback: the class changes every school year, so match by name + birthday
+	birthday, err := parseOptionalDate(row.Birthday)
+	if err != nil || birthday == nil {
 		return nil, nil // No existing student
 	}
 
-	if len(students) == 1 {
-		return &students[0].ID, nil
+	students, err = c.studentRepo.FindByNameAndBirthday(ctx, row.FirstName, row.LastName, *birthday)
+	if err != nil {
+		return nil, err
 	}
 
-	// Multiple matches - ambiguous
-	return nil, fmt.Errorf("mehrere Schüler gefunden mit Name '%s %s' in Klasse '%s'",
-		row.FirstName, row.LastName, row.SchoolClass)
+	switch len(students) {
+	case 0:
+		return nil, nil // No existing student
+	case 1:
+		return &students[0].ID, nil
+	default:
+		return nil, fmt.Errorf("mehrere Schüler gefunden mit Name '%s %s' und Geburtsdatum '%s'",
+			row.FirstName, row.LastName, row.Birthday)
+	}
 }
 
 // Create creates a new student with all related entities
@@ -366,6 +385,7 @@ func (c *StudentImportConfig) createPersonFromRow(ctx context.Context, row impor
 
 // createStudentFromRow creates a student from person and row
 func (c *StudentImportConfig) createStudentFromRow(ctx context.Context, personID int64, row importModels.StudentImportRow) (*users.Student, error) {
+	bus := boolValue(row.BusPermission)
 	student := &users.Student{
 		PersonID:        personID,
 		SchoolClass:     strings.TrimSpace(row.SchoolClass),
@@ -374,6 +394,7 @@ func (c *StudentImportConfig) createStudentFromRow(ctx context.Context, personID
 		SupervisorNotes: stringPtr(row.SupervisorNotes),
 		HealthInfo:      stringPtr(row.HealthInfo),
 		PickupStatus:    stringPtr(row.PickupStatus),
+		Bus:             &bus,
 	}
 
 	if err := c.studentRepo.Create(ctx, student); err != nil {
@@ -404,9 +425,9 @@ func (c *StudentImportConfig) createSingleGuardianRelationship(ctx context.Conte
 		StudentID:          studentID,
 		GuardianProfileID:  guardianID,
 		RelationshipType:   mapRelationshipType(guardianData.RelationshipType),
-		IsPrimary:          guardianData.IsPrimary,
-		IsEmergencyContact: guardianData.IsEmergencyContact,
-		CanPickup:          guardianData.CanPickup,
+		IsPrimary:          boolValue(guardianData.IsPrimary),
+		IsEmergencyContact: boolValue(guardianData.IsEmergencyContact),
+		CanPickup:          boolValue(guardianData.CanPickup),
 	}
 
 	if err := c.relationRepo.Create(ctx, relationship); err != nil {
@@ -482,8 +503,8 @@ func (c *StudentImportConfig) createOrFindGuardian(ctx context.Context, data imp
 			}
 		} else if existing != nil {
 			// Guardian found - reuse it (deduplication)
-			// But still add any new phone numbers from the import data
-			if err := c.createGuardianPhoneNumbers(ctx, existing.ID, data.PhoneNumbers); err != nil {
+			// But still add phone numbers from the import data that are not stored yet
+			if err := c.addMissingGuardianPhoneNumbers(ctx, existing.ID, data.PhoneNumbers); err != nil {
 				// Log but don't fail - phone numbers are additive
 				// Duplicates will be handled gracefully
 				return existing.ID, fmt.Errorf("add phone numbers to existing guardian: %w", err)
@@ -558,11 +579,6 @@ func mapPhoneType(importType string) users.PhoneType {
 	}
 }
 
-// Update updates an existing student (not implemented for MVP - see #556 for Phase 2)
-func (c *StudentImportConfig) Update(_ context.Context, _ int64, _ importModels.StudentImportRow) error {
-	return fmt.Errorf("update mode not supported in MVP - use create-only mode or manually update students")
-}
-
 // EntityName returns the entity type name
 func (c *StudentImportConfig) EntityName() string {
 	return "student"
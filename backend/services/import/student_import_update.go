@@ -0,0 +1,419 @@
+package importpkg
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	importModels "github.com/moto-nrw/project-phoenix/models/import"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/uptrace/bun"
+)
+
+// Update rules for re-imports:
+//   - school class and bus flag always take the imported value
+//   - optional fields (group, birthday, notes, pickup status) only change when the cell is filled
+//   - guardians are matched by email, or by name when no email is given; new ones are linked,
+//     existing links get their relationship flags updated and missing phone numbers added
+//   - guardians missing from the row are never unlinked
+
+// linkedGuardian is a guardian currently linked to the student being updated
+type linkedGuardian struct {
+	profile  *users.GuardianProfile
+	relation *users.StudentGuardian
+	phones   []*users.GuardianPhoneNumber
+}
+
+// studentSnapshot is the stored state an import row is compared against
+type studentSnapshot struct {
+	person    *users.Person
+	student   *users.Student
+	guardians []*linkedGuardian
+}
+
+// guardianUpdate describes what happens to one guardian of the import row
+type guardianUpdate struct {
+	index           int
+	data            importModels.GuardianImportData
+	existing        *linkedGuardian // nil: guardian is not linked yet
+	profileChanged  bool
+	relationChanged bool
+	newPhones       []importModels.PhoneImportData
+}
+
+// studentUpdatePlan collects the changes an import row applies to an existing student
+type studentUpdatePlan struct {
+	snapshot       *studentSnapshot
+	personChanged  bool
+	studentChanged bool
+	guardians      []guardianUpdate
+	changes        []importModels.FieldChange
+}
+
+func (p *studentUpdatePlan) record(field, oldValue, newValue string) {
+	p.changes = append(p.changes, importModels.FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+}
+
+// Diff returns the field changes an update with the row would apply, without writing anything
+func (c *StudentImportConfig) Diff(ctx context.Context, studentID int64, row importModels.StudentImportRow) ([]importModels.FieldChange, error) {
+	snapshot, err := c.loadStudentSnapshot(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return buildStudentUpdatePlan(snapshot, row, c.resolver.GroupName).changes, nil
+}
+
+// Update applies the import row to an existing student field by field
+func (c *StudentImportConfig) Update(ctx context.Context, studentID int64, row importModels.StudentImportRow) error {
+	return c.txHandler.RunInTx(ctx, func(txCtx context.Context, _ bun.Tx) error {
+		snapshot, err := c.loadStudentSnapshot(txCtx, studentID)
+		if err != nil {
+			return err
+		}
+
+		plan := buildStudentUpdatePlan(snapshot, row, c.resolver.GroupName)
+		return c.applyStudentUpdatePlan(txCtx, studentID, plan)
+	})
+}
+
+// loadStudentSnapshot loads the student with person, linked guardians and their phone numbers
+func (c *StudentImportConfig) loadStudentSnapshot(ctx context.Context, studentID int64) (*studentSnapshot, error) {
+	student, err := c.studentRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("load student: %w", err)
+	}
+
+	person, err := c.personRepo.FindByID(ctx, student.PersonID)
+	if err != nil {
+		return nil, fmt.Errorf("load person: %w", err)
+	}
+
+	relations, err := c.relationRepo.FindByStudentID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("load guardian relationships: %w", err)
+	}
+
+	snapshot := &studentSnapshot{person: person, student: student}
+	for _, relation := range relations {
+		profile, err := c.guardianRepo.FindByID(ctx, relation.GuardianProfileID)
+		if err != nil {
+			return nil, fmt.Errorf("load guardian %d: %w", relation.GuardianProfileID, err)
+		}
+
+		phones, err := c.guardianPhoneRepo.FindByGuardianID(ctx, profile.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load phone numbers for guardian %d: %w", profile.ID, err)
+		}
+
+		snapshot.guardians = append(snapshot.guardians, &linkedGuardian{profile: profile, relation: relation, phones: phones})
+	}
+
+	return snapshot, nil
+}
+
+// buildStudentUpdatePlan compares the snapshot with the row and applies the changes to the snapshot's models
+func buildStudentUpdatePlan(snapshot *studentSnapshot, row importModels.StudentImportRow, groupName func(*int64) string) *studentUpdatePlan {
+	plan := &studentUpdatePlan{snapshot: snapshot}
+
+	planPersonChanges(plan, row)
+	planStudentChanges(plan, row, groupName)
+
+	matched := make(map[*linkedGuardian]bool)
+	for i, data := range row.Guardians {
+		existing := matchLinkedGuardian(snapshot.guardians, data)
+		if existing != nil && matched[existing] {
+			continue // Same guardian listed twice in one row
+		}
+		if existing != nil {
+			matched[existing] = true
+		}
+		planGuardianChanges(plan, i+1, data, existing)
+	}
+
+	return plan
+}
+
+// planPersonChanges compares person fields (names are the match key and never change)
+func planPersonChanges(plan *studentUpdatePlan, row importModels.StudentImportRow) {
+	person := plan.snapshot.person
+
+	birthday, _ := parseOptionalDate(row.Birthday)
+	if birthday != nil && (person.Birthday == nil || !person.Birthday.Equal(*birthday)) {
+		plan.record("birthday", formatOptionalDate(person), row.Birthday)
+		person.Birthday = birthday
+		plan.personChanged = true
+	}
+}
+
+// formatOptionalDate formats a person's birthday for diffs
+func formatOptionalDate(person *users.Person) string {
+	if person.Birthday == nil {
+		return ""
+	}
+	return person.Birthday.Format("2006-01-02")
+}
+
+// planStudentChanges compares class, group, bus flag and the optional text fields
+func planStudentChanges(plan *studentUpdatePlan, row importModels.StudentImportRow, groupName func(*int64) string) {
+	student := plan.snapshot.student
+
+	if schoolClass := strings.TrimSpace(row.SchoolClass); schoolClass != "" && schoolClass != student.SchoolClass {
+		plan.record("school_class", student.SchoolClass, schoolClass)
+		student.SchoolClass = schoolClass
+		plan.studentChanged = true
+	}
+
+	if row.GroupID != nil && (student.GroupID == nil || *student.GroupID != *row.GroupID) {
+		plan.record("group", groupName(student.GroupID), row.GroupName)
+		groupID := *row.GroupID
+		student.GroupID = &groupID
+		plan.studentChanged = true
+	}
+
+	currentBus := boolValue(student.Bus)
+	if row.BusPermission != nil && *row.BusPermission != currentBus {
+		plan.record("bus", strconv.FormatBool(currentBus), strconv.FormatBool(*row.BusPermission))
+		bus := *row.BusPermission
+		student.Bus = &bus
+		plan.studentChanged = true
+	}
+
+	optionalFields := []struct {
+		field  string
+		value  string
+		target **string
+	}{
+		{"extra_info", row.ExtraInfo, &student.ExtraInfo},
+		{"supervisor_notes", row.SupervisorNotes, &student.SupervisorNotes},
+		{"health_info", row.HealthInfo, &student.HealthInfo},
+		{"pickup_status", row.PickupStatus, &student.PickupStatus},
+	}
+	for _, f := range optionalFields {
+		newValue := stringPtr(f.value)
+		if newValue == nil || (*f.target != nil && **f.target == *newValue) {
+			continue
+		}
+		plan.record(f.field, derefString(*f.target), *newValue)
+		*f.target = newValue
+		plan.studentChanged = true
+	}
+}
+
+// matchLinkedGuardian finds an already linked guardian by email, or by full name when no email is given
+func matchLinkedGuardian(linked []*linkedGuardian, data importModels.GuardianImportData) *linkedGuardian {
+	email := strings.TrimSpace(data.Email)
+	firstName := strings.TrimSpace(data.FirstName)
+	lastName := strings.TrimSpace(data.LastName)
+
+	for _, g := range linked {
+		if email != "" {
+			if g.profile.Email != nil && strings.EqualFold(*g.profile.Email, email) {
+				return g
+			}
+			continue
+		}
+		if firstName != "" && lastName != "" &&
+			strings.EqualFold(g.profile.FirstName, firstName) && strings.EqualFold(g.profile.LastName, lastName) {
+			return g
+		}
+	}
+	return nil
+}
+
+// planGuardianChanges compares one guardian of the row with its linked counterpart
+func planGuardianChanges(plan *studentUpdatePlan, index int, data importModels.GuardianImportData, existing *linkedGuardian) {
+	prefix := fmt.Sprintf("guardian_%d", index)
+	update := guardianUpdate{index: index, data: data, existing: existing}
+
+	if existing == nil {
+		plan.record(prefix, "", guardianLabel(data))
+		plan.guardians = append(plan.guardians, update)
+		return
+	}
+
+	profile := existing.profile
+	if firstName := strings.TrimSpace(data.FirstName); firstName != "" && firstName != profile.FirstName {
+		plan.record(prefix+"_first_name", profile.FirstName, firstName)
+		profile.FirstName = firstName
+		update.profileChanged = true
+	}
+	if lastName := strings.TrimSpace(data.LastName); lastName != "" && lastName != profile.LastName {
+		plan.record(prefix+"_last_name", profile.LastName, lastName)
+		profile.LastName = lastName
+		update.profileChanged = true
+	}
+
+	relation := existing.relation
+	if data.RelationshipType != "" {
+		if relationshipType := mapRelationshipType(data.RelationshipType); relationshipType != relation.RelationshipType {
+			plan.record(prefix+"_relationship_type", relation.RelationshipType, relationshipType)
+			relation.RelationshipType = relationshipType
+			update.relationChanged = true
+		}
+	}
+
+	flags := []struct {
+		field  string
+		value  *bool
+		target *bool
+	}{
+		{"_is_primary", data.IsPrimary, &relation.IsPrimary},
+		{"_is_emergency_contact", data.IsEmergencyContact, &relation.IsEmergencyContact},
+		{"_can_pickup", data.CanPickup, &relation.CanPickup},
+	}
+	for _, f := range flags {
+		if f.value == nil || *f.target == *f.value {
+			continue
+		}
+		plan.record(prefix+f.field, strconv.FormatBool(*f.target), strconv.FormatBool(*f.value))
+		*f.target = *f.value
+		update.relationChanged = true
+	}
+
+	update.newPhones = missingPhoneNumbers(existing.phones, data.PhoneNumbers)
+	for _, phone := range update.newPhones {
+		plan.record(prefix+"_phone", "", phone.PhoneNumber)
+	}
+
+	if update.profileChanged || update.relationChanged || len(update.newPhones) > 0 {
+		plan.guardians = append(plan.guardians, update)
+	}
+}
+
+// guardianLabel describes a guardian in diffs
+func guardianLabel(data importModels.GuardianImportData) string {
+	name := strings.TrimSpace(strings.TrimSpace(data.FirstName) + " " + strings.TrimSpace(data.LastName))
+	email := strings.TrimSpace(data.Email)
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
+		return name
+	default:
+		return email
+	}
+}
+
+// missingPhoneNumbers returns the imported phone numbers not yet stored for the guardian
+func missingPhoneNumbers(stored []*users.GuardianPhoneNumber, imported []importModels.PhoneImportData) []importModels.PhoneImportData {
+	known := make(map[string]bool, len(stored))
+	for _, phone := range stored {
+		known[normalizePhoneNumber(phone.PhoneNumber)] = true
+	}
+
+	var missing []importModels.PhoneImportData
+	for _, phone := range imported {
+		normalized := normalizePhoneNumber(phone.PhoneNumber)
+		if normalized == "" || known[normalized] {
+			continue
+		}
+		known[normalized] = true
+		missing = append(missing, phone)
+	}
+	return missing
+}
+
+// normalizePhoneNumber strips formatting so "0221-123 45" and "022112345" compare equal
+func normalizePhoneNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '+' {
+			return r
+		}
+		return -1
+	}, number)
+}
+
+// boolValue returns the value of an optional flag, treating nil as false
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+// derefString returns the string value or an empty string for nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// applyStudentUpdatePlan writes the planned changes
+func (c *StudentImportConfig) applyStudentUpdatePlan(ctx context.Context, studentID int64, plan *studentUpdatePlan) error {
+	if plan.personChanged {
+		if err := c.personRepo.Update(ctx, plan.snapshot.person); err != nil {
+			return fmt.Errorf("update person: %w", err)
+		}
+	}
+
+	if plan.studentChanged {
+		if err := c.studentRepo.Update(ctx, plan.snapshot.student); err != nil {
+			return fmt.Errorf("update student: %w", err)
+		}
+	}
+
+	for _, update := range plan.guardians {
+		if err := c.applyGuardianUpdate(ctx, studentID, update); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyGuardianUpdate links a new guardian or updates an existing link
+func (c *StudentImportConfig) applyGuardianUpdate(ctx context.Context, studentID int64, update guardianUpdate) error {
+	if update.existing == nil {
+		return c.createSingleGuardianRelationship(ctx, studentID, update.data, update.index)
+	}
+
+	if update.profileChanged {
+		if err := c.guardianRepo.Update(ctx, update.existing.profile); err != nil {
+			return fmt.Errorf("guardian %d: update profile: %w", update.index, err)
+		}
+	}
+
+	if update.relationChanged {
+		if err := c.relationRepo.Update(ctx, update.existing.relation); err != nil {
+			return fmt.Errorf("guardian %d: update relationship: %w", update.index, err)
+		}
+	}
+
+	if err := c.addGuardianPhoneNumbers(ctx, update.existing.profile.ID, update.newPhones, len(update.existing.phones)); err != nil {
+		return fmt.Errorf("guardian %d: add phone numbers: %w", update.index, err)
+	}
+
+	return nil
+}
+
+// addGuardianPhoneNumbers appends phone numbers after the stored ones without changing the primary number
+func (c *StudentImportConfig) addGuardianPhoneNumbers(ctx context.Context, guardianID int64, phones []importModels.PhoneImportData, storedCount int) error {
+	for i, phoneData := range phones {
+		var label *string
+		if phoneData.Label != "" {
+			label = &phoneData.Label
+		}
+
+		phone := &users.GuardianPhoneNumber{
+			GuardianProfileID: guardianID,
+			PhoneNumber:       phoneData.PhoneNumber,
+			PhoneType:         mapPhoneType(phoneData.PhoneType),
+			Label:             label,
+			IsPrimary:         storedCount == 0 && phoneData.IsPrimary,
+			Priority:          storedCount + i + 1,
+		}
+
+		if err := c.guardianPhoneRepo.Create(ctx, phone); err != nil {
+			return fmt.Errorf("phone %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// addMissingGuardianPhoneNumbers adds the imported phone numbers an existing guardian does not have yet
+func (c *StudentImportConfig) addMissingGuardianPhoneNumbers(ctx context.Context, guardianID int64, phones []importModels.PhoneImportData) error {
+	stored, err := c.guardianPhoneRepo.FindByGuardianID(ctx, guardianID)
+	if err != nil {
+		return err
+	}
+	return c.addGuardianPhoneNumbers(ctx, guardianID, missingPhoneNumbers(stored, phones), len(stored))
+}
@@ -0,0 +1,252 @@
+package importpkg
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	importModels "github.com/moto-nrw/project-phoenix/models/import"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGroupA int64 = 11
+	testGroupB int64 = 12
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func testGroupName(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return map[int64]string{testGroupA: "Gruppe A", testGroupB: "Gruppe B"}[*id]
+}
+
+func newTestSnapshot() *studentSnapshot {
+	birthday := time.Date(2016, 4, 2, 0, 0, 0, 0, time.UTC)
+	bus := false
+	groupID := testGroupA
+	email := "eva@example.com"
+
+	return &studentSnapshot{
+		person:  &users.Person{FirstName: "Mia", LastName: "Muster", Birthday: &birthday},
+		student: &users.Student{SchoolClass: "3a", GroupID: &groupID, Bus: &bus},
+		guardians: []*linkedGuardian{
+			{
+				profile:  &users.GuardianProfile{FirstName: "Eva", LastName: "Muster", Email: &email},
+				relation: &users.StudentGuardian{RelationshipType: "parent", IsPrimary: true, CanPickup: true},
+				phones:   []*users.GuardianPhoneNumber{{PhoneNumber: "0221 12345"}},
+			},
+		},
+	}
+}
+
+func newTestRow() importModels.StudentImportRow {
+	return importModels.StudentImportRow{
+		FirstName:   "Mia",
+		LastName:    "Muster",
+		SchoolClass: "3a",
+		Birthday:    "2016-04-02",
+		Guardians: []importModels.GuardianImportData{
+			{
+				FirstName:        "Eva",
+				LastName:         "Muster",
+				Email:            "EVA@example.com",
+				RelationshipType: "parent",
+				IsPrimary:        boolPtr(true),
+				CanPickup:        boolPtr(true),
+				PhoneNumbers:     []importModels.PhoneImportData{{PhoneNumber: "0221-12345"}},
+			},
+		},
+	}
+}
+
+func changedFields(plan *studentUpdatePlan) []string {
+	fields := make([]string, 0, len(plan.changes))
+	for _, c := range plan.changes {
+		fields = append(fields, c.Field)
+	}
+	return fields
+}
+
+func TestBuildStudentUpdatePlan_Unchanged(t *testing.T) {
+	plan := buildStudentUpdatePlan(newTestSnapshot(), newTestRow(), testGroupName)
+
+	assert.Empty(t, plan.changes)
+	assert.False(t, plan.personChanged)
+	assert.False(t, plan.studentChanged)
+	assert.Empty(t, plan.guardians)
+}
+
+func TestBuildStudentUpdatePlan_StudentFields(t *testing.T) {
+	snapshot := newTestSnapshot()
+	row := newTestRow()
+	groupID := testGroupB
+	row.SchoolClass = "4a"
+	row.GroupID = &groupID
+	row.GroupName = "Gruppe B"
+	row.BusPermission = boolPtr(true)
+	row.HealthInfo = "Allergie"
+
+	plan := buildStudentUpdatePlan(snapshot, row, testGroupName)
+
+	assert.True(t, plan.studentChanged)
+	assert.False(t, plan.personChanged)
+	assert.Equal(t, []importModels.FieldChange{
+		{Field: "school_class", OldValue: "3a", NewValue: "4a"},
+		{Field: "group", OldValue: "Gruppe A", NewValue: "Gruppe B"},
+		{Field: "bus", OldValue: "false", NewValue: "true"},
+		{Field: "health_info", OldValue: "", NewValue: "Allergie"},
+	}, plan.changes)
+
+	// The snapshot models carry the new values for the update
+	assert.Equal(t, "4a", snapshot.student.SchoolClass)
+	assert.Equal(t, testGroupB, *snapshot.student.GroupID)
+	assert.True(t, *snapshot.student.Bus)
+	require.NotNil(t, snapshot.student.HealthInfo)
+	assert.Equal(t, "Allergie", *snapshot.student.HealthInfo)
+}
+
+func TestBuildStudentUpdatePlan_BlankCellsKeepStoredValues(t *testing.T) {
+	snapshot := newTestSnapshot()
+	notes := "Braucht Ruhe"
+	snapshot.student.SupervisorNotes = &notes
+	row := newTestRow()
+	row.Birthday = ""
+	row.SupervisorNotes = "  "
+
+	plan := buildStudentUpdatePlan(snapshot, row, testGroupName)
+
+	assert.Empty(t, plan.changes)
+	assert.Equal(t, "Braucht Ruhe", *snapshot.student.SupervisorNotes)
+	assert.NotNil(t, snapshot.person.Birthday)
+}
+
+func TestBuildStudentUpdatePlan_MissingFlagColumnsKeepStoredValues(t *testing.T) {
+	snapshot := newTestSnapshot()
+	bus := true
+	snapshot.student.Bus = &bus
+	snapshot.guardians[0].relation.IsEmergencyContact = true
+
+	// A roster without Bus, Erz1.Primär, Erz1.Notfall and Erz1.Abholung columns
+	csvData := "Vorname,Nachname,Klasse,Geburtstag,Erz1.Vorname,Erz1.Nachname,Erz1.Email,Erz1.Verhältnis\n" +
+		"Mia,Muster,3a,02.04.2016,Eva,Muster,eva@example.com,parent\n"
+	rows, err := NewCSVParser().ParseStudents(strings.NewReader(csvData))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Len(t, rows[0].Guardians, 1)
+	assert.Nil(t, rows[0].BusPermission)
+	assert.Nil(t, rows[0].Guardians[0].CanPickup)
+
+	plan := buildStudentUpdatePlan(snapshot, rows[0], testGroupName)
+
+	assert.Empty(t, plan.changes)
+	assert.False(t, plan.studentChanged)
+	assert.Empty(t, plan.guardians)
+	assert.True(t, *snapshot.student.Bus)
+	assert.True(t, snapshot.guardians[0].relation.IsPrimary)
+	assert.True(t, snapshot.guardians[0].relation.IsEmergencyContact)
+	assert.True(t, snapshot.guardians[0].relation.CanPickup)
+}
+
+func TestBuildStudentUpdatePlan_Birthday(t *testing.T) {
+	snapshot := newTestSnapshot()
+	row := newTestRow()
+	row.Birthday = "2016-04-03"
+
+	plan := buildStudentUpdatePlan(snapshot, row, testGroupName)
+
+	assert.True(t, plan.personChanged)
+	assert.Equal(t, []importModels.FieldChange{{Field: "birthday", OldValue: "2016-04-02", NewValue: "2016-04-03"}}, plan.changes)
+}
+
+func TestBuildStudentUpdatePlan_ExistingGuardian(t *testing.T) {
+	snapshot := newTestSnapshot()
+	row := newTestRow()
+	row.Guardians[0].CanPickup = boolPtr(false)
+	row.Guardians[0].IsEmergencyContact = boolPtr(true)
+	row.Guardians[0].PhoneNumbers = append(row.Guardians[0].PhoneNumbers, importModels.PhoneImportData{PhoneNumber: "0170 9876543"})
+
+	plan := buildStudentUpdatePlan(snapshot, row, testGroupName)
+
+	assert.ElementsMatch(t, []string{"guardian_1_is_emergency_contact", "guardian_1_can_pickup", "guardian_1_phone"}, changedFields(plan))
+	require.Len(t, plan.guardians, 1)
+	update := plan.guardians[0]
+	assert.Same(t, snapshot.guardians[0], update.existing)
+	assert.True(t, update.relationChanged)
+	assert.False(t, update.profileChanged)
+	require.Len(t, update.newPhones, 1)
+	assert.Equal(t, "0170 9876543", update.newPhones[0].PhoneNumber)
+	assert.False(t, snapshot.guardians[0].relation.CanPickup)
+}
+
+func TestBuildStudentUpdatePlan_NewGuardian(t *testing.T) {
+	snapshot := newTestSnapshot()
+	row := newTestRow()
+	row.Guardians = append(row.Guardians, importModels.GuardianImportData{
+		FirstName: "Tom",
+		LastName:  "Muster",
+		Email:     "tom@example.com",
+	})
+
+	plan := buildStudentUpdatePlan(snapshot, row, testGroupName)
+
+	assert.Equal(t, []importModels.FieldChange{{Field: "guardian_2", OldValue: "", NewValue: "Tom Muster <tom@example.com>"}}, plan.changes)
+	require.Len(t, plan.guardians, 1)
+	assert.Nil(t, plan.guardians[0].existing)
+	assert.Equal(t, 2, plan.guardians[0].index)
+}
+
+func TestBuildStudentUpdatePlan_UnlistedGuardiansAreKept(t *testing.T) {
+	row := newTestRow()
+	row.Guardians = nil
+
+	plan := buildStudentUpdatePlan(newTestSnapshot(), row, testGroupName)
+
+	assert.Empty(t, plan.changes)
+	assert.Empty(t, plan.guardians)
+}
+
+func TestMatchLinkedGuardian(t *testing.T) {
+	email := "eva@example.com"
+	withEmail := &linkedGuardian{profile: &users.GuardianProfile{FirstName: "Eva", LastName: "Muster", Email: &email}}
+	withoutEmail := &linkedGuardian{profile: &users.GuardianProfile{FirstName: "Tom", LastName: "Muster"}}
+	linked := []*linkedGuardian{withEmail, withoutEmail}
+
+	tests := []struct {
+		name     string
+		data     importModels.GuardianImportData
+		expected *linkedGuardian
+	}{
+		{"email is case-insensitive", importModels.GuardianImportData{Email: "Eva@Example.com"}, withEmail},
+		{"email takes precedence over name", importModels.GuardianImportData{FirstName: "Tom", LastName: "Muster", Email: "other@example.com"}, nil},
+		{"name without email", importModels.GuardianImportData{FirstName: "tom", LastName: "muster"}, withoutEmail},
+		{"first name only", importModels.GuardianImportData{FirstName: "Tom"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.expected, matchLinkedGuardian(linked, tt.data))
+		})
+	}
+}
+
+func TestMissingPhoneNumbers(t *testing.T) {
+	stored := []*users.GuardianPhoneNumber{{PhoneNumber: "+49 221 12345"}}
+	imported := []importModels.PhoneImportData{
+		{PhoneNumber: "+4922112345"},
+		{PhoneNumber: "0170/123"},
+		{PhoneNumber: "0170 123"},
+		{PhoneNumber: ""},
+	}
+
+	missing := missingPhoneNumbers(stored, imported)
+
+	require.Len(t, missing, 1)
+	assert.Equal(t, "0170/123", missing[0].PhoneNumber)
+}
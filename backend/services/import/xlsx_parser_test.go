@@ -208,7 +208,7 @@ func TestXLSXParser_ParseStudents(t *testing.T) {
 		// ASSERT
 		require.NoError(t, err)
 		require.Len(t, students, 1)
-		assert.True(t, students[0].BusPermission)
+		assert.True(t, boolValue(students[0].BusPermission))
 		assert.True(t, students[0].PrivacyAccepted)
 	})
 
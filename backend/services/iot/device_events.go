@@ -0,0 +1,75 @@
+package iot
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/moto-nrw/project-phoenix/realtime"
+)
+
+// recordTransition stores a status transition in the device history.
+// Must be called before device.Status is changed. Failures are logged and never returned.
+func (s *service) recordTransition(ctx context.Context, device *iot.Device, toStatus iot.DeviceStatus, reason string) {
+	if s.deviceEventRepo == nil || device == nil {
+		return
+	}
+
+	event := iot.NewDeviceEvent(device, toStatus, reason)
+	if err := s.deviceEventRepo.Create(ctx, event); err != nil {
+		slog.Error("failed to record device status transition",
+			slog.String("error", err.Error()),
+			slog.String("device_id", device.DeviceID),
+			slog.String("from_status", string(event.FromStatus)),
+			slog.String("to_status", string(event.ToStatus)),
+		)
+	}
+}
+
+// announceTransition broadcasts device_offline or device_online when a device
+// enters or leaves the offline status. device carries the new status.
+func (s *service) announceTransition(ctx context.Context, previous iot.DeviceStatus, device *iot.Device) {
+	wasOffline := previous == iot.DeviceStatusOffline
+	switch {
+	case device.IsOffline() && !wasOffline:
+		s.broadcastDeviceEvent(ctx, device, realtime.EventDeviceOffline)
+	case !device.IsOffline() && wasOffline:
+		s.broadcastDeviceEvent(ctx, device, realtime.EventDeviceOnline)
+	}
+}
+
+// broadcastDeviceEvent notifies admins, and the session running on the device, that the device
+// went offline or came back
+func (s *service) broadcastDeviceEvent(ctx context.Context, device *iot.Device, eventType realtime.EventType) {
+	if s.broadcaster == nil || device == nil {
+		return
+	}
+
+	deviceID := device.DeviceID
+	data := realtime.EventData{
+		DeviceID:   &deviceID,
+		DeviceName: device.Name,
+		LastSeen:   device.LastSeen,
+	}
+
+	topics := []string{realtime.TopicSchool}
+	activeGroupID := ""
+	if s.activeGroupRepo != nil {
+		if group, err := s.activeGroupRepo.FindActiveByDeviceID(ctx, device.ID); err == nil && group != nil {
+			activeGroupID = realtime.ActiveGroupTopic(group.ID)
+			roomID := strconv.FormatInt(group.RoomID, 10)
+			data.RoomID = &roomID
+			topics = append(topics, activeGroupID, realtime.RoomTopic(group.RoomID))
+		}
+	}
+
+	event := realtime.NewEvent(eventType, activeGroupID, data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		slog.Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(eventType)),
+			slog.String("device_id", deviceID),
+		)
+	}
+}
@@ -0,0 +1,54 @@
+package iot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBroadcaster captures broadcast events
+type recordingBroadcaster struct {
+	topics [][]string
+	events []realtime.Event
+}
+
+func (b *recordingBroadcaster) BroadcastToGroup(activeGroupID string, event realtime.Event) error {
+	return b.BroadcastToTopics([]string{activeGroupID}, event)
+}
+
+func (b *recordingBroadcaster) BroadcastToTopics(topics []string, event realtime.Event) error {
+	b.topics = append(b.topics, topics)
+	b.events = append(b.events, event)
+	return nil
+}
+
+func TestBroadcastDeviceEvent(t *testing.T) {
+	broadcaster := &recordingBroadcaster{}
+	svc := &service{broadcaster: broadcaster}
+	name := "Reader Raum 1"
+	lastSeen := time.Now().Add(-10 * time.Minute)
+
+	svc.broadcastDeviceEvent(context.Background(), &iot.Device{DeviceID: "rfid-001", Name: &name, LastSeen: &lastSeen}, realtime.EventDeviceOffline)
+
+	require.Len(t, broadcaster.events, 1)
+	event := broadcaster.events[0]
+	assert.Equal(t, realtime.EventDeviceOffline, event.Type)
+	assert.Equal(t, []string{realtime.TopicSchool}, broadcaster.topics[0])
+	require.NotNil(t, event.Data.DeviceID)
+	assert.Equal(t, "rfid-001", *event.Data.DeviceID)
+	assert.Equal(t, &name, event.Data.DeviceName)
+	assert.Equal(t, &lastSeen, event.Data.LastSeen)
+	assert.Nil(t, event.Data.StudentID)
+}
+
+func TestBroadcastDeviceEvent_NoBroadcaster(t *testing.T) {
+	svc := &service{}
+
+	// Must not panic without a broadcaster
+	svc.broadcastDeviceEvent(context.Background(), &iot.Device{DeviceID: "rfid-001"}, realtime.EventDeviceOffline)
+}
@@ -0,0 +1,169 @@
+package iot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/iot"
+)
+
+// DeviceHealthResult summarizes one health check run
+type DeviceHealthResult struct {
+	WentOffline int
+	CameOnline  int
+}
+
+// DeviceUptime is the availability of a device over a time window
+type DeviceUptime struct {
+	DeviceID      int64
+	From          time.Time
+	To            time.Time
+	UptimePercent float64
+	Events        []*iot.DeviceEvent
+}
+
+// CheckDeviceHealth moves active devices to offline when their last heartbeat is older than
+// threshold, and offline devices back to active once heartbeats resume.
+// Virtual devices never send heartbeats and are skipped.
+func (s *service) CheckDeviceHealth(ctx context.Context, threshold time.Duration) (*DeviceHealthResult, error) {
+	if threshold <= 0 {
+		return nil, &IoTError{Op: "CheckDeviceHealth", Err: errors.New("invalid offline threshold")}
+	}
+
+	result := &DeviceHealthResult{}
+	cutoff := time.Now().Add(-threshold)
+
+	stale, err := s.deviceRepo.FindOfflineDevices(ctx, threshold)
+	if err != nil {
+		return nil, &IoTError{Op: "CheckDeviceHealth", Err: err}
+	}
+	for _, device := range stale {
+		if !device.IsActive() || device.DeviceType == iot.DeviceTypeVirtual {
+			continue
+		}
+		if s.applyHealthTransition(ctx, device, iot.DeviceStatusOffline, iot.DeviceEventReasonHeartbeatTimeout, cutoff) {
+			result.WentOffline++
+		}
+	}
+
+	offline, err := s.deviceRepo.FindByStatus(ctx, iot.DeviceStatusOffline)
+	if err != nil {
+		return nil, &IoTError{Op: "CheckDeviceHealth", Err: err}
+	}
+	for _, device := range offline {
+		if device.LastSeen == nil || device.LastSeen.Before(cutoff) {
+			continue
+		}
+		if s.applyHealthTransition(ctx, device, iot.DeviceStatusActive, iot.DeviceEventReasonHeartbeatResumed, cutoff) {
+			result.CameOnline++
+		}
+	}
+
+	return result, nil
+}
+
+// applyHealthTransition persists a status change detected by the health check, records it and
+// announces it. Returns false if the status could not be updated.
+func (s *service) applyHealthTransition(ctx context.Context, device *iot.Device, toStatus iot.DeviceStatus, reason string, cutoff time.Time) bool {
+	updated, err := s.persistHealthTransition(ctx, device, toStatus, cutoff)
+	if err != nil {
+		slog.Error("failed to update device status",
+			slog.String("error", err.Error()),
+			slog.String("device_id", device.DeviceID),
+			slog.String("to_status", string(toStatus)),
+		)
+		return false
+	}
+	if !updated {
+		// A heartbeat arrived after the stale devices were loaded
+		return false
+	}
+
+	s.recordTransition(ctx, device, toStatus, reason)
+
+	previous := device.Status
+	device.Status = toStatus
+	slog.Warn("device status changed by health check",
+		slog.String("device_id", device.DeviceID),
+		slog.String("from_status", string(previous)),
+		slog.String("to_status", string(toStatus)),
+		slog.String("reason", reason),
+	)
+	s.announceTransition(ctx, previous, device)
+	return true
+}
+
+// persistHealthTransition writes the new status. Going offline is conditional on the device
+// still being stale, so a heartbeat that lands between the read and the write is not overridden.
+func (s *service) persistHealthTransition(ctx context.Context, device *iot.Device, toStatus iot.DeviceStatus, cutoff time.Time) (bool, error) {
+	if toStatus == iot.DeviceStatusOffline {
+		return s.deviceRepo.MarkOfflineIfStale(ctx, device.DeviceID, cutoff)
+	}
+	return true, s.deviceRepo.UpdateStatus(ctx, device.DeviceID, toStatus)
+}
+
+// GetDeviceUptime calculates the share of time since the given time the device was not offline
+func (s *service) GetDeviceUptime(ctx context.Context, id int64, since time.Time) (*DeviceUptime, error) {
+	if s.deviceEventRepo == nil {
+		return nil, &IoTError{Op: "GetDeviceUptime", Err: errors.New("device history not configured")}
+	}
+
+	device, err := s.GetDeviceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := since
+	if device.CreatedAt.After(from) {
+		from = device.CreatedAt
+	}
+
+	events, err := s.deviceEventRepo.FindByDeviceID(ctx, device.ID, from)
+	if err != nil {
+		return nil, &IoTError{Op: "GetDeviceUptime", Err: err}
+	}
+
+	initial, err := s.statusAt(ctx, device, from, events)
+	if err != nil {
+		return nil, &IoTError{Op: "GetDeviceUptime", Err: err}
+	}
+
+	return &DeviceUptime{
+		DeviceID:      device.ID,
+		From:          from,
+		To:            now,
+		UptimePercent: iot.CalculateUptime(initial, events, from, now),
+		Events:        events,
+	}, nil
+}
+
+// statusAt determines the device status at the start of the uptime window
+func (s *service) statusAt(ctx context.Context, device *iot.Device, at time.Time, events []*iot.DeviceEvent) (iot.DeviceStatus, error) {
+	previous, err := s.deviceEventRepo.FindLatestBefore(ctx, device.ID, at)
+	if err != nil {
+		return "", err
+	}
+	if previous != nil {
+		return previous.ToStatus, nil
+	}
+	if len(events) > 0 {
+		return events[0].FromStatus, nil
+	}
+	return device.Status, nil
+}
+
+// CleanupDeviceEvents deletes device history older than the retention period
+func (s *service) CleanupDeviceEvents(ctx context.Context, retention time.Duration) (int, error) {
+	if s.deviceEventRepo == nil {
+		return 0, nil
+	}
+
+	count, err := s.deviceEventRepo.DeleteOlderThan(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, &IoTError{Op: "CleanupDeviceEvents", Err: err}
+	}
+	return count, nil
+}
@@ -0,0 +1,253 @@
+package iot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/moto-nrw/project-phoenix/realtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDeviceRepo is a minimal in-memory iot.DeviceRepository for health check tests
+type memoryDeviceRepo struct {
+	devices []*iot.Device
+}
+
+func (r *memoryDeviceRepo) Create(_ context.Context, device *iot.Device) error {
+	r.devices = append(r.devices, device)
+	return nil
+}
+
+func (r *memoryDeviceRepo) FindByID(_ context.Context, id interface{}) (*iot.Device, error) {
+	for _, d := range r.devices {
+		if d.ID == id {
+			copied := *d
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryDeviceRepo) Update(_ context.Context, _ *iot.Device) error { return nil }
+func (r *memoryDeviceRepo) Delete(_ context.Context, _ interface{}) error { return nil }
+func (r *memoryDeviceRepo) List(_ context.Context, _ map[string]interface{}) ([]*iot.Device, error) {
+	return r.copies(func(*iot.Device) bool { return true }), nil
+}
+func (r *memoryDeviceRepo) FindByDeviceID(_ context.Context, _ string) (*iot.Device, error) {
+	return nil, nil
+}
+func (r *memoryDeviceRepo) FindByAPIKey(_ context.Context, _ string) (*iot.Device, error) {
+	return nil, nil
+}
+func (r *memoryDeviceRepo) FindByType(_ context.Context, _ string) ([]*iot.Device, error) {
+	return nil, nil
+}
+func (r *memoryDeviceRepo) FindByStatus(_ context.Context, status iot.DeviceStatus) ([]*iot.Device, error) {
+	return r.copies(func(d *iot.Device) bool { return d.Status == status }), nil
+}
+func (r *memoryDeviceRepo) FindByRegisteredBy(_ context.Context, _ int64) ([]*iot.Device, error) {
+	return nil, nil
+}
+func (r *memoryDeviceRepo) UpdateLastSeen(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+func (r *memoryDeviceRepo) UpdateStatus(_ context.Context, deviceID string, status iot.DeviceStatus) error {
+	for _, d := range r.devices {
+		if d.DeviceID == deviceID {
+			d.Status = status
+		}
+	}
+	return nil
+}
+func (r *memoryDeviceRepo) MarkOfflineIfStale(_ context.Context, deviceID string, cutoff time.Time) (bool, error) {
+	for _, d := range r.devices {
+		if d.DeviceID == deviceID && d.IsActive() && d.LastSeen != nil && d.LastSeen.Before(cutoff) {
+			d.Status = iot.DeviceStatusOffline
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (r *memoryDeviceRepo) UpdateAPIKeys(_ context.Context, _ int64, _, _ *string, _ *time.Time) error {
+	return nil
+}
+func (r *memoryDeviceRepo) FindActiveDevices(_ context.Context) ([]*iot.Device, error) {
+	return nil, nil
+}
+func (r *memoryDeviceRepo) FindDevicesRequiringMaintenance(_ context.Context) ([]*iot.Device, error) {
+	return nil, nil
+}
+func (r *memoryDeviceRepo) FindOfflineDevices(_ context.Context, offlineSince time.Duration) ([]*iot.Device, error) {
+	cutoff := time.Now().Add(-offlineSince)
+	return r.copies(func(d *iot.Device) bool { return d.LastSeen == nil || d.LastSeen.Before(cutoff) }), nil
+}
+func (r *memoryDeviceRepo) CountDevicesByType(_ context.Context) (map[string]int, error) {
+	return nil, nil
+}
+
+// copies returns copies of the matching devices, like rows read from the database
+func (r *memoryDeviceRepo) copies(match func(*iot.Device) bool) []*iot.Device {
+	var result []*iot.Device
+	for _, d := range r.devices {
+		if match(d) {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	return result
+}
+
+// memoryDeviceEventRepo is an in-memory iot.DeviceEventRepository
+type memoryDeviceEventRepo struct {
+	events []*iot.DeviceEvent
+}
+
+func (r *memoryDeviceEventRepo) Create(_ context.Context, event *iot.DeviceEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memoryDeviceEventRepo) FindByDeviceID(_ context.Context, deviceID int64, since time.Time) ([]*iot.DeviceEvent, error) {
+	var result []*iot.DeviceEvent
+	for _, e := range r.events {
+		if e.DeviceID == deviceID && !e.OccurredAt.Before(since) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryDeviceEventRepo) FindLatestBefore(_ context.Context, deviceID int64, before time.Time) (*iot.DeviceEvent, error) {
+	var latest *iot.DeviceEvent
+	for _, e := range r.events {
+		if e.DeviceID == deviceID && e.OccurredAt.Before(before) {
+			latest = e
+		}
+	}
+	return latest, nil
+}
+
+func (r *memoryDeviceEventRepo) DeleteOlderThan(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func newHealthTestDevice(id int64, deviceID string, status iot.DeviceStatus, lastSeenAgo time.Duration) *iot.Device {
+	lastSeen := time.Now().Add(-lastSeenAgo)
+	device := &iot.Device{DeviceID: deviceID, DeviceType: "rfid_reader", Status: status, LastSeen: &lastSeen}
+	device.ID = id
+	device.CreatedAt = time.Now().Add(-24 * time.Hour)
+	return device
+}
+
+func TestCheckDeviceHealth_Transitions(t *testing.T) {
+	stale := newHealthTestDevice(11, "rfid-stale", iot.DeviceStatusActive, 30*time.Minute)
+	healthy := newHealthTestDevice(12, "rfid-healthy", iot.DeviceStatusActive, time.Minute)
+	recovered := newHealthTestDevice(13, "rfid-recovered", iot.DeviceStatusOffline, time.Minute)
+	stillDown := newHealthTestDevice(14, "rfid-down", iot.DeviceStatusOffline, time.Hour)
+	maintenance := newHealthTestDevice(15, "rfid-maintenance", iot.DeviceStatusMaintenance, time.Hour)
+	virtual := newHealthTestDevice(16, "WEB-MANUAL-001", iot.DeviceStatusActive, 48*time.Hour)
+	virtual.DeviceType = iot.DeviceTypeVirtual
+
+	devices := &memoryDeviceRepo{devices: []*iot.Device{stale, healthy, recovered, stillDown, maintenance, virtual}}
+	events := &memoryDeviceEventRepo{}
+	broadcaster := &recordingBroadcaster{}
+	svc := &service{deviceRepo: devices, deviceEventRepo: events, broadcaster: broadcaster}
+
+	result, err := svc.CheckDeviceHealth(context.Background(), 5*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, &DeviceHealthResult{WentOffline: 1, CameOnline: 1}, result)
+	assert.Equal(t, iot.DeviceStatusOffline, stale.Status)
+	assert.Equal(t, iot.DeviceStatusActive, healthy.Status)
+	assert.Equal(t, iot.DeviceStatusActive, recovered.Status)
+	assert.Equal(t, iot.DeviceStatusOffline, stillDown.Status)
+	assert.Equal(t, iot.DeviceStatusMaintenance, maintenance.Status, "maintenance devices are left alone")
+	assert.Equal(t, iot.DeviceStatusActive, virtual.Status, "virtual devices never send heartbeats")
+
+	require.Len(t, events.events, 2)
+	assert.Equal(t, stale.ID, events.events[0].DeviceID)
+	assert.Equal(t, iot.DeviceEventReasonHeartbeatTimeout, events.events[0].Reason)
+	assert.Equal(t, recovered.ID, events.events[1].DeviceID)
+	assert.Equal(t, iot.DeviceEventReasonHeartbeatResumed, events.events[1].Reason)
+
+	require.Len(t, broadcaster.events, 2)
+	assert.Equal(t, realtime.EventDeviceOffline, broadcaster.events[0].Type)
+	assert.Equal(t, realtime.EventDeviceOnline, broadcaster.events[1].Type)
+
+	// A second run finds nothing to do
+	result, err = svc.CheckDeviceHealth(context.Background(), 5*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, &DeviceHealthResult{}, result)
+}
+
+// heartbeatDuringCheckRepo simulates heartbeats arriving right after the stale devices were read
+type heartbeatDuringCheckRepo struct {
+	*memoryDeviceRepo
+}
+
+func (r *heartbeatDuringCheckRepo) FindOfflineDevices(ctx context.Context, offlineSince time.Duration) ([]*iot.Device, error) {
+	stale, err := r.memoryDeviceRepo.FindOfflineDevices(ctx, offlineSince)
+	now := time.Now()
+	for _, d := range r.devices {
+		d.LastSeen = &now
+	}
+	return stale, err
+}
+
+func TestCheckDeviceHealth_HeartbeatDuringCheck(t *testing.T) {
+	device := newHealthTestDevice(17, "rfid-late-heartbeat", iot.DeviceStatusActive, 30*time.Minute)
+	events := &memoryDeviceEventRepo{}
+	broadcaster := &recordingBroadcaster{}
+	svc := &service{
+		deviceRepo:      &heartbeatDuringCheckRepo{&memoryDeviceRepo{devices: []*iot.Device{device}}},
+		deviceEventRepo: events,
+		broadcaster:     broadcaster,
+	}
+
+	result, err := svc.CheckDeviceHealth(context.Background(), 5*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, &DeviceHealthResult{}, result)
+	assert.Equal(t, iot.DeviceStatusActive, device.Status)
+	assert.Empty(t, events.events)
+	assert.Empty(t, broadcaster.events)
+}
+
+func TestCheckDeviceHealth_InvalidThreshold(t *testing.T) {
+	svc := &service{deviceRepo: &memoryDeviceRepo{}}
+
+	_, err := svc.CheckDeviceHealth(context.Background(), 0)
+
+	require.Error(t, err)
+}
+
+func TestGetDeviceUptime(t *testing.T) {
+	device := newHealthTestDevice(21, "rfid-uptime", iot.DeviceStatusActive, time.Minute)
+	now := time.Now()
+	events := &memoryDeviceEventRepo{events: []*iot.DeviceEvent{
+		{DeviceID: device.ID, FromStatus: iot.DeviceStatusActive, ToStatus: iot.DeviceStatusOffline, Reason: iot.DeviceEventReasonHeartbeatTimeout, OccurredAt: now.Add(-4 * time.Hour)},
+		{DeviceID: device.ID, FromStatus: iot.DeviceStatusOffline, ToStatus: iot.DeviceStatusActive, Reason: iot.DeviceEventReasonHeartbeatResumed, OccurredAt: now.Add(-3 * time.Hour)},
+	}}
+	svc := &service{deviceRepo: &memoryDeviceRepo{devices: []*iot.Device{device}}, deviceEventRepo: events}
+
+	uptime, err := svc.GetDeviceUptime(context.Background(), device.ID, now.Add(-10*time.Hour))
+
+	require.NoError(t, err)
+	assert.Equal(t, device.ID, uptime.DeviceID)
+	assert.InDelta(t, 90.0, uptime.UptimePercent, 0.01)
+	assert.Len(t, uptime.Events, 2)
+}
+
+func TestGetDeviceUptime_NoHistory(t *testing.T) {
+	svc := &service{deviceRepo: &memoryDeviceRepo{}}
+
+	_, err := svc.GetDeviceUptime(context.Background(), 21, time.Now().Add(-time.Hour))
+
+	require.Error(t, err)
+}
@@ -14,6 +14,11 @@ var (
 	ErrDeviceOffline     = errors.New("device is offline")
 	ErrNetworkScanFailed = errors.New("network scan failed")
 	ErrDatabaseOperation = errors.New("database operation failed")
+
+	// Provisioning errors
+	ErrInvalidEnrollmentCode    = errors.New("invalid or expired enrollment code")
+	ErrInvalidEnrollmentRequest = errors.New("invalid enrollment request")
+	ErrInvalidGracePeriod       = errors.New("invalid key rotation grace period")
 )
 
 // IoTError wraps IoT service errors with operation context
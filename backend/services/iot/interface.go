@@ -34,7 +34,20 @@ type Service interface {
 	GetOfflineDevices(ctx context.Context, offlineDuration time.Duration) ([]*iot.Device, error)
 	GetDeviceTypeStatistics(ctx context.Context) (map[string]int, error)
 
+	// Health monitoring
+	CheckDeviceHealth(ctx context.Context, threshold time.Duration) (*DeviceHealthResult, error)
+	GetDeviceUptime(ctx context.Context, id int64, since time.Time) (*DeviceUptime, error)
+	CleanupDeviceEvents(ctx context.Context, retention time.Duration) (int, error)
+
+	// Provisioning
+	CreateEnrollmentCode(ctx context.Context, req EnrollmentCodeRequest, actor KeyActor) (*EnrollmentCode, error)
+	ListPendingEnrollments(ctx context.Context) ([]*iot.DeviceEnrollment, error)
+	EnrollDevice(ctx context.Context, code string, ipAddress string) (*DeviceCredentials, error)
+	RotateAPIKey(ctx context.Context, id int64, grace time.Duration, actor KeyActor) (*DeviceCredentials, error)
+	RevokeAPIKey(ctx context.Context, id int64, actor KeyActor) error
+
 	// Network operations
+	// DetectNewDevices lists devices with a pending enrollment code
 	DetectNewDevices(ctx context.Context) ([]*iot.Device, error)
 	ScanNetwork(ctx context.Context) (map[string]string, error)
 
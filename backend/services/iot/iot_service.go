@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/moto-nrw/project-phoenix/models/active"
+	"github.com/moto-nrw/project-phoenix/models/audit"
 	"github.com/moto-nrw/project-phoenix/models/base"
 	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/moto-nrw/project-phoenix/realtime"
 	"github.com/uptrace/bun"
 )
 
@@ -19,19 +22,43 @@ const (
 	errDeviceIDEmpty = "device ID cannot be empty"
 )
 
+// ServiceDependencies contains all dependencies required by the IoT service
+type ServiceDependencies struct {
+	DeviceRepo           iot.DeviceRepository
+	DeviceEventRepo      iot.DeviceEventRepository      // Status history used for uptime (optional)
+	DeviceEnrollmentRepo iot.DeviceEnrollmentRepository // One-time enrollment codes
+	DeviceKeyEventRepo   audit.DeviceKeyEventRepository // Audit log for API key changes
+
+	// Used for device_offline/device_online SSE events (optional)
+	ActiveGroupRepo active.GroupRepository
+	Broadcaster     realtime.Broadcaster
+
+	DB *bun.DB
+}
+
 // service implements the Service interface
 type service struct {
-	deviceRepo iot.DeviceRepository
-	db         *bun.DB
-	txHandler  *base.TxHandler
+	deviceRepo           iot.DeviceRepository
+	deviceEventRepo      iot.DeviceEventRepository
+	deviceEnrollmentRepo iot.DeviceEnrollmentRepository
+	deviceKeyEventRepo   audit.DeviceKeyEventRepository
+	activeGroupRepo      active.GroupRepository
+	broadcaster          realtime.Broadcaster
+	db                   *bun.DB
+	txHandler            *base.TxHandler
 }
 
 // NewService creates a new IoT service
-func NewService(deviceRepo iot.DeviceRepository, db *bun.DB) Service {
+func NewService(deps ServiceDependencies) Service {
 	return &service{
-		deviceRepo: deviceRepo,
-		db:         db,
-		txHandler:  base.NewTxHandler(db),
+		deviceRepo:           deps.DeviceRepo,
+		deviceEventRepo:      deps.DeviceEventRepo,
+		deviceEnrollmentRepo: deps.DeviceEnrollmentRepo,
+		deviceKeyEventRepo:   deps.DeviceKeyEventRepo,
+		activeGroupRepo:      deps.ActiveGroupRepo,
+		broadcaster:          deps.Broadcaster,
+		db:                   deps.DB,
+		txHandler:            base.NewTxHandler(deps.DB),
 	}
 }
 
@@ -47,9 +74,14 @@ func (s *service) WithTx(tx bun.Tx) interface{} {
 
 	// Return a new service with the transaction
 	return &service{
-		deviceRepo: deviceRepo,
-		db:         s.db,
-		txHandler:  s.txHandler.WithTx(tx),
+		deviceRepo:           deviceRepo,
+		deviceEventRepo:      s.deviceEventRepo,
+		deviceEnrollmentRepo: s.deviceEnrollmentRepo,
+		deviceKeyEventRepo:   s.deviceKeyEventRepo,
+		activeGroupRepo:      s.activeGroupRepo,
+		broadcaster:          s.broadcaster,
+		db:                   s.db,
+		txHandler:            s.txHandler.WithTx(tx),
 	}
 }
 
@@ -188,6 +220,17 @@ func (s *service) UpdateDevice(ctx context.Context, device *iot.Device) error {
 		return &IoTError{Op: "UpdateDevice", Err: err}
 	}
 
+	if device.Status != existingDevice.Status {
+		reason := iot.DeviceEventReasonManual
+		if existingDevice.IsOffline() && device.IsActive() && device.LastSeen != nil &&
+			(existingDevice.LastSeen == nil || device.LastSeen.After(*existingDevice.LastSeen)) {
+			// An authenticated request from an offline device is a resumed heartbeat
+			reason = iot.DeviceEventReasonHeartbeatResumed
+		}
+		s.recordTransition(ctx, existingDevice, device.Status, reason)
+		s.announceTransition(ctx, existingDevice.Status, device)
+	}
+
 	return nil
 }
 
@@ -255,6 +298,13 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, deviceID string, statu
 		return &IoTError{Op: "UpdateDeviceStatus", Err: err}
 	}
 
+	if status != existingDevice.Status {
+		s.recordTransition(ctx, existingDevice, status, iot.DeviceEventReasonManual)
+		previous := existingDevice.Status
+		existingDevice.Status = status
+		s.announceTransition(ctx, previous, existingDevice)
+	}
+
 	return nil
 }
 
@@ -371,13 +421,25 @@ func (s *service) GetDeviceTypeStatistics(ctx context.Context) (map[string]int,
 	return stats, nil
 }
 
-// DetectNewDevices scans the network for new devices and returns them
-// This is a placeholder implementation and would need to be extended
-// with actual IoT device discovery logic
-func (s *service) DetectNewDevices(_ context.Context) ([]*iot.Device, error) {
-	// This would be implemented with actual network scanning logic in a real system
-	// For now, just return an error to indicate this is not implemented
-	return nil, &IoTError{Op: "DetectNewDevices", Err: errors.New("device auto-discovery not implemented")}
+// DetectNewDevices returns devices that are waiting to be enrolled: one unsaved device
+// (ID 0, status inactive) per pending enrollment code
+func (s *service) DetectNewDevices(ctx context.Context) ([]*iot.Device, error) {
+	enrollments, err := s.deviceEnrollmentRepo.FindPending(ctx, time.Now())
+	if err != nil {
+		return nil, &IoTError{Op: "DetectNewDevices", Err: err}
+	}
+
+	devices := make([]*iot.Device, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		devices = append(devices, &iot.Device{
+			DeviceID:   enrollment.DeviceID,
+			DeviceType: enrollment.DeviceType,
+			Name:       enrollment.Name,
+			Status:     iot.DeviceStatusInactive,
+		})
+	}
+
+	return devices, nil
 }
 
 // ScanNetwork scans the network for all IoT devices and returns a map of device IDs to device types
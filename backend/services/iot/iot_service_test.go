@@ -884,7 +884,7 @@ func TestIoTService_GetDeviceTypeStatistics(t *testing.T) {
 }
 
 // =============================================================================
-// Network Operation Tests (Placeholder Implementations)
+// Network Operation Tests
 // =============================================================================
 
 func TestIoTService_DetectNewDevices(t *testing.T) {
@@ -894,14 +894,32 @@ func TestIoTService_DetectNewDevices(t *testing.T) {
 	service := setupIoTService(t, db)
 	ctx := context.Background()
 
-	t.Run("returns not implemented error", func(t *testing.T) {
+	t.Run("lists devices with a pending enrollment code", func(t *testing.T) {
+		// ARRANGE
+		deviceID := fmt.Sprintf("pending-device-%d", time.Now().UnixNano())
+		issued, err := service.CreateEnrollmentCode(ctx, iot.EnrollmentCodeRequest{
+			DeviceID:   deviceID,
+			DeviceType: "rfid_reader",
+		}, iot.KeyActor{IPAddress: "127.0.0.1"})
+		require.NoError(t, err)
+		defer func() {
+			_, _ = db.NewDelete().TableExpr("iot.device_enrollments").Where("id = ?", issued.Enrollment.ID).Exec(ctx)
+		}()
+
 		// ACT
 		result, err := service.DetectNewDevices(ctx)
 
 		// ASSERT
-		require.Error(t, err)
-		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "not implemented")
+		require.NoError(t, err)
+		var found *iotModels.Device
+		for _, d := range result {
+			if d.DeviceID == deviceID {
+				found = d
+			}
+		}
+		require.NotNil(t, found, "pending enrollment should be listed")
+		assert.Equal(t, int64(0), found.ID)
+		assert.Equal(t, iotModels.DeviceStatusInactive, found.Status)
 	})
 }
 
@@ -0,0 +1,298 @@
+package iot
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/audit"
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/uptrace/bun"
+)
+
+// Provisioning defaults and limits
+const (
+	DefaultEnrollmentCodeTTL = 15 * time.Minute
+	MaxEnrollmentCodeTTL     = 24 * time.Hour
+	DefaultKeyRotationGrace  = 24 * time.Hour
+	MaxKeyRotationGrace      = 7 * 24 * time.Hour
+
+	// Codes are typed by hand on the reader, so ambiguous characters (0/O, 1/I) are left out
+	enrollmentCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	enrollmentCodeLength   = 8
+)
+
+// KeyActor identifies who triggered a key operation, for the audit log.
+// AccountID is 0 when the device itself acted (enrollment).
+type KeyActor struct {
+	AccountID int64
+	IPAddress string
+}
+
+// EnrollmentCodeRequest describes the device a new enrollment code is issued for
+type EnrollmentCodeRequest struct {
+	DeviceID   string
+	DeviceType string
+	Name       *string
+	TTL        time.Duration // 0 uses DefaultEnrollmentCodeTTL
+}
+
+// EnrollmentCode is a freshly issued code. Code is only available here; the database
+// stores its hash.
+type EnrollmentCode struct {
+	Enrollment *iot.DeviceEnrollment
+	Code       string
+}
+
+// DeviceCredentials is the result of an enrollment or key rotation. APIKey is shown once.
+type DeviceCredentials struct {
+	Device               *iot.Device
+	APIKey               string
+	PreviousKeyExpiresAt *time.Time
+}
+
+// generateEnrollmentCode returns a random code formatted as XXXX-XXXX
+func generateEnrollmentCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(enrollmentCodeAlphabet)))
+	code := make([]byte, 0, enrollmentCodeLength+1)
+	for i := 0; i < enrollmentCodeLength; i++ {
+		if i == enrollmentCodeLength/2 {
+			code = append(code, '-')
+		}
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, enrollmentCodeAlphabet[n.Int64()])
+	}
+	return string(code), nil
+}
+
+// CreateEnrollmentCode issues a one-time code that a device exchanges for its API key.
+// Issuing a code for an existing device ID re-keys that device on enrollment.
+func (s *service) CreateEnrollmentCode(ctx context.Context, req EnrollmentCodeRequest, actor KeyActor) (*EnrollmentCode, error) {
+	if req.DeviceID == "" || req.DeviceType == "" {
+		return nil, &IoTError{Op: "CreateEnrollmentCode", Err: ErrInvalidEnrollmentRequest}
+	}
+
+	ttl := req.TTL
+	if ttl == 0 {
+		ttl = DefaultEnrollmentCodeTTL
+	}
+	if ttl < 0 || ttl > MaxEnrollmentCodeTTL {
+		return nil, &IoTError{Op: "CreateEnrollmentCode", Err: ErrInvalidEnrollmentRequest}
+	}
+
+	code, err := generateEnrollmentCode()
+	if err != nil {
+		return nil, &IoTError{Op: "CreateEnrollmentCode", Err: fmt.Errorf("failed to generate enrollment code: %w", err)}
+	}
+
+	enrollment := &iot.DeviceEnrollment{
+		CodeHash:   iot.HashEnrollmentCode(code),
+		DeviceID:   req.DeviceID,
+		DeviceType: req.DeviceType,
+		Name:       req.Name,
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+	if actor.AccountID > 0 {
+		accountID := actor.AccountID
+		enrollment.CreatedByAccountID = &accountID
+	}
+
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, _ bun.Tx) error {
+		if err := s.deviceEnrollmentRepo.Create(ctx, enrollment); err != nil {
+			return err
+		}
+		return s.recordKeyEvent(ctx, nil, req.DeviceID, audit.DeviceKeyEventEnrollmentCodeCreated, actor, map[string]interface{}{
+			"enrollment_id": enrollment.ID,
+			"expires_at":    enrollment.ExpiresAt,
+		})
+	})
+	if err != nil {
+		return nil, &IoTError{Op: "CreateEnrollmentCode", Err: err}
+	}
+
+	return &EnrollmentCode{Enrollment: enrollment, Code: code}, nil
+}
+
+// ListPendingEnrollments returns unused, unexpired enrollment codes
+func (s *service) ListPendingEnrollments(ctx context.Context) ([]*iot.DeviceEnrollment, error) {
+	enrollments, err := s.deviceEnrollmentRepo.FindPending(ctx, time.Now())
+	if err != nil {
+		return nil, &IoTError{Op: "ListPendingEnrollments", Err: err}
+	}
+	return enrollments, nil
+}
+
+// EnrollDevice exchanges a one-time code for the device's API key. The code is claimed,
+// the device created (or re-keyed) and the exchange audited in one transaction.
+func (s *service) EnrollDevice(ctx context.Context, code string, ipAddress string) (*DeviceCredentials, error) {
+	if iot.NormalizeEnrollmentCode(code) == "" {
+		return nil, &IoTError{Op: "EnrollDevice", Err: ErrInvalidEnrollmentCode}
+	}
+
+	apiKey, err := s.generateAPIKey()
+	if err != nil {
+		return nil, &IoTError{Op: "EnrollDevice", Err: fmt.Errorf("failed to generate API key: %w", err)}
+	}
+
+	var device *iot.Device
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, _ bun.Tx) error {
+		enrollment, err := s.deviceEnrollmentRepo.Claim(ctx, iot.HashEnrollmentCode(code), time.Now())
+		if err != nil {
+			return err
+		}
+		if enrollment == nil {
+			return ErrInvalidEnrollmentCode
+		}
+
+		reEnrolled := false
+		existing, err := s.deviceRepo.FindByDeviceID(ctx, enrollment.DeviceID)
+		switch {
+		case err == nil && existing != nil && existing.ID > 0:
+			// Replacement hardware or lost key: the old key stops working immediately
+			if err := s.deviceRepo.UpdateAPIKeys(ctx, existing.ID, &apiKey, nil, nil); err != nil {
+				return err
+			}
+			existing.APIKey = &apiKey
+			existing.PreviousAPIKey = nil
+			existing.PreviousAPIKeyExpiresAt = nil
+			device = existing
+			reEnrolled = true
+		case err != nil && !errors.Is(err, sql.ErrNoRows):
+			return err
+		default:
+			now := time.Now()
+			device = &iot.Device{
+				DeviceID:   enrollment.DeviceID,
+				DeviceType: enrollment.DeviceType,
+				Name:       enrollment.Name,
+				Status:     iot.DeviceStatusActive,
+				APIKey:     &apiKey,
+				LastSeen:   &now,
+			}
+			if err := s.deviceRepo.Create(ctx, device); err != nil {
+				return err
+			}
+		}
+
+		if err := s.deviceEnrollmentRepo.SetEnrolledDevice(ctx, enrollment.ID, device.ID); err != nil {
+			return err
+		}
+
+		return s.recordKeyEvent(ctx, device, device.DeviceID, audit.DeviceKeyEventDeviceEnrolled, KeyActor{IPAddress: ipAddress}, map[string]interface{}{
+			"enrollment_id": enrollment.ID,
+			"re_enrolled":   reEnrolled,
+		})
+	})
+	if err != nil {
+		return nil, &IoTError{Op: "EnrollDevice", Err: err}
+	}
+
+	return &DeviceCredentials{Device: device, APIKey: apiKey}, nil
+}
+
+// RotateAPIKey issues a new API key. The current key keeps working for the grace period
+// so the device can pick up the new one; a grace of 0 uses DefaultKeyRotationGrace.
+func (s *service) RotateAPIKey(ctx context.Context, id int64, grace time.Duration, actor KeyActor) (*DeviceCredentials, error) {
+	if grace == 0 {
+		grace = DefaultKeyRotationGrace
+	}
+	if grace < 0 || grace > MaxKeyRotationGrace {
+		return nil, &IoTError{Op: "RotateAPIKey", Err: ErrInvalidGracePeriod}
+	}
+
+	device, err := s.GetDeviceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := s.generateAPIKey()
+	if err != nil {
+		return nil, &IoTError{Op: "RotateAPIKey", Err: fmt.Errorf("failed to generate API key: %w", err)}
+	}
+
+	// A revoked device has no key to keep alive
+	var previousKey *string
+	var previousExpiresAt *time.Time
+	if device.HasAPIKey() {
+		expiresAt := time.Now().Add(grace)
+		previousKey = device.APIKey
+		previousExpiresAt = &expiresAt
+	}
+
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, _ bun.Tx) error {
+		if err := s.deviceRepo.UpdateAPIKeys(ctx, device.ID, &apiKey, previousKey, previousExpiresAt); err != nil {
+			return err
+		}
+		metadata := map[string]interface{}{"grace_seconds": int64(0)}
+		if previousExpiresAt != nil {
+			metadata["grace_seconds"] = int64(grace.Seconds())
+			metadata["previous_key_expires_at"] = *previousExpiresAt
+		}
+		return s.recordKeyEvent(ctx, device, device.DeviceID, audit.DeviceKeyEventAPIKeyRotated, actor, metadata)
+	})
+	if err != nil {
+		return nil, &IoTError{Op: "RotateAPIKey", Err: err}
+	}
+
+	device.APIKey = &apiKey
+	device.PreviousAPIKey = previousKey
+	device.PreviousAPIKeyExpiresAt = previousExpiresAt
+
+	return &DeviceCredentials{Device: device, APIKey: apiKey, PreviousKeyExpiresAt: previousExpiresAt}, nil
+}
+
+// RevokeAPIKey removes the device's current and previous API keys. The device can only
+// authenticate again after a rotation or a new enrollment.
+func (s *service) RevokeAPIKey(ctx context.Context, id int64, actor KeyActor) error {
+	device, err := s.GetDeviceByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, _ bun.Tx) error {
+		if err := s.deviceRepo.UpdateAPIKeys(ctx, device.ID, nil, nil, nil); err != nil {
+			return err
+		}
+		return s.recordKeyEvent(ctx, device, device.DeviceID, audit.DeviceKeyEventAPIKeyRevoked, actor, map[string]interface{}{
+			"had_grace_key": device.HasValidPreviousAPIKey(time.Now()),
+		})
+	})
+	if err != nil {
+		return &IoTError{Op: "RevokeAPIKey", Err: err}
+	}
+
+	return nil
+}
+
+// recordKeyEvent writes a device key audit record. It runs inside the caller's transaction,
+// so a failed audit write rolls back the key change.
+func (s *service) recordKeyEvent(ctx context.Context, device *iot.Device, deviceIdentifier, eventType string, actor KeyActor, metadata map[string]interface{}) error {
+	if s.deviceKeyEventRepo == nil {
+		return nil
+	}
+
+	event := &audit.DeviceKeyEvent{
+		DeviceIdentifier: deviceIdentifier,
+		EventType:        eventType,
+		IPAddress:        actor.IPAddress,
+		Metadata:         metadata,
+		CreatedAt:        time.Now(),
+	}
+	if device != nil && device.ID > 0 {
+		deviceID := device.ID
+		event.DeviceID = &deviceID
+	}
+	if actor.AccountID > 0 {
+		accountID := actor.AccountID
+		event.AccountID = &accountID
+	}
+
+	return s.deviceKeyEventRepo.Create(ctx, event)
+}
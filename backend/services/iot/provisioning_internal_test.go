@@ -0,0 +1,81 @@
+package iot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/iot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEnrollmentCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		code, err := generateEnrollmentCode()
+		require.NoError(t, err)
+		assert.Regexp(t, `^[A-HJ-NP-Z2-9]{4}-[A-HJ-NP-Z2-9]{4}$`, code)
+		seen[code] = true
+	}
+	assert.Greater(t, len(seen), 45, "codes should not repeat")
+}
+
+func TestCreateEnrollmentCode_Validation(t *testing.T) {
+	svc := &service{}
+	ctx := context.Background()
+
+	_, err := svc.CreateEnrollmentCode(ctx, EnrollmentCodeRequest{DeviceType: "rfid_reader"}, KeyActor{})
+	assert.True(t, errors.Is(err, ErrInvalidEnrollmentRequest), "device ID is required")
+
+	_, err = svc.CreateEnrollmentCode(ctx, EnrollmentCodeRequest{
+		DeviceID:   "reader-1",
+		DeviceType: "rfid_reader",
+		TTL:        MaxEnrollmentCodeTTL + time.Minute,
+	}, KeyActor{})
+	assert.True(t, errors.Is(err, ErrInvalidEnrollmentRequest), "TTL above the maximum")
+}
+
+func TestEnrollDevice_EmptyCode(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.EnrollDevice(context.Background(), " - ", "127.0.0.1")
+	assert.True(t, errors.Is(err, ErrInvalidEnrollmentCode))
+}
+
+func TestRotateAPIKey_InvalidGrace(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.RotateAPIKey(context.Background(), 42, -time.Minute, KeyActor{})
+	assert.True(t, errors.Is(err, ErrInvalidGracePeriod))
+}
+
+func TestDetectNewDevices_ListsPendingEnrollments(t *testing.T) {
+	name := "Mensa"
+	svc := &service{deviceEnrollmentRepo: &stubEnrollmentRepo{pending: []*iot.DeviceEnrollment{
+		{DeviceID: "reader-mensa", DeviceType: "rfid_reader", Name: &name, ExpiresAt: time.Now().Add(time.Minute)},
+	}}}
+
+	devices, err := svc.DetectNewDevices(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "reader-mensa", devices[0].DeviceID)
+	assert.Equal(t, &name, devices[0].Name)
+	assert.Equal(t, iot.DeviceStatusInactive, devices[0].Status)
+}
+
+// stubEnrollmentRepo returns a fixed list of pending enrollments
+type stubEnrollmentRepo struct {
+	pending []*iot.DeviceEnrollment
+}
+
+func (r *stubEnrollmentRepo) Create(_ context.Context, _ *iot.DeviceEnrollment) error { return nil }
+func (r *stubEnrollmentRepo) FindPending(_ context.Context, _ time.Time) ([]*iot.DeviceEnrollment, error) {
+	return r.pending, nil
+}
+func (r *stubEnrollmentRepo) Claim(_ context.Context, _ string, _ time.Time) (*iot.DeviceEnrollment, error) {
+	return nil, nil
+}
+func (r *stubEnrollmentRepo) SetEnrolledDevice(_ context.Context, _, _ int64) error { return nil }
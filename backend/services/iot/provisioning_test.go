@@ -0,0 +1,153 @@
+package iot_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/services/iot"
+	testpkg "github.com/moto-nrw/project-phoenix/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// cleanupProvisionedDevice removes a device created by enrollment together with its audit trail
+func cleanupProvisionedDevice(t *testing.T, db *bun.DB, deviceID string) {
+	t.Helper()
+	ctx := context.Background()
+	_, _ = db.NewDelete().TableExpr("audit.device_key_events").Where("device_identifier = ?", deviceID).Exec(ctx)
+	_, _ = db.NewDelete().TableExpr("iot.device_enrollments").Where("device_id = ?", deviceID).Exec(ctx)
+	_, _ = db.NewDelete().TableExpr("iot.devices").Where("device_id = ?", deviceID).Exec(ctx)
+}
+
+func TestIoTService_EnrollDevice(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	service := setupIoTService(t, db)
+	ctx := context.Background()
+
+	t.Run("exchanges a code for an API key exactly once", func(t *testing.T) {
+		// ARRANGE
+		deviceID := fmt.Sprintf("enroll-device-%d", time.Now().UnixNano())
+		defer cleanupProvisionedDevice(t, db, deviceID)
+
+		issued, err := service.CreateEnrollmentCode(ctx, iot.EnrollmentCodeRequest{
+			DeviceID:   deviceID,
+			DeviceType: "rfid_reader",
+			Name:       stringPtr("Eingang"),
+		}, iot.KeyActor{IPAddress: "127.0.0.1"})
+		require.NoError(t, err)
+		assert.Regexp(t, `^[A-Z2-9]{4}-[A-Z2-9]{4}$`, issued.Code)
+
+		// ACT - codes are case-insensitive and the dash is optional
+		creds, err := service.EnrollDevice(ctx, "  "+issued.Code[:4]+issued.Code[5:]+" ", "10.0.0.5")
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, deviceID, creds.Device.DeviceID)
+		assert.NotEmpty(t, creds.APIKey)
+
+		authenticated, err := service.GetDeviceByAPIKey(ctx, creds.APIKey)
+		require.NoError(t, err)
+		assert.Equal(t, creds.Device.ID, authenticated.ID)
+
+		_, err = service.EnrollDevice(ctx, issued.Code, "10.0.0.5")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, iot.ErrInvalidEnrollmentCode))
+	})
+
+	t.Run("re-enrolling an existing device replaces its key", func(t *testing.T) {
+		// ARRANGE
+		device := testpkg.CreateTestDevice(t, db, "re-enroll")
+		defer cleanupProvisionedDevice(t, db, device.DeviceID)
+
+		issued, err := service.CreateEnrollmentCode(ctx, iot.EnrollmentCodeRequest{
+			DeviceID:   device.DeviceID,
+			DeviceType: device.DeviceType,
+		}, iot.KeyActor{IPAddress: "127.0.0.1"})
+		require.NoError(t, err)
+
+		// ACT
+		creds, err := service.EnrollDevice(ctx, issued.Code, "10.0.0.5")
+
+		// ASSERT
+		require.NoError(t, err)
+		assert.Equal(t, device.ID, creds.Device.ID)
+
+		_, err = service.GetDeviceByAPIKey(ctx, *device.APIKey)
+		assert.Error(t, err, "old key must stop working after re-enrollment")
+	})
+
+	t.Run("rejects unknown codes", func(t *testing.T) {
+		_, err := service.EnrollDevice(ctx, "ZZZZ-ZZZZ", "10.0.0.5")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, iot.ErrInvalidEnrollmentCode))
+	})
+}
+
+func TestIoTService_RotateAndRevokeAPIKey(t *testing.T) {
+	db := testpkg.SetupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	service := setupIoTService(t, db)
+	ctx := context.Background()
+	actor := iot.KeyActor{IPAddress: "127.0.0.1"}
+
+	t.Run("old key works during the grace period", func(t *testing.T) {
+		// ARRANGE
+		device := testpkg.CreateTestDevice(t, db, "rotate")
+		defer cleanupProvisionedDevice(t, db, device.DeviceID)
+
+		// ACT
+		creds, err := service.RotateAPIKey(ctx, device.ID, time.Hour, actor)
+
+		// ASSERT
+		require.NoError(t, err)
+		require.NotNil(t, creds.PreviousKeyExpiresAt)
+		assert.NotEqual(t, *device.APIKey, creds.APIKey)
+
+		for _, key := range []string{*device.APIKey, creds.APIKey} {
+			found, err := service.GetDeviceByAPIKey(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, device.ID, found.ID)
+		}
+	})
+
+	t.Run("revocation disables both keys", func(t *testing.T) {
+		// ARRANGE
+		device := testpkg.CreateTestDevice(t, db, "revoke")
+		defer cleanupProvisionedDevice(t, db, device.DeviceID)
+
+		creds, err := service.RotateAPIKey(ctx, device.ID, time.Hour, actor)
+		require.NoError(t, err)
+
+		// ACT
+		err = service.RevokeAPIKey(ctx, device.ID, actor)
+
+		// ASSERT
+		require.NoError(t, err)
+		for _, key := range []string{*device.APIKey, creds.APIKey} {
+			_, err := service.GetDeviceByAPIKey(ctx, key)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("rejects grace periods above the maximum", func(t *testing.T) {
+		device := testpkg.CreateTestDevice(t, db, "rotate-grace")
+		defer cleanupProvisionedDevice(t, db, device.DeviceID)
+
+		_, err := service.RotateAPIKey(ctx, device.ID, iot.MaxKeyRotationGrace+time.Hour, actor)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, iot.ErrInvalidGracePeriod))
+	})
+
+	t.Run("returns not found for unknown devices", func(t *testing.T) {
+		err := service.RevokeAPIKey(ctx, 999999999, actor)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, iot.ErrDeviceNotFound))
+	})
+}
@@ -0,0 +1,79 @@
+package parent
+
+import "fmt"
+
+// InvalidCredentialsError is returned when credentials are invalid
+type InvalidCredentialsError struct{}
+
+func (e *InvalidCredentialsError) Error() string {
+	return "invalid credentials"
+}
+
+// AccountInactiveError is returned when a parent account is inactive
+type AccountInactiveError struct {
+	AccountID int64
+}
+
+func (e *AccountInactiveError) Error() string {
+	return fmt.Sprintf("parent account %d is inactive", e.AccountID)
+}
+
+// GuardianProfileNotFoundError is returned when no guardian profile is linked to a parent account
+type GuardianProfileNotFoundError struct {
+	AccountID int64
+}
+
+func (e *GuardianProfileNotFoundError) Error() string {
+	return fmt.Sprintf("no guardian profile linked to parent account %d", e.AccountID)
+}
+
+// StudentNotLinkedError is returned when a guardian accesses a student they are not linked to
+type StudentNotLinkedError struct {
+	StudentID int64
+}
+
+func (e *StudentNotLinkedError) Error() string {
+	return fmt.Sprintf("student %d is not linked to this guardian", e.StudentID)
+}
+
+// PickupExceptionNotFoundError is returned when a pickup exception does not exist for the student
+type PickupExceptionNotFoundError struct {
+	ExceptionID int64
+}
+
+func (e *PickupExceptionNotFoundError) Error() string {
+	return fmt.Sprintf("pickup exception with ID %d not found", e.ExceptionID)
+}
+
+// InvalidDataError is returned when data validation fails
+type InvalidDataError struct {
+	Err error
+}
+
+func (e *InvalidDataError) Error() string {
+	return fmt.Sprintf("invalid data: %v", e.Err)
+}
+
+func (e *InvalidDataError) Unwrap() error {
+	return e.Err
+}
+
+// AbsenceOverlapError is returned when a reported absence overlaps an existing absence
+type AbsenceOverlapError struct {
+	Err error
+}
+
+func (e *AbsenceOverlapError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AbsenceOverlapError) Unwrap() error {
+	return e.Err
+}
+
+// AbsencesUnavailableError is returned when student absences are not configured for the portal
+type AbsencesUnavailableError struct{}
+
+func (e *AbsencesUnavailableError) Error() string {
+	return "student absences are not available"
+}
@@ -0,0 +1,116 @@
+package parent
+
+import (
+	"context"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/active"
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	usersSvc "github.com/moto-nrw/project-phoenix/services/users"
+)
+
+// Service provides the guardian-facing operations of the parent portal.
+// Every student-scoped method first verifies that the student is linked to the
+// calling guardian through users.StudentGuardian.
+type Service interface {
+	// Login authenticates a parent account and returns a parent-scoped access token.
+	// There is no refresh token; parents log in again once the token expires.
+	Login(ctx context.Context, email, password string) (accessToken string, account *authModels.AccountParent, err error)
+
+	// GetChildren returns all students linked to the guardian with today's attendance
+	GetChildren(ctx context.Context, accountID int64) ([]*Child, error)
+
+	// GetChildAttendance returns today's attendance status for a linked student
+	GetChildAttendance(ctx context.Context, accountID, studentID int64) (*activeSvc.AttendanceStatus, error)
+
+	// GetPickupExceptions returns upcoming pickup exceptions for a linked student
+	GetPickupExceptions(ctx context.Context, accountID, studentID int64) ([]*schedule.StudentPickupException, error)
+
+	// CreatePickupException creates a guardian-authored pickup exception
+	CreatePickupException(ctx context.Context, accountID int64, exception *schedule.StudentPickupException) error
+
+	// UpdatePickupException updates a pickup exception of a linked student, keeping its original author
+	UpdatePickupException(ctx context.Context, accountID int64, exception *schedule.StudentPickupException) error
+
+	// DeletePickupException deletes a pickup exception of a linked student
+	DeletePickupException(ctx context.Context, accountID, studentID, exceptionID int64) error
+
+	// ReportSickness sets or clears the sick flag of a linked student.
+	// A new sickness report is also recorded as a one-day sick absence for today.
+	ReportSickness(ctx context.Context, accountID, studentID int64, sick bool) (*users.Student, error)
+
+	// GetAbsences returns the absence history of a linked student, most recent first
+	GetAbsences(ctx context.Context, accountID, studentID int64) ([]*active.StudentAbsence, error)
+
+	// ReportAbsence records a guardian-reported absence (sick, excused or vacation) for a linked student
+	ReportAbsence(ctx context.Context, accountID, studentID int64, req activeSvc.StudentAbsenceRequest) (*active.StudentAbsence, error)
+}
+
+// Child is a student as seen by one of their guardians
+type Child struct {
+	StudentID        int64
+	FirstName        string
+	LastName         string
+	SchoolClass      string
+	RelationshipType string
+	Sick             bool
+	SickSince        *time.Time
+	Attendance       *activeSvc.AttendanceStatus
+}
+
+// ParentAccountRepository exposes the parent account operations required for login.
+type ParentAccountRepository interface {
+	FindByEmail(ctx context.Context, email string) (*authModels.AccountParent, error)
+	UpdateLastLogin(ctx context.Context, id int64) error
+}
+
+// GuardianProfileFinder resolves the guardian profile behind a parent account.
+type GuardianProfileFinder interface {
+	FindByAccountID(ctx context.Context, accountID int64) (*users.GuardianProfile, error)
+}
+
+// GuardianLinks exposes the student-guardian lookups from the guardian service.
+type GuardianLinks interface {
+	GetStudentGuardians(ctx context.Context, studentID int64) ([]*usersSvc.GuardianWithRelationship, error)
+	GetGuardianStudents(ctx context.Context, guardianProfileID int64) ([]*usersSvc.StudentWithRelationship, error)
+}
+
+// StudentStore exposes the student operations required for sickness reports.
+type StudentStore interface {
+	FindByID(ctx context.Context, id interface{}) (*users.Student, error)
+	Update(ctx context.Context, student *users.Student) error
+}
+
+// SicknessNotifier broadcasts sick flag changes to the student's group teachers.
+type SicknessNotifier interface {
+	BroadcastStudentSickness(ctx context.Context, student *users.Student)
+}
+
+// PersonFinder resolves student names.
+type PersonFinder interface {
+	FindByID(ctx context.Context, id interface{}) (*users.Person, error)
+}
+
+// AttendanceReader exposes today's attendance lookup from the active service.
+type AttendanceReader interface {
+	GetStudentAttendanceStatus(ctx context.Context, studentID int64) (*activeSvc.AttendanceStatus, error)
+}
+
+// StudentAbsenceManager exposes the student absence operations from the student absence service.
+type StudentAbsenceManager interface {
+	ListAbsences(ctx context.Context, studentID int64) ([]*active.StudentAbsence, error)
+	CreateAbsence(ctx context.Context, studentID int64, reporter activeSvc.StudentAbsenceReporter, req activeSvc.StudentAbsenceRequest) (*active.StudentAbsence, error)
+	GetAbsenceOnDate(ctx context.Context, studentID int64, date time.Time) (*active.StudentAbsence, error)
+}
+
+// PickupExceptionManager exposes the pickup exception operations from the pickup schedule service.
+type PickupExceptionManager interface {
+	GetStudentPickupExceptionByID(ctx context.Context, exceptionID int64) (*schedule.StudentPickupException, error)
+	GetUpcomingStudentPickupExceptions(ctx context.Context, studentID int64) ([]*schedule.StudentPickupException, error)
+	CreateStudentPickupException(ctx context.Context, exception *schedule.StudentPickupException) error
+	UpdateStudentPickupException(ctx context.Context, exception *schedule.StudentPickupException) error
+	DeleteStudentPickupException(ctx context.Context, exceptionID int64) error
+}
@@ -0,0 +1,436 @@
+package parent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/auth/userpass"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+// guardianRole is the only role carried by parent-scoped tokens
+const guardianRole = "guardian"
+
+type service struct {
+	accountRepo      ParentAccountRepository
+	guardianProfiles GuardianProfileFinder
+	guardianLinks    GuardianLinks
+	studentRepo      StudentStore
+	personRepo       PersonFinder
+	attendance       AttendanceReader
+	pickupExceptions PickupExceptionManager
+	sickness         SicknessNotifier
+	absences         StudentAbsenceManager
+	tokenAuth        *jwt.TokenAuth
+	logger           *slog.Logger
+}
+
+// ServiceConfig holds dependencies for the parent portal service
+type ServiceConfig struct {
+	AccountRepo      ParentAccountRepository
+	GuardianProfiles GuardianProfileFinder
+	GuardianLinks    GuardianLinks
+	StudentRepo      StudentStore
+	PersonRepo       PersonFinder
+	Attendance       AttendanceReader
+	PickupExceptions PickupExceptionManager
+	Sickness         SicknessNotifier      // Optional
+	Absences         StudentAbsenceManager // Optional
+	TokenAuth        *jwt.TokenAuth
+	Logger           *slog.Logger
+}
+
+// NewService creates a new parent portal service
+func NewService(cfg ServiceConfig) (Service, error) {
+	tokenAuth := cfg.TokenAuth
+	if tokenAuth == nil {
+		var err error
+		tokenAuth, err = jwt.NewTokenAuth()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create token auth: %w", err)
+		}
+	}
+
+	return &service{
+		accountRepo:      cfg.AccountRepo,
+		guardianProfiles: cfg.GuardianProfiles,
+		guardianLinks:    cfg.GuardianLinks,
+		studentRepo:      cfg.StudentRepo,
+		personRepo:       cfg.PersonRepo,
+		attendance:       cfg.Attendance,
+		pickupExceptions: cfg.PickupExceptions,
+		sickness:         cfg.Sickness,
+		absences:         cfg.Absences,
+		tokenAuth:        tokenAuth,
+		logger:           cfg.Logger,
+	}, nil
+}
+
+func (s *service) getLogger() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return slog.Default()
+}
+
+// Login authenticates a parent account and returns a parent-scoped access token. No refresh
+// token is issued: it would have to be persisted and revocable like staff tokens.
+func (s *service) Login(ctx context.Context, email, password string) (string, *authModels.AccountParent, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	account, err := s.accountRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, &InvalidCredentialsError{}
+		}
+		return "", nil, err
+	}
+	if account == nil || account.PasswordHash == nil {
+		return "", nil, &InvalidCredentialsError{}
+	}
+
+	match, err := userpass.VerifyPassword(password, *account.PasswordHash)
+	if err != nil || !match {
+		return "", nil, &InvalidCredentialsError{}
+	}
+
+	// Checked after the password so inactive accounts cannot be probed
+	if !account.Active {
+		return "", nil, &AccountInactiveError{AccountID: account.ID}
+	}
+
+	profile, err := s.guardianProfiles.FindByAccountID(ctx, account.ID)
+	if err != nil || profile == nil {
+		return "", nil, &GuardianProfileNotFoundError{AccountID: account.ID}
+	}
+
+	accessClaims := jwt.AppClaims{
+		ID:          int(account.ID),
+		Sub:         fmt.Sprintf("parent:%d", account.ID),
+		Username:    account.Email,
+		FirstName:   profile.FirstName,
+		LastName:    profile.LastName,
+		Roles:       []string{guardianRole},
+		Permissions: []string{}, // Access is derived from student-guardian links, not permissions
+		IsAdmin:     false,
+		Scope:       jwt.ScopeParent,
+	}
+
+	accessToken, err := s.tokenAuth.CreateJWT(accessClaims)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	if err := s.accountRepo.UpdateLastLogin(ctx, account.ID); err != nil {
+		s.getLogger().Error("failed to update last login",
+			"parent_account_id", account.ID,
+			"error", err,
+		)
+	}
+
+	return accessToken, account, nil
+}
+
+// GetChildren returns all students linked to the guardian with today's attendance
+func (s *service) GetChildren(ctx context.Context, accountID int64) ([]*Child, error) {
+	profile, err := s.resolveProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.guardianLinks.GetGuardianStudents(ctx, profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guardian students: %w", err)
+	}
+
+	children := make([]*Child, 0, len(linked))
+	for _, link := range linked {
+		if link.Student == nil {
+			continue
+		}
+		children = append(children, s.buildChild(ctx, link.Student, link.Relationship))
+	}
+
+	return children, nil
+}
+
+// buildChild assembles a child overview; missing names or attendance are logged, not fatal
+func (s *service) buildChild(ctx context.Context, student *users.Student, relationship *users.StudentGuardian) *Child {
+	child := &Child{
+		StudentID:   student.ID,
+		SchoolClass: student.SchoolClass,
+		Sick:        student.Sick != nil && *student.Sick,
+		SickSince:   student.SickSince,
+	}
+	if relationship != nil {
+		child.RelationshipType = relationship.RelationshipType
+	}
+
+	if person, err := s.personRepo.FindByID(ctx, student.PersonID); err != nil {
+		s.getLogger().Warn("failed to load student name for parent portal",
+			"student_id", student.ID,
+			"error", err,
+		)
+	} else if person != nil {
+		child.FirstName = person.FirstName
+		child.LastName = person.LastName
+	}
+
+	if status, err := s.attendance.GetStudentAttendanceStatus(ctx, student.ID); err != nil {
+		s.getLogger().Warn("failed to load attendance for parent portal",
+			"student_id", student.ID,
+			"error", err,
+		)
+	} else {
+		child.Attendance = status
+	}
+
+	return child
+}
+
+// GetChildAttendance returns today's attendance status for a linked student
+func (s *service) GetChildAttendance(ctx context.Context, accountID, studentID int64) (*activeSvc.AttendanceStatus, error) {
+	if _, err := s.ensureLinked(ctx, accountID, studentID); err != nil {
+		return nil, err
+	}
+	return s.attendance.GetStudentAttendanceStatus(ctx, studentID)
+}
+
+// GetPickupExceptions returns upcoming pickup exceptions for a linked student
+func (s *service) GetPickupExceptions(ctx context.Context, accountID, studentID int64) ([]*schedule.StudentPickupException, error) {
+	if _, err := s.ensureLinked(ctx, accountID, studentID); err != nil {
+		return nil, err
+	}
+	return s.pickupExceptions.GetUpcomingStudentPickupExceptions(ctx, studentID)
+}
+
+// CreatePickupException creates a guardian-authored pickup exception
+func (s *service) CreatePickupException(ctx context.Context, accountID int64, exception *schedule.StudentPickupException) error {
+	profile, err := s.ensureLinked(ctx, accountID, exception.StudentID)
+	if err != nil {
+		return err
+	}
+
+	exception.CreatedBy = 0
+	exception.CreatedByGuardianID = &profile.ID
+	if err := validateGuardianException(exception); err != nil {
+		return err
+	}
+
+	return s.pickupExceptions.CreateStudentPickupException(ctx, exception)
+}
+
+// UpdatePickupException updates a pickup exception of a linked student, keeping its original author.
+// A nil reason keeps the existing reason.
+func (s *service) UpdatePickupException(ctx context.Context, accountID int64, exception *schedule.StudentPickupException) error {
+	if _, err := s.ensureLinked(ctx, accountID, exception.StudentID); err != nil {
+		return err
+	}
+
+	existing, err := s.findStudentException(ctx, exception.StudentID, exception.ID)
+	if err != nil {
+		return err
+	}
+
+	exception.CreatedBy = existing.CreatedBy
+	exception.CreatedByGuardianID = existing.CreatedByGuardianID
+	exception.CreatedAt = existing.CreatedAt
+	if exception.Reason == nil {
+		exception.Reason = existing.Reason
+	}
+	if err := validateGuardianException(exception); err != nil {
+		return err
+	}
+
+	return s.pickupExceptions.UpdateStudentPickupException(ctx, exception)
+}
+
+// DeletePickupException deletes a pickup exception of a linked student
+func (s *service) DeletePickupException(ctx context.Context, accountID, studentID, exceptionID int64) error {
+	if _, err := s.ensureLinked(ctx, accountID, studentID); err != nil {
+		return err
+	}
+
+	if _, err := s.findStudentException(ctx, studentID, exceptionID); err != nil {
+		return err
+	}
+
+	return s.pickupExceptions.DeleteStudentPickupException(ctx, exceptionID)
+}
+
+// ReportSickness sets or clears the sick flag of a linked student and records new reports
+// in the absence history
+func (s *service) ReportSickness(ctx context.Context, accountID, studentID int64, sick bool) (*users.Student, error) {
+	profile, err := s.ensureLinked(ctx, accountID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := s.studentRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student: %w", err)
+	}
+
+	wasSick := student.Sick != nil && *student.Sick
+	student.Sick = &sick
+	if sick {
+		// Keep the original report time when sickness is reported again
+		if student.SickSince == nil {
+			now := time.Now()
+			student.SickSince = &now
+		}
+	} else {
+		student.SickSince = nil
+	}
+
+	if err := s.studentRepo.Update(ctx, student); err != nil {
+		return nil, fmt.Errorf("failed to update student: %w", err)
+	}
+
+	if s.sickness != nil && sick != wasSick {
+		s.sickness.BroadcastStudentSickness(ctx, student)
+	}
+
+	if sick {
+		s.recordSickAbsence(ctx, profile.ID, studentID)
+	}
+
+	return student, nil
+}
+
+// recordSickAbsence keeps a sickness report in the absence history as a one-day sick
+// absence for today, unless an absence already covers today. Failures are only logged
+// since the sick flag is already set.
+func (s *service) recordSickAbsence(ctx context.Context, guardianProfileID, studentID int64) {
+	if s.absences == nil {
+		return
+	}
+
+	today := time.Now()
+	existing, err := s.absences.GetAbsenceOnDate(ctx, studentID, today)
+	if err != nil || existing != nil {
+		return
+	}
+
+	date := today.Format("2006-01-02")
+	_, err = s.absences.CreateAbsence(ctx, studentID,
+		activeSvc.StudentAbsenceReporter{GuardianProfileID: guardianProfileID},
+		activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeSick, DateStart: date, DateEnd: date})
+	if err != nil {
+		s.getLogger().WarnContext(ctx, "failed to record sick absence",
+			slog.Int64("student_id", studentID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// guardianAbsenceTypes are the absence types guardians may report; trips are entered by staff
+var guardianAbsenceTypes = []string{
+	active.StudentAbsenceTypeSick,
+	active.StudentAbsenceTypeExcused,
+	active.StudentAbsenceTypeVacation,
+}
+
+// GetAbsences returns the absence history of a linked student, most recent first
+func (s *service) GetAbsences(ctx context.Context, accountID, studentID int64) ([]*active.StudentAbsence, error) {
+	if _, err := s.ensureLinked(ctx, accountID, studentID); err != nil {
+		return nil, err
+	}
+	if s.absences == nil {
+		return nil, &AbsencesUnavailableError{}
+	}
+
+	return s.absences.ListAbsences(ctx, studentID)
+}
+
+// ReportAbsence records a guardian-reported absence for a linked student
+func (s *service) ReportAbsence(ctx context.Context, accountID, studentID int64, req activeSvc.StudentAbsenceRequest) (*active.StudentAbsence, error) {
+	profile, err := s.ensureLinked(ctx, accountID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if s.absences == nil {
+		return nil, &AbsencesUnavailableError{}
+	}
+	if !slices.Contains(guardianAbsenceTypes, req.AbsenceType) {
+		return nil, &InvalidDataError{Err: errors.New("absence_type must be one of: sick, excused, vacation")}
+	}
+
+	absence, err := s.absences.CreateAbsence(ctx, studentID,
+		activeSvc.StudentAbsenceReporter{GuardianProfileID: profile.ID}, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, activeSvc.ErrStudentAbsenceOverlap):
+			return nil, &AbsenceOverlapError{Err: err}
+		case errors.Is(err, activeSvc.ErrInvalidStudentAbsence):
+			return nil, &InvalidDataError{Err: err}
+		default:
+			return nil, err
+		}
+	}
+	return absence, nil
+}
+
+// resolveProfile returns the guardian profile linked to a parent account
+func (s *service) resolveProfile(ctx context.Context, accountID int64) (*users.GuardianProfile, error) {
+	profile, err := s.guardianProfiles.FindByAccountID(ctx, accountID)
+	if err != nil || profile == nil {
+		return nil, &GuardianProfileNotFoundError{AccountID: accountID}
+	}
+	return profile, nil
+}
+
+// ensureLinked verifies that the student is linked to the account's guardian profile
+func (s *service) ensureLinked(ctx context.Context, accountID, studentID int64) (*users.GuardianProfile, error) {
+	profile, err := s.resolveProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	guardians, err := s.guardianLinks.GetStudentGuardians(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student guardians: %w", err)
+	}
+
+	for _, g := range guardians {
+		if g.Profile != nil && g.Profile.ID == profile.ID {
+			return profile, nil
+		}
+	}
+
+	return nil, &StudentNotLinkedError{StudentID: studentID}
+}
+
+// findStudentException loads an exception and checks that it belongs to the student
+func (s *service) findStudentException(ctx context.Context, studentID, exceptionID int64) (*schedule.StudentPickupException, error) {
+	exception, err := s.pickupExceptions.GetStudentPickupExceptionByID(ctx, exceptionID)
+	if err != nil || exception == nil || exception.StudentID != studentID {
+		return nil, &PickupExceptionNotFoundError{ExceptionID: exceptionID}
+	}
+	return exception, nil
+}
+
+// validateGuardianException validates an exception and rejects dates in the past
+func validateGuardianException(exception *schedule.StudentPickupException) error {
+	if err := exception.Validate(); err != nil {
+		return &InvalidDataError{Err: err}
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, exception.ExceptionDate.Location())
+	if exception.ExceptionDate.Before(today) {
+		return &InvalidDataError{Err: errors.New("exception_date cannot be in the past")}
+	}
+
+	return nil
+}
@@ -0,0 +1,511 @@
+package parent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/auth/jwt"
+	"github.com/moto-nrw/project-phoenix/auth/userpass"
+	"github.com/moto-nrw/project-phoenix/models/active"
+	authModels "github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	parentSvc "github.com/moto-nrw/project-phoenix/services/parent"
+	usersSvc "github.com/moto-nrw/project-phoenix/services/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAccountID  int64 = 10
+	testProfileID  int64 = 20
+	linkedStudent  int64 = 30
+	foreignStudent int64 = 31
+)
+
+// =============================================================================
+// Fakes
+// =============================================================================
+
+type fakeAccounts struct {
+	account    *authModels.AccountParent
+	findErr    error
+	lastLogins []int64
+}
+
+func (f *fakeAccounts) FindByEmail(_ context.Context, _ string) (*authModels.AccountParent, error) {
+	return f.account, f.findErr
+}
+
+func (f *fakeAccounts) UpdateLastLogin(_ context.Context, id int64) error {
+	f.lastLogins = append(f.lastLogins, id)
+	return nil
+}
+
+type fakeProfiles struct{}
+
+func (fakeProfiles) FindByAccountID(_ context.Context, accountID int64) (*users.GuardianProfile, error) {
+	if accountID != testAccountID {
+		return nil, errors.New("guardian profile not found")
+	}
+	return &users.GuardianProfile{Model: base.Model{ID: testProfileID}, FirstName: "Eva", LastName: "Muster"}, nil
+}
+
+type fakeLinks struct{}
+
+func (fakeLinks) GetStudentGuardians(_ context.Context, studentID int64) ([]*usersSvc.GuardianWithRelationship, error) {
+	if studentID != linkedStudent {
+		return []*usersSvc.GuardianWithRelationship{}, nil
+	}
+	return []*usersSvc.GuardianWithRelationship{
+		{Profile: &users.GuardianProfile{Model: base.Model{ID: testProfileID}}},
+	}, nil
+}
+
+func (fakeLinks) GetGuardianStudents(_ context.Context, _ int64) ([]*usersSvc.StudentWithRelationship, error) {
+	sick := true
+	return []*usersSvc.StudentWithRelationship{
+		{
+			Student:      &users.Student{Model: base.Model{ID: linkedStudent}, PersonID: 1, SchoolClass: "2b", Sick: &sick},
+			Relationship: &users.StudentGuardian{RelationshipType: "parent"},
+		},
+	}, nil
+}
+
+type fakeStudents struct {
+	student *users.Student
+	updated *users.Student
+}
+
+func (f *fakeStudents) FindByID(_ context.Context, _ interface{}) (*users.Student, error) {
+	return f.student, nil
+}
+
+func (f *fakeStudents) Update(_ context.Context, student *users.Student) error {
+	f.updated = student
+	return nil
+}
+
+type fakePersons struct{}
+
+func (fakePersons) FindByID(_ context.Context, _ interface{}) (*users.Person, error) {
+	return &users.Person{FirstName: "Mia", LastName: "Muster"}, nil
+}
+
+type fakeAttendance struct{}
+
+func (fakeAttendance) GetStudentAttendanceStatus(_ context.Context, studentID int64) (*activeSvc.AttendanceStatus, error) {
+	return &activeSvc.AttendanceStatus{StudentID: studentID, Status: "checked_in"}, nil
+}
+
+type fakeExceptions struct {
+	existing *schedule.StudentPickupException
+	created  *schedule.StudentPickupException
+	updated  *schedule.StudentPickupException
+	deleted  []int64
+}
+
+func (f *fakeExceptions) GetStudentPickupExceptionByID(_ context.Context, exceptionID int64) (*schedule.StudentPickupException, error) {
+	if f.existing == nil || f.existing.ID != exceptionID {
+		return nil, fmt.Errorf("exception %d not found", exceptionID)
+	}
+	return f.existing, nil
+}
+
+func (f *fakeExceptions) GetUpcomingStudentPickupExceptions(_ context.Context, _ int64) ([]*schedule.StudentPickupException, error) {
+	return []*schedule.StudentPickupException{f.existing}, nil
+}
+
+func (f *fakeExceptions) CreateStudentPickupException(_ context.Context, exception *schedule.StudentPickupException) error {
+	f.created = exception
+	return nil
+}
+
+func (f *fakeExceptions) UpdateStudentPickupException(_ context.Context, exception *schedule.StudentPickupException) error {
+	f.updated = exception
+	return nil
+}
+
+func (f *fakeExceptions) DeleteStudentPickupException(_ context.Context, exceptionID int64) error {
+	f.deleted = append(f.deleted, exceptionID)
+	return nil
+}
+
+type fakeSickness struct {
+	notified []*users.Student
+}
+
+func (f *fakeSickness) BroadcastStudentSickness(_ context.Context, student *users.Student) {
+	f.notified = append(f.notified, student)
+}
+
+type fakeAbsences struct {
+	today    *active.StudentAbsence
+	created  []*active.StudentAbsence
+	reporter activeSvc.StudentAbsenceReporter
+	err      error
+}
+
+func (f *fakeAbsences) ListAbsences(_ context.Context, _ int64) ([]*active.StudentAbsence, error) {
+	return f.created, nil
+}
+
+func (f *fakeAbsences) CreateAbsence(_ context.Context, studentID int64, reporter activeSvc.StudentAbsenceReporter, req activeSvc.StudentAbsenceRequest) (*active.StudentAbsence, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	absence := &active.StudentAbsence{StudentID: studentID, AbsenceType: req.AbsenceType}
+	f.reporter = reporter
+	f.created = append(f.created, absence)
+	return absence, nil
+}
+
+func (f *fakeAbsences) GetAbsenceOnDate(_ context.Context, _ int64, _ time.Time) (*active.StudentAbsence, error) {
+	return f.today, nil
+}
+
+type testDeps struct {
+	accounts   *fakeAccounts
+	students   *fakeStudents
+	exceptions *fakeExceptions
+	sickness   *fakeSickness
+	absences   *fakeAbsences
+}
+
+func newTestService(t *testing.T) (parentSvc.Service, *testDeps) {
+	t.Helper()
+	deps := &testDeps{
+		accounts:   &fakeAccounts{},
+		students:   &fakeStudents{student: &users.Student{Model: base.Model{ID: linkedStudent}}},
+		exceptions: &fakeExceptions{},
+		sickness:   &fakeSickness{},
+		absences:   &fakeAbsences{},
+	}
+
+	tokenAuth, err := jwt.NewTokenAuthWithSecret("parent-portal-test-secret-at-least-32-chars")
+	require.NoError(t, err)
+
+	service, err := parentSvc.NewService(parentSvc.ServiceConfig{
+		AccountRepo:      deps.accounts,
+		GuardianProfiles: fakeProfiles{},
+		GuardianLinks:    fakeLinks{},
+		StudentRepo:      deps.students,
+		PersonRepo:       fakePersons{},
+		Attendance:       fakeAttendance{},
+		PickupExceptions: deps.exceptions,
+		Sickness:         deps.sickness,
+		Absences:         deps.absences,
+		TokenAuth:        tokenAuth,
+		Logger:           slog.Default(),
+	})
+	require.NoError(t, err)
+	return service, deps
+}
+
+func tomorrow() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// =============================================================================
+// Login Tests
+// =============================================================================
+
+func TestLogin_Success(t *testing.T) {
+	service, deps := newTestService(t)
+
+	hash, err := userpass.HashPassword("Secret123!", userpass.DefaultParams())
+	require.NoError(t, err)
+	deps.accounts.account = &authModels.AccountParent{
+		Model:        base.Model{ID: testAccountID},
+		Email:        "eva@example.com",
+		PasswordHash: &hash,
+		Active:       true,
+	}
+
+	access, account, err := service.Login(context.Background(), " EVA@example.com ", "Secret123!")
+	require.NoError(t, err)
+	assert.NotEmpty(t, access)
+	assert.Equal(t, testAccountID, account.ID)
+	assert.Equal(t, []int64{testAccountID}, deps.accounts.lastLogins)
+}
+
+func TestLogin_WrongPassword(t *testing.T) {
+	service, deps := newTestService(t)
+
+	hash, err := userpass.HashPassword("Secret123!", userpass.DefaultParams())
+	require.NoError(t, err)
+	deps.accounts.account = &authModels.AccountParent{Model: base.Model{ID: testAccountID}, PasswordHash: &hash, Active: true}
+
+	_, _, err = service.Login(context.Background(), "eva@example.com", "wrong")
+	assert.IsType(t, &parentSvc.InvalidCredentialsError{}, err)
+	assert.Empty(t, deps.accounts.lastLogins)
+}
+
+func TestLogin_InactiveAccount(t *testing.T) {
+	service, deps := newTestService(t)
+
+	hash, err := userpass.HashPassword("Secret123!", userpass.DefaultParams())
+	require.NoError(t, err)
+	deps.accounts.account = &authModels.AccountParent{Model: base.Model{ID: testAccountID}, PasswordHash: &hash, Active: false}
+
+	_, _, err = service.Login(context.Background(), "eva@example.com", "Secret123!")
+	assert.IsType(t, &parentSvc.AccountInactiveError{}, err)
+}
+
+func TestLogin_AccountWithoutPassword(t *testing.T) {
+	service, deps := newTestService(t)
+	deps.accounts.account = &authModels.AccountParent{Model: base.Model{ID: testAccountID}, Active: true}
+
+	_, _, err := service.Login(context.Background(), "eva@example.com", "anything")
+	assert.IsType(t, &parentSvc.InvalidCredentialsError{}, err)
+}
+
+func TestLogin_RepositoryError(t *testing.T) {
+	service, deps := newTestService(t)
+	deps.accounts.findErr = errors.New("database error")
+
+	_, _, err := service.Login(context.Background(), "eva@example.com", "Secret123!")
+	assert.ErrorContains(t, err, "database error")
+}
+
+// =============================================================================
+// Children and Attendance Tests
+// =============================================================================
+
+func TestGetChildren(t *testing.T) {
+	service, _ := newTestService(t)
+
+	children, err := service.GetChildren(context.Background(), testAccountID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+
+	child := children[0]
+	assert.Equal(t, linkedStudent, child.StudentID)
+	assert.Equal(t, "Mia", child.FirstName)
+	assert.Equal(t, "2b", child.SchoolClass)
+	assert.Equal(t, "parent", child.RelationshipType)
+	assert.True(t, child.Sick)
+	require.NotNil(t, child.Attendance)
+	assert.Equal(t, "checked_in", child.Attendance.Status)
+}
+
+func TestGetChildren_UnknownAccount(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.GetChildren(context.Background(), testAccountID+1)
+	assert.IsType(t, &parentSvc.GuardianProfileNotFoundError{}, err)
+}
+
+func TestGetChildAttendance_NotLinked(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.GetChildAttendance(context.Background(), testAccountID, foreignStudent)
+	assert.IsType(t, &parentSvc.StudentNotLinkedError{}, err)
+
+	status, err := service.GetChildAttendance(context.Background(), testAccountID, linkedStudent)
+	require.NoError(t, err)
+	assert.Equal(t, linkedStudent, status.StudentID)
+}
+
+// =============================================================================
+// Pickup Exception Tests
+// =============================================================================
+
+func TestCreatePickupException_SetsGuardianAuthor(t *testing.T) {
+	service, deps := newTestService(t)
+
+	exception := &schedule.StudentPickupException{StudentID: linkedStudent, ExceptionDate: tomorrow(), CreatedBy: 99}
+	require.NoError(t, service.CreatePickupException(context.Background(), testAccountID, exception))
+
+	require.NotNil(t, deps.exceptions.created)
+	assert.Zero(t, deps.exceptions.created.CreatedBy, "guardians cannot claim a staff author")
+	require.NotNil(t, deps.exceptions.created.CreatedByGuardianID)
+	assert.Equal(t, testProfileID, *deps.exceptions.created.CreatedByGuardianID)
+}
+
+func TestCreatePickupException_RejectsPastDate(t *testing.T) {
+	service, deps := newTestService(t)
+
+	exception := &schedule.StudentPickupException{StudentID: linkedStudent, ExceptionDate: time.Now().AddDate(0, 0, -2)}
+	err := service.CreatePickupException(context.Background(), testAccountID, exception)
+	assert.IsType(t, &parentSvc.InvalidDataError{}, err)
+	assert.Nil(t, deps.exceptions.created)
+}
+
+func TestCreatePickupException_NotLinked(t *testing.T) {
+	service, deps := newTestService(t)
+
+	exception := &schedule.StudentPickupException{StudentID: foreignStudent, ExceptionDate: tomorrow()}
+	err := service.CreatePickupException(context.Background(), testAccountID, exception)
+	assert.IsType(t, &parentSvc.StudentNotLinkedError{}, err)
+	assert.Nil(t, deps.exceptions.created)
+}
+
+func TestUpdatePickupException_PreservesAuthor(t *testing.T) {
+	service, deps := newTestService(t)
+
+	reason := "Arzttermin"
+	deps.exceptions.existing = &schedule.StudentPickupException{
+		Model:         base.Model{ID: 5},
+		StudentID:     linkedStudent,
+		ExceptionDate: tomorrow(),
+		Reason:        &reason,
+		CreatedBy:     7,
+	}
+
+	update := &schedule.StudentPickupException{Model: base.Model{ID: 5}, StudentID: linkedStudent, ExceptionDate: tomorrow().AddDate(0, 0, 1)}
+	require.NoError(t, service.UpdatePickupException(context.Background(), testAccountID, update))
+
+	require.NotNil(t, deps.exceptions.updated)
+	assert.Equal(t, deps.exceptions.existing.CreatedBy, deps.exceptions.updated.CreatedBy)
+	assert.Nil(t, deps.exceptions.updated.CreatedByGuardianID)
+	assert.Equal(t, &reason, deps.exceptions.updated.Reason)
+}
+
+func TestUpdatePickupException_OtherStudentsException(t *testing.T) {
+	service, deps := newTestService(t)
+	deps.exceptions.existing = &schedule.StudentPickupException{Model: base.Model{ID: 5}, StudentID: foreignStudent, ExceptionDate: tomorrow(), CreatedBy: 7}
+
+	update := &schedule.StudentPickupException{Model: base.Model{ID: 5}, StudentID: linkedStudent, ExceptionDate: tomorrow()}
+	err := service.UpdatePickupException(context.Background(), testAccountID, update)
+	assert.IsType(t, &parentSvc.PickupExceptionNotFoundError{}, err)
+	assert.Nil(t, deps.exceptions.updated)
+}
+
+func TestDeletePickupException(t *testing.T) {
+	service, deps := newTestService(t)
+	deps.exceptions.existing = &schedule.StudentPickupException{Model: base.Model{ID: 5}, StudentID: linkedStudent, ExceptionDate: tomorrow(), CreatedBy: 7}
+
+	err := service.DeletePickupException(context.Background(), testAccountID, linkedStudent, 6)
+	assert.IsType(t, &parentSvc.PickupExceptionNotFoundError{}, err)
+
+	require.NoError(t, service.DeletePickupException(context.Background(), testAccountID, linkedStudent, 5))
+	assert.Equal(t, []int64{5}, deps.exceptions.deleted)
+}
+
+// =============================================================================
+// Sickness Tests
+// =============================================================================
+
+func TestReportSickness(t *testing.T) {
+	service, deps := newTestService(t)
+
+	student, err := service.ReportSickness(context.Background(), testAccountID, linkedStudent, true)
+	require.NoError(t, err)
+	require.NotNil(t, student.Sick)
+	assert.True(t, *student.Sick)
+	require.NotNil(t, student.SickSince)
+	since := *student.SickSince
+
+	// Reporting again keeps the original timestamp
+	student, err = service.ReportSickness(context.Background(), testAccountID, linkedStudent, true)
+	require.NoError(t, err)
+	assert.Equal(t, since, *student.SickSince)
+
+	student, err = service.ReportSickness(context.Background(), testAccountID, linkedStudent, false)
+	require.NoError(t, err)
+	assert.False(t, *student.Sick)
+	assert.Nil(t, student.SickSince)
+	assert.Same(t, student, deps.students.updated)
+}
+
+func TestReportSickness_NotifiesOnChange(t *testing.T) {
+	service, deps := newTestService(t)
+
+	_, err := service.ReportSickness(context.Background(), testAccountID, linkedStudent, true)
+	require.NoError(t, err)
+	require.Len(t, deps.sickness.notified, 1)
+
+	// Repeated report doesn't change the flag, so nobody is notified
+	_, err = service.ReportSickness(context.Background(), testAccountID, linkedStudent, true)
+	require.NoError(t, err)
+	assert.Len(t, deps.sickness.notified, 1)
+
+	_, err = service.ReportSickness(context.Background(), testAccountID, linkedStudent, false)
+	require.NoError(t, err)
+	assert.Len(t, deps.sickness.notified, 2)
+}
+
+func TestReportSickness_NotLinked(t *testing.T) {
+	service, deps := newTestService(t)
+
+	_, err := service.ReportSickness(context.Background(), testAccountID, foreignStudent, true)
+	assert.IsType(t, &parentSvc.StudentNotLinkedError{}, err)
+	assert.Nil(t, deps.students.updated)
+}
+
+func TestReportSickness_RecordsSickAbsence(t *testing.T) {
+	service, deps := newTestService(t)
+
+	_, err := service.ReportSickness(context.Background(), testAccountID, linkedStudent, true)
+	require.NoError(t, err)
+	require.Len(t, deps.absences.created, 1)
+	assert.Equal(t, active.StudentAbsenceTypeSick, deps.absences.created[0].AbsenceType)
+	assert.Equal(t, testProfileID, deps.absences.reporter.GuardianProfileID)
+
+	// An absence already covering today is not duplicated
+	deps.absences.today = deps.absences.created[0]
+	_, err = service.ReportSickness(context.Background(), testAccountID, linkedStudent, true)
+	require.NoError(t, err)
+	assert.Len(t, deps.absences.created, 1)
+
+	// Clearing the flag keeps the history
+	_, err = service.ReportSickness(context.Background(), testAccountID, linkedStudent, false)
+	require.NoError(t, err)
+	assert.Len(t, deps.absences.created, 1)
+}
+
+// =============================================================================
+// Absence Tests
+// =============================================================================
+
+func TestReportAbsence(t *testing.T) {
+	service, deps := newTestService(t)
+	req := activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeVacation, DateStart: "2026-03-02", DateEnd: "2026-03-06"}
+
+	absence, err := service.ReportAbsence(context.Background(), testAccountID, linkedStudent, req)
+	require.NoError(t, err)
+	assert.Equal(t, linkedStudent, absence.StudentID)
+	assert.Equal(t, testProfileID, deps.absences.reporter.GuardianProfileID)
+
+	absences, err := service.GetAbsences(context.Background(), testAccountID, linkedStudent)
+	require.NoError(t, err)
+	assert.Len(t, absences, 1)
+}
+
+func TestReportAbsence_Rejected(t *testing.T) {
+	service, deps := newTestService(t)
+
+	// Trips are entered by staff
+	_, err := service.ReportAbsence(context.Background(), testAccountID, linkedStudent,
+		activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeTrip, DateStart: "2026-03-02", DateEnd: "2026-03-02"})
+	assert.IsType(t, &parentSvc.InvalidDataError{}, err)
+
+	deps.absences.err = fmt.Errorf("%w with existing sick absence from 2026-03-02 to 2026-03-02", activeSvc.ErrStudentAbsenceOverlap)
+	_, err = service.ReportAbsence(context.Background(), testAccountID, linkedStudent,
+		activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeSick, DateStart: "2026-03-02", DateEnd: "2026-03-02"})
+	assert.IsType(t, &parentSvc.AbsenceOverlapError{}, err)
+
+	deps.absences.err = fmt.Errorf("%w: date_end must not be before date_start", activeSvc.ErrInvalidStudentAbsence)
+	_, err = service.ReportAbsence(context.Background(), testAccountID, linkedStudent,
+		activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeSick, DateStart: "2026-03-02", DateEnd: "2026-03-01"})
+	assert.IsType(t, &parentSvc.InvalidDataError{}, err)
+
+	deps.absences.err = errors.New("failed to create student absence: connection refused")
+	_, err = service.ReportAbsence(context.Background(), testAccountID, linkedStudent,
+		activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeSick, DateStart: "2026-03-02", DateEnd: "2026-03-02"})
+	require.Error(t, err)
+	assert.NotErrorAs(t, err, new(*parentSvc.InvalidDataError))
+
+	_, err = service.ReportAbsence(context.Background(), testAccountID, foreignStudent,
+		activeSvc.StudentAbsenceRequest{AbsenceType: active.StudentAbsenceTypeSick})
+	assert.IsType(t, &parentSvc.StudentNotLinkedError{}, err)
+	assert.Empty(t, deps.absences.created)
+}
@@ -0,0 +1,465 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/ical"
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/education"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+)
+
+// Operation names for calendar feed errors
+const (
+	opCreateCalendarFeed = "create calendar feed"
+	opRevokeCalendarFeed = "revoke calendar feed"
+	opRenderCalendarFeed = "render calendar feed"
+)
+
+// calendarFeedTokenPrefix marks subscription tokens so they are recognizable in URLs and logs
+const calendarFeedTokenPrefix = "cal_"
+
+// feedUIDDomain is the right-hand side of the UIDs of generated events
+const feedUIDDomain = "@project-phoenix"
+
+// Substitutions that ended longer ago than this are left out of staff feeds
+const substitutionFeedLookback = 30 * 24 * time.Hour
+
+// Activity groups without a term recur open-ended; their closure days are excluded for this long
+const openEndedFeedHorizon = 365 * 24 * time.Hour
+
+// CalendarFeedService manages the iCalendar subscriptions of staff members
+type CalendarFeedService interface {
+	// ListFeeds returns the calendar feeds owned by a staff member, newest first
+	ListFeeds(ctx context.Context, staffID int64) ([]*schedule.CalendarFeed, error)
+
+	// CreateFeed creates a feed for the staff member's supervision plan, or for an activity group
+	// if activityGroupID is set. The returned token is only available here; it is stored hashed.
+	CreateFeed(ctx context.Context, staffID int64, name string, activityGroupID *int64) (*schedule.CalendarFeed, string, error)
+
+	// RevokeFeed revokes a feed of the staff member; feeds of other staff are reported as not found
+	RevokeFeed(ctx context.Context, feedID, staffID int64) error
+
+	// RenderFeed renders the iCalendar document of the feed identified by its token.
+	// Feeds of staff members whose account is inactive are not served.
+	RenderFeed(ctx context.Context, token string, now time.Time) ([]byte, error)
+}
+
+// CalendarFeedServiceDependencies contains all dependencies required by the calendar feed service
+type CalendarFeedServiceDependencies struct {
+	FeedRepo             schedule.CalendarFeedRepository
+	DateframeRepo        schedule.DateframeRepository
+	TimeframeRepo        schedule.TimeframeRepository
+	ActivityGroupRepo    activities.GroupRepository
+	ActivityScheduleRepo activities.ScheduleRepository
+	SupervisorRepo       activities.SupervisorPlannedRepository
+	SubstitutionRepo     education.GroupSubstitutionRepository
+	EducationGroupRepo   education.GroupRepository
+	StaffRepo            users.StaffRepository
+	AccountRepo          auth.AccountRepository
+	Logger               *slog.Logger
+}
+
+// calendarFeedService implements CalendarFeedService
+type calendarFeedService struct {
+	feedRepo             schedule.CalendarFeedRepository
+	dateframeRepo        schedule.DateframeRepository
+	timeframeRepo        schedule.TimeframeRepository
+	activityGroupRepo    activities.GroupRepository
+	activityScheduleRepo activities.ScheduleRepository
+	supervisorRepo       activities.SupervisorPlannedRepository
+	substitutionRepo     education.GroupSubstitutionRepository
+	educationGroupRepo   education.GroupRepository
+	staffRepo            users.StaffRepository
+	accountRepo          auth.AccountRepository
+	logger               *slog.Logger
+}
+
+// NewCalendarFeedService creates a new calendar feed service
+func NewCalendarFeedService(deps CalendarFeedServiceDependencies) CalendarFeedService {
+	return &calendarFeedService{
+		feedRepo:             deps.FeedRepo,
+		dateframeRepo:        deps.DateframeRepo,
+		timeframeRepo:        deps.TimeframeRepo,
+		activityGroupRepo:    deps.ActivityGroupRepo,
+		activityScheduleRepo: deps.ActivityScheduleRepo,
+		supervisorRepo:       deps.SupervisorRepo,
+		substitutionRepo:     deps.SubstitutionRepo,
+		educationGroupRepo:   deps.EducationGroupRepo,
+		staffRepo:            deps.StaffRepo,
+		accountRepo:          deps.AccountRepo,
+		logger:               deps.Logger,
+	}
+}
+
+// getLogger returns a nil-safe logger, falling back to slog.Default() if logger is nil
+func (s *calendarFeedService) getLogger() *slog.Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return slog.Default()
+}
+
+// ListFeeds returns the calendar feeds owned by a staff member, newest first
+func (s *calendarFeedService) ListFeeds(ctx context.Context, staffID int64) ([]*schedule.CalendarFeed, error) {
+	feeds, err := s.feedRepo.FindByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, &ScheduleError{Op: "list calendar feeds", Err: err}
+	}
+	return feeds, nil
+}
+
+// CreateFeed creates a feed and returns it together with its plaintext token
+func (s *calendarFeedService) CreateFeed(ctx context.Context, staffID int64, name string, activityGroupID *int64) (*schedule.CalendarFeed, string, error) {
+	if activityGroupID != nil {
+		if _, err := s.activityGroupRepo.FindByID(ctx, *activityGroupID); err != nil {
+			return nil, "", &ScheduleError{Op: opCreateCalendarFeed, Err: ErrCalendarFeedGroupNotFound}
+		}
+	}
+
+	token, err := generateFeedToken()
+	if err != nil {
+		return nil, "", &ScheduleError{Op: opCreateCalendarFeed, Err: err}
+	}
+
+	feed := &schedule.CalendarFeed{
+		StaffID:         staffID,
+		ActivityGroupID: activityGroupID,
+		Name:            name,
+		TokenHash:       schedule.HashFeedToken(token),
+	}
+	if err := s.feedRepo.Create(ctx, feed); err != nil {
+		return nil, "", &ScheduleError{Op: opCreateCalendarFeed, Err: err}
+	}
+
+	return feed, token, nil
+}
+
+// RevokeFeed revokes a feed of the staff member. Revoking a revoked feed is a no-op.
+func (s *calendarFeedService) RevokeFeed(ctx context.Context, feedID, staffID int64) error {
+	feed, err := s.feedRepo.FindByID(ctx, feedID)
+	if err != nil || feed == nil || feed.StaffID != staffID {
+		return &ScheduleError{Op: opRevokeCalendarFeed, Err: ErrCalendarFeedNotFound}
+	}
+	if feed.IsRevoked() {
+		return nil
+	}
+
+	feed.Revoke(time.Now())
+	if err := s.feedRepo.Update(ctx, feed); err != nil {
+		return &ScheduleError{Op: opRevokeCalendarFeed, Err: err}
+	}
+	return nil
+}
+
+// RenderFeed renders the iCalendar document of the feed identified by its token.
+// Unknown and revoked tokens, and tokens of staff members whose account is inactive,
+// are reported as ErrCalendarFeedNotFound.
+func (s *calendarFeedService) RenderFeed(ctx context.Context, token string, now time.Time) ([]byte, error) {
+	if !strings.HasPrefix(strings.TrimSpace(token), calendarFeedTokenPrefix) {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: ErrCalendarFeedNotFound}
+	}
+
+	feed, err := s.feedRepo.FindByTokenHash(ctx, schedule.HashFeedToken(token))
+	if err != nil {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: err}
+	}
+	if feed == nil || feed.IsRevoked() {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: ErrCalendarFeedNotFound}
+	}
+
+	ownerActive, err := s.ownerAccountActive(ctx, feed.StaffID)
+	if err != nil {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: err}
+	}
+	if !ownerActive {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: ErrCalendarFeedNotFound}
+	}
+
+	var events []ical.VEvent
+	if feed.IsStaffFeed() {
+		events, err = s.staffEvents(ctx, feed.StaffID, now)
+	} else {
+		events, err = s.activityGroupEvents(ctx, *feed.ActivityGroupID, "", now)
+	}
+	if err != nil {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: err}
+	}
+
+	// Last access is informational; a failed update must not break the subscription
+	if err := s.feedRepo.TouchLastAccessed(ctx, feed.ID, now); err != nil {
+		s.getLogger().WarnContext(ctx, "failed to record calendar feed access",
+			slog.Int64("feed_id", feed.ID),
+			slog.String("error", err.Error()))
+	}
+
+	cal := &ical.Calendar{Name: feed.Name, Stamp: now, Events: events}
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf); err != nil {
+		return nil, &ScheduleError{Op: opRenderCalendarFeed, Err: err}
+	}
+	return buf.Bytes(), nil
+}
+
+// ownerAccountActive reports whether the staff member owning a feed still has an active account.
+// Staff without a person or account cannot sign in and are treated as inactive.
+func (s *calendarFeedService) ownerAccountActive(ctx context.Context, staffID int64) (bool, error) {
+	staff, err := s.staffRepo.FindWithPerson(ctx, staffID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get feed owner: %w", err)
+	}
+	if staff == nil || staff.Person == nil || staff.Person.AccountID == nil {
+		return false, nil
+	}
+
+	account, err := s.accountRepo.FindByID(ctx, *staff.Person.AccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get feed owner account: %w", err)
+	}
+	return account != nil && account.IsActive(), nil
+}
+
+// staffEvents builds the supervision plan of a staff member: the weekly sessions of all activity
+// groups with a planned supervision, and current and upcoming group substitutions
+func (s *calendarFeedService) staffEvents(ctx context.Context, staffID int64, now time.Time) ([]ical.VEvent, error) {
+	supervisions, err := s.supervisorRepo.FindByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get planned supervisions: %w", err)
+	}
+
+	var events []ical.VEvent
+	seen := make(map[int64]bool, len(supervisions))
+	for _, sp := range supervisions {
+		if seen[sp.GroupID] {
+			continue
+		}
+		seen[sp.GroupID] = true
+
+		role := "Aufsicht"
+		if sp.IsPrimary {
+			role = "Hauptaufsicht"
+		}
+		groupEvents, err := s.activityGroupEvents(ctx, sp.GroupID, role, now)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, groupEvents...)
+	}
+
+	substitutionEvents, err := s.substitutionEvents(ctx, staffID, now)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, substitutionEvents...), nil
+}
+
+// substitutionEvents returns the substitutions of a staff member as all-day events
+func (s *calendarFeedService) substitutionEvents(ctx context.Context, staffID int64, now time.Time) ([]ical.VEvent, error) {
+	substitutions, err := s.substitutionRepo.FindBySubstituteStaff(ctx, staffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get substitutions: %w", err)
+	}
+
+	cutoff := dateOnly(now.Add(-substitutionFeedLookback))
+	groupIDs := make([]int64, 0, len(substitutions))
+	for _, sub := range substitutions {
+		groupIDs = append(groupIDs, sub.GroupID)
+	}
+	groups := map[int64]*education.Group{}
+	if len(groupIDs) > 0 {
+		if groups, err = s.educationGroupRepo.FindByIDs(ctx, groupIDs); err != nil {
+			return nil, fmt.Errorf("failed to get substitution groups: %w", err)
+		}
+	}
+
+	events := make([]ical.VEvent, 0, len(substitutions))
+	for _, sub := range substitutions {
+		if dateOnly(sub.EndDate).Before(cutoff) {
+			continue
+		}
+		summary := "Vertretung"
+		if g, ok := groups[sub.GroupID]; ok && g != nil {
+			summary += ": " + g.Name
+		}
+		events = append(events, ical.VEvent{
+			UID:         fmt.Sprintf("group-substitution-%d%s", sub.ID, feedUIDDomain),
+			Summary:     summary,
+			Description: sub.Reason,
+			Start:       dateOnly(sub.StartDate),
+			End:         dateOnly(sub.EndDate),
+			AllDay:      true,
+		})
+	}
+	return events, nil
+}
+
+// activityGroupEvents returns one weekly recurring event per schedule of the activity group
+func (s *calendarFeedService) activityGroupEvents(ctx context.Context, groupID int64, role string, now time.Time) ([]ical.VEvent, error) {
+	group, err := s.activityGroupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity group %d: %w", groupID, err)
+	}
+
+	schedules, err := s.activityScheduleRepo.FindByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity schedules: %w", err)
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	var term *schedule.Dateframe
+	if group.DateframeID != nil {
+		if term, err = s.dateframeRepo.FindByID(ctx, *group.DateframeID); err != nil {
+			return nil, fmt.Errorf("failed to get term of activity group %d: %w", groupID, err)
+		}
+	}
+
+	timeframes := make(map[int64]*schedule.Timeframe)
+	for _, sched := range schedules {
+		if sched.TimeframeID == nil {
+			continue
+		}
+		if _, ok := timeframes[*sched.TimeframeID]; ok {
+			continue
+		}
+		tf, err := s.timeframeRepo.FindByID(ctx, *sched.TimeframeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get timeframe %d: %w", *sched.TimeframeID, err)
+		}
+		timeframes[*sched.TimeframeID] = tf
+	}
+
+	from, to := feedPeriod(term, now)
+	closed, err := schedule.SchoolClosureDays(ctx, s.dateframeRepo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return activityEvents(group, role, schedules, timeframes, term, now, closed), nil
+}
+
+// feedPeriod returns the dates an activity recurs on: the group's term, or from the start of the
+// current week for a year if the group has none
+func feedPeriod(term *schedule.Dateframe, now time.Time) (time.Time, time.Time) {
+	if term != nil {
+		return dateOnly(term.StartDate), dateOnly(term.EndDate)
+	}
+	today := timezone.DateOfUTC(now)
+	from := today.AddDate(0, 0, 1-activities.ISOWeekday(today))
+	return from, dateOnly(from.Add(openEndedFeedHorizon))
+}
+
+// activityEvents converts the schedules of an activity group into weekly recurring events.
+// The RRULE is generated from a weekly RecurrenceRule ending with the term; public holidays and
+// closure days are excluded with EXDATEs. Schedules without a timeframe become all-day events.
+func activityEvents(
+	group *activities.Group,
+	role string,
+	schedules []*activities.Schedule,
+	timeframes map[int64]*schedule.Timeframe,
+	term *schedule.Dateframe,
+	now time.Time,
+	closed map[time.Time]string,
+) []ical.VEvent {
+	from, to := feedPeriod(term, now)
+
+	var until *time.Time
+	if term != nil {
+		until = &to
+	}
+
+	summary := group.Name
+	if role != "" {
+		summary = role + ": " + group.Name
+	}
+
+	events := make([]ical.VEvent, 0, len(schedules))
+	for _, sched := range schedules {
+		if sched.Weekday < activities.WeekdayMonday || sched.Weekday > activities.WeekdaySunday {
+			continue
+		}
+		first := from.AddDate(0, 0, (sched.Weekday-activities.ISOWeekday(from)+7)%7)
+		if first.After(to) {
+			continue
+		}
+
+		event := ical.VEvent{
+			UID:     fmt.Sprintf("activity-schedule-%d%s", sched.ID, feedUIDDomain),
+			Summary: summary,
+			Start:   first,
+			End:     first,
+			AllDay:  true,
+		}
+		if sched.TimeframeID != nil {
+			if tf := timeframes[*sched.TimeframeID]; tf != nil {
+				event.AllDay = false
+				event.Start = atClock(first, tf.StartTime)
+				event.End = time.Time{}
+				if tf.EndTime != nil {
+					event.End = atClock(first, *tf.EndTime)
+				}
+				event.Description = tf.Description
+			}
+		}
+
+		rule := &schedule.RecurrenceRule{
+			Frequency:     schedule.FrequencyWeekly,
+			IntervalCount: 1,
+			Weekdays:      []string{schedule.ValidWeekdays[sched.Weekday-1]},
+			EndDate:       until,
+		}
+		event.RRule = rule.RRule(event.AllDay)
+
+		for day := first; !day.After(to); day = day.AddDate(0, 0, 7) {
+			if _, ok := closed[day]; ok {
+				event.ExDates = append(event.ExDates, day)
+			}
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+// atClock returns the day at the wall-clock time of t in Europe/Berlin
+func atClock(day, t time.Time) time.Time {
+	hour, minute, sec := t.Clock()
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, sec, 0, timezone.Berlin)
+}
+
+// dateOnly returns the calendar date of t as midnight UTC
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// generateFeedToken returns a new random subscription token
+func generateFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	return calendarFeedTokenPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,311 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/activities"
+	"github.com/moto-nrw/project-phoenix/models/auth"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/education"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feedRepo keeps calendar feeds in memory
+type feedRepo struct {
+	schedule.CalendarFeedRepository
+	feeds   map[int64]*schedule.CalendarFeed
+	touched map[int64]time.Time
+}
+
+func (m *feedRepo) Create(_ context.Context, feed *schedule.CalendarFeed) error {
+	if err := feed.Validate(); err != nil {
+		return err
+	}
+	feed.ID = int64(len(m.feeds) + 10)
+	m.feeds[feed.ID] = feed
+	return nil
+}
+
+func (m *feedRepo) Update(_ context.Context, feed *schedule.CalendarFeed) error {
+	m.feeds[feed.ID] = feed
+	return nil
+}
+
+func (m *feedRepo) FindByID(_ context.Context, id interface{}) (*schedule.CalendarFeed, error) {
+	if feed, ok := m.feeds[id.(int64)]; ok {
+		return feed, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *feedRepo) FindByTokenHash(_ context.Context, tokenHash string) (*schedule.CalendarFeed, error) {
+	for _, feed := range m.feeds {
+		if feed.TokenHash == tokenHash {
+			return feed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *feedRepo) TouchLastAccessed(_ context.Context, id int64, at time.Time) error {
+	m.touched[id] = at
+	return nil
+}
+
+type feedDateframeRepo struct {
+	schedule.DateframeRepository
+	term     *schedule.Dateframe
+	closures []*schedule.Dateframe
+}
+
+func (m *feedDateframeRepo) FindByID(_ context.Context, _ interface{}) (*schedule.Dateframe, error) {
+	return m.term, nil
+}
+
+func (m *feedDateframeRepo) FindClosures(_ context.Context, _, _ time.Time) ([]*schedule.Dateframe, error) {
+	return m.closures, nil
+}
+
+type feedTimeframeRepo struct {
+	schedule.TimeframeRepository
+	timeframe *schedule.Timeframe
+}
+
+func (m *feedTimeframeRepo) FindByID(_ context.Context, _ interface{}) (*schedule.Timeframe, error) {
+	return m.timeframe, nil
+}
+
+type feedActivityGroupRepo struct {
+	activities.GroupRepository
+	group *activities.Group
+}
+
+func (m *feedActivityGroupRepo) FindByID(_ context.Context, id interface{}) (*activities.Group, error) {
+	if id.(int64) != m.group.ID {
+		return nil, errors.New("not found")
+	}
+	return m.group, nil
+}
+
+type feedActivityScheduleRepo struct {
+	activities.ScheduleRepository
+	schedules []*activities.Schedule
+}
+
+func (m *feedActivityScheduleRepo) FindByGroupID(_ context.Context, _ int64) ([]*activities.Schedule, error) {
+	return m.schedules, nil
+}
+
+type feedSupervisorRepo struct {
+	activities.SupervisorPlannedRepository
+	plans []*activities.SupervisorPlanned
+}
+
+func (m *feedSupervisorRepo) FindByStaffID(_ context.Context, _ int64) ([]*activities.SupervisorPlanned, error) {
+	return m.plans, nil
+}
+
+type feedSubstitutionRepo struct {
+	education.GroupSubstitutionRepository
+	substitutions []*education.GroupSubstitution
+}
+
+func (m *feedSubstitutionRepo) FindBySubstituteStaff(_ context.Context, _ int64) ([]*education.GroupSubstitution, error) {
+	return m.substitutions, nil
+}
+
+type feedEducationGroupRepo struct {
+	education.GroupRepository
+}
+
+func (m *feedEducationGroupRepo) FindByIDs(_ context.Context, ids []int64) (map[int64]*education.Group, error) {
+	groups := make(map[int64]*education.Group, len(ids))
+	for _, id := range ids {
+		groups[id] = &education.Group{Model: base.Model{ID: id}, Name: "Gruppe Sonne"}
+	}
+	return groups, nil
+}
+
+type feedStaffRepo struct {
+	users.StaffRepository
+}
+
+func (m *feedStaffRepo) FindWithPerson(_ context.Context, id int64) (*users.Staff, error) {
+	accountID := id + 100
+	return &users.Staff{Model: base.Model{ID: id}, Person: &users.Person{AccountID: &accountID}}, nil
+}
+
+type feedAccountRepo struct {
+	auth.AccountRepository
+	inactive map[int64]bool
+}
+
+func (m *feedAccountRepo) FindByID(_ context.Context, id interface{}) (*auth.Account, error) {
+	return &auth.Account{Active: !m.inactive[id.(int64)]}, nil
+}
+
+func newTestCalendarFeedService() (*calendarFeedService, *feedRepo) {
+	termID := int64(20)
+	timeframeID := int64(50)
+	endTime := time.Date(2026, 1, 1, 15, 30, 0, 0, time.UTC)
+
+	feeds := &feedRepo{feeds: make(map[int64]*schedule.CalendarFeed), touched: make(map[int64]time.Time)}
+	svc := &calendarFeedService{
+		feedRepo: feeds,
+		dateframeRepo: &feedDateframeRepo{
+			term: &schedule.Dateframe{
+				Model:     base.Model{ID: termID},
+				StartDate: time.Date(2026, 8, 24, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2027, 1, 29, 0, 0, 0, 0, time.UTC),
+				Kind:      schedule.DateframeKindTerm,
+			},
+			closures: []*schedule.Dateframe{{
+				StartDate: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+				Name:      "Herbstferien",
+				Kind:      schedule.DateframeKindHoliday,
+			}},
+		},
+		timeframeRepo: &feedTimeframeRepo{timeframe: &schedule.Timeframe{
+			Model:     base.Model{ID: timeframeID},
+			StartTime: time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC),
+			EndTime:   &endTime,
+		}},
+		activityGroupRepo: &feedActivityGroupRepo{group: &activities.Group{
+			Model:       base.Model{ID: 30},
+			Name:        "Fußball-AG",
+			DateframeID: &termID,
+		}},
+		activityScheduleRepo: &feedActivityScheduleRepo{schedules: []*activities.Schedule{
+			{Model: base.Model{ID: 40}, Weekday: activities.WeekdayWednesday, TimeframeID: &timeframeID, ActivityGroupID: 30},
+		}},
+		supervisorRepo: &feedSupervisorRepo{plans: []*activities.SupervisorPlanned{
+			{StaffID: 10, GroupID: 30, IsPrimary: true},
+		}},
+		substitutionRepo: &feedSubstitutionRepo{substitutions: []*education.GroupSubstitution{
+			{Model: base.Model{ID: 60}, GroupID: 70, SubstituteStaffID: 10,
+				StartDate: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), Reason: "Fortbildung"},
+			{Model: base.Model{ID: 61}, GroupID: 70, SubstituteStaffID: 10,
+				StartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)},
+		}},
+		educationGroupRepo: &feedEducationGroupRepo{},
+		staffRepo:          &feedStaffRepo{},
+		accountRepo:        &feedAccountRepo{inactive: map[int64]bool{}},
+	}
+	return svc, feeds
+}
+
+func TestCalendarFeedService_RenderActivityGroupFeed(t *testing.T) {
+	svc, feeds := newTestCalendarFeedService()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	groupID := int64(30)
+
+	feed, token, err := svc.CreateFeed(ctx, 10, "Fußball-AG", &groupID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, calendarFeedTokenPrefix))
+	assert.Equal(t, schedule.HashFeedToken(token), feed.TokenHash)
+
+	body, err := svc.RenderFeed(ctx, token, now)
+	require.NoError(t, err)
+	out := string(body)
+
+	assert.Contains(t, out, "X-WR-CALNAME:Fußball-AG\r\n")
+	assert.Contains(t, out, "SUMMARY:Fußball-AG\r\n")
+	// First Wednesday of the term, at the timeframe's wall-clock time
+	assert.Contains(t, out, "DTSTART;TZID=Europe/Berlin:20260826T140000\r\n")
+	assert.Contains(t, out, "DTEND;TZID=Europe/Berlin:20260826T153000\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=WE;UNTIL=20270129T235959Z\r\n")
+	// Both Wednesdays of the autumn holidays are excluded
+	assert.Contains(t, out, "EXDATE;TZID=Europe/Berlin:20261021T140000\r\n")
+	assert.Contains(t, out, "EXDATE;TZID=Europe/Berlin:20261028T140000\r\n")
+	assert.Equal(t, 2, strings.Count(out, "EXDATE"))
+	assert.Equal(t, now, feeds.touched[feed.ID])
+}
+
+func TestCalendarFeedService_RenderStaffFeed(t *testing.T) {
+	svc, _ := newTestCalendarFeedService()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	feed, token, err := svc.CreateFeed(ctx, 10, "Mein Aufsichtsplan", nil)
+	require.NoError(t, err)
+	assert.True(t, feed.IsStaffFeed())
+
+	body, err := svc.RenderFeed(ctx, token, now)
+	require.NoError(t, err)
+	out := string(body)
+
+	assert.Contains(t, out, "SUMMARY:Hauptaufsicht: Fußball-AG\r\n")
+	assert.Contains(t, out, "UID:activity-schedule-40@project-phoenix\r\n")
+	assert.Contains(t, out, "SUMMARY:Vertretung: Gruppe Sonne\r\n")
+	assert.Contains(t, out, "DESCRIPTION:Fortbildung\r\n")
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20261019\r\n")
+	assert.Contains(t, out, "DTEND;VALUE=DATE:20261021\r\n")
+	assert.NotContains(t, out, "group-substitution-61", "past substitutions are left out")
+}
+
+func TestCalendarFeedService_RevokedAndUnknownTokens(t *testing.T) {
+	svc, _ := newTestCalendarFeedService()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	feed, token, err := svc.CreateFeed(ctx, 10, "Mein Aufsichtsplan", nil)
+	require.NoError(t, err)
+
+	_, err = svc.RenderFeed(ctx, "cal_unknown", now)
+	assert.ErrorIs(t, err, ErrCalendarFeedNotFound)
+	_, err = svc.RenderFeed(ctx, "not-a-token", now)
+	assert.ErrorIs(t, err, ErrCalendarFeedNotFound)
+
+	// Only the owner can revoke the feed
+	assert.ErrorIs(t, svc.RevokeFeed(ctx, feed.ID, 11), ErrCalendarFeedNotFound)
+	require.NoError(t, svc.RevokeFeed(ctx, feed.ID, 10))
+	require.NoError(t, svc.RevokeFeed(ctx, feed.ID, 10), "revoking twice is a no-op")
+
+	_, err = svc.RenderFeed(ctx, token, now)
+	assert.ErrorIs(t, err, ErrCalendarFeedNotFound)
+}
+
+func TestCalendarFeedService_InactiveOwner(t *testing.T) {
+	svc, feeds := newTestCalendarFeedService()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	feed, token, err := svc.CreateFeed(ctx, 10, "Mein Aufsichtsplan", nil)
+	require.NoError(t, err)
+
+	// Staff member 10 signs in with account 110, which has been deactivated
+	svc.accountRepo.(*feedAccountRepo).inactive[110] = true
+
+	_, err = svc.RenderFeed(ctx, token, now)
+	assert.ErrorIs(t, err, ErrCalendarFeedNotFound)
+	assert.NotContains(t, feeds.touched, feed.ID)
+}
+
+func TestCalendarFeedService_CreateFeedUnknownGroup(t *testing.T) {
+	svc, _ := newTestCalendarFeedService()
+	groupID := int64(99)
+
+	_, _, err := svc.CreateFeed(context.Background(), 10, "AG", &groupID)
+	assert.ErrorIs(t, err, ErrCalendarFeedGroupNotFound)
+}
+
+func TestActivityEvents_WithoutTermOrTimeframe(t *testing.T) {
+	group := &activities.Group{Model: base.Model{ID: 30}, Name: "Kunst-AG"}
+	schedules := []*activities.Schedule{{Model: base.Model{ID: 41}, Weekday: activities.WeekdayFriday, ActivityGroupID: 30}}
+	now := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC) // Wednesday
+
+	events := activityEvents(group, "", schedules, nil, nil, now, nil)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].AllDay)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), events[0].Start)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=1;BYDAY=FR", events[0].RRule, "groups without a term recur open-ended")
+}
@@ -0,0 +1,137 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/ical"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/uptrace/bun"
+)
+
+const opImportHolidayCalendar = "import holiday calendar"
+
+// CalendarImportResult summarizes an iCalendar import
+type CalendarImportResult struct {
+	Created    int                   `json:"created"`
+	Updated    int                   `json:"updated"`
+	Unchanged  int                   `json:"unchanged"`
+	Dateframes []*schedule.Dateframe `json:"dateframes"`
+}
+
+// ListClosures returns the holiday and closure dateframes overlapping the given date range
+func (s *service) ListClosures(ctx context.Context, startDate, endDate time.Time) ([]*schedule.Dateframe, error) {
+	if startDate.After(endDate) {
+		return nil, &ScheduleError{Op: "list closures", Err: ErrInvalidDateRange}
+	}
+
+	dateframes, err := s.dateframeRepo.FindClosures(ctx, startDate, endDate)
+	if err != nil {
+		return nil, &ScheduleError{Op: "list closures", Err: err}
+	}
+
+	return dateframes, nil
+}
+
+// ImportHolidayCalendar imports the events of an iCalendar file (e.g. the NRW school holidays) as
+// holiday or closure dateframes. Events are matched by their UID, so importing an updated file
+// again updates the existing dateframes instead of duplicating them. The file is imported in one
+// transaction; if any event fails, none of them are saved.
+func (s *service) ImportHolidayCalendar(ctx context.Context, r io.Reader, kind string) (*CalendarImportResult, error) {
+	if kind == "" {
+		kind = schedule.DateframeKindHoliday
+	}
+	if kind != schedule.DateframeKindHoliday && kind != schedule.DateframeKindClosure {
+		return nil, &ScheduleError{Op: opImportHolidayCalendar, Err: ErrInvalidDateframeKind}
+	}
+
+	events, err := ical.Parse(r)
+	if err != nil {
+		return nil, &ScheduleError{Op: opImportHolidayCalendar, Err: ErrInvalidCalendar}
+	}
+
+	var result *CalendarImportResult
+	err = s.txHandler.RunInTx(ctx, func(ctx context.Context, tx bun.Tx) error {
+		txService := s.WithTx(tx).(*service)
+		result, err = txService.importCalendarEvents(ctx, events, kind)
+		return err
+	})
+	if err != nil {
+		return nil, &ScheduleError{Op: opImportHolidayCalendar, Err: err}
+	}
+
+	return result, nil
+}
+
+// importCalendarEvents creates or updates a dateframe for each event
+func (s *service) importCalendarEvents(ctx context.Context, events []ical.Event, kind string) (*CalendarImportResult, error) {
+	result := &CalendarImportResult{Dateframes: make([]*schedule.Dateframe, 0, len(events))}
+	for _, event := range events {
+		imported := dateframeFromEvent(event, kind)
+
+		existing, err := s.dateframeRepo.FindByExternalUID(ctx, *imported.ExternalUID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case existing == nil:
+			if err := s.dateframeRepo.Create(ctx, imported); err != nil {
+				return nil, err
+			}
+			result.Created++
+			result.Dateframes = append(result.Dateframes, imported)
+		case sameCalendarEntry(existing, imported):
+			result.Unchanged++
+			result.Dateframes = append(result.Dateframes, existing)
+		default:
+			existing.StartDate = imported.StartDate
+			existing.EndDate = imported.EndDate
+			existing.Name = imported.Name
+			existing.Description = imported.Description
+			existing.Kind = imported.Kind
+			if err := s.dateframeRepo.Update(ctx, existing); err != nil {
+				return nil, err
+			}
+			result.Updated++
+			result.Dateframes = append(result.Dateframes, existing)
+		}
+	}
+
+	return result, nil
+}
+
+// dateframeFromEvent converts a calendar event into a dateframe of the given kind. Events without
+// a UID get one derived from their start date and summary so re-imports still match.
+func dateframeFromEvent(event ical.Event, kind string) *schedule.Dateframe {
+	uid := strings.TrimSpace(event.UID)
+	if uid == "" {
+		uid = fmt.Sprintf("%s/%s", event.Start.Format("20060102"), strings.ToLower(strings.TrimSpace(event.Summary)))
+	}
+
+	name := strings.TrimSpace(event.Summary)
+	if name == "" {
+		name = "Ferien"
+	}
+
+	return &schedule.Dateframe{
+		StartDate:   event.Start,
+		EndDate:     event.End,
+		Name:        name,
+		Description: strings.TrimSpace(event.Description),
+		Kind:        kind,
+		ExternalUID: &uid,
+	}
+}
+
+// sameCalendarEntry reports whether an imported dateframe carries no changes
+func sameCalendarEntry(existing, imported *schedule.Dateframe) bool {
+	return existing.StartDate.Equal(imported.StartDate) &&
+		existing.EndDate.Equal(imported.EndDate) &&
+		existing.Name == imported.Name &&
+		existing.Description == imported.Description &&
+		existing.Kind == imported.Kind
+}
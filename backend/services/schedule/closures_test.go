@@ -0,0 +1,125 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/ical"
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+// importDateframeRepo keeps dateframes in memory, keyed by external UID
+type importDateframeRepo struct {
+	schedule.DateframeRepository
+	byUID     map[string]*schedule.Dateframe
+	nextID    int64
+	updates   int
+	createErr error
+}
+
+// newImportService returns a schedule service whose transaction handler already holds a
+// transaction, so RunInTx runs without a database
+func newImportService(repo *importDateframeRepo) *service {
+	return &service{dateframeRepo: repo, txHandler: base.NewTxHandler(nil).WithTx(bun.Tx{})}
+}
+
+func (m *importDateframeRepo) FindByExternalUID(_ context.Context, uid string) (*schedule.Dateframe, error) {
+	return m.byUID[uid], nil
+}
+
+func (m *importDateframeRepo) Create(_ context.Context, dateframe *schedule.Dateframe) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	if err := dateframe.Validate(); err != nil {
+		return err
+	}
+	m.nextID++
+	dateframe.ID = m.nextID
+	m.byUID[*dateframe.ExternalUID] = dateframe
+	return nil
+}
+
+func (m *importDateframeRepo) Update(_ context.Context, dateframe *schedule.Dateframe) error {
+	m.updates++
+	return dateframe.Validate()
+}
+
+const holidayCalendar = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:herbstferien-2026-nw
+SUMMARY:Herbstferien
+DTSTART;VALUE=DATE:20261017
+DTEND;VALUE=DATE:20261101
+END:VEVENT
+BEGIN:VEVENT
+UID:weihnachtsferien-2026-nw
+SUMMARY:Weihnachtsferien
+DTSTART;VALUE=DATE:20261223
+DTEND;VALUE=DATE:20270107
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestImportHolidayCalendar_UpsertsByUID(t *testing.T) {
+	repo := &importDateframeRepo{byUID: make(map[string]*schedule.Dateframe), nextID: 10}
+	svc := newImportService(repo)
+	ctx := context.Background()
+
+	result, err := svc.ImportHolidayCalendar(ctx, strings.NewReader(holidayCalendar), "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	require.Len(t, result.Dateframes, 2)
+	assert.Equal(t, schedule.DateframeKindHoliday, result.Dateframes[0].Kind)
+	assert.Equal(t, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), result.Dateframes[0].EndDate)
+
+	// The ministry moved the christmas holidays by a day
+	updated := strings.Replace(holidayCalendar, "DTEND;VALUE=DATE:20270107", "DTEND;VALUE=DATE:20270108", 1)
+	result, err = svc.ImportHolidayCalendar(ctx, strings.NewReader(updated), schedule.DateframeKindHoliday)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, repo.updates)
+	assert.Len(t, repo.byUID, 2, "re-imports must not duplicate dateframes")
+	assert.Equal(t, time.Date(2027, 1, 7, 0, 0, 0, 0, time.UTC), repo.byUID["weihnachtsferien-2026-nw"].EndDate)
+}
+
+func TestImportHolidayCalendar_InvalidInput(t *testing.T) {
+	svc := newImportService(&importDateframeRepo{byUID: make(map[string]*schedule.Dateframe)})
+	ctx := context.Background()
+
+	_, err := svc.ImportHolidayCalendar(ctx, strings.NewReader(holidayCalendar), schedule.DateframeKindTerm)
+	assert.ErrorIs(t, err, ErrInvalidDateframeKind)
+
+	_, err = svc.ImportHolidayCalendar(ctx, strings.NewReader("Herbstferien;17.10.2026;31.10.2026"), "")
+	assert.ErrorIs(t, err, ErrInvalidCalendar)
+}
+
+func TestImportHolidayCalendar_SaveError(t *testing.T) {
+	repo := &importDateframeRepo{byUID: make(map[string]*schedule.Dateframe), createErr: errors.New("connection reset")}
+	svc := newImportService(repo)
+
+	result, err := svc.ImportHolidayCalendar(context.Background(), strings.NewReader(holidayCalendar), "")
+	assert.Nil(t, result)
+	var scheduleErr *ScheduleError
+	require.ErrorAs(t, err, &scheduleErr)
+	assert.Equal(t, opImportHolidayCalendar, scheduleErr.Op)
+	assert.ErrorContains(t, err, "connection reset")
+}
+
+func TestDateframeFromEvent_DerivesMissingUID(t *testing.T) {
+	day := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	df := dateframeFromEvent(ical.Event{Summary: " Pädagogischer Tag ", Start: day, End: day}, schedule.DateframeKindClosure)
+	require.NotNil(t, df.ExternalUID)
+	assert.Equal(t, "20261102/pädagogischer tag", *df.ExternalUID)
+	assert.Equal(t, "Pädagogischer Tag", df.Name)
+	assert.Equal(t, schedule.DateframeKindClosure, df.Kind)
+}
@@ -8,12 +8,16 @@ import (
 
 // Common schedule errors
 var (
-	ErrDateframeNotFound      = errors.New("dateframe not found")
-	ErrTimeframeNotFound      = errors.New("timeframe not found")
-	ErrRecurrenceRuleNotFound = errors.New("recurrence rule not found")
-	ErrInvalidDateRange       = errors.New("invalid date range")
-	ErrInvalidTimeRange       = errors.New("invalid time range")
-	ErrInvalidDuration        = errors.New("invalid duration")
+	ErrDateframeNotFound         = errors.New("dateframe not found")
+	ErrTimeframeNotFound         = errors.New("timeframe not found")
+	ErrRecurrenceRuleNotFound    = errors.New("recurrence rule not found")
+	ErrInvalidDateRange          = errors.New("invalid date range")
+	ErrInvalidTimeRange          = errors.New("invalid time range")
+	ErrInvalidDuration           = errors.New("invalid duration")
+	ErrInvalidCalendar           = errors.New("invalid iCalendar file")
+	ErrInvalidDateframeKind      = errors.New("invalid dateframe kind")
+	ErrCalendarFeedNotFound      = errors.New("calendar feed not found")
+	ErrCalendarFeedGroupNotFound = errors.New("activity group for calendar feed not found")
 )
 
 // ScheduleError represents a schedule-related error
@@ -129,6 +129,30 @@ func TestScheduleError_AllOperations(t *testing.T) {
 			err:  ErrInvalidDuration,
 			want: "schedule error during CalculateDuration: invalid duration",
 		},
+		{
+			name: "invalid calendar",
+			op:   "ImportHolidayCalendar",
+			err:  ErrInvalidCalendar,
+			want: "schedule error during ImportHolidayCalendar: invalid iCalendar file",
+		},
+		{
+			name: "invalid dateframe kind",
+			op:   "ImportHolidayCalendar",
+			err:  ErrInvalidDateframeKind,
+			want: "schedule error during ImportHolidayCalendar: invalid dateframe kind",
+		},
+		{
+			name: "calendar feed not found",
+			op:   "RenderCalendarFeed",
+			err:  ErrCalendarFeedNotFound,
+			want: "schedule error during RenderCalendarFeed: calendar feed not found",
+		},
+		{
+			name: "calendar feed group not found",
+			op:   "CreateCalendarFeed",
+			err:  ErrCalendarFeedGroupNotFound,
+			want: "schedule error during CreateCalendarFeed: activity group for calendar feed not found",
+		},
 	}
 
 	for _, tt := range tests {
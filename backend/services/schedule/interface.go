@@ -3,6 +3,7 @@ package schedule
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/moto-nrw/project-phoenix/models/base"
@@ -21,6 +22,10 @@ type Service interface {
 	FindDateframesByDate(ctx context.Context, date time.Time) ([]*schedule.Dateframe, error)
 	FindOverlappingDateframes(ctx context.Context, startDate, endDate time.Time) ([]*schedule.Dateframe, error)
 
+	// Holiday and closure calendar operations
+	ListClosures(ctx context.Context, startDate, endDate time.Time) ([]*schedule.Dateframe, error)
+	ImportHolidayCalendar(ctx context.Context, r io.Reader, kind string) (*CalendarImportResult, error)
+
 	// Timeframe operations
 	GetTimeframe(ctx context.Context, id int64) (*schedule.Timeframe, error)
 	CreateTimeframe(ctx context.Context, timeframe *schedule.Timeframe) error
@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/moto-nrw/project-phoenix/realtime"
+)
+
+// pickupDateLayout is the date format of pickup_exception events
+const pickupDateLayout = "2006-01-02"
+
+// isToday reports whether a pickup exception date is today (Berlin time)
+func isToday(date time.Time) bool {
+	return date.Format(pickupDateLayout) == timezone.Today().Format(pickupDateLayout)
+}
+
+// broadcastPickupException tells the student's group teachers that today's pickup changed.
+// Only exceptions for today are announced; the reason is never included (GDPR).
+// pickupTime is nil when the exception was removed or has no pickup time.
+func (s *pickupScheduleService) broadcastPickupException(ctx context.Context, studentID int64, date time.Time, pickupTime *time.Time) {
+	if s.broadcaster == nil || s.studentRepo == nil || !isToday(date) {
+		return
+	}
+
+	student, err := s.studentRepo.FindByID(ctx, studentID)
+	if err != nil || student == nil {
+		return
+	}
+
+	studentIDStr := strconv.FormatInt(studentID, 10)
+	pickupDate := date.Format(pickupDateLayout)
+	data := realtime.EventData{
+		StudentID:  &studentIDStr,
+		PickupDate: &pickupDate,
+	}
+	if pickupTime != nil {
+		formatted := pickupTime.Format("15:04")
+		data.PickupTime = &formatted
+	}
+	if s.personRepo != nil {
+		if person, err := s.personRepo.FindByID(ctx, student.PersonID); err == nil && person != nil {
+			name := fmt.Sprintf("%s %s", person.FirstName, person.LastName)
+			data.StudentName = &name
+		}
+	}
+
+	topics := []string{realtime.TopicSchool}
+	if student.GroupID != nil {
+		topics = append(topics, realtime.EducationGroupTopic(*student.GroupID))
+	}
+
+	event := realtime.NewEvent(realtime.EventPickupException, "", data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		slog.Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(realtime.EventPickupException)),
+			slog.String("student_id", studentIDStr),
+		)
+	}
+}
@@ -0,0 +1,20 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsToday(t *testing.T) {
+	today := timezone.Today()
+
+	// Exception dates come back from the DATE column as UTC midnight
+	assert.True(t, isToday(timezone.TodayUTC()))
+	assert.True(t, isToday(today))
+	assert.False(t, isToday(today.AddDate(0, 0, 1)))
+	assert.False(t, isToday(today.AddDate(0, 0, -1)))
+	assert.False(t, isToday(time.Time{}))
+}
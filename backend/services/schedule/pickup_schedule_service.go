@@ -9,6 +9,8 @@ import (
 	"github.com/moto-nrw/project-phoenix/internal/timezone"
 	"github.com/moto-nrw/project-phoenix/models/base"
 	"github.com/moto-nrw/project-phoenix/models/schedule"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
 	"github.com/uptrace/bun"
 )
 
@@ -84,21 +86,31 @@ type pickupScheduleService struct {
 	scheduleRepo  schedule.StudentPickupScheduleRepository
 	exceptionRepo schedule.StudentPickupExceptionRepository
 	noteRepo      schedule.StudentPickupNoteRepository
+	studentRepo   users.StudentRepository
+	personRepo    users.PersonRepository
+	broadcaster   realtime.Broadcaster
 	db            *bun.DB
 	txHandler     *base.TxHandler
 }
 
-// NewPickupScheduleService creates a new pickup schedule service
+// NewPickupScheduleService creates a new pickup schedule service.
+// studentRepo, personRepo and broadcaster are used for SSE events and may be nil.
 func NewPickupScheduleService(
 	scheduleRepo schedule.StudentPickupScheduleRepository,
 	exceptionRepo schedule.StudentPickupExceptionRepository,
 	noteRepo schedule.StudentPickupNoteRepository,
+	studentRepo users.StudentRepository,
+	personRepo users.PersonRepository,
+	broadcaster realtime.Broadcaster,
 	db *bun.DB,
 ) PickupScheduleService {
 	return &pickupScheduleService{
 		scheduleRepo:  scheduleRepo,
 		exceptionRepo: exceptionRepo,
 		noteRepo:      noteRepo,
+		studentRepo:   studentRepo,
+		personRepo:    personRepo,
+		broadcaster:   broadcaster,
 		db:            db,
 		txHandler:     base.NewTxHandler(db),
 	}
@@ -238,6 +250,8 @@ func (s *pickupScheduleService) CreateStudentPickupException(ctx context.Context
 	if err := s.exceptionRepo.Create(ctx, exception); err != nil {
 		return &ScheduleError{Op: opCreateStudentPickupException, Err: err}
 	}
+
+	s.broadcastPickupException(ctx, exception.StudentID, exception.ExceptionDate, exception.PickupTime)
 	return nil
 }
 
@@ -256,17 +270,31 @@ func (s *pickupScheduleService) UpdateStudentPickupException(ctx context.Context
 		return &ScheduleError{Op: opUpdateStudentPickupException, Err: errors.New("exception already exists for this date")}
 	}
 
+	// Remember the previous date so moving an exception away from today is announced too
+	previous, _ := s.exceptionRepo.FindByID(ctx, exception.ID)
+
 	if err := s.exceptionRepo.Update(ctx, exception); err != nil {
 		return &ScheduleError{Op: opUpdateStudentPickupException, Err: err}
 	}
+
+	if previous != nil && isToday(previous.ExceptionDate) && !isToday(exception.ExceptionDate) {
+		s.broadcastPickupException(ctx, exception.StudentID, previous.ExceptionDate, nil)
+	}
+	s.broadcastPickupException(ctx, exception.StudentID, exception.ExceptionDate, exception.PickupTime)
 	return nil
 }
 
 // DeleteStudentPickupException deletes a pickup exception by ID
 func (s *pickupScheduleService) DeleteStudentPickupException(ctx context.Context, exceptionID int64) error {
+	existing, _ := s.exceptionRepo.FindByID(ctx, exceptionID)
+
 	if err := s.exceptionRepo.Delete(ctx, exceptionID); err != nil {
 		return &ScheduleError{Op: "delete student pickup exception", Err: err}
 	}
+
+	if existing != nil {
+		s.broadcastPickupException(ctx, existing.StudentID, existing.ExceptionDate, nil)
+	}
 	return nil
 }
 
@@ -598,7 +598,7 @@ func (s *service) FindAvailableSlots(ctx context.Context, startDate, endDate tim
 	return availableSlots, nil
 }
 
-// GetCurrentDateframe gets the active dateframe for the current date
+// GetCurrentDateframe gets the active term dateframe for the current date; holidays and closures are skipped
 func (s *service) GetCurrentDateframe(ctx context.Context) (*schedule.Dateframe, error) {
 	now := time.Now()
 
@@ -607,11 +607,13 @@ func (s *service) GetCurrentDateframe(ctx context.Context) (*schedule.Dateframe,
 		return nil, &ScheduleError{Op: "get current dateframe", Err: err}
 	}
 
-	if len(dateframes) == 0 {
-		return nil, &ScheduleError{Op: "get current dateframe", Err: ErrDateframeNotFound}
+	// If multiple terms are active, prioritize by name or creation date
+	// For now, just return the first one
+	for _, dateframe := range dateframes {
+		if !dateframe.IsClosure() {
+			return dateframe, nil
+		}
 	}
 
-	// If multiple dateframes are active, prioritize by name or creation date
-	// For now, just return the first one
-	return dateframes[0], nil
+	return nil, &ScheduleError{Op: "get current dateframe", Err: ErrDateframeNotFound}
 }
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/internal/timezone"
+)
+
+// ActivityRegisterDeriver derives the attendance registers of ended activity sessions.
+type ActivityRegisterDeriver interface {
+	DeriveEndedSessions(ctx context.Context, from, to time.Time) (int, error)
+}
+
+// SetActivityRegisterDeriver sets the activity register deriver (optional).
+func (s *Scheduler) SetActivityRegisterDeriver(d ActivityRegisterDeriver) {
+	s.activityRegisters = d
+}
+
+// scheduleActivityRegisterTask schedules the derivation of activity attendance registers
+func (s *Scheduler) scheduleActivityRegisterTask() {
+	if s.activityRegisters == nil {
+		s.getLogger().Info("activity registers not configured (no ActivityRegisterDeriver)")
+		return
+	}
+
+	if os.Getenv("ACTIVITY_REGISTERS_ENABLED") == "false" {
+		s.getLogger().Info("activity registers are disabled")
+		return
+	}
+
+	// Sessions end on their own schedule; a few minutes of delay is fine for the register
+	intervalSeconds := parsePositiveIntEnv("ACTIVITY_REGISTER_INTERVAL_SECONDS", 300)
+
+	task := &ScheduledTask{
+		Name:     "activity-registers",
+		Schedule: strconv.Itoa(intervalSeconds) + "s",
+	}
+
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runActivityRegisterTask(task, intervalSeconds)
+}
+
+// runActivityRegisterTask derives the registers of ended sessions at configured intervals.
+// The first run covers everything that ended today so a restart does not lose sessions.
+func (s *Scheduler) runActivityRegisterTask(task *ScheduledTask, intervalSeconds int) {
+	defer s.wg.Done()
+
+	s.getLogger().Info("activity register task scheduled",
+		slog.Int("interval_seconds", intervalSeconds))
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	since := timezone.Today()
+	for {
+		select {
+		case <-ticker.C:
+			if until, ok := s.executeActivityRegisters(task, intervalSeconds, since); ok {
+				since = until
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// executeActivityRegisters derives the registers of sessions ended since the last successful run.
+// It returns the end of the covered window and whether the run succeeded.
+func (s *Scheduler) executeActivityRegisters(task *ScheduledTask, intervalSeconds int, since time.Time) (time.Time, bool) {
+	task.mu.Lock()
+	if task.Running {
+		task.mu.Unlock()
+		return since, false
+	}
+	task.Running = true
+	task.LastRun = time.Now()
+	task.mu.Unlock()
+
+	defer func() {
+		task.mu.Lock()
+		task.Running = false
+		task.NextRun = time.Now().Add(time.Duration(intervalSeconds) * time.Second)
+		task.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	until := time.Now()
+	derived, err := s.activityRegisters.DeriveEndedSessions(ctx, since, until)
+	if err != nil {
+		s.getLogger().Error("activity register derivation failed", "error", err)
+		return since, false
+	}
+
+	if derived > 0 {
+		s.getLogger().Info("activity registers derived",
+			slog.Int("registers", derived))
+	}
+	return until, true
+}
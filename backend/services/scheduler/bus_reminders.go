@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+// BusReminderDispatcher sends the "leave for the bus" reminders of bus routes.
+type BusReminderDispatcher interface {
+	DispatchBusReminders(ctx context.Context, now time.Time) (*activeSvc.BusReminderResult, error)
+}
+
+// SetBusReminderDispatcher sets the bus reminder dispatcher (optional).
+func (s *Scheduler) SetBusReminderDispatcher(d BusReminderDispatcher) {
+	s.busReminders = d
+}
+
+// scheduleBusReminderTask schedules the bus departure reminders
+func (s *Scheduler) scheduleBusReminderTask() {
+	if s.busReminders == nil {
+		s.getLogger().Info("bus reminders not configured (no BusReminderDispatcher)")
+		return
+	}
+
+	if os.Getenv("BUS_REMINDERS_ENABLED") == "false" {
+		s.getLogger().Info("bus reminders are disabled")
+		return
+	}
+
+	// Check every minute by default; departure times have minute precision
+	intervalSeconds := parsePositiveIntEnv("BUS_REMINDER_INTERVAL_SECONDS", 60)
+
+	task := &ScheduledTask{
+		Name:     "bus-reminders",
+		Schedule: strconv.Itoa(intervalSeconds) + "s",
+	}
+
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runBusReminderTask(task, intervalSeconds)
+}
+
+// runBusReminderTask sends due bus reminders at configured intervals.
+func (s *Scheduler) runBusReminderTask(task *ScheduledTask, intervalSeconds int) {
+	defer s.wg.Done()
+
+	s.getLogger().Info("bus reminder task scheduled",
+		slog.Int("interval_seconds", intervalSeconds))
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.executeBusReminders(task, intervalSeconds)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// executeBusReminders runs one bus reminder check.
+func (s *Scheduler) executeBusReminders(task *ScheduledTask, intervalSeconds int) {
+	task.mu.Lock()
+	if task.Running {
+		task.mu.Unlock()
+		return
+	}
+	task.Running = true
+	task.LastRun = time.Now()
+	task.mu.Unlock()
+
+	defer func() {
+		task.mu.Lock()
+		task.Running = false
+		task.NextRun = time.Now().Add(time.Duration(intervalSeconds) * time.Second)
+		task.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.busReminders.DispatchBusReminders(ctx, time.Now())
+	if err != nil {
+		s.getLogger().Error("bus reminders failed", "error", err)
+		return
+	}
+
+	if result.StudentsNotified > 0 {
+		s.getLogger().Info("bus reminders sent",
+			slog.Int("routes_due", result.RoutesDue),
+			slog.Int("students_notified", result.StudentsNotified))
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBusReminderDispatcher struct {
+	mu            sync.Mutex
+	dispatchCalls int
+}
+
+func (f *fakeBusReminderDispatcher) DispatchBusReminders(_ context.Context, _ time.Time) (*activeSvc.BusReminderResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dispatchCalls++
+	return &activeSvc.BusReminderResult{RoutesDue: 1, StudentsNotified: 4}, nil
+}
+
+func TestBusReminderDispatcher_InterfaceCompliance(_ *testing.T) {
+	var _ BusReminderDispatcher = &fakeBusReminderDispatcher{}
+	var _ BusReminderDispatcher = activeSvc.BusRouteService(nil)
+}
+
+func TestScheduleBusReminderTask_Disabled(t *testing.T) {
+	t.Setenv("BUS_REMINDERS_ENABLED", "false")
+
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetBusReminderDispatcher(&fakeBusReminderDispatcher{})
+	s.scheduleBusReminderTask()
+
+	_, exists := s.tasks["bus-reminders"]
+	assert.False(t, exists)
+}
+
+func TestScheduleBusReminderTask_NotConfigured(t *testing.T) {
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.scheduleBusReminderTask()
+
+	_, exists := s.tasks["bus-reminders"]
+	assert.False(t, exists)
+}
+
+func TestScheduleBusReminderTask_RemindsOnInterval(t *testing.T) {
+	t.Setenv("BUS_REMINDER_INTERVAL_SECONDS", "30")
+
+	synctest.Test(t, func(t *testing.T) {
+		dispatcher := &fakeBusReminderDispatcher{}
+		s := NewScheduler(nil, nil, nil, nil, slog.Default())
+		s.SetBusReminderDispatcher(dispatcher)
+		s.scheduleBusReminderTask()
+
+		time.Sleep(91 * time.Second)
+		synctest.Wait()
+
+		s.mu.RLock()
+		task, exists := s.tasks["bus-reminders"]
+		s.mu.RUnlock()
+		require.True(t, exists)
+		assert.Equal(t, "30s", task.Schedule)
+
+		dispatcher.mu.Lock()
+		assert.Equal(t, 3, dispatcher.dispatchCalls)
+		dispatcher.mu.Unlock()
+
+		close(s.done)
+		s.wg.Wait()
+	})
+}
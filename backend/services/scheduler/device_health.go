@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	iotSvc "github.com/moto-nrw/project-phoenix/services/iot"
+)
+
+// DeviceHealthMonitor exposes the IoT device health check.
+type DeviceHealthMonitor interface {
+	CheckDeviceHealth(ctx context.Context, threshold time.Duration) (*iotSvc.DeviceHealthResult, error)
+	CleanupDeviceEvents(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// SetDeviceHealthMonitor sets the IoT device health monitor (optional).
+// Device history older than DEVICE_EVENT_RETENTION_DAYS is purged with the hourly token cleanup.
+func (s *Scheduler) SetDeviceHealthMonitor(m DeviceHealthMonitor) {
+	s.deviceHealth = m
+	if m == nil {
+		return
+	}
+
+	retentionDays := parsePositiveIntEnv("DEVICE_EVENT_RETENTION_DAYS", 90)
+	s.cleanupJobs = append(s.cleanupJobs, CleanupJob{
+		Description: "Device event history cleanup",
+		Run: func(ctx context.Context) (int, error) {
+			return m.CleanupDeviceEvents(ctx, time.Duration(retentionDays)*24*time.Hour)
+		},
+	})
+}
+
+// parsePositiveIntEnv reads a positive integer from the environment, falling back to def
+func parsePositiveIntEnv(name string, def int) int {
+	if envValue := os.Getenv(name); envValue != "" {
+		if parsed, err := strconv.Atoi(envValue); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return def
+}
+
+// scheduleDeviceHealthTask schedules the IoT device health check
+func (s *Scheduler) scheduleDeviceHealthTask() {
+	if s.deviceHealth == nil {
+		s.getLogger().Info("device health monitoring not configured (no DeviceHealthMonitor)")
+		return
+	}
+
+	if os.Getenv("DEVICE_HEALTH_ENABLED") == "false" {
+		s.getLogger().Info("device health monitoring is disabled")
+		return
+	}
+
+	// Check every minute by default; devices are offline after 5 minutes without a ping,
+	// matching Device.IsOnline
+	intervalSeconds := parsePositiveIntEnv("DEVICE_HEALTH_INTERVAL_SECONDS", 60)
+	threshold := time.Duration(parsePositiveIntEnv("DEVICE_OFFLINE_THRESHOLD_MINUTES", 5)) * time.Minute
+
+	task := &ScheduledTask{
+		Name:     "device-health",
+		Schedule: strconv.Itoa(intervalSeconds) + "s",
+	}
+
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runDeviceHealthTask(task, intervalSeconds, threshold)
+}
+
+// runDeviceHealthTask checks device heartbeats at configured intervals.
+func (s *Scheduler) runDeviceHealthTask(task *ScheduledTask, intervalSeconds int, threshold time.Duration) {
+	defer s.wg.Done()
+
+	s.getLogger().Info("device health task scheduled",
+		slog.Int("interval_seconds", intervalSeconds),
+		slog.Duration("offline_threshold", threshold))
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.executeDeviceHealthCheck(task, intervalSeconds, threshold)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// executeDeviceHealthCheck runs one health check.
+func (s *Scheduler) executeDeviceHealthCheck(task *ScheduledTask, intervalSeconds int, threshold time.Duration) {
+	task.mu.Lock()
+	if task.Running {
+		task.mu.Unlock()
+		return
+	}
+	task.Running = true
+	task.LastRun = time.Now()
+	task.mu.Unlock()
+
+	defer func() {
+		task.mu.Lock()
+		task.Running = false
+		task.NextRun = time.Now().Add(time.Duration(intervalSeconds) * time.Second)
+		task.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.deviceHealth.CheckDeviceHealth(ctx, threshold)
+	if err != nil {
+		s.getLogger().Error("device health check failed", "error", err)
+		return
+	}
+
+	if result.WentOffline > 0 || result.CameOnline > 0 {
+		s.getLogger().Info("device health check completed",
+			slog.Int("devices_offline", result.WentOffline),
+			slog.Int("devices_online", result.CameOnline))
+	}
+}
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	iotSvc "github.com/moto-nrw/project-phoenix/services/iot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeviceHealth struct {
+	mu           sync.Mutex
+	checkCalls   int
+	threshold    time.Duration
+	retention    time.Duration
+	cleanupCalls int
+}
+
+func (f *fakeDeviceHealth) CheckDeviceHealth(_ context.Context, threshold time.Duration) (*iotSvc.DeviceHealthResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checkCalls++
+	f.threshold = threshold
+	return &iotSvc.DeviceHealthResult{WentOffline: 1}, nil
+}
+
+func (f *fakeDeviceHealth) CleanupDeviceEvents(_ context.Context, retention time.Duration) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleanupCalls++
+	f.retention = retention
+	return 3, nil
+}
+
+func TestDeviceHealthMonitor_InterfaceCompliance(_ *testing.T) {
+	var _ DeviceHealthMonitor = &fakeDeviceHealth{}
+	var _ DeviceHealthMonitor = iotSvc.Service(nil)
+}
+
+func TestSetDeviceHealthMonitor_RegistersCleanupJob(t *testing.T) {
+	t.Setenv("DEVICE_EVENT_RETENTION_DAYS", "30")
+
+	monitor := &fakeDeviceHealth{}
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetDeviceHealthMonitor(monitor)
+
+	require.Len(t, s.cleanupJobs, 1)
+	assert.Equal(t, "Device event history cleanup", s.cleanupJobs[0].Description)
+
+	require.NoError(t, s.RunCleanupJobs())
+	assert.Equal(t, 1, monitor.cleanupCalls)
+	assert.Equal(t, 30*24*time.Hour, monitor.retention)
+}
+
+func TestScheduleDeviceHealthTask_Disabled(t *testing.T) {
+	t.Setenv("DEVICE_HEALTH_ENABLED", "false")
+
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetDeviceHealthMonitor(&fakeDeviceHealth{})
+	s.scheduleDeviceHealthTask()
+
+	_, exists := s.tasks["device-health"]
+	assert.False(t, exists)
+}
+
+func TestScheduleDeviceHealthTask_NotConfigured(t *testing.T) {
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.scheduleDeviceHealthTask()
+
+	_, exists := s.tasks["device-health"]
+	assert.False(t, exists)
+}
+
+func TestScheduleDeviceHealthTask_ChecksOnInterval(t *testing.T) {
+	t.Setenv("DEVICE_HEALTH_INTERVAL_SECONDS", "30")
+	t.Setenv("DEVICE_OFFLINE_THRESHOLD_MINUTES", "10")
+
+	synctest.Test(t, func(t *testing.T) {
+		monitor := &fakeDeviceHealth{}
+		s := NewScheduler(nil, nil, nil, nil, slog.Default())
+		s.SetDeviceHealthMonitor(monitor)
+		s.scheduleDeviceHealthTask()
+
+		time.Sleep(31 * time.Second)
+		synctest.Wait()
+
+		s.mu.RLock()
+		task, exists := s.tasks["device-health"]
+		s.mu.RUnlock()
+		require.True(t, exists)
+		assert.Equal(t, "30s", task.Schedule)
+
+		monitor.mu.Lock()
+		assert.Equal(t, 1, monitor.checkCalls)
+		assert.Equal(t, 10*time.Minute, monitor.threshold)
+		monitor.mu.Unlock()
+
+		close(s.done)
+		s.wg.Wait()
+	})
+}
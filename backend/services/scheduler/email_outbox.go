@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// EmailOutboxProcessor exposes the durable email outbox worker.
+type EmailOutboxProcessor interface {
+	ProcessOutbox(ctx context.Context) (int, error)
+	CleanupOutbox(ctx context.Context) (int, error)
+}
+
+// SetEmailOutboxProcessor sets the email outbox worker (optional).
+// Finished outbox rows are purged together with the hourly token cleanup.
+func (s *Scheduler) SetEmailOutboxProcessor(p EmailOutboxProcessor) {
+	s.emailOutbox = p
+	if p == nil {
+		return
+	}
+	s.cleanupJobs = append(s.cleanupJobs, CleanupJob{
+		Description: "Email outbox cleanup",
+		Run: func(ctx context.Context) (int, error) {
+			return p.CleanupOutbox(ctx)
+		},
+	})
+}
+
+// EmailOutboxEnabled reports whether the email outbox worker runs (EMAIL_OUTBOX_ENABLED, default true).
+// Mail should only be queued in the outbox when it does; otherwise nothing would deliver it.
+func EmailOutboxEnabled() bool {
+	return os.Getenv("EMAIL_OUTBOX_ENABLED") != "false"
+}
+
+// scheduleEmailOutboxTask schedules the email outbox delivery task
+func (s *Scheduler) scheduleEmailOutboxTask() {
+	if s.emailOutbox == nil {
+		s.getLogger().Info("email outbox not configured (no EmailOutboxProcessor)")
+		return
+	}
+
+	if !EmailOutboxEnabled() {
+		s.getLogger().Info("email outbox worker is disabled")
+		return
+	}
+
+	// Parse interval from env (default 15 seconds)
+	intervalSeconds := 15
+	if envInterval := os.Getenv("EMAIL_OUTBOX_INTERVAL_SECONDS"); envInterval != "" {
+		if parsed, err := strconv.Atoi(envInterval); err == nil && parsed > 0 {
+			intervalSeconds = parsed
+		}
+	}
+
+	task := &ScheduledTask{
+		Name:     "email-outbox",
+		Schedule: strconv.Itoa(intervalSeconds) + "s",
+	}
+
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runEmailOutboxTask(task, intervalSeconds)
+}
+
+// runEmailOutboxTask delivers due outbox messages at configured intervals.
+func (s *Scheduler) runEmailOutboxTask(task *ScheduledTask, intervalSeconds int) {
+	defer s.wg.Done()
+
+	s.getLogger().Info("email outbox task scheduled",
+		slog.Int("interval_seconds", intervalSeconds))
+
+	// Deliver anything left over from before a restart right away
+	s.executeEmailOutbox(task, intervalSeconds)
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.executeEmailOutbox(task, intervalSeconds)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// executeEmailOutbox processes one batch of due outbox messages.
+func (s *Scheduler) executeEmailOutbox(task *ScheduledTask, intervalSeconds int) {
+	task.mu.Lock()
+	if task.Running {
+		task.mu.Unlock()
+		return
+	}
+	task.Running = true
+	task.LastRun = time.Now()
+	task.mu.Unlock()
+
+	defer func() {
+		task.mu.Lock()
+		task.Running = false
+		task.NextRun = time.Now().Add(time.Duration(intervalSeconds) * time.Second)
+		task.mu.Unlock()
+	}()
+
+	// SMTP round trips can be slow; still bound the batch so shutdown is not blocked
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	count, err := s.emailOutbox.ProcessOutbox(ctx)
+	if err != nil {
+		s.getLogger().Error("email outbox processing failed", "error", err)
+		return
+	}
+
+	if count > 0 {
+		s.getLogger().Info("email outbox processed",
+			slog.Int("emails_processed", count))
+	}
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"testing/synctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEmailOutbox struct {
+	mu           sync.Mutex
+	processCalls int
+	cleanupCalls int
+	processErr   error
+}
+
+func (f *fakeEmailOutbox) ProcessOutbox(_ context.Context) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.processCalls++
+	return 1, f.processErr
+}
+
+func (f *fakeEmailOutbox) CleanupOutbox(_ context.Context) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleanupCalls++
+	return 2, nil
+}
+
+func TestEmailOutboxProcessor_InterfaceCompliance(_ *testing.T) {
+	var _ EmailOutboxProcessor = &fakeEmailOutbox{}
+}
+
+func TestSetEmailOutboxProcessor_RegistersCleanupJob(t *testing.T) {
+	outbox := &fakeEmailOutbox{}
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetEmailOutboxProcessor(outbox)
+
+	require.Len(t, s.cleanupJobs, 1)
+	assert.Equal(t, "Email outbox cleanup", s.cleanupJobs[0].Description)
+
+	require.NoError(t, s.RunCleanupJobs())
+	assert.Equal(t, 1, outbox.cleanupCalls)
+}
+
+func TestSetEmailOutboxProcessor_Nil(t *testing.T) {
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetEmailOutboxProcessor(nil)
+
+	assert.Empty(t, s.cleanupJobs)
+}
+
+func TestScheduleEmailOutboxTask_NotConfigured(t *testing.T) {
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.scheduleEmailOutboxTask()
+
+	_, exists := s.tasks["email-outbox"]
+	assert.False(t, exists)
+}
+
+func TestScheduleEmailOutboxTask_Disabled(t *testing.T) {
+	t.Setenv("EMAIL_OUTBOX_ENABLED", "false")
+
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetEmailOutboxProcessor(&fakeEmailOutbox{})
+	s.scheduleEmailOutboxTask()
+
+	_, exists := s.tasks["email-outbox"]
+	assert.False(t, exists)
+}
+
+func TestScheduleEmailOutboxTask_RunsOnStartup(t *testing.T) {
+	t.Setenv("EMAIL_OUTBOX_INTERVAL_SECONDS", "30")
+
+	synctest.Test(t, func(t *testing.T) {
+		outbox := &fakeEmailOutbox{processErr: errors.New("claim failed")}
+		s := NewScheduler(nil, nil, nil, nil, slog.Default())
+		s.SetEmailOutboxProcessor(outbox)
+		s.scheduleEmailOutboxTask()
+
+		synctest.Wait()
+
+		s.mu.RLock()
+		task, exists := s.tasks["email-outbox"]
+		s.mu.RUnlock()
+		require.True(t, exists)
+		assert.Equal(t, "30s", task.Schedule)
+
+		outbox.mu.Lock()
+		calls := outbox.processCalls
+		outbox.mu.Unlock()
+		assert.Equal(t, 1, calls, "errors should be logged, not stop the task")
+
+		close(s.done)
+		s.wg.Wait()
+	})
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+// MissingStudentChecker exposes the check for expected students who have not checked in.
+type MissingStudentChecker interface {
+	CheckMissingStudents(ctx context.Context, now time.Time) (*activeSvc.MissingStudentCheckResult, error)
+}
+
+// SetMissingStudentChecker sets the missing student checker (optional).
+func (s *Scheduler) SetMissingStudentChecker(c MissingStudentChecker) {
+	s.missingStudents = c
+}
+
+// scheduleMissingStudentTask schedules the missing student check
+func (s *Scheduler) scheduleMissingStudentTask() {
+	if s.missingStudents == nil {
+		s.getLogger().Info("missing student alerts not configured (no MissingStudentChecker)")
+		return
+	}
+
+	if os.Getenv("MISSING_STUDENT_ALERTS_ENABLED") == "false" {
+		s.getLogger().Info("missing student alerts are disabled")
+		return
+	}
+
+	// Check every 5 minutes by default; the per-weekday grace period decides when
+	// students count as missing, so the interval only bounds the alert delay
+	intervalSeconds := parsePositiveIntEnv("MISSING_STUDENT_CHECK_INTERVAL_SECONDS", 300)
+
+	task := &ScheduledTask{
+		Name:     "missing-students",
+		Schedule: strconv.Itoa(intervalSeconds) + "s",
+	}
+
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runMissingStudentTask(task, intervalSeconds)
+}
+
+// runMissingStudentTask checks for missing students at configured intervals.
+func (s *Scheduler) runMissingStudentTask(task *ScheduledTask, intervalSeconds int) {
+	defer s.wg.Done()
+
+	s.getLogger().Info("missing student task scheduled",
+		slog.Int("interval_seconds", intervalSeconds))
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.executeMissingStudentCheck(task, intervalSeconds)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// executeMissingStudentCheck runs one missing student check.
+func (s *Scheduler) executeMissingStudentCheck(task *ScheduledTask, intervalSeconds int) {
+	task.mu.Lock()
+	if task.Running {
+		task.mu.Unlock()
+		return
+	}
+	task.Running = true
+	task.LastRun = time.Now()
+	task.mu.Unlock()
+
+	defer func() {
+		task.mu.Lock()
+		task.Running = false
+		task.NextRun = time.Now().Add(time.Duration(intervalSeconds) * time.Second)
+		task.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.missingStudents.CheckMissingStudents(ctx, time.Now())
+	if err != nil {
+		s.getLogger().Error("missing student check failed", "error", err)
+		return
+	}
+
+	if result.AlertsRaised > 0 {
+		s.getLogger().Info("missing student check completed",
+			slog.Int("students_expected", result.Expected),
+			slog.Int("alerts_raised", result.AlertsRaised))
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMissingStudentChecker struct {
+	mu         sync.Mutex
+	checkCalls int
+}
+
+func (f *fakeMissingStudentChecker) CheckMissingStudents(_ context.Context, _ time.Time) (*activeSvc.MissingStudentCheckResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checkCalls++
+	return &activeSvc.MissingStudentCheckResult{Checked: true, Expected: 12, AlertsRaised: 2}, nil
+}
+
+func TestMissingStudentChecker_InterfaceCompliance(_ *testing.T) {
+	var _ MissingStudentChecker = &fakeMissingStudentChecker{}
+	var _ MissingStudentChecker = activeSvc.MissingStudentService(nil)
+}
+
+func TestScheduleMissingStudentTask_Disabled(t *testing.T) {
+	t.Setenv("MISSING_STUDENT_ALERTS_ENABLED", "false")
+
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetMissingStudentChecker(&fakeMissingStudentChecker{})
+	s.scheduleMissingStudentTask()
+
+	_, exists := s.tasks["missing-students"]
+	assert.False(t, exists)
+}
+
+func TestScheduleMissingStudentTask_NotConfigured(t *testing.T) {
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.scheduleMissingStudentTask()
+
+	_, exists := s.tasks["missing-students"]
+	assert.False(t, exists)
+}
+
+func TestScheduleMissingStudentTask_ChecksOnInterval(t *testing.T) {
+	t.Setenv("MISSING_STUDENT_CHECK_INTERVAL_SECONDS", "60")
+
+	synctest.Test(t, func(t *testing.T) {
+		checker := &fakeMissingStudentChecker{}
+		s := NewScheduler(nil, nil, nil, nil, slog.Default())
+		s.SetMissingStudentChecker(checker)
+		s.scheduleMissingStudentTask()
+
+		time.Sleep(121 * time.Second)
+		synctest.Wait()
+
+		s.mu.RLock()
+		task, exists := s.tasks["missing-students"]
+		s.mu.RUnlock()
+		require.True(t, exists)
+		assert.Equal(t, "60s", task.Schedule)
+
+		checker.mu.Lock()
+		assert.Equal(t, 2, checker.checkCalls)
+		checker.mu.Unlock()
+
+		close(s.done)
+		s.wg.Wait()
+	})
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+)
+
+// PickupDispatcher exposes the notifications of the pickup dispatch board.
+type PickupDispatcher interface {
+	DispatchPickups(ctx context.Context, now time.Time) (*activeSvc.PickupDispatchResult, error)
+}
+
+// SetPickupDispatcher sets the pickup dispatcher (optional).
+func (s *Scheduler) SetPickupDispatcher(d PickupDispatcher) {
+	s.pickupDispatcher = d
+}
+
+// schedulePickupDispatchTask schedules the pickup dispatch
+func (s *Scheduler) schedulePickupDispatchTask() {
+	if s.pickupDispatcher == nil {
+		s.getLogger().Info("pickup dispatch not configured (no PickupDispatcher)")
+		return
+	}
+
+	if os.Getenv("PICKUP_DISPATCH_ENABLED") == "false" {
+		s.getLogger().Info("pickup dispatch is disabled")
+		return
+	}
+
+	// Check every minute by default; pickup times have minute precision
+	intervalSeconds := parsePositiveIntEnv("PICKUP_DISPATCH_INTERVAL_SECONDS", 60)
+
+	task := &ScheduledTask{
+		Name:     "pickup-dispatch",
+		Schedule: strconv.Itoa(intervalSeconds) + "s",
+	}
+
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.runPickupDispatchTask(task, intervalSeconds)
+}
+
+// runPickupDispatchTask dispatches due pickups at configured intervals.
+func (s *Scheduler) runPickupDispatchTask(task *ScheduledTask, intervalSeconds int) {
+	defer s.wg.Done()
+
+	s.getLogger().Info("pickup dispatch task scheduled",
+		slog.Int("interval_seconds", intervalSeconds))
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.executePickupDispatch(task, intervalSeconds)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// executePickupDispatch runs one pickup dispatch.
+func (s *Scheduler) executePickupDispatch(task *ScheduledTask, intervalSeconds int) {
+	task.mu.Lock()
+	if task.Running {
+		task.mu.Unlock()
+		return
+	}
+	task.Running = true
+	task.LastRun = time.Now()
+	task.mu.Unlock()
+
+	defer func() {
+		task.mu.Lock()
+		task.Running = false
+		task.NextRun = time.Now().Add(time.Duration(intervalSeconds) * time.Second)
+		task.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.pickupDispatcher.DispatchPickups(ctx, time.Now())
+	if err != nil {
+		s.getLogger().Error("pickup dispatch failed", "error", err)
+		return
+	}
+
+	if result.LeaveNowNotified > 0 || result.OverdueEscalated > 0 {
+		s.getLogger().Info("pickup dispatch completed",
+			slog.Int("leave_now_notified", result.LeaveNowNotified),
+			slog.Int("overdue_escalated", result.OverdueEscalated))
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	activeSvc "github.com/moto-nrw/project-phoenix/services/active"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePickupDispatcher struct {
+	mu            sync.Mutex
+	dispatchCalls int
+}
+
+func (f *fakePickupDispatcher) DispatchPickups(_ context.Context, _ time.Time) (*activeSvc.PickupDispatchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dispatchCalls++
+	return &activeSvc.PickupDispatchResult{LeaveNowNotified: 3}, nil
+}
+
+func TestPickupDispatcher_InterfaceCompliance(_ *testing.T) {
+	var _ PickupDispatcher = &fakePickupDispatcher{}
+	var _ PickupDispatcher = activeSvc.PickupBoardService(nil)
+}
+
+func TestSchedulePickupDispatchTask_Disabled(t *testing.T) {
+	t.Setenv("PICKUP_DISPATCH_ENABLED", "false")
+
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.SetPickupDispatcher(&fakePickupDispatcher{})
+	s.schedulePickupDispatchTask()
+
+	_, exists := s.tasks["pickup-dispatch"]
+	assert.False(t, exists)
+}
+
+func TestSchedulePickupDispatchTask_NotConfigured(t *testing.T) {
+	s := NewScheduler(nil, nil, nil, nil, slog.Default())
+	s.schedulePickupDispatchTask()
+
+	_, exists := s.tasks["pickup-dispatch"]
+	assert.False(t, exists)
+}
+
+func TestSchedulePickupDispatchTask_DispatchesOnInterval(t *testing.T) {
+	t.Setenv("PICKUP_DISPATCH_INTERVAL_SECONDS", "30")
+
+	synctest.Test(t, func(t *testing.T) {
+		dispatcher := &fakePickupDispatcher{}
+		s := NewScheduler(nil, nil, nil, nil, slog.Default())
+		s.SetPickupDispatcher(dispatcher)
+		s.schedulePickupDispatchTask()
+
+		time.Sleep(91 * time.Second)
+		synctest.Wait()
+
+		s.mu.RLock()
+		task, exists := s.tasks["pickup-dispatch"]
+		s.mu.RUnlock()
+		require.True(t, exists)
+		assert.Equal(t, "30s", task.Schedule)
+
+		dispatcher.mu.Lock()
+		assert.Equal(t, 3, dispatcher.dispatchCalls)
+		dispatcher.mu.Unlock()
+
+		close(s.done)
+		s.wg.Wait()
+	})
+}
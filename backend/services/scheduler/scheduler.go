@@ -48,6 +48,12 @@ type Scheduler struct {
 	invitationCleanup  InvitationCleaner
 	workSessionCleanup WorkSessionCleaner
 	breakAutoEnder     BreakAutoEnder
+	emailOutbox        EmailOutboxProcessor
+	deviceHealth       DeviceHealthMonitor
+	missingStudents    MissingStudentChecker
+	pickupDispatcher   PickupDispatcher
+	busReminders       BusReminderDispatcher
+	activityRegisters  ActivityRegisterDeriver
 	cleanupJobs        []CleanupJob
 	tasks              map[string]*ScheduledTask
 	mu                 sync.RWMutex
@@ -124,6 +130,24 @@ func (s *Scheduler) Start() {
 
 	// Schedule break auto-end task
 	s.scheduleBreakAutoEndTask()
+
+	// Schedule email outbox delivery
+	s.scheduleEmailOutboxTask()
+
+	// Schedule IoT device health monitoring
+	s.scheduleDeviceHealthTask()
+
+	// Schedule missing student alerts
+	s.scheduleMissingStudentTask()
+
+	// Schedule pickup dispatch (should-leave-now and overdue notifications)
+	s.schedulePickupDispatchTask()
+
+	// Schedule bus departure reminders
+	s.scheduleBusReminderTask()
+
+	// Schedule activity attendance register derivation
+	s.scheduleActivityRegisterTask()
 }
 
 // Stop gracefully stops the scheduler
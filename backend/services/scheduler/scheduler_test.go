@@ -1121,9 +1121,15 @@ func (m *mockActiveService) GetStudentAttendanceStatus(_ context.Context, _ int6
 func (m *mockActiveService) GetStudentsAttendanceStatuses(_ context.Context, _ []int64) (map[int64]*activeService.AttendanceStatus, error) {
 	return nil, nil
 }
+func (m *mockActiveService) GetStudentAbsenceOnDate(_ context.Context, _ int64, _ time.Time) (*active.StudentAbsence, error) {
+	return nil, nil
+}
 func (m *mockActiveService) ToggleStudentAttendance(_ context.Context, _, _, _ int64, _ bool) (*activeService.AttendanceResult, error) {
 	return nil, nil
 }
+func (m *mockActiveService) CheckOutStudent(_ context.Context, _, _, _ int64, _ bool, _ *activeService.CheckoutDetails) (*activeService.AttendanceResult, error) {
+	return nil, nil
+}
 func (m *mockActiveService) CheckTeacherStudentAccess(_ context.Context, _, _ int64) (bool, error) {
 	return false, nil
 }
@@ -45,6 +45,12 @@ var (
 
 	// ErrInvalidPIN indicates an invalid staff PIN
 	ErrInvalidPIN = errors.New("invalid staff PIN")
+
+	// ErrPickupPersonNotFound indicates the selected pickup person is not on the student's pickup list
+	ErrPickupPersonNotFound = errors.New("pickup person not found")
+
+	// ErrPickupNotAuthorized indicates the selected person may not collect the student
+	ErrPickupNotAuthorized = errors.New("person is not authorized to pick up this student")
 )
 
 // UsersError represents an error in the users service
@@ -23,6 +23,8 @@ const (
 	errMsgGuardianNotFound = "guardian profile not found: %w"
 	// errMsgPhoneNotFound is the error message format for phone number not found errors
 	errMsgPhoneNotFound = "phone number not found: %w"
+	// guardianInvitationEmailType identifies guardian invitation mails in the email outbox
+	guardianInvitationEmailType = "guardian_invitation"
 )
 
 // GuardianServiceDependencies contains all dependencies required by the guardian service
@@ -71,7 +73,7 @@ func NewGuardianService(deps GuardianServiceDependencies) GuardianService {
 		dispatcher = email.NewDispatcher(deps.Mailer, slog.Default().With("component", "email"))
 	}
 
-	return &guardianService{
+	service := &guardianService{
 		guardianProfileRepo:     deps.GuardianProfileRepo,
 		guardianPhoneNumberRepo: deps.GuardianPhoneNumberRepo,
 		studentGuardianRepo:     deps.StudentGuardianRepo,
@@ -86,6 +88,10 @@ func NewGuardianService(deps GuardianServiceDependencies) GuardianService {
 		db:                      deps.DB,
 		txHandler:               base.NewTxHandler(deps.DB),
 	}
+
+	dispatcher.RegisterHandler(guardianInvitationEmailType, service.persistInvitationDelivery)
+
+	return service
 }
 
 // WithTx returns a new service instance with repositories bound to the transaction
@@ -355,22 +361,48 @@ func (s *guardianService) sendInvitationEmail(invitation *authModels.GuardianInv
 	}
 
 	meta := email.DeliveryMetadata{
-		Type:        "guardian_invitation",
+		Type:        guardianInvitationEmailType,
 		ReferenceID: invitation.ID,
 		Token:       invitation.Token,
 		Recipient:   *profile.Email,
 	}
 
-	if s.dispatcher != nil {
-		s.dispatcher.Dispatch(context.Background(), email.DeliveryRequest{
-			Message:  message,
-			Metadata: meta,
-		})
+	// Outbox deliveries report to the handler registered for this type in NewGuardianService instead of the callback
+	s.dispatcher.Dispatch(context.Background(), email.DeliveryRequest{
+		Message:  message,
+		Metadata: meta,
+		Callback: s.persistInvitationDelivery,
+	})
+}
+
+// persistInvitationDelivery records a delivery attempt on the guardian invitation
+func (s *guardianService) persistInvitationDelivery(ctx context.Context, result email.DeliveryResult) {
+	var sentAt *time.Time
+	var errText *string
+
+	if result.Status == email.DeliveryStatusSent {
+		sentTime := result.SentAt
+		sentAt = &sentTime
+	} else if result.Err != nil {
+		msg := email.ErrorText(result.Err)
+		errText = &msg
 	}
 
-	// Update email status
-	now := time.Now()
-	_ = s.guardianInvitationRepo.UpdateEmailStatus(context.Background(), invitation.ID, &now, nil, 0)
+	if err := s.guardianInvitationRepo.UpdateEmailStatus(ctx, result.Metadata.ReferenceID, sentAt, errText, result.Attempt); err != nil {
+		slog.Error("failed to update guardian invitation delivery status",
+			slog.Int64("invitation_id", result.Metadata.ReferenceID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	if result.Final && result.Status == email.DeliveryStatusFailed {
+		slog.Error("guardian invitation email permanently failed",
+			slog.Int64("invitation_id", result.Metadata.ReferenceID),
+			slog.String("recipient", result.Metadata.Recipient),
+			slog.Any("error", result.Err),
+		)
+	}
 }
 
 // getStudentNamesForGuardian retrieves the full names of all students linked to a guardian
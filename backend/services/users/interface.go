@@ -85,4 +85,7 @@ type PersonService interface {
 
 	// GetAllStudentsWithGroups retrieves all students with their group info
 	GetAllStudentsWithGroups(ctx context.Context) ([]StudentWithGroup, error)
+
+	// BroadcastStudentSickness notifies the student's group teachers that the sick flag changed
+	BroadcastStudentSickness(ctx context.Context, student *userModels.Student)
 }
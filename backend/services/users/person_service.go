@@ -9,6 +9,7 @@ import (
 	"github.com/moto-nrw/project-phoenix/models/auth"
 	"github.com/moto-nrw/project-phoenix/models/base"
 	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
 	"github.com/uptrace/bun"
 )
 
@@ -47,7 +48,8 @@ type PersonServiceDependencies struct {
 	TeacherRepo        userModels.TeacherRepository
 
 	// Infrastructure
-	DB *bun.DB
+	DB          *bun.DB
+	Broadcaster realtime.Broadcaster // SSE event broadcaster (optional - can be nil for testing)
 }
 
 // personService implements the PersonService interface
@@ -61,6 +63,7 @@ type personService struct {
 	teacherRepo        userModels.TeacherRepository
 	db                 *bun.DB
 	txHandler          *base.TxHandler
+	broadcaster        realtime.Broadcaster
 }
 
 // NewPersonService creates a new person service
@@ -75,6 +78,7 @@ func NewPersonService(deps PersonServiceDependencies) PersonService {
 		teacherRepo:        deps.TeacherRepo,
 		db:                 deps.DB,
 		txHandler:          base.NewTxHandler(deps.DB),
+		broadcaster:        deps.Broadcaster,
 	}
 }
 
@@ -123,6 +127,7 @@ func (s *personService) WithTx(tx bun.Tx) interface{} {
 		teacherRepo:        teacherRepo,
 		db:                 s.db,
 		txHandler:          s.txHandler.WithTx(tx),
+		broadcaster:        s.broadcaster,
 	}
 }
 
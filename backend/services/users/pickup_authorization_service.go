@@ -0,0 +1,279 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/users"
+)
+
+// Pickup person types
+const (
+	PickupTypeGuardian = "guardian" // Guardian linked via StudentGuardian
+	PickupTypePerson   = "person"   // Named extra pickup person
+)
+
+// Reasons why a listed person may not collect the student
+const (
+	PickupReasonNotAuthorized = "not_authorized" // Flagged as not authorized
+	PickupReasonNotYetValid   = "not_yet_valid"  // Validity period has not started
+	PickupReasonExpired       = "expired"        // Validity period has ended
+)
+
+// AuthorizedPickup is one entry of a student's pickup list
+type AuthorizedPickup struct {
+	Type         string     `json:"type"` // guardian or person
+	ID           int64      `json:"id"`   // Guardian profile ID or pickup person ID
+	Name         string     `json:"name"`
+	Relationship string     `json:"relationship,omitempty"`
+	Phone        string     `json:"phone,omitempty"`
+	Authorized   bool       `json:"authorized"`
+	Reason       string     `json:"reason,omitempty"` // Set when the person may not collect the student
+	Notes        string     `json:"notes,omitempty"`
+	ValidFrom    *time.Time `json:"valid_from,omitempty"`
+	ValidUntil   *time.Time `json:"valid_until,omitempty"`
+}
+
+// PickupSelection identifies the person who collects a student at checkout
+type PickupSelection struct {
+	Type string
+	ID   int64
+}
+
+// PickupNotAuthorizedError is returned when the selected person may not collect the student.
+// It carries the list entry so callers can show the reason and notes.
+type PickupNotAuthorizedError struct {
+	Pickup *AuthorizedPickup
+}
+
+// Error returns the error message
+func (e *PickupNotAuthorizedError) Error() string {
+	return fmt.Sprintf("%s is not authorized to pick up this student (%s)", e.Pickup.Name, e.Pickup.Reason)
+}
+
+// Unwrap returns ErrPickupNotAuthorized so callers can use errors.Is
+func (e *PickupNotAuthorizedError) Unwrap() error {
+	return ErrPickupNotAuthorized
+}
+
+// PickupAuthorizationService manages the persons allowed to collect a student and
+// verifies the selection at checkout
+type PickupAuthorizationService interface {
+	// GetAuthorizedPickups lists the guardians and extra pickup persons of a student
+	// with their authorization state on the given date
+	GetAuthorizedPickups(ctx context.Context, studentID int64, date time.Time) ([]*AuthorizedPickup, error)
+
+	// VerifyPickup checks the selected person against the pickup list. Returns a
+	// *PickupNotAuthorizedError when the person is flagged or outside the validity period.
+	VerifyPickup(ctx context.Context, studentID int64, selection PickupSelection, date time.Time) (*AuthorizedPickup, error)
+
+	// GetStudentPickupPersons returns the extra pickup persons of a student
+	GetStudentPickupPersons(ctx context.Context, studentID int64) ([]*users.StudentPickupPerson, error)
+
+	// GetPickupPerson returns an extra pickup person by ID
+	GetPickupPerson(ctx context.Context, id int64) (*users.StudentPickupPerson, error)
+
+	// CreatePickupPerson adds an extra pickup person
+	CreatePickupPerson(ctx context.Context, person *users.StudentPickupPerson) error
+
+	// UpdatePickupPerson updates an extra pickup person
+	UpdatePickupPerson(ctx context.Context, person *users.StudentPickupPerson) error
+
+	// DeletePickupPerson removes an extra pickup person
+	DeletePickupPerson(ctx context.Context, id int64) error
+}
+
+// PickupGuardianProvider returns the guardians of a student (implemented by GuardianService)
+type PickupGuardianProvider interface {
+	GetStudentGuardians(ctx context.Context, studentID int64) ([]*GuardianWithRelationship, error)
+}
+
+// PickupAuthorizationServiceDependencies contains all dependencies required by the pickup authorization service
+type PickupAuthorizationServiceDependencies struct {
+	PickupPersonRepo users.StudentPickupPersonRepository
+	Guardians        PickupGuardianProvider
+}
+
+type pickupAuthorizationService struct {
+	pickupPersonRepo users.StudentPickupPersonRepository
+	guardians        PickupGuardianProvider
+}
+
+// NewPickupAuthorizationService creates a new pickup authorization service
+func NewPickupAuthorizationService(deps PickupAuthorizationServiceDependencies) PickupAuthorizationService {
+	return &pickupAuthorizationService{
+		pickupPersonRepo: deps.PickupPersonRepo,
+		guardians:        deps.Guardians,
+	}
+}
+
+// GetAuthorizedPickups lists the guardians and extra pickup persons of a student
+// with their authorization state on the given date
+func (s *pickupAuthorizationService) GetAuthorizedPickups(ctx context.Context, studentID int64, date time.Time) ([]*AuthorizedPickup, error) {
+	guardians, err := s.guardians.GetStudentGuardians(ctx, studentID)
+	if err != nil {
+		return nil, &UsersError{Op: "get authorized pickups", Err: err}
+	}
+
+	persons, err := s.pickupPersonRepo.FindByStudentID(ctx, studentID)
+	if err != nil {
+		return nil, &UsersError{Op: "get authorized pickups", Err: err}
+	}
+
+	pickups := make([]*AuthorizedPickup, 0, len(guardians)+len(persons))
+	for _, g := range guardians {
+		if g.Profile == nil || g.Relationship == nil {
+			continue
+		}
+		pickups = append(pickups, guardianPickup(g))
+	}
+	for _, p := range persons {
+		pickups = append(pickups, personPickup(p, date))
+	}
+	return pickups, nil
+}
+
+// guardianPickup converts a guardian relationship to a pickup list entry
+func guardianPickup(g *GuardianWithRelationship) *AuthorizedPickup {
+	pickup := &AuthorizedPickup{
+		Type:         PickupTypeGuardian,
+		ID:           g.Profile.ID,
+		Name:         g.Profile.GetFullName(),
+		Relationship: g.Relationship.RelationshipType,
+		Phone:        g.Profile.GetPrimaryPhone(),
+		Authorized:   g.Relationship.CanPickup,
+	}
+	if !pickup.Authorized {
+		pickup.Reason = PickupReasonNotAuthorized
+	}
+	if g.Relationship.PickupNotes != nil {
+		pickup.Notes = *g.Relationship.PickupNotes
+	}
+	return pickup
+}
+
+// personPickup converts an extra pickup person to a pickup list entry
+func personPickup(p *users.StudentPickupPerson, date time.Time) *AuthorizedPickup {
+	pickup := &AuthorizedPickup{
+		Type:         PickupTypePerson,
+		ID:           p.ID,
+		Name:         p.FullName(),
+		Relationship: p.Relationship,
+		Authorized:   p.IsAuthorized,
+		ValidFrom:    p.ValidFrom,
+		ValidUntil:   p.ValidUntil,
+	}
+	if p.Phone != nil {
+		pickup.Phone = *p.Phone
+	}
+	if p.Notes != nil {
+		pickup.Notes = *p.Notes
+	}
+
+	switch {
+	case !p.IsAuthorized:
+		pickup.Reason = PickupReasonNotAuthorized
+	case !p.IsValidOn(date):
+		pickup.Authorized = false
+		pickup.Reason = PickupReasonExpired
+		if p.ValidFrom != nil && date.Before(*p.ValidFrom) {
+			pickup.Reason = PickupReasonNotYetValid
+		}
+	}
+	return pickup
+}
+
+// VerifyPickup checks the selected person against the pickup list
+func (s *pickupAuthorizationService) VerifyPickup(ctx context.Context, studentID int64, selection PickupSelection, date time.Time) (*AuthorizedPickup, error) {
+	pickups, err := s.GetAuthorizedPickups(ctx, studentID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pickups {
+		if p.Type != selection.Type || p.ID != selection.ID {
+			continue
+		}
+		if !p.Authorized {
+			return nil, &PickupNotAuthorizedError{Pickup: p}
+		}
+		return p, nil
+	}
+
+	return nil, &UsersError{Op: "verify pickup", Err: ErrPickupPersonNotFound}
+}
+
+// GetStudentPickupPersons returns the extra pickup persons of a student
+func (s *pickupAuthorizationService) GetStudentPickupPersons(ctx context.Context, studentID int64) ([]*users.StudentPickupPerson, error) {
+	persons, err := s.pickupPersonRepo.FindByStudentID(ctx, studentID)
+	if err != nil {
+		return nil, &UsersError{Op: "get pickup persons", Err: err}
+	}
+	return persons, nil
+}
+
+// GetPickupPerson returns an extra pickup person by ID
+func (s *pickupAuthorizationService) GetPickupPerson(ctx context.Context, id int64) (*users.StudentPickupPerson, error) {
+	person, err := s.pickupPersonRepo.FindByID(ctx, id)
+	if err != nil || person == nil {
+		return nil, &UsersError{Op: "get pickup person", Err: ErrPickupPersonNotFound}
+	}
+	return person, nil
+}
+
+// CreatePickupPerson adds an extra pickup person
+func (s *pickupAuthorizationService) CreatePickupPerson(ctx context.Context, person *users.StudentPickupPerson) error {
+	normalizePickupPerson(person)
+	if err := person.Validate(); err != nil {
+		return &UsersError{Op: "create pickup person", Err: fmt.Errorf("%w: %v", ErrInvalidPersonData, err)}
+	}
+	if err := s.pickupPersonRepo.Create(ctx, person); err != nil {
+		return &UsersError{Op: "create pickup person", Err: err}
+	}
+	return nil
+}
+
+// UpdatePickupPerson updates an extra pickup person
+func (s *pickupAuthorizationService) UpdatePickupPerson(ctx context.Context, person *users.StudentPickupPerson) error {
+	normalizePickupPerson(person)
+	if err := person.Validate(); err != nil {
+		return &UsersError{Op: "update pickup person", Err: fmt.Errorf("%w: %v", ErrInvalidPersonData, err)}
+	}
+	if err := s.pickupPersonRepo.Update(ctx, person); err != nil {
+		return &UsersError{Op: "update pickup person", Err: err}
+	}
+	return nil
+}
+
+// DeletePickupPerson removes an extra pickup person
+func (s *pickupAuthorizationService) DeletePickupPerson(ctx context.Context, id int64) error {
+	if err := s.pickupPersonRepo.Delete(ctx, id); err != nil {
+		return &UsersError{Op: "delete pickup person", Err: err}
+	}
+	return nil
+}
+
+// normalizePickupPerson trims free-text notes and drops empty ones
+func normalizePickupPerson(person *users.StudentPickupPerson) {
+	if person.Notes == nil {
+		return
+	}
+	trimmed := strings.TrimSpace(*person.Notes)
+	if trimmed == "" {
+		person.Notes = nil
+		return
+	}
+	person.Notes = &trimmed
+}
+
+// IsPickupNotAuthorized reports whether err blocks a checkout because the selected person may not collect the student
+func IsPickupNotAuthorized(err error) (*AuthorizedPickup, bool) {
+	var notAuthorized *PickupNotAuthorizedError
+	if errors.As(err, &notAuthorized) {
+		return notAuthorized.Pickup, true
+	}
+	return nil, false
+}
@@ -0,0 +1,152 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moto-nrw/project-phoenix/models/base"
+	"github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// Mocks (prefixed with pauth)
+// ============================================================================
+
+type pauthGuardianProvider struct {
+	guardians []*GuardianWithRelationship
+}
+
+func (p *pauthGuardianProvider) GetStudentGuardians(_ context.Context, _ int64) ([]*GuardianWithRelationship, error) {
+	return p.guardians, nil
+}
+
+type pauthPersonRepoMock struct {
+	persons   []*users.StudentPickupPerson
+	updateErr error
+}
+
+func (m *pauthPersonRepoMock) Create(_ context.Context, _ *users.StudentPickupPerson) error {
+	return nil
+}
+func (m *pauthPersonRepoMock) FindByID(_ context.Context, _ interface{}) (*users.StudentPickupPerson, error) {
+	return nil, errors.New("not found")
+}
+func (m *pauthPersonRepoMock) FindByStudentID(_ context.Context, _ int64) ([]*users.StudentPickupPerson, error) {
+	return m.persons, nil
+}
+func (m *pauthPersonRepoMock) Update(_ context.Context, _ *users.StudentPickupPerson) error {
+	return m.updateErr
+}
+func (m *pauthPersonRepoMock) Delete(_ context.Context, _ interface{}) error {
+	return nil
+}
+
+func pauthService() PickupAuthorizationService {
+	pickupNote := "Nur mit Ausweis"
+	validFrom := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)
+
+	return NewPickupAuthorizationService(PickupAuthorizationServiceDependencies{
+		Guardians: &pauthGuardianProvider{guardians: []*GuardianWithRelationship{
+			{
+				Profile:      &users.GuardianProfile{Model: base.Model{ID: 20}, FirstName: "Anna", LastName: "Becker"},
+				Relationship: &users.StudentGuardian{RelationshipType: "parent", CanPickup: true, PickupNotes: &pickupNote},
+			},
+			{
+				Profile:      &users.GuardianProfile{Model: base.Model{ID: 21}, FirstName: "Jan", LastName: "Becker"},
+				Relationship: &users.StudentGuardian{RelationshipType: "parent", CanPickup: false},
+			},
+		}},
+		PickupPersonRepo: &pauthPersonRepoMock{persons: []*users.StudentPickupPerson{
+			{Model: base.Model{ID: 30}, FirstName: "Erika", LastName: "Zimmer", Relationship: "Oma", IsAuthorized: true},
+			{Model: base.Model{ID: 31}, FirstName: "Tom", LastName: "Nachbar", IsAuthorized: true, ValidFrom: &validFrom},
+			{Model: base.Model{ID: 32}, FirstName: "Lena", LastName: "Kurz", IsAuthorized: true, ValidUntil: &validUntil},
+		}},
+	})
+}
+
+func TestGetAuthorizedPickups(t *testing.T) {
+	date := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	pickups, err := pauthService().GetAuthorizedPickups(context.Background(), 10, date)
+	require.NoError(t, err)
+	require.Len(t, pickups, 5)
+
+	byID := make(map[int64]*AuthorizedPickup, len(pickups))
+	for _, p := range pickups {
+		byID[p.ID] = p
+	}
+
+	assert.Equal(t, PickupTypeGuardian, byID[20].Type)
+	assert.Equal(t, "Anna Becker", byID[20].Name)
+	assert.True(t, byID[20].Authorized)
+	assert.Equal(t, "Nur mit Ausweis", byID[20].Notes)
+
+	assert.False(t, byID[21].Authorized)
+	assert.Equal(t, PickupReasonNotAuthorized, byID[21].Reason)
+
+	assert.Equal(t, PickupTypePerson, byID[30].Type)
+	assert.True(t, byID[30].Authorized)
+
+	assert.False(t, byID[31].Authorized)
+	assert.Equal(t, PickupReasonNotYetValid, byID[31].Reason)
+
+	assert.False(t, byID[32].Authorized)
+	assert.Equal(t, PickupReasonExpired, byID[32].Reason)
+}
+
+func TestVerifyPickup(t *testing.T) {
+	svc := pauthService()
+	date := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("authorized guardian", func(t *testing.T) {
+		pickup, err := svc.VerifyPickup(context.Background(), 10, PickupSelection{Type: PickupTypeGuardian, ID: 20}, date)
+		require.NoError(t, err)
+		assert.Equal(t, "Anna Becker", pickup.Name)
+	})
+
+	t.Run("guardian flagged not authorized blocks", func(t *testing.T) {
+		_, err := svc.VerifyPickup(context.Background(), 10, PickupSelection{Type: PickupTypeGuardian, ID: 21}, date)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrPickupNotAuthorized)
+
+		pickup, ok := IsPickupNotAuthorized(err)
+		require.True(t, ok)
+		assert.Equal(t, "Jan Becker", pickup.Name)
+	})
+
+	t.Run("expired pickup person blocks", func(t *testing.T) {
+		_, err := svc.VerifyPickup(context.Background(), 10, PickupSelection{Type: PickupTypePerson, ID: 32}, date)
+		assert.ErrorIs(t, err, ErrPickupNotAuthorized)
+	})
+
+	t.Run("person not on the list", func(t *testing.T) {
+		_, err := svc.VerifyPickup(context.Background(), 10, PickupSelection{Type: PickupTypePerson, ID: 20}, date)
+		assert.ErrorIs(t, err, ErrPickupPersonNotFound)
+	})
+}
+
+func TestUpdatePickupPerson_Errors(t *testing.T) {
+	repo := &pauthPersonRepoMock{}
+	svc := NewPickupAuthorizationService(PickupAuthorizationServiceDependencies{PickupPersonRepo: repo})
+	person := &users.StudentPickupPerson{Model: base.Model{ID: 30}, StudentID: 10, FirstName: "Erika", LastName: "Zimmer", IsAuthorized: true, CreatedBy: 40}
+
+	require.NoError(t, svc.UpdatePickupPerson(context.Background(), person))
+
+	t.Run("invalid data", func(t *testing.T) {
+		invalid := *person
+		invalid.LastName = " "
+		assert.ErrorIs(t, svc.UpdatePickupPerson(context.Background(), &invalid), ErrInvalidPersonData)
+	})
+
+	t.Run("database failure is not invalid data", func(t *testing.T) {
+		repo.updateErr = errors.New("connection refused")
+		err := svc.UpdatePickupPerson(context.Background(), person)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidPersonData)
+	})
+}
@@ -0,0 +1,48 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	userModels "github.com/moto-nrw/project-phoenix/models/users"
+	"github.com/moto-nrw/project-phoenix/realtime"
+)
+
+// BroadcastStudentSickness sends a student_sick event to the student's OGS group and the
+// school-wide topic
+func (s *personService) BroadcastStudentSickness(ctx context.Context, student *userModels.Student) {
+	if s.broadcaster == nil || student == nil {
+		return
+	}
+
+	studentID := strconv.FormatInt(student.ID, 10)
+	sick := student.Sick != nil && *student.Sick
+	data := realtime.EventData{
+		StudentID: &studentID,
+		Sick:      &sick,
+	}
+	if student.SchoolClass != "" {
+		schoolClass := student.SchoolClass
+		data.SchoolClass = &schoolClass
+	}
+	if person, err := s.personRepo.FindByID(ctx, student.PersonID); err == nil && person != nil {
+		name := fmt.Sprintf("%s %s", person.FirstName, person.LastName)
+		data.StudentName = &name
+	}
+
+	topics := []string{realtime.TopicSchool}
+	if student.GroupID != nil {
+		topics = append(topics, realtime.EducationGroupTopic(*student.GroupID))
+	}
+
+	event := realtime.NewEvent(realtime.EventStudentSick, "", data)
+	if err := s.broadcaster.BroadcastToTopics(topics, event); err != nil {
+		slog.Error("SSE broadcast failed",
+			slog.String("error", err.Error()),
+			slog.String("event_type", string(realtime.EventStudentSick)),
+			slog.String("student_id", studentID),
+		)
+	}
+}
@@ -31,14 +31,14 @@ interface ImportRowResult {
       email: string;
       phone: string;
       relationship_type: string;
-      is_primary: boolean;
+      is_primary?: boolean;
     }>;
     health_info?: string;
     supervisor_notes?: string;
     extra_info?: string;
     privacy_accepted: boolean;
     data_retention_days: number;
-    bus_permission: boolean;
+    bus_permission?: boolean;
   };
   Errors: ImportError[];
   Timestamp: string;
@@ -5,7 +5,16 @@ export type SSEEventType =
   | "student_checkout"
   | "activity_start"
   | "activity_end"
-  | "activity_update";
+  | "activity_update"
+  | "student_sick"
+  | "pickup_exception"
+  | "pickup_due"
+  | "pickup_overdue"
+  | "supervisor_change"
+  | "student_missing"
+  | "bus_departure_due"
+  | "device_offline"
+  | "device_online";
 
 // SSE Connection Status
 export type ConnectionStatus = "connected" | "reconnecting" | "failed" | "idle";
@@ -23,6 +32,29 @@ export interface SSEEventData {
   room_name?: string;
   supervisor_ids?: string[];
 
+  // Sickness fields (for student_sick events)
+  sick?: boolean;
+
+  // Pickup fields (for pickup_exception/pickup_due/pickup_overdue events)
+  pickup_date?: string; // YYYY-MM-DD
+  pickup_time?: string; // HH:MM, absent when the exception was removed
+
+  // Bus fields (for bus_departure_due events)
+  bus_route_id?: string;
+  bus_route_name?: string;
+  departure_time?: string; // HH:MM
+
+  // Missing student fields (for student_missing events)
+  alert_id?: string;
+  alert_status?: "open" | "resolved";
+  resolution?: "found" | "sick" | "went_home";
+  expected_by?: string; // ISO 8601 string
+
+  // Device fields (for device_offline/device_online events)
+  device_id?: string;
+  device_name?: string;
+  last_seen?: string; // ISO 8601 string
+
   // Source tracking
   source?: "rfid" | "manual" | "automated";
 }